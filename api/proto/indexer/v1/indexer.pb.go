@@ -84,6 +84,7 @@ const (
 	ChainStatus_CHAIN_STATUS_INACTIVE    ChainStatus = 2
 	ChainStatus_CHAIN_STATUS_SYNCING     ChainStatus = 3
 	ChainStatus_CHAIN_STATUS_ERROR       ChainStatus = 4
+	ChainStatus_CHAIN_STATUS_PAUSED      ChainStatus = 5
 )
 
 // Enum value maps for ChainStatus.
@@ -94,6 +95,7 @@ var (
 		2: "CHAIN_STATUS_INACTIVE",
 		3: "CHAIN_STATUS_SYNCING",
 		4: "CHAIN_STATUS_ERROR",
+		5: "CHAIN_STATUS_PAUSED",
 	}
 	ChainStatus_value = map[string]int32{
 		"CHAIN_STATUS_UNSPECIFIED": 0,
@@ -101,6 +103,7 @@ var (
 		"CHAIN_STATUS_INACTIVE":    2,
 		"CHAIN_STATUS_SYNCING":     3,
 		"CHAIN_STATUS_ERROR":       4,
+		"CHAIN_STATUS_PAUSED":      5,
 	}
 )
 
@@ -184,6 +187,109 @@ func (TransactionStatus) EnumDescriptor() ([]byte, []int) {
 	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{2}
 }
 
+// OperationKind is the kind of a long-running admin operation
+type OperationKind int32
+
+const (
+	OperationKind_OPERATION_KIND_UNSPECIFIED   OperationKind = 0
+	OperationKind_OPERATION_KIND_REINDEX_RANGE OperationKind = 1
+	OperationKind_OPERATION_KIND_RECOVER_GAPS  OperationKind = 2
+)
+
+// Enum value maps for OperationKind.
+var (
+	OperationKind_name = map[int32]string{
+		0: "OPERATION_KIND_UNSPECIFIED",
+		1: "OPERATION_KIND_REINDEX_RANGE",
+		2: "OPERATION_KIND_RECOVER_GAPS",
+	}
+	OperationKind_value = map[string]int32{
+		"OPERATION_KIND_UNSPECIFIED":   0,
+		"OPERATION_KIND_REINDEX_RANGE": 1,
+		"OPERATION_KIND_RECOVER_GAPS":  2,
+	}
+)
+
+func (x OperationKind) Enum() *OperationKind {
+	p := new(OperationKind)
+	*p = x
+	return p
+}
+
+func (x OperationKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OperationKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_indexer_v1_indexer_proto_enumTypes[3].Descriptor()
+}
+
+func (OperationKind) Type() protoreflect.EnumType {
+	return &file_api_proto_indexer_v1_indexer_proto_enumTypes[3]
+}
+
+func (x OperationKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OperationKind.Descriptor instead.
+func (OperationKind) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{3}
+}
+
+// OperationState is the state of a long-running admin operation
+type OperationState int32
+
+const (
+	OperationState_OPERATION_STATE_UNSPECIFIED OperationState = 0
+	OperationState_OPERATION_STATE_RUNNING     OperationState = 1
+	OperationState_OPERATION_STATE_SUCCEEDED   OperationState = 2
+	OperationState_OPERATION_STATE_FAILED      OperationState = 3
+)
+
+// Enum value maps for OperationState.
+var (
+	OperationState_name = map[int32]string{
+		0: "OPERATION_STATE_UNSPECIFIED",
+		1: "OPERATION_STATE_RUNNING",
+		2: "OPERATION_STATE_SUCCEEDED",
+		3: "OPERATION_STATE_FAILED",
+	}
+	OperationState_value = map[string]int32{
+		"OPERATION_STATE_UNSPECIFIED": 0,
+		"OPERATION_STATE_RUNNING":     1,
+		"OPERATION_STATE_SUCCEEDED":   2,
+		"OPERATION_STATE_FAILED":      3,
+	}
+)
+
+func (x OperationState) Enum() *OperationState {
+	p := new(OperationState)
+	*p = x
+	return p
+}
+
+func (x OperationState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OperationState) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_indexer_v1_indexer_proto_enumTypes[4].Descriptor()
+}
+
+func (OperationState) Type() protoreflect.EnumType {
+	return &file_api_proto_indexer_v1_indexer_proto_enumTypes[4]
+}
+
+func (x OperationState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OperationState.Descriptor instead.
+func (OperationState) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{4}
+}
+
 // Chain information
 type Chain struct {
 	state              protoimpl.MessageState `protogen:"open.v1"`
@@ -196,8 +302,10 @@ type Chain struct {
 	LatestIndexedBlock uint64                 `protobuf:"varint,7,opt,name=latest_indexed_block,json=latestIndexedBlock,proto3" json:"latest_indexed_block,omitempty"`
 	LatestChainBlock   uint64                 `protobuf:"varint,8,opt,name=latest_chain_block,json=latestChainBlock,proto3" json:"latest_chain_block,omitempty"`
 	LastUpdated        *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	// Features the chain's adapter supports, such as logs or traces
+	Capabilities  []string `protobuf:"bytes,10,rep,name=capabilities,proto3" json:"capabilities,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Chain) Reset() {
@@ -293,21 +401,31 @@ func (x *Chain) GetLastUpdated() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Chain) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
 // Block represents a blockchain block
 type Block struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
-	ChainType     ChainType              `protobuf:"varint,2,opt,name=chain_type,json=chainType,proto3,enum=indexer.v1.ChainType" json:"chain_type,omitempty"`
-	Number        uint64                 `protobuf:"varint,3,opt,name=number,proto3" json:"number,omitempty"`
-	Hash          string                 `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`
-	ParentHash    string                 `protobuf:"bytes,5,opt,name=parent_hash,json=parentHash,proto3" json:"parent_hash,omitempty"`
-	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	GasUsed       uint64                 `protobuf:"varint,7,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
-	GasLimit      uint64                 `protobuf:"varint,8,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
-	Miner         string                 `protobuf:"bytes,9,opt,name=miner,proto3" json:"miner,omitempty"`
-	TxCount       int32                  `protobuf:"varint,10,opt,name=tx_count,json=txCount,proto3" json:"tx_count,omitempty"`
-	Transactions  []*Transaction         `protobuf:"bytes,11,rep,name=transactions,proto3" json:"transactions,omitempty"`
-	IndexedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=indexed_at,json=indexedAt,proto3" json:"indexed_at,omitempty"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ChainId      string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	ChainType    ChainType              `protobuf:"varint,2,opt,name=chain_type,json=chainType,proto3,enum=indexer.v1.ChainType" json:"chain_type,omitempty"`
+	Number       uint64                 `protobuf:"varint,3,opt,name=number,proto3" json:"number,omitempty"`
+	Hash         string                 `protobuf:"bytes,4,opt,name=hash,proto3" json:"hash,omitempty"`
+	ParentHash   string                 `protobuf:"bytes,5,opt,name=parent_hash,json=parentHash,proto3" json:"parent_hash,omitempty"`
+	Timestamp    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	GasUsed      uint64                 `protobuf:"varint,7,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+	GasLimit     uint64                 `protobuf:"varint,8,opt,name=gas_limit,json=gasLimit,proto3" json:"gas_limit,omitempty"`
+	Miner        string                 `protobuf:"bytes,9,opt,name=miner,proto3" json:"miner,omitempty"`
+	TxCount      int32                  `protobuf:"varint,10,opt,name=tx_count,json=txCount,proto3" json:"tx_count,omitempty"`
+	Transactions []*Transaction         `protobuf:"bytes,11,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	IndexedAt    *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=indexed_at,json=indexedAt,proto3" json:"indexed_at,omitempty"`
+	// "pending", "safe" or "finalized", empty for blocks indexed before
+	// finality was tracked
+	Finality      string `protobuf:"bytes,13,opt,name=finality,proto3" json:"finality,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -426,28 +544,36 @@ func (x *Block) GetIndexedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Block) GetFinality() string {
+	if x != nil {
+		return x.Finality
+	}
+	return ""
+}
+
 // Transaction represents a blockchain transaction
 type Transaction struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	ChainId         string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
-	Hash            string                 `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
-	BlockNumber     uint64                 `protobuf:"varint,3,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
-	BlockHash       string                 `protobuf:"bytes,4,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
-	BlockTimestamp  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=block_timestamp,json=blockTimestamp,proto3" json:"block_timestamp,omitempty"`
-	TxIndex         uint32                 `protobuf:"varint,6,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
-	From            string                 `protobuf:"bytes,7,opt,name=from,proto3" json:"from,omitempty"`
-	To              string                 `protobuf:"bytes,8,opt,name=to,proto3" json:"to,omitempty"`
-	Value           string                 `protobuf:"bytes,9,opt,name=value,proto3" json:"value,omitempty"`
-	GasPrice        string                 `protobuf:"bytes,10,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`
-	GasUsed         uint64                 `protobuf:"varint,11,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
-	Nonce           uint64                 `protobuf:"varint,12,opt,name=nonce,proto3" json:"nonce,omitempty"`
-	Input           []byte                 `protobuf:"bytes,13,opt,name=input,proto3" json:"input,omitempty"`
-	Status          TransactionStatus      `protobuf:"varint,14,opt,name=status,proto3,enum=indexer.v1.TransactionStatus" json:"status,omitempty"`
-	ContractAddress string                 `protobuf:"bytes,15,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
-	Logs            []*Log                 `protobuf:"bytes,16,rep,name=logs,proto3" json:"logs,omitempty"`
-	IndexedAt       *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=indexed_at,json=indexedAt,proto3" json:"indexed_at,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	ChainId              string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Hash                 string                 `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	BlockNumber          uint64                 `protobuf:"varint,3,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	BlockHash            string                 `protobuf:"bytes,4,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	BlockTimestamp       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=block_timestamp,json=blockTimestamp,proto3" json:"block_timestamp,omitempty"`
+	TxIndex              uint32                 `protobuf:"varint,6,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
+	From                 string                 `protobuf:"bytes,7,opt,name=from,proto3" json:"from,omitempty"`
+	To                   string                 `protobuf:"bytes,8,opt,name=to,proto3" json:"to,omitempty"`
+	Value                string                 `protobuf:"bytes,9,opt,name=value,proto3" json:"value,omitempty"`
+	GasPrice             string                 `protobuf:"bytes,10,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`
+	GasUsed              uint64                 `protobuf:"varint,11,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+	Nonce                uint64                 `protobuf:"varint,12,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Input                []byte                 `protobuf:"bytes,13,opt,name=input,proto3" json:"input,omitempty"`
+	Status               TransactionStatus      `protobuf:"varint,14,opt,name=status,proto3,enum=indexer.v1.TransactionStatus" json:"status,omitempty"`
+	ContractAddress      string                 `protobuf:"bytes,15,opt,name=contract_address,json=contractAddress,proto3" json:"contract_address,omitempty"`
+	Logs                 []*Log                 `protobuf:"bytes,16,rep,name=logs,proto3" json:"logs,omitempty"`
+	IndexedAt            *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=indexed_at,json=indexedAt,proto3" json:"indexed_at,omitempty"`
+	InternalTransactions []*InternalTransaction `protobuf:"bytes,18,rep,name=internal_transactions,json=internalTransactions,proto3" json:"internal_transactions,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
 }
 
 func (x *Transaction) Reset() {
@@ -599,31 +725,44 @@ func (x *Transaction) GetIndexedAt() *timestamppb.Timestamp {
 	return nil
 }
 
-// Log represents a transaction event log
-type Log struct {
+func (x *Transaction) GetInternalTransactions() []*InternalTransaction {
+	if x != nil {
+		return x.InternalTransactions
+	}
+	return nil
+}
+
+// InternalTransaction represents a call made by a contract while a
+// transaction executed
+type InternalTransaction struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
-	Topics        []string               `protobuf:"bytes,2,rep,name=topics,proto3" json:"topics,omitempty"`
-	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
-	LogIndex      uint32                 `protobuf:"varint,4,opt,name=log_index,json=logIndex,proto3" json:"log_index,omitempty"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	From          string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,3,opt,name=to,proto3" json:"to,omitempty"`
+	Value         string                 `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	Gas           uint64                 `protobuf:"varint,5,opt,name=gas,proto3" json:"gas,omitempty"`
+	GasUsed       uint64                 `protobuf:"varint,6,opt,name=gas_used,json=gasUsed,proto3" json:"gas_used,omitempty"`
+	Input         []byte                 `protobuf:"bytes,7,opt,name=input,proto3" json:"input,omitempty"`
+	Error         string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	TraceAddress  []uint64               `protobuf:"varint,9,rep,packed,name=trace_address,json=traceAddress,proto3" json:"trace_address,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Log) Reset() {
-	*x = Log{}
+func (x *InternalTransaction) Reset() {
+	*x = InternalTransaction{}
 	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Log) String() string {
+func (x *InternalTransaction) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Log) ProtoMessage() {}
+func (*InternalTransaction) ProtoMessage() {}
 
-func (x *Log) ProtoReflect() protoreflect.Message {
+func (x *InternalTransaction) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -635,72 +774,103 @@ func (x *Log) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Log.ProtoReflect.Descriptor instead.
-func (*Log) Descriptor() ([]byte, []int) {
+// Deprecated: Use InternalTransaction.ProtoReflect.Descriptor instead.
+func (*InternalTransaction) Descriptor() ([]byte, []int) {
 	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *Log) GetAddress() string {
+func (x *InternalTransaction) GetType() string {
 	if x != nil {
-		return x.Address
+		return x.Type
 	}
 	return ""
 }
 
-func (x *Log) GetTopics() []string {
+func (x *InternalTransaction) GetFrom() string {
 	if x != nil {
-		return x.Topics
+		return x.From
 	}
-	return nil
+	return ""
 }
 
-func (x *Log) GetData() []byte {
+func (x *InternalTransaction) GetTo() string {
 	if x != nil {
-		return x.Data
+		return x.To
 	}
-	return nil
+	return ""
 }
 
-func (x *Log) GetLogIndex() uint32 {
+func (x *InternalTransaction) GetValue() string {
 	if x != nil {
-		return x.LogIndex
+		return x.Value
+	}
+	return ""
+}
+
+func (x *InternalTransaction) GetGas() uint64 {
+	if x != nil {
+		return x.Gas
 	}
 	return 0
 }
 
-// Progress represents indexing progress
-type Progress struct {
-	state                    protoimpl.MessageState `protogen:"open.v1"`
-	ChainId                  string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
-	ChainType                string                 `protobuf:"bytes,2,opt,name=chain_type,json=chainType,proto3" json:"chain_type,omitempty"`
-	LatestIndexedBlock       uint64                 `protobuf:"varint,3,opt,name=latest_indexed_block,json=latestIndexedBlock,proto3" json:"latest_indexed_block,omitempty"`
-	LatestChainBlock         uint64                 `protobuf:"varint,4,opt,name=latest_chain_block,json=latestChainBlock,proto3" json:"latest_chain_block,omitempty"`
-	TargetBlock              uint64                 `protobuf:"varint,5,opt,name=target_block,json=targetBlock,proto3" json:"target_block,omitempty"`
-	StartBlock               uint64                 `protobuf:"varint,6,opt,name=start_block,json=startBlock,proto3" json:"start_block,omitempty"`
-	BlocksBehind             uint64                 `protobuf:"varint,7,opt,name=blocks_behind,json=blocksBehind,proto3" json:"blocks_behind,omitempty"`
-	ProgressPercentage       float64                `protobuf:"fixed64,8,opt,name=progress_percentage,json=progressPercentage,proto3" json:"progress_percentage,omitempty"`
-	BlocksPerSecond          float64                `protobuf:"fixed64,9,opt,name=blocks_per_second,json=blocksPerSecond,proto3" json:"blocks_per_second,omitempty"`
-	EstimatedTimeLeftSeconds int64                  `protobuf:"varint,10,opt,name=estimated_time_left_seconds,json=estimatedTimeLeftSeconds,proto3" json:"estimated_time_left_seconds,omitempty"`
-	LastUpdated              *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
-	Status                   string                 `protobuf:"bytes,12,opt,name=status,proto3" json:"status,omitempty"`
-	unknownFields            protoimpl.UnknownFields
-	sizeCache                protoimpl.SizeCache
+func (x *InternalTransaction) GetGasUsed() uint64 {
+	if x != nil {
+		return x.GasUsed
+	}
+	return 0
 }
 
-func (x *Progress) Reset() {
-	*x = Progress{}
+func (x *InternalTransaction) GetInput() []byte {
+	if x != nil {
+		return x.Input
+	}
+	return nil
+}
+
+func (x *InternalTransaction) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *InternalTransaction) GetTraceAddress() []uint64 {
+	if x != nil {
+		return x.TraceAddress
+	}
+	return nil
+}
+
+// Log represents a transaction event log
+type Log struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Topics        []string               `protobuf:"bytes,2,rep,name=topics,proto3" json:"topics,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	LogIndex      uint32                 `protobuf:"varint,4,opt,name=log_index,json=logIndex,proto3" json:"log_index,omitempty"`
+	BlockNumber   uint64                 `protobuf:"varint,5,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	BlockHash     string                 `protobuf:"bytes,6,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	TxHash        string                 `protobuf:"bytes,7,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	TxIndex       uint32                 `protobuf:"varint,8,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Log) Reset() {
+	*x = Log{}
 	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Progress) String() string {
+func (x *Log) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Progress) ProtoMessage() {}
+func (*Log) ProtoMessage() {}
 
-func (x *Progress) ProtoReflect() protoreflect.Message {
+func (x *Log) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -712,120 +882,90 @@ func (x *Progress) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Progress.ProtoReflect.Descriptor instead.
-func (*Progress) Descriptor() ([]byte, []int) {
+// Deprecated: Use Log.ProtoReflect.Descriptor instead.
+func (*Log) Descriptor() ([]byte, []int) {
 	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *Progress) GetChainId() string {
-	if x != nil {
-		return x.ChainId
-	}
-	return ""
-}
-
-func (x *Progress) GetChainType() string {
+func (x *Log) GetAddress() string {
 	if x != nil {
-		return x.ChainType
+		return x.Address
 	}
 	return ""
 }
 
-func (x *Progress) GetLatestIndexedBlock() uint64 {
-	if x != nil {
-		return x.LatestIndexedBlock
-	}
-	return 0
-}
-
-func (x *Progress) GetLatestChainBlock() uint64 {
+func (x *Log) GetTopics() []string {
 	if x != nil {
-		return x.LatestChainBlock
+		return x.Topics
 	}
-	return 0
+	return nil
 }
 
-func (x *Progress) GetTargetBlock() uint64 {
+func (x *Log) GetData() []byte {
 	if x != nil {
-		return x.TargetBlock
+		return x.Data
 	}
-	return 0
+	return nil
 }
 
-func (x *Progress) GetStartBlock() uint64 {
+func (x *Log) GetLogIndex() uint32 {
 	if x != nil {
-		return x.StartBlock
+		return x.LogIndex
 	}
 	return 0
 }
 
-func (x *Progress) GetBlocksBehind() uint64 {
+func (x *Log) GetBlockNumber() uint64 {
 	if x != nil {
-		return x.BlocksBehind
+		return x.BlockNumber
 	}
 	return 0
 }
 
-func (x *Progress) GetProgressPercentage() float64 {
+func (x *Log) GetBlockHash() string {
 	if x != nil {
-		return x.ProgressPercentage
+		return x.BlockHash
 	}
-	return 0
+	return ""
 }
 
-func (x *Progress) GetBlocksPerSecond() float64 {
+func (x *Log) GetTxHash() string {
 	if x != nil {
-		return x.BlocksPerSecond
+		return x.TxHash
 	}
-	return 0
+	return ""
 }
 
-func (x *Progress) GetEstimatedTimeLeftSeconds() int64 {
+func (x *Log) GetTxIndex() uint32 {
 	if x != nil {
-		return x.EstimatedTimeLeftSeconds
+		return x.TxIndex
 	}
 	return 0
 }
 
-func (x *Progress) GetLastUpdated() *timestamppb.Timestamp {
-	if x != nil {
-		return x.LastUpdated
-	}
-	return nil
-}
-
-func (x *Progress) GetStatus() string {
-	if x != nil {
-		return x.Status
-	}
-	return ""
-}
-
-// Gap represents a gap in indexed blocks
-type Gap struct {
+// TopicFilter matches one log topic position against any of its values.
+// A filter without values matches any topic.
+type TopicFilter struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
-	StartBlock    uint64                 `protobuf:"varint,2,opt,name=start_block,json=startBlock,proto3" json:"start_block,omitempty"`
-	EndBlock      uint64                 `protobuf:"varint,3,opt,name=end_block,json=endBlock,proto3" json:"end_block,omitempty"`
-	Size          uint64                 `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	Values        []string               `protobuf:"bytes,1,rep,name=values,proto3" json:"values,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Gap) Reset() {
-	*x = Gap{}
+func (x *TopicFilter) Reset() {
+	*x = TopicFilter{}
 	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Gap) String() string {
+func (x *TopicFilter) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Gap) ProtoMessage() {}
+func (*TopicFilter) ProtoMessage() {}
 
-func (x *Gap) ProtoReflect() protoreflect.Message {
+func (x *TopicFilter) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -837,66 +977,210 @@ func (x *Gap) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Gap.ProtoReflect.Descriptor instead.
-func (*Gap) Descriptor() ([]byte, []int) {
+// Deprecated: Use TopicFilter.ProtoReflect.Descriptor instead.
+func (*TopicFilter) Descriptor() ([]byte, []int) {
 	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *Gap) GetChainId() string {
+func (x *TopicFilter) GetValues() []string {
 	if x != nil {
-		return x.ChainId
+		return x.Values
 	}
-	return ""
+	return nil
 }
 
-func (x *Gap) GetStartBlock() uint64 {
-	if x != nil {
-		return x.StartBlock
-	}
-	return 0
-}
+// TokenTransfer represents a token movement decoded from an ERC-20,
+// ERC-721 or ERC-1155 transfer event
+type TokenTransfer struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ChainId        string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Standard       string                 `protobuf:"bytes,2,opt,name=standard,proto3" json:"standard,omitempty"`
+	Token          string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	From           string                 `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`
+	To             string                 `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`
+	Operator       string                 `protobuf:"bytes,6,opt,name=operator,proto3" json:"operator,omitempty"`
+	Value          string                 `protobuf:"bytes,7,opt,name=value,proto3" json:"value,omitempty"`
+	TokenId        string                 `protobuf:"bytes,8,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"`
+	BlockNumber    uint64                 `protobuf:"varint,9,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	BlockHash      string                 `protobuf:"bytes,10,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	BlockTimestamp *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=block_timestamp,json=blockTimestamp,proto3" json:"block_timestamp,omitempty"`
+	TxHash         string                 `protobuf:"bytes,12,opt,name=tx_hash,json=txHash,proto3" json:"tx_hash,omitempty"`
+	TxIndex        uint32                 `protobuf:"varint,13,opt,name=tx_index,json=txIndex,proto3" json:"tx_index,omitempty"`
+	LogIndex       uint32                 `protobuf:"varint,14,opt,name=log_index,json=logIndex,proto3" json:"log_index,omitempty"`
+	BatchIndex     uint32                 `protobuf:"varint,15,opt,name=batch_index,json=batchIndex,proto3" json:"batch_index,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TokenTransfer) Reset() {
+	*x = TokenTransfer{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-func (x *Gap) GetEndBlock() uint64 {
+func (x *TokenTransfer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenTransfer) ProtoMessage() {}
+
+func (x *TokenTransfer) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[6]
 	if x != nil {
-		return x.EndBlock
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenTransfer.ProtoReflect.Descriptor instead.
+func (*TokenTransfer) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TokenTransfer) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *TokenTransfer) GetStandard() string {
+	if x != nil {
+		return x.Standard
+	}
+	return ""
+}
+
+func (x *TokenTransfer) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *TokenTransfer) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *TokenTransfer) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *TokenTransfer) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *TokenTransfer) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *TokenTransfer) GetTokenId() string {
+	if x != nil {
+		return x.TokenId
+	}
+	return ""
+}
+
+func (x *TokenTransfer) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
 	}
 	return 0
 }
 
-func (x *Gap) GetSize() uint64 {
+func (x *TokenTransfer) GetBlockHash() string {
 	if x != nil {
-		return x.Size
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *TokenTransfer) GetBlockTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BlockTimestamp
+	}
+	return nil
+}
+
+func (x *TokenTransfer) GetTxHash() string {
+	if x != nil {
+		return x.TxHash
+	}
+	return ""
+}
+
+func (x *TokenTransfer) GetTxIndex() uint32 {
+	if x != nil {
+		return x.TxIndex
 	}
 	return 0
 }
 
-// Statistics
-type Stats struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	TotalBlocks       uint64                 `protobuf:"varint,1,opt,name=total_blocks,json=totalBlocks,proto3" json:"total_blocks,omitempty"`
-	TotalTransactions uint64                 `protobuf:"varint,2,opt,name=total_transactions,json=totalTransactions,proto3" json:"total_transactions,omitempty"`
-	ChainsIndexed     int32                  `protobuf:"varint,3,opt,name=chains_indexed,json=chainsIndexed,proto3" json:"chains_indexed,omitempty"`
-	AverageBlockTime  float64                `protobuf:"fixed64,4,opt,name=average_block_time,json=averageBlockTime,proto3" json:"average_block_time,omitempty"`
-	AverageTxPerBlock float64                `protobuf:"fixed64,5,opt,name=average_tx_per_block,json=averageTxPerBlock,proto3" json:"average_tx_per_block,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+func (x *TokenTransfer) GetLogIndex() uint32 {
+	if x != nil {
+		return x.LogIndex
+	}
+	return 0
 }
 
-func (x *Stats) Reset() {
-	*x = Stats{}
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[6]
+func (x *TokenTransfer) GetBatchIndex() uint32 {
+	if x != nil {
+		return x.BatchIndex
+	}
+	return 0
+}
+
+// Progress represents indexing progress
+type Progress struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	ChainId                  string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	ChainType                string                 `protobuf:"bytes,2,opt,name=chain_type,json=chainType,proto3" json:"chain_type,omitempty"`
+	LatestIndexedBlock       uint64                 `protobuf:"varint,3,opt,name=latest_indexed_block,json=latestIndexedBlock,proto3" json:"latest_indexed_block,omitempty"`
+	LatestChainBlock         uint64                 `protobuf:"varint,4,opt,name=latest_chain_block,json=latestChainBlock,proto3" json:"latest_chain_block,omitempty"`
+	TargetBlock              uint64                 `protobuf:"varint,5,opt,name=target_block,json=targetBlock,proto3" json:"target_block,omitempty"`
+	StartBlock               uint64                 `protobuf:"varint,6,opt,name=start_block,json=startBlock,proto3" json:"start_block,omitempty"`
+	BlocksBehind             uint64                 `protobuf:"varint,7,opt,name=blocks_behind,json=blocksBehind,proto3" json:"blocks_behind,omitempty"`
+	ProgressPercentage       float64                `protobuf:"fixed64,8,opt,name=progress_percentage,json=progressPercentage,proto3" json:"progress_percentage,omitempty"`
+	BlocksPerSecond          float64                `protobuf:"fixed64,9,opt,name=blocks_per_second,json=blocksPerSecond,proto3" json:"blocks_per_second,omitempty"`
+	EstimatedTimeLeftSeconds int64                  `protobuf:"varint,10,opt,name=estimated_time_left_seconds,json=estimatedTimeLeftSeconds,proto3" json:"estimated_time_left_seconds,omitempty"`
+	LastUpdated              *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=last_updated,json=lastUpdated,proto3" json:"last_updated,omitempty"`
+	Status                   string                 `protobuf:"bytes,12,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *Progress) Reset() {
+	*x = Progress{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Stats) String() string {
+func (x *Progress) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Stats) ProtoMessage() {}
+func (*Progress) ProtoMessage() {}
 
-func (x *Stats) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[6]
+func (x *Progress) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -907,73 +1191,121 @@ func (x *Stats) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Stats.ProtoReflect.Descriptor instead.
-func (*Stats) Descriptor() ([]byte, []int) {
-	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use Progress.ProtoReflect.Descriptor instead.
+func (*Progress) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *Stats) GetTotalBlocks() uint64 {
+func (x *Progress) GetChainId() string {
 	if x != nil {
-		return x.TotalBlocks
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *Progress) GetChainType() string {
+	if x != nil {
+		return x.ChainType
+	}
+	return ""
+}
+
+func (x *Progress) GetLatestIndexedBlock() uint64 {
+	if x != nil {
+		return x.LatestIndexedBlock
 	}
 	return 0
 }
 
-func (x *Stats) GetTotalTransactions() uint64 {
+func (x *Progress) GetLatestChainBlock() uint64 {
 	if x != nil {
-		return x.TotalTransactions
+		return x.LatestChainBlock
 	}
 	return 0
 }
 
-func (x *Stats) GetChainsIndexed() int32 {
+func (x *Progress) GetTargetBlock() uint64 {
 	if x != nil {
-		return x.ChainsIndexed
+		return x.TargetBlock
 	}
 	return 0
 }
 
-func (x *Stats) GetAverageBlockTime() float64 {
+func (x *Progress) GetStartBlock() uint64 {
 	if x != nil {
-		return x.AverageBlockTime
+		return x.StartBlock
 	}
 	return 0
 }
 
-func (x *Stats) GetAverageTxPerBlock() float64 {
+func (x *Progress) GetBlocksBehind() uint64 {
 	if x != nil {
-		return x.AverageTxPerBlock
+		return x.BlocksBehind
 	}
 	return 0
 }
 
-// Pagination
-type PageInfo struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	HasNextPage     bool                   `protobuf:"varint,1,opt,name=has_next_page,json=hasNextPage,proto3" json:"has_next_page,omitempty"`
-	HasPreviousPage bool                   `protobuf:"varint,2,opt,name=has_previous_page,json=hasPreviousPage,proto3" json:"has_previous_page,omitempty"`
-	StartCursor     string                 `protobuf:"bytes,3,opt,name=start_cursor,json=startCursor,proto3" json:"start_cursor,omitempty"`
-	EndCursor       string                 `protobuf:"bytes,4,opt,name=end_cursor,json=endCursor,proto3" json:"end_cursor,omitempty"`
-	TotalCount      int32                  `protobuf:"varint,5,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+func (x *Progress) GetProgressPercentage() float64 {
+	if x != nil {
+		return x.ProgressPercentage
+	}
+	return 0
 }
 
-func (x *PageInfo) Reset() {
-	*x = PageInfo{}
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[7]
+func (x *Progress) GetBlocksPerSecond() float64 {
+	if x != nil {
+		return x.BlocksPerSecond
+	}
+	return 0
+}
+
+func (x *Progress) GetEstimatedTimeLeftSeconds() int64 {
+	if x != nil {
+		return x.EstimatedTimeLeftSeconds
+	}
+	return 0
+}
+
+func (x *Progress) GetLastUpdated() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUpdated
+	}
+	return nil
+}
+
+func (x *Progress) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// Gap represents a gap in indexed blocks
+type Gap struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	StartBlock    uint64                 `protobuf:"varint,2,opt,name=start_block,json=startBlock,proto3" json:"start_block,omitempty"`
+	EndBlock      uint64                 `protobuf:"varint,3,opt,name=end_block,json=endBlock,proto3" json:"end_block,omitempty"`
+	Size          uint64                 `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Gap) Reset() {
+	*x = Gap{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PageInfo) String() string {
+func (x *Gap) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PageInfo) ProtoMessage() {}
+func (*Gap) ProtoMessage() {}
 
-func (x *PageInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[7]
+func (x *Gap) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -984,69 +1316,1502 @@ func (x *PageInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PageInfo.ProtoReflect.Descriptor instead.
-func (*PageInfo) Descriptor() ([]byte, []int) {
-	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use Gap.ProtoReflect.Descriptor instead.
+func (*Gap) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *PageInfo) GetHasNextPage() bool {
+func (x *Gap) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *Gap) GetStartBlock() uint64 {
+	if x != nil {
+		return x.StartBlock
+	}
+	return 0
+}
+
+func (x *Gap) GetEndBlock() uint64 {
+	if x != nil {
+		return x.EndBlock
+	}
+	return 0
+}
+
+func (x *Gap) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+// Statistics
+type Stats struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	TotalBlocks       uint64                 `protobuf:"varint,1,opt,name=total_blocks,json=totalBlocks,proto3" json:"total_blocks,omitempty"`
+	TotalTransactions uint64                 `protobuf:"varint,2,opt,name=total_transactions,json=totalTransactions,proto3" json:"total_transactions,omitempty"`
+	ChainsIndexed     int32                  `protobuf:"varint,3,opt,name=chains_indexed,json=chainsIndexed,proto3" json:"chains_indexed,omitempty"`
+	AverageBlockTime  float64                `protobuf:"fixed64,4,opt,name=average_block_time,json=averageBlockTime,proto3" json:"average_block_time,omitempty"`
+	AverageTxPerBlock float64                `protobuf:"fixed64,5,opt,name=average_tx_per_block,json=averageTxPerBlock,proto3" json:"average_tx_per_block,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *Stats) Reset() {
+	*x = Stats{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Stats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Stats) ProtoMessage() {}
+
+func (x *Stats) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Stats.ProtoReflect.Descriptor instead.
+func (*Stats) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Stats) GetTotalBlocks() uint64 {
+	if x != nil {
+		return x.TotalBlocks
+	}
+	return 0
+}
+
+func (x *Stats) GetTotalTransactions() uint64 {
+	if x != nil {
+		return x.TotalTransactions
+	}
+	return 0
+}
+
+func (x *Stats) GetChainsIndexed() int32 {
+	if x != nil {
+		return x.ChainsIndexed
+	}
+	return 0
+}
+
+func (x *Stats) GetAverageBlockTime() float64 {
+	if x != nil {
+		return x.AverageBlockTime
+	}
+	return 0
+}
+
+func (x *Stats) GetAverageTxPerBlock() float64 {
+	if x != nil {
+		return x.AverageTxPerBlock
+	}
+	return 0
+}
+
+// Pagination
+type PageInfo struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	HasNextPage     bool                   `protobuf:"varint,1,opt,name=has_next_page,json=hasNextPage,proto3" json:"has_next_page,omitempty"`
+	HasPreviousPage bool                   `protobuf:"varint,2,opt,name=has_previous_page,json=hasPreviousPage,proto3" json:"has_previous_page,omitempty"`
+	StartCursor     string                 `protobuf:"bytes,3,opt,name=start_cursor,json=startCursor,proto3" json:"start_cursor,omitempty"`
+	EndCursor       string                 `protobuf:"bytes,4,opt,name=end_cursor,json=endCursor,proto3" json:"end_cursor,omitempty"`
+	TotalCount      int32                  `protobuf:"varint,5,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *PageInfo) Reset() {
+	*x = PageInfo{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PageInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageInfo) ProtoMessage() {}
+
+func (x *PageInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageInfo.ProtoReflect.Descriptor instead.
+func (*PageInfo) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PageInfo) GetHasNextPage() bool {
 	if x != nil {
 		return x.HasNextPage
 	}
 	return false
 }
 
-func (x *PageInfo) GetHasPreviousPage() bool {
-	if x != nil {
-		return x.HasPreviousPage
-	}
-	return false
+func (x *PageInfo) GetHasPreviousPage() bool {
+	if x != nil {
+		return x.HasPreviousPage
+	}
+	return false
+}
+
+func (x *PageInfo) GetStartCursor() string {
+	if x != nil {
+		return x.StartCursor
+	}
+	return ""
+}
+
+func (x *PageInfo) GetEndCursor() string {
+	if x != nil {
+		return x.EndCursor
+	}
+	return ""
+}
+
+func (x *PageInfo) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+// GetChainRequest
+type GetChainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChainRequest) Reset() {
+	*x = GetChainRequest{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChainRequest) ProtoMessage() {}
+
+func (x *GetChainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChainRequest.ProtoReflect.Descriptor instead.
+func (*GetChainRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetChainRequest) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+// GetChainResponse
+type GetChainResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chain         *Chain                 `protobuf:"bytes,1,opt,name=chain,proto3" json:"chain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChainResponse) Reset() {
+	*x = GetChainResponse{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChainResponse) ProtoMessage() {}
+
+func (x *GetChainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChainResponse.ProtoReflect.Descriptor instead.
+func (*GetChainResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetChainResponse) GetChain() *Chain {
+	if x != nil {
+		return x.Chain
+	}
+	return nil
+}
+
+// ListChainsRequest
+type ListChainsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChainsRequest) Reset() {
+	*x = ListChainsRequest{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChainsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChainsRequest) ProtoMessage() {}
+
+func (x *ListChainsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChainsRequest.ProtoReflect.Descriptor instead.
+func (*ListChainsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{13}
+}
+
+// ListChainsResponse
+type ListChainsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chains        []*Chain               `protobuf:"bytes,1,rep,name=chains,proto3" json:"chains,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChainsResponse) Reset() {
+	*x = ListChainsResponse{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChainsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChainsResponse) ProtoMessage() {}
+
+func (x *ListChainsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListChainsResponse.ProtoReflect.Descriptor instead.
+func (*ListChainsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListChainsResponse) GetChains() []*Chain {
+	if x != nil {
+		return x.Chains
+	}
+	return nil
+}
+
+// GetBlockRequest
+type GetBlockRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	ChainId string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Number  uint64                 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
+	// Finality level: "finalized", "safe" or "latest" (default)
+	Finality      string `protobuf:"bytes,3,opt,name=finality,proto3" json:"finality,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBlockRequest) Reset() {
+	*x = GetBlockRequest{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlockRequest) ProtoMessage() {}
+
+func (x *GetBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlockRequest.ProtoReflect.Descriptor instead.
+func (*GetBlockRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetBlockRequest) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *GetBlockRequest) GetNumber() uint64 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *GetBlockRequest) GetFinality() string {
+	if x != nil {
+		return x.Finality
+	}
+	return ""
+}
+
+// GetBlockResponse
+type GetBlockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Block         *Block                 `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBlockResponse) Reset() {
+	*x = GetBlockResponse{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlockResponse) ProtoMessage() {}
+
+func (x *GetBlockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlockResponse.ProtoReflect.Descriptor instead.
+func (*GetBlockResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetBlockResponse) GetBlock() *Block {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+// GetBlockByHashRequest
+type GetBlockByHashRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	ChainId string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Hash    string                 `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	// Finality level: "finalized", "safe" or "latest" (default)
+	Finality      string `protobuf:"bytes,3,opt,name=finality,proto3" json:"finality,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBlockByHashRequest) Reset() {
+	*x = GetBlockByHashRequest{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlockByHashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlockByHashRequest) ProtoMessage() {}
+
+func (x *GetBlockByHashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlockByHashRequest.ProtoReflect.Descriptor instead.
+func (*GetBlockByHashRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetBlockByHashRequest) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *GetBlockByHashRequest) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *GetBlockByHashRequest) GetFinality() string {
+	if x != nil {
+		return x.Finality
+	}
+	return ""
+}
+
+// GetBlockByHashResponse
+type GetBlockByHashResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Block         *Block                 `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBlockByHashResponse) Reset() {
+	*x = GetBlockByHashResponse{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBlockByHashResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBlockByHashResponse) ProtoMessage() {}
+
+func (x *GetBlockByHashResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBlockByHashResponse.ProtoReflect.Descriptor instead.
+func (*GetBlockByHashResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetBlockByHashResponse) GetBlock() *Block {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+// ListBlocksRequest
+type ListBlocksRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ChainId    string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	StartBlock uint64                 `protobuf:"varint,2,opt,name=start_block,json=startBlock,proto3" json:"start_block,omitempty"`
+	EndBlock   uint64                 `protobuf:"varint,3,opt,name=end_block,json=endBlock,proto3" json:"end_block,omitempty"`
+	PageSize   int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken  string                 `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Finality level: "finalized", "safe" or "latest" (default)
+	Finality      string `protobuf:"bytes,6,opt,name=finality,proto3" json:"finality,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlocksRequest) Reset() {
+	*x = ListBlocksRequest{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlocksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlocksRequest) ProtoMessage() {}
+
+func (x *ListBlocksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlocksRequest.ProtoReflect.Descriptor instead.
+func (*ListBlocksRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListBlocksRequest) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *ListBlocksRequest) GetStartBlock() uint64 {
+	if x != nil {
+		return x.StartBlock
+	}
+	return 0
+}
+
+func (x *ListBlocksRequest) GetEndBlock() uint64 {
+	if x != nil {
+		return x.EndBlock
+	}
+	return 0
+}
+
+func (x *ListBlocksRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListBlocksRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListBlocksRequest) GetFinality() string {
+	if x != nil {
+		return x.Finality
+	}
+	return ""
+}
+
+// ListBlocksResponse
+type ListBlocksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Blocks        []*Block               `protobuf:"bytes,1,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBlocksResponse) Reset() {
+	*x = ListBlocksResponse{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBlocksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBlocksResponse) ProtoMessage() {}
+
+func (x *ListBlocksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBlocksResponse.ProtoReflect.Descriptor instead.
+func (*ListBlocksResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListBlocksResponse) GetBlocks() []*Block {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+func (x *ListBlocksResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListBlocksResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+// GetLatestBlockRequest
+type GetLatestBlockRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	ChainId string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	// Finality level: "finalized", "safe" or "latest" (default)
+	Finality      string `protobuf:"bytes,2,opt,name=finality,proto3" json:"finality,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLatestBlockRequest) Reset() {
+	*x = GetLatestBlockRequest{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLatestBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLatestBlockRequest) ProtoMessage() {}
+
+func (x *GetLatestBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLatestBlockRequest.ProtoReflect.Descriptor instead.
+func (*GetLatestBlockRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetLatestBlockRequest) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *GetLatestBlockRequest) GetFinality() string {
+	if x != nil {
+		return x.Finality
+	}
+	return ""
+}
+
+// GetLatestBlockResponse
+type GetLatestBlockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Block         *Block                 `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLatestBlockResponse) Reset() {
+	*x = GetLatestBlockResponse{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLatestBlockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLatestBlockResponse) ProtoMessage() {}
+
+func (x *GetLatestBlockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLatestBlockResponse.ProtoReflect.Descriptor instead.
+func (*GetLatestBlockResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetLatestBlockResponse) GetBlock() *Block {
+	if x != nil {
+		return x.Block
+	}
+	return nil
+}
+
+// GetTransactionRequest
+type GetTransactionRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	ChainId string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Hash    string                 `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	// Finality level: "finalized", "safe" or "latest" (default)
+	Finality      string `protobuf:"bytes,3,opt,name=finality,proto3" json:"finality,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionRequest) Reset() {
+	*x = GetTransactionRequest{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionRequest) ProtoMessage() {}
+
+func (x *GetTransactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionRequest.ProtoReflect.Descriptor instead.
+func (*GetTransactionRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetTransactionRequest) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *GetTransactionRequest) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *GetTransactionRequest) GetFinality() string {
+	if x != nil {
+		return x.Finality
+	}
+	return ""
+}
+
+// GetTransactionResponse
+type GetTransactionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transaction   *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTransactionResponse) Reset() {
+	*x = GetTransactionResponse{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTransactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTransactionResponse) ProtoMessage() {}
+
+func (x *GetTransactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTransactionResponse.ProtoReflect.Descriptor instead.
+func (*GetTransactionResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetTransactionResponse) GetTransaction() *Transaction {
+	if x != nil {
+		return x.Transaction
+	}
+	return nil
+}
+
+// ListTransactionsByBlockRequest
+type ListTransactionsByBlockRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ChainId     string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	BlockNumber uint64                 `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	// Finality level: "finalized", "safe" or "latest" (default)
+	Finality      string `protobuf:"bytes,3,opt,name=finality,proto3" json:"finality,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsByBlockRequest) Reset() {
+	*x = ListTransactionsByBlockRequest{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsByBlockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsByBlockRequest) ProtoMessage() {}
+
+func (x *ListTransactionsByBlockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsByBlockRequest.ProtoReflect.Descriptor instead.
+func (*ListTransactionsByBlockRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListTransactionsByBlockRequest) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *ListTransactionsByBlockRequest) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *ListTransactionsByBlockRequest) GetFinality() string {
+	if x != nil {
+		return x.Finality
+	}
+	return ""
+}
+
+// ListTransactionsByBlockResponse
+type ListTransactionsByBlockResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsByBlockResponse) Reset() {
+	*x = ListTransactionsByBlockResponse{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsByBlockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsByBlockResponse) ProtoMessage() {}
+
+func (x *ListTransactionsByBlockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsByBlockResponse.ProtoReflect.Descriptor instead.
+func (*ListTransactionsByBlockResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListTransactionsByBlockResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+// ListTransactionsByAddressRequest
+type ListTransactionsByAddressRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ChainId   string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Address   string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	PageSize  int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// Finality level: "finalized", "safe" or "latest" (default)
+	Finality      string `protobuf:"bytes,5,opt,name=finality,proto3" json:"finality,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsByAddressRequest) Reset() {
+	*x = ListTransactionsByAddressRequest{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsByAddressRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsByAddressRequest) ProtoMessage() {}
+
+func (x *ListTransactionsByAddressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsByAddressRequest.ProtoReflect.Descriptor instead.
+func (*ListTransactionsByAddressRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListTransactionsByAddressRequest) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *ListTransactionsByAddressRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ListTransactionsByAddressRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTransactionsByAddressRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListTransactionsByAddressRequest) GetFinality() string {
+	if x != nil {
+		return x.Finality
+	}
+	return ""
+}
+
+// ListTransactionsByAddressResponse
+type ListTransactionsByAddressResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTransactionsByAddressResponse) Reset() {
+	*x = ListTransactionsByAddressResponse{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTransactionsByAddressResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTransactionsByAddressResponse) ProtoMessage() {}
+
+func (x *ListTransactionsByAddressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTransactionsByAddressResponse.ProtoReflect.Descriptor instead.
+func (*ListTransactionsByAddressResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListTransactionsByAddressResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *ListTransactionsByAddressResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *ListTransactionsByAddressResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+// ListLogsRequest
+type ListLogsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Topics        []*TopicFilter         `protobuf:"bytes,3,rep,name=topics,proto3" json:"topics,omitempty"`
+	FromBlock     *uint64                `protobuf:"varint,4,opt,name=from_block,json=fromBlock,proto3,oneof" json:"from_block,omitempty"`
+	ToBlock       *uint64                `protobuf:"varint,5,opt,name=to_block,json=toBlock,proto3,oneof" json:"to_block,omitempty"`
+	PageSize      int32                  `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLogsRequest) Reset() {
+	*x = ListLogsRequest{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLogsRequest) ProtoMessage() {}
+
+func (x *ListLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLogsRequest.ProtoReflect.Descriptor instead.
+func (*ListLogsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListLogsRequest) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *ListLogsRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ListLogsRequest) GetTopics() []*TopicFilter {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+func (x *ListLogsRequest) GetFromBlock() uint64 {
+	if x != nil && x.FromBlock != nil {
+		return *x.FromBlock
+	}
+	return 0
+}
+
+func (x *ListLogsRequest) GetToBlock() uint64 {
+	if x != nil && x.ToBlock != nil {
+		return *x.ToBlock
+	}
+	return 0
+}
+
+func (x *ListLogsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListLogsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// ListLogsResponse
+type ListLogsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Logs          []*Log                 `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLogsResponse) Reset() {
+	*x = ListLogsResponse{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLogsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLogsResponse) ProtoMessage() {}
+
+func (x *ListLogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLogsResponse.ProtoReflect.Descriptor instead.
+func (*ListLogsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ListLogsResponse) GetLogs() []*Log {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+func (x *ListLogsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// ListTokenTransfersRequest. address matches transfers sent or received
+// by the address.
+type ListTokenTransfersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	Standard      string                 `protobuf:"bytes,4,opt,name=standard,proto3" json:"standard,omitempty"`
+	FromBlock     *uint64                `protobuf:"varint,5,opt,name=from_block,json=fromBlock,proto3,oneof" json:"from_block,omitempty"`
+	ToBlock       *uint64                `protobuf:"varint,6,opt,name=to_block,json=toBlock,proto3,oneof" json:"to_block,omitempty"`
+	PageSize      int32                  `protobuf:"varint,7,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,8,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTokenTransfersRequest) Reset() {
+	*x = ListTokenTransfersRequest{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTokenTransfersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTokenTransfersRequest) ProtoMessage() {}
+
+func (x *ListTokenTransfersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTokenTransfersRequest.ProtoReflect.Descriptor instead.
+func (*ListTokenTransfersRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListTokenTransfersRequest) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+func (x *ListTokenTransfersRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *ListTokenTransfersRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ListTokenTransfersRequest) GetStandard() string {
+	if x != nil {
+		return x.Standard
+	}
+	return ""
+}
+
+func (x *ListTokenTransfersRequest) GetFromBlock() uint64 {
+	if x != nil && x.FromBlock != nil {
+		return *x.FromBlock
+	}
+	return 0
+}
+
+func (x *ListTokenTransfersRequest) GetToBlock() uint64 {
+	if x != nil && x.ToBlock != nil {
+		return *x.ToBlock
+	}
+	return 0
+}
+
+func (x *ListTokenTransfersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListTokenTransfersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// ListTokenTransfersResponse
+type ListTokenTransfersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transfers     []*TokenTransfer       `protobuf:"bytes,1,rep,name=transfers,proto3" json:"transfers,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTokenTransfersResponse) Reset() {
+	*x = ListTokenTransfersResponse{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTokenTransfersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *PageInfo) GetStartCursor() string {
+func (*ListTokenTransfersResponse) ProtoMessage() {}
+
+func (x *ListTokenTransfersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[32]
 	if x != nil {
-		return x.StartCursor
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *PageInfo) GetEndCursor() string {
+// Deprecated: Use ListTokenTransfersResponse.ProtoReflect.Descriptor instead.
+func (*ListTokenTransfersResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListTokenTransfersResponse) GetTransfers() []*TokenTransfer {
 	if x != nil {
-		return x.EndCursor
+		return x.Transfers
 	}
-	return ""
+	return nil
 }
 
-func (x *PageInfo) GetTotalCount() int32 {
+func (x *ListTokenTransfersResponse) GetNextPageToken() string {
 	if x != nil {
-		return x.TotalCount
+		return x.NextPageToken
 	}
-	return 0
+	return ""
 }
 
-// GetChainRequest
-type GetChainRequest struct {
+// GetProgressRequest
+type GetProgressRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetChainRequest) Reset() {
-	*x = GetChainRequest{}
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[8]
+func (x *GetProgressRequest) Reset() {
+	*x = GetProgressRequest{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetChainRequest) String() string {
+func (x *GetProgressRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetChainRequest) ProtoMessage() {}
+func (*GetProgressRequest) ProtoMessage() {}
 
-func (x *GetChainRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[8]
+func (x *GetProgressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1057,41 +2822,41 @@ func (x *GetChainRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetChainRequest.ProtoReflect.Descriptor instead.
-func (*GetChainRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use GetProgressRequest.ProtoReflect.Descriptor instead.
+func (*GetProgressRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{33}
 }
 
-func (x *GetChainRequest) GetChainId() string {
+func (x *GetProgressRequest) GetChainId() string {
 	if x != nil {
 		return x.ChainId
 	}
 	return ""
 }
 
-// GetChainResponse
-type GetChainResponse struct {
+// GetProgressResponse
+type GetProgressResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Chain         *Chain                 `protobuf:"bytes,1,opt,name=chain,proto3" json:"chain,omitempty"`
+	Progress      *Progress              `protobuf:"bytes,1,opt,name=progress,proto3" json:"progress,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetChainResponse) Reset() {
-	*x = GetChainResponse{}
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[9]
+func (x *GetProgressResponse) Reset() {
+	*x = GetProgressResponse{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetChainResponse) String() string {
+func (x *GetProgressResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetChainResponse) ProtoMessage() {}
+func (*GetProgressResponse) ProtoMessage() {}
 
-func (x *GetChainResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[9]
+func (x *GetProgressResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1102,40 +2867,41 @@ func (x *GetChainResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetChainResponse.ProtoReflect.Descriptor instead.
-func (*GetChainResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use GetProgressResponse.ProtoReflect.Descriptor instead.
+func (*GetProgressResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *GetChainResponse) GetChain() *Chain {
+func (x *GetProgressResponse) GetProgress() *Progress {
 	if x != nil {
-		return x.Chain
+		return x.Progress
 	}
 	return nil
 }
 
-// ListChainsRequest
-type ListChainsRequest struct {
+// ListGapsRequest
+type ListGapsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListChainsRequest) Reset() {
-	*x = ListChainsRequest{}
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[10]
+func (x *ListGapsRequest) Reset() {
+	*x = ListGapsRequest{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListChainsRequest) String() string {
+func (x *ListGapsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListChainsRequest) ProtoMessage() {}
+func (*ListGapsRequest) ProtoMessage() {}
 
-func (x *ListChainsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[10]
+func (x *ListGapsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1146,34 +2912,41 @@ func (x *ListChainsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListChainsRequest.ProtoReflect.Descriptor instead.
-func (*ListChainsRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use ListGapsRequest.ProtoReflect.Descriptor instead.
+func (*ListGapsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{35}
 }
 
-// ListChainsResponse
-type ListChainsResponse struct {
+func (x *ListGapsRequest) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+// ListGapsResponse
+type ListGapsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Chains        []*Chain               `protobuf:"bytes,1,rep,name=chains,proto3" json:"chains,omitempty"`
+	Gaps          []*Gap                 `protobuf:"bytes,1,rep,name=gaps,proto3" json:"gaps,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListChainsResponse) Reset() {
-	*x = ListChainsResponse{}
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[11]
+func (x *ListGapsResponse) Reset() {
+	*x = ListGapsResponse{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListChainsResponse) String() string {
+func (x *ListGapsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListChainsResponse) ProtoMessage() {}
+func (*ListGapsResponse) ProtoMessage() {}
 
-func (x *ListChainsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[11]
+func (x *ListGapsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1184,42 +2957,41 @@ func (x *ListChainsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListChainsResponse.ProtoReflect.Descriptor instead.
-func (*ListChainsResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ListGapsResponse.ProtoReflect.Descriptor instead.
+func (*ListGapsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *ListChainsResponse) GetChains() []*Chain {
+func (x *ListGapsResponse) GetGaps() []*Gap {
 	if x != nil {
-		return x.Chains
+		return x.Gaps
 	}
 	return nil
 }
 
-// GetBlockRequest
-type GetBlockRequest struct {
+// GetStatsRequest
+type GetStatsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
-	Number        uint64                 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetBlockRequest) Reset() {
-	*x = GetBlockRequest{}
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[12]
+func (x *GetStatsRequest) Reset() {
+	*x = GetStatsRequest{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetBlockRequest) String() string {
+func (x *GetStatsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBlockRequest) ProtoMessage() {}
+func (*GetStatsRequest) ProtoMessage() {}
 
-func (x *GetBlockRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[12]
+func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1230,48 +3002,41 @@ func (x *GetBlockRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBlockRequest.ProtoReflect.Descriptor instead.
-func (*GetBlockRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetStatsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *GetBlockRequest) GetChainId() string {
+func (x *GetStatsRequest) GetChainId() string {
 	if x != nil {
 		return x.ChainId
 	}
 	return ""
 }
 
-func (x *GetBlockRequest) GetNumber() uint64 {
-	if x != nil {
-		return x.Number
-	}
-	return 0
-}
-
-// GetBlockResponse
-type GetBlockResponse struct {
+// GetStatsResponse
+type GetStatsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Block         *Block                 `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	Stats         *Stats                 `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetBlockResponse) Reset() {
-	*x = GetBlockResponse{}
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[13]
+func (x *GetStatsResponse) Reset() {
+	*x = GetStatsResponse{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetBlockResponse) String() string {
+func (x *GetStatsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBlockResponse) ProtoMessage() {}
+func (*GetStatsResponse) ProtoMessage() {}
 
-func (x *GetBlockResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[13]
+func (x *GetStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1282,42 +3047,41 @@ func (x *GetBlockResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBlockResponse.ProtoReflect.Descriptor instead.
-func (*GetBlockResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use GetStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetStatsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *GetBlockResponse) GetBlock() *Block {
+func (x *GetStatsResponse) GetStats() *Stats {
 	if x != nil {
-		return x.Block
+		return x.Stats
 	}
 	return nil
 }
 
-// GetBlockByHashRequest
-type GetBlockByHashRequest struct {
+// StreamBlocksRequest
+type StreamBlocksRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
-	Hash          string                 `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetBlockByHashRequest) Reset() {
-	*x = GetBlockByHashRequest{}
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[14]
+func (x *StreamBlocksRequest) Reset() {
+	*x = StreamBlocksRequest{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetBlockByHashRequest) String() string {
+func (x *StreamBlocksRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBlockByHashRequest) ProtoMessage() {}
+func (*StreamBlocksRequest) ProtoMessage() {}
 
-func (x *GetBlockByHashRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[14]
+func (x *StreamBlocksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1328,48 +3092,86 @@ func (x *GetBlockByHashRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBlockByHashRequest.ProtoReflect.Descriptor instead.
-func (*GetBlockByHashRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use StreamBlocksRequest.ProtoReflect.Descriptor instead.
+func (*StreamBlocksRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *StreamBlocksRequest) GetChainId() string {
+	if x != nil {
+		return x.ChainId
+	}
+	return ""
+}
+
+// StreamTransactionsRequest
+type StreamTransactionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StreamTransactionsRequest) Reset() {
+	*x = StreamTransactionsRequest{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StreamTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamTransactionsRequest) ProtoMessage() {}
+
+func (x *StreamTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (x *GetBlockByHashRequest) GetChainId() string {
-	if x != nil {
-		return x.ChainId
-	}
-	return ""
+// Deprecated: Use StreamTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*StreamTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *GetBlockByHashRequest) GetHash() string {
+func (x *StreamTransactionsRequest) GetChainId() string {
 	if x != nil {
-		return x.Hash
+		return x.ChainId
 	}
 	return ""
 }
 
-// GetBlockByHashResponse
-type GetBlockByHashResponse struct {
+// StreamProgressRequest
+type StreamProgressRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Block         *Block                 `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetBlockByHashResponse) Reset() {
-	*x = GetBlockByHashResponse{}
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[15]
+func (x *StreamProgressRequest) Reset() {
+	*x = StreamProgressRequest{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetBlockByHashResponse) String() string {
+func (x *StreamProgressRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBlockByHashResponse) ProtoMessage() {}
+func (*StreamProgressRequest) ProtoMessage() {}
 
-func (x *GetBlockByHashResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[15]
+func (x *StreamProgressRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1380,45 +3182,51 @@ func (x *GetBlockByHashResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBlockByHashResponse.ProtoReflect.Descriptor instead.
-func (*GetBlockByHashResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use StreamProgressRequest.ProtoReflect.Descriptor instead.
+func (*StreamProgressRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{41}
 }
 
-func (x *GetBlockByHashResponse) GetBlock() *Block {
+func (x *StreamProgressRequest) GetChainId() string {
 	if x != nil {
-		return x.Block
+		return x.ChainId
 	}
-	return nil
+	return ""
 }
 
-// ListBlocksRequest
-type ListBlocksRequest struct {
+// Operation is a long-running admin operation. done counts the blocks
+// handled out of total.
+type Operation struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
-	StartBlock    uint64                 `protobuf:"varint,2,opt,name=start_block,json=startBlock,proto3" json:"start_block,omitempty"`
-	EndBlock      uint64                 `protobuf:"varint,3,opt,name=end_block,json=endBlock,proto3" json:"end_block,omitempty"`
-	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken     string                 `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind          OperationKind          `protobuf:"varint,2,opt,name=kind,proto3,enum=indexer.v1.OperationKind" json:"kind,omitempty"`
+	ChainId       string                 `protobuf:"bytes,3,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	State         OperationState         `protobuf:"varint,4,opt,name=state,proto3,enum=indexer.v1.OperationState" json:"state,omitempty"`
+	Done          uint64                 `protobuf:"varint,5,opt,name=done,proto3" json:"done,omitempty"`
+	Total         uint64                 `protobuf:"varint,6,opt,name=total,proto3" json:"total,omitempty"`
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	FinishedAt    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListBlocksRequest) Reset() {
-	*x = ListBlocksRequest{}
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[16]
+func (x *Operation) Reset() {
+	*x = Operation{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListBlocksRequest) String() string {
+func (x *Operation) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListBlocksRequest) ProtoMessage() {}
+func (*Operation) ProtoMessage() {}
 
-func (x *ListBlocksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[16]
+func (x *Operation) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1429,71 +3237,104 @@ func (x *ListBlocksRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListBlocksRequest.ProtoReflect.Descriptor instead.
-func (*ListBlocksRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use Operation.ProtoReflect.Descriptor instead.
+func (*Operation) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *ListBlocksRequest) GetChainId() string {
+func (x *Operation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Operation) GetKind() OperationKind {
+	if x != nil {
+		return x.Kind
+	}
+	return OperationKind_OPERATION_KIND_UNSPECIFIED
+}
+
+func (x *Operation) GetChainId() string {
 	if x != nil {
 		return x.ChainId
 	}
 	return ""
 }
 
-func (x *ListBlocksRequest) GetStartBlock() uint64 {
+func (x *Operation) GetState() OperationState {
 	if x != nil {
-		return x.StartBlock
+		return x.State
 	}
-	return 0
+	return OperationState_OPERATION_STATE_UNSPECIFIED
 }
 
-func (x *ListBlocksRequest) GetEndBlock() uint64 {
+func (x *Operation) GetDone() uint64 {
 	if x != nil {
-		return x.EndBlock
+		return x.Done
 	}
 	return 0
 }
 
-func (x *ListBlocksRequest) GetPageSize() int32 {
+func (x *Operation) GetTotal() uint64 {
 	if x != nil {
-		return x.PageSize
+		return x.Total
 	}
 	return 0
 }
 
-func (x *ListBlocksRequest) GetPageToken() string {
+func (x *Operation) GetError() string {
 	if x != nil {
-		return x.PageToken
+		return x.Error
 	}
 	return ""
 }
 
-// ListBlocksResponse
-type ListBlocksResponse struct {
+func (x *Operation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Operation) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Operation) GetFinishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return nil
+}
+
+// PauseChainRequest
+type PauseChainRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Blocks        []*Block               `protobuf:"bytes,1,rep,name=blocks,proto3" json:"blocks,omitempty"`
-	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
-	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListBlocksResponse) Reset() {
-	*x = ListBlocksResponse{}
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[17]
+func (x *PauseChainRequest) Reset() {
+	*x = PauseChainRequest{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListBlocksResponse) String() string {
+func (x *PauseChainRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListBlocksResponse) ProtoMessage() {}
+func (*PauseChainRequest) ProtoMessage() {}
 
-func (x *ListBlocksResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[17]
+func (x *PauseChainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1504,55 +3345,86 @@ func (x *ListBlocksResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListBlocksResponse.ProtoReflect.Descriptor instead.
-func (*ListBlocksResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use PauseChainRequest.ProtoReflect.Descriptor instead.
+func (*PauseChainRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *ListBlocksResponse) GetBlocks() []*Block {
+func (x *PauseChainRequest) GetChainId() string {
 	if x != nil {
-		return x.Blocks
+		return x.ChainId
 	}
-	return nil
+	return ""
 }
 
-func (x *ListBlocksResponse) GetNextPageToken() string {
+// PauseChainResponse
+type PauseChainResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chain         *Chain                 `protobuf:"bytes,1,opt,name=chain,proto3" json:"chain,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseChainResponse) Reset() {
+	*x = PauseChainResponse{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseChainResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseChainResponse) ProtoMessage() {}
+
+func (x *PauseChainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[44]
 	if x != nil {
-		return x.NextPageToken
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *ListBlocksResponse) GetTotalCount() int32 {
+// Deprecated: Use PauseChainResponse.ProtoReflect.Descriptor instead.
+func (*PauseChainResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *PauseChainResponse) GetChain() *Chain {
 	if x != nil {
-		return x.TotalCount
+		return x.Chain
 	}
-	return 0
+	return nil
 }
 
-// GetLatestBlockRequest
-type GetLatestBlockRequest struct {
+// ResumeChainRequest
+type ResumeChainRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetLatestBlockRequest) Reset() {
-	*x = GetLatestBlockRequest{}
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[18]
+func (x *ResumeChainRequest) Reset() {
+	*x = ResumeChainRequest{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetLatestBlockRequest) String() string {
+func (x *ResumeChainRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLatestBlockRequest) ProtoMessage() {}
+func (*ResumeChainRequest) ProtoMessage() {}
 
-func (x *GetLatestBlockRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[18]
+func (x *ResumeChainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1563,41 +3435,41 @@ func (x *GetLatestBlockRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLatestBlockRequest.ProtoReflect.Descriptor instead.
-func (*GetLatestBlockRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use ResumeChainRequest.ProtoReflect.Descriptor instead.
+func (*ResumeChainRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *GetLatestBlockRequest) GetChainId() string {
+func (x *ResumeChainRequest) GetChainId() string {
 	if x != nil {
 		return x.ChainId
 	}
 	return ""
 }
 
-// GetLatestBlockResponse
-type GetLatestBlockResponse struct {
+// ResumeChainResponse
+type ResumeChainResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Block         *Block                 `protobuf:"bytes,1,opt,name=block,proto3" json:"block,omitempty"`
+	Chain         *Chain                 `protobuf:"bytes,1,opt,name=chain,proto3" json:"chain,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetLatestBlockResponse) Reset() {
-	*x = GetLatestBlockResponse{}
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[19]
+func (x *ResumeChainResponse) Reset() {
+	*x = ResumeChainResponse{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetLatestBlockResponse) String() string {
+func (x *ResumeChainResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLatestBlockResponse) ProtoMessage() {}
+func (*ResumeChainResponse) ProtoMessage() {}
 
-func (x *GetLatestBlockResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[19]
+func (x *ResumeChainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1608,42 +3480,43 @@ func (x *GetLatestBlockResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLatestBlockResponse.ProtoReflect.Descriptor instead.
-func (*GetLatestBlockResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use ResumeChainResponse.ProtoReflect.Descriptor instead.
+func (*ResumeChainResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *GetLatestBlockResponse) GetBlock() *Block {
+func (x *ResumeChainResponse) GetChain() *Chain {
 	if x != nil {
-		return x.Block
+		return x.Chain
 	}
 	return nil
 }
 
-// GetTransactionRequest
-type GetTransactionRequest struct {
+// ReindexRangeRequest. The range includes both end blocks.
+type ReindexRangeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
-	Hash          string                 `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	StartBlock    uint64                 `protobuf:"varint,2,opt,name=start_block,json=startBlock,proto3" json:"start_block,omitempty"`
+	EndBlock      uint64                 `protobuf:"varint,3,opt,name=end_block,json=endBlock,proto3" json:"end_block,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTransactionRequest) Reset() {
-	*x = GetTransactionRequest{}
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[20]
+func (x *ReindexRangeRequest) Reset() {
+	*x = ReindexRangeRequest{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTransactionRequest) String() string {
+func (x *ReindexRangeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTransactionRequest) ProtoMessage() {}
+func (*ReindexRangeRequest) ProtoMessage() {}
 
-func (x *GetTransactionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[20]
+func (x *ReindexRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1654,48 +3527,55 @@ func (x *GetTransactionRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTransactionRequest.ProtoReflect.Descriptor instead.
-func (*GetTransactionRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use ReindexRangeRequest.ProtoReflect.Descriptor instead.
+func (*ReindexRangeRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *GetTransactionRequest) GetChainId() string {
+func (x *ReindexRangeRequest) GetChainId() string {
 	if x != nil {
 		return x.ChainId
 	}
 	return ""
 }
 
-func (x *GetTransactionRequest) GetHash() string {
+func (x *ReindexRangeRequest) GetStartBlock() uint64 {
 	if x != nil {
-		return x.Hash
+		return x.StartBlock
 	}
-	return ""
+	return 0
 }
 
-// GetTransactionResponse
-type GetTransactionResponse struct {
+func (x *ReindexRangeRequest) GetEndBlock() uint64 {
+	if x != nil {
+		return x.EndBlock
+	}
+	return 0
+}
+
+// ReindexRangeResponse
+type ReindexRangeResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Transaction   *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
+	Operation     *Operation             `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetTransactionResponse) Reset() {
-	*x = GetTransactionResponse{}
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[21]
+func (x *ReindexRangeResponse) Reset() {
+	*x = ReindexRangeResponse{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetTransactionResponse) String() string {
+func (x *ReindexRangeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetTransactionResponse) ProtoMessage() {}
+func (*ReindexRangeResponse) ProtoMessage() {}
 
-func (x *GetTransactionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[21]
+func (x *ReindexRangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1706,42 +3586,41 @@ func (x *GetTransactionResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetTransactionResponse.ProtoReflect.Descriptor instead.
-func (*GetTransactionResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use ReindexRangeResponse.ProtoReflect.Descriptor instead.
+func (*ReindexRangeResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *GetTransactionResponse) GetTransaction() *Transaction {
+func (x *ReindexRangeResponse) GetOperation() *Operation {
 	if x != nil {
-		return x.Transaction
+		return x.Operation
 	}
 	return nil
 }
 
-// ListTransactionsByBlockRequest
-type ListTransactionsByBlockRequest struct {
+// RecoverGapsRequest
+type RecoverGapsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
-	BlockNumber   uint64                 `protobuf:"varint,2,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTransactionsByBlockRequest) Reset() {
-	*x = ListTransactionsByBlockRequest{}
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[22]
+func (x *RecoverGapsRequest) Reset() {
+	*x = RecoverGapsRequest{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTransactionsByBlockRequest) String() string {
+func (x *RecoverGapsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTransactionsByBlockRequest) ProtoMessage() {}
+func (*RecoverGapsRequest) ProtoMessage() {}
 
-func (x *ListTransactionsByBlockRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[22]
+func (x *RecoverGapsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1752,48 +3631,41 @@ func (x *ListTransactionsByBlockRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTransactionsByBlockRequest.ProtoReflect.Descriptor instead.
-func (*ListTransactionsByBlockRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use RecoverGapsRequest.ProtoReflect.Descriptor instead.
+func (*RecoverGapsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *ListTransactionsByBlockRequest) GetChainId() string {
+func (x *RecoverGapsRequest) GetChainId() string {
 	if x != nil {
 		return x.ChainId
 	}
 	return ""
 }
 
-func (x *ListTransactionsByBlockRequest) GetBlockNumber() uint64 {
-	if x != nil {
-		return x.BlockNumber
-	}
-	return 0
-}
-
-// ListTransactionsByBlockResponse
-type ListTransactionsByBlockResponse struct {
+// RecoverGapsResponse
+type RecoverGapsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	Operation     *Operation             `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListTransactionsByBlockResponse) Reset() {
-	*x = ListTransactionsByBlockResponse{}
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[23]
+func (x *RecoverGapsResponse) Reset() {
+	*x = RecoverGapsResponse{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTransactionsByBlockResponse) String() string {
+func (x *RecoverGapsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTransactionsByBlockResponse) ProtoMessage() {}
+func (*RecoverGapsResponse) ProtoMessage() {}
 
-func (x *ListTransactionsByBlockResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[23]
+func (x *RecoverGapsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1804,44 +3676,51 @@ func (x *ListTransactionsByBlockResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTransactionsByBlockResponse.ProtoReflect.Descriptor instead.
-func (*ListTransactionsByBlockResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use RecoverGapsResponse.ProtoReflect.Descriptor instead.
+func (*RecoverGapsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *ListTransactionsByBlockResponse) GetTransactions() []*Transaction {
+func (x *RecoverGapsResponse) GetOperation() *Operation {
 	if x != nil {
-		return x.Transactions
+		return x.Operation
 	}
 	return nil
 }
 
-// ListTransactionsByAddressRequest
-type ListTransactionsByAddressRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
-	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
-	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// AddChainRequest. chain_type is the chain type name, such as evm or
+// bitcoin.
+type AddChainRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	ChainId            string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	ChainType          string                 `protobuf:"bytes,2,opt,name=chain_type,json=chainType,proto3" json:"chain_type,omitempty"`
+	Name               string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Network            string                 `protobuf:"bytes,4,opt,name=network,proto3" json:"network,omitempty"`
+	RpcEndpoints       []string               `protobuf:"bytes,5,rep,name=rpc_endpoints,json=rpcEndpoints,proto3" json:"rpc_endpoints,omitempty"`
+	WsEndpoints        []string               `protobuf:"bytes,6,rep,name=ws_endpoints,json=wsEndpoints,proto3" json:"ws_endpoints,omitempty"`
+	StartBlock         uint64                 `protobuf:"varint,7,opt,name=start_block,json=startBlock,proto3" json:"start_block,omitempty"`
+	BatchSize          int32                  `protobuf:"varint,8,opt,name=batch_size,json=batchSize,proto3" json:"batch_size,omitempty"`
+	Workers            int32                  `protobuf:"varint,9,opt,name=workers,proto3" json:"workers,omitempty"`
+	ConfirmationBlocks uint64                 `protobuf:"varint,10,opt,name=confirmation_blocks,json=confirmationBlocks,proto3" json:"confirmation_blocks,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
-func (x *ListTransactionsByAddressRequest) Reset() {
-	*x = ListTransactionsByAddressRequest{}
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[24]
+func (x *AddChainRequest) Reset() {
+	*x = AddChainRequest{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListTransactionsByAddressRequest) String() string {
+func (x *AddChainRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListTransactionsByAddressRequest) ProtoMessage() {}
+func (*AddChainRequest) ProtoMessage() {}
 
-func (x *ListTransactionsByAddressRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[24]
+func (x *AddChainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1852,168 +3731,104 @@ func (x *ListTransactionsByAddressRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListTransactionsByAddressRequest.ProtoReflect.Descriptor instead.
-func (*ListTransactionsByAddressRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use AddChainRequest.ProtoReflect.Descriptor instead.
+func (*AddChainRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *ListTransactionsByAddressRequest) GetChainId() string {
+func (x *AddChainRequest) GetChainId() string {
 	if x != nil {
 		return x.ChainId
 	}
 	return ""
 }
 
-func (x *ListTransactionsByAddressRequest) GetAddress() string {
+func (x *AddChainRequest) GetChainType() string {
 	if x != nil {
-		return x.Address
+		return x.ChainType
 	}
 	return ""
 }
 
-func (x *ListTransactionsByAddressRequest) GetPageSize() int32 {
+func (x *AddChainRequest) GetName() string {
 	if x != nil {
-		return x.PageSize
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *ListTransactionsByAddressRequest) GetPageToken() string {
+func (x *AddChainRequest) GetNetwork() string {
 	if x != nil {
-		return x.PageToken
+		return x.Network
 	}
 	return ""
 }
 
-// ListTransactionsByAddressResponse
-type ListTransactionsByAddressResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
-	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
-	TotalCount    int32                  `protobuf:"varint,3,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *ListTransactionsByAddressResponse) Reset() {
-	*x = ListTransactionsByAddressResponse{}
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[25]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *ListTransactionsByAddressResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*ListTransactionsByAddressResponse) ProtoMessage() {}
-
-func (x *ListTransactionsByAddressResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[25]
+func (x *AddChainRequest) GetRpcEndpoints() []string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.RpcEndpoints
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use ListTransactionsByAddressResponse.ProtoReflect.Descriptor instead.
-func (*ListTransactionsByAddressResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{25}
+	return nil
 }
 
-func (x *ListTransactionsByAddressResponse) GetTransactions() []*Transaction {
+func (x *AddChainRequest) GetWsEndpoints() []string {
 	if x != nil {
-		return x.Transactions
+		return x.WsEndpoints
 	}
 	return nil
 }
 
-func (x *ListTransactionsByAddressResponse) GetNextPageToken() string {
+func (x *AddChainRequest) GetStartBlock() uint64 {
 	if x != nil {
-		return x.NextPageToken
+		return x.StartBlock
 	}
-	return ""
+	return 0
 }
 
-func (x *ListTransactionsByAddressResponse) GetTotalCount() int32 {
+func (x *AddChainRequest) GetBatchSize() int32 {
 	if x != nil {
-		return x.TotalCount
+		return x.BatchSize
 	}
 	return 0
 }
 
-// GetProgressRequest
-type GetProgressRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetProgressRequest) Reset() {
-	*x = GetProgressRequest{}
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[26]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetProgressRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*GetProgressRequest) ProtoMessage() {}
-
-func (x *GetProgressRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[26]
+func (x *AddChainRequest) GetWorkers() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Workers
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetProgressRequest.ProtoReflect.Descriptor instead.
-func (*GetProgressRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{26}
+	return 0
 }
 
-func (x *GetProgressRequest) GetChainId() string {
+func (x *AddChainRequest) GetConfirmationBlocks() uint64 {
 	if x != nil {
-		return x.ChainId
+		return x.ConfirmationBlocks
 	}
-	return ""
+	return 0
 }
 
-// GetProgressResponse
-type GetProgressResponse struct {
+// AddChainResponse
+type AddChainResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Progress      *Progress              `protobuf:"bytes,1,opt,name=progress,proto3" json:"progress,omitempty"`
+	Chain         *Chain                 `protobuf:"bytes,1,opt,name=chain,proto3" json:"chain,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetProgressResponse) Reset() {
-	*x = GetProgressResponse{}
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[27]
+func (x *AddChainResponse) Reset() {
+	*x = AddChainResponse{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetProgressResponse) String() string {
+func (x *AddChainResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetProgressResponse) ProtoMessage() {}
+func (*AddChainResponse) ProtoMessage() {}
 
-func (x *GetProgressResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[27]
+func (x *AddChainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2024,41 +3839,41 @@ func (x *GetProgressResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetProgressResponse.ProtoReflect.Descriptor instead.
-func (*GetProgressResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{27}
+// Deprecated: Use AddChainResponse.ProtoReflect.Descriptor instead.
+func (*AddChainResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *GetProgressResponse) GetProgress() *Progress {
+func (x *AddChainResponse) GetChain() *Chain {
 	if x != nil {
-		return x.Progress
+		return x.Chain
 	}
 	return nil
 }
 
-// ListGapsRequest
-type ListGapsRequest struct {
+// RemoveChainRequest
+type RemoveChainRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListGapsRequest) Reset() {
-	*x = ListGapsRequest{}
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[28]
+func (x *RemoveChainRequest) Reset() {
+	*x = RemoveChainRequest{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListGapsRequest) String() string {
+func (x *RemoveChainRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListGapsRequest) ProtoMessage() {}
+func (*RemoveChainRequest) ProtoMessage() {}
 
-func (x *ListGapsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[28]
+func (x *RemoveChainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2069,41 +3884,40 @@ func (x *ListGapsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListGapsRequest.ProtoReflect.Descriptor instead.
-func (*ListGapsRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use RemoveChainRequest.ProtoReflect.Descriptor instead.
+func (*RemoveChainRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *ListGapsRequest) GetChainId() string {
+func (x *RemoveChainRequest) GetChainId() string {
 	if x != nil {
 		return x.ChainId
 	}
 	return ""
 }
 
-// ListGapsResponse
-type ListGapsResponse struct {
+// RemoveChainResponse
+type RemoveChainResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Gaps          []*Gap                 `protobuf:"bytes,1,rep,name=gaps,proto3" json:"gaps,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListGapsResponse) Reset() {
-	*x = ListGapsResponse{}
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[29]
+func (x *RemoveChainResponse) Reset() {
+	*x = RemoveChainResponse{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListGapsResponse) String() string {
+func (x *RemoveChainResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListGapsResponse) ProtoMessage() {}
+func (*RemoveChainResponse) ProtoMessage() {}
 
-func (x *ListGapsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[29]
+func (x *RemoveChainResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2114,41 +3928,34 @@ func (x *ListGapsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListGapsResponse.ProtoReflect.Descriptor instead.
-func (*ListGapsResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{29}
-}
-
-func (x *ListGapsResponse) GetGaps() []*Gap {
-	if x != nil {
-		return x.Gaps
-	}
-	return nil
+// Deprecated: Use RemoveChainResponse.ProtoReflect.Descriptor instead.
+func (*RemoveChainResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{54}
 }
 
-// GetStatsRequest
-type GetStatsRequest struct {
+// GetOperationRequest
+type GetOperationRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetStatsRequest) Reset() {
-	*x = GetStatsRequest{}
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[30]
+func (x *GetOperationRequest) Reset() {
+	*x = GetOperationRequest{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetStatsRequest) String() string {
+func (x *GetOperationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetStatsRequest) ProtoMessage() {}
+func (*GetOperationRequest) ProtoMessage() {}
 
-func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[30]
+func (x *GetOperationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2159,41 +3966,41 @@ func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
-func (*GetStatsRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use GetOperationRequest.ProtoReflect.Descriptor instead.
+func (*GetOperationRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *GetStatsRequest) GetChainId() string {
+func (x *GetOperationRequest) GetId() string {
 	if x != nil {
-		return x.ChainId
+		return x.Id
 	}
 	return ""
 }
 
-// GetStatsResponse
-type GetStatsResponse struct {
+// GetOperationResponse
+type GetOperationResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Stats         *Stats                 `protobuf:"bytes,1,opt,name=stats,proto3" json:"stats,omitempty"`
+	Operation     *Operation             `protobuf:"bytes,1,opt,name=operation,proto3" json:"operation,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetStatsResponse) Reset() {
-	*x = GetStatsResponse{}
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[31]
+func (x *GetOperationResponse) Reset() {
+	*x = GetOperationResponse{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetStatsResponse) String() string {
+func (x *GetOperationResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetStatsResponse) ProtoMessage() {}
+func (*GetOperationResponse) ProtoMessage() {}
 
-func (x *GetStatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[31]
+func (x *GetOperationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2204,41 +4011,42 @@ func (x *GetStatsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetStatsResponse.ProtoReflect.Descriptor instead.
-func (*GetStatsResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use GetOperationResponse.ProtoReflect.Descriptor instead.
+func (*GetOperationResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *GetStatsResponse) GetStats() *Stats {
+func (x *GetOperationResponse) GetOperation() *Operation {
 	if x != nil {
-		return x.Stats
+		return x.Operation
 	}
 	return nil
 }
 
-// StreamBlocksRequest
-type StreamBlocksRequest struct {
+// ListOperationsRequest. An empty chain_id lists the operations of every
+// chain.
+type ListOperationsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StreamBlocksRequest) Reset() {
-	*x = StreamBlocksRequest{}
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[32]
+func (x *ListOperationsRequest) Reset() {
+	*x = ListOperationsRequest{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StreamBlocksRequest) String() string {
+func (x *ListOperationsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StreamBlocksRequest) ProtoMessage() {}
+func (*ListOperationsRequest) ProtoMessage() {}
 
-func (x *StreamBlocksRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[32]
+func (x *ListOperationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2249,41 +4057,41 @@ func (x *StreamBlocksRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StreamBlocksRequest.ProtoReflect.Descriptor instead.
-func (*StreamBlocksRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{32}
+// Deprecated: Use ListOperationsRequest.ProtoReflect.Descriptor instead.
+func (*ListOperationsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *StreamBlocksRequest) GetChainId() string {
+func (x *ListOperationsRequest) GetChainId() string {
 	if x != nil {
 		return x.ChainId
 	}
 	return ""
 }
 
-// StreamTransactionsRequest
-type StreamTransactionsRequest struct {
+// ListOperationsResponse
+type ListOperationsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Operations    []*Operation           `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StreamTransactionsRequest) Reset() {
-	*x = StreamTransactionsRequest{}
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[33]
+func (x *ListOperationsResponse) Reset() {
+	*x = ListOperationsResponse{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StreamTransactionsRequest) String() string {
+func (x *ListOperationsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StreamTransactionsRequest) ProtoMessage() {}
+func (*ListOperationsResponse) ProtoMessage() {}
 
-func (x *StreamTransactionsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[33]
+func (x *ListOperationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2294,41 +4102,41 @@ func (x *StreamTransactionsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StreamTransactionsRequest.ProtoReflect.Descriptor instead.
-func (*StreamTransactionsRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use ListOperationsResponse.ProtoReflect.Descriptor instead.
+func (*ListOperationsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *StreamTransactionsRequest) GetChainId() string {
+func (x *ListOperationsResponse) GetOperations() []*Operation {
 	if x != nil {
-		return x.ChainId
+		return x.Operations
 	}
-	return ""
+	return nil
 }
 
-// StreamProgressRequest
-type StreamProgressRequest struct {
+// WatchOperationRequest
+type WatchOperationRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ChainId       string                 `protobuf:"bytes,1,opt,name=chain_id,json=chainId,proto3" json:"chain_id,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StreamProgressRequest) Reset() {
-	*x = StreamProgressRequest{}
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[34]
+func (x *WatchOperationRequest) Reset() {
+	*x = WatchOperationRequest{}
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StreamProgressRequest) String() string {
+func (x *WatchOperationRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StreamProgressRequest) ProtoMessage() {}
+func (*WatchOperationRequest) ProtoMessage() {}
 
-func (x *StreamProgressRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[34]
+func (x *WatchOperationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_indexer_v1_indexer_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2339,14 +4147,14 @@ func (x *StreamProgressRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StreamProgressRequest.ProtoReflect.Descriptor instead.
-func (*StreamProgressRequest) Descriptor() ([]byte, []int) {
-	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{34}
+// Deprecated: Use WatchOperationRequest.ProtoReflect.Descriptor instead.
+func (*WatchOperationRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_indexer_v1_indexer_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *StreamProgressRequest) GetChainId() string {
+func (x *WatchOperationRequest) GetId() string {
 	if x != nil {
-		return x.ChainId
+		return x.Id
 	}
 	return ""
 }
@@ -2356,7 +4164,7 @@ var File_api_proto_indexer_v1_indexer_proto protoreflect.FileDescriptor
 const file_api_proto_indexer_v1_indexer_proto_rawDesc = "" +
 	"\n" +
 	"\"api/proto/indexer/v1/indexer.proto\x12\n" +
-	"indexer.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xf7\x02\n" +
+	"indexer.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9b\x03\n" +
 	"\x05Chain\x12\x19\n" +
 	"\bchain_id\x18\x01 \x01(\tR\achainId\x124\n" +
 	"\n" +
@@ -2368,7 +4176,9 @@ const file_api_proto_indexer_v1_indexer_proto_rawDesc = "" +
 	"startBlock\x120\n" +
 	"\x14latest_indexed_block\x18\a \x01(\x04R\x12latestIndexedBlock\x12,\n" +
 	"\x12latest_chain_block\x18\b \x01(\x04R\x10latestChainBlock\x12=\n" +
-	"\flast_updated\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vlastUpdated\"\xc0\x03\n" +
+	"\flast_updated\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vlastUpdated\x12\"\n" +
+	"\fcapabilities\x18\n" +
+	" \x03(\tR\fcapabilities\"\xdc\x03\n" +
 	"\x05Block\x12\x19\n" +
 	"\bchain_id\x18\x01 \x01(\tR\achainId\x124\n" +
 	"\n" +
@@ -2385,7 +4195,8 @@ const file_api_proto_indexer_v1_indexer_proto_rawDesc = "" +
 	" \x01(\x05R\atxCount\x12;\n" +
 	"\ftransactions\x18\v \x03(\v2\x17.indexer.v1.TransactionR\ftransactions\x129\n" +
 	"\n" +
-	"indexed_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tindexedAt\"\xbe\x04\n" +
+	"indexed_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tindexedAt\x12\x1a\n" +
+	"\bfinality\x18\r \x01(\tR\bfinality\"\x94\x05\n" +
 	"\vTransaction\x12\x19\n" +
 	"\bchain_id\x18\x01 \x01(\tR\achainId\x12\x12\n" +
 	"\x04hash\x18\x02 \x01(\tR\x04hash\x12!\n" +
@@ -2406,12 +4217,49 @@ const file_api_proto_indexer_v1_indexer_proto_rawDesc = "" +
 	"\x10contract_address\x18\x0f \x01(\tR\x0fcontractAddress\x12#\n" +
 	"\x04logs\x18\x10 \x03(\v2\x0f.indexer.v1.LogR\x04logs\x129\n" +
 	"\n" +
-	"indexed_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tindexedAt\"h\n" +
+	"indexed_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tindexedAt\x12T\n" +
+	"\x15internal_transactions\x18\x12 \x03(\v2\x1f.indexer.v1.InternalTransactionR\x14internalTransactions\"\xe1\x01\n" +
+	"\x13InternalTransaction\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\tR\x02to\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\tR\x05value\x12\x10\n" +
+	"\x03gas\x18\x05 \x01(\x04R\x03gas\x12\x19\n" +
+	"\bgas_used\x18\x06 \x01(\x04R\agasUsed\x12\x14\n" +
+	"\x05input\x18\a \x01(\fR\x05input\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\x12#\n" +
+	"\rtrace_address\x18\t \x03(\x04R\ftraceAddress\"\xde\x01\n" +
 	"\x03Log\x12\x18\n" +
 	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x16\n" +
 	"\x06topics\x18\x02 \x03(\tR\x06topics\x12\x12\n" +
 	"\x04data\x18\x03 \x01(\fR\x04data\x12\x1b\n" +
-	"\tlog_index\x18\x04 \x01(\rR\blogIndex\"\x80\x04\n" +
+	"\tlog_index\x18\x04 \x01(\rR\blogIndex\x12!\n" +
+	"\fblock_number\x18\x05 \x01(\x04R\vblockNumber\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\x06 \x01(\tR\tblockHash\x12\x17\n" +
+	"\atx_hash\x18\a \x01(\tR\x06txHash\x12\x19\n" +
+	"\btx_index\x18\b \x01(\rR\atxIndex\"%\n" +
+	"\vTopicFilter\x12\x16\n" +
+	"\x06values\x18\x01 \x03(\tR\x06values\"\xc6\x03\n" +
+	"\rTokenTransfer\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\tR\achainId\x12\x1a\n" +
+	"\bstandard\x18\x02 \x01(\tR\bstandard\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\x12\x12\n" +
+	"\x04from\x18\x04 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x05 \x01(\tR\x02to\x12\x1a\n" +
+	"\boperator\x18\x06 \x01(\tR\boperator\x12\x14\n" +
+	"\x05value\x18\a \x01(\tR\x05value\x12\x19\n" +
+	"\btoken_id\x18\b \x01(\tR\atokenId\x12!\n" +
+	"\fblock_number\x18\t \x01(\x04R\vblockNumber\x12\x1d\n" +
+	"\n" +
+	"block_hash\x18\n" +
+	" \x01(\tR\tblockHash\x12C\n" +
+	"\x0fblock_timestamp\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\x0eblockTimestamp\x12\x17\n" +
+	"\atx_hash\x18\f \x01(\tR\x06txHash\x12\x19\n" +
+	"\btx_index\x18\r \x01(\rR\atxIndex\x12\x1b\n" +
+	"\tlog_index\x18\x0e \x01(\rR\blogIndex\x12\x1f\n" +
+	"\vbatch_index\x18\x0f \x01(\rR\n" +
+	"batchIndex\"\x80\x04\n" +
 	"\bProgress\x12\x19\n" +
 	"\bchain_id\x18\x01 \x01(\tR\achainId\x12\x1d\n" +
 	"\n" +
@@ -2454,17 +4302,19 @@ const file_api_proto_indexer_v1_indexer_proto_rawDesc = "" +
 	"\x05chain\x18\x01 \x01(\v2\x11.indexer.v1.ChainR\x05chain\"\x13\n" +
 	"\x11ListChainsRequest\"?\n" +
 	"\x12ListChainsResponse\x12)\n" +
-	"\x06chains\x18\x01 \x03(\v2\x11.indexer.v1.ChainR\x06chains\"D\n" +
+	"\x06chains\x18\x01 \x03(\v2\x11.indexer.v1.ChainR\x06chains\"`\n" +
 	"\x0fGetBlockRequest\x12\x19\n" +
 	"\bchain_id\x18\x01 \x01(\tR\achainId\x12\x16\n" +
-	"\x06number\x18\x02 \x01(\x04R\x06number\";\n" +
+	"\x06number\x18\x02 \x01(\x04R\x06number\x12\x1a\n" +
+	"\bfinality\x18\x03 \x01(\tR\bfinality\";\n" +
 	"\x10GetBlockResponse\x12'\n" +
-	"\x05block\x18\x01 \x01(\v2\x11.indexer.v1.BlockR\x05block\"F\n" +
+	"\x05block\x18\x01 \x01(\v2\x11.indexer.v1.BlockR\x05block\"b\n" +
 	"\x15GetBlockByHashRequest\x12\x19\n" +
 	"\bchain_id\x18\x01 \x01(\tR\achainId\x12\x12\n" +
-	"\x04hash\x18\x02 \x01(\tR\x04hash\"A\n" +
+	"\x04hash\x18\x02 \x01(\tR\x04hash\x12\x1a\n" +
+	"\bfinality\x18\x03 \x01(\tR\bfinality\"A\n" +
 	"\x16GetBlockByHashResponse\x12'\n" +
-	"\x05block\x18\x01 \x01(\v2\x11.indexer.v1.BlockR\x05block\"\xa8\x01\n" +
+	"\x05block\x18\x01 \x01(\v2\x11.indexer.v1.BlockR\x05block\"\xc4\x01\n" +
 	"\x11ListBlocksRequest\x12\x19\n" +
 	"\bchain_id\x18\x01 \x01(\tR\achainId\x12\x1f\n" +
 	"\vstart_block\x18\x02 \x01(\x04R\n" +
@@ -2472,37 +4322,73 @@ const file_api_proto_indexer_v1_indexer_proto_rawDesc = "" +
 	"\tend_block\x18\x03 \x01(\x04R\bendBlock\x12\x1b\n" +
 	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1d\n" +
 	"\n" +
-	"page_token\x18\x05 \x01(\tR\tpageToken\"\x88\x01\n" +
+	"page_token\x18\x05 \x01(\tR\tpageToken\x12\x1a\n" +
+	"\bfinality\x18\x06 \x01(\tR\bfinality\"\x88\x01\n" +
 	"\x12ListBlocksResponse\x12)\n" +
 	"\x06blocks\x18\x01 \x03(\v2\x11.indexer.v1.BlockR\x06blocks\x12&\n" +
 	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1f\n" +
 	"\vtotal_count\x18\x03 \x01(\x05R\n" +
-	"totalCount\"2\n" +
+	"totalCount\"N\n" +
 	"\x15GetLatestBlockRequest\x12\x19\n" +
-	"\bchain_id\x18\x01 \x01(\tR\achainId\"A\n" +
+	"\bchain_id\x18\x01 \x01(\tR\achainId\x12\x1a\n" +
+	"\bfinality\x18\x02 \x01(\tR\bfinality\"A\n" +
 	"\x16GetLatestBlockResponse\x12'\n" +
-	"\x05block\x18\x01 \x01(\v2\x11.indexer.v1.BlockR\x05block\"F\n" +
+	"\x05block\x18\x01 \x01(\v2\x11.indexer.v1.BlockR\x05block\"b\n" +
 	"\x15GetTransactionRequest\x12\x19\n" +
 	"\bchain_id\x18\x01 \x01(\tR\achainId\x12\x12\n" +
-	"\x04hash\x18\x02 \x01(\tR\x04hash\"S\n" +
+	"\x04hash\x18\x02 \x01(\tR\x04hash\x12\x1a\n" +
+	"\bfinality\x18\x03 \x01(\tR\bfinality\"S\n" +
 	"\x16GetTransactionResponse\x129\n" +
-	"\vtransaction\x18\x01 \x01(\v2\x17.indexer.v1.TransactionR\vtransaction\"^\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x17.indexer.v1.TransactionR\vtransaction\"z\n" +
 	"\x1eListTransactionsByBlockRequest\x12\x19\n" +
 	"\bchain_id\x18\x01 \x01(\tR\achainId\x12!\n" +
-	"\fblock_number\x18\x02 \x01(\x04R\vblockNumber\"^\n" +
+	"\fblock_number\x18\x02 \x01(\x04R\vblockNumber\x12\x1a\n" +
+	"\bfinality\x18\x03 \x01(\tR\bfinality\"^\n" +
 	"\x1fListTransactionsByBlockResponse\x12;\n" +
-	"\ftransactions\x18\x01 \x03(\v2\x17.indexer.v1.TransactionR\ftransactions\"\x93\x01\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x17.indexer.v1.TransactionR\ftransactions\"\xaf\x01\n" +
 	" ListTransactionsByAddressRequest\x12\x19\n" +
 	"\bchain_id\x18\x01 \x01(\tR\achainId\x12\x18\n" +
 	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x1b\n" +
 	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
 	"\n" +
-	"page_token\x18\x04 \x01(\tR\tpageToken\"\xa9\x01\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\x12\x1a\n" +
+	"\bfinality\x18\x05 \x01(\tR\bfinality\"\xa9\x01\n" +
 	"!ListTransactionsByAddressResponse\x12;\n" +
 	"\ftransactions\x18\x01 \x03(\v2\x17.indexer.v1.TransactionR\ftransactions\x12&\n" +
 	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1f\n" +
 	"\vtotal_count\x18\x03 \x01(\x05R\n" +
-	"totalCount\"/\n" +
+	"totalCount\"\x93\x02\n" +
+	"\x0fListLogsRequest\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\tR\achainId\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12/\n" +
+	"\x06topics\x18\x03 \x03(\v2\x17.indexer.v1.TopicFilterR\x06topics\x12\"\n" +
+	"\n" +
+	"from_block\x18\x04 \x01(\x04H\x00R\tfromBlock\x88\x01\x01\x12\x1e\n" +
+	"\bto_block\x18\x05 \x01(\x04H\x01R\atoBlock\x88\x01\x01\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tR\tpageTokenB\r\n" +
+	"\v_from_blockB\v\n" +
+	"\t_to_block\"_\n" +
+	"\x10ListLogsResponse\x12#\n" +
+	"\x04logs\x18\x01 \x03(\v2\x0f.indexer.v1.LogR\x04logs\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x9e\x02\n" +
+	"\x19ListTokenTransfersRequest\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\tR\achainId\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\x12\x1a\n" +
+	"\bstandard\x18\x04 \x01(\tR\bstandard\x12\"\n" +
+	"\n" +
+	"from_block\x18\x05 \x01(\x04H\x00R\tfromBlock\x88\x01\x01\x12\x1e\n" +
+	"\bto_block\x18\x06 \x01(\x04H\x01R\atoBlock\x88\x01\x01\x12\x1b\n" +
+	"\tpage_size\x18\a \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\b \x01(\tR\tpageTokenB\r\n" +
+	"\v_from_blockB\v\n" +
+	"\t_to_block\"}\n" +
+	"\x1aListTokenTransfersResponse\x127\n" +
+	"\ttransfers\x18\x01 \x03(\v2\x19.indexer.v1.TokenTransferR\ttransfers\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"/\n" +
 	"\x12GetProgressRequest\x12\x19\n" +
 	"\bchain_id\x18\x01 \x01(\tR\achainId\"G\n" +
 	"\x13GetProgressResponse\x120\n" +
@@ -2520,23 +4406,99 @@ const file_api_proto_indexer_v1_indexer_proto_rawDesc = "" +
 	"\x19StreamTransactionsRequest\x12\x19\n" +
 	"\bchain_id\x18\x01 \x01(\tR\achainId\"2\n" +
 	"\x15StreamProgressRequest\x12\x19\n" +
-	"\bchain_id\x18\x01 \x01(\tR\achainId*i\n" +
+	"\bchain_id\x18\x01 \x01(\tR\achainId\"\x8a\x03\n" +
+	"\tOperation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12-\n" +
+	"\x04kind\x18\x02 \x01(\x0e2\x19.indexer.v1.OperationKindR\x04kind\x12\x19\n" +
+	"\bchain_id\x18\x03 \x01(\tR\achainId\x120\n" +
+	"\x05state\x18\x04 \x01(\x0e2\x1a.indexer.v1.OperationStateR\x05state\x12\x12\n" +
+	"\x04done\x18\x05 \x01(\x04R\x04done\x12\x14\n" +
+	"\x05total\x18\x06 \x01(\x04R\x05total\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12;\n" +
+	"\vfinished_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"finishedAt\".\n" +
+	"\x11PauseChainRequest\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\tR\achainId\"=\n" +
+	"\x12PauseChainResponse\x12'\n" +
+	"\x05chain\x18\x01 \x01(\v2\x11.indexer.v1.ChainR\x05chain\"/\n" +
+	"\x12ResumeChainRequest\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\tR\achainId\">\n" +
+	"\x13ResumeChainResponse\x12'\n" +
+	"\x05chain\x18\x01 \x01(\v2\x11.indexer.v1.ChainR\x05chain\"n\n" +
+	"\x13ReindexRangeRequest\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\tR\achainId\x12\x1f\n" +
+	"\vstart_block\x18\x02 \x01(\x04R\n" +
+	"startBlock\x12\x1b\n" +
+	"\tend_block\x18\x03 \x01(\x04R\bendBlock\"K\n" +
+	"\x14ReindexRangeResponse\x123\n" +
+	"\toperation\x18\x01 \x01(\v2\x15.indexer.v1.OperationR\toperation\"/\n" +
+	"\x12RecoverGapsRequest\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\tR\achainId\"J\n" +
+	"\x13RecoverGapsResponse\x123\n" +
+	"\toperation\x18\x01 \x01(\v2\x15.indexer.v1.OperationR\toperation\"\xcc\x02\n" +
+	"\x0fAddChainRequest\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\tR\achainId\x12\x1d\n" +
+	"\n" +
+	"chain_type\x18\x02 \x01(\tR\tchainType\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x18\n" +
+	"\anetwork\x18\x04 \x01(\tR\anetwork\x12#\n" +
+	"\rrpc_endpoints\x18\x05 \x03(\tR\frpcEndpoints\x12!\n" +
+	"\fws_endpoints\x18\x06 \x03(\tR\vwsEndpoints\x12\x1f\n" +
+	"\vstart_block\x18\a \x01(\x04R\n" +
+	"startBlock\x12\x1d\n" +
+	"\n" +
+	"batch_size\x18\b \x01(\x05R\tbatchSize\x12\x18\n" +
+	"\aworkers\x18\t \x01(\x05R\aworkers\x12/\n" +
+	"\x13confirmation_blocks\x18\n" +
+	" \x01(\x04R\x12confirmationBlocks\";\n" +
+	"\x10AddChainResponse\x12'\n" +
+	"\x05chain\x18\x01 \x01(\v2\x11.indexer.v1.ChainR\x05chain\"/\n" +
+	"\x12RemoveChainRequest\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\tR\achainId\"\x15\n" +
+	"\x13RemoveChainResponse\"%\n" +
+	"\x13GetOperationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"K\n" +
+	"\x14GetOperationResponse\x123\n" +
+	"\toperation\x18\x01 \x01(\v2\x15.indexer.v1.OperationR\toperation\"2\n" +
+	"\x15ListOperationsRequest\x12\x19\n" +
+	"\bchain_id\x18\x01 \x01(\tR\achainId\"O\n" +
+	"\x16ListOperationsResponse\x125\n" +
+	"\n" +
+	"operations\x18\x01 \x03(\v2\x15.indexer.v1.OperationR\n" +
+	"operations\"'\n" +
+	"\x15WatchOperationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id*i\n" +
 	"\tChainType\x12\x1a\n" +
 	"\x16CHAIN_TYPE_UNSPECIFIED\x10\x00\x12\x12\n" +
 	"\x0eCHAIN_TYPE_EVM\x10\x01\x12\x15\n" +
 	"\x11CHAIN_TYPE_SOLANA\x10\x02\x12\x15\n" +
-	"\x11CHAIN_TYPE_COSMOS\x10\x03*\x91\x01\n" +
+	"\x11CHAIN_TYPE_COSMOS\x10\x03*\xaa\x01\n" +
 	"\vChainStatus\x12\x1c\n" +
 	"\x18CHAIN_STATUS_UNSPECIFIED\x10\x00\x12\x17\n" +
 	"\x13CHAIN_STATUS_ACTIVE\x10\x01\x12\x19\n" +
 	"\x15CHAIN_STATUS_INACTIVE\x10\x02\x12\x18\n" +
 	"\x14CHAIN_STATUS_SYNCING\x10\x03\x12\x16\n" +
-	"\x12CHAIN_STATUS_ERROR\x10\x04*\x96\x01\n" +
+	"\x12CHAIN_STATUS_ERROR\x10\x04\x12\x17\n" +
+	"\x13CHAIN_STATUS_PAUSED\x10\x05*\x96\x01\n" +
 	"\x11TransactionStatus\x12\"\n" +
 	"\x1eTRANSACTION_STATUS_UNSPECIFIED\x10\x00\x12\x1e\n" +
 	"\x1aTRANSACTION_STATUS_PENDING\x10\x01\x12\x1e\n" +
 	"\x1aTRANSACTION_STATUS_SUCCESS\x10\x02\x12\x1d\n" +
-	"\x19TRANSACTION_STATUS_FAILED\x10\x032\xfa\t\n" +
+	"\x19TRANSACTION_STATUS_FAILED\x10\x03*r\n" +
+	"\rOperationKind\x12\x1e\n" +
+	"\x1aOPERATION_KIND_UNSPECIFIED\x10\x00\x12 \n" +
+	"\x1cOPERATION_KIND_REINDEX_RANGE\x10\x01\x12\x1f\n" +
+	"\x1bOPERATION_KIND_RECOVER_GAPS\x10\x02*\x89\x01\n" +
+	"\x0eOperationState\x12\x1f\n" +
+	"\x1bOPERATION_STATE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17OPERATION_STATE_RUNNING\x10\x01\x12\x1d\n" +
+	"\x19OPERATION_STATE_SUCCEEDED\x10\x02\x12\x1a\n" +
+	"\x16OPERATION_STATE_FAILED\x10\x032\xa6\v\n" +
 	"\x0eIndexerService\x12E\n" +
 	"\bGetChain\x12\x1b.indexer.v1.GetChainRequest\x1a\x1c.indexer.v1.GetChainResponse\x12K\n" +
 	"\n" +
@@ -2548,13 +4510,26 @@ const file_api_proto_indexer_v1_indexer_proto_rawDesc = "" +
 	"\x0eGetLatestBlock\x12!.indexer.v1.GetLatestBlockRequest\x1a\".indexer.v1.GetLatestBlockResponse\x12W\n" +
 	"\x0eGetTransaction\x12!.indexer.v1.GetTransactionRequest\x1a\".indexer.v1.GetTransactionResponse\x12r\n" +
 	"\x17ListTransactionsByBlock\x12*.indexer.v1.ListTransactionsByBlockRequest\x1a+.indexer.v1.ListTransactionsByBlockResponse\x12x\n" +
-	"\x19ListTransactionsByAddress\x12,.indexer.v1.ListTransactionsByAddressRequest\x1a-.indexer.v1.ListTransactionsByAddressResponse\x12N\n" +
+	"\x19ListTransactionsByAddress\x12,.indexer.v1.ListTransactionsByAddressRequest\x1a-.indexer.v1.ListTransactionsByAddressResponse\x12E\n" +
+	"\bListLogs\x12\x1b.indexer.v1.ListLogsRequest\x1a\x1c.indexer.v1.ListLogsResponse\x12c\n" +
+	"\x12ListTokenTransfers\x12%.indexer.v1.ListTokenTransfersRequest\x1a&.indexer.v1.ListTokenTransfersResponse\x12N\n" +
 	"\vGetProgress\x12\x1e.indexer.v1.GetProgressRequest\x1a\x1f.indexer.v1.GetProgressResponse\x12E\n" +
 	"\bListGaps\x12\x1b.indexer.v1.ListGapsRequest\x1a\x1c.indexer.v1.ListGapsResponse\x12E\n" +
 	"\bGetStats\x12\x1b.indexer.v1.GetStatsRequest\x1a\x1c.indexer.v1.GetStatsResponse\x12D\n" +
 	"\fStreamBlocks\x12\x1f.indexer.v1.StreamBlocksRequest\x1a\x11.indexer.v1.Block0\x01\x12V\n" +
 	"\x12StreamTransactions\x12%.indexer.v1.StreamTransactionsRequest\x1a\x17.indexer.v1.Transaction0\x01\x12K\n" +
-	"\x0eStreamProgress\x12!.indexer.v1.StreamProgressRequest\x1a\x14.indexer.v1.Progress0\x01BMZKgithub.com/sage-x-project/blockchain-indexer/api/proto/indexer/v1;indexerv1b\x06proto3"
+	"\x0eStreamProgress\x12!.indexer.v1.StreamProgressRequest\x1a\x14.indexer.v1.Progress0\x012\xe6\x05\n" +
+	"\x13IndexerAdminService\x12K\n" +
+	"\n" +
+	"PauseChain\x12\x1d.indexer.v1.PauseChainRequest\x1a\x1e.indexer.v1.PauseChainResponse\x12N\n" +
+	"\vResumeChain\x12\x1e.indexer.v1.ResumeChainRequest\x1a\x1f.indexer.v1.ResumeChainResponse\x12E\n" +
+	"\bAddChain\x12\x1b.indexer.v1.AddChainRequest\x1a\x1c.indexer.v1.AddChainResponse\x12N\n" +
+	"\vRemoveChain\x12\x1e.indexer.v1.RemoveChainRequest\x1a\x1f.indexer.v1.RemoveChainResponse\x12Q\n" +
+	"\fReindexRange\x12\x1f.indexer.v1.ReindexRangeRequest\x1a .indexer.v1.ReindexRangeResponse\x12N\n" +
+	"\vRecoverGaps\x12\x1e.indexer.v1.RecoverGapsRequest\x1a\x1f.indexer.v1.RecoverGapsResponse\x12Q\n" +
+	"\fGetOperation\x12\x1f.indexer.v1.GetOperationRequest\x1a .indexer.v1.GetOperationResponse\x12W\n" +
+	"\x0eListOperations\x12!.indexer.v1.ListOperationsRequest\x1a\".indexer.v1.ListOperationsResponse\x12L\n" +
+	"\x0eWatchOperation\x12!.indexer.v1.WatchOperationRequest\x1a\x15.indexer.v1.Operation0\x01BMZKgithub.com/sage-x-project/blockchain-indexer/api/proto/indexer/v1;indexerv1b\x06proto3"
 
 var (
 	file_api_proto_indexer_v1_indexer_proto_rawDescOnce sync.Once
@@ -2568,109 +4543,175 @@ func file_api_proto_indexer_v1_indexer_proto_rawDescGZIP() []byte {
 	return file_api_proto_indexer_v1_indexer_proto_rawDescData
 }
 
-var file_api_proto_indexer_v1_indexer_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_api_proto_indexer_v1_indexer_proto_msgTypes = make([]protoimpl.MessageInfo, 35)
+var file_api_proto_indexer_v1_indexer_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_api_proto_indexer_v1_indexer_proto_msgTypes = make([]protoimpl.MessageInfo, 60)
 var file_api_proto_indexer_v1_indexer_proto_goTypes = []any{
 	(ChainType)(0),                            // 0: indexer.v1.ChainType
 	(ChainStatus)(0),                          // 1: indexer.v1.ChainStatus
 	(TransactionStatus)(0),                    // 2: indexer.v1.TransactionStatus
-	(*Chain)(nil),                             // 3: indexer.v1.Chain
-	(*Block)(nil),                             // 4: indexer.v1.Block
-	(*Transaction)(nil),                       // 5: indexer.v1.Transaction
-	(*Log)(nil),                               // 6: indexer.v1.Log
-	(*Progress)(nil),                          // 7: indexer.v1.Progress
-	(*Gap)(nil),                               // 8: indexer.v1.Gap
-	(*Stats)(nil),                             // 9: indexer.v1.Stats
-	(*PageInfo)(nil),                          // 10: indexer.v1.PageInfo
-	(*GetChainRequest)(nil),                   // 11: indexer.v1.GetChainRequest
-	(*GetChainResponse)(nil),                  // 12: indexer.v1.GetChainResponse
-	(*ListChainsRequest)(nil),                 // 13: indexer.v1.ListChainsRequest
-	(*ListChainsResponse)(nil),                // 14: indexer.v1.ListChainsResponse
-	(*GetBlockRequest)(nil),                   // 15: indexer.v1.GetBlockRequest
-	(*GetBlockResponse)(nil),                  // 16: indexer.v1.GetBlockResponse
-	(*GetBlockByHashRequest)(nil),             // 17: indexer.v1.GetBlockByHashRequest
-	(*GetBlockByHashResponse)(nil),            // 18: indexer.v1.GetBlockByHashResponse
-	(*ListBlocksRequest)(nil),                 // 19: indexer.v1.ListBlocksRequest
-	(*ListBlocksResponse)(nil),                // 20: indexer.v1.ListBlocksResponse
-	(*GetLatestBlockRequest)(nil),             // 21: indexer.v1.GetLatestBlockRequest
-	(*GetLatestBlockResponse)(nil),            // 22: indexer.v1.GetLatestBlockResponse
-	(*GetTransactionRequest)(nil),             // 23: indexer.v1.GetTransactionRequest
-	(*GetTransactionResponse)(nil),            // 24: indexer.v1.GetTransactionResponse
-	(*ListTransactionsByBlockRequest)(nil),    // 25: indexer.v1.ListTransactionsByBlockRequest
-	(*ListTransactionsByBlockResponse)(nil),   // 26: indexer.v1.ListTransactionsByBlockResponse
-	(*ListTransactionsByAddressRequest)(nil),  // 27: indexer.v1.ListTransactionsByAddressRequest
-	(*ListTransactionsByAddressResponse)(nil), // 28: indexer.v1.ListTransactionsByAddressResponse
-	(*GetProgressRequest)(nil),                // 29: indexer.v1.GetProgressRequest
-	(*GetProgressResponse)(nil),               // 30: indexer.v1.GetProgressResponse
-	(*ListGapsRequest)(nil),                   // 31: indexer.v1.ListGapsRequest
-	(*ListGapsResponse)(nil),                  // 32: indexer.v1.ListGapsResponse
-	(*GetStatsRequest)(nil),                   // 33: indexer.v1.GetStatsRequest
-	(*GetStatsResponse)(nil),                  // 34: indexer.v1.GetStatsResponse
-	(*StreamBlocksRequest)(nil),               // 35: indexer.v1.StreamBlocksRequest
-	(*StreamTransactionsRequest)(nil),         // 36: indexer.v1.StreamTransactionsRequest
-	(*StreamProgressRequest)(nil),             // 37: indexer.v1.StreamProgressRequest
-	(*timestamppb.Timestamp)(nil),             // 38: google.protobuf.Timestamp
+	(OperationKind)(0),                        // 3: indexer.v1.OperationKind
+	(OperationState)(0),                       // 4: indexer.v1.OperationState
+	(*Chain)(nil),                             // 5: indexer.v1.Chain
+	(*Block)(nil),                             // 6: indexer.v1.Block
+	(*Transaction)(nil),                       // 7: indexer.v1.Transaction
+	(*InternalTransaction)(nil),               // 8: indexer.v1.InternalTransaction
+	(*Log)(nil),                               // 9: indexer.v1.Log
+	(*TopicFilter)(nil),                       // 10: indexer.v1.TopicFilter
+	(*TokenTransfer)(nil),                     // 11: indexer.v1.TokenTransfer
+	(*Progress)(nil),                          // 12: indexer.v1.Progress
+	(*Gap)(nil),                               // 13: indexer.v1.Gap
+	(*Stats)(nil),                             // 14: indexer.v1.Stats
+	(*PageInfo)(nil),                          // 15: indexer.v1.PageInfo
+	(*GetChainRequest)(nil),                   // 16: indexer.v1.GetChainRequest
+	(*GetChainResponse)(nil),                  // 17: indexer.v1.GetChainResponse
+	(*ListChainsRequest)(nil),                 // 18: indexer.v1.ListChainsRequest
+	(*ListChainsResponse)(nil),                // 19: indexer.v1.ListChainsResponse
+	(*GetBlockRequest)(nil),                   // 20: indexer.v1.GetBlockRequest
+	(*GetBlockResponse)(nil),                  // 21: indexer.v1.GetBlockResponse
+	(*GetBlockByHashRequest)(nil),             // 22: indexer.v1.GetBlockByHashRequest
+	(*GetBlockByHashResponse)(nil),            // 23: indexer.v1.GetBlockByHashResponse
+	(*ListBlocksRequest)(nil),                 // 24: indexer.v1.ListBlocksRequest
+	(*ListBlocksResponse)(nil),                // 25: indexer.v1.ListBlocksResponse
+	(*GetLatestBlockRequest)(nil),             // 26: indexer.v1.GetLatestBlockRequest
+	(*GetLatestBlockResponse)(nil),            // 27: indexer.v1.GetLatestBlockResponse
+	(*GetTransactionRequest)(nil),             // 28: indexer.v1.GetTransactionRequest
+	(*GetTransactionResponse)(nil),            // 29: indexer.v1.GetTransactionResponse
+	(*ListTransactionsByBlockRequest)(nil),    // 30: indexer.v1.ListTransactionsByBlockRequest
+	(*ListTransactionsByBlockResponse)(nil),   // 31: indexer.v1.ListTransactionsByBlockResponse
+	(*ListTransactionsByAddressRequest)(nil),  // 32: indexer.v1.ListTransactionsByAddressRequest
+	(*ListTransactionsByAddressResponse)(nil), // 33: indexer.v1.ListTransactionsByAddressResponse
+	(*ListLogsRequest)(nil),                   // 34: indexer.v1.ListLogsRequest
+	(*ListLogsResponse)(nil),                  // 35: indexer.v1.ListLogsResponse
+	(*ListTokenTransfersRequest)(nil),         // 36: indexer.v1.ListTokenTransfersRequest
+	(*ListTokenTransfersResponse)(nil),        // 37: indexer.v1.ListTokenTransfersResponse
+	(*GetProgressRequest)(nil),                // 38: indexer.v1.GetProgressRequest
+	(*GetProgressResponse)(nil),               // 39: indexer.v1.GetProgressResponse
+	(*ListGapsRequest)(nil),                   // 40: indexer.v1.ListGapsRequest
+	(*ListGapsResponse)(nil),                  // 41: indexer.v1.ListGapsResponse
+	(*GetStatsRequest)(nil),                   // 42: indexer.v1.GetStatsRequest
+	(*GetStatsResponse)(nil),                  // 43: indexer.v1.GetStatsResponse
+	(*StreamBlocksRequest)(nil),               // 44: indexer.v1.StreamBlocksRequest
+	(*StreamTransactionsRequest)(nil),         // 45: indexer.v1.StreamTransactionsRequest
+	(*StreamProgressRequest)(nil),             // 46: indexer.v1.StreamProgressRequest
+	(*Operation)(nil),                         // 47: indexer.v1.Operation
+	(*PauseChainRequest)(nil),                 // 48: indexer.v1.PauseChainRequest
+	(*PauseChainResponse)(nil),                // 49: indexer.v1.PauseChainResponse
+	(*ResumeChainRequest)(nil),                // 50: indexer.v1.ResumeChainRequest
+	(*ResumeChainResponse)(nil),               // 51: indexer.v1.ResumeChainResponse
+	(*ReindexRangeRequest)(nil),               // 52: indexer.v1.ReindexRangeRequest
+	(*ReindexRangeResponse)(nil),              // 53: indexer.v1.ReindexRangeResponse
+	(*RecoverGapsRequest)(nil),                // 54: indexer.v1.RecoverGapsRequest
+	(*RecoverGapsResponse)(nil),               // 55: indexer.v1.RecoverGapsResponse
+	(*AddChainRequest)(nil),                   // 56: indexer.v1.AddChainRequest
+	(*AddChainResponse)(nil),                  // 57: indexer.v1.AddChainResponse
+	(*RemoveChainRequest)(nil),                // 58: indexer.v1.RemoveChainRequest
+	(*RemoveChainResponse)(nil),               // 59: indexer.v1.RemoveChainResponse
+	(*GetOperationRequest)(nil),               // 60: indexer.v1.GetOperationRequest
+	(*GetOperationResponse)(nil),              // 61: indexer.v1.GetOperationResponse
+	(*ListOperationsRequest)(nil),             // 62: indexer.v1.ListOperationsRequest
+	(*ListOperationsResponse)(nil),            // 63: indexer.v1.ListOperationsResponse
+	(*WatchOperationRequest)(nil),             // 64: indexer.v1.WatchOperationRequest
+	(*timestamppb.Timestamp)(nil),             // 65: google.protobuf.Timestamp
 }
 var file_api_proto_indexer_v1_indexer_proto_depIdxs = []int32{
 	0,  // 0: indexer.v1.Chain.chain_type:type_name -> indexer.v1.ChainType
 	1,  // 1: indexer.v1.Chain.status:type_name -> indexer.v1.ChainStatus
-	38, // 2: indexer.v1.Chain.last_updated:type_name -> google.protobuf.Timestamp
+	65, // 2: indexer.v1.Chain.last_updated:type_name -> google.protobuf.Timestamp
 	0,  // 3: indexer.v1.Block.chain_type:type_name -> indexer.v1.ChainType
-	38, // 4: indexer.v1.Block.timestamp:type_name -> google.protobuf.Timestamp
-	5,  // 5: indexer.v1.Block.transactions:type_name -> indexer.v1.Transaction
-	38, // 6: indexer.v1.Block.indexed_at:type_name -> google.protobuf.Timestamp
-	38, // 7: indexer.v1.Transaction.block_timestamp:type_name -> google.protobuf.Timestamp
+	65, // 4: indexer.v1.Block.timestamp:type_name -> google.protobuf.Timestamp
+	7,  // 5: indexer.v1.Block.transactions:type_name -> indexer.v1.Transaction
+	65, // 6: indexer.v1.Block.indexed_at:type_name -> google.protobuf.Timestamp
+	65, // 7: indexer.v1.Transaction.block_timestamp:type_name -> google.protobuf.Timestamp
 	2,  // 8: indexer.v1.Transaction.status:type_name -> indexer.v1.TransactionStatus
-	6,  // 9: indexer.v1.Transaction.logs:type_name -> indexer.v1.Log
-	38, // 10: indexer.v1.Transaction.indexed_at:type_name -> google.protobuf.Timestamp
-	38, // 11: indexer.v1.Progress.last_updated:type_name -> google.protobuf.Timestamp
-	3,  // 12: indexer.v1.GetChainResponse.chain:type_name -> indexer.v1.Chain
-	3,  // 13: indexer.v1.ListChainsResponse.chains:type_name -> indexer.v1.Chain
-	4,  // 14: indexer.v1.GetBlockResponse.block:type_name -> indexer.v1.Block
-	4,  // 15: indexer.v1.GetBlockByHashResponse.block:type_name -> indexer.v1.Block
-	4,  // 16: indexer.v1.ListBlocksResponse.blocks:type_name -> indexer.v1.Block
-	4,  // 17: indexer.v1.GetLatestBlockResponse.block:type_name -> indexer.v1.Block
-	5,  // 18: indexer.v1.GetTransactionResponse.transaction:type_name -> indexer.v1.Transaction
-	5,  // 19: indexer.v1.ListTransactionsByBlockResponse.transactions:type_name -> indexer.v1.Transaction
-	5,  // 20: indexer.v1.ListTransactionsByAddressResponse.transactions:type_name -> indexer.v1.Transaction
-	7,  // 21: indexer.v1.GetProgressResponse.progress:type_name -> indexer.v1.Progress
-	8,  // 22: indexer.v1.ListGapsResponse.gaps:type_name -> indexer.v1.Gap
-	9,  // 23: indexer.v1.GetStatsResponse.stats:type_name -> indexer.v1.Stats
-	11, // 24: indexer.v1.IndexerService.GetChain:input_type -> indexer.v1.GetChainRequest
-	13, // 25: indexer.v1.IndexerService.ListChains:input_type -> indexer.v1.ListChainsRequest
-	15, // 26: indexer.v1.IndexerService.GetBlock:input_type -> indexer.v1.GetBlockRequest
-	17, // 27: indexer.v1.IndexerService.GetBlockByHash:input_type -> indexer.v1.GetBlockByHashRequest
-	19, // 28: indexer.v1.IndexerService.ListBlocks:input_type -> indexer.v1.ListBlocksRequest
-	21, // 29: indexer.v1.IndexerService.GetLatestBlock:input_type -> indexer.v1.GetLatestBlockRequest
-	23, // 30: indexer.v1.IndexerService.GetTransaction:input_type -> indexer.v1.GetTransactionRequest
-	25, // 31: indexer.v1.IndexerService.ListTransactionsByBlock:input_type -> indexer.v1.ListTransactionsByBlockRequest
-	27, // 32: indexer.v1.IndexerService.ListTransactionsByAddress:input_type -> indexer.v1.ListTransactionsByAddressRequest
-	29, // 33: indexer.v1.IndexerService.GetProgress:input_type -> indexer.v1.GetProgressRequest
-	31, // 34: indexer.v1.IndexerService.ListGaps:input_type -> indexer.v1.ListGapsRequest
-	33, // 35: indexer.v1.IndexerService.GetStats:input_type -> indexer.v1.GetStatsRequest
-	35, // 36: indexer.v1.IndexerService.StreamBlocks:input_type -> indexer.v1.StreamBlocksRequest
-	36, // 37: indexer.v1.IndexerService.StreamTransactions:input_type -> indexer.v1.StreamTransactionsRequest
-	37, // 38: indexer.v1.IndexerService.StreamProgress:input_type -> indexer.v1.StreamProgressRequest
-	12, // 39: indexer.v1.IndexerService.GetChain:output_type -> indexer.v1.GetChainResponse
-	14, // 40: indexer.v1.IndexerService.ListChains:output_type -> indexer.v1.ListChainsResponse
-	16, // 41: indexer.v1.IndexerService.GetBlock:output_type -> indexer.v1.GetBlockResponse
-	18, // 42: indexer.v1.IndexerService.GetBlockByHash:output_type -> indexer.v1.GetBlockByHashResponse
-	20, // 43: indexer.v1.IndexerService.ListBlocks:output_type -> indexer.v1.ListBlocksResponse
-	22, // 44: indexer.v1.IndexerService.GetLatestBlock:output_type -> indexer.v1.GetLatestBlockResponse
-	24, // 45: indexer.v1.IndexerService.GetTransaction:output_type -> indexer.v1.GetTransactionResponse
-	26, // 46: indexer.v1.IndexerService.ListTransactionsByBlock:output_type -> indexer.v1.ListTransactionsByBlockResponse
-	28, // 47: indexer.v1.IndexerService.ListTransactionsByAddress:output_type -> indexer.v1.ListTransactionsByAddressResponse
-	30, // 48: indexer.v1.IndexerService.GetProgress:output_type -> indexer.v1.GetProgressResponse
-	32, // 49: indexer.v1.IndexerService.ListGaps:output_type -> indexer.v1.ListGapsResponse
-	34, // 50: indexer.v1.IndexerService.GetStats:output_type -> indexer.v1.GetStatsResponse
-	4,  // 51: indexer.v1.IndexerService.StreamBlocks:output_type -> indexer.v1.Block
-	5,  // 52: indexer.v1.IndexerService.StreamTransactions:output_type -> indexer.v1.Transaction
-	7,  // 53: indexer.v1.IndexerService.StreamProgress:output_type -> indexer.v1.Progress
-	39, // [39:54] is the sub-list for method output_type
-	24, // [24:39] is the sub-list for method input_type
-	24, // [24:24] is the sub-list for extension type_name
-	24, // [24:24] is the sub-list for extension extendee
-	0,  // [0:24] is the sub-list for field type_name
+	9,  // 9: indexer.v1.Transaction.logs:type_name -> indexer.v1.Log
+	65, // 10: indexer.v1.Transaction.indexed_at:type_name -> google.protobuf.Timestamp
+	8,  // 11: indexer.v1.Transaction.internal_transactions:type_name -> indexer.v1.InternalTransaction
+	65, // 12: indexer.v1.TokenTransfer.block_timestamp:type_name -> google.protobuf.Timestamp
+	65, // 13: indexer.v1.Progress.last_updated:type_name -> google.protobuf.Timestamp
+	5,  // 14: indexer.v1.GetChainResponse.chain:type_name -> indexer.v1.Chain
+	5,  // 15: indexer.v1.ListChainsResponse.chains:type_name -> indexer.v1.Chain
+	6,  // 16: indexer.v1.GetBlockResponse.block:type_name -> indexer.v1.Block
+	6,  // 17: indexer.v1.GetBlockByHashResponse.block:type_name -> indexer.v1.Block
+	6,  // 18: indexer.v1.ListBlocksResponse.blocks:type_name -> indexer.v1.Block
+	6,  // 19: indexer.v1.GetLatestBlockResponse.block:type_name -> indexer.v1.Block
+	7,  // 20: indexer.v1.GetTransactionResponse.transaction:type_name -> indexer.v1.Transaction
+	7,  // 21: indexer.v1.ListTransactionsByBlockResponse.transactions:type_name -> indexer.v1.Transaction
+	7,  // 22: indexer.v1.ListTransactionsByAddressResponse.transactions:type_name -> indexer.v1.Transaction
+	10, // 23: indexer.v1.ListLogsRequest.topics:type_name -> indexer.v1.TopicFilter
+	9,  // 24: indexer.v1.ListLogsResponse.logs:type_name -> indexer.v1.Log
+	11, // 25: indexer.v1.ListTokenTransfersResponse.transfers:type_name -> indexer.v1.TokenTransfer
+	12, // 26: indexer.v1.GetProgressResponse.progress:type_name -> indexer.v1.Progress
+	13, // 27: indexer.v1.ListGapsResponse.gaps:type_name -> indexer.v1.Gap
+	14, // 28: indexer.v1.GetStatsResponse.stats:type_name -> indexer.v1.Stats
+	3,  // 29: indexer.v1.Operation.kind:type_name -> indexer.v1.OperationKind
+	4,  // 30: indexer.v1.Operation.state:type_name -> indexer.v1.OperationState
+	65, // 31: indexer.v1.Operation.created_at:type_name -> google.protobuf.Timestamp
+	65, // 32: indexer.v1.Operation.updated_at:type_name -> google.protobuf.Timestamp
+	65, // 33: indexer.v1.Operation.finished_at:type_name -> google.protobuf.Timestamp
+	5,  // 34: indexer.v1.PauseChainResponse.chain:type_name -> indexer.v1.Chain
+	5,  // 35: indexer.v1.ResumeChainResponse.chain:type_name -> indexer.v1.Chain
+	47, // 36: indexer.v1.ReindexRangeResponse.operation:type_name -> indexer.v1.Operation
+	47, // 37: indexer.v1.RecoverGapsResponse.operation:type_name -> indexer.v1.Operation
+	5,  // 38: indexer.v1.AddChainResponse.chain:type_name -> indexer.v1.Chain
+	47, // 39: indexer.v1.GetOperationResponse.operation:type_name -> indexer.v1.Operation
+	47, // 40: indexer.v1.ListOperationsResponse.operations:type_name -> indexer.v1.Operation
+	16, // 41: indexer.v1.IndexerService.GetChain:input_type -> indexer.v1.GetChainRequest
+	18, // 42: indexer.v1.IndexerService.ListChains:input_type -> indexer.v1.ListChainsRequest
+	20, // 43: indexer.v1.IndexerService.GetBlock:input_type -> indexer.v1.GetBlockRequest
+	22, // 44: indexer.v1.IndexerService.GetBlockByHash:input_type -> indexer.v1.GetBlockByHashRequest
+	24, // 45: indexer.v1.IndexerService.ListBlocks:input_type -> indexer.v1.ListBlocksRequest
+	26, // 46: indexer.v1.IndexerService.GetLatestBlock:input_type -> indexer.v1.GetLatestBlockRequest
+	28, // 47: indexer.v1.IndexerService.GetTransaction:input_type -> indexer.v1.GetTransactionRequest
+	30, // 48: indexer.v1.IndexerService.ListTransactionsByBlock:input_type -> indexer.v1.ListTransactionsByBlockRequest
+	32, // 49: indexer.v1.IndexerService.ListTransactionsByAddress:input_type -> indexer.v1.ListTransactionsByAddressRequest
+	34, // 50: indexer.v1.IndexerService.ListLogs:input_type -> indexer.v1.ListLogsRequest
+	36, // 51: indexer.v1.IndexerService.ListTokenTransfers:input_type -> indexer.v1.ListTokenTransfersRequest
+	38, // 52: indexer.v1.IndexerService.GetProgress:input_type -> indexer.v1.GetProgressRequest
+	40, // 53: indexer.v1.IndexerService.ListGaps:input_type -> indexer.v1.ListGapsRequest
+	42, // 54: indexer.v1.IndexerService.GetStats:input_type -> indexer.v1.GetStatsRequest
+	44, // 55: indexer.v1.IndexerService.StreamBlocks:input_type -> indexer.v1.StreamBlocksRequest
+	45, // 56: indexer.v1.IndexerService.StreamTransactions:input_type -> indexer.v1.StreamTransactionsRequest
+	46, // 57: indexer.v1.IndexerService.StreamProgress:input_type -> indexer.v1.StreamProgressRequest
+	48, // 58: indexer.v1.IndexerAdminService.PauseChain:input_type -> indexer.v1.PauseChainRequest
+	50, // 59: indexer.v1.IndexerAdminService.ResumeChain:input_type -> indexer.v1.ResumeChainRequest
+	56, // 60: indexer.v1.IndexerAdminService.AddChain:input_type -> indexer.v1.AddChainRequest
+	58, // 61: indexer.v1.IndexerAdminService.RemoveChain:input_type -> indexer.v1.RemoveChainRequest
+	52, // 62: indexer.v1.IndexerAdminService.ReindexRange:input_type -> indexer.v1.ReindexRangeRequest
+	54, // 63: indexer.v1.IndexerAdminService.RecoverGaps:input_type -> indexer.v1.RecoverGapsRequest
+	60, // 64: indexer.v1.IndexerAdminService.GetOperation:input_type -> indexer.v1.GetOperationRequest
+	62, // 65: indexer.v1.IndexerAdminService.ListOperations:input_type -> indexer.v1.ListOperationsRequest
+	64, // 66: indexer.v1.IndexerAdminService.WatchOperation:input_type -> indexer.v1.WatchOperationRequest
+	17, // 67: indexer.v1.IndexerService.GetChain:output_type -> indexer.v1.GetChainResponse
+	19, // 68: indexer.v1.IndexerService.ListChains:output_type -> indexer.v1.ListChainsResponse
+	21, // 69: indexer.v1.IndexerService.GetBlock:output_type -> indexer.v1.GetBlockResponse
+	23, // 70: indexer.v1.IndexerService.GetBlockByHash:output_type -> indexer.v1.GetBlockByHashResponse
+	25, // 71: indexer.v1.IndexerService.ListBlocks:output_type -> indexer.v1.ListBlocksResponse
+	27, // 72: indexer.v1.IndexerService.GetLatestBlock:output_type -> indexer.v1.GetLatestBlockResponse
+	29, // 73: indexer.v1.IndexerService.GetTransaction:output_type -> indexer.v1.GetTransactionResponse
+	31, // 74: indexer.v1.IndexerService.ListTransactionsByBlock:output_type -> indexer.v1.ListTransactionsByBlockResponse
+	33, // 75: indexer.v1.IndexerService.ListTransactionsByAddress:output_type -> indexer.v1.ListTransactionsByAddressResponse
+	35, // 76: indexer.v1.IndexerService.ListLogs:output_type -> indexer.v1.ListLogsResponse
+	37, // 77: indexer.v1.IndexerService.ListTokenTransfers:output_type -> indexer.v1.ListTokenTransfersResponse
+	39, // 78: indexer.v1.IndexerService.GetProgress:output_type -> indexer.v1.GetProgressResponse
+	41, // 79: indexer.v1.IndexerService.ListGaps:output_type -> indexer.v1.ListGapsResponse
+	43, // 80: indexer.v1.IndexerService.GetStats:output_type -> indexer.v1.GetStatsResponse
+	6,  // 81: indexer.v1.IndexerService.StreamBlocks:output_type -> indexer.v1.Block
+	7,  // 82: indexer.v1.IndexerService.StreamTransactions:output_type -> indexer.v1.Transaction
+	12, // 83: indexer.v1.IndexerService.StreamProgress:output_type -> indexer.v1.Progress
+	49, // 84: indexer.v1.IndexerAdminService.PauseChain:output_type -> indexer.v1.PauseChainResponse
+	51, // 85: indexer.v1.IndexerAdminService.ResumeChain:output_type -> indexer.v1.ResumeChainResponse
+	57, // 86: indexer.v1.IndexerAdminService.AddChain:output_type -> indexer.v1.AddChainResponse
+	59, // 87: indexer.v1.IndexerAdminService.RemoveChain:output_type -> indexer.v1.RemoveChainResponse
+	53, // 88: indexer.v1.IndexerAdminService.ReindexRange:output_type -> indexer.v1.ReindexRangeResponse
+	55, // 89: indexer.v1.IndexerAdminService.RecoverGaps:output_type -> indexer.v1.RecoverGapsResponse
+	61, // 90: indexer.v1.IndexerAdminService.GetOperation:output_type -> indexer.v1.GetOperationResponse
+	63, // 91: indexer.v1.IndexerAdminService.ListOperations:output_type -> indexer.v1.ListOperationsResponse
+	47, // 92: indexer.v1.IndexerAdminService.WatchOperation:output_type -> indexer.v1.Operation
+	67, // [67:93] is the sub-list for method output_type
+	41, // [41:67] is the sub-list for method input_type
+	41, // [41:41] is the sub-list for extension type_name
+	41, // [41:41] is the sub-list for extension extendee
+	0,  // [0:41] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_indexer_v1_indexer_proto_init() }
@@ -2678,15 +4719,17 @@ func file_api_proto_indexer_v1_indexer_proto_init() {
 	if File_api_proto_indexer_v1_indexer_proto != nil {
 		return
 	}
+	file_api_proto_indexer_v1_indexer_proto_msgTypes[29].OneofWrappers = []any{}
+	file_api_proto_indexer_v1_indexer_proto_msgTypes[31].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_indexer_v1_indexer_proto_rawDesc), len(file_api_proto_indexer_v1_indexer_proto_rawDesc)),
-			NumEnums:      3,
-			NumMessages:   35,
+			NumEnums:      5,
+			NumMessages:   60,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_api_proto_indexer_v1_indexer_proto_goTypes,
 		DependencyIndexes: file_api_proto_indexer_v1_indexer_proto_depIdxs,
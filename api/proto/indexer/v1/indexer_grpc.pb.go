@@ -28,6 +28,8 @@ const (
 	IndexerService_GetTransaction_FullMethodName            = "/indexer.v1.IndexerService/GetTransaction"
 	IndexerService_ListTransactionsByBlock_FullMethodName   = "/indexer.v1.IndexerService/ListTransactionsByBlock"
 	IndexerService_ListTransactionsByAddress_FullMethodName = "/indexer.v1.IndexerService/ListTransactionsByAddress"
+	IndexerService_ListLogs_FullMethodName                  = "/indexer.v1.IndexerService/ListLogs"
+	IndexerService_ListTokenTransfers_FullMethodName        = "/indexer.v1.IndexerService/ListTokenTransfers"
 	IndexerService_GetProgress_FullMethodName               = "/indexer.v1.IndexerService/GetProgress"
 	IndexerService_ListGaps_FullMethodName                  = "/indexer.v1.IndexerService/ListGaps"
 	IndexerService_GetStats_FullMethodName                  = "/indexer.v1.IndexerService/GetStats"
@@ -54,6 +56,10 @@ type IndexerServiceClient interface {
 	GetTransaction(ctx context.Context, in *GetTransactionRequest, opts ...grpc.CallOption) (*GetTransactionResponse, error)
 	ListTransactionsByBlock(ctx context.Context, in *ListTransactionsByBlockRequest, opts ...grpc.CallOption) (*ListTransactionsByBlockResponse, error)
 	ListTransactionsByAddress(ctx context.Context, in *ListTransactionsByAddressRequest, opts ...grpc.CallOption) (*ListTransactionsByAddressResponse, error)
+	// Log operations
+	ListLogs(ctx context.Context, in *ListLogsRequest, opts ...grpc.CallOption) (*ListLogsResponse, error)
+	// Token transfer operations
+	ListTokenTransfers(ctx context.Context, in *ListTokenTransfersRequest, opts ...grpc.CallOption) (*ListTokenTransfersResponse, error)
 	// Progress operations
 	GetProgress(ctx context.Context, in *GetProgressRequest, opts ...grpc.CallOption) (*GetProgressResponse, error)
 	// Gap operations
@@ -164,6 +170,26 @@ func (c *indexerServiceClient) ListTransactionsByAddress(ctx context.Context, in
 	return out, nil
 }
 
+func (c *indexerServiceClient) ListLogs(ctx context.Context, in *ListLogsRequest, opts ...grpc.CallOption) (*ListLogsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLogsResponse)
+	err := c.cc.Invoke(ctx, IndexerService_ListLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexerServiceClient) ListTokenTransfers(ctx context.Context, in *ListTokenTransfersRequest, opts ...grpc.CallOption) (*ListTokenTransfersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTokenTransfersResponse)
+	err := c.cc.Invoke(ctx, IndexerService_ListTokenTransfers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *indexerServiceClient) GetProgress(ctx context.Context, in *GetProgressRequest, opts ...grpc.CallOption) (*GetProgressResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetProgressResponse)
@@ -269,6 +295,10 @@ type IndexerServiceServer interface {
 	GetTransaction(context.Context, *GetTransactionRequest) (*GetTransactionResponse, error)
 	ListTransactionsByBlock(context.Context, *ListTransactionsByBlockRequest) (*ListTransactionsByBlockResponse, error)
 	ListTransactionsByAddress(context.Context, *ListTransactionsByAddressRequest) (*ListTransactionsByAddressResponse, error)
+	// Log operations
+	ListLogs(context.Context, *ListLogsRequest) (*ListLogsResponse, error)
+	// Token transfer operations
+	ListTokenTransfers(context.Context, *ListTokenTransfersRequest) (*ListTokenTransfersResponse, error)
 	// Progress operations
 	GetProgress(context.Context, *GetProgressRequest) (*GetProgressResponse, error)
 	// Gap operations
@@ -316,6 +346,12 @@ func (UnimplementedIndexerServiceServer) ListTransactionsByBlock(context.Context
 func (UnimplementedIndexerServiceServer) ListTransactionsByAddress(context.Context, *ListTransactionsByAddressRequest) (*ListTransactionsByAddressResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListTransactionsByAddress not implemented")
 }
+func (UnimplementedIndexerServiceServer) ListLogs(context.Context, *ListLogsRequest) (*ListLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLogs not implemented")
+}
+func (UnimplementedIndexerServiceServer) ListTokenTransfers(context.Context, *ListTokenTransfersRequest) (*ListTokenTransfersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTokenTransfers not implemented")
+}
 func (UnimplementedIndexerServiceServer) GetProgress(context.Context, *GetProgressRequest) (*GetProgressResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetProgress not implemented")
 }
@@ -517,6 +553,42 @@ func _IndexerService_ListTransactionsByAddress_Handler(srv interface{}, ctx cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _IndexerService_ListLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexerServiceServer).ListLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndexerService_ListLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexerServiceServer).ListLogs(ctx, req.(*ListLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexerService_ListTokenTransfers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTokenTransfersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexerServiceServer).ListTokenTransfers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndexerService_ListTokenTransfers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexerServiceServer).ListTokenTransfers(ctx, req.(*ListTokenTransfersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _IndexerService_GetProgress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetProgressRequest)
 	if err := dec(in); err != nil {
@@ -647,6 +719,14 @@ var IndexerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListTransactionsByAddress",
 			Handler:    _IndexerService_ListTransactionsByAddress_Handler,
 		},
+		{
+			MethodName: "ListLogs",
+			Handler:    _IndexerService_ListLogs_Handler,
+		},
+		{
+			MethodName: "ListTokenTransfers",
+			Handler:    _IndexerService_ListTokenTransfers_Handler,
+		},
 		{
 			MethodName: "GetProgress",
 			Handler:    _IndexerService_GetProgress_Handler,
@@ -679,3 +759,423 @@ var IndexerService_ServiceDesc = grpc.ServiceDesc{
 	},
 	Metadata: "api/proto/indexer/v1/indexer.proto",
 }
+
+const (
+	IndexerAdminService_PauseChain_FullMethodName     = "/indexer.v1.IndexerAdminService/PauseChain"
+	IndexerAdminService_ResumeChain_FullMethodName    = "/indexer.v1.IndexerAdminService/ResumeChain"
+	IndexerAdminService_AddChain_FullMethodName       = "/indexer.v1.IndexerAdminService/AddChain"
+	IndexerAdminService_RemoveChain_FullMethodName    = "/indexer.v1.IndexerAdminService/RemoveChain"
+	IndexerAdminService_ReindexRange_FullMethodName   = "/indexer.v1.IndexerAdminService/ReindexRange"
+	IndexerAdminService_RecoverGaps_FullMethodName    = "/indexer.v1.IndexerAdminService/RecoverGaps"
+	IndexerAdminService_GetOperation_FullMethodName   = "/indexer.v1.IndexerAdminService/GetOperation"
+	IndexerAdminService_ListOperations_FullMethodName = "/indexer.v1.IndexerAdminService/ListOperations"
+	IndexerAdminService_WatchOperation_FullMethodName = "/indexer.v1.IndexerAdminService/WatchOperation"
+)
+
+// IndexerAdminServiceClient is the client API for IndexerAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// IndexerAdminService controls the indexer. Calls must carry the admin
+// token as "authorization: Bearer <token>" metadata.
+type IndexerAdminServiceClient interface {
+	// Chain operations
+	PauseChain(ctx context.Context, in *PauseChainRequest, opts ...grpc.CallOption) (*PauseChainResponse, error)
+	ResumeChain(ctx context.Context, in *ResumeChainRequest, opts ...grpc.CallOption) (*ResumeChainResponse, error)
+	AddChain(ctx context.Context, in *AddChainRequest, opts ...grpc.CallOption) (*AddChainResponse, error)
+	RemoveChain(ctx context.Context, in *RemoveChainRequest, opts ...grpc.CallOption) (*RemoveChainResponse, error)
+	// Long-running operations
+	ReindexRange(ctx context.Context, in *ReindexRangeRequest, opts ...grpc.CallOption) (*ReindexRangeResponse, error)
+	RecoverGaps(ctx context.Context, in *RecoverGapsRequest, opts ...grpc.CallOption) (*RecoverGapsResponse, error)
+	GetOperation(ctx context.Context, in *GetOperationRequest, opts ...grpc.CallOption) (*GetOperationResponse, error)
+	ListOperations(ctx context.Context, in *ListOperationsRequest, opts ...grpc.CallOption) (*ListOperationsResponse, error)
+	WatchOperation(ctx context.Context, in *WatchOperationRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Operation], error)
+}
+
+type indexerAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewIndexerAdminServiceClient(cc grpc.ClientConnInterface) IndexerAdminServiceClient {
+	return &indexerAdminServiceClient{cc}
+}
+
+func (c *indexerAdminServiceClient) PauseChain(ctx context.Context, in *PauseChainRequest, opts ...grpc.CallOption) (*PauseChainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PauseChainResponse)
+	err := c.cc.Invoke(ctx, IndexerAdminService_PauseChain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexerAdminServiceClient) ResumeChain(ctx context.Context, in *ResumeChainRequest, opts ...grpc.CallOption) (*ResumeChainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResumeChainResponse)
+	err := c.cc.Invoke(ctx, IndexerAdminService_ResumeChain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexerAdminServiceClient) AddChain(ctx context.Context, in *AddChainRequest, opts ...grpc.CallOption) (*AddChainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddChainResponse)
+	err := c.cc.Invoke(ctx, IndexerAdminService_AddChain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexerAdminServiceClient) RemoveChain(ctx context.Context, in *RemoveChainRequest, opts ...grpc.CallOption) (*RemoveChainResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveChainResponse)
+	err := c.cc.Invoke(ctx, IndexerAdminService_RemoveChain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexerAdminServiceClient) ReindexRange(ctx context.Context, in *ReindexRangeRequest, opts ...grpc.CallOption) (*ReindexRangeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReindexRangeResponse)
+	err := c.cc.Invoke(ctx, IndexerAdminService_ReindexRange_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexerAdminServiceClient) RecoverGaps(ctx context.Context, in *RecoverGapsRequest, opts ...grpc.CallOption) (*RecoverGapsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecoverGapsResponse)
+	err := c.cc.Invoke(ctx, IndexerAdminService_RecoverGaps_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexerAdminServiceClient) GetOperation(ctx context.Context, in *GetOperationRequest, opts ...grpc.CallOption) (*GetOperationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetOperationResponse)
+	err := c.cc.Invoke(ctx, IndexerAdminService_GetOperation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexerAdminServiceClient) ListOperations(ctx context.Context, in *ListOperationsRequest, opts ...grpc.CallOption) (*ListOperationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOperationsResponse)
+	err := c.cc.Invoke(ctx, IndexerAdminService_ListOperations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *indexerAdminServiceClient) WatchOperation(ctx context.Context, in *WatchOperationRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Operation], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &IndexerAdminService_ServiceDesc.Streams[0], IndexerAdminService_WatchOperation_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchOperationRequest, Operation]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type IndexerAdminService_WatchOperationClient = grpc.ServerStreamingClient[Operation]
+
+// IndexerAdminServiceServer is the server API for IndexerAdminService service.
+// All implementations must embed UnimplementedIndexerAdminServiceServer
+// for forward compatibility.
+//
+// IndexerAdminService controls the indexer. Calls must carry the admin
+// token as "authorization: Bearer <token>" metadata.
+type IndexerAdminServiceServer interface {
+	// Chain operations
+	PauseChain(context.Context, *PauseChainRequest) (*PauseChainResponse, error)
+	ResumeChain(context.Context, *ResumeChainRequest) (*ResumeChainResponse, error)
+	AddChain(context.Context, *AddChainRequest) (*AddChainResponse, error)
+	RemoveChain(context.Context, *RemoveChainRequest) (*RemoveChainResponse, error)
+	// Long-running operations
+	ReindexRange(context.Context, *ReindexRangeRequest) (*ReindexRangeResponse, error)
+	RecoverGaps(context.Context, *RecoverGapsRequest) (*RecoverGapsResponse, error)
+	GetOperation(context.Context, *GetOperationRequest) (*GetOperationResponse, error)
+	ListOperations(context.Context, *ListOperationsRequest) (*ListOperationsResponse, error)
+	WatchOperation(*WatchOperationRequest, grpc.ServerStreamingServer[Operation]) error
+	mustEmbedUnimplementedIndexerAdminServiceServer()
+}
+
+// UnimplementedIndexerAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedIndexerAdminServiceServer struct{}
+
+func (UnimplementedIndexerAdminServiceServer) PauseChain(context.Context, *PauseChainRequest) (*PauseChainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseChain not implemented")
+}
+func (UnimplementedIndexerAdminServiceServer) ResumeChain(context.Context, *ResumeChainRequest) (*ResumeChainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeChain not implemented")
+}
+func (UnimplementedIndexerAdminServiceServer) AddChain(context.Context, *AddChainRequest) (*AddChainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddChain not implemented")
+}
+func (UnimplementedIndexerAdminServiceServer) RemoveChain(context.Context, *RemoveChainRequest) (*RemoveChainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveChain not implemented")
+}
+func (UnimplementedIndexerAdminServiceServer) ReindexRange(context.Context, *ReindexRangeRequest) (*ReindexRangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReindexRange not implemented")
+}
+func (UnimplementedIndexerAdminServiceServer) RecoverGaps(context.Context, *RecoverGapsRequest) (*RecoverGapsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecoverGaps not implemented")
+}
+func (UnimplementedIndexerAdminServiceServer) GetOperation(context.Context, *GetOperationRequest) (*GetOperationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOperation not implemented")
+}
+func (UnimplementedIndexerAdminServiceServer) ListOperations(context.Context, *ListOperationsRequest) (*ListOperationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOperations not implemented")
+}
+func (UnimplementedIndexerAdminServiceServer) WatchOperation(*WatchOperationRequest, grpc.ServerStreamingServer[Operation]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchOperation not implemented")
+}
+func (UnimplementedIndexerAdminServiceServer) mustEmbedUnimplementedIndexerAdminServiceServer() {}
+func (UnimplementedIndexerAdminServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafeIndexerAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to IndexerAdminServiceServer will
+// result in compilation errors.
+type UnsafeIndexerAdminServiceServer interface {
+	mustEmbedUnimplementedIndexerAdminServiceServer()
+}
+
+func RegisterIndexerAdminServiceServer(s grpc.ServiceRegistrar, srv IndexerAdminServiceServer) {
+	// If the following call pancis, it indicates UnimplementedIndexerAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&IndexerAdminService_ServiceDesc, srv)
+}
+
+func _IndexerAdminService_PauseChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseChainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexerAdminServiceServer).PauseChain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndexerAdminService_PauseChain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexerAdminServiceServer).PauseChain(ctx, req.(*PauseChainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexerAdminService_ResumeChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeChainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexerAdminServiceServer).ResumeChain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndexerAdminService_ResumeChain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexerAdminServiceServer).ResumeChain(ctx, req.(*ResumeChainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexerAdminService_AddChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddChainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexerAdminServiceServer).AddChain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndexerAdminService_AddChain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexerAdminServiceServer).AddChain(ctx, req.(*AddChainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexerAdminService_RemoveChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveChainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexerAdminServiceServer).RemoveChain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndexerAdminService_RemoveChain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexerAdminServiceServer).RemoveChain(ctx, req.(*RemoveChainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexerAdminService_ReindexRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReindexRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexerAdminServiceServer).ReindexRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndexerAdminService_ReindexRange_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexerAdminServiceServer).ReindexRange(ctx, req.(*ReindexRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexerAdminService_RecoverGaps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecoverGapsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexerAdminServiceServer).RecoverGaps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndexerAdminService_RecoverGaps_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexerAdminServiceServer).RecoverGaps(ctx, req.(*RecoverGapsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexerAdminService_GetOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexerAdminServiceServer).GetOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndexerAdminService_GetOperation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexerAdminServiceServer).GetOperation(ctx, req.(*GetOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexerAdminService_ListOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOperationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IndexerAdminServiceServer).ListOperations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: IndexerAdminService_ListOperations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IndexerAdminServiceServer).ListOperations(ctx, req.(*ListOperationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IndexerAdminService_WatchOperation_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchOperationRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(IndexerAdminServiceServer).WatchOperation(m, &grpc.GenericServerStream[WatchOperationRequest, Operation]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type IndexerAdminService_WatchOperationServer = grpc.ServerStreamingServer[Operation]
+
+// IndexerAdminService_ServiceDesc is the grpc.ServiceDesc for IndexerAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var IndexerAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "indexer.v1.IndexerAdminService",
+	HandlerType: (*IndexerAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "PauseChain",
+			Handler:    _IndexerAdminService_PauseChain_Handler,
+		},
+		{
+			MethodName: "ResumeChain",
+			Handler:    _IndexerAdminService_ResumeChain_Handler,
+		},
+		{
+			MethodName: "AddChain",
+			Handler:    _IndexerAdminService_AddChain_Handler,
+		},
+		{
+			MethodName: "RemoveChain",
+			Handler:    _IndexerAdminService_RemoveChain_Handler,
+		},
+		{
+			MethodName: "ReindexRange",
+			Handler:    _IndexerAdminService_ReindexRange_Handler,
+		},
+		{
+			MethodName: "RecoverGaps",
+			Handler:    _IndexerAdminService_RecoverGaps_Handler,
+		},
+		{
+			MethodName: "GetOperation",
+			Handler:    _IndexerAdminService_GetOperation_Handler,
+		},
+		{
+			MethodName: "ListOperations",
+			Handler:    _IndexerAdminService_ListOperations_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchOperation",
+			Handler:       _IndexerAdminService_WatchOperation_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/proto/indexer/v1/indexer.proto",
+}
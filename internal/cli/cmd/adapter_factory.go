@@ -2,25 +2,42 @@ package cmd
 
 import (
 	"fmt"
+	"net/http"
 	"time"
 
+	"github.com/sage-x-project/blockchain-indexer/pkg/application/processor"
 	"github.com/sage-x-project/blockchain-indexer/pkg/domain/service"
 	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/avalanche"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/bitcoin"
 	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/cosmos"
 	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/evm"
 	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/polkadot"
 	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/ripple"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/sim"
 	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/solana"
 	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/config"
 	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/logger"
+	"gopkg.in/yaml.v3"
 )
 
 // CreateChainAdapter creates a chain adapter based on the chain configuration
 func CreateChainAdapter(chainCfg *config.ChainConfig, log *logger.Logger) (service.ChainAdapter, error) {
+	return createChainAdapter(chainCfg, log, nil)
+}
+
+// createChainAdapter creates a chain adapter whose HTTP RPC requests go
+// through transport if it is set. Only the EVM, Solana, Cosmos and Polkadot
+// adapters support a custom transport.
+func createChainAdapter(chainCfg *config.ChainConfig, log *logger.Logger, transport http.RoundTripper) (service.ChainAdapter, error) {
 	if chainCfg == nil {
 		return nil, fmt.Errorf("chain config is nil")
 	}
 
+	// The simulated chain runs in-process
+	if chainCfg.ChainType == "sim" {
+		return createSimAdapter(chainCfg, log)
+	}
+
 	if len(chainCfg.RPCEndpoints) == 0 {
 		return nil, fmt.Errorf("no RPC endpoints configured for chain %s", chainCfg.ChainID)
 	}
@@ -34,91 +51,168 @@ func CreateChainAdapter(chainCfg *config.ChainConfig, log *logger.Logger) (servi
 	// Create adapter based on chain type
 	switch chainCfg.ChainType {
 	case "evm", "ethereum", "bsc", "polygon":
-		return createEVMAdapter(chainCfg, retryDelay, log)
+		return createEVMAdapter(chainCfg, retryDelay, log, transport)
 	case "solana":
-		return createSolanaAdapter(chainCfg, retryDelay, log)
+		return createSolanaAdapter(chainCfg, retryDelay, log, transport)
 	case "cosmos":
-		return createCosmosAdapter(chainCfg, retryDelay, log)
+		return createCosmosAdapter(chainCfg, retryDelay, log, transport)
 	case "polkadot", "substrate":
-		return createPolkadotAdapter(chainCfg, retryDelay, log)
+		return createPolkadotAdapter(chainCfg, retryDelay, log, transport)
 	case "avalanche":
 		return createAvalancheAdapter(chainCfg, retryDelay, log)
 	case "ripple", "xrpl":
 		return createRippleAdapter(chainCfg, retryDelay, log)
+	case "bitcoin", "btc":
+		return createBitcoinAdapter(chainCfg, retryDelay, log)
 	default:
 		return nil, fmt.Errorf("unsupported chain type: %s", chainCfg.ChainType)
 	}
 }
 
-func createEVMAdapter(chainCfg *config.ChainConfig, retryDelay time.Duration, log *logger.Logger) (service.ChainAdapter, error) {
+// CreateTokenTransferDecoder returns the decoder for the token transfer
+// events of the chain, or nil if the chain has none
+func CreateTokenTransferDecoder(chainCfg *config.ChainConfig) processor.TokenTransferDecoder {
+	switch chainCfg.ChainType {
+	case "evm", "ethereum", "bsc", "polygon":
+		return evm.NewNormalizer(chainCfg.ChainID, chainCfg.Network)
+	case "avalanche":
+		// Only the C-Chain runs the EVM
+		if chainCfg.AvalancheChainType == "" || avalanche.ChainType(chainCfg.AvalancheChainType) == avalanche.CChain {
+			return evm.NewNormalizer(chainCfg.ChainID, chainCfg.Network)
+		}
+	}
+	return nil
+}
+
+func createEVMAdapter(chainCfg *config.ChainConfig, retryDelay time.Duration, log *logger.Logger, transport http.RoundTripper) (service.ChainAdapter, error) {
 	adapterCfg := evm.DefaultConfig()
 	adapterCfg.ChainID = chainCfg.ChainID
 	adapterCfg.ChainName = chainCfg.Name
 	adapterCfg.Network = chainCfg.Network
 	adapterCfg.RPCEndpoints = chainCfg.RPCEndpoints
 	adapterCfg.WSEndpoints = chainCfg.WSEndpoints
+	if chainCfg.MaxEndpointLag > 0 {
+		adapterCfg.MaxEndpointLag = chainCfg.MaxEndpointLag
+	}
 	adapterCfg.MaxRetries = chainCfg.RetryAttempts
 	adapterCfg.RetryDelay = retryDelay
 	adapterCfg.BlockConfirmations = chainCfg.ConfirmationBlocks
 	adapterCfg.BatchSize = chainCfg.BatchSize
 	adapterCfg.ConcurrentFetches = chainCfg.Workers
+	adapterCfg.Transport = transport
+
+	// Internal transactions are only indexed when a trace API is configured
+	if value, ok := chainCfg.Config["trace_api"]; ok {
+		traceAPI, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid trace_api for chain %s: %v", chainCfg.ChainID, value)
+		}
+		adapterCfg.EnableTraceAPI = true
+		adapterCfg.TraceAPI = traceAPI
+	}
+	if value, ok := chainCfg.Config["trace_batch_size"]; ok {
+		batchSize, ok := value.(int)
+		if !ok || batchSize <= 0 {
+			return nil, fmt.Errorf("invalid trace_batch_size for chain %s: %v", chainCfg.ChainID, value)
+		}
+		adapterCfg.TraceBatchSize = batchSize
+	}
 
 	return evm.NewAdapter(adapterCfg)
 }
 
-func createSolanaAdapter(chainCfg *config.ChainConfig, retryDelay time.Duration, log *logger.Logger) (service.ChainAdapter, error) {
+func createSolanaAdapter(chainCfg *config.ChainConfig, retryDelay time.Duration, log *logger.Logger, transport http.RoundTripper) (service.ChainAdapter, error) {
 	rpcEndpoint := chainCfg.RPCEndpoints[0]
 	adapterCfg := solana.DefaultConfig(chainCfg.ChainID, chainCfg.Network, rpcEndpoint)
+	adapterCfg.FallbackRPCEndpoints = chainCfg.RPCEndpoints[1:]
+	if chainCfg.MaxEndpointLag > 0 {
+		adapterCfg.MaxEndpointLag = chainCfg.MaxEndpointLag
+	}
 
 	if len(chainCfg.WSEndpoints) > 0 {
 		adapterCfg.WSEndpoint = chainCfg.WSEndpoints[0]
+		adapterCfg.EnableWebSocket = true
 	}
 	adapterCfg.MaxRetries = chainCfg.RetryAttempts
 	adapterCfg.RetryDelay = retryDelay
+	adapterCfg.Transport = transport
+	adapterCfg.Logger = log
 
 	return solana.NewAdapter(adapterCfg)
 }
 
-func createCosmosAdapter(chainCfg *config.ChainConfig, retryDelay time.Duration, log *logger.Logger) (service.ChainAdapter, error) {
+func createCosmosAdapter(chainCfg *config.ChainConfig, retryDelay time.Duration, log *logger.Logger, transport http.RoundTripper) (service.ChainAdapter, error) {
 	adapterCfg := cosmos.DefaultConfig()
 	adapterCfg.ChainID = chainCfg.ChainID
 	adapterCfg.ChainName = chainCfg.Name
 	adapterCfg.Network = chainCfg.Network
 	adapterCfg.RPCURL = chainCfg.RPCEndpoints[0]
+	adapterCfg.FallbackRPCURLs = chainCfg.RPCEndpoints[1:]
+	if chainCfg.MaxEndpointLag > 0 {
+		adapterCfg.MaxEndpointLag = chainCfg.MaxEndpointLag
+	}
 	adapterCfg.RetryDelay = retryDelay
+	adapterCfg.Transport = transport
 
 	return cosmos.NewAdapter(adapterCfg)
 }
 
-func createPolkadotAdapter(chainCfg *config.ChainConfig, retryDelay time.Duration, log *logger.Logger) (service.ChainAdapter, error) {
+func createPolkadotAdapter(chainCfg *config.ChainConfig, retryDelay time.Duration, log *logger.Logger, transport http.RoundTripper) (service.ChainAdapter, error) {
 	adapterCfg := polkadot.DefaultConfig()
 	adapterCfg.ChainID = chainCfg.ChainID
 	adapterCfg.ChainName = chainCfg.Name
 	adapterCfg.Network = chainCfg.Network
 	adapterCfg.RPCURL = chainCfg.RPCEndpoints[0]
+	adapterCfg.FallbackRPCURLs = chainCfg.RPCEndpoints[1:]
+	if chainCfg.MaxEndpointLag > 0 {
+		adapterCfg.MaxEndpointLag = chainCfg.MaxEndpointLag
+	}
 	if len(chainCfg.WSEndpoints) > 0 {
 		adapterCfg.WSURL = chainCfg.WSEndpoints[0]
 	}
 	adapterCfg.RetryAttempts = chainCfg.RetryAttempts
 	adapterCfg.RetryDelay = retryDelay
+	adapterCfg.Transport = transport
+	if value, ok := chainCfg.Config["ss58_format"]; ok {
+		format, ok := value.(int)
+		if !ok || format < 0 || format > 16383 {
+			return nil, fmt.Errorf("invalid ss58_format for chain %s: %v", chainCfg.ChainID, value)
+		}
+		adapterCfg.SS58Format = uint16(format)
+	}
+	if value, ok := chainCfg.Config["include_events"]; ok {
+		includeEvents, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("invalid include_events for chain %s: %v", chainCfg.ChainID, value)
+		}
+		adapterCfg.IncludeEvents = includeEvents
+	}
 
 	return polkadot.NewAdapter(adapterCfg)
 }
 
 func createAvalancheAdapter(chainCfg *config.ChainConfig, retryDelay time.Duration, log *logger.Logger) (service.ChainAdapter, error) {
-	// Avalanche doesn't have DefaultConfig, create manually
-	adapterCfg := &avalanche.Config{
-		ChainID:   chainCfg.ChainID,
-		ChainName: chainCfg.Name,
-		Network:   chainCfg.Network,
-		ChainType: avalanche.CChain, // Default to C-Chain (EVM compatible)
-		RPCURL:    chainCfg.RPCEndpoints[0],
-		Timeout:   30 * time.Second,
+	adapterCfg := avalanche.DefaultCChainConfig()
+	adapterCfg.ChainID = chainCfg.ChainID
+	adapterCfg.ChainName = chainCfg.Name
+	adapterCfg.Network = chainCfg.Network
+	adapterCfg.RPCURL = chainCfg.RPCEndpoints[0]
+	adapterCfg.FallbackRPCURLs = chainCfg.RPCEndpoints[1:]
+	if chainCfg.MaxEndpointLag > 0 {
+		adapterCfg.MaxEndpointLag = chainCfg.MaxEndpointLag
 	}
-
+	adapterCfg.WSURL = ""
 	if len(chainCfg.WSEndpoints) > 0 {
 		adapterCfg.WSURL = chainCfg.WSEndpoints[0]
 	}
+	if chainCfg.AvalancheChainType != "" {
+		adapterCfg.ChainType = avalanche.ChainType(chainCfg.AvalancheChainType)
+	}
+	adapterCfg.RetryAttempts = chainCfg.RetryAttempts
+	adapterCfg.RetryDelay = retryDelay
+	if chainCfg.BatchSize > 0 {
+		adapterCfg.BatchSize = chainCfg.BatchSize
+	}
 
 	return avalanche.NewAdapter(adapterCfg)
 }
@@ -129,6 +223,10 @@ func createRippleAdapter(chainCfg *config.ChainConfig, retryDelay time.Duration,
 	adapterCfg.ChainName = chainCfg.Name
 	adapterCfg.Network = chainCfg.Network
 	adapterCfg.RPCURL = chainCfg.RPCEndpoints[0]
+	adapterCfg.FallbackRPCURLs = chainCfg.RPCEndpoints[1:]
+	if chainCfg.MaxEndpointLag > 0 {
+		adapterCfg.MaxEndpointLag = chainCfg.MaxEndpointLag
+	}
 	if len(chainCfg.WSEndpoints) > 0 {
 		adapterCfg.WebSocketURL = chainCfg.WSEndpoints[0]
 	}
@@ -136,3 +234,76 @@ func createRippleAdapter(chainCfg *config.ChainConfig, retryDelay time.Duration,
 
 	return ripple.NewAdapter(adapterCfg)
 }
+
+func createBitcoinAdapter(chainCfg *config.ChainConfig, retryDelay time.Duration, log *logger.Logger) (service.ChainAdapter, error) {
+	adapterCfg := bitcoin.DefaultConfig()
+	adapterCfg.ChainID = chainCfg.ChainID
+	adapterCfg.ChainName = chainCfg.Name
+	adapterCfg.Network = chainCfg.Network
+	adapterCfg.RPCURL = chainCfg.RPCEndpoints[0]
+	adapterCfg.FallbackRPCURLs = chainCfg.RPCEndpoints[1:]
+	if chainCfg.MaxEndpointLag > 0 {
+		adapterCfg.MaxEndpointLag = chainCfg.MaxEndpointLag
+	}
+	adapterCfg.RetryAttempts = chainCfg.RetryAttempts
+	adapterCfg.RetryDelay = retryDelay
+	if chainCfg.BatchSize > 0 {
+		adapterCfg.BatchSize = chainCfg.BatchSize
+	}
+	if value, ok := chainCfg.Config["rpc_user"]; ok {
+		user, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid rpc_user for chain %s: %v", chainCfg.ChainID, value)
+		}
+		adapterCfg.RPCUser = user
+	}
+	if value, ok := chainCfg.Config["rpc_password"]; ok {
+		password, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid rpc_password for chain %s", chainCfg.ChainID)
+		}
+		adapterCfg.RPCPassword = password
+	}
+	if value, ok := chainCfg.Config["resolve_prevouts"]; ok {
+		resolvePrevouts, ok := value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("invalid resolve_prevouts for chain %s: %v", chainCfg.ChainID, value)
+		}
+		adapterCfg.ResolvePrevouts = resolvePrevouts
+	}
+	if value, ok := chainCfg.Config["finality_depth"]; ok {
+		depth, ok := value.(int)
+		if !ok || depth < 0 {
+			return nil, fmt.Errorf("invalid finality_depth for chain %s: %v", chainCfg.ChainID, value)
+		}
+		adapterCfg.FinalityDepth = uint64(depth)
+	}
+
+	return bitcoin.NewAdapter(adapterCfg)
+}
+
+func createSimAdapter(chainCfg *config.ChainConfig, log *logger.Logger) (service.ChainAdapter, error) {
+	adapterCfg := sim.DefaultConfig()
+
+	// The config map holds sim.Config fields by their YAML names
+	if len(chainCfg.Config) > 0 {
+		data, err := yaml.Marshal(chainCfg.Config)
+		if err != nil {
+			return nil, fmt.Errorf("invalid config for chain %s: %w", chainCfg.ChainID, err)
+		}
+		if err := yaml.Unmarshal(data, adapterCfg); err != nil {
+			return nil, fmt.Errorf("invalid config for chain %s: %w", chainCfg.ChainID, err)
+		}
+	}
+
+	adapterCfg.ChainID = chainCfg.ChainID
+	adapterCfg.ChainName = chainCfg.Name
+	if chainCfg.Network != "" {
+		adapterCfg.Network = chainCfg.Network
+	}
+	if chainCfg.BatchSize > 0 {
+		adapterCfg.BatchSize = chainCfg.BatchSize
+	}
+
+	return sim.NewAdapter(adapterCfg)
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/transport"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/config"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/logger"
+)
+
+var (
+	fixturesConfigFile string
+	fixturesChainID    string
+	fixturesBlocks     string
+	fixturesOutput     string
+)
+
+// NewFixturesCmd creates a fixtures command
+func NewFixturesCmd() *cobra.Command {
+	fixturesCmd := &cobra.Command{
+		Use:   "fixtures",
+		Short: "Adapter test fixture commands",
+		Long:  "Commands for capturing RPC traffic as adapter regression tests",
+	}
+
+	recordCmd := &cobra.Command{
+		Use:   "record",
+		Short: "Record the RPC traffic of a block range to a fixture file",
+		Long: `Fetch a block range from a configured chain, record every RPC request
+and response to a fixture file and write the normalized blocks next to it as
+a golden file.
+
+Fixtures placed in an adapter's testdata/fixtures directory are replayed
+without a network by its tests, which compare the normalized blocks with the
+golden file. Recording is supported for EVM, Solana, Cosmos and Polkadot
+chains over HTTP.`,
+		Example: `  blockchain-indexer fixtures record --chain ethereum --blocks 17034870-17034872 \
+    --output pkg/infrastructure/adapter/evm/testdata/fixtures/ethereum-17034870-17034872.json`,
+		RunE: runFixturesRecord,
+	}
+
+	recordCmd.Flags().StringVarP(&fixturesConfigFile, "config", "c", "config.yaml", "Path to configuration file")
+	recordCmd.Flags().StringVar(&fixturesChainID, "chain", "", "Chain ID to record from")
+	recordCmd.Flags().StringVar(&fixturesBlocks, "blocks", "", "Block or block range to record, e.g. 100 or 100-105")
+	recordCmd.Flags().StringVarP(&fixturesOutput, "output", "o", "", "Fixture file (default testdata/fixtures/<chain>-<start>-<end>.json)")
+	recordCmd.MarkFlagRequired("chain")
+	recordCmd.MarkFlagRequired("blocks")
+
+	fixturesCmd.AddCommand(recordCmd)
+
+	return fixturesCmd
+}
+
+func runFixturesRecord(cmd *cobra.Command, args []string) error {
+	start, end, err := parseBlockRange(fixturesBlocks)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load(fixturesConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	var chainCfg *config.ChainConfig
+	for i := range cfg.Chains {
+		if cfg.Chains[i].ChainID == fixturesChainID {
+			chainCfg = &cfg.Chains[i]
+			break
+		}
+	}
+	if chainCfg == nil {
+		return fmt.Errorf("chain %s not found", fixturesChainID)
+	}
+
+	switch chainCfg.ChainType {
+	case "evm", "ethereum", "bsc", "polygon", "solana", "cosmos", "polkadot", "substrate":
+	default:
+		return fmt.Errorf("recording is not supported for chain type %s", chainCfg.ChainType)
+	}
+
+	output := fixturesOutput
+	if output == "" {
+		output = filepath.Join("testdata", "fixtures", fmt.Sprintf("%s-%d-%d.json", chainCfg.ChainID, start, end))
+	}
+
+	log, err := logger.New(&logger.Config{
+		Level:      cfg.Logging.Level,
+		Format:     cfg.Logging.Format,
+		Output:     cfg.Logging.Output,
+		FilePath:   cfg.Logging.FilePath,
+		MaxSize:    cfg.Logging.MaxSize,
+		MaxBackups: cfg.Logging.MaxBackups,
+		MaxAge:     cfg.Logging.MaxAge,
+		Compress:   cfg.Logging.Compress,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to initialize logger: %w", err)
+	}
+	defer log.Sync()
+
+	recorder := transport.NewRecorder(nil)
+	adapter, err := createChainAdapter(chainCfg, log, recorder)
+	if err != nil {
+		return fmt.Errorf("failed to create chain adapter: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := adapter.Connect(ctx); err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+	defer adapter.Disconnect()
+
+	fmt.Printf("Recording blocks %d-%d of %s (%s)\n", start, end, chainCfg.ChainID, chainCfg.ChainType)
+
+	blocks, err := transport.FetchBlocks(ctx, adapter, start, end)
+	if err != nil {
+		return err
+	}
+
+	fixture := &transport.Fixture{
+		ChainType:    chainCfg.ChainType,
+		ChainID:      chainCfg.ChainID,
+		Network:      chainCfg.Network,
+		StartBlock:   start,
+		EndBlock:     end,
+		RecordedAt:   time.Now().UTC(),
+		Interactions: recorder.Interactions(),
+	}
+	if err := fixture.Save(output); err != nil {
+		return err
+	}
+
+	golden := transport.GoldenPath(output)
+	if err := transport.WriteGolden(golden, blocks); err != nil {
+		return err
+	}
+
+	fmt.Printf("  Requests: %d\n", len(fixture.Interactions))
+	fmt.Printf("  Fixture:  %s\n", output)
+	fmt.Printf("  Golden:   %s\n", golden)
+	return nil
+}
+
+// parseBlockRange parses a block number or an inclusive range like 100-105
+func parseBlockRange(value string) (uint64, uint64, error) {
+	startValue, endValue, isRange := strings.Cut(value, "-")
+	if !isRange {
+		endValue = startValue
+	}
+
+	start, err := strconv.ParseUint(strings.TrimSpace(startValue), 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid block range %q: %w", value, err)
+	}
+	end, err := strconv.ParseUint(strings.TrimSpace(endValue), 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid block range %q: %w", value, err)
+	}
+	if start > end {
+		return 0, 0, fmt.Errorf("invalid block range %q: start is after end", value)
+	}
+
+	return start, end, nil
+}
@@ -2,11 +2,11 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
 	"path/filepath"
-	"sync"
 	"syscall"
 	"time"
 
@@ -15,6 +15,10 @@ import (
 
 	"github.com/sage-x-project/blockchain-indexer/pkg/application/indexer"
 	"github.com/sage-x-project/blockchain-indexer/pkg/application/processor"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/repository"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/endpoint"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/polkadot"
 	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/config"
 	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/event"
 	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/logger"
@@ -123,6 +127,9 @@ func runIndexer(cmd *cobra.Command, args []string) error {
 		Path:    cfg.Metrics.Path,
 	}
 	appMetrics := metrics.New(metricsCfg)
+	if err := appMetrics.Register(endpoint.NewCollector()); err != nil {
+		return fmt.Errorf("failed to register endpoint metrics: %w", err)
+	}
 
 	// Start metrics server in background
 	if cfg.Metrics.Enabled {
@@ -144,8 +151,10 @@ func runIndexer(cmd *cobra.Command, args []string) error {
 
 	log.Info("initializing storage", zap.String("path", storagePath))
 	storage, err := pebble.NewStorage(&pebble.Config{
-		Path:      storagePath,
-		CacheSize: 128 << 20, // 128 MB cache
+		Path:        storagePath,
+		CacheSize:   128 << 20, // 128 MB cache
+		Codec:       cfg.Storage.Pebble.Codec,
+		Compression: cfg.Storage.Pebble.Compression,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to initialize storage: %w", err)
@@ -167,105 +176,28 @@ func runIndexer(cmd *cobra.Command, args []string) error {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Create indexers for each chain
-	indexers := make([]*indexer.BlockIndexer, 0, len(chainsToIndex))
-	var indexersMu sync.Mutex
-
-	for _, chainCfg := range chainsToIndex {
-		log.Info("initializing chain indexer",
-			zap.String("chain_id", chainCfg.ChainID),
-			zap.String("chain_type", chainCfg.ChainType),
-		)
-
-		// Create chain adapter
-		adapter, err := CreateChainAdapter(chainCfg, log)
-		if err != nil {
-			log.Error("failed to create chain adapter",
-				zap.String("chain_id", chainCfg.ChainID),
-				zap.Error(err),
-			)
-			continue
-		}
-
-		// Create block processor
-		blockProcessor := processor.NewBlockProcessor(
-			storage,
-			storage,
-			storage,
-			eventBus,
-			log,
-			appMetrics,
-		)
-
-		// Create gap recovery
-		gapRecovery := indexer.NewGapRecovery(
-			adapter,
-			storage,
-			blockProcessor,
-			eventBus,
-			log,
-		)
-
-		// Create progress tracker
-		progressTracker := indexer.NewProgressTracker(
-			adapter,
-			storage,
-			storage,
-			blockProcessor,
-			log,
-			appMetrics,
-		)
-
-		// Create block indexer configuration
-		indexerConfig := &indexer.BlockIndexerConfig{
-			ChainID:            chainCfg.ChainID,
-			StartBlock:         chainCfg.StartBlock,
-			EndBlock:           0, // Continuous indexing
-			BatchSize:          chainCfg.BatchSize,
-			WorkerCount:        chainCfg.Workers,
-			ConfirmationBlocks: chainCfg.ConfirmationBlocks,
-			PollInterval:       5 * time.Second,
-			EnableGapRecovery:  true,
-		}
-
-		// Create block indexer
-		blockIndexer := indexer.NewBlockIndexer(
-			adapter,
-			blockProcessor,
-			gapRecovery,
-			progressTracker,
-			indexerConfig,
-			log,
-		)
-
-		// Start indexer
-		if err := blockIndexer.Start(ctx); err != nil {
-			log.Error("failed to start indexer",
-				zap.String("chain_id", chainCfg.ChainID),
-				zap.Error(err),
-			)
-			continue
-		}
-
-		indexersMu.Lock()
-		indexers = append(indexers, blockIndexer)
-		indexersMu.Unlock()
+	orchestratorConfig := indexer.DefaultOrchestratorConfig()
+	if chainID != "" {
+		orchestratorConfig.Chains = []string{chainID}
+	}
+	orchestrator := newOrchestrator(ctx, cfg, chainsToIndex, orchestratorConfig, storage, eventBus, appMetrics, log)
 
-		log.Info("chain indexer started",
-			zap.String("chain_id", chainCfg.ChainID),
-			zap.Uint64("start_block", chainCfg.StartBlock),
-			zap.Int("workers", chainCfg.Workers),
-		)
+	if err := orchestrator.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start indexers: %w", err)
 	}
 
-	if len(indexers) == 0 {
+	statuses, err := orchestrator.GetAllStatuses()
+	if err != nil {
+		return fmt.Errorf("failed to get indexer statuses: %w", err)
+	}
+	if len(statuses) == 0 {
 		return fmt.Errorf("failed to start any indexers")
 	}
 
 	log.Info("all indexers started successfully",
-		zap.Int("active_indexers", len(indexers)),
+		zap.Int("active_indexers", len(statuses)),
 	)
-	fmt.Printf("\n✅ Indexing started for %d chain(s)\n", len(indexers))
+	fmt.Printf("\n✅ Indexing started for %d chain(s)\n", len(statuses))
 	fmt.Println("📊 Metrics available at: http://localhost:9091/metrics")
 	fmt.Println("Press Ctrl+C to stop gracefully...")
 	fmt.Println()
@@ -281,39 +213,220 @@ func runIndexer(cmd *cobra.Command, args []string) error {
 	)
 	fmt.Printf("\n🛑 Shutdown signal received (%s), stopping gracefully...\n", sig.String())
 
-	// Cancel context to stop all indexers
-	cancel()
-
-	// Stop all indexers
-	var wg sync.WaitGroup
-	for _, idx := range indexers {
-		wg.Add(1)
-		go func(i *indexer.BlockIndexer) {
-			defer wg.Done()
-			if err := i.Stop(); err != nil {
-				log.Error("error stopping indexer", zap.Error(err))
-			}
-		}(idx)
-	}
-
-	// Wait for all indexers to stop (with timeout)
-	done := make(chan struct{})
-	go func() {
-		wg.Wait()
-		close(done)
-	}()
+	// Stop all indexers (with timeout)
+	stopCtx, stopCancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer stopCancel()
 
-	select {
-	case <-done:
+	if err := orchestrator.Stop(stopCtx); err != nil {
+		log.Warn("timeout waiting for indexers to stop", zap.Error(err))
+		fmt.Println("⚠️  Timeout waiting for indexers to stop")
+	} else {
 		log.Info("all indexers stopped successfully")
 		fmt.Println("✅ All indexers stopped successfully")
-	case <-time.After(30 * time.Second):
-		log.Warn("timeout waiting for indexers to stop")
-		fmt.Println("⚠️  Timeout waiting for indexers to stop")
 	}
+	cancel()
 
 	log.Info("indexer shutdown complete")
 	fmt.Println("👋 Goodbye!")
 
 	return nil
 }
+
+// newOrchestrator creates the orchestrator of the configured chains in
+// chainsToIndex. Stored chains the configuration disables are disabled, so
+// that Start does not load them.
+func newOrchestrator(
+	ctx context.Context,
+	cfg *config.Config,
+	chainsToIndex []*config.ChainConfig,
+	orchestratorConfig *indexer.OrchestratorConfig,
+	storage *pebble.PebbleStorage,
+	eventBus event.EventBus,
+	appMetrics *metrics.Metrics,
+	log *logger.Logger,
+) *indexer.Orchestrator {
+	// Build the indexer of a chain every time the orchestrator starts it
+	chainConfigs := make(map[string]*config.ChainConfig, len(chainsToIndex))
+	for _, chainCfg := range chainsToIndex {
+		chainConfigs[chainCfg.ChainID] = chainCfg
+	}
+	factory := func(chain *models.Chain) (*indexer.BlockIndexer, error) {
+		chainCfg, ok := chainConfigs[chain.ChainID]
+		if !ok {
+			// Chains added at runtime have no configuration entry
+			chainCfg = chainConfigFor(chain)
+		}
+		return newChainIndexer(chainCfg, storage, eventBus, appMetrics, log)
+	}
+
+	orchestrator := indexer.NewOrchestrator(storage, factory, orchestratorConfig, log)
+
+	for _, chainCfg := range cfg.Chains {
+		if !chainCfg.Enabled {
+			if err := disableChain(ctx, storage, chainCfg.ChainID); err != nil {
+				log.Warn("failed to disable chain",
+					zap.String("chain_id", chainCfg.ChainID),
+					zap.Error(err),
+				)
+			}
+		}
+	}
+
+	for _, chainCfg := range chainsToIndex {
+		log.Info("initializing chain indexer",
+			zap.String("chain_id", chainCfg.ChainID),
+			zap.String("chain_type", chainCfg.ChainType),
+		)
+
+		if err := orchestrator.AddChain(ctx, chainFromConfig(chainCfg)); err != nil {
+			log.Error("failed to add chain",
+				zap.String("chain_id", chainCfg.ChainID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return orchestrator
+}
+
+// newChainIndexer creates the indexer of a configured chain with its own
+// adapter, block processor, gap recovery, reorg handler and progress tracker
+func newChainIndexer(
+	chainCfg *config.ChainConfig,
+	storage *pebble.PebbleStorage,
+	eventBus event.EventBus,
+	appMetrics *metrics.Metrics,
+	log *logger.Logger,
+) (*indexer.BlockIndexer, error) {
+	// Create chain adapter
+	adapter, err := CreateChainAdapter(chainCfg, log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create chain adapter: %w", err)
+	}
+
+	// Substrate nodes cannot look extrinsics up by hash
+	if polkadotAdapter, ok := adapter.(*polkadot.Adapter); ok {
+		polkadotAdapter.SetExtrinsicIndex(storage)
+	}
+
+	// Create block processor
+	blockProcessor := processor.NewBlockProcessor(
+		storage,
+		storage,
+		storage,
+		storage,
+		CreateTokenTransferDecoder(chainCfg),
+		eventBus,
+		log,
+		appMetrics,
+	)
+
+	// Create gap recovery
+	gapRecovery := indexer.NewGapRecovery(
+		adapter,
+		storage,
+		blockProcessor,
+		eventBus,
+		log,
+	)
+
+	// Create reorg handler
+	reorgHandler := indexer.NewReorgHandler(
+		adapter,
+		storage,
+		blockProcessor,
+		eventBus,
+		log,
+		indexer.DefaultMaxReorgDepth,
+	)
+
+	// Create progress tracker
+	progressTracker := indexer.NewProgressTracker(
+		adapter,
+		storage,
+		storage,
+		blockProcessor,
+		log,
+		appMetrics,
+	)
+
+	// Create block indexer configuration
+	indexerConfig := &indexer.BlockIndexerConfig{
+		ChainID:            chainCfg.ChainID,
+		StartBlock:         chainCfg.StartBlock,
+		EndBlock:           0, // Continuous indexing
+		BatchSize:          chainCfg.BatchSize,
+		WorkerCount:        chainCfg.Workers,
+		ConfirmationBlocks: chainCfg.ConfirmationBlocks,
+		PollInterval:       5 * time.Second,
+		EnableGapRecovery:  true,
+		EnableRealtime:     chainCfg.IsRealtimeEnabled(),
+		IndexPending:       chainCfg.IndexPending,
+		MaxRetries:         chainCfg.RetryAttempts, // Then restart with a new connection
+	}
+
+	return indexer.NewBlockIndexer(
+		adapter,
+		blockProcessor,
+		storage,
+		gapRecovery,
+		reorgHandler,
+		progressTracker,
+		indexerConfig,
+		log,
+	), nil
+}
+
+// chainFromConfig returns the chain of a configuration entry
+func chainFromConfig(chainCfg *config.ChainConfig) *models.Chain {
+	chain := models.NewChain(models.ChainType(chainCfg.ChainType), chainCfg.ChainID, chainCfg.Name)
+	chain.Network = chainCfg.Network
+	chain.RPCEndpoints = chainCfg.RPCEndpoints
+	chain.WSEndpoints = chainCfg.WSEndpoints
+	chain.StartBlock = chainCfg.StartBlock
+	chain.BatchSize = chainCfg.BatchSize
+	chain.Workers = chainCfg.Workers
+	chain.ConfirmationBlocks = chainCfg.ConfirmationBlocks
+	chain.Config = chainCfg.Config
+
+	return chain
+}
+
+// chainConfigFor returns the configuration of a chain added at runtime,
+// with the default retry settings
+func chainConfigFor(chain *models.Chain) *config.ChainConfig {
+	return &config.ChainConfig{
+		ChainType:          string(chain.ChainType),
+		ChainID:            chain.ChainID,
+		Name:               chain.Name,
+		Network:            chain.Network,
+		Enabled:            chain.Enabled,
+		RPCEndpoints:       chain.RPCEndpoints,
+		WSEndpoints:        chain.WSEndpoints,
+		StartBlock:         chain.StartBlock,
+		BatchSize:          chain.BatchSize,
+		Workers:            chain.Workers,
+		ConfirmationBlocks: chain.ConfirmationBlocks,
+		RetryAttempts:      3,
+		RetryDelay:         "5s",
+		Config:             chain.Config,
+	}
+}
+
+// disableChain marks a stored chain as disabled, so that it is not indexed
+// until it is enabled in the configuration again
+func disableChain(ctx context.Context, chainRepo repository.ChainRepository, chainID string) error {
+	chain, err := chainRepo.GetChain(ctx, chainID)
+	if err != nil {
+		if errors.Is(err, repository.ErrChainNotFound) {
+			return nil
+		}
+		return err
+	}
+	if !chain.Enabled {
+		return nil
+	}
+
+	chain.Enabled = false
+	return chainRepo.UpdateChain(ctx, chain)
+}
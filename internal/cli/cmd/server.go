@@ -19,6 +19,7 @@ import (
 	"github.com/sage-x-project/blockchain-indexer/pkg/application/indexer"
 	"github.com/sage-x-project/blockchain-indexer/pkg/application/processor"
 	"github.com/sage-x-project/blockchain-indexer/pkg/application/statistics"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/endpoint"
 	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/config"
 	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/event"
 	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/logger"
@@ -43,7 +44,11 @@ The server provides:
   - REST API on HTTP port (default: 8080)
   - GraphQL API on HTTP port (default: 8080)
   - gRPC API on gRPC port (default: 9090)
-  - Prometheus metrics on metrics port (default: 9091)`,
+  - Prometheus metrics on metrics port (default: 9091)
+
+With server.admin enabled the server also indexes the enabled chains and
+serves the admin API, which pauses, resumes, adds and removes chains and
+reindexes blocks, over REST under /api/v1/admin and over gRPC.`,
 		RunE: runServer,
 	}
 
@@ -95,6 +100,9 @@ func runServer(cmd *cobra.Command, args []string) error {
 			Port:    cfg.Metrics.Port,
 			Path:    cfg.Metrics.Path,
 		})
+		if err := appMetrics.Register(endpoint.NewCollector()); err != nil {
+			return fmt.Errorf("failed to register endpoint metrics: %w", err)
+		}
 
 		go func() {
 			addr := fmt.Sprintf("%s:%d", cfg.Metrics.Host, cfg.Metrics.Port)
@@ -112,7 +120,9 @@ func runServer(cmd *cobra.Command, args []string) error {
 	}
 	log.Info("initializing storage", zap.String("type", "pebble"), zap.String("path", storagePath))
 	storage, err := pebble.NewStorage(&pebble.Config{
-		Path: storagePath,
+		Path:        storagePath,
+		Codec:       cfg.Storage.Pebble.Codec,
+		Compression: cfg.Storage.Pebble.Compression,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to initialize storage: %w", err)
@@ -122,6 +132,8 @@ func runServer(cmd *cobra.Command, args []string) error {
 	// Create repositories
 	blockRepo := storage
 	transactionRepo := storage
+	logRepo := storage
+	transferRepo := storage
 	chainRepo := storage
 
 	// Initialize event bus
@@ -162,11 +174,14 @@ func runServer(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		log.Warn("failed to get chains for gap recovery", zap.Error(err))
 	} else {
-		// Create block processor for gap recovery
+		// Create block processor for gap recovery. Without adapters it
+		// never processes blocks, so no token transfer decoder is needed.
 		blockProcessor := processor.NewBlockProcessor(
 			storage,
 			storage,
 			storage,
+			storage,
+			nil,
 			eventBus,
 			log,
 			appMetrics,
@@ -189,6 +204,37 @@ func runServer(cmd *cobra.Command, args []string) error {
 		log.Info("gap recovery initialized", zap.Int("chains", len(gapRecoveryMap)))
 	}
 
+	// Run the indexer in this process for the admin API to control it
+	var (
+		orchestrator *indexer.Orchestrator
+		operations   *indexer.OperationTracker
+		admin        *indexer.Admin
+		adminToken   string
+	)
+	if cfg.Server.Admin.Enabled {
+		log.Info("initializing indexer for the admin API")
+		indexerMetrics := appMetrics
+		if indexerMetrics == nil {
+			indexerMetrics = metrics.New(&metrics.Config{Enabled: false})
+		}
+
+		var chainsToIndex []*config.ChainConfig
+		for i := range cfg.Chains {
+			if cfg.Chains[i].Enabled {
+				chainsToIndex = append(chainsToIndex, &cfg.Chains[i])
+			}
+		}
+
+		orchestrator = newOrchestrator(ctx, cfg, chainsToIndex, indexer.DefaultOrchestratorConfig(), storage, eventBus, indexerMetrics, log)
+		if err := orchestrator.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start indexers: %w", err)
+		}
+
+		operations = indexer.NewOperationTracker(nil, log)
+		admin = indexer.NewAdmin(orchestrator, storage, operations)
+		adminToken = cfg.Server.Admin.Token
+	}
+
 	// Initialize health checker
 	log.Info("initializing health checker")
 	healthChecker := health.NewChecker(log, 30*time.Second)
@@ -267,8 +313,11 @@ func runServer(cmd *cobra.Command, args []string) error {
 	// Initialize REST API
 	if cfg.Server.HTTP.Enabled {
 		log.Info("initializing REST API")
-		restHandler := handler.NewHandler(blockRepo, transactionRepo, chainRepo, nil, gapRecoveryMap, statsCollector, log)
-		restRouter := rest.NewRouter(restHandler, log)
+		restHandler := handler.NewHandler(blockRepo, transactionRepo, logRepo, transferRepo, chainRepo, nil, gapRecoveryMap, statsCollector, log)
+		if admin != nil {
+			restHandler.SetAdmin(admin)
+		}
+		restRouter := rest.NewRouter(restHandler, log, adminToken)
 		httpMux.Handle("/api/", http.StripPrefix("/api", restRouter))
 		log.Info("REST API registered at /api/*")
 	}
@@ -276,7 +325,7 @@ func runServer(cmd *cobra.Command, args []string) error {
 	// Initialize GraphQL API
 	if cfg.Server.HTTP.Enabled {
 		log.Info("initializing GraphQL API")
-		graphqlResolver := resolver.NewResolver(blockRepo, transactionRepo, chainRepo, nil, statsCollector, gapRecoveryMap, eventBus, log)
+		graphqlResolver := resolver.NewResolver(blockRepo, transactionRepo, logRepo, transferRepo, chainRepo, nil, statsCollector, gapRecoveryMap, eventBus, log)
 		graphqlHandler := resolver.NewGraphQLHandler(graphqlResolver, true) // enable playground
 		httpMux.Handle("/graphql", graphqlHandler)
 		log.Info("GraphQL API registered at /graphql")
@@ -313,11 +362,15 @@ func runServer(cmd *cobra.Command, args []string) error {
 			Port:             cfg.Server.GRPC.Port,
 			BlockRepo:        blockRepo,
 			TransactionRepo:  transactionRepo,
+			LogRepo:          logRepo,
+			TransferRepo:     transferRepo,
 			ChainRepo:        chainRepo,
 			GapRecovery:      gapRecoveryMap,
 			StatsCollector:   statsCollector,
 			EventBus:         eventBus,
 			EnableReflection: true,
+			Admin:            admin,
+			AdminToken:       adminToken,
 		})
 		if err != nil {
 			return fmt.Errorf("failed to create gRPC server: %w", err)
@@ -371,19 +424,28 @@ func runServer(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	// 3. Stop statistics collector
+	// 3. Stop admin operations and indexers
+	if orchestrator != nil {
+		log.Info("stopping indexers")
+		operations.Close()
+		if err := orchestrator.Stop(shutdownCtx); err != nil {
+			log.Error("indexer shutdown error", zap.Error(err))
+		}
+	}
+
+	// 4. Stop statistics collector
 	log.Info("stopping statistics collector")
 	if err := statsCollector.Stop(); err != nil {
 		log.Error("statistics collector shutdown error", zap.Error(err))
 	}
 
-	// 4. Stop event bus
+	// 5. Stop event bus
 	log.Info("stopping event bus")
 	if err := eventBus.Stop(); err != nil {
 		log.Error("event bus shutdown error", zap.Error(err))
 	}
 
-	// 5. Close storage
+	// 6. Close storage
 	log.Info("closing storage")
 	if err := storage.Close(); err != nil {
 		log.Error("storage close error", zap.Error(err))
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/config"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/storage/pebble"
+)
+
+var (
+	storageConfigFile  string
+	storagePathFlag    string
+	storageCodec       string
+	storageCompression string
+	storageCompact     bool
+)
+
+// NewStorageCmd creates a storage command
+func NewStorageCmd() *cobra.Command {
+	storageCmd := &cobra.Command{
+		Use:   "storage",
+		Short: "Storage maintenance commands",
+		Long:  "Commands for maintaining the blockchain indexer database",
+	}
+
+	reencodeCmd := &cobra.Command{
+		Use:   "reencode",
+		Short: "Re-encode stored blocks, transactions, logs and token transfers",
+		Long: `Rewrite every stored block, transaction, log and token transfer in the
+configured record format.
+
+Records already in the target format are skipped, so the command can be
+interrupted and run again. Stop the indexer before running it.`,
+		RunE: runReencode,
+	}
+
+	reencodeCmd.Flags().StringVarP(&storageConfigFile, "config", "c", "config.yaml", "Path to configuration file")
+	reencodeCmd.Flags().StringVar(&storagePathFlag, "path", "", "Database path (overrides the configuration)")
+	reencodeCmd.Flags().StringVar(&storageCodec, "codec", "", "Target codec: cbor or json (overrides the configuration)")
+	reencodeCmd.Flags().StringVar(&storageCompression, "compression", "", "Target compression: zstd or none (overrides the configuration)")
+	reencodeCmd.Flags().BoolVar(&storageCompact, "compact", true, "Compact the database afterwards to reclaim space")
+
+	storageCmd.AddCommand(reencodeCmd)
+
+	return storageCmd
+}
+
+func runReencode(cmd *cobra.Command, args []string) error {
+	pebbleCfg := config.Default().Storage.Pebble
+	if storagePathFlag == "" || storageCodec == "" || storageCompression == "" {
+		cfg, err := config.Load(storageConfigFile)
+		if err != nil {
+			return fmt.Errorf("failed to load config: %w", err)
+		}
+		pebbleCfg = cfg.Storage.Pebble
+	}
+
+	if storagePathFlag != "" {
+		pebbleCfg.Path = storagePathFlag
+	}
+	if storageCodec != "" {
+		pebbleCfg.Codec = storageCodec
+	}
+	if storageCompression != "" {
+		pebbleCfg.Compression = storageCompression
+	}
+	if pebbleCfg.Path == "" {
+		return fmt.Errorf("database path is required")
+	}
+
+	storage, err := pebble.NewStorage(&pebble.Config{
+		Path:        filepath.Clean(pebbleCfg.Path),
+		CacheSize:   128 << 20, // 128 MB cache
+		Codec:       pebbleCfg.Codec,
+		Compression: pebbleCfg.Compression,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to open storage: %w", err)
+	}
+	defer storage.Close()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	fmt.Printf("Re-encoding %s as %s\n", storage.Path(), storage.RecordFormat())
+	start := time.Now()
+
+	stats, err := storage.Reencode(ctx)
+	if stats != nil {
+		fmt.Printf("  Blocks:       %d\n", stats.Blocks)
+		fmt.Printf("  Transactions: %d\n", stats.Transactions)
+		fmt.Printf("  Logs:         %d\n", stats.Logs)
+		fmt.Printf("  Transfers:    %d\n", stats.Transfers)
+		fmt.Printf("  Skipped:      %d\n", stats.Skipped)
+		fmt.Printf("  Size:         %d -> %d bytes\n", stats.BytesBefore, stats.BytesAfter)
+	}
+	if err != nil {
+		return fmt.Errorf("re-encode failed: %w", err)
+	}
+
+	if storageCompact {
+		fmt.Println("Compacting database...")
+		if err := storage.CompactAll(); err != nil {
+			return fmt.Errorf("failed to compact database: %w", err)
+		}
+	}
+
+	fmt.Printf("Done in %s\n", time.Since(start).Round(time.Millisecond))
+	return nil
+}
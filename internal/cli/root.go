@@ -41,6 +41,8 @@ Features:
 	rootCmd.AddCommand(cmd.NewIndexCmd())
 	rootCmd.AddCommand(cmd.NewVersionCmd(version, commit, date))
 	rootCmd.AddCommand(cmd.NewConfigCmd())
+	rootCmd.AddCommand(cmd.NewStorageCmd())
+	rootCmd.AddCommand(cmd.NewFixturesCmd())
 
 	return rootCmd.Execute()
 }
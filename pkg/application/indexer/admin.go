@@ -0,0 +1,102 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/repository"
+)
+
+// Admin carries out operator commands on the chains of an orchestrator.
+// Chain changes apply before the call returns, while reindexing and gap
+// recovery run as operations that can be polled or watched.
+type Admin struct {
+	orchestrator *Orchestrator
+	chainRepo    repository.ChainRepository
+	operations   *OperationTracker
+}
+
+// NewAdmin creates a new admin
+func NewAdmin(
+	orchestrator *Orchestrator,
+	chainRepo repository.ChainRepository,
+	operations *OperationTracker,
+) *Admin {
+	return &Admin{
+		orchestrator: orchestrator,
+		chainRepo:    chainRepo,
+		operations:   operations,
+	}
+}
+
+// PauseChain pauses a chain and returns it
+func (a *Admin) PauseChain(ctx context.Context, chainID string) (*models.Chain, error) {
+	if err := a.orchestrator.PauseChain(ctx, chainID); err != nil {
+		return nil, err
+	}
+	return a.chainRepo.GetChain(ctx, chainID)
+}
+
+// ResumeChain resumes a paused chain and returns it
+func (a *Admin) ResumeChain(ctx context.Context, chainID string) (*models.Chain, error) {
+	if err := a.orchestrator.ResumeChain(ctx, chainID); err != nil {
+		return nil, err
+	}
+	return a.chainRepo.GetChain(ctx, chainID)
+}
+
+// AddChain adds a chain and returns it as stored
+func (a *Admin) AddChain(ctx context.Context, chain *models.Chain) (*models.Chain, error) {
+	if err := a.orchestrator.AddChain(ctx, chain); err != nil {
+		return nil, err
+	}
+	return a.chainRepo.GetChain(ctx, chain.ChainID)
+}
+
+// RemoveChain stops indexing a chain and deletes it
+func (a *Admin) RemoveChain(ctx context.Context, chainID string) error {
+	return a.orchestrator.RemoveChain(ctx, chainID)
+}
+
+// ReindexRange starts reindexing the blocks of a chain from start to end
+func (a *Admin) ReindexRange(chainID string, start, end uint64) (*Operation, error) {
+	if start > end {
+		return nil, fmt.Errorf("start block %d is above end block %d: %w", start, end, models.ErrInvalidBlockRange)
+	}
+	if !a.orchestrator.HasChain(chainID) {
+		return nil, fmt.Errorf("chain %s: %w", chainID, repository.ErrChainNotFound)
+	}
+
+	return a.operations.Start(OperationReindexRange, chainID, func(ctx context.Context, progress ProgressFunc) error {
+		return a.orchestrator.ReindexRange(ctx, chainID, start, end, progress)
+	}), nil
+}
+
+// RecoverGaps starts recovering the gaps of a chain
+func (a *Admin) RecoverGaps(chainID string) (*Operation, error) {
+	if !a.orchestrator.HasChain(chainID) {
+		return nil, fmt.Errorf("chain %s: %w", chainID, repository.ErrChainNotFound)
+	}
+
+	return a.operations.Start(OperationRecoverGaps, chainID, func(ctx context.Context, progress ProgressFunc) error {
+		return a.orchestrator.RecoverGaps(ctx, chainID, progress)
+	}), nil
+}
+
+// GetOperation returns an operation
+func (a *Admin) GetOperation(id string) (*Operation, error) {
+	return a.operations.Get(id)
+}
+
+// ListOperations returns the operations of a chain, or of every chain when
+// chainID is empty, newest first
+func (a *Admin) ListOperations(chainID string) []*Operation {
+	return a.operations.List(chainID)
+}
+
+// WatchOperation returns a channel with the updates of an operation that is
+// closed once it finished
+func (a *Admin) WatchOperation(ctx context.Context, id string) (<-chan *Operation, error) {
+	return a.operations.Watch(ctx, id)
+}
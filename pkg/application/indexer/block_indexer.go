@@ -2,11 +2,14 @@ package indexer
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
 
 	"github.com/sage-x-project/blockchain-indexer/pkg/application/processor"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/repository"
 	"github.com/sage-x-project/blockchain-indexer/pkg/domain/service"
 	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/logger"
 	"go.uber.org/zap"
@@ -16,8 +19,10 @@ import (
 type BlockIndexer struct {
 	adapter        service.ChainAdapter
 	processor      *processor.BlockProcessor
+	cursorRepo     repository.CursorRepository
 	workerPool     *WorkerPool
 	gapRecovery    *GapRecovery
+	reorgHandler   *ReorgHandler
 	progressTracker *ProgressTracker
 	logger         *logger.Logger
 
@@ -26,6 +31,25 @@ type BlockIndexer struct {
 	running  bool
 	stopChan chan struct{}
 
+	// Closed when the indexing loop exits, with the error it failed with
+	done     chan struct{}
+	doneOnce sync.Once
+	err      error
+
+	// Committed cursor
+	cursor      *CursorTracker
+	cursorMu    sync.Mutex
+	savedCursor uint64
+	cursorSaved bool
+
+	// Finality heads new blocks are marked with
+	finalityMu sync.RWMutex
+	heads      models.FinalityHeads
+
+	// Held shared while blocks are stored and exclusively while a stored
+	// block is replaced
+	writeMu sync.RWMutex
+
 	// Configuration
 	config *BlockIndexerConfig
 }
@@ -40,6 +64,21 @@ type BlockIndexerConfig struct {
 	ConfirmationBlocks uint64
 	PollInterval      time.Duration
 	EnableGapRecovery bool
+
+	// EnableRealtime follows new heads through the adapter's block
+	// subscription once the indexer has caught up, falling back to polling
+	// every PollInterval when the subscription fails
+	EnableRealtime bool
+
+	// IndexPending indexes new blocks right away instead of waiting until
+	// they are ConfirmationBlocks deep. They are marked pending and
+	// promoted as the chain's finality advances.
+	IndexPending bool
+
+	// MaxRetries is how many times in a row the latest block number may
+	// fail to load, or a block range may be retried after failing, before
+	// the indexer gives up with an error, 0 to retry forever
+	MaxRetries int
 }
 
 // DefaultBlockIndexerConfig returns default configuration
@@ -53,14 +92,40 @@ func DefaultBlockIndexerConfig(chainID string) *BlockIndexerConfig {
 		ConfirmationBlocks: 12,
 		PollInterval:       5 * time.Second,
 		EnableGapRecovery:  true,
+		EnableRealtime:     true,
 	}
 }
 
+// BlockIndexerConfigFrom returns the configuration for indexing chainID
+// under the service-level indexer configuration cfg
+func BlockIndexerConfigFrom(chainID string, cfg *service.IndexerConfig) *BlockIndexerConfig {
+	config := DefaultBlockIndexerConfig(chainID)
+	if cfg == nil {
+		return config
+	}
+
+	if cfg.WorkersPerChain > 0 {
+		config.WorkerCount = cfg.WorkersPerChain
+	}
+	if cfg.BatchSize > 0 {
+		config.BatchSize = cfg.BatchSize
+	}
+	config.ConfirmationBlocks = cfg.ConfirmationBlocks
+	config.EnableGapRecovery = cfg.EnableGapRecovery
+	config.EnableRealtime = cfg.EnableRealtime
+	config.IndexPending = cfg.IndexPending
+	config.MaxRetries = cfg.MaxRetries
+
+	return config
+}
+
 // NewBlockIndexer creates a new block indexer
 func NewBlockIndexer(
 	adapter service.ChainAdapter,
 	processor *processor.BlockProcessor,
+	cursorRepo repository.CursorRepository,
 	gapRecovery *GapRecovery,
+	reorgHandler *ReorgHandler,
 	progressTracker *ProgressTracker,
 	config *BlockIndexerConfig,
 	logger *logger.Logger,
@@ -81,12 +146,15 @@ func NewBlockIndexer(
 	indexer := &BlockIndexer{
 		adapter:         adapter,
 		processor:       processor,
+		cursorRepo:      cursorRepo,
 		workerPool:      workerPool,
 		gapRecovery:     gapRecovery,
+		reorgHandler:    reorgHandler,
 		progressTracker: progressTracker,
 		config:          config,
 		logger:          logger,
 		stopChan:        make(chan struct{}),
+		done:            make(chan struct{}),
 	}
 
 	// Set job handler for worker pool
@@ -105,9 +173,13 @@ func (b *BlockIndexer) Start(ctx context.Context) error {
 	b.running = true
 	b.mu.Unlock()
 
+	// Resume from the committed cursor if we indexed this chain before
+	startBlock := b.resolveStartBlock(ctx)
+	b.cursor = NewCursorTracker(startBlock)
+
 	b.logger.Info("starting block indexer",
 		zap.String("chain_id", b.config.ChainID),
-		zap.Uint64("start_block", b.config.StartBlock),
+		zap.Uint64("start_block", startBlock),
 		zap.Uint64("end_block", b.config.EndBlock),
 		zap.Int("batch_size", b.config.BatchSize),
 		zap.Int("workers", b.config.WorkerCount),
@@ -117,13 +189,16 @@ func (b *BlockIndexer) Start(ctx context.Context) error {
 	go b.handleResults()
 
 	// Start indexing
-	go b.indexLoop(ctx)
+	go b.indexLoop(ctx, startBlock)
 
 	// Start gap recovery if enabled
-	if b.config.EnableGapRecovery && b.gapRecovery != nil {
+	if b.config.EnableGapRecovery && b.gapRecovery != nil && b.gapRecovery.CanRecover() {
 		go b.gapRecoveryLoop(ctx)
 	}
 
+	// Start promoting blocks as the chain finalizes them
+	go b.finalityLoop(ctx, startBlock)
+
 	// Start progress tracking
 	if b.progressTracker != nil {
 		go b.progressTrackingLoop(ctx)
@@ -155,21 +230,74 @@ func (b *BlockIndexer) Stop() error {
 	return nil
 }
 
+// resolveStartBlock returns the block to start indexing from, which is the
+// block after the persisted cursor when it is ahead of the configured start
+func (b *BlockIndexer) resolveStartBlock(ctx context.Context) uint64 {
+	startBlock := b.config.StartBlock
+	if b.cursorRepo == nil {
+		return startBlock
+	}
+
+	cursor, err := b.cursorRepo.GetCursor(ctx, b.config.ChainID)
+	if err != nil {
+		if !errors.Is(err, repository.ErrCursorNotFound) {
+			b.logger.Warn("failed to load indexing cursor, using start block",
+				zap.String("chain_id", b.config.ChainID),
+				zap.Error(err),
+			)
+		}
+		return startBlock
+	}
+
+	b.cursorMu.Lock()
+	b.savedCursor = cursor
+	b.cursorSaved = true
+	b.cursorMu.Unlock()
+
+	if cursor+1 > startBlock {
+		b.logger.Info("resuming from indexing cursor",
+			zap.String("chain_id", b.config.ChainID),
+			zap.Uint64("cursor", cursor),
+			zap.Uint64("configured_start_block", startBlock),
+		)
+		startBlock = cursor + 1
+	}
+
+	return startBlock
+}
+
 // indexLoop is the main indexing loop
-func (b *BlockIndexer) indexLoop(ctx context.Context) {
+func (b *BlockIndexer) indexLoop(ctx context.Context, startBlock uint64) {
+	var loopErr error
 	defer func() {
 		if r := recover(); r != nil {
 			b.logger.Error("indexing loop panicked",
 				zap.String("chain_id", b.config.ChainID),
 				zap.Any("panic", r),
 			)
+			loopErr = fmt.Errorf("indexing loop panicked: %v", r)
 		}
+		b.exit(loopErr)
 	}()
 
-	currentBlock := b.config.StartBlock
+	currentBlock := startBlock
 	ticker := time.NewTicker(b.config.PollInterval)
 	defer ticker.Stop()
 
+	// Earliest time to open the block subscription again after it failed
+	var realtimeRetryAt time.Time
+
+	// Failed attempts in a row to get the latest block number
+	failures := 0
+
+	// Adapters without block subscriptions are polled for new blocks
+	realtime := b.adapter.Capabilities().BlockSubscription
+	if b.config.EnableRealtime && !realtime {
+		b.logger.Info("adapter has no block subscriptions, polling for new blocks",
+			zap.String("chain_id", b.config.ChainID),
+		)
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -177,6 +305,16 @@ func (b *BlockIndexer) indexLoop(ctx context.Context) {
 		case <-b.stopChan:
 			return
 		case <-ticker.C:
+			// Retry failed ranges first so the cursor can move past them
+			if err := b.retryFailedRanges(); err != nil {
+				b.logger.Error("giving up on block range",
+					zap.String("chain_id", b.config.ChainID),
+					zap.Error(err),
+				)
+				loopErr = err
+				return
+			}
+
 			// Check if we reached end block
 			if b.config.EndBlock > 0 && currentBlock >= b.config.EndBlock {
 				b.logger.Info("reached end block",
@@ -189,17 +327,24 @@ func (b *BlockIndexer) indexLoop(ctx context.Context) {
 			// Get latest block from chain
 			latestBlock, err := b.adapter.GetLatestBlockNumber(ctx)
 			if err != nil {
+				failures++
 				b.logger.Error("failed to get latest block number",
 					zap.String("chain_id", b.config.ChainID),
+					zap.Int("failures", failures),
 					zap.Error(err),
 				)
+				if b.config.MaxRetries > 0 && failures > b.config.MaxRetries {
+					loopErr = fmt.Errorf("failed to get latest block number %d times: %w", failures, err)
+					return
+				}
 				continue
 			}
+			failures = 0
 
 			// Apply confirmation blocks
 			confirmedBlock := latestBlock
-			if latestBlock > b.config.ConfirmationBlocks {
-				confirmedBlock = latestBlock - b.config.ConfirmationBlocks
+			if depth := b.confirmationDepth(); latestBlock > depth {
+				confirmedBlock = latestBlock - depth
 			}
 
 			// Index blocks in batches
@@ -241,8 +386,51 @@ func (b *BlockIndexer) indexLoop(ctx context.Context) {
 
 				currentBlock = batchEnd + 1
 			}
+
+			// Switch to the realtime lane once every block up to the
+			// confirmed head is submitted and at most a batch is in flight
+			if b.config.EnableRealtime && realtime && b.config.EndBlock == 0 &&
+				currentBlock-b.cursor.Next() <= uint64(b.config.BatchSize) &&
+				time.Now().After(realtimeRetryAt) {
+				next, failed := b.followHeads(ctx, currentBlock)
+				currentBlock = next
+				if failed {
+					realtimeRetryAt = time.Now().Add(realtimeRetryInterval)
+				}
+			}
+		}
+	}
+}
+
+// retryFailedRanges resubmits block ranges whose jobs failed once their
+// retry delay has passed. It fails once a range failed more than MaxRetries
+// times in a row.
+func (b *BlockIndexer) retryFailedRanges() error {
+	for _, payload := range b.cursor.TakeFailed(time.Now()) {
+		failures := b.cursor.Failures(payload.StartBlock)
+		if b.config.MaxRetries > 0 && failures > b.config.MaxRetries {
+			return fmt.Errorf("block range %d-%d failed %d times: %w",
+				payload.StartBlock, payload.EndBlock, failures, service.ErrMaxRetriesExceeded)
+		}
+
+		job := Job{
+			ID:      fmt.Sprintf("block-range-%d-%d-retry", payload.StartBlock, payload.EndBlock),
+			Type:    JobTypeBlockRange,
+			Payload: payload,
+		}
+
+		if err := b.workerPool.Submit(job); err != nil {
+			b.logger.Warn("failed to resubmit block range",
+				zap.String("chain_id", b.config.ChainID),
+				zap.String("job_id", job.ID),
+				zap.Error(err),
+			)
+			// Keep it for the next tick
+			b.cursor.Retry(payload)
 		}
 	}
+
+	return nil
 }
 
 // gapRecoveryLoop periodically runs gap recovery
@@ -257,7 +445,7 @@ func (b *BlockIndexer) gapRecoveryLoop(ctx context.Context) {
 		case <-b.stopChan:
 			return
 		case <-ticker.C:
-			gaps, err := b.gapRecovery.DetectGaps(ctx, b.config.ChainID)
+			gaps, err := b.gapRecovery.DetectGaps(ctx, b.config.ChainID, b.config.StartBlock)
 			if err != nil {
 				b.logger.Error("failed to detect gaps",
 					zap.String("chain_id", b.config.ChainID),
@@ -348,7 +536,11 @@ func (b *BlockIndexer) handleResults() {
 func (b *BlockIndexer) handleJob(ctx context.Context, job Job) Result {
 	switch job.Type {
 	case JobTypeBlockRange:
-		return b.handleBlockRangeJob(ctx, job)
+		result := b.handleBlockRangeJob(ctx, job)
+		if payload, ok := job.Payload.(*BlockRangePayload); ok {
+			b.trackRange(payload, result.Success)
+		}
+		return result
 	case JobTypeBlock:
 		return b.handleBlockJob(ctx, job)
 	default:
@@ -361,6 +553,9 @@ func (b *BlockIndexer) handleJob(ctx context.Context, job Job) Result {
 
 // handleBlockRangeJob processes a block range job
 func (b *BlockIndexer) handleBlockRangeJob(ctx context.Context, job Job) Result {
+	b.writeMu.RLock()
+	defer b.writeMu.RUnlock()
+
 	payload, ok := job.Payload.(*BlockRangePayload)
 	if !ok {
 		return Result{
@@ -370,19 +565,39 @@ func (b *BlockIndexer) handleBlockRangeJob(ctx context.Context, job Job) Result
 	}
 
 	// Fetch blocks
-	blocks, err := b.adapter.GetBlocks(ctx, payload.StartBlock, payload.EndBlock)
+	blocks, err := fetchBlocks(ctx, b.adapter, payload.StartBlock, payload.EndBlock)
 	if err != nil {
 		return Result{
 			Success: false,
 			Error:   fmt.Errorf("failed to fetch blocks: %w", err),
 		}
 	}
+	b.markFinality(blocks...)
+
+	// Process blocks, recording the whole range as indexed
+	if err := b.processor.ProcessBlockRange(ctx, b.config.ChainID, payload.StartBlock, payload.EndBlock, blocks); err != nil {
+		if !b.recoverFromReorg(ctx, err) {
+			return Result{
+				Success: false,
+				Error:   fmt.Errorf("failed to process blocks: %w", err),
+			}
+		}
 
-	// Process blocks
-	if err := b.processor.ProcessBlocks(ctx, blocks); err != nil {
-		return Result{
-			Success: false,
-			Error:   fmt.Errorf("failed to process blocks: %w", err),
+		// The canonical branch changed under us, fetch the range again
+		blocks, err = fetchBlocks(ctx, b.adapter, payload.StartBlock, payload.EndBlock)
+		if err != nil {
+			return Result{
+				Success: false,
+				Error:   fmt.Errorf("failed to fetch blocks after reorg: %w", err),
+			}
+		}
+		b.markFinality(blocks...)
+
+		if err := b.processor.ProcessBlockRange(ctx, b.config.ChainID, payload.StartBlock, payload.EndBlock, blocks); err != nil {
+			return Result{
+				Success: false,
+				Error:   fmt.Errorf("failed to process blocks after reorg: %w", err),
+			}
 		}
 	}
 
@@ -394,6 +609,9 @@ func (b *BlockIndexer) handleBlockRangeJob(ctx context.Context, job Job) Result
 
 // handleBlockJob processes a single block job
 func (b *BlockIndexer) handleBlockJob(ctx context.Context, job Job) Result {
+	b.writeMu.RLock()
+	defer b.writeMu.RUnlock()
+
 	payload, ok := job.Payload.(*BlockPayload)
 	if !ok {
 		return Result{
@@ -410,12 +628,32 @@ func (b *BlockIndexer) handleBlockJob(ctx context.Context, job Job) Result {
 			Error:   fmt.Errorf("failed to fetch block: %w", err),
 		}
 	}
+	b.markFinality(block)
 
 	// Process block
 	if err := b.processor.ProcessBlock(ctx, block); err != nil {
-		return Result{
-			Success: false,
-			Error:   fmt.Errorf("failed to process block: %w", err),
+		if !b.recoverFromReorg(ctx, err) {
+			return Result{
+				Success: false,
+				Error:   fmt.Errorf("failed to process block: %w", err),
+			}
+		}
+
+		// The canonical branch changed under us, fetch the block again
+		block, err = b.adapter.GetBlockByNumber(ctx, payload.BlockNumber)
+		if err != nil {
+			return Result{
+				Success: false,
+				Error:   fmt.Errorf("failed to fetch block after reorg: %w", err),
+			}
+		}
+		b.markFinality(block)
+
+		if err := b.processor.ProcessBlock(ctx, block); err != nil {
+			return Result{
+				Success: false,
+				Error:   fmt.Errorf("failed to process block after reorg: %w", err),
+			}
 		}
 	}
 
@@ -425,6 +663,158 @@ func (b *BlockIndexer) handleBlockJob(ctx context.Context, job Job) Result {
 	}
 }
 
+// trackRange records the outcome of a block range job and persists the
+// cursor once every range below it has been committed
+func (b *BlockIndexer) trackRange(payload *BlockRangePayload, success bool) {
+	if b.cursor == nil {
+		return
+	}
+
+	if !success {
+		b.cursor.Fail(payload.StartBlock, payload.EndBlock)
+		return
+	}
+
+	watermark, advanced := b.cursor.Complete(payload.StartBlock, payload.EndBlock)
+	if !advanced || b.cursorRepo == nil {
+		return
+	}
+
+	b.cursorMu.Lock()
+	defer b.cursorMu.Unlock()
+
+	// Ranges can complete concurrently, never move the cursor backwards
+	if b.cursorSaved && watermark <= b.savedCursor {
+		return
+	}
+
+	if err := b.cursorRepo.SaveCursor(context.Background(), b.config.ChainID, watermark); err != nil {
+		b.logger.Error("failed to save indexing cursor",
+			zap.String("chain_id", b.config.ChainID),
+			zap.Uint64("cursor", watermark),
+			zap.Error(err),
+		)
+		return
+	}
+
+	b.savedCursor = watermark
+	b.cursorSaved = true
+}
+
+// recoverFromReorg handles err if it reports a chain reorganization and
+// returns true when the orphaned blocks were rolled back successfully
+func (b *BlockIndexer) recoverFromReorg(ctx context.Context, err error) bool {
+	var reorgErr *processor.ReorgError
+	if b.reorgHandler == nil || !errors.As(err, &reorgErr) {
+		return false
+	}
+
+	if _, err := b.reorgHandler.HandleReorg(ctx, reorgErr); err != nil {
+		b.logger.Error("failed to handle chain reorganization",
+			zap.String("chain_id", b.config.ChainID),
+			zap.Uint64("block_number", reorgErr.BlockNumber),
+			zap.Error(err),
+		)
+		return false
+	}
+
+	return true
+}
+
+// exit records why the indexing loop exited and closes the done channel
+func (b *BlockIndexer) exit(err error) {
+	b.doneOnce.Do(func() {
+		b.mu.Lock()
+		b.err = err
+		b.mu.Unlock()
+
+		close(b.done)
+	})
+}
+
+// Done returns a channel that is closed once the indexing loop exits,
+// because the indexer was stopped, reached EndBlock or failed
+func (b *BlockIndexer) Done() <-chan struct{} {
+	return b.done
+}
+
+// Err returns the error the indexing loop failed with, nil while it runs
+// or if it exited without failing
+func (b *BlockIndexer) Err() error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.err
+}
+
+// IndexBlock fetches and stores the block at number outside of the indexing
+// loop, without moving the cursor
+func (b *BlockIndexer) IndexBlock(ctx context.Context, number uint64) error {
+	result := b.handleBlockJob(ctx, Job{
+		ID:      fmt.Sprintf("block-%d", number),
+		Type:    JobTypeBlock,
+		Payload: &BlockPayload{BlockNumber: number},
+	})
+	return result.Error
+}
+
+// IndexRange fetches and stores the blocks from start to end outside of the
+// indexing loop in batches of BatchSize, without moving the cursor
+func (b *BlockIndexer) IndexRange(ctx context.Context, start, end uint64) error {
+	if start > end {
+		return fmt.Errorf("invalid block range: %d-%d", start, end)
+	}
+
+	batchSize := uint64(max(b.config.BatchSize, 1))
+	for batchStart := start; ; batchStart += batchSize {
+		batchEnd := min(batchStart+batchSize-1, end)
+
+		result := b.handleBlockRangeJob(ctx, Job{
+			ID:      fmt.Sprintf("block-range-%d-%d", batchStart, batchEnd),
+			Type:    JobTypeBlockRange,
+			Payload: &BlockRangePayload{StartBlock: batchStart, EndBlock: batchEnd},
+		})
+		if !result.Success {
+			return result.Error
+		}
+
+		if batchEnd == end {
+			return nil
+		}
+	}
+}
+
+// ReindexBlock fetches the block at number again and replaces the stored
+// block with it, dropping the transactions only the stored block had. The
+// stored block is kept if the new one cannot be stored, and the indexing
+// loop does not store blocks meanwhile.
+func (b *BlockIndexer) ReindexBlock(ctx context.Context, number uint64) error {
+	block, err := b.adapter.GetBlockByNumber(ctx, number)
+	if err != nil {
+		return fmt.Errorf("failed to fetch block: %w", err)
+	}
+	b.markFinality(block)
+
+	if err := b.replaceBlock(ctx, block); err != nil {
+		if !b.recoverFromReorg(ctx, err) {
+			return fmt.Errorf("failed to process block: %w", err)
+		}
+
+		// The canonical branch changed under us, index the block again
+		return b.IndexBlock(ctx, number)
+	}
+
+	return nil
+}
+
+// replaceBlock replaces the stored block at the height of block while no
+// other block is stored
+func (b *BlockIndexer) replaceBlock(ctx context.Context, block *models.Block) error {
+	b.writeMu.Lock()
+	defer b.writeMu.Unlock()
+
+	return b.processor.ReplaceBlock(ctx, block)
+}
+
 // IsRunning returns true if the indexer is running
 func (b *BlockIndexer) IsRunning() bool {
 	b.mu.RLock()
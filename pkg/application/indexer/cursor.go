@@ -0,0 +1,151 @@
+package indexer
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// rangeRetryDelay is how long a failed range waits before it is retried,
+	// doubling with every failure in a row up to maxRangeRetryDelay
+	rangeRetryDelay    = 5 * time.Second
+	maxRangeRetryDelay = 5 * time.Minute
+)
+
+// failedRange is a block range waiting to be retried
+type failedRange struct {
+	payload *BlockRangePayload
+	retryAt time.Time
+}
+
+// CursorTracker tracks committed block ranges that may finish out of order
+// and advances a contiguous watermark only once every earlier range is done
+type CursorTracker struct {
+	mu        sync.Mutex
+	next      uint64            // First block not yet known to be committed
+	completed map[uint64]uint64 // Start -> end of ranges committed ahead of next
+	failed    []*failedRange
+	failures  map[uint64]int // Start -> failures in a row of ranges not yet committed
+}
+
+// NewCursorTracker creates a tracker expecting the first range to start at next
+func NewCursorTracker(next uint64) *CursorTracker {
+	return &CursorTracker{
+		next:      next,
+		completed: make(map[uint64]uint64),
+		failed:    make([]*failedRange, 0),
+		failures:  make(map[uint64]int),
+	}
+}
+
+// Complete marks a range as committed. It returns the new watermark (the
+// highest block below which everything is committed) and whether it advanced.
+func (t *CursorTracker) Complete(start, end uint64) (uint64, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.failures, start)
+
+	if end < t.next {
+		// Already covered, e.g. a retried range
+		return 0, false
+	}
+	if start < t.next {
+		start = t.next
+	}
+	if current, exists := t.completed[start]; !exists || end > current {
+		t.completed[start] = end
+	}
+
+	advanced := false
+	for {
+		end, exists := t.completed[t.next]
+		if !exists {
+			break
+		}
+		delete(t.completed, t.next)
+		t.next = end + 1
+		advanced = true
+	}
+
+	if !advanced {
+		return 0, false
+	}
+	return t.next - 1, true
+}
+
+// Fail records a failed attempt at a range, which is retried after a delay
+// that doubles with every failure in a row
+func (t *CursorTracker) Fail(start, end uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.failures[start]++
+	failures := t.failures[start]
+
+	delay := rangeRetryDelay
+	for i := 1; i < failures && delay < maxRangeRetryDelay; i++ {
+		delay *= 2
+	}
+
+	t.failed = append(t.failed, &failedRange{
+		payload: &BlockRangePayload{StartBlock: start, EndBlock: end},
+		retryAt: time.Now().Add(min(delay, maxRangeRetryDelay)),
+	})
+}
+
+// Retry records a range that must be submitted again at the next chance
+// without counting a failure, e.g. when it could not be submitted
+func (t *CursorTracker) Retry(payload *BlockRangePayload) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.failed = append(t.failed, &failedRange{payload: payload})
+}
+
+// TakeFailed returns and clears the ranges due for a retry at now, lowest
+// first. Ranges still backing off are kept.
+func (t *CursorTracker) TakeFailed(now time.Time) []*BlockRangePayload {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	due := make([]*BlockRangePayload, 0)
+	waiting := make([]*failedRange, 0, len(t.failed))
+	for _, rng := range t.failed {
+		if rng.retryAt.After(now) {
+			waiting = append(waiting, rng)
+			continue
+		}
+		due = append(due, rng.payload)
+	}
+	t.failed = waiting
+
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].StartBlock < due[j].StartBlock
+	})
+
+	return due
+}
+
+// Failures returns the number of failures in a row of the range starting
+// at start
+func (t *CursorTracker) Failures(start uint64) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.failures[start]
+}
+
+// Next returns the first block not yet known to be committed
+func (t *CursorTracker) Next() uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.next
+}
+
+// Pending returns the number of committed ranges waiting on an earlier range
+func (t *CursorTracker) Pending() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.completed)
+}
@@ -0,0 +1,36 @@
+package indexer
+
+import (
+	"context"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/service"
+)
+
+// fetchBlocks fetches the blocks from start to end, splitting the range into
+// the chunks the adapter's GetBlocks covers so that the range is never cut
+// short. Numbers without a block are left out.
+func fetchBlocks(ctx context.Context, adapter service.ChainAdapter, start, end uint64) ([]*models.Block, error) {
+	limit := adapter.Capabilities().MaxBlockRange
+	if limit == 0 || start > end || end-start < limit {
+		return adapter.GetBlocks(ctx, start, end)
+	}
+
+	blocks := make([]*models.Block, 0, end-start+1)
+	for chunkStart := start; ; chunkStart += limit {
+		chunkEnd := chunkStart + limit - 1
+		if chunkEnd > end {
+			chunkEnd = end
+		}
+
+		chunk, err := adapter.GetBlocks(ctx, chunkStart, chunkEnd)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, chunk...)
+
+		if chunkEnd == end {
+			return blocks, nil
+		}
+	}
+}
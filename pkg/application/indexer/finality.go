@@ -0,0 +1,106 @@
+package indexer
+
+import (
+	"context"
+	"time"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"go.uber.org/zap"
+)
+
+// confirmationDepth returns how deep a block has to be before it is
+// indexed, which is zero when pending blocks are indexed
+func (b *BlockIndexer) confirmationDepth() uint64 {
+	if b.config.IndexPending {
+		return 0
+	}
+	return b.config.ConfirmationBlocks
+}
+
+// markFinality sets the finality of blocks under the current heads
+func (b *BlockIndexer) markFinality(blocks ...*models.Block) {
+	b.finalityMu.RLock()
+	heads := b.heads
+	b.finalityMu.RUnlock()
+
+	for _, block := range blocks {
+		if block != nil {
+			block.Finality = heads.Finality(block.Number)
+		}
+	}
+}
+
+// finalityLoop refreshes the finality heads every PollInterval and promotes
+// the committed blocks they cover. Chains without recorded finality are
+// promoted from start.
+func (b *BlockIndexer) finalityLoop(ctx context.Context, start uint64) {
+	ticker := time.NewTicker(b.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-b.stopChan:
+			return
+		case <-ticker.C:
+			heads, err := b.refreshFinality(ctx)
+			if err != nil {
+				b.logger.Warn("failed to get finality heads",
+					zap.String("chain_id", b.config.ChainID),
+					zap.Error(err),
+				)
+				continue
+			}
+
+			next := b.cursor.Next()
+			if next == 0 {
+				continue
+			}
+
+			b.writeMu.RLock()
+			err = b.processor.PromoteBlocks(ctx, b.config.ChainID, heads, start, next-1)
+			b.writeMu.RUnlock()
+			if err != nil {
+				b.logger.Warn("failed to promote blocks",
+					zap.String("chain_id", b.config.ChainID),
+					zap.Uint64("safe_block", heads.Safe),
+					zap.Uint64("finalized_block", heads.Finalized),
+					zap.Error(err),
+				)
+			}
+		}
+	}
+}
+
+// refreshFinality updates the finality heads from the adapter and returns
+// them. Adapters that cannot report them fall back to the blocks
+// ConfirmationBlocks below the latest one. The heads never move back.
+func (b *BlockIndexer) refreshFinality(ctx context.Context) (models.FinalityHeads, error) {
+	heads, err := b.adapter.GetFinalityHeads(ctx)
+	if err != nil {
+		latest, latestErr := b.adapter.GetLatestBlockNumber(ctx)
+		if latestErr != nil {
+			return models.FinalityHeads{}, latestErr
+		}
+
+		b.logger.Debug("finality heads unavailable, using confirmation depth",
+			zap.String("chain_id", b.config.ChainID),
+			zap.Error(err),
+		)
+
+		confirmed := uint64(0)
+		if latest > b.config.ConfirmationBlocks {
+			confirmed = latest - b.config.ConfirmationBlocks
+		}
+		heads = &models.FinalityHeads{Safe: confirmed, Finalized: confirmed}
+	}
+
+	b.finalityMu.Lock()
+	defer b.finalityMu.Unlock()
+
+	b.heads.Safe = max(b.heads.Safe, heads.Safe)
+	b.heads.Finalized = max(b.heads.Finalized, heads.Finalized)
+
+	return b.heads, nil
+}
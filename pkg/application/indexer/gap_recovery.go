@@ -3,7 +3,6 @@ package indexer
 import (
 	"context"
 	"fmt"
-	"sort"
 
 	"github.com/sage-x-project/blockchain-indexer/pkg/application/processor"
 	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
@@ -17,7 +16,7 @@ import (
 // GapRecovery handles detection and recovery of missing blocks
 type GapRecovery struct {
 	adapter   service.ChainAdapter
-	blockRepo repository.BlockRepository
+	rangeRepo repository.IndexedRangeRepository
 	processor *processor.BlockProcessor
 	eventBus  event.EventBus
 	logger    *logger.Logger
@@ -26,20 +25,26 @@ type GapRecovery struct {
 // NewGapRecovery creates a new gap recovery instance
 func NewGapRecovery(
 	adapter service.ChainAdapter,
-	blockRepo repository.BlockRepository,
+	rangeRepo repository.IndexedRangeRepository,
 	processor *processor.BlockProcessor,
 	eventBus event.EventBus,
 	logger *logger.Logger,
 ) *GapRecovery {
 	return &GapRecovery{
 		adapter:   adapter,
-		blockRepo: blockRepo,
+		rangeRepo: rangeRepo,
 		processor: processor,
 		eventBus:  eventBus,
 		logger:    logger,
 	}
 }
 
+// CanRecover returns true if gaps can be recovered, which needs a chain
+// adapter. Without one gaps can only be detected.
+func (g *GapRecovery) CanRecover() bool {
+	return g.adapter != nil
+}
+
 // Gap represents a range of missing blocks
 type Gap struct {
 	ChainID    string
@@ -53,52 +58,27 @@ func (g *Gap) String() string {
 	return fmt.Sprintf("Gap[%s: %d-%d, size=%d]", g.ChainID, g.StartBlock, g.EndBlock, g.Size)
 }
 
-// DetectGaps detects gaps in indexed blocks
-func (g *GapRecovery) DetectGaps(ctx context.Context, chainID string) ([]*Gap, error) {
-	g.logger.Debug("detecting gaps", zap.String("chain_id", chainID))
-
-	// Get latest indexed block
-	latestBlock, err := g.blockRepo.GetLatestBlock(ctx, chainID)
+// ListGaps returns every range of missing blocks from the chain's start
+// block up to the highest indexed block. It reads the chain's indexed ranges
+// rather than the blocks, so the cost depends on the number of gaps and not
+// on the chain height.
+func (g *GapRecovery) ListGaps(ctx context.Context, chainID string, startBlock uint64) ([]*Gap, error) {
+	ranges, err := g.rangeRepo.GetIndexedRanges(ctx, chainID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get latest block: %w", err)
-	}
-
-	if latestBlock == nil {
-		// No blocks indexed yet
-		return nil, nil
+		return nil, fmt.Errorf("failed to get indexed ranges: %w", err)
 	}
 
-	// Sample blocks to find gaps
-	// In production, you might want to use a more sophisticated approach
-	// such as maintaining a bitmap or using database queries
-	gaps := make([]*Gap, 0)
-
-	// Check blocks in ranges (sampling approach)
-	// This is simplified - in production you'd check more thoroughly
-	const sampleSize = 1000
-	endBlock := latestBlock.Number
-
-	for start := uint64(0); start < endBlock; start += sampleSize {
-		end := start + sampleSize
-		if end > endBlock {
-			end = endBlock
-		}
+	return findGapsBetweenRanges(chainID, startBlock, ranges), nil
+}
 
-		// Get blocks in range
-		blocks, err := g.blockRepo.GetBlocks(ctx, chainID, start, end)
-		if err != nil {
-			g.logger.Error("failed to get blocks for gap detection",
-				zap.String("chain_id", chainID),
-				zap.Uint64("start", start),
-				zap.Uint64("end", end),
-				zap.Error(err),
-			)
-			continue
-		}
+// DetectGaps detects gaps in the blocks indexed from startBlock on and
+// publishes an event for each
+func (g *GapRecovery) DetectGaps(ctx context.Context, chainID string, startBlock uint64) ([]*Gap, error) {
+	g.logger.Debug("detecting gaps", zap.String("chain_id", chainID))
 
-		// Find gaps in this range
-		rangeGaps := g.findGapsInRange(chainID, blocks, start, end)
-		gaps = append(gaps, rangeGaps...)
+	gaps, err := g.ListGaps(ctx, chainID, startBlock)
+	if err != nil {
+		return nil, err
 	}
 
 	if len(gaps) > 0 {
@@ -124,47 +104,27 @@ func (g *GapRecovery) DetectGaps(ctx context.Context, chainID string) ([]*Gap, e
 	return gaps, nil
 }
 
-// findGapsInRange finds gaps in a sorted list of blocks
-func (g *GapRecovery) findGapsInRange(chainID string, blocks []*models.Block, start, end uint64) []*Gap {
-	if len(blocks) == 0 {
-		// Entire range is a gap
-		return []*Gap{{
-			ChainID:    chainID,
-			StartBlock: start,
-			EndBlock:   end,
-			Size:       end - start + 1,
-		}}
-	}
-
-	// Sort blocks by number
-	sort.Slice(blocks, func(i, j int) bool {
-		return blocks[i].Number < blocks[j].Number
-	})
-
+// findGapsBetweenRanges returns the missing blocks from startBlock up to the
+// end of the last range. Blocks below startBlock are never indexed, so they
+// are not missing. Ranges must be sorted and must not overlap.
+func findGapsBetweenRanges(chainID string, startBlock uint64, ranges []*models.BlockRange) []*Gap {
 	gaps := make([]*Gap, 0)
-	expectedBlock := start
+	expectedBlock := startBlock
 
-	for _, block := range blocks {
-		if block.Number > expectedBlock {
-			// Found a gap
+	for _, rng := range ranges {
+		// Skip ranges below the start block
+		if rng.End < expectedBlock {
+			continue
+		}
+		if rng.Start > expectedBlock {
 			gaps = append(gaps, &Gap{
 				ChainID:    chainID,
 				StartBlock: expectedBlock,
-				EndBlock:   block.Number - 1,
-				Size:       block.Number - expectedBlock,
+				EndBlock:   rng.Start - 1,
+				Size:       rng.Start - expectedBlock,
 			})
 		}
-		expectedBlock = block.Number + 1
-	}
-
-	// Check if there's a gap at the end
-	if expectedBlock <= end {
-		gaps = append(gaps, &Gap{
-			ChainID:    chainID,
-			StartBlock: expectedBlock,
-			EndBlock:   end,
-			Size:       end - expectedBlock + 1,
-		})
+		expectedBlock = rng.End + 1
 	}
 
 	return gaps
@@ -172,6 +132,10 @@ func (g *GapRecovery) findGapsInRange(chainID string, blocks []*models.Block, st
 
 // RecoverGap recovers a gap by fetching and processing missing blocks
 func (g *GapRecovery) RecoverGap(ctx context.Context, gap *Gap) error {
+	if !g.CanRecover() {
+		return fmt.Errorf("no chain adapter to recover gaps of %s with: %w", gap.ChainID, service.ErrNotSupported)
+	}
+
 	g.logger.Info("recovering gap",
 		zap.String("chain_id", gap.ChainID),
 		zap.Uint64("start", gap.StartBlock),
@@ -180,16 +144,26 @@ func (g *GapRecovery) RecoverGap(ctx context.Context, gap *Gap) error {
 	)
 
 	// Fetch missing blocks
-	blocks, err := g.adapter.GetBlocks(ctx, gap.StartBlock, gap.EndBlock)
+	blocks, err := fetchBlocks(ctx, g.adapter, gap.StartBlock, gap.EndBlock)
 	if err != nil {
 		return fmt.Errorf("failed to fetch blocks for gap recovery: %w", err)
 	}
 
-	// Process blocks
-	if err := g.processor.ProcessBlocks(ctx, blocks); err != nil {
+	// Process blocks, recording the whole gap as indexed
+	if err := g.processor.ProcessBlockRange(ctx, gap.ChainID, gap.StartBlock, gap.EndBlock, blocks); err != nil {
 		return fmt.Errorf("failed to process blocks for gap recovery: %w", err)
 	}
 
+	// Heights without blocks, such as skipped Solana slots, only close the gap
+	if len(blocks) == 0 {
+		g.logger.Info("gap holds no blocks",
+			zap.String("chain_id", gap.ChainID),
+			zap.Uint64("start", gap.StartBlock),
+			zap.Uint64("end", gap.EndBlock),
+		)
+		return nil
+	}
+
 	g.logger.Info("gap recovered",
 		zap.String("chain_id", gap.ChainID),
 		zap.Uint64("start", gap.StartBlock),
@@ -211,9 +185,13 @@ func (g *GapRecovery) RecoverGap(ctx context.Context, gap *Gap) error {
 	return nil
 }
 
-// RecoverAllGaps detects and recovers all gaps
-func (g *GapRecovery) RecoverAllGaps(ctx context.Context, chainID string) error {
-	gaps, err := g.DetectGaps(ctx, chainID)
+// RecoverAllGaps detects and recovers all gaps from startBlock on
+func (g *GapRecovery) RecoverAllGaps(ctx context.Context, chainID string, startBlock uint64) error {
+	if !g.CanRecover() {
+		return fmt.Errorf("no chain adapter to recover gaps of %s with: %w", chainID, service.ErrNotSupported)
+	}
+
+	gaps, err := g.DetectGaps(ctx, chainID, startBlock)
 	if err != nil {
 		return fmt.Errorf("failed to detect gaps: %w", err)
 	}
@@ -262,28 +240,17 @@ func (g *GapRecovery) RecoverAllGaps(ctx context.Context, chainID string) error
 
 // VerifyBlockContinuity verifies that blocks are continuous within a range
 func (g *GapRecovery) VerifyBlockContinuity(ctx context.Context, chainID string, start, end uint64) (bool, error) {
-	blocks, err := g.blockRepo.GetBlocks(ctx, chainID, start, end)
+	ranges, err := g.rangeRepo.GetIndexedRanges(ctx, chainID)
 	if err != nil {
-		return false, fmt.Errorf("failed to get blocks: %w", err)
-	}
-
-	expectedCount := end - start + 1
-	if uint64(len(blocks)) != expectedCount {
-		return false, nil
+		return false, fmt.Errorf("failed to get indexed ranges: %w", err)
 	}
 
-	// Sort blocks by number
-	sort.Slice(blocks, func(i, j int) bool {
-		return blocks[i].Number < blocks[j].Number
-	})
-
-	// Verify continuity
-	for i, block := range blocks {
-		expectedNumber := start + uint64(i)
-		if block.Number != expectedNumber {
-			return false, nil
+	// Ranges never touch, so a continuous span lies within a single range
+	for _, rng := range ranges {
+		if rng.Start <= start && end <= rng.End {
+			return true, nil
 		}
 	}
 
-	return true, nil
+	return false, nil
 }
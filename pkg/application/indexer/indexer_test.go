@@ -1,8 +1,24 @@
 package indexer
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/application/processor"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/repository"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/service"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/sim"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/event"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/logger"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/metrics"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/storage/pebble"
+	"go.uber.org/zap"
 )
 
 func TestDefaultWorkerPoolConfig(t *testing.T) {
@@ -40,6 +56,35 @@ func TestDefaultBlockIndexerConfig(t *testing.T) {
 	if cfg.PollInterval <= 0 {
 		t.Error("PollInterval should be positive")
 	}
+
+	if !cfg.EnableRealtime {
+		t.Error("EnableRealtime should default to true")
+	}
+}
+
+func TestBlockIndexerConfigFrom(t *testing.T) {
+	cfg := BlockIndexerConfigFrom("ethereum", &service.IndexerConfig{
+		WorkersPerChain:    4,
+		BatchSize:          50,
+		ConfirmationBlocks: 6,
+		EnableGapRecovery:  false,
+		EnableRealtime:     false,
+		IndexPending:       true,
+		MaxRetries:         5,
+	})
+
+	if cfg.WorkerCount != 4 || cfg.BatchSize != 50 || cfg.ConfirmationBlocks != 6 || cfg.MaxRetries != 5 {
+		t.Errorf("WorkerCount, BatchSize, ConfirmationBlocks, MaxRetries = %d, %d, %d, %d, want 4, 50, 6, 5",
+			cfg.WorkerCount, cfg.BatchSize, cfg.ConfirmationBlocks, cfg.MaxRetries)
+	}
+
+	if cfg.EnableGapRecovery || cfg.EnableRealtime || !cfg.IndexPending {
+		t.Error("EnableGapRecovery, EnableRealtime and IndexPending should follow the indexer config")
+	}
+
+	if cfg.PollInterval <= 0 {
+		t.Error("PollInterval should keep its default")
+	}
 }
 
 func TestJobType_String(t *testing.T) {
@@ -95,6 +140,129 @@ func TestGap_String(t *testing.T) {
 	}
 }
 
+func TestReorg_String(t *testing.T) {
+	reorg := &Reorg{
+		ChainID:        "ethereum",
+		CommonAncestor: 99,
+		StartBlock:     100,
+		EndBlock:       102,
+		Depth:          3,
+	}
+
+	str := reorg.String()
+	if str == "" {
+		t.Error("String() should not be empty")
+	}
+}
+
+func TestNewReorgHandler_DefaultDepth(t *testing.T) {
+	handler := NewReorgHandler(nil, nil, nil, nil, nil, 0)
+
+	if handler.maxDepth != DefaultMaxReorgDepth {
+		t.Errorf("maxDepth = %d, want %d", handler.maxDepth, DefaultMaxReorgDepth)
+	}
+}
+
+func TestCursorTracker_Complete(t *testing.T) {
+	tracker := NewCursorTracker(100)
+
+	// A later range finishing first must not move the watermark
+	if _, advanced := tracker.Complete(200, 299); advanced {
+		t.Error("watermark should not advance past an uncommitted range")
+	}
+
+	if tracker.Pending() != 1 {
+		t.Errorf("Pending() = %d, want 1", tracker.Pending())
+	}
+
+	// Once the earlier range is committed, both ranges are covered
+	watermark, advanced := tracker.Complete(100, 199)
+	if !advanced {
+		t.Fatal("watermark should advance")
+	}
+
+	if watermark != 299 {
+		t.Errorf("watermark = %d, want 299", watermark)
+	}
+
+	if tracker.Next() != 300 {
+		t.Errorf("Next() = %d, want 300", tracker.Next())
+	}
+
+	// Completing an already covered range is a no-op
+	if _, advanced := tracker.Complete(100, 199); advanced {
+		t.Error("watermark should not advance for a covered range")
+	}
+}
+
+func TestCursorTracker_Failed(t *testing.T) {
+	tracker := NewCursorTracker(0)
+
+	tracker.Fail(20, 29)
+	tracker.Fail(10, 19)
+
+	// Failed ranges back off before they are retried
+	if failed := tracker.TakeFailed(time.Now()); len(failed) != 0 {
+		t.Errorf("TakeFailed() right after failing returned %d ranges, want 0", len(failed))
+	}
+
+	failed := tracker.TakeFailed(time.Now().Add(rangeRetryDelay))
+	if len(failed) != 2 {
+		t.Fatalf("TakeFailed() returned %d ranges, want 2", len(failed))
+	}
+
+	if failed[0].StartBlock != 10 || failed[1].StartBlock != 20 {
+		t.Errorf("TakeFailed() not sorted: %d, %d", failed[0].StartBlock, failed[1].StartBlock)
+	}
+
+	if len(tracker.TakeFailed(time.Now().Add(maxRangeRetryDelay))) != 0 {
+		t.Error("TakeFailed() should clear the failed ranges")
+	}
+
+	// The delay doubles with every failure in a row
+	tracker.Fail(10, 19)
+	if failed := tracker.TakeFailed(time.Now().Add(rangeRetryDelay)); len(failed) != 0 {
+		t.Errorf("TakeFailed() after a second failure returned %d ranges, want 0", len(failed))
+	}
+	if failed := tracker.TakeFailed(time.Now().Add(2 * rangeRetryDelay)); len(failed) != 1 {
+		t.Errorf("TakeFailed() after twice the delay returned %d ranges, want 1", len(failed))
+	}
+	if failures := tracker.Failures(10); failures != 2 {
+		t.Errorf("Failures(10) = %d, want 2", failures)
+	}
+
+	// Committing the range clears its failures
+	tracker.Complete(10, 19)
+	if failures := tracker.Failures(10); failures != 0 {
+		t.Errorf("Failures(10) after Complete() = %d, want 0", failures)
+	}
+
+	// Ranges that could not be submitted are retried right away
+	tracker.Retry(&BlockRangePayload{StartBlock: 30, EndBlock: 39})
+	if failed := tracker.TakeFailed(time.Now()); len(failed) != 1 || tracker.Failures(30) != 0 {
+		t.Errorf("TakeFailed() after Retry() = %d ranges with %d failures, want 1 with 0", len(failed), tracker.Failures(30))
+	}
+}
+
+func TestBlockIndexer_GivesUpOnFailingRange(t *testing.T) {
+	config := DefaultBlockIndexerConfig("ethereum")
+	config.MaxRetries = 2
+	b := &BlockIndexer{
+		config: config,
+		cursor: NewCursorTracker(0),
+		logger: &logger.Logger{Logger: zap.NewNop()},
+	}
+
+	for i := 0; i <= config.MaxRetries; i++ {
+		b.cursor.Fail(0, 9)
+		b.cursor.Retry(b.cursor.TakeFailed(time.Now().Add(maxRangeRetryDelay))[0])
+	}
+
+	if err := b.retryFailedRanges(); !errors.Is(err, service.ErrMaxRetriesExceeded) {
+		t.Errorf("retryFailedRanges() error = %v, want ErrMaxRetriesExceeded", err)
+	}
+}
+
 func TestProgress_String(t *testing.T) {
 	progress := &Progress{
 		ChainID:            "ethereum",
@@ -117,3 +285,871 @@ func TestProgress_String(t *testing.T) {
 // Note: Full integration tests would require mocking the dependencies
 // (adapter, repositories, processor, etc.). For now, we focus on unit tests
 // of the configuration and data structures.
+
+func TestFindGapsBetweenRanges(t *testing.T) {
+	ranges := []*models.BlockRange{
+		{Start: 5, End: 9},
+		{Start: 20, End: 29},
+		{Start: 31, End: 40},
+	}
+
+	gaps := findGapsBetweenRanges("ethereum", 0, ranges)
+
+	want := []Gap{
+		{ChainID: "ethereum", StartBlock: 0, EndBlock: 4, Size: 5},
+		{ChainID: "ethereum", StartBlock: 10, EndBlock: 19, Size: 10},
+		{ChainID: "ethereum", StartBlock: 30, EndBlock: 30, Size: 1},
+	}
+	if len(gaps) != len(want) {
+		t.Fatalf("len(gaps) = %d, want %d", len(gaps), len(want))
+	}
+	for i, gap := range gaps {
+		if *gap != want[i] {
+			t.Errorf("gaps[%d] = %v, want %v", i, gap, want[i])
+		}
+	}
+
+	if gaps := findGapsBetweenRanges("ethereum", 0, nil); len(gaps) != 0 {
+		t.Errorf("findGapsBetweenRanges(nil) = %v, want no gaps", gaps)
+	}
+}
+
+func TestFindGapsBetweenRanges_StartBlock(t *testing.T) {
+	ranges := []*models.BlockRange{
+		{Start: 0, End: 3},
+		{Start: 100, End: 109},
+		{Start: 115, End: 120},
+	}
+
+	tests := []struct {
+		name       string
+		startBlock uint64
+		want       []Gap
+	}{
+		{
+			name:       "start block at the first range",
+			startBlock: 100,
+			want:       []Gap{{ChainID: "ethereum", StartBlock: 110, EndBlock: 114, Size: 5}},
+		},
+		{
+			name:       "start block inside a range",
+			startBlock: 105,
+			want:       []Gap{{ChainID: "ethereum", StartBlock: 110, EndBlock: 114, Size: 5}},
+		},
+		{
+			name:       "start block below the first range",
+			startBlock: 90,
+			want: []Gap{
+				{ChainID: "ethereum", StartBlock: 90, EndBlock: 99, Size: 10},
+				{ChainID: "ethereum", StartBlock: 110, EndBlock: 114, Size: 5},
+			},
+		},
+		{
+			name:       "start block inside a gap",
+			startBlock: 112,
+			want:       []Gap{{ChainID: "ethereum", StartBlock: 112, EndBlock: 114, Size: 3}},
+		},
+		{
+			name:       "start block above every range",
+			startBlock: 200,
+			want:       nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gaps := findGapsBetweenRanges("ethereum", tt.startBlock, ranges)
+			if len(gaps) != len(tt.want) {
+				t.Fatalf("findGapsBetweenRanges() = %v, want %v", gaps, tt.want)
+			}
+			for i, gap := range gaps {
+				if *gap != tt.want[i] {
+					t.Errorf("gaps[%d] = %v, want %v", i, gap, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestHeadBuffer_Take(t *testing.T) {
+	head := func(number uint64, hash, parent string) *models.Block {
+		return &models.Block{Number: number, Hash: hash, ParentHash: parent}
+	}
+	numbers := func(blocks []*models.Block) []uint64 {
+		out := make([]uint64, len(blocks))
+		for i, block := range blocks {
+			out[i] = block.Number
+		}
+		return out
+	}
+
+	buffer := newHeadBuffer()
+	buffer.add(head(10, "a10", "a9"))
+	buffer.add(head(11, "a11", "a10"))
+	buffer.add(head(12, "a12", "a11"))
+
+	// A competing head at 11 replaces the buffered 11 and 12
+	buffer.add(head(11, "b11", "a10"))
+	buffer.add(head(12, "b12", "b11"))
+	buffer.add(head(13, "b13", "b12"))
+
+	got := buffer.take(10, 12)
+	if want := []uint64{10, 11, 12}; fmt.Sprint(numbers(got)) != fmt.Sprint(want) {
+		t.Fatalf("take(10, 12) = %v, want %v", numbers(got), want)
+	}
+	if got[1].Hash != "b11" || got[2].Hash != "b12" {
+		t.Errorf("take(10, 12) returned %s and %s, want the b branch", got[1].Hash, got[2].Hash)
+	}
+
+	// Taken heads are removed, the unconfirmed head stays
+	if len(buffer.heads) != 1 || buffer.heads[13] == nil {
+		t.Errorf("buffer holds %d heads after take, want only 13", len(buffer.heads))
+	}
+
+	// Heads that do not link to the newest head are left to be fetched
+	buffer = newHeadBuffer()
+	buffer.add(head(20, "c20", "c19"))
+	buffer.add(head(22, "c22", "c21"))
+	if got := buffer.take(20, 22); fmt.Sprint(numbers(got)) != "[22]" {
+		t.Errorf("take(20, 22) = %v, want [22]", numbers(got))
+	}
+
+	// Skipped heights are fine as long as the parent hashes link up
+	buffer = newHeadBuffer()
+	buffer.add(head(30, "d30", "d29"))
+	buffer.add(head(32, "d32", "d30"))
+	if got := buffer.take(30, 32); fmt.Sprint(numbers(got)) != "[30 32]" {
+		t.Errorf("take(30, 32) = %v, want [30 32]", numbers(got))
+	}
+}
+
+func TestFetchBlocks_SplitsAtMaxBlockRange(t *testing.T) {
+	config := sim.DefaultConfig()
+	config.BlockTime = 0
+	config.InitialBlocks = 20
+	config.BatchSize = 4
+
+	adapter, err := sim.NewAdapter(config)
+	if err != nil {
+		t.Fatalf("NewAdapter() error = %v", err)
+	}
+	ctx := context.Background()
+
+	// The adapter stops at MaxBlockRange blocks
+	if blocks, err := adapter.GetBlocks(ctx, 1, 10); err != nil || len(blocks) != 4 {
+		t.Fatalf("GetBlocks(1, 10) = %d blocks, %v; want 4 blocks", len(blocks), err)
+	}
+
+	blocks, err := fetchBlocks(ctx, adapter, 1, 10)
+	if err != nil {
+		t.Fatalf("fetchBlocks(1, 10) error = %v", err)
+	}
+	if len(blocks) != 10 {
+		t.Fatalf("fetchBlocks(1, 10) returned %d blocks, want 10", len(blocks))
+	}
+	for i, block := range blocks {
+		if block.Number != uint64(i+1) {
+			t.Errorf("blocks[%d].Number = %d, want %d", i, block.Number, i+1)
+		}
+	}
+}
+
+func TestGapRecovery_WithoutAdapter(t *testing.T) {
+	recovery := NewGapRecovery(nil, nil, nil, nil, nil)
+
+	if recovery.CanRecover() {
+		t.Error("CanRecover() without an adapter = true, want false")
+	}
+
+	gap := &Gap{ChainID: "ethereum", StartBlock: 10, EndBlock: 19, Size: 10}
+	if err := recovery.RecoverGap(context.Background(), gap); !errors.Is(err, service.ErrNotSupported) {
+		t.Errorf("RecoverGap() error = %v, want ErrNotSupported", err)
+	}
+	if err := recovery.RecoverAllGaps(context.Background(), "ethereum", 0); !errors.Is(err, service.ErrNotSupported) {
+		t.Errorf("RecoverAllGaps() error = %v, want ErrNotSupported", err)
+	}
+}
+
+// skippingAdapter leaves out the blocks at the skipped heights, as a Solana
+// node does for slots without a block
+type skippingAdapter struct {
+	*sim.Adapter
+	skipped map[uint64]bool
+}
+
+func (a *skippingAdapter) GetBlocks(ctx context.Context, start, end uint64) ([]*models.Block, error) {
+	blocks, err := a.Adapter.GetBlocks(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	kept := make([]*models.Block, 0, len(blocks))
+	for _, block := range blocks {
+		if !a.skipped[block.Number] {
+			kept = append(kept, block)
+		}
+	}
+	return kept, nil
+}
+
+func TestGapRecovery_SkippedHeights(t *testing.T) {
+	storage, err := pebble.NewStorage(pebble.DefaultConfig(t.TempDir()))
+	if err != nil {
+		t.Fatalf("NewStorage() error = %v", err)
+	}
+	defer storage.Close()
+
+	log := &logger.Logger{Logger: zap.NewNop()}
+	bus := event.NewEventBus(nil, log)
+	if err := bus.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer bus.Stop()
+
+	recovered := make(chan *event.GapPayload, 2)
+	if _, err := bus.SubscribeType(event.EventTypeGapRecovered, func(evt *event.Event) {
+		recovered <- evt.Payload.(*event.GapPayload)
+	}); err != nil {
+		t.Fatalf("SubscribeType() error = %v", err)
+	}
+
+	config := sim.DefaultConfig()
+	config.BlockTime = 0
+	config.InitialBlocks = 20
+	base, err := sim.NewAdapter(config)
+	if err != nil {
+		t.Fatalf("NewAdapter() error = %v", err)
+	}
+	adapter := &skippingAdapter{Adapter: base, skipped: map[uint64]bool{4: true, 5: true, 11: true, 12: true}}
+
+	proc := processor.NewBlockProcessor(storage, storage, storage, storage, nil, bus, log, metrics.New(&metrics.Config{Enabled: false}))
+	recovery := NewGapRecovery(adapter, storage, proc, bus, log)
+
+	ctx := context.Background()
+	chainID := config.ChainID
+
+	// A gap without blocks is closed but not reported as recovered
+	if err := recovery.RecoverGap(ctx, &Gap{ChainID: chainID, StartBlock: 11, EndBlock: 12, Size: 2}); err != nil {
+		t.Fatalf("RecoverGap(11-12) error = %v", err)
+	}
+	if err := recovery.RecoverGap(ctx, &Gap{ChainID: chainID, StartBlock: 1, EndBlock: 10, Size: 10}); err != nil {
+		t.Fatalf("RecoverGap(1-10) error = %v", err)
+	}
+
+	if _, err := storage.GetBlock(ctx, chainID, 4); !errors.Is(err, repository.ErrBlockNotFound) {
+		t.Errorf("GetBlock(4) error = %v, want ErrBlockNotFound", err)
+	}
+	if _, err := storage.GetBlock(ctx, chainID, 10); err != nil {
+		t.Errorf("GetBlock(10) error = %v", err)
+	}
+
+	// The skipped heights do not turn into gaps
+	gaps, err := recovery.ListGaps(ctx, chainID, 1)
+	if err != nil {
+		t.Fatalf("ListGaps() error = %v", err)
+	}
+	if len(gaps) != 0 {
+		t.Errorf("ListGaps() = %v, want no gaps", gaps)
+	}
+
+	select {
+	case gap := <-recovered:
+		if gap.StartBlock != 1 || gap.EndBlock != 10 {
+			t.Errorf("gap.recovered for %d-%d, want 1-10", gap.StartBlock, gap.EndBlock)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no gap.recovered event published")
+	}
+	select {
+	case gap := <-recovered:
+		t.Errorf("gap.recovered published for %d-%d, want only 1-10", gap.StartBlock, gap.EndBlock)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestBlockIndexer_RefreshFinality(t *testing.T) {
+	config := sim.DefaultConfig()
+	config.BlockTime = 0
+	config.InitialBlocks = 20
+	config.Faults.ReorgDepth = 3
+
+	adapter, err := sim.NewAdapter(config)
+	if err != nil {
+		t.Fatalf("NewAdapter() error = %v", err)
+	}
+
+	b := &BlockIndexer{
+		adapter: adapter,
+		config:  DefaultBlockIndexerConfig(config.ChainID),
+		logger:  &logger.Logger{Logger: zap.NewNop()},
+	}
+
+	heads, err := b.refreshFinality(context.Background())
+	if err != nil {
+		t.Fatalf("refreshFinality() error = %v", err)
+	}
+	if heads.Safe != 17 || heads.Finalized != 17 {
+		t.Fatalf("refreshFinality() = safe %d, finalized %d, want 17, 17", heads.Safe, heads.Finalized)
+	}
+
+	finalized := models.NewBlock(models.ChainTypeEVM, config.ChainID, 17, "0x17")
+	pending := models.NewBlock(models.ChainTypeEVM, config.ChainID, 18, "0x18")
+	b.markFinality(finalized, pending, nil)
+	if finalized.Finality != models.FinalityFinalized || pending.Finality != models.FinalityPending {
+		t.Errorf("markFinality() = %q, %q, want finalized, pending", finalized.Finality, pending.Finality)
+	}
+
+	// Pending blocks are indexed without waiting for confirmations
+	if depth := b.confirmationDepth(); depth != b.config.ConfirmationBlocks {
+		t.Errorf("confirmationDepth() = %d, want %d", depth, b.config.ConfirmationBlocks)
+	}
+	b.config.IndexPending = true
+	if depth := b.confirmationDepth(); depth != 0 {
+		t.Errorf("confirmationDepth() with IndexPending = %d, want 0", depth)
+	}
+}
+
+func TestRateMeter_Observe(t *testing.T) {
+	var meter rateMeter
+	start := time.Now()
+
+	if rate := meter.observe(start, 100); rate != 0 {
+		t.Errorf("observe() first sample = %v, want 0", rate)
+	}
+	if rate := meter.observe(start.Add(10*time.Second), 150); rate != 5 {
+		t.Errorf("observe() after 10s = %v, want 5", rate)
+	}
+	if rate := meter.observe(start.Add(20*time.Second), 300); rate != 10 {
+		t.Errorf("observe() after 20s = %v, want 10", rate)
+	}
+
+	// Samples older than the window no longer count
+	if rate := meter.observe(start.Add(rateWindow+15*time.Second), 300); rate != 0 {
+		t.Errorf("observe() after the window = %v, want 0", rate)
+	}
+
+	// A rollback starts over
+	if rate := meter.observe(start.Add(rateWindow+20*time.Second), 200); rate != 0 {
+		t.Errorf("observe() after a rollback = %v, want 0", rate)
+	}
+}
+
+// memChainRepo is an in-memory ChainRepository
+type memChainRepo struct {
+	repository.ChainRepository
+
+	mu     sync.Mutex
+	chains map[string]models.Chain
+}
+
+func newMemChainRepo() *memChainRepo {
+	return &memChainRepo{chains: make(map[string]models.Chain)}
+}
+
+func (r *memChainRepo) GetChain(ctx context.Context, chainID string) (*models.Chain, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	chain, ok := r.chains[chainID]
+	if !ok {
+		return nil, repository.ErrChainNotFound
+	}
+	return &chain, nil
+}
+
+func (r *memChainRepo) GetEnabledChains(ctx context.Context) ([]*models.Chain, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	chains := make([]*models.Chain, 0, len(r.chains))
+	for _, chain := range r.chains {
+		if chain.Enabled {
+			chain := chain
+			chains = append(chains, &chain)
+		}
+	}
+	return chains, nil
+}
+
+func (r *memChainRepo) SaveChain(ctx context.Context, chain *models.Chain) error {
+	if err := chain.Validate(); err != nil {
+		return fmt.Errorf("invalid chain: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.chains[chain.ChainID] = *chain
+	return nil
+}
+
+func (r *memChainRepo) UpdateChain(ctx context.Context, chain *models.Chain) error {
+	return r.SaveChain(ctx, chain)
+}
+
+func (r *memChainRepo) DeleteChain(ctx context.Context, chainID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.chains, chainID)
+	return nil
+}
+
+func (r *memChainRepo) UpdateChainStatus(ctx context.Context, chainID string, status models.ChainStatus) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	chain, ok := r.chains[chainID]
+	if !ok {
+		return repository.ErrChainNotFound
+	}
+	chain.Status = status
+	r.chains[chainID] = chain
+	return nil
+}
+
+// waitStatus waits until the orchestrator reports status for the chain
+func waitStatus(t *testing.T, o *Orchestrator, chainID string, status models.ChainStatus) *service.IndexerStatus {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		got, err := o.GetStatus(chainID)
+		if err != nil {
+			t.Fatalf("GetStatus() error = %v", err)
+		}
+		if got.Status == status {
+			return got
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("GetStatus() status = %s, want %s", got.Status, status)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestOrchestrator_Lifecycle(t *testing.T) {
+	repo := newMemChainRepo()
+	log := &logger.Logger{Logger: zap.NewNop()}
+
+	// The first attempts fail, then the chain indexes the simulated chain
+	var attempts int
+	var attemptsMu sync.Mutex
+	factory := func(chain *models.Chain) (*BlockIndexer, error) {
+		attemptsMu.Lock()
+		defer attemptsMu.Unlock()
+
+		attempts++
+		if attempts <= 2 {
+			return nil, fmt.Errorf("node unreachable")
+		}
+
+		config := sim.DefaultConfig()
+		config.ChainID = chain.ChainID
+		config.BlockTime = 0
+		adapter, err := sim.NewAdapter(config)
+		if err != nil {
+			return nil, err
+		}
+
+		indexerConfig := DefaultBlockIndexerConfig(chain.ChainID)
+		indexerConfig.PollInterval = time.Hour
+		return NewBlockIndexer(adapter, nil, nil, nil, nil, nil, indexerConfig, log), nil
+	}
+
+	o := NewOrchestrator(repo, factory, &OrchestratorConfig{
+		MinBackoff:    time.Millisecond,
+		MaxBackoff:    10 * time.Millisecond,
+		StatusTimeout: time.Second,
+	}, log)
+
+	ctx := context.Background()
+	chain := models.NewChain(models.ChainTypeSim, "sim-1", "Sim")
+	if err := o.AddChain(ctx, chain); err != nil {
+		t.Fatalf("AddChain() error = %v", err)
+	}
+	if err := o.AddChain(ctx, chain); !errors.Is(err, repository.ErrAlreadyExists) {
+		t.Errorf("AddChain() twice error = %v, want ErrAlreadyExists", err)
+	}
+
+	if err := o.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer o.Stop(ctx)
+
+	// Failed starts are retried with backoff
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		status, err := o.GetStatus("sim-1")
+		if err != nil {
+			t.Fatalf("GetStatus() error = %v", err)
+		}
+		if status.Status == models.ChainStatusSyncing && status.LastError != "" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("GetStatus() = %s, %q, want syncing after failed starts", status.Status, status.LastError)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	stored, err := repo.GetChain(ctx, "sim-1")
+	if err != nil {
+		t.Fatalf("GetChain() error = %v", err)
+	}
+	if !stored.Enabled || stored.Capabilities == nil {
+		t.Errorf("stored chain enabled = %v, capabilities = %v, want enabled with capabilities", stored.Enabled, stored.Capabilities)
+	}
+
+	// Paused chains stay paused across restarts
+	if err := o.PauseChain(ctx, "sim-1"); err != nil {
+		t.Fatalf("PauseChain() error = %v", err)
+	}
+	waitStatus(t, o, "sim-1", models.ChainStatusPaused)
+	if stored, _ := repo.GetChain(ctx, "sim-1"); stored.Status != models.ChainStatusPaused {
+		t.Errorf("stored status = %s, want paused", stored.Status)
+	}
+
+	if err := o.Stop(ctx); err != nil {
+		t.Fatalf("Stop() error = %v", err)
+	}
+
+	restarted := NewOrchestrator(repo, factory, nil, log)
+	if err := restarted.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer restarted.Stop(ctx)
+	waitStatus(t, restarted, "sim-1", models.ChainStatusPaused)
+
+	if err := restarted.ResumeChain(ctx, "sim-1"); err != nil {
+		t.Fatalf("ResumeChain() error = %v", err)
+	}
+	waitStatus(t, restarted, "sim-1", models.ChainStatusSyncing)
+
+	if err := restarted.RemoveChain(ctx, "sim-1"); err != nil {
+		t.Fatalf("RemoveChain() error = %v", err)
+	}
+	if _, err := repo.GetChain(ctx, "sim-1"); !errors.Is(err, repository.ErrChainNotFound) {
+		t.Errorf("GetChain() after RemoveChain error = %v, want ErrChainNotFound", err)
+	}
+	if _, err := restarted.GetStatus("sim-1"); !errors.Is(err, repository.ErrChainNotFound) {
+		t.Errorf("GetStatus() after RemoveChain error = %v, want ErrChainNotFound", err)
+	}
+}
+
+func TestOrchestrator_AddSimChainToStorage(t *testing.T) {
+	storage, err := pebble.NewStorage(pebble.DefaultConfig(t.TempDir()))
+	if err != nil {
+		t.Fatalf("NewStorage() error = %v", err)
+	}
+	defer storage.Close()
+
+	log := &logger.Logger{Logger: zap.NewNop()}
+	factory := func(chain *models.Chain) (*BlockIndexer, error) {
+		config := sim.DefaultConfig()
+		config.ChainID = chain.ChainID
+		config.BlockTime = 0
+		adapter, err := sim.NewAdapter(config)
+		if err != nil {
+			return nil, err
+		}
+
+		indexerConfig := DefaultBlockIndexerConfig(chain.ChainID)
+		indexerConfig.PollInterval = time.Hour
+		return NewBlockIndexer(adapter, nil, nil, nil, nil, nil, indexerConfig, log), nil
+	}
+
+	o := NewOrchestrator(storage, factory, nil, log)
+	ctx := context.Background()
+
+	// The simulated chain has no RPC endpoints
+	chain := models.NewChain(models.ChainTypeSim, "sim-1", "Sim")
+	if err := o.AddChain(ctx, chain); err != nil {
+		t.Fatalf("AddChain() error = %v", err)
+	}
+
+	if err := o.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer o.Stop(ctx)
+	waitStatus(t, o, "sim-1", models.ChainStatusSyncing)
+
+	if _, err := storage.GetChain(ctx, "sim-1"); err != nil {
+		t.Errorf("GetChain() error = %v", err)
+	}
+}
+
+func TestOrchestrator_AddChainWithoutEndpoints(t *testing.T) {
+	storage, err := pebble.NewStorage(pebble.DefaultConfig(t.TempDir()))
+	if err != nil {
+		t.Fatalf("NewStorage() error = %v", err)
+	}
+	defer storage.Close()
+
+	factory := func(chain *models.Chain) (*BlockIndexer, error) {
+		t.Fatalf("factory called for %s", chain.ChainID)
+		return nil, nil
+	}
+	o := NewOrchestrator(storage, factory, nil, &logger.Logger{Logger: zap.NewNop()})
+	ctx := context.Background()
+
+	chain := models.NewChain(models.ChainTypeEVM, "eth-1", "Ethereum")
+	if err := o.AddChain(ctx, chain); !errors.Is(err, models.ErrInvalidChain) {
+		t.Fatalf("AddChain() error = %v, want %v", err, models.ErrInvalidChain)
+	}
+	if _, err := storage.GetChain(ctx, "eth-1"); !errors.Is(err, repository.ErrChainNotFound) {
+		t.Errorf("GetChain() error = %v, want %v", err, repository.ErrChainNotFound)
+	}
+}
+
+// failingBatches fails to commit the next failures batches it creates
+type failingBatches struct {
+	repository.BatchProvider
+	failures atomic.Int32
+}
+
+func (f *failingBatches) NewBatch() repository.Batch {
+	return &failingBatch{Batch: f.BatchProvider.NewBatch(), fail: f.failures.Add(-1) >= 0}
+}
+
+type failingBatch struct {
+	repository.Batch
+	fail bool
+}
+
+func (b *failingBatch) Commit() error {
+	if b.fail {
+		return errors.New("disk full")
+	}
+	return b.Batch.Commit()
+}
+
+func TestBlockIndexer_ReindexBlockKeepsStoredBlock(t *testing.T) {
+	storage, err := pebble.NewStorage(pebble.DefaultConfig(t.TempDir()))
+	if err != nil {
+		t.Fatalf("NewStorage() error = %v", err)
+	}
+	defer storage.Close()
+
+	log := &logger.Logger{Logger: zap.NewNop()}
+	batches := &failingBatches{BatchProvider: storage}
+	proc := processor.NewBlockProcessor(storage, storage, storage, batches, nil, nil, log, metrics.New(&metrics.Config{Enabled: false}))
+
+	config := sim.DefaultConfig()
+	config.BlockTime = 0
+	config.MinTxsPerBlock = 1
+	adapter, err := sim.NewAdapter(config)
+	if err != nil {
+		t.Fatalf("NewAdapter() error = %v", err)
+	}
+	b := NewBlockIndexer(adapter, proc, nil, nil, nil, nil, DefaultBlockIndexerConfig(config.ChainID), log)
+
+	ctx := context.Background()
+	if err := b.IndexBlock(ctx, 5); err != nil {
+		t.Fatalf("IndexBlock() error = %v", err)
+	}
+	stored, err := storage.GetBlock(ctx, config.ChainID, 5)
+	if err != nil {
+		t.Fatalf("GetBlock() error = %v", err)
+	}
+
+	// A block that cannot be stored leaves the stored one in place
+	batches.failures.Store(1)
+	if err := b.ReindexBlock(ctx, 5); err == nil {
+		t.Fatal("ReindexBlock() error = nil, want the commit error")
+	}
+
+	kept, err := storage.GetBlock(ctx, config.ChainID, 5)
+	if err != nil {
+		t.Fatalf("GetBlock() after a failed reindex error = %v", err)
+	}
+	if kept.Hash != stored.Hash {
+		t.Errorf("kept block hash = %s, want %s", kept.Hash, stored.Hash)
+	}
+	txs, err := storage.GetTransactionsByBlock(ctx, config.ChainID, 5)
+	if err != nil {
+		t.Fatalf("GetTransactionsByBlock() error = %v", err)
+	}
+	if len(txs) != stored.TxCount || len(txs) == 0 {
+		t.Errorf("kept %d transactions, want %d", len(txs), stored.TxCount)
+	}
+
+	if err := b.ReindexBlock(ctx, 5); err != nil {
+		t.Fatalf("ReindexBlock() error = %v", err)
+	}
+}
+
+func TestBlockIndexer_HandlesReorg(t *testing.T) {
+	storage, err := pebble.NewStorage(pebble.DefaultConfig(t.TempDir()))
+	if err != nil {
+		t.Fatalf("NewStorage() error = %v", err)
+	}
+	defer storage.Close()
+
+	log := &logger.Logger{Logger: zap.NewNop()}
+	bus := event.NewEventBus(nil, log)
+	if err := bus.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer bus.Stop()
+
+	reorgs := make(chan *event.ReorgPayload, 1)
+	if _, err := bus.SubscribeType(event.EventTypeChainReorg, func(evt *event.Event) {
+		reorgs <- evt.Payload.(*event.ReorgPayload)
+	}); err != nil {
+		t.Fatalf("SubscribeType() error = %v", err)
+	}
+
+	config := sim.DefaultConfig()
+	config.BlockTime = 0
+	config.InitialBlocks = 20
+	config.MinTxsPerBlock = 1
+	config.Faults.ReorgDepth = 3
+	adapter, err := sim.NewAdapter(config)
+	if err != nil {
+		t.Fatalf("NewAdapter() error = %v", err)
+	}
+
+	proc := processor.NewBlockProcessor(storage, storage, storage, storage, nil, bus, log, metrics.New(&metrics.Config{Enabled: false}))
+	handler := NewReorgHandler(adapter, storage, proc, bus, log, 0)
+	b := NewBlockIndexer(adapter, proc, nil, nil, handler, nil, DefaultBlockIndexerConfig(config.ChainID), log)
+
+	ctx := context.Background()
+	if err := b.IndexRange(ctx, 1, 20); err != nil {
+		t.Fatalf("IndexRange() error = %v", err)
+	}
+
+	orphaned := make([]*models.Block, 0)
+	for number := uint64(18); number <= 20; number++ {
+		block, err := storage.GetBlock(ctx, config.ChainID, number)
+		if err != nil {
+			t.Fatalf("GetBlock(%d) error = %v", number, err)
+		}
+		if block.Transactions, err = storage.GetTransactionsByBlock(ctx, config.ChainID, number); err != nil {
+			t.Fatalf("GetTransactionsByBlock(%d) error = %v", number, err)
+		}
+		orphaned = append(orphaned, block)
+	}
+
+	// Replace blocks 18-20 and mine a block whose parent the store lacks
+	adapter.Reorg(config.Faults.ReorgDepth)
+	adapter.Mine(1)
+	if err := b.IndexBlock(ctx, 21); err != nil {
+		t.Fatalf("IndexBlock(21) error = %v", err)
+	}
+
+	canonicalTxs := make(map[string]bool)
+	for number := uint64(18); number <= 21; number++ {
+		canonical, err := adapter.GetBlockByNumber(ctx, number)
+		if err != nil {
+			t.Fatalf("adapter GetBlockByNumber(%d) error = %v", number, err)
+		}
+		stored, err := storage.GetBlock(ctx, config.ChainID, number)
+		if err != nil {
+			t.Fatalf("GetBlock(%d) error = %v", number, err)
+		}
+		if stored.Hash != canonical.Hash {
+			t.Errorf("stored block %d = %s, want canonical %s", number, stored.Hash, canonical.Hash)
+		}
+
+		txs, err := storage.GetTransactionsByBlock(ctx, config.ChainID, number)
+		if err != nil {
+			t.Fatalf("GetTransactionsByBlock(%d) error = %v", number, err)
+		}
+		if len(txs) != len(canonical.Transactions) {
+			t.Errorf("block %d has %d stored transactions, want %d", number, len(txs), len(canonical.Transactions))
+		}
+		for _, tx := range canonical.Transactions {
+			canonicalTxs[tx.Hash] = true
+		}
+	}
+
+	for _, block := range orphaned {
+		if _, err := storage.GetBlockByHash(ctx, config.ChainID, block.Hash); !errors.Is(err, repository.ErrBlockNotFound) {
+			t.Errorf("GetBlockByHash(orphaned %d) error = %v, want ErrBlockNotFound", block.Number, err)
+		}
+		for _, tx := range block.Transactions {
+			if canonicalTxs[tx.Hash] {
+				continue
+			}
+			if _, err := storage.GetTransaction(ctx, config.ChainID, tx.Hash); !errors.Is(err, repository.ErrTransactionNotFound) {
+				t.Errorf("GetTransaction(orphaned %s) error = %v, want ErrTransactionNotFound", tx.Hash, err)
+			}
+		}
+	}
+
+	select {
+	case reorg := <-reorgs:
+		if reorg.CommonAncestor != 17 || reorg.Depth != 3 || reorg.StartBlock != 18 || reorg.EndBlock != 20 {
+			t.Errorf("reorg = ancestor %d, depth %d, blocks %d-%d, want 17, 3, 18-20",
+				reorg.CommonAncestor, reorg.Depth, reorg.StartBlock, reorg.EndBlock)
+		}
+		if reorg.OldHeadHash != orphaned[2].Hash {
+			t.Errorf("reorg old head = %s, want %s", reorg.OldHeadHash, orphaned[2].Hash)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no chain.reorg event published")
+	}
+}
+
+func TestOperationTracker_Watch(t *testing.T) {
+	tracker := NewOperationTracker(&OperationTrackerConfig{MaxFinished: 1}, &logger.Logger{Logger: zap.NewNop()})
+	defer tracker.Close()
+
+	release := make(chan struct{})
+	op := tracker.Start(OperationReindexRange, "test-chain", func(ctx context.Context, progress ProgressFunc) error {
+		<-release
+		for done := uint64(1); done <= 3; done++ {
+			progress(done, 3)
+		}
+		return errors.New("rpc down")
+	})
+	if op.State != OperationRunning {
+		t.Fatalf("State = %s, want %s", op.State, OperationRunning)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	updates, err := tracker.Watch(ctx, op.ID)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	if first := <-updates; first.Finished() {
+		t.Fatalf("first update finished: %+v", first)
+	}
+	close(release)
+
+	var last *Operation
+	for update := range updates {
+		if last != nil && update.Done < last.Done {
+			t.Errorf("Done went back from %d to %d", last.Done, update.Done)
+		}
+		last = update
+	}
+	if last == nil || last.State != OperationFailed || last.Error != "rpc down" || last.Done != 3 || last.Total != 3 {
+		t.Fatalf("last update = %+v, want failed after 3/3", last)
+	}
+
+	if got, err := tracker.Get(op.ID); err != nil || got.FinishedAt == nil {
+		t.Errorf("Get() = %+v, %v, want finished operation", got, err)
+	}
+
+	// Only the newest finished operation is kept
+	second := tracker.Start(OperationRecoverGaps, "other-chain", func(ctx context.Context, progress ProgressFunc) error {
+		return nil
+	})
+	updates, err = tracker.Watch(ctx, second.ID)
+	if err != nil {
+		t.Fatalf("Watch() error = %v", err)
+	}
+	for range updates {
+	}
+
+	if _, err := tracker.Get(op.ID); !errors.Is(err, ErrOperationNotFound) {
+		t.Errorf("Get() of the oldest operation error = %v, want %v", err, ErrOperationNotFound)
+	}
+	if ops := tracker.List(""); len(ops) != 1 || ops[0].ID != second.ID || ops[0].State != OperationSucceeded {
+		t.Errorf("List() = %+v, want the second operation only", ops)
+	}
+}
@@ -0,0 +1,303 @@
+package indexer
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// ErrOperationNotFound is returned for an unknown operation ID
+var ErrOperationNotFound = errors.New("operation not found")
+
+// OperationKind is the kind of a long-running operation
+type OperationKind string
+
+const (
+	// OperationReindexRange reindexes a range of blocks
+	OperationReindexRange OperationKind = "reindex_range"
+
+	// OperationRecoverGaps indexes the missing blocks below the highest
+	// indexed one
+	OperationRecoverGaps OperationKind = "recover_gaps"
+)
+
+// OperationState is the state of a long-running operation
+type OperationState string
+
+const (
+	// OperationRunning marks an operation that has not finished yet
+	OperationRunning OperationState = "running"
+
+	// OperationSucceeded marks an operation that finished without error
+	OperationSucceeded OperationState = "succeeded"
+
+	// OperationFailed marks an operation that stopped with an error
+	OperationFailed OperationState = "failed"
+)
+
+// ProgressFunc reports how many of the total units of work are done
+type ProgressFunc func(done, total uint64)
+
+// Operation is a snapshot of a long-running operation
+type Operation struct {
+	ID         string         `json:"id"`
+	Kind       OperationKind  `json:"kind"`
+	ChainID    string         `json:"chain_id"`
+	State      OperationState `json:"state"`
+	Done       uint64         `json:"done"`
+	Total      uint64         `json:"total"`
+	Error      string         `json:"error,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	FinishedAt *time.Time     `json:"finished_at,omitempty"`
+}
+
+// Finished returns true if the operation succeeded or failed
+func (op *Operation) Finished() bool {
+	return op.State != OperationRunning
+}
+
+// OperationTrackerConfig holds operation tracker configuration
+type OperationTrackerConfig struct {
+	// MaxFinished is how many finished operations are kept to be polled,
+	// the oldest ones are forgotten first
+	MaxFinished int
+}
+
+// DefaultOperationTrackerConfig returns default configuration
+func DefaultOperationTrackerConfig() *OperationTrackerConfig {
+	return &OperationTrackerConfig{
+		MaxFinished: 100,
+	}
+}
+
+// OperationTracker runs long-running operations in the background and keeps
+// their progress, so that callers can poll or watch them by ID
+type OperationTracker struct {
+	config *OperationTrackerConfig
+	logger *logger.Logger
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu         sync.Mutex
+	operations map[string]*trackedOperation
+}
+
+// trackedOperation is an operation and the channel that is closed and
+// replaced every time it changes
+type trackedOperation struct {
+	op      Operation
+	changed chan struct{}
+}
+
+// NewOperationTracker creates a new operation tracker
+func NewOperationTracker(config *OperationTrackerConfig, logger *logger.Logger) *OperationTracker {
+	if config == nil {
+		config = DefaultOperationTrackerConfig()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &OperationTracker{
+		config:     config,
+		logger:     logger,
+		ctx:        ctx,
+		cancel:     cancel,
+		operations: make(map[string]*trackedOperation),
+	}
+}
+
+// Start runs fn in the background as a new operation and returns its first
+// snapshot. fn reports its progress through the ProgressFunc it is given.
+func (t *OperationTracker) Start(kind OperationKind, chainID string, fn func(ctx context.Context, progress ProgressFunc) error) *Operation {
+	now := time.Now()
+	tracked := &trackedOperation{
+		op: Operation{
+			ID:        rand.Text(),
+			Kind:      kind,
+			ChainID:   chainID,
+			State:     OperationRunning,
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		changed: make(chan struct{}),
+	}
+
+	t.mu.Lock()
+	t.operations[tracked.op.ID] = tracked
+	op := tracked.op
+	t.mu.Unlock()
+
+	t.logger.Info("operation started",
+		zap.String("operation_id", op.ID),
+		zap.String("kind", string(kind)),
+		zap.String("chain_id", chainID),
+	)
+
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+
+		err := fn(t.ctx, func(done, total uint64) {
+			t.setProgress(tracked, done, total)
+		})
+		t.finish(tracked, err)
+	}()
+
+	return &op
+}
+
+// Get returns a snapshot of an operation
+func (t *OperationTracker) Get(id string) (*Operation, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tracked, ok := t.operations[id]
+	if !ok {
+		return nil, ErrOperationNotFound
+	}
+
+	op := tracked.op
+	return &op, nil
+}
+
+// List returns snapshots of the operations of a chain, or of every chain
+// when chainID is empty, newest first
+func (t *OperationTracker) List(chainID string) []*Operation {
+	t.mu.Lock()
+	ops := make([]*Operation, 0, len(t.operations))
+	for _, tracked := range t.operations {
+		if chainID == "" || tracked.op.ChainID == chainID {
+			op := tracked.op
+			ops = append(ops, &op)
+		}
+	}
+	t.mu.Unlock()
+
+	sort.Slice(ops, func(i, j int) bool {
+		return ops[i].CreatedAt.After(ops[j].CreatedAt)
+	})
+
+	return ops
+}
+
+// Watch returns a channel that receives a snapshot of an operation now and
+// after it changes, and is closed once the operation finished or ctx is
+// done. Changes that happen while the receiver is busy are coalesced.
+func (t *OperationTracker) Watch(ctx context.Context, id string) (<-chan *Operation, error) {
+	t.mu.Lock()
+	tracked, ok := t.operations[id]
+	t.mu.Unlock()
+	if !ok {
+		return nil, ErrOperationNotFound
+	}
+
+	updates := make(chan *Operation)
+	go func() {
+		defer close(updates)
+
+		for {
+			t.mu.Lock()
+			op := tracked.op
+			changed := tracked.changed
+			t.mu.Unlock()
+
+			select {
+			case updates <- &op:
+			case <-ctx.Done():
+				return
+			}
+
+			if op.Finished() {
+				return
+			}
+
+			select {
+			case <-changed:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return updates, nil
+}
+
+// Close stops the running operations, which fail with context.Canceled,
+// and waits for them to finish
+func (t *OperationTracker) Close() {
+	t.cancel()
+	t.wg.Wait()
+}
+
+// setProgress records the progress of an operation and wakes up its
+// watchers
+func (t *OperationTracker) setProgress(tracked *trackedOperation, done, total uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tracked.op.Done = done
+	tracked.op.Total = total
+	tracked.op.UpdatedAt = time.Now()
+
+	close(tracked.changed)
+	tracked.changed = make(chan struct{})
+}
+
+// finish records the outcome of an operation and forgets the oldest
+// finished operations beyond MaxFinished
+func (t *OperationTracker) finish(tracked *trackedOperation, err error) {
+	t.mu.Lock()
+	finishedAt := time.Now()
+	tracked.op.FinishedAt = &finishedAt
+	tracked.op.UpdatedAt = finishedAt
+	tracked.op.State = OperationSucceeded
+	if err != nil {
+		tracked.op.State = OperationFailed
+		tracked.op.Error = err.Error()
+	}
+	op := tracked.op
+	t.prune()
+	close(tracked.changed)
+	t.mu.Unlock()
+
+	fields := []zap.Field{
+		zap.String("operation_id", op.ID),
+		zap.String("kind", string(op.Kind)),
+		zap.String("chain_id", op.ChainID),
+	}
+	if err != nil {
+		t.logger.Error("operation failed", append(fields, zap.Error(err))...)
+	} else {
+		t.logger.Info("operation succeeded", fields...)
+	}
+}
+
+// prune forgets the oldest finished operations beyond MaxFinished. The
+// caller holds mu.
+func (t *OperationTracker) prune() {
+	finished := make([]*trackedOperation, 0, len(t.operations))
+	for _, tracked := range t.operations {
+		if tracked.op.Finished() {
+			finished = append(finished, tracked)
+		}
+	}
+	if len(finished) <= t.config.MaxFinished {
+		return
+	}
+
+	sort.Slice(finished, func(i, j int) bool {
+		return finished[i].op.FinishedAt.Before(*finished[j].op.FinishedAt)
+	})
+	for _, old := range finished[:len(finished)-t.config.MaxFinished] {
+		delete(t.operations, old.op.ID)
+	}
+}
@@ -0,0 +1,757 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/repository"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/service"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// Orchestrator implements service.Indexer
+var _ service.Indexer = (*Orchestrator)(nil)
+
+// ChainIndexerFactory builds the indexer of a chain around an adapter that
+// is not connected yet. It is called again every time the chain restarts.
+type ChainIndexerFactory func(chain *models.Chain) (*BlockIndexer, error)
+
+// OrchestratorConfig holds orchestrator configuration
+type OrchestratorConfig struct {
+	// Chains limits the chains Start loads from the repository to these
+	// IDs, every enabled chain when empty
+	Chains []string
+
+	// MinBackoff is the wait before a failed chain is restarted. It doubles
+	// with every failure in a row up to MaxBackoff, and starts over once the
+	// chain ran longer than MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// StatusTimeout bounds the RPC and storage calls of a status report
+	StatusTimeout time.Duration
+
+	// LiveThreshold is how many blocks behind the chain head a syncing
+	// chain may be to be reported live
+	LiveThreshold uint64
+}
+
+// DefaultOrchestratorConfig returns default configuration
+func DefaultOrchestratorConfig() *OrchestratorConfig {
+	return &OrchestratorConfig{
+		MinBackoff:    time.Second,
+		MaxBackoff:    5 * time.Minute,
+		StatusTimeout: 10 * time.Second,
+		LiveThreshold: 10,
+	}
+}
+
+// Orchestrator runs a BlockIndexer for every chain it manages. Chains can be
+// added, removed, paused and resumed while it runs, and their state is kept
+// in the chain repository so that it survives restarts. A chain whose
+// indexer fails is restarted with exponential backoff without affecting
+// the others.
+type Orchestrator struct {
+	chainRepo repository.ChainRepository
+	factory   ChainIndexerFactory
+	config    *OrchestratorConfig
+	logger    *logger.Logger
+
+	mu      sync.RWMutex
+	running bool
+	ctx     context.Context
+	cancel  context.CancelFunc
+	chains  map[string]*chainRunner
+}
+
+// chainRunner supervises the indexer of one chain
+type chainRunner struct {
+	chainID string
+
+	// cancel stops the supervisor and done is closed once it exited, both
+	// nil while the chain is not running
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	// indexer is the running indexer, nil between restarts
+	indexer *BlockIndexer
+
+	status   models.ChainStatus
+	restarts int
+	lastErr  error
+}
+
+// NewOrchestrator creates a new orchestrator
+func NewOrchestrator(
+	chainRepo repository.ChainRepository,
+	factory ChainIndexerFactory,
+	config *OrchestratorConfig,
+	logger *logger.Logger,
+) *Orchestrator {
+	if config == nil {
+		config = DefaultOrchestratorConfig()
+	}
+
+	return &Orchestrator{
+		chainRepo: chainRepo,
+		factory:   factory,
+		config:    config,
+		logger:    logger,
+		chains:    make(map[string]*chainRunner),
+	}
+}
+
+// Start loads the enabled chains from the repository and starts indexing
+// every chain that is not paused, including chains added before Start
+func (o *Orchestrator) Start(ctx context.Context) error {
+	chains, err := o.chainRepo.GetEnabledChains(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load chains: %w", err)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.running {
+		return service.ErrIndexerAlreadyRunning
+	}
+	o.running = true
+	o.ctx, o.cancel = context.WithCancel(ctx)
+
+	for _, chain := range chains {
+		if !o.selected(chain.ChainID) {
+			continue
+		}
+		if _, ok := o.chains[chain.ChainID]; !ok {
+			o.chains[chain.ChainID] = &chainRunner{chainID: chain.ChainID, status: chain.Status}
+		}
+	}
+
+	for _, r := range o.chains {
+		if r.status != models.ChainStatusPaused {
+			o.startRunner(r)
+		}
+	}
+
+	o.logger.Info("orchestrator started", zap.Int("chain_count", len(o.chains)))
+
+	return nil
+}
+
+// Stop stops every chain and waits for their indexers to stop until ctx is
+// done. The chains keep their state for the next Start.
+func (o *Orchestrator) Stop(ctx context.Context) error {
+	o.mu.Lock()
+	if !o.running {
+		o.mu.Unlock()
+		return service.ErrIndexerNotRunning
+	}
+	o.running = false
+	o.cancel()
+
+	pending := make([]chan struct{}, 0, len(o.chains))
+	for _, r := range o.chains {
+		if r.done != nil {
+			pending = append(pending, r.done)
+		}
+		r.cancel = nil
+		r.done = nil
+	}
+	o.mu.Unlock()
+
+	for _, done := range pending {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	o.logger.Info("orchestrator stopped")
+
+	return nil
+}
+
+// IsRunning returns true if the orchestrator is running
+func (o *Orchestrator) IsRunning() bool {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	return o.running
+}
+
+// AddChain adds a chain and starts indexing it if the orchestrator runs. A
+// chain that was indexed before keeps its progress and a paused chain stays
+// paused.
+func (o *Orchestrator) AddChain(ctx context.Context, chain *models.Chain) error {
+	if chain == nil {
+		return fmt.Errorf("chain is nil")
+	}
+	if err := chain.Validate(); err != nil {
+		return fmt.Errorf("%w: %w", models.ErrInvalidChain, err)
+	}
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if _, ok := o.chains[chain.ChainID]; ok {
+		return fmt.Errorf("chain %s: %w", chain.ChainID, repository.ErrAlreadyExists)
+	}
+
+	stored, err := o.chainRepo.GetChain(ctx, chain.ChainID)
+	switch {
+	case err == nil && stored != nil:
+		keepChainState(chain, stored)
+	case err != nil && !errors.Is(err, repository.ErrChainNotFound):
+		return fmt.Errorf("failed to get chain: %w", err)
+	}
+
+	chain.Enabled = true
+	if err := o.chainRepo.SaveChain(ctx, chain); err != nil {
+		return fmt.Errorf("failed to save chain: %w", err)
+	}
+
+	r := &chainRunner{chainID: chain.ChainID, status: chain.Status}
+	o.chains[chain.ChainID] = r
+
+	if o.running && r.status != models.ChainStatusPaused {
+		o.startRunner(r)
+	}
+
+	o.logger.Info("chain added",
+		zap.String("chain_id", chain.ChainID),
+		zap.String("chain_type", string(chain.ChainType)),
+		zap.String("status", string(chain.Status)),
+	)
+
+	return nil
+}
+
+// RemoveChain stops indexing a chain and deletes it from the repository.
+// Its indexed blocks are kept.
+func (o *Orchestrator) RemoveChain(ctx context.Context, chainID string) error {
+	o.mu.Lock()
+	r, ok := o.chains[chainID]
+	if !ok {
+		o.mu.Unlock()
+		return fmt.Errorf("chain %s: %w", chainID, repository.ErrChainNotFound)
+	}
+	delete(o.chains, chainID)
+	done := o.stopRunner(r)
+	o.mu.Unlock()
+
+	if err := waitDone(ctx, done); err != nil {
+		return err
+	}
+
+	if err := o.chainRepo.DeleteChain(ctx, chainID); err != nil && !errors.Is(err, repository.ErrChainNotFound) {
+		return fmt.Errorf("failed to delete chain: %w", err)
+	}
+
+	o.logger.Info("chain removed", zap.String("chain_id", chainID))
+
+	return nil
+}
+
+// PauseChain stops indexing a chain until it is resumed, also across
+// restarts. Pausing a paused chain does nothing.
+func (o *Orchestrator) PauseChain(ctx context.Context, chainID string) error {
+	o.mu.Lock()
+	r, ok := o.chains[chainID]
+	if !ok {
+		o.mu.Unlock()
+		return fmt.Errorf("chain %s: %w", chainID, repository.ErrChainNotFound)
+	}
+	if r.status == models.ChainStatusPaused {
+		o.mu.Unlock()
+		return nil
+	}
+	r.status = models.ChainStatusPaused
+	done := o.stopRunner(r)
+	o.mu.Unlock()
+
+	if err := waitDone(ctx, done); err != nil {
+		return err
+	}
+
+	if err := o.chainRepo.UpdateChainStatus(ctx, chainID, models.ChainStatusPaused); err != nil {
+		return fmt.Errorf("failed to update chain status: %w", err)
+	}
+
+	o.logger.Info("chain paused", zap.String("chain_id", chainID))
+
+	return nil
+}
+
+// ResumeChain starts indexing a paused chain again. Resuming a chain that
+// is not paused does nothing.
+func (o *Orchestrator) ResumeChain(ctx context.Context, chainID string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	r, ok := o.chains[chainID]
+	if !ok {
+		return fmt.Errorf("chain %s: %w", chainID, repository.ErrChainNotFound)
+	}
+	if r.status != models.ChainStatusPaused {
+		return nil
+	}
+
+	if err := o.chainRepo.UpdateChainStatus(ctx, chainID, models.ChainStatusSyncing); err != nil {
+		return fmt.Errorf("failed to update chain status: %w", err)
+	}
+
+	r.status = models.ChainStatusSyncing
+	r.restarts = 0
+	r.lastErr = nil
+	if o.running {
+		o.startRunner(r)
+	}
+
+	o.logger.Info("chain resumed", zap.String("chain_id", chainID))
+
+	return nil
+}
+
+// IndexBlock indexes a single block of a chain
+func (o *Orchestrator) IndexBlock(ctx context.Context, chainID string, blockNumber uint64) error {
+	return o.withIndexer(ctx, chainID, func(b *BlockIndexer) error {
+		return b.IndexBlock(ctx, blockNumber)
+	})
+}
+
+// IndexBlockRange indexes the blocks of a chain from start to end
+func (o *Orchestrator) IndexBlockRange(ctx context.Context, chainID string, start, end uint64) error {
+	return o.withIndexer(ctx, chainID, func(b *BlockIndexer) error {
+		return b.IndexRange(ctx, start, end)
+	})
+}
+
+// ReindexBlock fetches a block of a chain again and replaces the stored one
+func (o *Orchestrator) ReindexBlock(ctx context.Context, chainID string, blockNumber uint64) error {
+	return o.withIndexer(ctx, chainID, func(b *BlockIndexer) error {
+		return b.ReindexBlock(ctx, blockNumber)
+	})
+}
+
+// ReindexRange reindexes the blocks of a chain from start to end one after
+// another and reports the reindexed block count to progress
+func (o *Orchestrator) ReindexRange(ctx context.Context, chainID string, start, end uint64, progress ProgressFunc) error {
+	if start > end {
+		return fmt.Errorf("start block %d is above end block %d: %w", start, end, models.ErrInvalidBlockRange)
+	}
+
+	return o.withIndexer(ctx, chainID, func(b *BlockIndexer) error {
+		total := end - start + 1
+		progress(0, total)
+
+		for number := start; number <= end; number++ {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := b.ReindexBlock(ctx, number); err != nil {
+				return fmt.Errorf("failed to reindex block %d: %w", number, err)
+			}
+			progress(number-start+1, total)
+		}
+
+		return nil
+	})
+}
+
+// RecoverGaps indexes the missing blocks of a chain below its highest
+// indexed block and reports the recovered block count to progress. It
+// continues past gaps that fail and returns an error naming how many did.
+func (o *Orchestrator) RecoverGaps(ctx context.Context, chainID string, progress ProgressFunc) error {
+	return o.withIndexer(ctx, chainID, func(b *BlockIndexer) error {
+		if b.gapRecovery == nil || !b.gapRecovery.CanRecover() {
+			return fmt.Errorf("chain %s cannot recover gaps: %w", chainID, service.ErrNotSupported)
+		}
+
+		gaps, err := b.gapRecovery.DetectGaps(ctx, chainID, b.config.StartBlock)
+		if err != nil {
+			return fmt.Errorf("failed to detect gaps: %w", err)
+		}
+
+		total := uint64(0)
+		for _, gap := range gaps {
+			total += gap.Size
+		}
+		progress(0, total)
+
+		done := uint64(0)
+		failed := 0
+		for _, gap := range gaps {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := b.gapRecovery.RecoverGap(ctx, gap); err != nil {
+				o.logger.Error("failed to recover gap",
+					zap.String("chain_id", chainID),
+					zap.Uint64("start", gap.StartBlock),
+					zap.Uint64("end", gap.EndBlock),
+					zap.Error(err),
+				)
+				failed++
+			}
+			done += gap.Size
+			progress(done, total)
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("failed to recover %d/%d gaps", failed, len(gaps))
+		}
+
+		return nil
+	})
+}
+
+// HasChain returns true if the orchestrator manages a chain
+func (o *Orchestrator) HasChain(chainID string) bool {
+	o.mu.RLock()
+	defer o.mu.RUnlock()
+	_, ok := o.chains[chainID]
+	return ok
+}
+
+// GetStatus returns the status of a chain. The indexing rate is measured
+// over the progress reports of the last minutes.
+func (o *Orchestrator) GetStatus(chainID string) (*service.IndexerStatus, error) {
+	o.mu.RLock()
+	r, ok := o.chains[chainID]
+	var (
+		indexer *BlockIndexer
+		status  models.ChainStatus
+		lastErr error
+	)
+	if ok {
+		indexer, status, lastErr = r.indexer, r.status, r.lastErr
+	}
+	o.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("chain %s: %w", chainID, repository.ErrChainNotFound)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), o.config.StatusTimeout)
+	defer cancel()
+
+	chain, err := o.chainRepo.GetChain(ctx, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain: %w", err)
+	}
+
+	result := &service.IndexerStatus{
+		ChainID:            chainID,
+		ChainType:          chain.ChainType,
+		Status:             status,
+		LatestIndexedBlock: chain.LatestIndexedBlock,
+		LatestChainBlock:   chain.LatestChainBlock,
+		BlocksBehind:       chain.GetBlocksBehind(),
+		SyncProgress:       chain.GetSyncProgress(),
+	}
+	if lastErr != nil {
+		result.LastError = lastErr.Error()
+	}
+
+	if indexer == nil || indexer.progressTracker == nil {
+		return result, nil
+	}
+
+	progress, err := indexer.progressTracker.GetProgress(ctx, chainID)
+	if err != nil {
+		o.logger.Debug("failed to get progress, using recorded chain state",
+			zap.String("chain_id", chainID),
+			zap.Error(err),
+		)
+		return result, nil
+	}
+
+	result.LatestIndexedBlock = progress.LatestIndexedBlock
+	result.LatestChainBlock = progress.LatestChainBlock
+	result.BlocksBehind = progress.BlocksBehind
+	result.SyncProgress = progress.ProgressPercentage
+	result.BlocksPerSecond = progress.BlocksPerSecond
+	result.EstimatedTimeLeft = int64(progress.EstimatedTimeLeft.Seconds())
+
+	if status == models.ChainStatusSyncing && result.LatestChainBlock > 0 && result.BlocksBehind <= o.config.LiveThreshold {
+		result.Status = models.ChainStatusLive
+	}
+
+	return result, nil
+}
+
+// GetAllStatuses returns the status of every chain, ordered by chain ID
+func (o *Orchestrator) GetAllStatuses() ([]*service.IndexerStatus, error) {
+	o.mu.RLock()
+	chainIDs := make([]string, 0, len(o.chains))
+	for chainID := range o.chains {
+		chainIDs = append(chainIDs, chainID)
+	}
+	o.mu.RUnlock()
+
+	sort.Strings(chainIDs)
+
+	statuses := make([]*service.IndexerStatus, 0, len(chainIDs))
+	for _, chainID := range chainIDs {
+		status, err := o.GetStatus(chainID)
+		if err != nil {
+			// Removed since the IDs were listed
+			if errors.Is(err, repository.ErrChainNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+// selected returns true if Start should load the chain
+func (o *Orchestrator) selected(chainID string) bool {
+	if len(o.config.Chains) == 0 {
+		return true
+	}
+	for _, id := range o.config.Chains {
+		if id == chainID {
+			return true
+		}
+	}
+	return false
+}
+
+// startRunner starts the supervisor of a chain. The caller holds o.mu.
+func (o *Orchestrator) startRunner(r *chainRunner) {
+	if r.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(o.ctx)
+	r.cancel = cancel
+	r.done = make(chan struct{})
+	r.status = models.ChainStatusSyncing
+
+	go o.supervise(ctx, r, r.done)
+}
+
+// stopRunner stops the supervisor of a chain and returns the channel that
+// is closed once it exited, nil if it was not running. The caller holds
+// o.mu.
+func (o *Orchestrator) stopRunner(r *chainRunner) chan struct{} {
+	if r.cancel == nil {
+		return nil
+	}
+
+	r.cancel()
+	done := r.done
+	r.cancel = nil
+	r.done = nil
+
+	return done
+}
+
+// supervise runs the indexer of a chain until ctx is done, restarting it
+// with backoff whenever it fails
+func (o *Orchestrator) supervise(ctx context.Context, r *chainRunner, done chan struct{}) {
+	defer close(done)
+
+	backoff := o.config.MinBackoff
+	for {
+		started := time.Now()
+		err := o.runIndexer(ctx, r)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err == nil {
+			o.logger.Info("chain indexer finished", zap.String("chain_id", r.chainID))
+			o.setStatus(r, models.ChainStatusIdle, nil)
+			return
+		}
+
+		// A chain that ran for a while failed for a new reason
+		if time.Since(started) > o.config.MaxBackoff {
+			backoff = o.config.MinBackoff
+		}
+
+		o.mu.Lock()
+		r.restarts++
+		restarts := r.restarts
+		o.mu.Unlock()
+		o.setStatus(r, models.ChainStatusError, err)
+
+		o.logger.Warn("chain indexer failed, restarting",
+			zap.String("chain_id", r.chainID),
+			zap.Int("restarts", restarts),
+			zap.Duration("backoff", backoff),
+			zap.Error(err),
+		)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, o.config.MaxBackoff)
+	}
+}
+
+// runIndexer builds, connects and starts the indexer of a chain and waits
+// until it stops. It returns nil if ctx is done or the indexer finished its
+// range, and the error the indexer failed with otherwise.
+func (o *Orchestrator) runIndexer(ctx context.Context, r *chainRunner) error {
+	indexer, err := o.connectIndexer(ctx, r.chainID)
+	if err != nil {
+		return err
+	}
+	defer indexer.adapter.Disconnect()
+
+	if err := indexer.Start(ctx); err != nil {
+		return fmt.Errorf("failed to start indexer: %w", err)
+	}
+
+	o.mu.Lock()
+	r.indexer = indexer
+	o.mu.Unlock()
+	o.setStatus(r, models.ChainStatusSyncing, nil)
+
+	o.logger.Info("chain indexer started", zap.String("chain_id", r.chainID))
+
+	select {
+	case <-ctx.Done():
+	case <-indexer.Done():
+	}
+
+	o.mu.Lock()
+	r.indexer = nil
+	o.mu.Unlock()
+
+	if err := indexer.Stop(); err != nil {
+		o.logger.Debug("indexer already stopped",
+			zap.String("chain_id", r.chainID),
+			zap.Error(err),
+		)
+	}
+
+	if ctx.Err() != nil {
+		return nil
+	}
+
+	return indexer.Err()
+}
+
+// connectIndexer builds the indexer of a chain, connects its adapter and
+// records the adapter's capabilities on the chain
+func (o *Orchestrator) connectIndexer(ctx context.Context, chainID string) (*BlockIndexer, error) {
+	chain, err := o.chainRepo.GetChain(ctx, chainID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain: %w", err)
+	}
+
+	indexer, err := o.factory(chain)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create indexer: %w", err)
+	}
+
+	if err := indexer.adapter.Connect(ctx); err != nil {
+		return nil, fmt.Errorf("failed to connect: %w", err)
+	}
+
+	// Record what the chain supports so the API can refuse the rest
+	capabilities := indexer.adapter.Capabilities()
+	chain.Capabilities = &capabilities
+	if err := o.chainRepo.UpdateChain(ctx, chain); err != nil {
+		o.logger.Warn("failed to record chain capabilities",
+			zap.String("chain_id", chainID),
+			zap.Error(err),
+		)
+	}
+
+	return indexer, nil
+}
+
+// withIndexer calls fn with the running indexer of a chain, or with one
+// connected for the call while the chain does not run
+func (o *Orchestrator) withIndexer(ctx context.Context, chainID string, fn func(*BlockIndexer) error) error {
+	o.mu.RLock()
+	r, ok := o.chains[chainID]
+	var indexer *BlockIndexer
+	if ok {
+		indexer = r.indexer
+	}
+	o.mu.RUnlock()
+
+	if !ok {
+		return fmt.Errorf("chain %s: %w", chainID, repository.ErrChainNotFound)
+	}
+
+	if indexer == nil {
+		var err error
+		indexer, err = o.connectIndexer(ctx, chainID)
+		if err != nil {
+			return err
+		}
+		defer indexer.adapter.Disconnect()
+	}
+
+	return fn(indexer)
+}
+
+// setStatus records the status of a chain and the error that caused it
+func (o *Orchestrator) setStatus(r *chainRunner, status models.ChainStatus, err error) {
+	o.mu.Lock()
+	// A pause that raced with the indexer wins
+	if r.status == models.ChainStatusPaused {
+		o.mu.Unlock()
+		return
+	}
+	r.status = status
+	if err != nil {
+		r.lastErr = err
+	}
+	o.mu.Unlock()
+
+	if err := o.chainRepo.UpdateChainStatus(context.Background(), r.chainID, status); err != nil && !errors.Is(err, repository.ErrChainNotFound) {
+		o.logger.Warn("failed to update chain status",
+			zap.String("chain_id", r.chainID),
+			zap.String("status", string(status)),
+			zap.Error(err),
+		)
+	}
+}
+
+// waitDone waits until done is closed or ctx is done. A nil done channel is
+// closed already.
+func waitDone(ctx context.Context, done chan struct{}) error {
+	if done == nil {
+		return nil
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// keepChainState copies the indexing state of a stored chain onto the
+// chain added in its place
+func keepChainState(chain, stored *models.Chain) {
+	chain.Status = stored.Status
+	chain.LatestIndexedBlock = stored.LatestIndexedBlock
+	chain.LatestChainBlock = stored.LatestChainBlock
+	chain.SafeBlock = stored.SafeBlock
+	chain.FinalizedBlock = stored.FinalizedBlock
+	chain.LastUpdated = stored.LastUpdated
+	if chain.Capabilities == nil {
+		chain.Capabilities = stored.Capabilities
+	}
+}
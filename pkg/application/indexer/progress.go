@@ -3,6 +3,7 @@ package indexer
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/sage-x-project/blockchain-indexer/pkg/application/processor"
@@ -21,6 +22,10 @@ type ProgressTracker struct {
 	blockProcessor *processor.BlockProcessor
 	logger         *logger.Logger
 	metrics        *metrics.Metrics
+
+	// Indexing rate of every chain
+	ratesMu sync.Mutex
+	rates   map[string]*rateMeter
 }
 
 // NewProgressTracker creates a new progress tracker
@@ -39,6 +44,7 @@ func NewProgressTracker(
 		blockProcessor: blockProcessor,
 		logger:         logger,
 		metrics:        metrics,
+		rates:          make(map[string]*rateMeter),
 	}
 }
 
@@ -143,16 +149,11 @@ func (t *ProgressTracker) GetProgress(ctx context.Context, chainID string) (*Pro
 		progress.ProgressPercentage = float64(indexed) / float64(total) * 100
 	}
 
-	// Calculate blocks per second and ETA
-	// This is a simplified calculation - in production you'd track historical rates
-	if progress.BlocksBehind > 0 {
-		// Estimate based on recent processing speed
-		// For now, use a simple estimate
-		progress.BlocksPerSecond = 10.0 // Placeholder
-		if progress.BlocksPerSecond > 0 {
-			secondsLeft := float64(progress.BlocksBehind) / progress.BlocksPerSecond
-			progress.EstimatedTimeLeft = time.Duration(secondsLeft) * time.Second
-		}
+	// Calculate blocks per second over the recent progress and the ETA
+	progress.BlocksPerSecond = t.observeRate(chainID, progress.LastUpdated, latestIndexedBlock)
+	if progress.BlocksBehind > 0 && progress.BlocksPerSecond > 0 {
+		secondsLeft := float64(progress.BlocksBehind) / progress.BlocksPerSecond
+		progress.EstimatedTimeLeft = time.Duration(secondsLeft * float64(time.Second))
 	}
 
 	// Update metrics
@@ -172,6 +173,21 @@ func (t *ProgressTracker) GetProgress(ctx context.Context, chainID string) (*Pro
 	return progress, nil
 }
 
+// observeRate records the indexed height of a chain and returns how many
+// blocks per second it advanced by over the recent samples
+func (t *ProgressTracker) observeRate(chainID string, at time.Time, height uint64) float64 {
+	t.ratesMu.Lock()
+	defer t.ratesMu.Unlock()
+
+	meter, ok := t.rates[chainID]
+	if !ok {
+		meter = &rateMeter{}
+		t.rates[chainID] = meter
+	}
+
+	return meter.observe(at, height)
+}
+
 // GetAllProgress returns progress for specific chains
 // Note: In production, you'd want a ListChains method in the repository
 // For now, this is a placeholder that requires chain IDs
@@ -243,3 +259,47 @@ func (t *ProgressTracker) IsSynced(ctx context.Context, chainID string, threshol
 
 	return progress.BlocksBehind <= threshold, nil
 }
+
+const (
+	// rateWindow is how far back indexing rates are measured
+	rateWindow = 5 * time.Minute
+
+	// maxRateSamples bounds the samples a rate meter keeps
+	maxRateSamples = 64
+)
+
+// rateMeter measures how fast a block height advances over a sliding window
+type rateMeter struct {
+	samples []rateSample
+}
+
+// rateSample is a block height observed at a point in time
+type rateSample struct {
+	at     time.Time
+	height uint64
+}
+
+// observe records height at time at and returns the blocks per second over
+// the window, 0 until the samples span at least a second
+func (m *rateMeter) observe(at time.Time, height uint64) float64 {
+	// A height that moved back, such as after a rollback, starts over
+	if n := len(m.samples); n > 0 && height < m.samples[n-1].height {
+		m.samples = m.samples[:0]
+	}
+	m.samples = append(m.samples, rateSample{at: at, height: height})
+
+	// Drop the samples that left the window, always keeping the newest
+	drop := 0
+	for drop < len(m.samples)-1 && (at.Sub(m.samples[drop].at) > rateWindow || len(m.samples)-drop > maxRateSamples) {
+		drop++
+	}
+	m.samples = m.samples[drop:]
+
+	first := m.samples[0]
+	elapsed := at.Sub(first.at).Seconds()
+	if elapsed < 1 {
+		return 0
+	}
+
+	return float64(height-first.height) / elapsed
+}
@@ -0,0 +1,285 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"go.uber.org/zap"
+)
+
+// realtimeRetryInterval is how long the indexer polls before subscribing
+// again after a block subscription could not be opened or failed
+const realtimeRetryInterval = time.Minute
+
+// headBuffer holds the heads delivered by a block subscription until they
+// reach the confirmation depth
+type headBuffer struct {
+	heads map[uint64]*models.Block
+}
+
+// newHeadBuffer creates an empty head buffer
+func newHeadBuffer() *headBuffer {
+	return &headBuffer{
+		heads: make(map[uint64]*models.Block),
+	}
+}
+
+// add buffers a new head. Buffered heads at or above its height belong to
+// the branch it replaced and are dropped.
+func (h *headBuffer) add(head *models.Block) {
+	for number := range h.heads {
+		if number >= head.Number {
+			delete(h.heads, number)
+		}
+	}
+	h.heads[head.Number] = head
+}
+
+// take removes the heads up to confirmed and returns those from next up to
+// confirmed that are on the branch of the newest head, lowest first. Heads
+// missing from the returned branch have to be fetched from the chain.
+func (h *headBuffer) take(next, confirmed uint64) []*models.Block {
+	var newest *models.Block
+	byHash := make(map[string]*models.Block, len(h.heads))
+	for _, head := range h.heads {
+		byHash[head.Hash] = head
+		if newest == nil || head.Number > newest.Number {
+			newest = head
+		}
+	}
+
+	branch := make([]*models.Block, 0)
+	for block := newest; block != nil && block.Number >= next; {
+		if block.Number <= confirmed {
+			branch = append(branch, block)
+		}
+
+		parent := byHash[block.ParentHash]
+		if parent == nil || parent.Number >= block.Number {
+			break
+		}
+		block = parent
+	}
+
+	sort.Slice(branch, func(i, j int) bool {
+		return branch[i].Number < branch[j].Number
+	})
+
+	for number := range h.heads {
+		if number <= confirmed || number < next {
+			delete(h.heads, number)
+		}
+	}
+
+	return branch
+}
+
+// followHeads indexes the heads delivered by the adapter's block
+// subscription from next on, holding each head until it reaches the
+// confirmation depth. It returns the next block to index once the
+// subscription fails or the indexer falls behind so polling can take over,
+// and whether the subscription itself failed.
+func (b *BlockIndexer) followHeads(ctx context.Context, next uint64) (uint64, bool) {
+	sub, err := b.adapter.SubscribeNewBlocks(ctx)
+	if err == nil && sub == nil {
+		err = fmt.Errorf("adapter does not support block subscriptions")
+	}
+	if err != nil {
+		b.logger.Warn("block subscription unavailable, polling for new blocks",
+			zap.String("chain_id", b.config.ChainID),
+			zap.Error(err),
+		)
+		return next, true
+	}
+	defer sub.Unsubscribe()
+
+	b.logger.Info("following new heads",
+		zap.String("chain_id", b.config.ChainID),
+		zap.Uint64("next_block", next),
+		zap.Uint64("confirmation_blocks", b.confirmationDepth()),
+	)
+
+	buffer := newHeadBuffer()
+	lastHash := ""
+	heads, errs := sub.Channel(), sub.Err()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return next, false
+		case <-b.stopChan:
+			return next, false
+		case err, ok := <-errs:
+			if !ok {
+				// Some adapters close the error channel along with the
+				// block channel, which is handled below
+				errs = nil
+				continue
+			}
+			b.logger.Warn("block subscription failed, falling back to polling",
+				zap.String("chain_id", b.config.ChainID),
+				zap.Error(err),
+			)
+			return next, true
+		case head, ok := <-heads:
+			if !ok {
+				b.logger.Warn("block subscription closed, falling back to polling",
+					zap.String("chain_id", b.config.ChainID),
+				)
+				return next, true
+			}
+			if head == nil {
+				continue
+			}
+
+			next, lastHash, err = b.indexHeads(ctx, buffer, head, next, lastHash)
+			if err != nil {
+				b.logger.Warn("leaving realtime indexing, falling back to polling",
+					zap.String("chain_id", b.config.ChainID),
+					zap.Uint64("next_block", next),
+					zap.Error(err),
+				)
+				return next, false
+			}
+		}
+	}
+}
+
+// indexHeads buffers head and indexes every block from next up to the
+// confirmation depth below it. Buffered heads on the branch of head are
+// indexed as delivered; blocks the subscription skipped are fetched. It
+// returns the next block to index and the hash of the last indexed block.
+func (b *BlockIndexer) indexHeads(ctx context.Context, buffer *headBuffer, head *models.Block, next uint64, lastHash string) (uint64, string, error) {
+	buffer.add(head)
+
+	depth := b.confirmationDepth()
+	if head.Number < depth {
+		return next, lastHash, nil
+	}
+	confirmed := head.Number - depth
+	if confirmed < next {
+		return next, lastHash, nil
+	}
+	if behind := confirmed - next + 1; behind > uint64(b.config.BatchSize) {
+		return next, lastHash, fmt.Errorf("%d blocks behind confirmed head %d", behind, confirmed)
+	}
+
+	// Keep buffering until the backfill jobs below next are committed
+	if b.cursor.Next() < next {
+		return next, lastHash, nil
+	}
+
+	for _, block := range buffer.take(next, confirmed) {
+		if block.Number > next && block.ParentHash != lastHash {
+			hash, err := b.indexRange(ctx, next, block.Number-1)
+			if err != nil {
+				return next, lastHash, err
+			}
+			next = block.Number
+			if hash != "" {
+				lastHash = hash
+			}
+		}
+
+		// Either block is next or its parent hash shows that the heights
+		// in between hold no blocks, as with skipped Solana slots
+		hash, err := b.indexHead(ctx, block)
+		if err != nil {
+			return next, lastHash, err
+		}
+		if err := b.indexEmpty(ctx, next, block.Number); err != nil {
+			return next, lastHash, err
+		}
+		b.trackRange(&BlockRangePayload{StartBlock: next, EndBlock: block.Number}, true)
+		next, lastHash = block.Number+1, hash
+	}
+
+	if next <= confirmed {
+		hash, err := b.indexRange(ctx, next, confirmed)
+		if err != nil {
+			return next, lastHash, err
+		}
+		next = confirmed + 1
+		if hash != "" {
+			lastHash = hash
+		}
+	}
+
+	return next, lastHash, nil
+}
+
+// indexRange fetches, indexes and commits the blocks from start to end and
+// returns the hash of the last one, or "" if the range holds no blocks
+func (b *BlockIndexer) indexRange(ctx context.Context, start, end uint64) (string, error) {
+	blocks, err := fetchBlocks(ctx, b.adapter, start, end)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch blocks %d-%d: %w", start, end, err)
+	}
+
+	hash := ""
+	for _, block := range blocks {
+		if hash, err = b.indexHead(ctx, block); err != nil {
+			return "", err
+		}
+		if err := b.indexEmpty(ctx, start, block.Number); err != nil {
+			return "", err
+		}
+		b.trackRange(&BlockRangePayload{StartBlock: start, EndBlock: block.Number}, true)
+		start = block.Number + 1
+	}
+
+	// Commit the rest of the range even if it held no blocks
+	if start <= end {
+		if err := b.indexEmpty(ctx, start, end+1); err != nil {
+			return "", err
+		}
+		b.trackRange(&BlockRangePayload{StartBlock: start, EndBlock: end}, true)
+	}
+
+	return hash, nil
+}
+
+// indexEmpty records the heights from start up to next, which hold no
+// blocks, as indexed so they are not reported as gaps
+func (b *BlockIndexer) indexEmpty(ctx context.Context, start, next uint64) error {
+	if start >= next {
+		return nil
+	}
+
+	if err := b.processor.ProcessBlockRange(ctx, b.config.ChainID, start, next-1, nil); err != nil {
+		return fmt.Errorf("failed to index empty blocks %d-%d: %w", start, next-1, err)
+	}
+	return nil
+}
+
+// indexHead processes a block of the realtime lane and returns the hash of
+// the indexed block, fetching it again when it turns out to be on an
+// orphaned branch
+func (b *BlockIndexer) indexHead(ctx context.Context, block *models.Block) (string, error) {
+	b.writeMu.RLock()
+	defer b.writeMu.RUnlock()
+
+	b.markFinality(block)
+	if err := b.processor.ProcessBlock(ctx, block); err != nil {
+		if !b.recoverFromReorg(ctx, err) {
+			return "", fmt.Errorf("failed to process block %d: %w", block.Number, err)
+		}
+
+		// The canonical branch changed under us, fetch the block again
+		number := block.Number
+		block, err = b.adapter.GetBlockByNumber(ctx, number)
+		if err != nil {
+			return "", fmt.Errorf("failed to fetch block %d after reorg: %w", number, err)
+		}
+		b.markFinality(block)
+
+		if err := b.processor.ProcessBlock(ctx, block); err != nil {
+			return "", fmt.Errorf("failed to process block %d after reorg: %w", number, err)
+		}
+	}
+
+	return block.Hash, nil
+}
@@ -0,0 +1,207 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/application/processor"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/repository"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/service"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/event"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/logger"
+	"go.uber.org/zap"
+)
+
+// DefaultMaxReorgDepth is the default number of blocks walked back when
+// looking for the common ancestor of a reorganization
+const DefaultMaxReorgDepth uint64 = 128
+
+// ReorgHandler rolls back orphaned blocks and re-indexes the canonical branch
+// after a chain reorganization
+type ReorgHandler struct {
+	adapter   service.ChainAdapter
+	blockRepo repository.BlockRepository
+	processor *processor.BlockProcessor
+	eventBus  event.EventBus
+	logger    *logger.Logger
+	maxDepth  uint64
+
+	// Reorgs are handled one at a time per chain
+	mu sync.Mutex
+}
+
+// NewReorgHandler creates a new reorg handler
+func NewReorgHandler(
+	adapter service.ChainAdapter,
+	blockRepo repository.BlockRepository,
+	processor *processor.BlockProcessor,
+	eventBus event.EventBus,
+	logger *logger.Logger,
+	maxDepth uint64,
+) *ReorgHandler {
+	if maxDepth == 0 {
+		maxDepth = DefaultMaxReorgDepth
+	}
+
+	return &ReorgHandler{
+		adapter:   adapter,
+		blockRepo: blockRepo,
+		processor: processor,
+		eventBus:  eventBus,
+		logger:    logger,
+		maxDepth:  maxDepth,
+	}
+}
+
+// Reorg describes a handled chain reorganization
+type Reorg struct {
+	ChainID        string
+	CommonAncestor uint64
+	StartBlock     uint64
+	EndBlock       uint64
+	Depth          uint64
+	OldHeadHash    string
+	NewHeadHash    string
+}
+
+// String returns a string representation of the reorg
+func (r *Reorg) String() string {
+	return fmt.Sprintf("Reorg[%s: ancestor=%d, orphaned=%d-%d, depth=%d]",
+		r.ChainID, r.CommonAncestor, r.StartBlock, r.EndBlock, r.Depth)
+}
+
+// HandleReorg walks back from the conflicting block to the common ancestor,
+// removes the orphaned blocks and indexes the canonical branch in their place
+func (h *ReorgHandler) HandleReorg(ctx context.Context, reorgErr *processor.ReorgError) (*Reorg, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	chainID := reorgErr.ChainID
+
+	h.logger.Warn("chain reorganization detected",
+		zap.String("chain_id", chainID),
+		zap.Uint64("block_number", reorgErr.BlockNumber),
+		zap.String("parent_hash", reorgErr.ParentHash),
+		zap.String("stored_parent", reorgErr.StoredParent),
+	)
+
+	ancestor, err := h.findCommonAncestor(ctx, chainID, reorgErr.BlockNumber-1)
+	if err != nil {
+		return nil, err
+	}
+
+	oldLatest, err := h.blockRepo.GetLatestHeight(ctx, chainID)
+	if err != nil && !errors.Is(err, repository.ErrBlockNotFound) {
+		return nil, fmt.Errorf("failed to get latest height: %w", err)
+	}
+
+	removed, err := h.processor.RollbackBlocks(ctx, chainID, ancestor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to roll back orphaned blocks: %w", err)
+	}
+
+	reorg := &Reorg{
+		ChainID:        chainID,
+		CommonAncestor: ancestor,
+		StartBlock:     ancestor + 1,
+		EndBlock:       ancestor,
+		Depth:          uint64(len(removed)),
+	}
+	if len(removed) > 0 {
+		reorg.EndBlock = removed[0].Number
+		reorg.OldHeadHash = removed[0].Hash
+	}
+
+	// Re-fetch the canonical branch up to the height we had before
+	end := oldLatest
+	if end < reorgErr.BlockNumber {
+		end = reorgErr.BlockNumber
+	}
+	if head, err := h.adapter.GetLatestBlockNumber(ctx); err == nil && head < end {
+		end = head
+	}
+
+	for start := ancestor + 1; start <= end; start += h.maxDepth {
+		chunkEnd := start + h.maxDepth - 1
+		if chunkEnd > end {
+			chunkEnd = end
+		}
+
+		blocks, err := fetchBlocks(ctx, h.adapter, start, chunkEnd)
+		if err != nil {
+			return reorg, fmt.Errorf("failed to fetch canonical blocks %d-%d: %w", start, chunkEnd, err)
+		}
+
+		if err := h.processor.ProcessBlockRange(ctx, chainID, start, chunkEnd, blocks); err != nil {
+			return reorg, fmt.Errorf("failed to process canonical blocks %d-%d: %w", start, chunkEnd, err)
+		}
+
+		if len(blocks) > 0 {
+			reorg.NewHeadHash = blocks[len(blocks)-1].Hash
+		}
+	}
+
+	h.logger.Info("chain reorganization handled",
+		zap.String("chain_id", chainID),
+		zap.Uint64("common_ancestor", ancestor),
+		zap.Uint64("depth", reorg.Depth),
+		zap.Uint64("start", reorg.StartBlock),
+		zap.Uint64("end", reorg.EndBlock),
+	)
+
+	// Publish reorg event
+	if h.eventBus != nil {
+		evt := event.NewEvent(event.EventTypeChainReorg, chainID, &event.ReorgPayload{
+			ChainID:        chainID,
+			Depth:          reorg.Depth,
+			CommonAncestor: reorg.CommonAncestor,
+			StartBlock:     reorg.StartBlock,
+			EndBlock:       reorg.EndBlock,
+			OldHeadHash:    reorg.OldHeadHash,
+			NewHeadHash:    reorg.NewHeadHash,
+		})
+		h.eventBus.PublishAsync(evt)
+	}
+
+	return reorg, nil
+}
+
+// findCommonAncestor returns the highest block at or below from whose stored
+// hash matches the canonical chain
+func (h *ReorgHandler) findCommonAncestor(ctx context.Context, chainID string, from uint64) (uint64, error) {
+	for number, depth := from, uint64(0); depth <= h.maxDepth; depth++ {
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		default:
+		}
+
+		stored, err := h.blockRepo.GetBlock(ctx, chainID, number)
+		if err != nil && !errors.Is(err, repository.ErrBlockNotFound) {
+			return 0, fmt.Errorf("failed to get stored block %d: %w", number, err)
+		}
+
+		// Nothing stored at this height, so the fork starts above it
+		if stored == nil {
+			return number, nil
+		}
+
+		canonical, err := h.adapter.GetBlockByNumber(ctx, number)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get canonical block %d: %w", number, err)
+		}
+
+		if canonical.Hash == stored.Hash {
+			return number, nil
+		}
+
+		if number == 0 {
+			return 0, fmt.Errorf("genesis block mismatch for chain %s", chainID)
+		}
+		number--
+	}
+
+	return 0, fmt.Errorf("common ancestor not found within %d blocks of %d", h.maxDepth, from)
+}
@@ -2,6 +2,7 @@ package processor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -18,16 +19,52 @@ type BlockProcessor struct {
 	blockRepo repository.BlockRepository
 	txRepo    repository.TransactionRepository
 	chainRepo repository.ChainRepository
+	batches   repository.BatchProvider
+	transfers TokenTransferDecoder
 	eventBus  event.EventBus
 	logger    *logger.Logger
 	metrics   *metrics.Metrics
 }
 
-// NewBlockProcessor creates a new block processor
+// TokenTransferDecoder decodes the token transfers of a transaction from
+// its logs. It is implemented by the normalizers of chains with token
+// standards built on event logs.
+type TokenTransferDecoder interface {
+	DecodeTokenTransfers(tx *models.Transaction) []*models.TokenTransfer
+}
+
+// ErrReorgDetected is returned when a block does not link to the stored parent
+var ErrReorgDetected = errors.New("chain reorganization detected")
+
+// ReorgError describes a parent hash mismatch between an incoming block
+// and the block stored at the previous height
+type ReorgError struct {
+	ChainID      string
+	BlockNumber  uint64 // Number of the incoming block
+	BlockHash    string // Hash of the incoming block
+	ParentHash   string // Parent hash reported by the incoming block
+	StoredParent string // Hash of the block stored at BlockNumber-1
+}
+
+// Error implements the error interface
+func (e *ReorgError) Error() string {
+	return fmt.Sprintf("%s: chain %s block %d has parent %s, stored block %d is %s",
+		ErrReorgDetected, e.ChainID, e.BlockNumber, e.ParentHash, e.BlockNumber-1, e.StoredParent)
+}
+
+// Unwrap returns ErrReorgDetected so callers can use errors.Is
+func (e *ReorgError) Unwrap() error {
+	return ErrReorgDetected
+}
+
+// NewBlockProcessor creates a new block processor. transfers may be nil
+// for chains without token transfer events.
 func NewBlockProcessor(
 	blockRepo repository.BlockRepository,
 	txRepo repository.TransactionRepository,
 	chainRepo repository.ChainRepository,
+	batches repository.BatchProvider,
+	transfers TokenTransferDecoder,
 	eventBus event.EventBus,
 	logger *logger.Logger,
 	metrics *metrics.Metrics,
@@ -36,13 +73,16 @@ func NewBlockProcessor(
 		blockRepo: blockRepo,
 		txRepo:    txRepo,
 		chainRepo: chainRepo,
+		batches:   batches,
+		transfers: transfers,
 		eventBus:  eventBus,
 		logger:    logger,
 		metrics:   metrics,
 	}
 }
 
-// ProcessBlock processes a single block and stores it
+// ProcessBlock processes a single block and stores it together with its
+// transactions in one atomic batch
 func (p *BlockProcessor) ProcessBlock(ctx context.Context, block *models.Block) error {
 	if block == nil {
 		return fmt.Errorf("block is nil")
@@ -58,56 +98,215 @@ func (p *BlockProcessor) ProcessBlock(ctx context.Context, block *models.Block)
 		zap.Int("tx_count", block.TxCount),
 	)
 
-	// Validate block
-	if err := block.Validate(); err != nil {
+	if err := p.prepareBlock(ctx, block, nil); err != nil {
 		p.metrics.RecordBlockProcessed(chainID, false)
-		return fmt.Errorf("invalid block: %w", err)
+		return err
 	}
 
-	// Save block
-	if err := p.blockRepo.SaveBlock(ctx, block); err != nil {
+	if err := p.commitBlocks(ctx, []*models.Block{block}, chainID, nil); err != nil {
 		p.metrics.RecordBlockProcessed(chainID, false)
 		return fmt.Errorf("failed to save block %d: %w", block.Number, err)
 	}
 
-	// Save transactions if present
-	if len(block.Transactions) > 0 {
-		for _, tx := range block.Transactions {
-			if err := p.txRepo.SaveTransaction(ctx, tx); err != nil {
-				p.logger.Error("failed to save transaction",
-					zap.String("chain_id", chainID),
-					zap.String("tx_hash", tx.Hash),
-					zap.Error(err),
-				)
-				// Continue processing other transactions
-			} else {
-				p.metrics.RecordTransactionIndexed(chainID)
-			}
+	p.updateChainProgressOrWarn(ctx, chainID, block.Number)
+
+	duration := time.Since(startTime)
+	p.recordBlock(block, duration)
+
+	p.logger.Debug("block processed successfully",
+		zap.String("chain_id", chainID),
+		zap.Uint64("block_number", block.Number),
+		zap.Duration("duration", duration),
+	)
+
+	return nil
+}
+
+// ProcessBlocks processes multiple blocks in order and stores them in a
+// single atomic batch. Either every block in the range is written or none is.
+func (p *BlockProcessor) ProcessBlocks(ctx context.Context, blocks []*models.Block) error {
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	return p.processBlocks(ctx, blocks[0].ChainID, blocks, nil)
+}
+
+// ProcessBlockRange processes the blocks fetched for the range from start to
+// end like ProcessBlocks and records the whole range as indexed in the same
+// batch, so heights that hold no block, such as skipped Solana slots, are
+// not reported as gaps. blocks may be empty.
+func (p *BlockProcessor) ProcessBlockRange(ctx context.Context, chainID string, start, end uint64, blocks []*models.Block) error {
+	if start > end {
+		return fmt.Errorf("invalid block range: %d-%d", start, end)
+	}
+
+	if len(blocks) == 0 {
+		if err := p.commitBlocks(ctx, nil, chainID, &models.BlockRange{Start: start, End: end}); err != nil {
+			return fmt.Errorf("failed to save empty block range %d-%d: %w", start, end, err)
+		}
+		return nil
+	}
+
+	return p.processBlocks(ctx, chainID, blocks, &models.BlockRange{Start: start, End: end})
+}
+
+// processBlocks validates blocks and stores them, marking span as indexed
+// when it is set
+func (p *BlockProcessor) processBlocks(ctx context.Context, chainID string, blocks []*models.Block, span *models.BlockRange) error {
+	startTime := time.Now()
+	p.logger.Info("processing blocks batch",
+		zap.String("chain_id", chainID),
+		zap.Int("count", len(blocks)),
+		zap.Uint64("start_block", blocks[0].Number),
+		zap.Uint64("end_block", blocks[len(blocks)-1].Number),
+	)
+
+	var prev *models.Block
+	var highest uint64
+	for _, block := range blocks {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		if block == nil {
+			return fmt.Errorf("block is nil")
+		}
+
+		// A reorg or invalid block fails the whole range before anything is written
+		if err := p.prepareBlock(ctx, block, prev); err != nil {
+			p.metrics.RecordBlockProcessed(chainID, false)
+			return err
+		}
+
+		prev = block
+		if block.Number > highest {
+			highest = block.Number
+		}
+	}
+
+	if err := p.commitBlocks(ctx, blocks, chainID, span); err != nil {
+		for range blocks {
+			p.metrics.RecordBlockProcessed(chainID, false)
+		}
+		return fmt.Errorf("failed to save blocks %d-%d: %w",
+			blocks[0].Number, blocks[len(blocks)-1].Number, err)
+	}
+
+	p.updateChainProgressOrWarn(ctx, chainID, highest)
+
+	duration := time.Since(startTime)
+	perBlock := duration / time.Duration(len(blocks))
+	for _, block := range blocks {
+		p.recordBlock(block, perBlock)
+	}
+
+	p.logger.Info("blocks batch processed",
+		zap.String("chain_id", chainID),
+		zap.Int("count", len(blocks)),
+		zap.Duration("duration", duration),
+	)
+
+	return nil
+}
+
+// prepareBlock validates a block, checks that it extends prev when prev is
+// the block directly below it, or the stored chain otherwise, and decodes
+// the token transfers of its transactions
+func (p *BlockProcessor) prepareBlock(ctx context.Context, block *models.Block, prev *models.Block) error {
+	if err := block.Validate(); err != nil {
+		return fmt.Errorf("invalid block %d: %w", block.Number, err)
+	}
+
+	// Make sure the block extends the chain we have stored
+	if err := p.verifyParent(ctx, block, prev); err != nil {
+		return err
+	}
+
+	p.decodeTokenTransfers(block)
+	return nil
+}
+
+// decodeTokenTransfers fills in the token transfers of the block's
+// transactions so they are stored and indexed with them
+func (p *BlockProcessor) decodeTokenTransfers(block *models.Block) {
+	if p.transfers == nil {
+		return
+	}
+
+	for _, tx := range block.Transactions {
+		if tx == nil || len(tx.Logs) == 0 {
+			continue
 		}
+		tx.TokenTransfers = p.transfers.DecodeTokenTransfers(tx)
 	}
+}
 
-	// Update chain latest indexed block
-	if err := p.updateChainProgress(ctx, chainID, block.Number); err != nil {
+// commitBlocks writes blocks, their transactions, all index entries and the
+// indexed span of a chain, if set, in one batch, so a failure leaves none of
+// them stored
+func (p *BlockProcessor) commitBlocks(ctx context.Context, blocks []*models.Block, chainID string, span *models.BlockRange) error {
+	batch := p.batches.NewBatch()
+	defer batch.Close()
+
+	if err := stageBlocks(ctx, batch, blocks); err != nil {
+		return err
+	}
+
+	if span != nil {
+		if err := batch.SetIndexedRange(ctx, chainID, span.Start, span.End); err != nil {
+			return fmt.Errorf("failed to batch indexed range: %w", err)
+		}
+	}
+
+	if err := batch.Commit(); err != nil {
+		return fmt.Errorf("failed to commit batch: %w", err)
+	}
+
+	return nil
+}
+
+// stageBlocks adds blocks and their transactions to batch
+func stageBlocks(ctx context.Context, batch repository.Batch, blocks []*models.Block) error {
+	for _, block := range blocks {
+		if err := batch.SetBlock(ctx, block); err != nil {
+			return fmt.Errorf("failed to batch block %d: %w", block.Number, err)
+		}
+
+		if err := batch.SetTransactions(ctx, block.Transactions); err != nil {
+			return fmt.Errorf("failed to batch transactions of block %d: %w", block.Number, err)
+		}
+	}
+
+	return nil
+}
+
+// updateChainProgressOrWarn updates chain progress, logging instead of
+// failing since the blocks are already stored
+func (p *BlockProcessor) updateChainProgressOrWarn(ctx context.Context, chainID string, blockNumber uint64) {
+	if err := p.updateChainProgress(ctx, chainID, blockNumber); err != nil {
 		p.logger.Warn("failed to update chain progress",
 			zap.String("chain_id", chainID),
-			zap.Uint64("block_number", block.Number),
+			zap.Uint64("block_number", blockNumber),
 			zap.Error(err),
 		)
 	}
+}
 
-	// Record metrics
-	duration := time.Since(startTime)
+// recordBlock records metrics and publishes events for a stored block
+func (p *BlockProcessor) recordBlock(block *models.Block, duration time.Duration) {
+	chainID := block.ChainID
+
+	for range block.Transactions {
+		p.metrics.RecordTransactionIndexed(chainID)
+	}
 	p.metrics.RecordBlockIndexed(chainID)
 	p.metrics.RecordBlockProcessed(chainID, true)
 	p.metrics.RecordBlockProcessTime(chainID, duration)
 	p.metrics.UpdateLatestBlockHeight(chainID, block.Number)
 
-	p.logger.Debug("block processed successfully",
-		zap.String("chain_id", chainID),
-		zap.Uint64("block_number", block.Number),
-		zap.Duration("duration", duration),
-	)
-
 	// Publish block indexed event
 	if p.eventBus != nil {
 		evt := event.NewEvent(event.EventTypeBlockIndexed, chainID, &event.BlockIndexedPayload{
@@ -126,55 +325,247 @@ func (p *BlockProcessor) ProcessBlock(ctx context.Context, block *models.Block)
 			p.eventBus.PublishAsync(txEvt)
 		}
 	}
+}
+
+// verifyParent checks that the block's parent hash matches the block stored
+// at the previous height. Blocks without a parent hash or whose parent is not
+// stored yet (out-of-order batches, skipped slots) are accepted as is.
+func (p *BlockProcessor) verifyParent(ctx context.Context, block *models.Block, prev *models.Block) error {
+	if block.ParentHash == "" || block.Number == 0 {
+		return nil
+	}
+
+	parent := prev
+	if parent == nil || parent.Number != block.Number-1 {
+		stored, err := p.blockRepo.GetBlock(ctx, block.ChainID, block.Number-1)
+		if err != nil {
+			if errors.Is(err, repository.ErrBlockNotFound) {
+				return nil
+			}
+			return fmt.Errorf("failed to get parent block %d: %w", block.Number-1, err)
+		}
+		parent = stored
+	}
+
+	if parent.Hash == block.ParentHash {
+		return nil
+	}
+
+	return &ReorgError{
+		ChainID:      block.ChainID,
+		BlockNumber:  block.Number,
+		BlockHash:    block.Hash,
+		ParentHash:   block.ParentHash,
+		StoredParent: parent.Hash,
+	}
+}
+
+// RollbackBlocks removes every stored block above ancestor together with its
+// transactions, hash index and address index entries in one atomic batch,
+// and rewinds the chain progress to ancestor. It returns the removed blocks,
+// highest first.
+func (p *BlockProcessor) RollbackBlocks(ctx context.Context, chainID string, ancestor uint64) ([]*models.Block, error) {
+	latest, err := p.blockRepo.GetLatestHeight(ctx, chainID)
+	if err != nil {
+		if errors.Is(err, repository.ErrBlockNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get latest height: %w", err)
+	}
+
+	batch := p.batches.NewBatch()
+	defer batch.Close()
+
+	removed := make([]*models.Block, 0)
+	for number := latest; number > ancestor; number-- {
+		block, err := p.deleteBlock(ctx, batch, chainID, number)
+		if err != nil {
+			return nil, err
+		}
+		if block != nil {
+			removed = append(removed, block)
+		}
+	}
+
+	if len(removed) > 0 {
+		if err := batch.Commit(); err != nil {
+			return nil, fmt.Errorf("failed to commit rollback: %w", err)
+		}
+	}
+
+	for _, block := range removed {
+		p.logger.Debug("rolled back block",
+			zap.String("chain_id", chainID),
+			zap.Uint64("block_number", block.Number),
+			zap.String("block_hash", block.Hash),
+			zap.Int("tx_count", block.TxCount),
+		)
+	}
+
+	// Rewind chain progress, including finality heads a deep reorg went past
+	chain, err := p.chainRepo.GetChain(ctx, chainID)
+	if err == nil && chain != nil && chain.LatestIndexedBlock > ancestor {
+		chain.LatestIndexedBlock = ancestor
+		chain.SafeBlock = min(chain.SafeBlock, ancestor)
+		chain.FinalizedBlock = min(chain.FinalizedBlock, ancestor)
+		chain.LastUpdated = time.Now()
+		if err := p.chainRepo.UpdateChain(ctx, chain); err != nil {
+			p.logger.Warn("failed to rewind chain progress",
+				zap.String("chain_id", chainID),
+				zap.Uint64("ancestor", ancestor),
+				zap.Error(err),
+			)
+		}
+	}
+
+	if len(removed) > 0 {
+		p.metrics.UpdateLatestBlockHeight(chainID, ancestor)
+	}
+
+	return removed, nil
+}
+
+// ReplaceBlock stores block in place of the block stored at its height,
+// dropping the transactions only the stored block had. The block is checked
+// against the stored chain first, and the stored block is removed in the
+// same batch that writes the new one, so a failure leaves it in place.
+func (p *BlockProcessor) ReplaceBlock(ctx context.Context, block *models.Block) error {
+	if block == nil {
+		return fmt.Errorf("block is nil")
+	}
+
+	startTime := time.Now()
+	chainID := block.ChainID
+
+	if err := p.prepareBlock(ctx, block, nil); err != nil {
+		p.metrics.RecordBlockProcessed(chainID, false)
+		return err
+	}
+
+	batch := p.batches.NewBatch()
+	defer batch.Close()
+
+	if _, err := p.deleteBlock(ctx, batch, chainID, block.Number); err != nil {
+		p.metrics.RecordBlockProcessed(chainID, false)
+		return err
+	}
+
+	if err := stageBlocks(ctx, batch, []*models.Block{block}); err != nil {
+		p.metrics.RecordBlockProcessed(chainID, false)
+		return err
+	}
+
+	if err := batch.Commit(); err != nil {
+		p.metrics.RecordBlockProcessed(chainID, false)
+		return fmt.Errorf("failed to save block %d: %w", block.Number, err)
+	}
+
+	p.updateChainProgressOrWarn(ctx, chainID, block.Number)
+	p.recordBlock(block, time.Since(startTime))
 
 	return nil
 }
 
-// ProcessBlocks processes multiple blocks in order
-func (p *BlockProcessor) ProcessBlocks(ctx context.Context, blocks []*models.Block) error {
-	if len(blocks) == 0 {
-		return nil
+// deleteBlock adds the removal of a stored block and its transactions to
+// batch and returns the block with them, nil if the block is not stored
+func (p *BlockProcessor) deleteBlock(ctx context.Context, batch repository.Batch, chainID string, number uint64) (*models.Block, error) {
+	block, err := p.blockRepo.GetBlock(ctx, chainID, number)
+	if err != nil {
+		if errors.Is(err, repository.ErrBlockNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get block %d: %w", number, err)
 	}
 
-	chainID := blocks[0].ChainID
-	p.logger.Info("processing blocks batch",
-		zap.String("chain_id", chainID),
-		zap.Int("count", len(blocks)),
-		zap.Uint64("start_block", blocks[0].Number),
-		zap.Uint64("end_block", blocks[len(blocks)-1].Number),
-	)
+	txs, err := p.txRepo.GetTransactionsByBlock(ctx, chainID, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions for block %d: %w", number, err)
+	}
 
-	successCount := 0
-	errorCount := 0
+	for _, tx := range txs {
+		if err := batch.DeleteTransaction(ctx, tx); err != nil {
+			return nil, fmt.Errorf("failed to delete transaction %s: %w", tx.Hash, err)
+		}
+	}
 
-	for _, block := range blocks {
+	if err := batch.DeleteBlock(ctx, block); err != nil {
+		return nil, fmt.Errorf("failed to delete block %d: %w", number, err)
+	}
+
+	block.Transactions = txs
+	return block, nil
+}
+
+// PromoteBlocks raises the stored blocks of a chain to the finality heads
+// report for them, up to end, and records the heads up to end on the chain.
+// It starts above the finalized block recorded on the chain, or at start
+// for a chain without one. Blocks that already have their finality are not
+// written again.
+func (p *BlockProcessor) PromoteBlocks(ctx context.Context, chainID string, heads models.FinalityHeads, start, end uint64) error {
+	chain, err := p.chainRepo.GetChain(ctx, chainID)
+	if err != nil {
+		return fmt.Errorf("failed to get chain: %w", err)
+	}
+
+	if chain == nil {
+		return fmt.Errorf("chain not found: %s", chainID)
+	}
+
+	if chain.FinalizedBlock > 0 {
+		start = chain.FinalizedBlock + 1
+	}
+
+	// Heads only move forward here, a rollback lowers them
+	safe := max(min(heads.Safe, end), chain.SafeBlock)
+	finalized := max(min(heads.Finalized, end), chain.FinalizedBlock)
+	heads = models.FinalityHeads{Safe: safe, Finalized: finalized}
+
+	promoted := 0
+	for number := start; number <= safe; number++ {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
 		}
 
-		if err := p.ProcessBlock(ctx, block); err != nil {
-			p.logger.Error("failed to process block",
-				zap.String("chain_id", chainID),
-				zap.Uint64("block_number", block.Number),
-				zap.Error(err),
-			)
-			errorCount++
-			// Continue processing other blocks
-		} else {
-			successCount++
+		block, err := p.blockRepo.GetBlock(ctx, chainID, number)
+		if err != nil {
+			if errors.Is(err, repository.ErrBlockNotFound) {
+				continue
+			}
+			return fmt.Errorf("failed to get block %d: %w", number, err)
+		}
+
+		finality := heads.Finality(number)
+		if block.Finality == finality {
+			continue
 		}
+
+		block.Finality = finality
+		if err := p.blockRepo.UpdateBlock(ctx, block); err != nil {
+			return fmt.Errorf("failed to promote block %d: %w", number, err)
+		}
+		promoted++
 	}
 
-	p.logger.Info("blocks batch processed",
-		zap.String("chain_id", chainID),
-		zap.Int("success", successCount),
-		zap.Int("errors", errorCount),
-	)
+	if promoted > 0 {
+		p.logger.Debug("promoted blocks",
+			zap.String("chain_id", chainID),
+			zap.Int("count", promoted),
+			zap.Uint64("safe_block", safe),
+			zap.Uint64("finalized_block", finalized),
+		)
+	}
+
+	if chain.SafeBlock == safe && chain.FinalizedBlock == finalized {
+		return nil
+	}
 
-	if errorCount > 0 {
-		return fmt.Errorf("processed %d blocks with %d errors", len(blocks), errorCount)
+	chain.SafeBlock = safe
+	chain.FinalizedBlock = finalized
+	chain.LastUpdated = time.Now()
+	if err := p.chainRepo.UpdateChain(ctx, chain); err != nil {
+		return fmt.Errorf("failed to update chain: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,164 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/repository"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/logger"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/metrics"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/storage/pebble"
+	"go.uber.org/zap"
+)
+
+func newTestProcessor(t *testing.T) (*BlockProcessor, *pebble.PebbleStorage) {
+	t.Helper()
+
+	storage, err := pebble.NewStorage(pebble.DefaultConfig(t.TempDir()))
+	if err != nil {
+		t.Fatalf("NewStorage() error = %v", err)
+	}
+	t.Cleanup(func() { storage.Close() })
+
+	chain := models.NewChain(models.ChainTypeEVM, "ethereum", "Ethereum")
+	chain.RPCEndpoints = []string{"http://localhost:8545"}
+	if err := storage.SaveChain(context.Background(), chain); err != nil {
+		t.Fatalf("SaveChain() error = %v", err)
+	}
+
+	log := &logger.Logger{Logger: zap.NewNop()}
+	proc := NewBlockProcessor(storage, storage, storage, storage, nil, nil, log, metrics.New(&metrics.Config{Enabled: false}))
+	return proc, storage
+}
+
+// testBranch returns blocks start to end, each with one transaction, linked
+// by parent hash from parent
+func testBranch(branch string, parent string, start, end uint64) []*models.Block {
+	blocks := make([]*models.Block, 0)
+	for number := start; number <= end; number++ {
+		hash := fmt.Sprintf("0x%s%d", branch, number)
+		block := models.NewBlock(models.ChainTypeEVM, "ethereum", number, hash)
+		block.ParentHash = parent
+
+		tx := models.NewTransaction(models.ChainTypeEVM, "ethereum", hash+"tx")
+		tx.BlockNumber = number
+		tx.BlockHash = hash
+		tx.From = "0xfrom"
+		tx.To = "0xto"
+		block.Transactions = []*models.Transaction{tx}
+		block.TxCount = 1
+
+		blocks = append(blocks, block)
+		parent = hash
+	}
+	return blocks
+}
+
+func TestBlockProcessor_DetectsReorg(t *testing.T) {
+	proc, _ := newTestProcessor(t)
+	ctx := context.Background()
+
+	if err := proc.ProcessBlocks(ctx, testBranch("a", "", 1, 5)); err != nil {
+		t.Fatalf("ProcessBlocks() error = %v", err)
+	}
+
+	// Block 6 of another branch does not link to the stored block 5
+	fork := testBranch("b", "0xb5", 6, 6)
+	err := proc.ProcessBlock(ctx, fork[0])
+
+	var reorgErr *ReorgError
+	if !errors.As(err, &reorgErr) {
+		t.Fatalf("ProcessBlock() error = %v, want a ReorgError", err)
+	}
+	if reorgErr.BlockNumber != 6 || reorgErr.StoredParent != "0xa5" || reorgErr.ParentHash != "0xb5" {
+		t.Errorf("ReorgError = %+v, want block 6 with stored parent 0xa5", reorgErr)
+	}
+
+	// A range that breaks its own links is refused before anything is stored
+	broken := testBranch("a", "0xa5", 6, 8)
+	broken[2].ParentHash = "0xother"
+	if err := proc.ProcessBlocks(ctx, broken); !errors.Is(err, ErrReorgDetected) {
+		t.Fatalf("ProcessBlocks() error = %v, want ErrReorgDetected", err)
+	}
+}
+
+func TestBlockProcessor_RollbackBlocks(t *testing.T) {
+	proc, storage := newTestProcessor(t)
+	ctx := context.Background()
+
+	blocks := testBranch("a", "", 1, 10)
+	if err := proc.ProcessBlocks(ctx, blocks); err != nil {
+		t.Fatalf("ProcessBlocks() error = %v", err)
+	}
+
+	removed, err := proc.RollbackBlocks(ctx, "ethereum", 7)
+	if err != nil {
+		t.Fatalf("RollbackBlocks() error = %v", err)
+	}
+	if len(removed) != 3 || removed[0].Number != 10 || removed[2].Number != 8 {
+		t.Fatalf("RollbackBlocks() removed %d blocks, want 10, 9 and 8", len(removed))
+	}
+
+	for _, block := range blocks[7:] {
+		if _, err := storage.GetBlock(ctx, "ethereum", block.Number); !errors.Is(err, repository.ErrBlockNotFound) {
+			t.Errorf("GetBlock(%d) error = %v, want ErrBlockNotFound", block.Number, err)
+		}
+		if _, err := storage.GetTransaction(ctx, "ethereum", block.Transactions[0].Hash); !errors.Is(err, repository.ErrTransactionNotFound) {
+			t.Errorf("GetTransaction(%s) error = %v, want ErrTransactionNotFound", block.Transactions[0].Hash, err)
+		}
+	}
+
+	height, err := storage.GetLatestHeight(ctx, "ethereum")
+	if err != nil || height != 7 {
+		t.Errorf("GetLatestHeight() = %d, %v, want 7", height, err)
+	}
+	chain, err := storage.GetChain(ctx, "ethereum")
+	if err != nil {
+		t.Fatalf("GetChain() error = %v", err)
+	}
+	if chain.LatestIndexedBlock != 7 {
+		t.Errorf("LatestIndexedBlock = %d, want 7", chain.LatestIndexedBlock)
+	}
+
+	// The other branch now extends the stored chain
+	if err := proc.ProcessBlocks(ctx, testBranch("b", "0xa7", 8, 9)); err != nil {
+		t.Fatalf("ProcessBlocks() of the new branch error = %v", err)
+	}
+}
+
+func TestBlockProcessor_ReplaceBlock(t *testing.T) {
+	proc, storage := newTestProcessor(t)
+	ctx := context.Background()
+
+	blocks := testBranch("a", "", 1, 5)
+	if err := proc.ProcessBlocks(ctx, blocks); err != nil {
+		t.Fatalf("ProcessBlocks() error = %v", err)
+	}
+
+	replacement := testBranch("b", "0xa4", 5, 5)[0]
+	if err := proc.ReplaceBlock(ctx, replacement); err != nil {
+		t.Fatalf("ReplaceBlock() error = %v", err)
+	}
+
+	stored, err := storage.GetBlock(ctx, "ethereum", 5)
+	if err != nil {
+		t.Fatalf("GetBlock(5) error = %v", err)
+	}
+	if stored.Hash != replacement.Hash {
+		t.Errorf("GetBlock(5).Hash = %s, want %s", stored.Hash, replacement.Hash)
+	}
+	if _, err := storage.GetTransaction(ctx, "ethereum", blocks[4].Transactions[0].Hash); !errors.Is(err, repository.ErrTransactionNotFound) {
+		t.Errorf("GetTransaction(replaced) error = %v, want ErrTransactionNotFound", err)
+	}
+	if _, err := storage.GetTransaction(ctx, "ethereum", replacement.Transactions[0].Hash); err != nil {
+		t.Errorf("GetTransaction(replacement) error = %v", err)
+	}
+
+	height, err := storage.GetLatestHeight(ctx, "ethereum")
+	if err != nil || height != 5 {
+		t.Errorf("GetLatestHeight() = %d, %v, want 5", height, err)
+	}
+}
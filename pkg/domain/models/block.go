@@ -44,6 +44,10 @@ type Block struct {
 
 	// Indexing metadata
 	IndexedAt time.Time `json:"indexed_at"` // When this block was indexed
+
+	// Finality of the block when it was indexed or last promoted. Blocks
+	// indexed before finality was tracked leave it empty.
+	Finality Finality `json:"finality,omitempty"`
 }
 
 // NewBlock creates a new Block with basic information
@@ -0,0 +1,99 @@
+package models
+
+// Capability names an optional chain adapter feature
+type Capability string
+
+const (
+	// CapabilityBlockSubscription streams new blocks
+	CapabilityBlockSubscription Capability = "block_subscription"
+
+	// CapabilityTransactionSubscription streams new transactions
+	CapabilityTransactionSubscription Capability = "transaction_subscription"
+
+	// CapabilityBlockByHash looks blocks up by hash
+	CapabilityBlockByHash Capability = "block_by_hash"
+
+	// CapabilityTransactionByHash looks transactions up by hash
+	CapabilityTransactionByHash Capability = "transaction_by_hash"
+
+	// CapabilityTraces attaches internal transactions from execution traces
+	CapabilityTraces Capability = "traces"
+
+	// CapabilityLogs attaches event logs to transactions
+	CapabilityLogs Capability = "logs"
+
+	// CapabilityFinalityTags reads the safe and finalized heads from the
+	// chain instead of deriving them from the block depth
+	CapabilityFinalityTags Capability = "finality_tags"
+
+	// CapabilityBatchFetch fetches the blocks of a range in batched or
+	// concurrent requests
+	CapabilityBatchFetch Capability = "batch_fetch"
+)
+
+// Capabilities describes the optional features of a chain adapter, so that
+// callers can pick a strategy up front instead of learning from errors
+type Capabilities struct {
+	BlockSubscription       bool `json:"block_subscription"`
+	TransactionSubscription bool `json:"transaction_subscription"`
+	BlockByHash             bool `json:"block_by_hash"`
+	TransactionByHash       bool `json:"transaction_by_hash"`
+	Traces                  bool `json:"traces"`
+	Logs                    bool `json:"logs"`
+	FinalityTags            bool `json:"finality_tags"`
+
+	// BatchFetch is set when GetBlocks fetches a range in batched or
+	// concurrent requests rather than one block after another
+	BatchFetch bool `json:"batch_fetch"`
+
+	// MaxBlockRange is the most block numbers a GetBlocks call covers
+	// before it stops early, 0 if it covers any range
+	MaxBlockRange uint64 `json:"max_block_range,omitempty"`
+}
+
+// Supports returns true if the capability is supported
+func (c Capabilities) Supports(capability Capability) bool {
+	switch capability {
+	case CapabilityBlockSubscription:
+		return c.BlockSubscription
+	case CapabilityTransactionSubscription:
+		return c.TransactionSubscription
+	case CapabilityBlockByHash:
+		return c.BlockByHash
+	case CapabilityTransactionByHash:
+		return c.TransactionByHash
+	case CapabilityTraces:
+		return c.Traces
+	case CapabilityLogs:
+		return c.Logs
+	case CapabilityFinalityTags:
+		return c.FinalityTags
+	case CapabilityBatchFetch:
+		return c.BatchFetch
+	default:
+		return false
+	}
+}
+
+// List returns the supported capabilities
+func (c Capabilities) List() []Capability {
+	all := []Capability{
+		CapabilityBlockSubscription,
+		CapabilityTransactionSubscription,
+		CapabilityBlockByHash,
+		CapabilityTransactionByHash,
+		CapabilityTraces,
+		CapabilityLogs,
+		CapabilityFinalityTags,
+		CapabilityBatchFetch,
+	}
+
+	supported := make([]Capability, 0, len(all))
+	for _, capability := range all {
+		if c.Supports(capability) {
+			supported = append(supported, capability)
+		}
+	}
+
+	return supported
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCapabilities_Supports(t *testing.T) {
+	caps := Capabilities{
+		BlockSubscription: true,
+		TransactionByHash: true,
+		Logs:              true,
+		BatchFetch:        true,
+	}
+
+	tests := []struct {
+		name       string
+		capability Capability
+		want       bool
+	}{
+		{name: "block subscription", capability: CapabilityBlockSubscription, want: true},
+		{name: "transaction subscription", capability: CapabilityTransactionSubscription, want: false},
+		{name: "block by hash", capability: CapabilityBlockByHash, want: false},
+		{name: "transaction by hash", capability: CapabilityTransactionByHash, want: true},
+		{name: "traces", capability: CapabilityTraces, want: false},
+		{name: "logs", capability: CapabilityLogs, want: true},
+		{name: "finality tags", capability: CapabilityFinalityTags, want: false},
+		{name: "batch fetch", capability: CapabilityBatchFetch, want: true},
+		{name: "unknown capability", capability: Capability("teleport"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := caps.Supports(tt.capability); got != tt.want {
+				t.Errorf("Supports(%q) = %v, want %v", tt.capability, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCapabilities_List(t *testing.T) {
+	caps := Capabilities{
+		BlockByHash:  true,
+		Traces:       true,
+		FinalityTags: true,
+	}
+
+	want := []Capability{CapabilityBlockByHash, CapabilityTraces, CapabilityFinalityTags}
+	if got := caps.List(); !reflect.DeepEqual(got, want) {
+		t.Errorf("List() = %v, want %v", got, want)
+	}
+
+	if got := (Capabilities{}).List(); len(got) != 0 {
+		t.Errorf("List() of no capabilities = %v, want empty", got)
+	}
+}
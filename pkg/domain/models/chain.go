@@ -27,11 +27,19 @@ type Chain struct {
 	// Chain-specific configuration
 	Config map[string]interface{} `json:"config,omitempty"`
 
+	// Capabilities of the chain's adapter, nil until an indexer records them
+	Capabilities *Capabilities `json:"capabilities,omitempty"`
+
 	// Status information
 	LatestIndexedBlock uint64    `json:"latest_indexed_block"`
 	LatestChainBlock   uint64    `json:"latest_chain_block"`
 	LastUpdated        time.Time `json:"last_updated"`
 	Status             ChainStatus `json:"status"`
+
+	// Highest indexed blocks that are safe and finalized, as last promoted
+	// by the indexer
+	SafeBlock      uint64 `json:"safe_block,omitempty"`
+	FinalizedBlock uint64 `json:"finalized_block,omitempty"`
 }
 
 // ChainStatus represents the status of chain indexing
@@ -88,7 +96,8 @@ func (c *Chain) Validate() error {
 	if c.Name == "" {
 		return errors.New("chain name is required")
 	}
-	if len(c.RPCEndpoints) == 0 {
+	// The simulated chain runs in-process
+	if len(c.RPCEndpoints) == 0 && c.ChainType != ChainTypeSim {
 		return errors.New("at least one RPC endpoint is required")
 	}
 	if c.BatchSize <= 0 {
@@ -105,6 +114,15 @@ func (c *Chain) IsSynced() bool {
 	return c.Status == ChainStatusLive
 }
 
+// Supports returns true if the chain's adapter supports the capability.
+// Chains whose capabilities were never recorded are assumed to support it.
+func (c *Chain) Supports(capability Capability) bool {
+	if c.Capabilities == nil {
+		return true
+	}
+	return c.Capabilities.Supports(capability)
+}
+
 // GetSyncProgress returns the sync progress as a percentage (0-100)
 func (c *Chain) GetSyncProgress() float64 {
 	if c.LatestChainBlock == 0 {
@@ -134,6 +152,57 @@ func (c *Chain) UpdateLatestBlock(indexedBlock, chainBlock uint64) {
 	c.LastUpdated = time.Now()
 }
 
+// FinalityHeight returns the highest block a query at finality level may
+// return, or false if the level does not limit the blocks
+func (c *Chain) FinalityHeight(level Finality) (uint64, bool) {
+	switch level {
+	case FinalitySafe:
+		return c.SafeBlock, true
+	case FinalityFinalized:
+		return c.FinalizedBlock, true
+	default:
+		return 0, false
+	}
+}
+
+// FinalityLimit is the highest block a query at a finality level may return
+type FinalityLimit struct {
+	Finality Finality
+	Height   uint64
+	Limited  bool
+}
+
+// NewFinalityLimit resolves a finality level against the heads recorded on
+// chain. A nil chain is unknown and has no finalized blocks, so it only
+// allows blocks at FinalityLatest.
+func NewFinalityLimit(level Finality, chain *Chain) FinalityLimit {
+	limit := FinalityLimit{Finality: level}
+	if level == FinalityLatest {
+		return limit
+	}
+
+	limit.Limited = true
+	if chain != nil {
+		limit.Height, limit.Limited = chain.FinalityHeight(level)
+	}
+	return limit
+}
+
+// Allows returns true if the block at number may be returned
+func (l FinalityLimit) Allows(number uint64) bool {
+	return !l.Limited || number <= l.Height
+}
+
+// MaxHeight returns the highest block that may be returned, or nil if the
+// limit allows every block
+func (l FinalityLimit) MaxHeight() *uint64 {
+	if !l.Limited {
+		return nil
+	}
+	height := l.Height
+	return &height
+}
+
 // GetConfig retrieves a configuration value by key
 func (c *Chain) GetConfig(key string) (interface{}, bool) {
 	if c.Config == nil {
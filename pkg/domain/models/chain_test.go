@@ -119,6 +119,17 @@ func TestChain_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "at least one RPC endpoint is required",
 		},
+		{
+			name: "simulated chain without RPC endpoints",
+			chain: &Chain{
+				ChainType: ChainTypeSim,
+				ChainID:   "sim",
+				Name:      "Simulated",
+				BatchSize: 100,
+				Workers:   10,
+			},
+			wantErr: false,
+		},
 		{
 			name: "invalid batch size",
 			chain: &Chain{
@@ -473,3 +484,74 @@ func BenchmarkChain_GetStats(b *testing.B) {
 		_ = chain.GetStats()
 	}
 }
+
+func TestChain_Supports(t *testing.T) {
+	chain := NewChain(ChainTypeBitcoin, "bitcoin", "Bitcoin")
+
+	// Chains without recorded capabilities are assumed to support everything
+	if !chain.Supports(CapabilityLogs) {
+		t.Error("Supports() without capabilities should be true")
+	}
+
+	chain.Capabilities = &Capabilities{BlockByHash: true}
+	if chain.Supports(CapabilityLogs) {
+		t.Error("Supports(logs) should be false")
+	}
+	if !chain.Supports(CapabilityBlockByHash) {
+		t.Error("Supports(block_by_hash) should be true")
+	}
+}
+
+func TestChain_FinalityHeight(t *testing.T) {
+	chain := &Chain{SafeBlock: 120, FinalizedBlock: 100}
+
+	if _, limited := chain.FinalityHeight(FinalityLatest); limited {
+		t.Error("FinalityHeight(latest) should not limit blocks")
+	}
+	if height, limited := chain.FinalityHeight(FinalitySafe); !limited || height != 120 {
+		t.Errorf("FinalityHeight(safe) = %d, %v, want 120, true", height, limited)
+	}
+	if height, limited := chain.FinalityHeight(FinalityFinalized); !limited || height != 100 {
+		t.Errorf("FinalityHeight(finalized) = %d, %v, want 100, true", height, limited)
+	}
+}
+
+func TestNewFinalityLimit(t *testing.T) {
+	chain := &Chain{SafeBlock: 120, FinalizedBlock: 100}
+
+	tests := []struct {
+		name    string
+		level   Finality
+		chain   *Chain
+		allowed []uint64
+		denied  []uint64
+	}{
+		{"latest", FinalityLatest, chain, []uint64{0, 100, 121, 1000}, nil},
+		{"safe", FinalitySafe, chain, []uint64{0, 100, 120}, []uint64{121}},
+		{"finalized", FinalityFinalized, chain, []uint64{0, 100}, []uint64{101, 120}},
+		{"latest on unknown chain", FinalityLatest, nil, []uint64{0, 1000}, nil},
+		{"finalized on unknown chain", FinalityFinalized, nil, nil, []uint64{1, 100}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit := NewFinalityLimit(tt.level, tt.chain)
+			if limit.Finality != tt.level {
+				t.Errorf("Finality = %v, want %v", limit.Finality, tt.level)
+			}
+			for _, number := range tt.allowed {
+				if !limit.Allows(number) {
+					t.Errorf("Allows(%d) = false, want true", number)
+				}
+			}
+			for _, number := range tt.denied {
+				if limit.Allows(number) {
+					t.Errorf("Allows(%d) = true, want false", number)
+				}
+			}
+			if max := limit.MaxHeight(); (max == nil) != (tt.denied == nil) || (max != nil && !limit.Allows(*max)) {
+				t.Errorf("MaxHeight() = %v with denied blocks %v", max, tt.denied)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ var (
 	ErrInvalidBlockHash  = errors.New("invalid block hash")
 	ErrInvalidParentHash = errors.New("invalid parent hash")
 	ErrBlockNotFound     = errors.New("block not found")
+	ErrInvalidBlockRange = errors.New("invalid block range")
 
 	// Transaction errors
 	ErrInvalidTxHash       = errors.New("invalid transaction hash")
@@ -14,7 +15,11 @@ var (
 	ErrInvalidToAddress    = errors.New("invalid to address")
 	ErrTransactionNotFound = errors.New("transaction not found")
 
+	// Token transfer errors
+	ErrInvalidTokenStandard = errors.New("invalid token standard")
+
 	// Chain errors
+	ErrInvalidChain     = errors.New("invalid chain")
 	ErrInvalidChainType = errors.New("invalid chain type")
 	ErrInvalidChainID   = errors.New("invalid chain ID")
 	ErrChainNotFound    = errors.New("chain not found")
@@ -0,0 +1,57 @@
+package models
+
+import "fmt"
+
+// Finality is how settled a block is on its chain
+type Finality string
+
+const (
+	// FinalityPending marks a block above the safe head that can still be
+	// replaced by a reorg
+	FinalityPending Finality = "pending"
+
+	// FinalitySafe marks a block the chain considers unlikely to be
+	// reorganized, such as the Ethereum safe head
+	FinalitySafe Finality = "safe"
+
+	// FinalityFinalized marks a block that can no longer be reorganized
+	FinalityFinalized Finality = "finalized"
+
+	// FinalityLatest selects every indexed block in queries, pending or not
+	FinalityLatest Finality = "latest"
+)
+
+// ParseFinality parses the finality level of a query. An empty string
+// selects FinalityLatest.
+func ParseFinality(s string) (Finality, error) {
+	switch Finality(s) {
+	case "", FinalityLatest:
+		return FinalityLatest, nil
+	case FinalitySafe:
+		return FinalitySafe, nil
+	case FinalityFinalized:
+		return FinalityFinalized, nil
+	default:
+		return "", fmt.Errorf("invalid finality %q, want finalized, safe or latest", s)
+	}
+}
+
+// FinalityHeads are the highest blocks a chain treats as safe and as
+// finalized. Chains without a separate safe head report the finalized one
+// for both.
+type FinalityHeads struct {
+	Safe      uint64 `json:"safe"`
+	Finalized uint64 `json:"finalized"`
+}
+
+// Finality returns the finality of the block at number under the heads
+func (h FinalityHeads) Finality(number uint64) Finality {
+	switch {
+	case number <= h.Finalized:
+		return FinalityFinalized
+	case number <= h.Safe:
+		return FinalitySafe
+	default:
+		return FinalityPending
+	}
+}
@@ -0,0 +1,51 @@
+package models
+
+import "testing"
+
+func TestParseFinality(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    Finality
+		wantErr bool
+	}{
+		{input: "", want: FinalityLatest},
+		{input: "latest", want: FinalityLatest},
+		{input: "safe", want: FinalitySafe},
+		{input: "finalized", want: FinalityFinalized},
+		{input: "pending", wantErr: true},
+		{input: "FINALIZED", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseFinality(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseFinality(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseFinality(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFinalityHeads_Finality(t *testing.T) {
+	heads := FinalityHeads{Safe: 120, Finalized: 100}
+
+	tests := []struct {
+		number uint64
+		want   Finality
+	}{
+		{number: 0, want: FinalityFinalized},
+		{number: 100, want: FinalityFinalized},
+		{number: 101, want: FinalitySafe},
+		{number: 120, want: FinalitySafe},
+		{number: 121, want: FinalityPending},
+	}
+
+	for _, tt := range tests {
+		if got := heads.Finality(tt.number); got != tt.want {
+			t.Errorf("Finality(%d) = %q, want %q", tt.number, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package models
+
+import "strings"
+
+// CallType represents the kind of call an internal transaction was made with
+type CallType string
+
+const (
+	// CallTypeCall represents a regular message call
+	CallTypeCall CallType = "call"
+
+	// CallTypeCallCode represents a legacy CALLCODE
+	CallTypeCallCode CallType = "callcode"
+
+	// CallTypeDelegateCall represents a DELEGATECALL, which runs the callee's
+	// code in the caller's context
+	CallTypeDelegateCall CallType = "delegatecall"
+
+	// CallTypeStaticCall represents a read-only STATICCALL
+	CallTypeStaticCall CallType = "staticcall"
+
+	// CallTypeCreate represents a contract creation with CREATE
+	CallTypeCreate CallType = "create"
+
+	// CallTypeCreate2 represents a contract creation with CREATE2
+	CallTypeCreate2 CallType = "create2"
+
+	// CallTypeSelfDestruct represents a SELFDESTRUCT sending the remaining
+	// balance of a contract to a beneficiary
+	CallTypeSelfDestruct CallType = "selfdestruct"
+)
+
+// String returns the string representation of CallType
+func (c CallType) String() string {
+	return string(c)
+}
+
+// IsValid checks if the call type is valid
+func (c CallType) IsValid() bool {
+	switch c {
+	case CallTypeCall, CallTypeCallCode, CallTypeDelegateCall, CallTypeStaticCall,
+		CallTypeCreate, CallTypeCreate2, CallTypeSelfDestruct:
+		return true
+	default:
+		return false
+	}
+}
+
+// InternalTransaction represents a call a contract made while a transaction
+// executed, as reported by the node's trace API. Value moved by internal
+// transactions does not show up in the transaction itself or its logs.
+type InternalTransaction struct {
+	Type  CallType `json:"type"`
+	From  string   `json:"from"`
+	To    string   `json:"to,omitempty"` // Created contract for creations
+	Value string   `json:"value"`        // Amount moved (as string to handle big numbers)
+
+	Gas     uint64 `json:"gas"`
+	GasUsed uint64 `json:"gas_used"`
+	Input   []byte `json:"input,omitempty"`
+
+	// Error is the reason the call failed. State changes of failed calls
+	// and of the calls they made are reverted.
+	Error string `json:"error,omitempty"`
+
+	// TraceAddress is the position of the call in the transaction's call
+	// tree: the index of the call among its siblings at each depth
+	TraceAddress []uint64 `json:"trace_address"`
+}
+
+// Depth returns how deep the call is nested below the transaction itself,
+// starting at 1
+func (i *InternalTransaction) Depth() int {
+	return len(i.TraceAddress)
+}
+
+// Failed reports whether the call reverted
+func (i *InternalTransaction) Failed() bool {
+	return i.Error != ""
+}
+
+// InternalAddresses returns the addresses that took part in the internal
+// transactions of t other than its sender and receiver, each once. Hex
+// addresses are compared case-insensitively.
+func (t *Transaction) InternalAddresses() []string {
+	seen := map[string]bool{
+		strings.ToLower(t.From): true,
+		strings.ToLower(t.To):   true,
+		"":                      true,
+	}
+
+	addresses := make([]string, 0)
+	for _, internal := range t.InternalTransactions {
+		if internal == nil {
+			continue
+		}
+		for _, address := range []string{internal.From, internal.To} {
+			if key := strings.ToLower(address); !seen[key] {
+				seen[key] = true
+				addresses = append(addresses, address)
+			}
+		}
+	}
+
+	return addresses
+}
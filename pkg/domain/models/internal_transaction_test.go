@@ -0,0 +1,39 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCallType_IsValid(t *testing.T) {
+	for _, callType := range []CallType{CallTypeCall, CallTypeCallCode, CallTypeDelegateCall, CallTypeStaticCall, CallTypeCreate, CallTypeCreate2, CallTypeSelfDestruct} {
+		if !callType.IsValid() {
+			t.Errorf("%s.IsValid() = false, want true", callType)
+		}
+	}
+	if CallType("reward").IsValid() {
+		t.Error("reward.IsValid() = true, want false")
+	}
+}
+
+func TestTransaction_InternalAddresses(t *testing.T) {
+	tx := &Transaction{
+		From: "0x1111111111111111111111111111111111111111",
+		To:   "0xAbCdEf0000000000000000000000000000000000",
+		InternalTransactions: []*InternalTransaction{
+			{Type: CallTypeCall, From: "0xabcdef0000000000000000000000000000000000", To: "0x2222222222222222222222222222222222222222", TraceAddress: []uint64{0}},
+			nil,
+			{Type: CallTypeCreate, From: "0x2222222222222222222222222222222222222222", To: "0x3333333333333333333333333333333333333333", TraceAddress: []uint64{0, 0}},
+			{Type: CallTypeSelfDestruct, From: "0x3333333333333333333333333333333333333333", To: "0x1111111111111111111111111111111111111111", TraceAddress: []uint64{0, 0, 0}},
+		},
+	}
+
+	want := []string{"0x2222222222222222222222222222222222222222", "0x3333333333333333333333333333333333333333"}
+	if got := tx.InternalAddresses(); !reflect.DeepEqual(got, want) {
+		t.Errorf("InternalAddresses() = %v, want %v", got, want)
+	}
+
+	if depth := tx.InternalTransactions[2].Depth(); depth != 2 {
+		t.Errorf("Depth() = %d, want 2", depth)
+	}
+}
@@ -0,0 +1,103 @@
+package models
+
+import "strings"
+
+// TokenStandard represents the token standard a transfer was made under
+type TokenStandard string
+
+const (
+	// TokenStandardERC20 represents fungible ERC-20 tokens
+	TokenStandardERC20 TokenStandard = "erc20"
+
+	// TokenStandardERC721 represents non-fungible ERC-721 tokens
+	TokenStandardERC721 TokenStandard = "erc721"
+
+	// TokenStandardERC1155 represents ERC-1155 multi tokens
+	TokenStandardERC1155 TokenStandard = "erc1155"
+)
+
+// String returns the string representation of TokenStandard
+func (s TokenStandard) String() string {
+	return string(s)
+}
+
+// IsValid checks if the token standard is valid
+func (s TokenStandard) IsValid() bool {
+	switch s {
+	case TokenStandardERC20, TokenStandardERC721, TokenStandardERC1155:
+		return true
+	default:
+		return false
+	}
+}
+
+// TokenTransfer represents a movement of tokens decoded from a transfer
+// event. Mints come from and burns go to the zero address.
+type TokenTransfer struct {
+	ChainID  string        `json:"chain_id"`
+	Standard TokenStandard `json:"standard"`
+	Token    string        `json:"token"` // Token contract address
+
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Operator string `json:"operator,omitempty"` // Account that moved the tokens (ERC-1155)
+
+	Value   string `json:"value"`              // Amount moved (as string to handle big numbers), 1 for ERC-721
+	TokenID string `json:"token_id,omitempty"` // Token ID (ERC-721 and ERC-1155)
+
+	// Position of the event in the chain. BatchIndex is the position of the
+	// transfer within an ERC-1155 TransferBatch event and 0 otherwise.
+	BlockNumber uint64     `json:"block_number"`
+	BlockHash   string     `json:"block_hash"`
+	TxHash      string     `json:"tx_hash"`
+	TxIndex     uint64     `json:"tx_index"`
+	LogIndex    uint64     `json:"log_index"`
+	BatchIndex  uint64     `json:"batch_index,omitempty"`
+	Timestamp   *Timestamp `json:"timestamp,omitempty"`
+}
+
+// TokenTransferFilter represents filtering criteria for token transfer
+// queries. Address matches transfers sent or received by the address.
+type TokenTransferFilter struct {
+	ChainID   string         `json:"chain_id"`
+	Address   *string        `json:"address,omitempty"`
+	Token     *string        `json:"token,omitempty"`
+	Standard  *TokenStandard `json:"standard,omitempty"`
+	FromBlock *uint64        `json:"from_block,omitempty"`
+	ToBlock   *uint64        `json:"to_block,omitempty"`
+}
+
+// Validate validates the token transfer filter
+func (f *TokenTransferFilter) Validate() error {
+	if f.ChainID == "" {
+		return ErrInvalidChainID
+	}
+	if f.Standard != nil && !f.Standard.IsValid() {
+		return ErrInvalidTokenStandard
+	}
+	if f.FromBlock != nil && f.ToBlock != nil && *f.FromBlock > *f.ToBlock {
+		return ErrInvalidBlockRange
+	}
+	return nil
+}
+
+// Matches reports whether a transfer satisfies the filter. Hex addresses
+// are compared case-insensitively.
+func (f *TokenTransferFilter) Matches(transfer *TokenTransfer) bool {
+	if f.FromBlock != nil && transfer.BlockNumber < *f.FromBlock {
+		return false
+	}
+	if f.ToBlock != nil && transfer.BlockNumber > *f.ToBlock {
+		return false
+	}
+	if f.Address != nil && !strings.EqualFold(*f.Address, transfer.From) && !strings.EqualFold(*f.Address, transfer.To) {
+		return false
+	}
+	if f.Token != nil && !strings.EqualFold(*f.Token, transfer.Token) {
+		return false
+	}
+	if f.Standard != nil && *f.Standard != transfer.Standard {
+		return false
+	}
+	return true
+}
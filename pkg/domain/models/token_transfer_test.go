@@ -0,0 +1,80 @@
+package models
+
+import "testing"
+
+func TestTokenStandard_IsValid(t *testing.T) {
+	for _, standard := range []TokenStandard{TokenStandardERC20, TokenStandardERC721, TokenStandardERC1155} {
+		if !standard.IsValid() {
+			t.Errorf("%s.IsValid() = false, want true", standard)
+		}
+	}
+	if TokenStandard("bep20").IsValid() {
+		t.Error("bep20.IsValid() = true, want false")
+	}
+}
+
+func TestTokenTransferFilter_Matches(t *testing.T) {
+	transfer := &TokenTransfer{
+		Standard:    TokenStandardERC20,
+		Token:       "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
+		From:        "0x1111111111111111111111111111111111111111",
+		To:          "0xAbCdEf0000000000000000000000000000000000",
+		Value:       "100",
+		BlockNumber: 100,
+	}
+	sender := "0x1111111111111111111111111111111111111111"
+	receiver := "0xabcdef0000000000000000000000000000000000"
+	token := "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"
+	other := "0x0000000000000000000000000000000000000000"
+	erc20, erc721 := TokenStandardERC20, TokenStandardERC721
+	from, to := uint64(101), uint64(99)
+
+	tests := []struct {
+		name   string
+		filter TokenTransferFilter
+		want   bool
+	}{
+		{"empty filter", TokenTransferFilter{ChainID: "ethereum"}, true},
+		{"sender", TokenTransferFilter{ChainID: "ethereum", Address: &sender}, true},
+		{"receiver ignores case", TokenTransferFilter{ChainID: "ethereum", Address: &receiver}, true},
+		{"other address", TokenTransferFilter{ChainID: "ethereum", Address: &other}, false},
+		{"token ignores case", TokenTransferFilter{ChainID: "ethereum", Token: &token}, true},
+		{"other token", TokenTransferFilter{ChainID: "ethereum", Token: &other}, false},
+		{"standard", TokenTransferFilter{ChainID: "ethereum", Standard: &erc20}, true},
+		{"other standard", TokenTransferFilter{ChainID: "ethereum", Standard: &erc721}, false},
+		{"before range", TokenTransferFilter{ChainID: "ethereum", FromBlock: &from}, false},
+		{"after range", TokenTransferFilter{ChainID: "ethereum", ToBlock: &to}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(transfer); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenTransferFilter_Validate(t *testing.T) {
+	invalid := TokenStandard("bep20")
+	from, to := uint64(10), uint64(5)
+
+	tests := []struct {
+		name    string
+		filter  TokenTransferFilter
+		wantErr error
+	}{
+		{"valid", TokenTransferFilter{ChainID: "ethereum"}, nil},
+		{"missing chain", TokenTransferFilter{}, ErrInvalidChainID},
+		{"invalid standard", TokenTransferFilter{ChainID: "ethereum", Standard: &invalid}, ErrInvalidTokenStandard},
+		{"inverted range", TokenTransferFilter{ChainID: "ethereum", FromBlock: &from, ToBlock: &to}, ErrInvalidBlockRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.filter.Validate(); err != tt.wantErr {
+				t.Errorf("Validate() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
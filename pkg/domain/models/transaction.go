@@ -2,6 +2,7 @@ package models
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 )
 
@@ -44,6 +45,16 @@ type Transaction struct {
 	ContractAddress string   `json:"contract_address,omitempty"` // Created contract address
 	Logs            []*Log   `json:"logs,omitempty"`             // Transaction logs/events
 
+	// Token movements decoded from the logs
+	TokenTransfers []*TokenTransfer `json:"token_transfers,omitempty"`
+
+	// Calls made by contracts during execution, from the node's trace API
+	InternalTransactions []*InternalTransaction `json:"internal_transactions,omitempty"`
+
+	// Spent and created outputs (UTXO chains)
+	Inputs  []*TxInput  `json:"inputs,omitempty"`
+	Outputs []*TxOutput `json:"outputs,omitempty"`
+
 	// Timestamps
 	Timestamp *Timestamp `json:"timestamp"` // Transaction timestamp
 
@@ -78,7 +89,10 @@ func (t *Transaction) Validate() error {
 	if t.Hash == "" {
 		return ErrInvalidTxHash
 	}
-	if t.From == "" {
+	// Coinbase transactions, UTXO transactions whose inputs carry no
+	// address, unsigned extrinsics and Avalanche imports and rewards have
+	// no sender
+	if t.From == "" && t.ChainType != ChainTypeBitcoin && t.ChainType != ChainTypePolkadot && t.ChainType != ChainTypeAvalanche {
 		return ErrInvalidFromAddress
 	}
 	if t.Timestamp == nil {
@@ -192,12 +206,15 @@ func (t *Transaction) ToSummary() *TransactionSummary {
 	}
 }
 
-// TransactionFilter represents filtering criteria for transaction queries
+// TransactionFilter represents filtering criteria for transaction queries.
+// Address matches the transactions in an address's history, whether it sent,
+// received or otherwise took part in them.
 type TransactionFilter struct {
 	ChainType      *ChainType `json:"chain_type,omitempty"`
 	ChainID        *string    `json:"chain_id,omitempty"`
 	BlockNumberMin *uint64    `json:"block_number_min,omitempty"`
 	BlockNumberMax *uint64    `json:"block_number_max,omitempty"`
+	Address        *string    `json:"address,omitempty"`
 	From           *string    `json:"from,omitempty"`
 	To             *string    `json:"to,omitempty"`
 	Status         *TxStatus  `json:"status,omitempty"`
@@ -207,10 +224,74 @@ type TransactionFilter struct {
 
 // Log represents a transaction log/event
 type Log struct {
-	Index   uint64   `json:"index"`   // Log index within transaction
+	Index   uint64   `json:"index"`   // Log index within block (EVM) or transaction
 	Address string   `json:"address"` // Contract address that emitted the log
 	Topics  []string `json:"topics"`  // Event topics
 	Data    []byte   `json:"data"`    // Event data
+
+	// Position of the emitting transaction, set when the log is returned on
+	// its own rather than as part of a transaction
+	BlockNumber uint64 `json:"block_number,omitempty"`
+	BlockHash   string `json:"block_hash,omitempty"`
+	TxHash      string `json:"tx_hash,omitempty"`
+	TxIndex     uint64 `json:"tx_index,omitempty"`
+}
+
+// LogFilter represents filtering criteria for log queries. Topics are
+// matched by position like eth_getLogs: an empty position matches any
+// topic and a position with several values matches any of them.
+type LogFilter struct {
+	ChainID   string     `json:"chain_id"`
+	Address   *string    `json:"address,omitempty"`
+	Topics    [][]string `json:"topics,omitempty"`
+	FromBlock *uint64    `json:"from_block,omitempty"`
+	ToBlock   *uint64    `json:"to_block,omitempty"`
+}
+
+// Validate validates the log filter
+func (f *LogFilter) Validate() error {
+	if f.ChainID == "" {
+		return ErrInvalidChainID
+	}
+	if f.FromBlock != nil && f.ToBlock != nil && *f.FromBlock > *f.ToBlock {
+		return ErrInvalidBlockRange
+	}
+	return nil
+}
+
+// Matches reports whether a log satisfies the filter. Hex addresses and
+// topics are compared case-insensitively.
+func (f *LogFilter) Matches(log *Log) bool {
+	if f.FromBlock != nil && log.BlockNumber < *f.FromBlock {
+		return false
+	}
+	if f.ToBlock != nil && log.BlockNumber > *f.ToBlock {
+		return false
+	}
+	if f.Address != nil && !strings.EqualFold(*f.Address, log.Address) {
+		return false
+	}
+
+	for i, values := range f.Topics {
+		if len(values) == 0 {
+			continue
+		}
+		if i >= len(log.Topics) {
+			return false
+		}
+		matched := false
+		for _, value := range values {
+			if strings.EqualFold(value, log.Topics[i]) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return true
 }
 
 // NewLog creates a new Log
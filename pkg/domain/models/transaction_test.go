@@ -103,6 +103,17 @@ func TestTransaction_Validate(t *testing.T) {
 			},
 			wantErr: ErrInvalidFromAddress,
 		},
+		{
+			name: "coinbase without from address",
+			tx: &Transaction{
+				ChainType: ChainTypeBitcoin,
+				ChainID:   "bitcoin",
+				Hash:      "txcoinbase",
+				From:      "",
+				Timestamp: NewTimestamp(time.Now().Unix()),
+			},
+			wantErr: nil,
+		},
 		{
 			name: "nil timestamp",
 			tx: &Transaction{
@@ -360,3 +371,53 @@ func BenchmarkTransaction_MarshalJSON(b *testing.B) {
 		_, _ = json.Marshal(tx)
 	}
 }
+
+func TestLogFilter_Matches(t *testing.T) {
+	log := &Log{
+		Index:       1,
+		Address:     "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
+		Topics:      []string{"0xddf2", "0x0001"},
+		BlockNumber: 100,
+	}
+	address := "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"
+	other := "0x0000000000000000000000000000000000000000"
+	from, to := uint64(101), uint64(99)
+
+	tests := []struct {
+		name   string
+		filter LogFilter
+		want   bool
+	}{
+		{"empty filter", LogFilter{ChainID: "ethereum"}, true},
+		{"address ignores case", LogFilter{ChainID: "ethereum", Address: &address}, true},
+		{"other address", LogFilter{ChainID: "ethereum", Address: &other}, false},
+		{"topic0 any of", LogFilter{ChainID: "ethereum", Topics: [][]string{{"0xaaaa", "0xDDF2"}}}, true},
+		{"wildcard topic0", LogFilter{ChainID: "ethereum", Topics: [][]string{nil, {"0x0001"}}}, true},
+		{"topic1 mismatch", LogFilter{ChainID: "ethereum", Topics: [][]string{nil, {"0x0002"}}}, false},
+		{"missing topic position", LogFilter{ChainID: "ethereum", Topics: [][]string{nil, nil, {"0x0001"}}}, false},
+		{"before range", LogFilter{ChainID: "ethereum", FromBlock: &from}, false},
+		{"after range", LogFilter{ChainID: "ethereum", ToBlock: &to}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(log); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogFilter_Validate(t *testing.T) {
+	from, to := uint64(10), uint64(5)
+
+	if err := (&LogFilter{}).Validate(); err != ErrInvalidChainID {
+		t.Errorf("Validate() without chain = %v, want %v", err, ErrInvalidChainID)
+	}
+	if err := (&LogFilter{ChainID: "ethereum", FromBlock: &from, ToBlock: &to}).Validate(); err != ErrInvalidBlockRange {
+		t.Errorf("Validate() with inverted range = %v, want %v", err, ErrInvalidBlockRange)
+	}
+	if err := (&LogFilter{ChainID: "ethereum", FromBlock: &to, ToBlock: &from}).Validate(); err != nil {
+		t.Errorf("Validate() = %v, want nil", err)
+	}
+}
@@ -23,6 +23,13 @@ const (
 
 	// ChainTypeRipple represents Ripple (XRPL) blockchain
 	ChainTypeRipple ChainType = "ripple"
+
+	// ChainTypeBitcoin represents Bitcoin and other UTXO blockchains
+	ChainTypeBitcoin ChainType = "bitcoin"
+
+	// ChainTypeSim represents the in-process simulated chain used for tests
+	// and demos
+	ChainTypeSim ChainType = "sim"
 )
 
 // String returns the string representation of ChainType
@@ -34,7 +41,7 @@ func (c ChainType) String() string {
 func (c ChainType) IsValid() bool {
 	switch c {
 	case ChainTypeEVM, ChainTypeSolana, ChainTypeCosmos,
-		ChainTypePolkadot, ChainTypeAvalanche, ChainTypeRipple:
+		ChainTypePolkadot, ChainTypeAvalanche, ChainTypeRipple, ChainTypeBitcoin, ChainTypeSim:
 		return true
 	default:
 		return false
@@ -106,3 +113,14 @@ func NewTimestampWithSlot(unix int64, slot uint64) *Timestamp {
 func TimeFromUnix(unix int64) time.Time {
 	return time.Unix(unix, 0)
 }
+
+// BlockRange represents an inclusive range of block numbers
+type BlockRange struct {
+	Start uint64 `json:"start"`
+	End   uint64 `json:"end"`
+}
+
+// Size returns the number of blocks in the range
+func (r *BlockRange) Size() uint64 {
+	return r.End - r.Start + 1
+}
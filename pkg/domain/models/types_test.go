@@ -41,6 +41,16 @@ func TestChainType_String(t *testing.T) {
 			chainType: ChainTypeRipple,
 			want:     "ripple",
 		},
+		{
+			name:     "Bitcoin chain type",
+			chainType: ChainTypeBitcoin,
+			want:     "bitcoin",
+		},
+		{
+			name:     "Sim chain type",
+			chainType: ChainTypeSim,
+			want:     "sim",
+		},
 	}
 
 	for _, tt := range tests {
@@ -73,6 +83,16 @@ func TestChainType_IsValid(t *testing.T) {
 			chainType: ChainTypeCosmos,
 			want:     true,
 		},
+		{
+			name:     "valid Bitcoin",
+			chainType: ChainTypeBitcoin,
+			want:     true,
+		},
+		{
+			name:     "valid Sim",
+			chainType: ChainTypeSim,
+			want:     true,
+		},
 		{
 			name:     "invalid chain type",
 			chainType: ChainType("invalid"),
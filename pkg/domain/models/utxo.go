@@ -0,0 +1,76 @@
+package models
+
+// TxInput represents an input of a UTXO transaction, which spends an output
+// of an earlier transaction
+type TxInput struct {
+	Index uint32 `json:"index"` // Position among the transaction's inputs
+
+	// Output being spent. Coinbase inputs spend no output and have an
+	// empty PrevTxHash.
+	PrevTxHash      string `json:"prev_tx_hash,omitempty"`
+	PrevOutputIndex uint32 `json:"prev_output_index"`
+
+	// Address and value of the spent output, resolved from the transaction
+	// that created it. Address is empty for outputs without a standard
+	// address and Value is empty if the output could not be resolved.
+	Address string `json:"address,omitempty"`
+	Value   string `json:"value,omitempty"`
+
+	Sequence uint32 `json:"sequence"`
+	Coinbase []byte `json:"coinbase,omitempty"` // Coinbase data of coinbase inputs
+}
+
+// IsCoinbase reports whether the input creates new coins instead of
+// spending an output
+func (i *TxInput) IsCoinbase() bool {
+	return i.PrevTxHash == ""
+}
+
+// IsResolved reports whether the spent output's address and value are known
+func (i *TxInput) IsResolved() bool {
+	return i.Value != ""
+}
+
+// TxOutput represents an output created by a UTXO transaction
+type TxOutput struct {
+	Index uint32 `json:"index"` // Position among the transaction's outputs
+
+	// Address is empty for outputs without a standard address, such as
+	// OP_RETURN data outputs and bare multisig
+	Address string `json:"address,omitempty"`
+	Value   string `json:"value"` // Amount in the smallest unit (as string to handle big numbers)
+
+	ScriptType string `json:"script_type,omitempty"` // e.g. pubkeyhash, witness_v0_keyhash, nulldata
+	Script     []byte `json:"script,omitempty"`      // Locking script
+}
+
+// UTXOAddresses returns the addresses of the spent and created outputs of t
+// other than its sender and receiver, each once. UTXO addresses are
+// case-sensitive and compared as is.
+func (t *Transaction) UTXOAddresses() []string {
+	seen := map[string]bool{
+		t.From: true,
+		t.To:   true,
+		"":     true,
+	}
+
+	addresses := make([]string, 0)
+	add := func(address string) {
+		if !seen[address] {
+			seen[address] = true
+			addresses = append(addresses, address)
+		}
+	}
+	for _, input := range t.Inputs {
+		if input != nil {
+			add(input.Address)
+		}
+	}
+	for _, output := range t.Outputs {
+		if output != nil {
+			add(output.Address)
+		}
+	}
+
+	return addresses
+}
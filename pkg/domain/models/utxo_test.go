@@ -0,0 +1,47 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTxInput_IsCoinbase(t *testing.T) {
+	coinbase := &TxInput{Coinbase: []byte{0x03, 0x01}}
+	if !coinbase.IsCoinbase() || coinbase.IsResolved() {
+		t.Errorf("coinbase input: IsCoinbase() = %v, IsResolved() = %v", coinbase.IsCoinbase(), coinbase.IsResolved())
+	}
+
+	spend := &TxInput{PrevTxHash: "ab", PrevOutputIndex: 1, Address: "bc1qsender", Value: "5000"}
+	if spend.IsCoinbase() || !spend.IsResolved() {
+		t.Errorf("spending input: IsCoinbase() = %v, IsResolved() = %v", spend.IsCoinbase(), spend.IsResolved())
+	}
+}
+
+func TestTransaction_UTXOAddresses(t *testing.T) {
+	tx := &Transaction{
+		From: "bc1qsender",
+		To:   "bc1qreceiver",
+		Inputs: []*TxInput{
+			{Index: 0, PrevTxHash: "aa", Address: "bc1qsender", Value: "1000"},
+			{Index: 1, PrevTxHash: "bb", Address: "3OtherSender", Value: "2000"},
+			nil,
+		},
+		Outputs: []*TxOutput{
+			{Index: 0, Address: "bc1qreceiver", Value: "1500"},
+			{Index: 1, Value: "0", ScriptType: "nulldata"},
+			{Index: 2, Address: "bc1qchange", Value: "1000"},
+			{Index: 3, Address: "3OtherSender", Value: "400"},
+		},
+	}
+
+	want := []string{"3OtherSender", "bc1qchange"}
+	if got := tx.UTXOAddresses(); !reflect.DeepEqual(got, want) {
+		t.Errorf("UTXOAddresses() = %v, want %v", got, want)
+	}
+
+	// Addresses differing only in case are distinct
+	tx.Outputs = append(tx.Outputs, &TxOutput{Index: 4, Address: "BC1QSENDER", Value: "1"})
+	if got := tx.UTXOAddresses(); len(got) != 3 || got[2] != "BC1QSENDER" {
+		t.Errorf("UTXOAddresses() = %v, want BC1QSENDER last", got)
+	}
+}
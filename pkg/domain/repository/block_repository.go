@@ -15,6 +15,7 @@ type BlockRepository interface {
 	GetBlocks(ctx context.Context, chainID string, start, end uint64) ([]*models.Block, error)
 	GetLatestBlock(ctx context.Context, chainID string) (*models.Block, error)
 	GetLatestHeight(ctx context.Context, chainID string) (uint64, error)
+	GetRecentBlocks(ctx context.Context, chainID string, limit int) ([]*models.Block, error)
 	HasBlock(ctx context.Context, chainID string, number uint64) (bool, error)
 
 	// Query operations with filtering and pagination
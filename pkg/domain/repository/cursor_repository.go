@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+)
+
+// CursorRepository defines the interface for persisted indexing cursors
+// A cursor is the highest block below which every block has been committed,
+// which makes it a safe point to resume indexing from after a restart
+type CursorRepository interface {
+	// GetCursor returns the contiguously committed block height for a chain
+	GetCursor(ctx context.Context, chainID string) (uint64, error)
+
+	// SaveCursor persists the contiguously committed block height for a chain
+	SaveCursor(ctx context.Context, chainID string, blockNumber uint64) error
+
+	// DeleteCursor removes the cursor so indexing restarts from the configured start block
+	DeleteCursor(ctx context.Context, chainID string) error
+}
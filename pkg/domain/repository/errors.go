@@ -19,6 +19,7 @@ var (
 	ErrBlockNotFound       = errors.New("block not found")
 	ErrTransactionNotFound = errors.New("transaction not found")
 	ErrChainNotFound       = errors.New("chain not found")
+	ErrCursorNotFound      = errors.New("cursor not found")
 
 	// Batch errors
 	ErrBatchTooLarge       = errors.New("batch too large")
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+)
+
+// LogRepository defines the interface for querying event logs. Logs are
+// written together with the transactions that emitted them and indexed by
+// emitting contract address and by their first topic (the event signature).
+type LogRepository interface {
+	// QueryLogs returns the logs matching the filter in chain order
+	QueryLogs(ctx context.Context, filter *models.LogFilter, pagination *models.PaginationOptions) ([]*models.Log, error)
+}
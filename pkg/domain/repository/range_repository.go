@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+)
+
+// IndexedRangeRepository defines the interface for the per-chain record of
+// which blocks are stored. Stored blocks are kept as runs of contiguous block
+// numbers that are updated whenever blocks are committed or deleted, so
+// gaps can be found without reading the blocks themselves.
+type IndexedRangeRepository interface {
+	// GetIndexedRanges returns the runs of stored blocks for a chain,
+	// lowest first. Runs never overlap or touch.
+	GetIndexedRanges(ctx context.Context, chainID string) ([]*models.BlockRange, error)
+}
@@ -12,22 +12,33 @@ import (
 type Storage interface {
 	BlockRepository
 	TransactionRepository
+	LogRepository
+	TokenTransferRepository
 	ChainRepository
+	CursorRepository
+	IndexedRangeRepository
 
 	// Lifecycle methods
 	Close() error
 
 	// Transaction support
-	NewBatch() Batch
+	BatchProvider
 
 	// Statistics
 	GetStats(ctx context.Context) (*StorageStats, error)
 }
 
+// BatchProvider creates batches for atomic multi-record writes
+type BatchProvider interface {
+	NewBatch() Batch
+}
+
 // Batch provides atomic batch write operations
 // Following the Interface Segregation Principle
 type Batch interface {
 	// Block operations
+	// Setting a block also raises the chain's latest height and adds the
+	// block to the chain's indexed ranges on commit
 	SetBlock(ctx context.Context, block *models.Block) error
 	SetBlocks(ctx context.Context, blocks []*models.Block) error
 
@@ -35,6 +46,18 @@ type Batch interface {
 	SetTransaction(ctx context.Context, tx *models.Transaction) error
 	SetTransactions(ctx context.Context, txs []*models.Transaction) error
 
+	// Range operations
+	// Marking a range as indexed adds it to the chain's indexed ranges on
+	// commit even where it holds no block, such as skipped Solana slots
+	SetIndexedRange(ctx context.Context, chainID string, start, end uint64) error
+
+	// Delete operations
+	// Deleting a block also removes it from the chain's indexed ranges and
+	// lowers the chain's latest height to the highest block left on commit.
+	// Operations apply in the order they were added.
+	DeleteBlock(ctx context.Context, block *models.Block) error
+	DeleteTransaction(ctx context.Context, tx *models.Transaction) error
+
 	// Commit writes all batched operations atomically
 	Commit() error
 
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+)
+
+// TokenTransferRepository defines the interface for querying token
+// transfers. Transfers are written together with the transactions that
+// emitted them and indexed by sending and receiving address and by token.
+type TokenTransferRepository interface {
+	// QueryTokenTransfers returns the transfers matching the filter in chain order
+	QueryTokenTransfers(ctx context.Context, filter *models.TokenTransferFilter, pagination *models.PaginationOptions) ([]*models.TokenTransfer, error)
+}
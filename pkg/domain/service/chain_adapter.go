@@ -9,18 +9,34 @@ import (
 // ChainAdapter defines the interface for interacting with different blockchain types
 // Following the Adapter Pattern and Dependency Inversion Principle
 // Each blockchain implementation (EVM, Solana, Cosmos, etc.) must implement this interface
+//
+// Unknown blocks and transactions are reported with errors wrapping
+// models.ErrBlockNotFound and models.ErrTransactionNotFound. GetBlocks returns
+// the blocks from start in ascending order; it may stop before end to limit
+// the range and leaves out numbers without a block, such as skipped Solana
+// slots. Operations an adapter does not support, as reported by
+// Capabilities, fail with an error wrapping ErrNotSupported. The adaptertest
+// package checks adapters against this contract.
 type ChainAdapter interface {
 	// Chain information
 	GetChainType() models.ChainType
 	GetChainID() string
 	GetChainInfo() *models.ChainInfo
 
+	// Capabilities reports the optional features the adapter supports with
+	// its current configuration
+	Capabilities() models.Capabilities
+
 	// Block operations
 	GetLatestBlockNumber(ctx context.Context) (uint64, error)
 	GetBlockByNumber(ctx context.Context, number uint64) (*models.Block, error)
 	GetBlockByHash(ctx context.Context, hash string) (*models.Block, error)
 	GetBlocks(ctx context.Context, start, end uint64) ([]*models.Block, error)
 
+	// Finality reports the highest safe and finalized blocks of the chain,
+	// no higher than the latest block
+	GetFinalityHeads(ctx context.Context) (*models.FinalityHeads, error)
+
 	// Transaction operations
 	GetTransaction(ctx context.Context, hash string) (*models.Transaction, error)
 	GetTransactionsByBlock(ctx context.Context, blockNumber uint64) ([]*models.Transaction, error)
@@ -30,7 +46,9 @@ type ChainAdapter interface {
 	Connect(ctx context.Context) error
 	Disconnect() error
 
-	// Subscription support (optional, can return nil if not supported)
+	// Subscription support (optional, returns an error if not supported).
+	// The subscription channel is closed when the context is done or the
+	// subscription is cancelled.
 	SubscribeNewBlocks(ctx context.Context) (BlockSubscription, error)
 	SubscribeNewTransactions(ctx context.Context) (TransactionSubscription, error)
 }
@@ -43,7 +61,10 @@ type BlockSubscription interface {
 	// Unsubscribe cancels the subscription
 	Unsubscribe()
 
-	// Err returns any subscription error
+	// Err returns the channel that receives the error that ended the
+	// subscription. Errors the subscription recovers from, such as a
+	// dropped connection it re-established or a block that failed to load,
+	// are not sent on it; the indexer fetches blocks left out that way.
 	Err() <-chan error
 }
 
@@ -9,6 +9,7 @@ var (
 	ErrAdapterNotHealthy   = errors.New("adapter not healthy")
 	ErrConnectionFailed    = errors.New("connection failed")
 	ErrSubscriptionFailed  = errors.New("subscription failed")
+	ErrNotSupported        = errors.New("not supported by the chain adapter")
 
 	// Indexer errors
 	ErrIndexerNotRunning   = errors.New("indexer not running")
@@ -63,6 +63,9 @@ type IndexerConfig struct {
 
 	// Enable real-time indexing
 	EnableRealtime bool
+
+	// Index blocks before they are confirmed, marked pending
+	IndexPending bool
 }
 
 // Validate validates the indexer configuration
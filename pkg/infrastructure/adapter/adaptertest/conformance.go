@@ -0,0 +1,586 @@
+// Package adaptertest checks that chain adapters meet the
+// service.ChainAdapter contract. Each adapter runs RunConformance against the
+// fake backend of its tests, so all adapters answer the same situations the
+// same way.
+package adaptertest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/service"
+)
+
+// timeout bounds every wait of the suite
+const timeout = 5 * time.Second
+
+// Factory creates a connected adapter for the backend. The factory registers
+// its cleanup with t.
+type Factory func(t *testing.T) service.ChainAdapter
+
+// Backend describes the chain served by an adapter's fake backend
+type Backend struct {
+	// ChainType and ChainID are what the adapter reports
+	ChainType models.ChainType
+	ChainID   string
+
+	// First and Last bound the blocks the suite fetches. Last must not be
+	// above the head.
+	First uint64
+	Last  uint64
+
+	// Skipped are the numbers between First and Last that have no block,
+	// like Solana's skipped slots
+	Skipped []uint64
+
+	// TxHash is the hash of a transaction in a block between First and Last
+	TxHash string
+
+	// TxBlockUnknown is set when the backend does not report the block of a
+	// transaction fetched by hash, like the Avalanche X-Chain and P-Chain
+	TxBlockUnknown bool
+
+	// UnknownBlockHash and UnknownTxHash are well-formed hashes the backend
+	// does not know
+	UnknownBlockHash string
+	UnknownTxHash    string
+
+	// Produce, if set, makes the backend produce a block on top of the head
+	// so subscriptions can be checked to deliver it
+	Produce func()
+}
+
+// skipped returns whether the backend has no block at number
+func (b Backend) skipped(number uint64) bool {
+	for _, s := range b.Skipped {
+		if s == number {
+			return true
+		}
+	}
+	return false
+}
+
+// RunConformance checks the service.ChainAdapter contract:
+//
+//   - blocks and transactions carry the chain type and ID of the adapter
+//   - a block fetched by hash is the block fetched by number, and TxCount,
+//     TxHashes and Transactions agree
+//   - ParentHash links each block to the previous one
+//   - GetBlocks returns blocks in ascending order starting at start, stops
+//     early only when the adapter limits the range, never skips an existing
+//     block and fails for a range past the head
+//   - unknown blocks and transactions fail with models.ErrBlockNotFound and
+//     models.ErrTransactionNotFound
+//   - the finalized head is no higher than the safe head, and the safe
+//     head no higher than the latest block
+//   - calls with a cancelled context fail with context.Canceled
+//   - operations the adapter's Capabilities leave out fail with
+//     service.ErrNotSupported, and GetBlocks covers MaxBlockRange numbers
+//   - a supported subscription's channel is closed when it is unsubscribed
+//     or its context is done
+func RunConformance(t *testing.T, factory Factory, backend Backend) {
+	t.Run("ChainInfo", func(t *testing.T) {
+		adapter := factory(t)
+
+		if adapter.GetChainType() != backend.ChainType {
+			t.Errorf("GetChainType() = %s, want %s", adapter.GetChainType(), backend.ChainType)
+		}
+		if adapter.GetChainID() != backend.ChainID {
+			t.Errorf("GetChainID() = %s, want %s", adapter.GetChainID(), backend.ChainID)
+		}
+
+		info := adapter.GetChainInfo()
+		if info == nil {
+			t.Fatal("GetChainInfo() = nil")
+		}
+		if info.ChainType != backend.ChainType || info.ChainID != backend.ChainID {
+			t.Errorf("GetChainInfo() = %s/%s, want %s/%s", info.ChainType, info.ChainID, backend.ChainType, backend.ChainID)
+		}
+	})
+
+	t.Run("LatestBlockNumber", func(t *testing.T) {
+		adapter := factory(t)
+
+		head, err := adapter.GetLatestBlockNumber(context.Background())
+		if err != nil {
+			t.Fatalf("GetLatestBlockNumber() error = %v", err)
+		}
+		if head < backend.Last {
+			t.Errorf("GetLatestBlockNumber() = %d, want at least %d", head, backend.Last)
+		}
+	})
+
+	t.Run("FinalityHeads", func(t *testing.T) {
+		adapter := factory(t)
+		ctx := context.Background()
+
+		heads, err := adapter.GetFinalityHeads(ctx)
+		if err != nil {
+			t.Fatalf("GetFinalityHeads() error = %v", err)
+		}
+		head, err := adapter.GetLatestBlockNumber(ctx)
+		if err != nil {
+			t.Fatalf("GetLatestBlockNumber() error = %v", err)
+		}
+		if heads.Finalized > heads.Safe || heads.Safe > head {
+			t.Errorf("GetFinalityHeads() = finalized %d, safe %d, want finalized <= safe <= latest %d", heads.Finalized, heads.Safe, head)
+		}
+	})
+
+	t.Run("BlockByNumber", func(t *testing.T) {
+		adapter := factory(t)
+
+		for number := backend.First; number <= backend.Last; number++ {
+			if backend.skipped(number) {
+				continue
+			}
+			block, err := adapter.GetBlockByNumber(context.Background(), number)
+			if err != nil {
+				t.Fatalf("GetBlockByNumber(%d) error = %v", number, err)
+			}
+			if block.Number != number {
+				t.Errorf("GetBlockByNumber(%d) returned block %d", number, block.Number)
+			}
+			checkBlock(t, backend, block)
+		}
+	})
+
+	t.Run("BlockByHash", func(t *testing.T) {
+		adapter := factory(t)
+		ctx := context.Background()
+
+		if !adapter.Capabilities().BlockByHash {
+			block, err := adapter.GetBlockByNumber(ctx, backend.Last)
+			if err != nil {
+				t.Fatalf("GetBlockByNumber(%d) error = %v", backend.Last, err)
+			}
+			if _, err := adapter.GetBlockByHash(ctx, block.Hash); !errors.Is(err, service.ErrNotSupported) {
+				t.Errorf("GetBlockByHash(%s) error = %v, want ErrNotSupported", block.Hash, err)
+			}
+			return
+		}
+
+		for number := backend.First; number <= backend.Last; number++ {
+			if backend.skipped(number) {
+				continue
+			}
+			want, err := adapter.GetBlockByNumber(ctx, number)
+			if err != nil {
+				t.Fatalf("GetBlockByNumber(%d) error = %v", number, err)
+			}
+
+			block, err := adapter.GetBlockByHash(ctx, want.Hash)
+			if err != nil {
+				t.Fatalf("GetBlockByHash(%s) error = %v", want.Hash, err)
+			}
+			if block.Number != want.Number || block.Hash != want.Hash || block.ParentHash != want.ParentHash {
+				t.Errorf("GetBlockByHash(%s) = block %d %s, want block %d", want.Hash, block.Number, block.Hash, want.Number)
+			}
+			if !equalHashes(block.TxHashes, want.TxHashes) {
+				t.Errorf("GetBlockByHash(%s) transactions = %v, want %v", want.Hash, block.TxHashes, want.TxHashes)
+			}
+			checkBlock(t, backend, block)
+		}
+	})
+
+	t.Run("ParentHash", func(t *testing.T) {
+		adapter := factory(t)
+
+		var previous *models.Block
+		for number := backend.First; number <= backend.Last; number++ {
+			if backend.skipped(number) {
+				continue
+			}
+			block, err := adapter.GetBlockByNumber(context.Background(), number)
+			if err != nil {
+				t.Fatalf("GetBlockByNumber(%d) error = %v", number, err)
+			}
+			if previous != nil && block.ParentHash != previous.Hash {
+				t.Errorf("block %d parent = %q, want the hash of block %d %q", number, block.ParentHash, previous.Number, previous.Hash)
+			}
+			previous = block
+		}
+	})
+
+	t.Run("BlockNotFound", func(t *testing.T) {
+		adapter := factory(t)
+		ctx := context.Background()
+
+		head, err := adapter.GetLatestBlockNumber(ctx)
+		if err != nil {
+			t.Fatalf("GetLatestBlockNumber() error = %v", err)
+		}
+		if _, err := adapter.GetBlockByNumber(ctx, head+1000); !errors.Is(err, models.ErrBlockNotFound) {
+			t.Errorf("GetBlockByNumber(head+1000) error = %v, want ErrBlockNotFound", err)
+		}
+		if !adapter.Capabilities().BlockByHash {
+			return
+		}
+		if _, err := adapter.GetBlockByHash(ctx, backend.UnknownBlockHash); !errors.Is(err, models.ErrBlockNotFound) {
+			t.Errorf("GetBlockByHash(unknown) error = %v, want ErrBlockNotFound", err)
+		}
+	})
+
+	t.Run("Transaction", func(t *testing.T) {
+		adapter := factory(t)
+		ctx := context.Background()
+
+		if !adapter.Capabilities().TransactionByHash {
+			if _, err := adapter.GetTransaction(ctx, backend.TxHash); !errors.Is(err, service.ErrNotSupported) {
+				t.Errorf("GetTransaction(%s) error = %v, want ErrNotSupported", backend.TxHash, err)
+			}
+			return
+		}
+
+		tx, err := adapter.GetTransaction(ctx, backend.TxHash)
+		if err != nil {
+			t.Fatalf("GetTransaction(%s) error = %v", backend.TxHash, err)
+		}
+		if tx.Hash != backend.TxHash {
+			t.Errorf("GetTransaction(%s) returned %s", backend.TxHash, tx.Hash)
+		}
+		checkTransaction(t, backend, tx)
+		if backend.TxBlockUnknown {
+			return
+		}
+
+		block, err := adapter.GetBlockByNumber(ctx, tx.BlockNumber)
+		if err != nil {
+			t.Fatalf("GetBlockByNumber(%d) error = %v", tx.BlockNumber, err)
+		}
+		if tx.BlockHash != block.Hash {
+			t.Errorf("transaction block hash = %s, want %s", tx.BlockHash, block.Hash)
+		}
+		if !containsHash(block.TxHashes, tx.Hash) {
+			t.Errorf("block %d does not list transaction %s", block.Number, tx.Hash)
+		}
+	})
+
+	t.Run("TransactionNotFound", func(t *testing.T) {
+		adapter := factory(t)
+		if !adapter.Capabilities().TransactionByHash {
+			t.Skip("adapter cannot look transactions up by hash")
+		}
+
+		if _, err := adapter.GetTransaction(context.Background(), backend.UnknownTxHash); !errors.Is(err, models.ErrTransactionNotFound) {
+			t.Errorf("GetTransaction(unknown) error = %v, want ErrTransactionNotFound", err)
+		}
+	})
+
+	t.Run("TransactionsByBlock", func(t *testing.T) {
+		adapter := factory(t)
+		ctx := context.Background()
+
+		for number := backend.First; number <= backend.Last; number++ {
+			if backend.skipped(number) {
+				continue
+			}
+			block, err := adapter.GetBlockByNumber(ctx, number)
+			if err != nil {
+				t.Fatalf("GetBlockByNumber(%d) error = %v", number, err)
+			}
+
+			txs, err := adapter.GetTransactionsByBlock(ctx, number)
+			if err != nil {
+				t.Fatalf("GetTransactionsByBlock(%d) error = %v", number, err)
+			}
+			hashes := make([]string, len(txs))
+			for i, tx := range txs {
+				hashes[i] = tx.Hash
+				checkTransaction(t, backend, tx)
+			}
+			if !equalHashes(hashes, block.TxHashes) {
+				t.Errorf("GetTransactionsByBlock(%d) = %v, want %v", number, hashes, block.TxHashes)
+			}
+		}
+	})
+
+	t.Run("Range", func(t *testing.T) {
+		adapter := factory(t)
+		ctx := context.Background()
+
+		blocks, err := adapter.GetBlocks(ctx, backend.First, backend.Last)
+		if err != nil {
+			t.Fatalf("GetBlocks(%d, %d) error = %v", backend.First, backend.Last, err)
+		}
+		if len(blocks) == 0 {
+			t.Fatalf("GetBlocks(%d, %d) returned no blocks", backend.First, backend.Last)
+		}
+
+		// Blocks are in order, without gaps other than skipped numbers; the
+		// adapter may return fewer blocks than asked for
+		next := backend.First
+		for _, block := range blocks {
+			for backend.skipped(next) {
+				next++
+			}
+			if block.Number != next {
+				t.Fatalf("GetBlocks(%d, %d) returned block %d, want %d", backend.First, backend.Last, block.Number, next)
+			}
+			next++
+
+			single, err := adapter.GetBlockByNumber(ctx, block.Number)
+			if err != nil {
+				t.Fatalf("GetBlockByNumber(%d) error = %v", block.Number, err)
+			}
+			if block.Hash != single.Hash || !equalHashes(block.TxHashes, single.TxHashes) {
+				t.Errorf("GetBlocks returned block %d %s, GetBlockByNumber %s", block.Number, block.Hash, single.Hash)
+			}
+			checkBlock(t, backend, block)
+		}
+
+		first := backend.First
+		for backend.skipped(first) {
+			first++
+		}
+		// The range is only cut short at MaxBlockRange numbers
+		if limit := adapter.Capabilities().MaxBlockRange; limit > 0 {
+			last := blocks[len(blocks)-1].Number
+			if last-backend.First >= limit {
+				t.Errorf("GetBlocks(%d, %d) returned block %d, past MaxBlockRange %d", backend.First, backend.Last, last, limit)
+			}
+			if backend.Last-backend.First < limit && next <= backend.Last && !backend.skipped(backend.Last) {
+				t.Errorf("GetBlocks(%d, %d) stopped at block %d within MaxBlockRange %d", backend.First, backend.Last, last, limit)
+			}
+		}
+
+		blocks, err = adapter.GetBlocks(ctx, first, first)
+		if err != nil {
+			t.Fatalf("GetBlocks(%d, %d) error = %v", first, first, err)
+		}
+		if len(blocks) != 1 || blocks[0].Number != first {
+			t.Errorf("GetBlocks(%d, %d) returned %d blocks, want block %d", first, first, len(blocks), first)
+		}
+	})
+
+	t.Run("InvalidRange", func(t *testing.T) {
+		adapter := factory(t)
+
+		if _, err := adapter.GetBlocks(context.Background(), backend.Last+1, backend.Last); err == nil {
+			t.Error("GetBlocks(end+1, end) error = nil, want an error")
+		}
+	})
+
+	t.Run("RangePastHead", func(t *testing.T) {
+		adapter := factory(t)
+		ctx := context.Background()
+
+		head, err := adapter.GetLatestBlockNumber(ctx)
+		if err != nil {
+			t.Fatalf("GetLatestBlockNumber() error = %v", err)
+		}
+		if _, err := adapter.GetBlocks(ctx, head+1000, head+1001); !errors.Is(err, models.ErrBlockNotFound) {
+			t.Errorf("GetBlocks(head+1000, head+1001) error = %v, want ErrBlockNotFound", err)
+		}
+	})
+
+	t.Run("ContextCancellation", func(t *testing.T) {
+		adapter := factory(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		calls := map[string]func() error{
+			"GetLatestBlockNumber": func() error {
+				_, err := adapter.GetLatestBlockNumber(ctx)
+				return err
+			},
+			"GetFinalityHeads": func() error {
+				_, err := adapter.GetFinalityHeads(ctx)
+				return err
+			},
+			"GetBlockByNumber": func() error {
+				_, err := adapter.GetBlockByNumber(ctx, backend.Last)
+				return err
+			},
+			"GetBlocks": func() error {
+				_, err := adapter.GetBlocks(ctx, backend.First, backend.Last)
+				return err
+			},
+			"GetTransaction": func() error {
+				_, err := adapter.GetTransaction(ctx, backend.TxHash)
+				return err
+			},
+		}
+		for _, name := range []string{"GetLatestBlockNumber", "GetFinalityHeads", "GetBlockByNumber", "GetBlocks", "GetTransaction"} {
+			start := time.Now()
+			err := calls[name]()
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("%s() with a cancelled context error = %v, want context.Canceled", name, err)
+			}
+			if elapsed := time.Since(start); elapsed > timeout {
+				t.Errorf("%s() with a cancelled context took %s", name, elapsed)
+			}
+		}
+	})
+
+	t.Run("BlockSubscription", func(t *testing.T) {
+		adapter := factory(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		if !adapter.Capabilities().BlockSubscription {
+			if _, err := adapter.SubscribeNewBlocks(ctx); !errors.Is(err, service.ErrNotSupported) {
+				t.Errorf("SubscribeNewBlocks() error = %v, want ErrNotSupported", err)
+			}
+			return
+		}
+
+		sub, err := adapter.SubscribeNewBlocks(ctx)
+		if err != nil {
+			t.Fatalf("SubscribeNewBlocks() error = %v", err)
+		}
+		if sub == nil {
+			t.Fatal("SubscribeNewBlocks() = nil, nil; refuse unsupported subscriptions with an error")
+		}
+
+		if backend.Produce != nil {
+			backend.Produce()
+			select {
+			case block, ok := <-sub.Channel():
+				if !ok {
+					t.Fatal("subscription closed before delivering a block")
+				}
+				if block.Number <= backend.Last {
+					t.Errorf("subscription delivered block %d, want a block after %d", block.Number, backend.Last)
+				}
+				checkBlock(t, backend, block)
+			case err := <-sub.Err():
+				t.Fatalf("subscription error = %v", err)
+			case <-time.After(timeout):
+				t.Fatal("subscription delivered no block")
+			}
+		}
+
+		// Cancelling the context closes the channel
+		cancel()
+		waitClosed(t, sub.Channel())
+
+		// Unsubscribing closes the channel and may be repeated
+		sub, err = adapter.SubscribeNewBlocks(context.Background())
+		if err != nil {
+			t.Fatalf("SubscribeNewBlocks() error = %v", err)
+		}
+		sub.Unsubscribe()
+		waitClosed(t, sub.Channel())
+		sub.Unsubscribe()
+	})
+
+	t.Run("TransactionSubscription", func(t *testing.T) {
+		adapter := factory(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		if !adapter.Capabilities().TransactionSubscription {
+			if _, err := adapter.SubscribeNewTransactions(ctx); !errors.Is(err, service.ErrNotSupported) {
+				t.Errorf("SubscribeNewTransactions() error = %v, want ErrNotSupported", err)
+			}
+			return
+		}
+
+		sub, err := adapter.SubscribeNewTransactions(ctx)
+		if err != nil {
+			t.Fatalf("SubscribeNewTransactions() error = %v", err)
+		}
+		if sub == nil {
+			t.Fatal("SubscribeNewTransactions() = nil, nil; refuse unsupported subscriptions with an error")
+		}
+
+		cancel()
+		waitClosed(t, sub.Channel())
+
+		sub, err = adapter.SubscribeNewTransactions(context.Background())
+		if err != nil {
+			t.Fatalf("SubscribeNewTransactions() error = %v", err)
+		}
+		sub.Unsubscribe()
+		waitClosed(t, sub.Channel())
+		sub.Unsubscribe()
+	})
+}
+
+// checkBlock checks that a block belongs to the backend's chain and that its
+// transaction fields agree
+func checkBlock(t *testing.T, backend Backend, block *models.Block) {
+	t.Helper()
+
+	if block.ChainType != backend.ChainType || block.ChainID != backend.ChainID {
+		t.Errorf("block %d chain = %s/%s, want %s/%s", block.Number, block.ChainType, block.ChainID, backend.ChainType, backend.ChainID)
+	}
+	if block.Hash == "" {
+		t.Errorf("block %d has no hash", block.Number)
+	}
+	if block.TxCount != len(block.TxHashes) {
+		t.Errorf("block %d TxCount = %d, but it lists %d transactions", block.Number, block.TxCount, len(block.TxHashes))
+	}
+
+	// Transactions are optional, but must match TxHashes when present
+	if len(block.Transactions) == 0 {
+		return
+	}
+	if len(block.Transactions) != len(block.TxHashes) {
+		t.Errorf("block %d has %d transactions and %d transaction hashes", block.Number, len(block.Transactions), len(block.TxHashes))
+		return
+	}
+	for i, tx := range block.Transactions {
+		if tx.Hash != block.TxHashes[i] {
+			t.Errorf("block %d transaction %d = %s, want %s", block.Number, i, tx.Hash, block.TxHashes[i])
+		}
+		if tx.BlockNumber != block.Number || tx.BlockHash != block.Hash {
+			t.Errorf("transaction %s is in block %d %s, want %d %s", tx.Hash, tx.BlockNumber, tx.BlockHash, block.Number, block.Hash)
+		}
+		checkTransaction(t, backend, tx)
+	}
+}
+
+// checkTransaction checks that a transaction belongs to the backend's chain
+func checkTransaction(t *testing.T, backend Backend, tx *models.Transaction) {
+	t.Helper()
+
+	if tx.ChainType != backend.ChainType || tx.ChainID != backend.ChainID {
+		t.Errorf("transaction %s chain = %s/%s, want %s/%s", tx.Hash, tx.ChainType, tx.ChainID, backend.ChainType, backend.ChainID)
+	}
+}
+
+// waitClosed drains a subscription channel until it is closed
+func waitClosed[T any](t *testing.T, ch <-chan T) {
+	t.Helper()
+
+	deadline := time.After(timeout)
+	for {
+		select {
+		case _, ok := <-ch:
+			if !ok {
+				return
+			}
+		case <-deadline:
+			t.Fatal("subscription channel not closed")
+		}
+	}
+}
+
+func equalHashes(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func containsHash(hashes []string, hash string) bool {
+	for _, h := range hashes {
+		if h == hash {
+			return true
+		}
+	}
+	return false
+}
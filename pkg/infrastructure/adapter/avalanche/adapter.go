@@ -13,11 +13,12 @@ import (
 var _ service.ChainAdapter = (*Adapter)(nil)
 
 // Adapter implements the ChainAdapter interface for Avalanche chains
-// Currently supports C-Chain (EVM compatible) by wrapping EVM adapter
+// The C-Chain (EVM compatible) is served by wrapping the EVM adapter, the
+// X-Chain and P-Chain by a UTXOAdapter over the avm and platform APIs
 type Adapter struct {
-	config     *Config
-	evmAdapter service.ChainAdapter
-	chainInfo  *models.ChainInfo
+	config    *Config
+	chain     service.ChainAdapter
+	chainInfo *models.ChainInfo
 }
 
 // NewAdapter creates a new Avalanche chain adapter
@@ -26,9 +27,24 @@ func NewAdapter(config *Config) (*Adapter, error) {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
-	// For now, only C-Chain is supported (EVM compatible)
+	chainInfo := &models.ChainInfo{
+		ChainType: models.ChainTypeAvalanche,
+		ChainID:   config.ChainID,
+		Name:      config.ChainName,
+		Network:   config.Network,
+	}
+
 	if !config.IsCChain() {
-		return nil, fmt.Errorf("only C-Chain is currently supported")
+		utxoAdapter, err := NewUTXOAdapter(config)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create %s adapter: %w", config.ChainType, err)
+		}
+
+		return &Adapter{
+			config:    config,
+			chain:     utxoAdapter,
+			chainInfo: chainInfo,
+		}, nil
 	}
 
 	// Create EVM adapter config for C-Chain
@@ -36,7 +52,8 @@ func NewAdapter(config *Config) (*Adapter, error) {
 		ChainID:            config.ChainID,
 		ChainName:          config.ChainName,
 		Network:            config.Network,
-		RPCEndpoints:       []string{config.RPCURL},
+		RPCEndpoints:       config.RPCURLs(),
+		MaxEndpointLag:     config.MaxEndpointLag,
 		WSEndpoints:        []string{config.WSURL},
 		MaxConnections:     config.MaxConnections,
 		ConnectionTimeout:  config.Timeout,
@@ -57,17 +74,10 @@ func NewAdapter(config *Config) (*Adapter, error) {
 		return nil, fmt.Errorf("failed to create EVM adapter for C-Chain: %w", err)
 	}
 
-	chainInfo := &models.ChainInfo{
-		ChainType: models.ChainTypeAvalanche,
-		ChainID:   config.ChainID,
-		Name:      config.ChainName,
-		Network:   config.Network,
-	}
-
 	return &Adapter{
-		config:     config,
-		evmAdapter: evmAdapter,
-		chainInfo:  chainInfo,
+		config:    config,
+		chain:     evmAdapter,
+		chainInfo: chainInfo,
 	}, nil
 }
 
@@ -86,14 +96,24 @@ func (a *Adapter) GetChainInfo() *models.ChainInfo {
 	return a.chainInfo
 }
 
+// Capabilities reports the features of the adapter serving the chain
+func (a *Adapter) Capabilities() models.Capabilities {
+	return a.chain.Capabilities()
+}
+
 // GetLatestBlockNumber returns the latest block number
 func (a *Adapter) GetLatestBlockNumber(ctx context.Context) (uint64, error) {
-	return a.evmAdapter.GetLatestBlockNumber(ctx)
+	return a.chain.GetLatestBlockNumber(ctx)
+}
+
+// GetFinalityHeads returns the safe and finalized blocks
+func (a *Adapter) GetFinalityHeads(ctx context.Context) (*models.FinalityHeads, error) {
+	return a.chain.GetFinalityHeads(ctx)
 }
 
 // GetBlockByNumber fetches a block by number
 func (a *Adapter) GetBlockByNumber(ctx context.Context, number uint64) (*models.Block, error) {
-	block, err := a.evmAdapter.GetBlockByNumber(ctx, number)
+	block, err := a.chain.GetBlockByNumber(ctx, number)
 	if err != nil {
 		return nil, err
 	}
@@ -105,7 +125,7 @@ func (a *Adapter) GetBlockByNumber(ctx context.Context, number uint64) (*models.
 
 // GetBlockByHash fetches a block by hash
 func (a *Adapter) GetBlockByHash(ctx context.Context, hash string) (*models.Block, error) {
-	block, err := a.evmAdapter.GetBlockByHash(ctx, hash)
+	block, err := a.chain.GetBlockByHash(ctx, hash)
 	if err != nil {
 		return nil, err
 	}
@@ -117,7 +137,7 @@ func (a *Adapter) GetBlockByHash(ctx context.Context, hash string) (*models.Bloc
 
 // GetBlocks fetches multiple blocks in a range
 func (a *Adapter) GetBlocks(ctx context.Context, start, end uint64) ([]*models.Block, error) {
-	blocks, err := a.evmAdapter.GetBlocks(ctx, start, end)
+	blocks, err := a.chain.GetBlocks(ctx, start, end)
 	if err != nil {
 		return nil, err
 	}
@@ -132,7 +152,7 @@ func (a *Adapter) GetBlocks(ctx context.Context, start, end uint64) ([]*models.B
 
 // GetTransaction fetches a transaction by hash
 func (a *Adapter) GetTransaction(ctx context.Context, hash string) (*models.Transaction, error) {
-	tx, err := a.evmAdapter.GetTransaction(ctx, hash)
+	tx, err := a.chain.GetTransaction(ctx, hash)
 	if err != nil {
 		return nil, err
 	}
@@ -144,7 +164,7 @@ func (a *Adapter) GetTransaction(ctx context.Context, hash string) (*models.Tran
 
 // GetTransactionsByBlock fetches all transactions in a block
 func (a *Adapter) GetTransactionsByBlock(ctx context.Context, blockNumber uint64) ([]*models.Transaction, error) {
-	txs, err := a.evmAdapter.GetTransactionsByBlock(ctx, blockNumber)
+	txs, err := a.chain.GetTransactionsByBlock(ctx, blockNumber)
 	if err != nil {
 		return nil, err
 	}
@@ -159,27 +179,27 @@ func (a *Adapter) GetTransactionsByBlock(ctx context.Context, blockNumber uint64
 
 // IsHealthy checks if the adapter is healthy
 func (a *Adapter) IsHealthy(ctx context.Context) bool {
-	return a.evmAdapter.IsHealthy(ctx)
+	return a.chain.IsHealthy(ctx)
 }
 
 // Connect connects to the Avalanche node
 func (a *Adapter) Connect(ctx context.Context) error {
-	return a.evmAdapter.Connect(ctx)
+	return a.chain.Connect(ctx)
 }
 
 // Disconnect closes the connection
 func (a *Adapter) Disconnect() error {
-	return a.evmAdapter.Disconnect()
+	return a.chain.Disconnect()
 }
 
 // SubscribeNewBlocks subscribes to new blocks
 func (a *Adapter) SubscribeNewBlocks(ctx context.Context) (service.BlockSubscription, error) {
-	return a.evmAdapter.SubscribeNewBlocks(ctx)
+	return a.chain.SubscribeNewBlocks(ctx)
 }
 
 // SubscribeNewTransactions subscribes to new transactions
 func (a *Adapter) SubscribeNewTransactions(ctx context.Context) (service.TransactionSubscription, error) {
-	return a.evmAdapter.SubscribeNewTransactions(ctx)
+	return a.chain.SubscribeNewTransactions(ctx)
 }
 
 // GetConfig returns the adapter configuration
@@ -1,8 +1,16 @@
 package avalanche
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
 )
 
 func TestDefaultCChainConfig(t *testing.T) {
@@ -246,6 +254,361 @@ func TestAdapter_GetChainType(t *testing.T) {
 	}
 }
 
+// fakeNode serves canned avm or platform API results keyed by method and
+// the block height or ID in the params
+type fakeNode struct {
+	*httptest.Server
+	results map[string]string
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func newFakeNode(t *testing.T, results map[string]string) *fakeNode {
+	t.Helper()
+
+	f := &fakeNode{
+		results: results,
+		calls:   make(map[string]int),
+	}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(f.Close)
+
+	return f
+}
+
+func (f *fakeNode) callCount(key string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[key]
+}
+
+func (f *fakeNode) serve(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     int               `json:"id"`
+		Method string            `json:"method"`
+		Params map[string]string `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	key := req.Method
+	for _, param := range []string{"height", "blockID", "txID"} {
+		if v, ok := req.Params[param]; ok {
+			key += " " + v
+		}
+	}
+	f.mu.Lock()
+	f.calls[key]++
+	f.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	result, ok := f.results[key]
+	if !ok {
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"error":{"code":-32000,"message":"not found: %s"}}`, req.ID, key)
+		return
+	}
+	fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":%s}`, req.ID, result)
+}
+
+func newUTXOTestAdapter(t *testing.T, chainType ChainType, node *fakeNode) *Adapter {
+	t.Helper()
+
+	config := DefaultCChainConfig()
+	config.ChainID = "avalanche-" + string(chainType)
+	config.ChainType = chainType
+	config.RPCURL = node.URL
+	config.RetryAttempts = 0
+
+	adapter, err := NewAdapter(config)
+	if err != nil {
+		t.Fatalf("NewAdapter() error = %v", err)
+	}
+
+	if err := adapter.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+
+	return adapter
+}
+
+// X-Chain block with a transfer that spends an earlier transaction and an
+// export that spends the transfer's change in the same block
+const xChainBlock = `{"encoding": "json", "block": {
+	"id": "XBLOCK5", "parentID": "XBLOCK4", "height": 5, "time": 1700000000, "merkleRoot": "ROOT",
+	"txs": [
+		{"id": "TRANSFER", "unsignedTx": {
+			"networkID": 1, "blockchainID": "XCHAIN", "memo": "0x68656c6c6f",
+			"inputs": [{"txID": "PREV", "outputIndex": 1, "assetID": "AVAXID",
+				"input": {"amount": 10000, "signatureIndices": [0]}}],
+			"outputs": [
+				{"assetID": "AVAXID", "output": {"addresses": ["X-avax1recv"], "amount": 1000, "locktime": 0, "threshold": 1}},
+				{"assetID": "AVAXID", "output": {"addresses": ["X-avax1sender"], "amount": 8000, "locktime": 0, "threshold": 1}}
+			]}},
+		{"id": "EXPORT", "unsignedTx": {
+			"networkID": 1, "blockchainID": "XCHAIN", "destinationChain": "PCHAIN",
+			"inputs": [{"txID": "TRANSFER", "outputIndex": 1, "assetID": "AVAXID",
+				"input": {"amount": 8000, "signatureIndices": [0]}}],
+			"outputs": [
+				{"assetID": "AVAXID", "output": {"addresses": ["X-avax1sender"], "amount": 2000, "locktime": 0, "threshold": 1}}
+			],
+			"exportedOutputs": [
+				{"assetID": "AVAXID", "output": {"addresses": ["P-avax1staker"], "amount": 5000, "locktime": 0, "threshold": 1}}
+			]}}
+	]}}`
+
+const xChainPrevTx = `{"encoding": "json", "tx": {"id": "PREV", "unsignedTx": {
+	"networkID": 1, "blockchainID": "XCHAIN",
+	"inputs": [],
+	"outputs": [
+		{"assetID": "AVAXID", "output": {"addresses": ["X-avax1other"], "amount": 1, "locktime": 0, "threshold": 1}},
+		{"assetID": "AVAXID", "output": {"addresses": ["X-avax1sender"], "amount": 10000, "locktime": 0, "threshold": 1}}
+	]}}}`
+
+func TestUTXOAdapter_XChain(t *testing.T) {
+	node := newFakeNode(t, map[string]string{
+		"avm.getAssetDescription": `{"assetID": "AVAXID", "name": "Avalanche", "symbol": "AVAX", "denomination": "9"}`,
+		"avm.getHeight":           `{"height": "5"}`,
+		"avm.getBlockByHeight 5":  xChainBlock,
+		"avm.getBlock XBLOCK5":    xChainBlock,
+		"avm.getTx PREV":          xChainPrevTx,
+	})
+	adapter := newUTXOTestAdapter(t, XChain, node)
+	ctx := context.Background()
+
+	height, err := adapter.GetLatestBlockNumber(ctx)
+	if err != nil {
+		t.Fatalf("GetLatestBlockNumber() error = %v", err)
+	}
+	if height != 5 {
+		t.Errorf("GetLatestBlockNumber() = %d, want 5", height)
+	}
+
+	block, err := adapter.GetBlockByNumber(ctx, 5)
+	if err != nil {
+		t.Fatalf("GetBlockByNumber() error = %v", err)
+	}
+
+	t.Run("block", func(t *testing.T) {
+		if block.ChainID != "avalanche-X-Chain" || block.ChainType != models.ChainTypeAvalanche {
+			t.Errorf("unexpected chain: %s %s", block.ChainID, block.ChainType)
+		}
+		if block.Number != 5 || block.Hash != "XBLOCK5" || block.ParentHash != "XBLOCK4" {
+			t.Errorf("unexpected header: number=%d hash=%s parent=%s", block.Number, block.Hash, block.ParentHash)
+		}
+		if block.Timestamp.Unix != 1700000000 {
+			t.Errorf("Timestamp = %d, want 1700000000", block.Timestamp.Unix)
+		}
+		if block.TxCount != 2 || block.TxHashes[0] != "TRANSFER" || block.TxHashes[1] != "EXPORT" {
+			t.Errorf("unexpected transactions: %v", block.TxHashes)
+		}
+	})
+
+	t.Run("transfer", func(t *testing.T) {
+		tx := block.Transactions[0]
+
+		if tx.From != "X-avax1sender" || tx.To != "X-avax1recv" {
+			t.Errorf("unexpected parties: from=%s to=%s", tx.From, tx.To)
+		}
+		// The change output back to the sender is not part of the value
+		if tx.Value != "1000" || tx.Fee != "1000" {
+			t.Errorf("unexpected amounts: value=%s fee=%s", tx.Value, tx.Fee)
+		}
+		if tx.Metadata["tx_type"] != TxTypeBase || tx.Metadata["memo"] != "0x68656c6c6f" {
+			t.Errorf("unexpected metadata: %v", tx.Metadata)
+		}
+
+		inputs := tx.Metadata["inputs"].([]map[string]interface{})
+		if len(inputs) != 1 || inputs[0]["tx_id"] != "PREV" || inputs[0]["amount"] != uint64(10000) {
+			t.Errorf("unexpected inputs: %v", inputs)
+		}
+
+		outputs := tx.Metadata["outputs"].([]map[string]interface{})
+		if len(outputs) != 2 || outputs[1]["amount"] != uint64(8000) {
+			t.Errorf("unexpected outputs: %v", outputs)
+		}
+	})
+
+	t.Run("export spending the same block", func(t *testing.T) {
+		tx := block.Transactions[1]
+
+		if tx.From != "X-avax1sender" || tx.To != "P-avax1staker" {
+			t.Errorf("unexpected parties: from=%s to=%s", tx.From, tx.To)
+		}
+		if tx.Value != "5000" || tx.Fee != "1000" {
+			t.Errorf("unexpected amounts: value=%s fee=%s", tx.Value, tx.Fee)
+		}
+		if tx.Metadata["tx_type"] != TxTypeExport || tx.Metadata["destination_chain"] != "PCHAIN" {
+			t.Errorf("unexpected metadata: %v", tx.Metadata)
+		}
+
+		exported := tx.Metadata["exported_outputs"].([]map[string]interface{})
+		if len(exported) != 1 || exported[0]["amount"] != uint64(5000) {
+			t.Errorf("unexpected exported outputs: %v", exported)
+		}
+	})
+
+	t.Run("spent transactions are cached", func(t *testing.T) {
+		if _, err := adapter.GetBlockByHash(ctx, "XBLOCK5"); err != nil {
+			t.Fatalf("GetBlockByHash() error = %v", err)
+		}
+		if node.callCount("avm.getTx PREV") != 1 {
+			t.Errorf("getTx PREV called %d times, want 1", node.callCount("avm.getTx PREV"))
+		}
+		if node.callCount("avm.getTx TRANSFER") != 0 {
+			t.Error("outputs created in the same block should be resolved locally")
+		}
+	})
+}
+
+// P-Chain Banff standard block with a permissionless validator whose stake
+// is stakeable-locked, followed by an Apricot proposal block rewarding it
+const pChainStandardBlock = `{"encoding": "json", "block": {
+	"id": "PBLOCK10", "parentID": "PBLOCK9", "height": 10, "time": 1700000100,
+	"txs": [{"id": "STAKER", "unsignedTx": {
+		"networkID": 1, "blockchainID": "PCHAIN",
+		"inputs": [
+			{"txID": "GENESIS", "outputIndex": 0, "assetID": "AVAXID",
+				"input": {"locktime": 1800000000, "input": {"amount": 2000000, "signatureIndices": [0]}}},
+			{"txID": "FUNDING", "outputIndex": 0, "assetID": "AVAXID",
+				"input": {"amount": 10000, "signatureIndices": [0]}}
+		],
+		"outputs": [],
+		"validator": {"nodeID": "NodeID-abc", "start": 1700000000, "end": 1710000000, "weight": 2000000},
+		"subnetID": "11111111111111111111111111111111LpoYY",
+		"signer": {"publicKey": "0x01", "proofOfPossession": "0x02"},
+		"stake": [{"assetID": "AVAXID", "output": {"locktime": 1800000000,
+			"output": {"addresses": ["P-avax1staker"], "amount": 2000000, "locktime": 0, "threshold": 1}}}],
+		"validationRewardsOwner": {"addresses": ["P-avax1rewards"], "locktime": 0, "threshold": 1},
+		"delegationRewardsOwner": {"addresses": ["P-avax1rewards"], "locktime": 0, "threshold": 1},
+		"shares": 20000
+	}}]}}`
+
+const pChainProposalBlock = `{"encoding": "json", "block": {
+	"id": "PBLOCK11", "parentID": "PBLOCK10", "height": 11,
+	"tx": {"id": "REWARD", "unsignedTx": {"txID": "STAKER"}}}}`
+
+const pChainFundingTx = `{"encoding": "json", "tx": {"id": "FUNDING", "unsignedTx": {
+	"networkID": 1, "blockchainID": "PCHAIN", "inputs": [],
+	"outputs": [{"assetID": "AVAXID", "output": {"addresses": ["P-avax1funder"], "amount": 10000, "locktime": 0, "threshold": 1}}]}}}`
+
+func TestUTXOAdapter_PChain(t *testing.T) {
+	node := newFakeNode(t, map[string]string{
+		"platform.getStakingAssetID":   `{"assetID": "AVAXID"}`,
+		"platform.getBlockByHeight 10": pChainStandardBlock,
+		"platform.getBlockByHeight 11": pChainProposalBlock,
+		"platform.getTx FUNDING":       pChainFundingTx,
+		// GENESIS is not served, its owners stay unresolved
+	})
+	adapter := newUTXOTestAdapter(t, PChain, node)
+
+	blocks, err := adapter.GetBlocks(context.Background(), 10, 11)
+	if err != nil {
+		t.Fatalf("GetBlocks() error = %v", err)
+	}
+	if len(blocks) != 2 {
+		t.Fatalf("len(blocks) = %d, want 2", len(blocks))
+	}
+
+	t.Run("permissionless validator", func(t *testing.T) {
+		tx := blocks[0].Transactions[0]
+
+		if tx.Metadata["tx_type"] != TxTypeAddPermissionlessValidator {
+			t.Errorf("tx_type = %v, want %s", tx.Metadata["tx_type"], TxTypeAddPermissionlessValidator)
+		}
+		if tx.From != "P-avax1funder" || tx.To != "NodeID-abc" {
+			t.Errorf("unexpected parties: from=%s to=%s", tx.From, tx.To)
+		}
+		if tx.Value != "2000000" || tx.Fee != "10000" {
+			t.Errorf("unexpected amounts: value=%s fee=%s", tx.Value, tx.Fee)
+		}
+		if tx.Metadata["delegation_shares"] != uint32(20000) || tx.Metadata["end_time"] != uint64(1710000000) {
+			t.Errorf("unexpected staking terms: %v", tx.Metadata)
+		}
+
+		stake := tx.Metadata["stake_outputs"].([]map[string]interface{})
+		if len(stake) != 1 || stake[0]["stakeable_locktime"] != uint64(1800000000) {
+			t.Errorf("unexpected stake outputs: %v", stake)
+		}
+
+		inputs := tx.Metadata["inputs"].([]map[string]interface{})
+		if _, ok := inputs[0]["addresses"]; ok {
+			t.Error("unresolved inputs should have no addresses")
+		}
+		if inputs[0]["stakeable_locktime"] != uint64(1800000000) {
+			t.Errorf("unexpected stakeable input: %v", inputs[0])
+		}
+	})
+
+	t.Run("reward in a proposal block", func(t *testing.T) {
+		block := blocks[1]
+		if block.TxCount != 1 || block.TxHashes[0] != "REWARD" {
+			t.Fatalf("unexpected transactions: %v", block.TxHashes)
+		}
+
+		tx := block.Transactions[0]
+		if tx.Metadata["tx_type"] != TxTypeRewardValidator || tx.Metadata["staker_tx_id"] != "STAKER" {
+			t.Errorf("unexpected metadata: %v", tx.Metadata)
+		}
+		if tx.From != "" {
+			t.Errorf("From = %s, want no sender", tx.From)
+		}
+		if err := tx.Validate(); err != nil {
+			t.Errorf("Validate() error = %v", err)
+		}
+	})
+}
+
+func TestUTXOAdapter_Subscriptions(t *testing.T) {
+	node := newFakeNode(t, map[string]string{
+		"platform.getStakingAssetID": `{"assetID": "AVAXID"}`,
+	})
+	adapter := newUTXOTestAdapter(t, PChain, node)
+
+	if _, err := adapter.SubscribeNewBlocks(context.Background()); err == nil {
+		t.Error("SubscribeNewBlocks() should not be supported on the P-Chain")
+	}
+}
+
+func TestUnsignedTx_Type(t *testing.T) {
+	shares := uint32(20000)
+	validator := &Validator{NodeID: "NodeID-abc"}
+	owner := &OutputOwners{Addresses: []string{"P-avax1owner"}}
+	stake := []TransferableOutput{{AssetID: "AVAXID"}}
+
+	tests := []struct {
+		name string
+		tx   UnsignedTx
+		want string
+	}{
+		{"base", UnsignedTx{BlockchainID: "X"}, TxTypeBase},
+		{"import", UnsignedTx{SourceChain: "C"}, TxTypeImport},
+		{"export", UnsignedTx{DestinationChain: "C"}, TxTypeExport},
+		{"create asset", UnsignedTx{Name: "Token", Symbol: "TKN"}, TxTypeCreateAsset},
+		{"operation", UnsignedTx{Operations: json.RawMessage(`[{}]`)}, TxTypeOperation},
+		{"add validator", UnsignedTx{Validator: validator, Stake: stake, RewardsOwner: owner, Shares: &shares}, TxTypeAddValidator},
+		{"add delegator", UnsignedTx{Validator: validator, Stake: stake, RewardsOwner: owner}, TxTypeAddDelegator},
+		{"add subnet validator", UnsignedTx{Validator: validator, SubnetAuthorization: json.RawMessage(`{}`)}, TxTypeAddSubnetValidator},
+		{"add permissionless delegator", UnsignedTx{Validator: validator, SubnetID: "S", Stake: stake, RewardsOwner: owner}, TxTypeAddPermissionlessDelegator},
+		{"remove subnet validator", UnsignedTx{NodeID: "NodeID-abc", SubnetID: "S"}, TxTypeRemoveSubnetValidator},
+		{"reward validator", UnsignedTx{TxID: "STAKER"}, TxTypeRewardValidator},
+		{"advance time", UnsignedTx{Time: 1700000000}, TxTypeAdvanceTime},
+		{"create subnet", UnsignedTx{BlockchainID: "P", Owner: owner}, TxTypeCreateSubnet},
+		{"create chain", UnsignedTx{BlockchainID: "P", SubnetID: "S", ChainName: "chain", VMID: "vm"}, TxTypeCreateChain},
+		{"transfer subnet ownership", UnsignedTx{BlockchainID: "P", SubnetID: "S", NewOwner: owner}, TxTypeTransferSubnetOwnership},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tx.Type(); got != tt.want {
+				t.Errorf("Type() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
 // Benchmark tests
 func BenchmarkConfig_Validate(b *testing.B) {
 	config := DefaultCChainConfig()
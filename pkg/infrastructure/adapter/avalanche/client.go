@@ -0,0 +1,318 @@
+package avalanche
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/endpoint"
+)
+
+// Client wraps the X-Chain (avm) and P-Chain (platform) JSON-RPC APIs
+type Client struct {
+	config     *Config
+	namespace  string
+	httpClient *http.Client
+	pool       *endpoint.Pool
+	requestID  atomic.Int64
+	errorCount atomic.Int32
+	mu         sync.RWMutex
+	connected  bool
+}
+
+// NewClient creates a new client for the X-Chain or P-Chain API
+func NewClient(config *Config) (*Client, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	var namespace string
+	switch {
+	case config.IsXChain():
+		namespace = NamespaceAVM
+	case config.IsPChain():
+		namespace = NamespacePlatform
+	default:
+		return nil, fmt.Errorf("%s has no avm or platform API", config.ChainType)
+	}
+
+	client := &Client{
+		config:    config,
+		namespace: namespace,
+		httpClient: &http.Client{
+			Timeout: config.Timeout,
+			Transport: &http.Transport{
+				MaxConnsPerHost:     config.MaxConnections,
+				MaxIdleConnsPerHost: config.MaxConnections,
+			},
+		},
+		connected: true,
+	}
+
+	poolConfig := endpoint.DefaultConfig(config.ChainID)
+	poolConfig.MaxLag = config.MaxEndpointLag
+	pool, err := endpoint.NewPool(config.RPCURLs(), poolConfig, client.probeHeight)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create endpoint pool: %w", err)
+	}
+	client.pool = pool
+
+	return client, nil
+}
+
+// call makes a JSON-RPC call to the chain API, prefixing the method with
+// the chain's namespace. Requests go to the healthiest endpoint and fail
+// over to the others.
+func (c *Client) call(ctx context.Context, method string, params interface{}, result interface{}) error {
+	reqBody, err := c.newRequest(method, params)
+	if err != nil {
+		return err
+	}
+
+	// Execute request with retries
+	var lastErr error
+	for attempt := 0; attempt <= c.config.RetryAttempts; attempt++ {
+		if attempt > 0 {
+			// Wait before retry
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(c.config.RetryDelay):
+			}
+		}
+
+		err := c.pool.Do(ctx, func(ctx context.Context, e *endpoint.Endpoint) error {
+			return c.post(ctx, e.URL, reqBody, result)
+		})
+		if err == nil {
+			// Reset error count on success
+			c.errorCount.Store(0)
+			return nil
+		}
+		if endpoint.IsPermanent(err) {
+			return err
+		}
+
+		lastErr = err
+		c.errorCount.Add(1)
+	}
+
+	return fmt.Errorf("request failed after %d retries: %w", c.config.RetryAttempts, lastErr)
+}
+
+// newRequest encodes a JSON-RPC request for a method of the chain's namespace
+func (c *Client) newRequest(method string, params interface{}) ([]byte, error) {
+	if params == nil {
+		params = struct{}{}
+	}
+
+	reqBody, err := json.Marshal(RPCRequest{
+		JSONRPC: "2.0",
+		ID:      int(c.requestID.Add(1)),
+		Method:  c.namespace + "." + method,
+		Params:  params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	return reqBody, nil
+}
+
+// post sends a request body to a single RPC endpoint and decodes the result.
+// Errors reported by the node itself are marked permanent so the pool
+// neither fails over nor counts them against the endpoint.
+func (c *Client) post(ctx context.Context, url string, reqBody []byte, result interface{}) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(reqBody))
+	if err != nil {
+		return endpoint.Permanent(fmt.Errorf("failed to create request: %w", err))
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	// Parse response
+	var rpcResp RPCResponse
+	if err := json.Unmarshal(body, &rpcResp); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	// Check for RPC error
+	if rpcResp.Error != nil {
+		return endpoint.Permanent(rpcResp.Error)
+	}
+
+	// Unmarshal result
+	if result != nil && len(rpcResp.Result) > 0 {
+		if err := json.Unmarshal(rpcResp.Result, result); err != nil {
+			return endpoint.Permanent(fmt.Errorf("failed to unmarshal result: %w", err))
+		}
+	}
+
+	return nil
+}
+
+// probeHeight returns the height of a single endpoint for the pool's head
+// tracking
+func (c *Client) probeHeight(ctx context.Context, e *endpoint.Endpoint) (uint64, error) {
+	reqBody, err := c.newRequest("getHeight", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var result HeightResponse
+	if err := c.post(ctx, e.URL, reqBody, &result); err != nil {
+		return 0, err
+	}
+
+	return uint64(result.Height), nil
+}
+
+// GetHeight returns the height of the last accepted block
+func (c *Client) GetHeight(ctx context.Context) (uint64, error) {
+	var result HeightResponse
+	if err := c.call(ctx, "getHeight", nil, &result); err != nil {
+		return 0, fmt.Errorf("getHeight: %w", err)
+	}
+
+	return uint64(result.Height), nil
+}
+
+// GetBlockByHeight returns the accepted block at a height, or an error
+// wrapping models.ErrBlockNotFound above the last accepted block
+func (c *Client) GetBlockByHeight(ctx context.Context, height uint64) (*Block, error) {
+	var result BlockResponse
+	params := map[string]interface{}{
+		"height":   strconv.FormatUint(height, 10),
+		"encoding": EncodingJSON,
+	}
+
+	if err := c.call(ctx, "getBlockByHeight", params, &result); err != nil {
+		if isNotFound(err) {
+			return nil, fmt.Errorf("getBlockByHeight: %w: %w", models.ErrBlockNotFound, err)
+		}
+		return nil, fmt.Errorf("getBlockByHeight: %w", err)
+	}
+
+	return &result.Block, nil
+}
+
+// GetBlock returns a block by ID
+func (c *Client) GetBlock(ctx context.Context, blockID string) (*Block, error) {
+	var result BlockResponse
+	params := map[string]interface{}{
+		"blockID":  blockID,
+		"encoding": EncodingJSON,
+	}
+
+	if err := c.call(ctx, "getBlock", params, &result); err != nil {
+		if isNotFound(err) {
+			return nil, fmt.Errorf("getBlock: %w: %w", models.ErrBlockNotFound, err)
+		}
+		return nil, fmt.Errorf("getBlock: %w", err)
+	}
+
+	return &result.Block, nil
+}
+
+// GetTx returns a transaction by ID, or an error wrapping
+// models.ErrTransactionNotFound for an unknown ID
+func (c *Client) GetTx(ctx context.Context, txID string) (*Tx, error) {
+	var result TxResponse
+	params := map[string]interface{}{
+		"txID":     txID,
+		"encoding": EncodingJSON,
+	}
+
+	if err := c.call(ctx, "getTx", params, &result); err != nil {
+		if isNotFound(err) {
+			return nil, fmt.Errorf("getTx: %w: %w", models.ErrTransactionNotFound, err)
+		}
+		return nil, fmt.Errorf("getTx: %w", err)
+	}
+
+	// Older nodes omit the ID from the JSON encoding
+	if result.Tx.ID == "" {
+		result.Tx.ID = txID
+	}
+
+	return &result.Tx, nil
+}
+
+// GetFeeAssetID returns the ID of AVAX, the asset fees are paid in. The
+// X-Chain resolves it from its alias and the P-Chain reports its staking
+// asset, which is AVAX on the primary network.
+func (c *Client) GetFeeAssetID(ctx context.Context) (string, error) {
+	var result AssetResponse
+
+	if c.namespace == NamespaceAVM {
+		params := map[string]interface{}{"assetID": AssetAVAX}
+		if err := c.call(ctx, "getAssetDescription", params, &result); err != nil {
+			return "", fmt.Errorf("getAssetDescription: %w", err)
+		}
+	} else {
+		if err := c.call(ctx, "getStakingAssetID", nil, &result); err != nil {
+			return "", fmt.Errorf("getStakingAssetID: %w", err)
+		}
+	}
+
+	return result.AssetID, nil
+}
+
+// isNotFound reports whether the node answered that the block or
+// transaction does not exist
+func isNotFound(err error) bool {
+	var rpcErr *RPCError
+	return errors.As(err, &rpcErr) && rpcErr.IsNotFound()
+}
+
+// HealthStatus returns the current health status
+type HealthStatus struct {
+	Connected  bool
+	ErrorCount int32
+}
+
+// GetHealthStatus returns the current health status
+func (c *Client) GetHealthStatus() *HealthStatus {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return &HealthStatus{
+		Connected:  c.connected,
+		ErrorCount: c.errorCount.Load(),
+	}
+}
+
+// Close closes the client
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.connected = false
+	c.pool.Close()
+	c.httpClient.CloseIdleConnections()
+
+	return nil
+}
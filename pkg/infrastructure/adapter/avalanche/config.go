@@ -35,6 +35,13 @@ type Config struct {
 	// RPCURL is the RPC endpoint URL
 	RPCURL string `yaml:"rpc_url" json:"rpc_url"`
 
+	// FallbackRPCURLs are RPC endpoints used when RPCURL is unhealthy
+	FallbackRPCURLs []string `yaml:"fallback_rpc_urls,omitempty" json:"fallback_rpc_urls,omitempty"`
+
+	// MaxEndpointLag is how many blocks an RPC endpoint may fall behind the
+	// others before requests stop being routed to it
+	MaxEndpointLag uint64 `yaml:"max_endpoint_lag" json:"max_endpoint_lag"`
+
 	// WSURL is the WebSocket endpoint URL (optional)
 	WSURL string `yaml:"ws_url" json:"ws_url"`
 
@@ -73,6 +80,7 @@ func DefaultCChainConfig() *Config {
 		RetryAttempts:   3,
 		RetryDelay:      1 * time.Second,
 		MaxConnections:  10,
+		MaxEndpointLag:  10,
 		BatchSize:       100,
 		StartBlock:      0,
 		EnableWebSocket: false,
@@ -92,6 +100,7 @@ func FujiCChainConfig() *Config {
 		RetryAttempts:   3,
 		RetryDelay:      1 * time.Second,
 		MaxConnections:  10,
+		MaxEndpointLag:  10,
 		BatchSize:       100,
 		StartBlock:      0,
 		EnableWebSocket: false,
@@ -167,6 +176,11 @@ func (c *Config) GetRPCURL() string {
 	return c.RPCURL
 }
 
+// RPCURLs returns the RPC URL followed by the fallback URLs
+func (c *Config) RPCURLs() []string {
+	return append([]string{c.RPCURL}, c.FallbackRPCURLs...)
+}
+
 // GetWSURL returns the WebSocket URL
 func (c *Config) GetWSURL() string {
 	return c.WSURL
@@ -0,0 +1,68 @@
+package avalanche
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/service"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/adaptertest"
+)
+
+func TestUTXOAdapter_Conformance(t *testing.T) {
+	tests := []struct {
+		chainType ChainType
+		namespace string
+		feeAsset  string
+	}{
+		{XChain, NamespaceAVM, "getAssetDescription"},
+		{PChain, NamespacePlatform, "getStakingAssetID"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.chainType), func(t *testing.T) {
+			node := newFakeNode(t, cannedChain(tt.namespace, tt.feeAsset, 4))
+
+			adaptertest.RunConformance(t, func(t *testing.T) service.ChainAdapter {
+				adapter := newUTXOTestAdapter(t, tt.chainType, node)
+				t.Cleanup(func() { adapter.Disconnect() })
+
+				return adapter
+			}, adaptertest.Backend{
+				ChainType:        models.ChainTypeAvalanche,
+				ChainID:          "avalanche-" + string(tt.chainType),
+				First:            1,
+				Last:             4,
+				TxHash:           "TX3",
+				TxBlockUnknown:   true,
+				UnknownBlockHash: "UNKNOWNBLOCK",
+				UnknownTxHash:    "UNKNOWNTX",
+			})
+		})
+	}
+}
+
+// cannedChain returns the results of a chain of blocks 1 to head, each with
+// one transaction, for a fakeNode serving the namespace's API
+func cannedChain(namespace, feeAssetMethod string, head int) map[string]string {
+	results := map[string]string{
+		namespace + "." + feeAssetMethod: `{"assetID": "AVAXID"}`,
+		namespace + ".getHeight":         fmt.Sprintf(`{"height": "%d"}`, head),
+	}
+
+	for height := 1; height <= head; height++ {
+		tx := fmt.Sprintf(`{"id": "TX%d", "unsignedTx": {
+			"networkID": 1, "blockchainID": "CHAIN", "inputs": [],
+			"outputs": [{"assetID": "AVAXID", "output": {"addresses": ["avax1recv"], "amount": %d, "locktime": 0, "threshold": 1}}]}}`,
+			height, height*1000)
+		block := fmt.Sprintf(`{"encoding": "json", "block": {
+			"id": "BLOCK%d", "parentID": "BLOCK%d", "height": %d, "time": %d, "txs": [%s]}}`,
+			height, height-1, height, 1700000000+height, tx)
+
+		results[fmt.Sprintf("%s.getBlockByHeight %d", namespace, height)] = block
+		results[fmt.Sprintf("%s.getBlock BLOCK%d", namespace, height)] = block
+		results[fmt.Sprintf("%s.getTx TX%d", namespace, height)] = fmt.Sprintf(`{"encoding": "json", "tx": %s}`, tx)
+	}
+
+	return results
+}
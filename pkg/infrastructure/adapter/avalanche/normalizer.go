@@ -0,0 +1,382 @@
+package avalanche
+
+import (
+	"fmt"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+)
+
+// InputOwners maps the ID of a spent output (txID:outputIndex) to the
+// addresses that owned it. Inputs only reference the outputs they spend, so
+// owners are resolved from the spent transactions before normalization.
+type InputOwners map[string][]string
+
+// Normalizer converts X-Chain and P-Chain types to domain models
+type Normalizer struct {
+	chainID   string
+	network   string
+	chainType ChainType
+}
+
+// NewNormalizer creates a new normalizer for the X-Chain or P-Chain
+func NewNormalizer(chainID, network string, chainType ChainType) *Normalizer {
+	return &Normalizer{
+		chainID:   chainID,
+		network:   network,
+		chainType: chainType,
+	}
+}
+
+// NormalizeChainInfo returns the chain information
+func (n *Normalizer) NormalizeChainInfo(name string) *models.ChainInfo {
+	return &models.ChainInfo{
+		ChainType: models.ChainTypeAvalanche,
+		ChainID:   n.chainID,
+		Name:      name,
+		Network:   n.network,
+	}
+}
+
+// NormalizeBlock converts a block to a domain Block. Fees are computed in
+// feeAssetID and senders are looked up in owners.
+func (n *Normalizer) NormalizeBlock(block *Block, feeAssetID string, owners InputOwners) (*models.Block, error) {
+	if block == nil {
+		return nil, fmt.Errorf("block is nil")
+	}
+
+	number := uint64(block.Height)
+	txs := block.Transactions()
+
+	// Apricot blocks have no timestamp, the chain time is advanced by
+	// an AdvanceTimeTx instead
+	timestamp := int64(block.Time)
+	for i := range txs {
+		if timestamp == 0 && txs[i].UnsignedTx.Type() == TxTypeAdvanceTime {
+			timestamp = int64(txs[i].UnsignedTx.Time)
+		}
+	}
+
+	domainBlock := &models.Block{
+		ChainID:      n.chainID,
+		ChainType:    models.ChainTypeAvalanche,
+		Number:       number,
+		Hash:         block.ID,
+		ParentHash:   block.ParentID,
+		Timestamp:    models.NewTimestamp(timestamp),
+		TxCount:      len(txs),
+		TxHashes:     make([]string, 0, len(txs)),
+		Transactions: make([]*models.Transaction, 0, len(txs)),
+		Metadata:     make(map[string]interface{}),
+	}
+
+	for i := range txs {
+		domainTx, err := n.NormalizeTransaction(&txs[i], uint64(i), block, feeAssetID, owners)
+		if err != nil {
+			return nil, fmt.Errorf("failed to normalize transaction %d: %w", i, err)
+		}
+		domainBlock.Transactions = append(domainBlock.Transactions, domainTx)
+		domainBlock.TxHashes = append(domainBlock.TxHashes, domainTx.Hash)
+	}
+
+	// Add Avalanche-specific data
+	domainBlock.Metadata["avalanche_chain"] = string(n.chainType)
+	if block.MerkleRoot != "" {
+		domainBlock.TransactionsRoot = block.MerkleRoot
+	}
+
+	return domainBlock, nil
+}
+
+// NormalizeTransaction converts a transaction to a domain Transaction. The
+// inputs and outputs are preserved in the metadata, the sender is the owner
+// of the first input, and the value is the amount of the fee asset sent to
+// addresses other than the sender's. Staking transactions are addressed to
+// the validator's node ID and valued at the staked weight.
+func (n *Normalizer) NormalizeTransaction(
+	tx *Tx,
+	index uint64,
+	block *Block,
+	feeAssetID string,
+	owners InputOwners,
+) (*models.Transaction, error) {
+	if tx == nil {
+		return nil, fmt.Errorf("transaction is nil")
+	}
+	if tx.ID == "" {
+		return nil, fmt.Errorf("transaction ID is empty")
+	}
+
+	u := &tx.UnsignedTx
+	txType := u.Type()
+
+	domainTx := &models.Transaction{
+		ChainID:   n.chainID,
+		ChainType: models.ChainTypeAvalanche,
+		Hash:      tx.ID,
+		Index:     index,
+		Value:     "0",
+		Fee:       "0",
+		Status:    models.TxStatusSuccess, // Only accepted transactions are in blocks
+		Timestamp: models.NewTimestamp(0),
+		Logs:      make([]*models.Log, 0),
+		Metadata:  make(map[string]interface{}),
+	}
+
+	if block != nil {
+		domainTx.BlockNumber = uint64(block.Height)
+		domainTx.BlockHash = block.ID
+		domainTx.Timestamp = models.NewTimestamp(int64(block.Time))
+	}
+
+	// Resolve the sender from the owners of the spent outputs. Transactions
+	// without local inputs, such as imports and the P-Chain's reward and
+	// time transactions, have no sender.
+	senders := make(map[string]bool)
+	for _, in := range u.Inputs {
+		for _, addr := range owners[in.UTXOID()] {
+			if domainTx.From == "" {
+				domainTx.From = addr
+			}
+			senders[addr] = true
+		}
+	}
+
+	// Preserve inputs and outputs
+	domainTx.Metadata["avalanche_chain"] = string(n.chainType)
+	domainTx.Metadata["tx_type"] = txType
+	if u.BlockchainID != "" {
+		domainTx.Metadata["blockchain_id"] = u.BlockchainID
+	}
+	if u.Memo != "" && u.Memo != "0x" {
+		domainTx.Metadata["memo"] = u.Memo
+	}
+	domainTx.Metadata["inputs"] = normalizeInputs(u.Inputs, owners)
+	domainTx.Metadata["outputs"] = normalizeOutputs(u.Outputs)
+
+	if txType == TxTypeImport {
+		domainTx.Metadata["source_chain"] = u.SourceChain
+		domainTx.Metadata["imported_inputs"] = normalizeInputs(u.ImportedInputs, owners)
+	}
+	if txType == TxTypeExport {
+		domainTx.Metadata["destination_chain"] = u.DestinationChain
+		domainTx.Metadata["exported_outputs"] = normalizeOutputs(u.ExportedOutputs)
+	}
+
+	// Compute the fee as the fee asset burned by the transaction
+	if feeAssetID != "" {
+		consumed := sumInputs(u.Inputs, feeAssetID) + sumInputs(u.ImportedInputs, feeAssetID)
+		produced := sumOutputs(u.Outputs, feeAssetID) + sumOutputs(u.ExportedOutputs, feeAssetID) +
+			sumOutputs(u.Stake, feeAssetID)
+		if consumed > produced {
+			domainTx.Fee = fmt.Sprintf("%d", consumed-produced)
+		}
+	}
+
+	if u.IsStaker() {
+		n.normalizeStaker(domainTx, u)
+	} else {
+		n.normalizeTransfer(domainTx, u, feeAssetID, senders)
+	}
+
+	switch txType {
+	case TxTypeRewardValidator:
+		domainTx.Metadata["staker_tx_id"] = u.TxID
+	case TxTypeAdvanceTime:
+		domainTx.Metadata["time"] = uint64(u.Time)
+	case TxTypeRemoveSubnetValidator:
+		domainTx.To = u.NodeID
+		domainTx.Metadata["node_id"] = u.NodeID
+		domainTx.Metadata["subnet_id"] = u.SubnetID
+	case TxTypeCreateAsset:
+		domainTx.Metadata["asset_name"] = u.Name
+		domainTx.Metadata["asset_symbol"] = u.Symbol
+		if u.Denomination != nil {
+			domainTx.Metadata["denomination"] = *u.Denomination
+		}
+	case TxTypeCreateSubnet:
+		domainTx.Metadata["owner"] = u.Owner.Addresses
+	case TxTypeCreateChain:
+		domainTx.Metadata["chain_name"] = u.ChainName
+		domainTx.Metadata["vm_id"] = u.VMID
+		domainTx.Metadata["subnet_id"] = u.SubnetID
+	case TxTypeTransformSubnet:
+		domainTx.Metadata["subnet_id"] = u.SubnetID
+		domainTx.Metadata["asset_id"] = u.AssetID
+	case TxTypeTransferSubnetOwnership:
+		domainTx.Metadata["subnet_id"] = u.SubnetID
+		domainTx.Metadata["owner"] = u.NewOwner.Addresses
+	}
+
+	return domainTx, nil
+}
+
+// normalizeStaker sets the validator, weight and staking terms of a
+// transaction that adds a validator or delegator
+func (n *Normalizer) normalizeStaker(domainTx *models.Transaction, u *UnsignedTx) {
+	v := u.Validator
+
+	domainTx.To = v.NodeID
+	domainTx.Value = fmt.Sprintf("%d", uint64(v.Weight))
+
+	domainTx.Metadata["node_id"] = v.NodeID
+	domainTx.Metadata["start_time"] = uint64(v.Start)
+	domainTx.Metadata["end_time"] = uint64(v.End)
+	domainTx.Metadata["weight"] = uint64(v.Weight)
+	if subnetID := firstNonEmpty(u.SubnetID, v.SubnetID); subnetID != "" {
+		domainTx.Metadata["subnet_id"] = subnetID
+	}
+	if len(u.Stake) > 0 {
+		domainTx.Metadata["stake_outputs"] = normalizeOutputs(u.Stake)
+	}
+	if u.Shares != nil {
+		// Delegation fee in units of 0.0001%
+		domainTx.Metadata["delegation_shares"] = *u.Shares
+	}
+
+	if owner := u.ValidationRewardsOwner; owner != nil {
+		domainTx.Metadata["rewards_owner"] = owner.Addresses
+	} else if owner := u.RewardsOwner; owner != nil {
+		domainTx.Metadata["rewards_owner"] = owner.Addresses
+	}
+	if owner := u.DelegationRewardsOwner; owner != nil {
+		domainTx.Metadata["delegation_rewards_owner"] = owner.Addresses
+	}
+}
+
+// normalizeTransfer sets the recipient and value of a transaction from the
+// outputs not owned by the sender. Outputs back to the sender are change.
+func (n *Normalizer) normalizeTransfer(domainTx *models.Transaction, u *UnsignedTx, feeAssetID string, senders map[string]bool) {
+	var value uint64
+
+	outputs := make([]TransferableOutput, 0, len(u.Outputs)+len(u.ExportedOutputs))
+	outputs = append(outputs, u.Outputs...)
+	outputs = append(outputs, u.ExportedOutputs...)
+
+	for _, out := range outputs {
+		if isChange(out.Output.Addresses, senders) {
+			continue
+		}
+		if domainTx.To == "" && len(out.Output.Addresses) > 0 {
+			domainTx.To = out.Output.Addresses[0]
+		}
+		if out.AssetID == feeAssetID {
+			value += uint64(out.Output.Amount)
+		}
+	}
+
+	domainTx.Value = fmt.Sprintf("%d", value)
+}
+
+// isChange reports whether an output is owned only by the sender
+func isChange(addresses []string, senders map[string]bool) bool {
+	if len(addresses) == 0 || len(senders) == 0 {
+		return false
+	}
+	for _, addr := range addresses {
+		if !senders[addr] {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeInputs converts inputs to metadata, including the owners of the
+// spent outputs when they are known
+func normalizeInputs(inputs []TransferableInput, owners InputOwners) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(inputs))
+	for _, in := range inputs {
+		input := map[string]interface{}{
+			"tx_id":        in.TxID,
+			"output_index": in.OutputIndex,
+			"asset_id":     in.AssetID,
+			"amount":       uint64(in.Input.Amount),
+		}
+		if addrs, ok := owners[in.UTXOID()]; ok {
+			input["addresses"] = addrs
+		}
+		if in.Input.StakeableLocked != 0 {
+			input["stakeable_locktime"] = uint64(in.Input.StakeableLocked)
+		}
+		result = append(result, input)
+	}
+	return result
+}
+
+// normalizeOutputs converts outputs to metadata
+func normalizeOutputs(outputs []TransferableOutput) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(outputs))
+	for i, out := range outputs {
+		output := map[string]interface{}{
+			"index":     i,
+			"asset_id":  out.AssetID,
+			"amount":    uint64(out.Output.Amount),
+			"addresses": out.Output.Addresses,
+			"threshold": out.Output.Threshold,
+		}
+		if out.Output.Locktime != 0 {
+			output["locktime"] = uint64(out.Output.Locktime)
+		}
+		if out.Output.StakeableLocked != 0 {
+			output["stakeable_locktime"] = uint64(out.Output.StakeableLocked)
+		}
+		result = append(result, output)
+	}
+	return result
+}
+
+// sumInputs returns the total amount of an asset consumed by inputs
+func sumInputs(inputs []TransferableInput, assetID string) uint64 {
+	var total uint64
+	for _, in := range inputs {
+		if in.AssetID == assetID {
+			total += uint64(in.Input.Amount)
+		}
+	}
+	return total
+}
+
+// sumOutputs returns the total amount of an asset produced by outputs
+func sumOutputs(outputs []TransferableOutput, assetID string) uint64 {
+	var total uint64
+	for _, out := range outputs {
+		if out.AssetID == assetID {
+			total += uint64(out.Output.Amount)
+		}
+	}
+	return total
+}
+
+// spentOutputOwners returns the owners of the output at index of a
+// transaction. Outputs are numbered in order, followed by the stake
+// outputs, which staking transactions return once the staking period ends.
+func spentOutputOwners(tx *Tx, index uint32) []string {
+	u := &tx.UnsignedTx
+	i := int(index)
+
+	if i < len(u.Outputs) {
+		return u.Outputs[i].Output.Addresses
+	}
+	i -= len(u.Outputs)
+
+	if i < len(u.Stake) {
+		return u.Stake[i].Output.Addresses
+	}
+
+	// Staking rewards are paid to the rewards owner after the stake
+	if owner := u.ValidationRewardsOwner; owner != nil {
+		return owner.Addresses
+	}
+	if owner := u.RewardsOwner; owner != nil {
+		return owner.Addresses
+	}
+	return nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
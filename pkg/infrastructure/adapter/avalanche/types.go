@@ -0,0 +1,380 @@
+package avalanche
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// avm and platform API types - these match the JSON encoding used by
+// AvalancheGo for X-Chain and P-Chain blocks and transactions
+
+// EncodingJSON requests blocks and transactions as JSON instead of hex bytes
+const EncodingJSON = "json"
+
+// API namespaces of the X-Chain and P-Chain
+const (
+	NamespaceAVM      = "avm"
+	NamespacePlatform = "platform"
+)
+
+// AssetAVAX is the alias of the native asset on the X-Chain
+const AssetAVAX = "AVAX"
+
+// Transaction types, inferred from the fields of the unsigned transaction
+const (
+	TxTypeBase                       = "BaseTx"
+	TxTypeCreateAsset                = "CreateAssetTx"
+	TxTypeOperation                  = "OperationTx"
+	TxTypeImport                     = "ImportTx"
+	TxTypeExport                     = "ExportTx"
+	TxTypeAddValidator               = "AddValidatorTx"
+	TxTypeAddSubnetValidator         = "AddSubnetValidatorTx"
+	TxTypeAddDelegator               = "AddDelegatorTx"
+	TxTypeAddPermissionlessValidator = "AddPermissionlessValidatorTx"
+	TxTypeAddPermissionlessDelegator = "AddPermissionlessDelegatorTx"
+	TxTypeRemoveSubnetValidator      = "RemoveSubnetValidatorTx"
+	TxTypeRewardValidator            = "RewardValidatorTx"
+	TxTypeAdvanceTime                = "AdvanceTimeTx"
+	TxTypeCreateSubnet               = "CreateSubnetTx"
+	TxTypeCreateChain                = "CreateChainTx"
+	TxTypeTransformSubnet            = "TransformSubnetTx"
+	TxTypeTransferSubnetOwnership    = "TransferSubnetOwnershipTx"
+)
+
+// RPCRequest represents a JSON-RPC request
+type RPCRequest struct {
+	JSONRPC string      `json:"jsonrpc"`
+	ID      int         `json:"id"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
+// RPCResponse represents a JSON-RPC response
+type RPCResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      int             `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *RPCError       `json:"error,omitempty"`
+}
+
+// RPCError represents a JSON-RPC error
+type RPCError struct {
+	Code    int         `json:"code"`
+	Message string      `json:"message"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// Error implements the error interface
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("RPC error %d: %s", e.Code, e.Message)
+}
+
+// IsNotFound reports whether the error means the requested block or
+// transaction does not exist. AvalancheGo reports every failure with the
+// same code, so the database's "not found" in the message tells them apart.
+func (e *RPCError) IsNotFound() bool {
+	return strings.Contains(e.Message, "not found")
+}
+
+// HeightResponse represents the result of avm.getHeight and platform.getHeight
+type HeightResponse struct {
+	Height FlexUint64 `json:"height"`
+}
+
+// BlockResponse represents the result of the getBlock and getBlockByHeight methods
+type BlockResponse struct {
+	Block    Block  `json:"block"`
+	Encoding string `json:"encoding"`
+}
+
+// TxResponse represents the result of avm.getTx and platform.getTx
+type TxResponse struct {
+	Tx       Tx     `json:"tx"`
+	Encoding string `json:"encoding"`
+}
+
+// AssetResponse represents the result of avm.getAssetDescription and
+// platform.getStakingAssetID
+type AssetResponse struct {
+	AssetID      string     `json:"assetID"`
+	Name         string     `json:"name,omitempty"`
+	Symbol       string     `json:"symbol,omitempty"`
+	Denomination FlexUint64 `json:"denomination,omitempty"`
+}
+
+// Block represents an X-Chain or P-Chain block. Standard blocks carry txs,
+// Apricot proposal and atomic blocks carry a single tx, and commit and
+// abort blocks carry none.
+type Block struct {
+	ID         string     `json:"id"`
+	ParentID   string     `json:"parentID"`
+	Height     FlexUint64 `json:"height"`
+	Time       FlexUint64 `json:"time"`
+	MerkleRoot string     `json:"merkleRoot,omitempty"`
+	Txs        []Tx       `json:"txs,omitempty"`
+	Tx         *Tx        `json:"tx,omitempty"`
+}
+
+// Transactions returns the transactions of the block regardless of its type
+func (b *Block) Transactions() []Tx {
+	if b.Tx == nil {
+		return b.Txs
+	}
+
+	txs := make([]Tx, 0, len(b.Txs)+1)
+	txs = append(txs, *b.Tx)
+	return append(txs, b.Txs...)
+}
+
+// Tx represents a signed transaction
+type Tx struct {
+	ID          string          `json:"id"`
+	UnsignedTx  UnsignedTx      `json:"unsignedTx"`
+	Credentials json.RawMessage `json:"credentials,omitempty"`
+}
+
+// UnsignedTx holds the union of the fields of the X-Chain and P-Chain
+// transaction types. The JSON encoding does not name the type, so Type
+// infers it from the fields that are present.
+type UnsignedTx struct {
+	// BaseTx fields shared by most transaction types
+	NetworkID    uint32               `json:"networkID,omitempty"`
+	BlockchainID string               `json:"blockchainID,omitempty"`
+	Outputs      []TransferableOutput `json:"outputs,omitempty"`
+	Inputs       []TransferableInput  `json:"inputs,omitempty"`
+	Memo         string               `json:"memo,omitempty"`
+
+	// Atomic transactions
+	SourceChain      string               `json:"sourceChain,omitempty"`
+	ImportedInputs   []TransferableInput  `json:"importedInputs,omitempty"`
+	DestinationChain string               `json:"destinationChain,omitempty"`
+	ExportedOutputs  []TransferableOutput `json:"exportedOutputs,omitempty"`
+
+	// X-Chain asset transactions
+	Name          string          `json:"name,omitempty"`
+	Symbol        string          `json:"symbol,omitempty"`
+	Denomination  *uint8          `json:"denomination,omitempty"`
+	InitialStates json.RawMessage `json:"initialStates,omitempty"`
+	Operations    json.RawMessage `json:"operations,omitempty"`
+
+	// P-Chain staking transactions
+	Validator              *Validator           `json:"validator,omitempty"`
+	SubnetID               string               `json:"subnetID,omitempty"`
+	Signer                 json.RawMessage      `json:"signer,omitempty"`
+	Stake                  []TransferableOutput `json:"stake,omitempty"`
+	RewardsOwner           *OutputOwners        `json:"rewardsOwner,omitempty"`
+	ValidationRewardsOwner *OutputOwners        `json:"validationRewardsOwner,omitempty"`
+	DelegationRewardsOwner *OutputOwners        `json:"delegationRewardsOwner,omitempty"`
+	Shares                 *uint32              `json:"shares,omitempty"`
+	SubnetAuthorization    json.RawMessage      `json:"subnetAuthorization,omitempty"`
+	NodeID                 string               `json:"nodeID,omitempty"`
+
+	// P-Chain subnet and system transactions
+	TxID      string          `json:"txID,omitempty"`
+	Time      FlexUint64      `json:"time,omitempty"`
+	Owner     *OutputOwners   `json:"owner,omitempty"`
+	NewOwner  *OutputOwners   `json:"newOwner,omitempty"`
+	ChainName string          `json:"chainName,omitempty"`
+	VMID      string          `json:"vmID,omitempty"`
+	FxIDs     []string        `json:"fxIDs,omitempty"`
+	AssetID   string          `json:"assetID,omitempty"`
+	Genesis   json.RawMessage `json:"genesisData,omitempty"`
+}
+
+// Type infers the transaction type from the fields that are present
+func (u *UnsignedTx) Type() string {
+	switch {
+	case u.SourceChain != "" || len(u.ImportedInputs) > 0:
+		return TxTypeImport
+	case u.DestinationChain != "" || len(u.ExportedOutputs) > 0:
+		return TxTypeExport
+	case u.Symbol != "" || len(u.InitialStates) > 0:
+		return TxTypeCreateAsset
+	case len(u.Operations) > 0:
+		return TxTypeOperation
+	case u.Validator != nil:
+		return u.stakerType()
+	case u.NodeID != "" && u.SubnetID != "":
+		return TxTypeRemoveSubnetValidator
+	case u.TxID != "":
+		return TxTypeRewardValidator
+	case u.Time != 0 && u.BlockchainID == "":
+		return TxTypeAdvanceTime
+	case u.ChainName != "" || u.VMID != "":
+		return TxTypeCreateChain
+	case u.NewOwner != nil:
+		return TxTypeTransferSubnetOwnership
+	case u.AssetID != "" && u.SubnetID != "":
+		return TxTypeTransformSubnet
+	case u.Owner != nil:
+		return TxTypeCreateSubnet
+	default:
+		return TxTypeBase
+	}
+}
+
+// stakerType distinguishes the transactions that add a validator or delegator
+func (u *UnsignedTx) stakerType() string {
+	permissionless := u.ValidationRewardsOwner != nil || len(u.Signer) > 0 ||
+		(u.SubnetID != "" && len(u.Stake) > 0)
+
+	switch {
+	case len(u.SubnetAuthorization) > 0:
+		return TxTypeAddSubnetValidator
+	case permissionless && u.Shares != nil:
+		return TxTypeAddPermissionlessValidator
+	case permissionless:
+		return TxTypeAddPermissionlessDelegator
+	case u.Shares != nil:
+		return TxTypeAddValidator
+	default:
+		return TxTypeAddDelegator
+	}
+}
+
+// IsStaker returns true for transactions that add a validator or delegator
+func (u *UnsignedTx) IsStaker() bool {
+	return u.Validator != nil
+}
+
+// Validator represents the validator of a staking transaction
+type Validator struct {
+	NodeID   string     `json:"nodeID"`
+	Start    FlexUint64 `json:"start"`
+	End      FlexUint64 `json:"end"`
+	Weight   FlexUint64 `json:"weight"`
+	SubnetID string     `json:"subnetID,omitempty"`
+}
+
+// TransferableOutput represents an output of a transaction
+type TransferableOutput struct {
+	AssetID string `json:"assetID"`
+	FxID    string `json:"fxID,omitempty"`
+	Output  Output `json:"output"`
+}
+
+// Output represents a secp256k1fx transfer output. Stakeable outputs wrap
+// the transfer output with a lock time, which UnmarshalJSON flattens.
+type Output struct {
+	Amount          FlexUint64 `json:"amount"`
+	Locktime        FlexUint64 `json:"locktime"`
+	Threshold       uint32     `json:"threshold"`
+	Addresses       []string   `json:"addresses"`
+	StakeableLocked FlexUint64 `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler for plain and stakeable outputs
+func (o *Output) UnmarshalJSON(data []byte) error {
+	type plain Output
+	var wrapper struct {
+		Locktime FlexUint64      `json:"locktime"`
+		Output   json.RawMessage `json:"output"`
+	}
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		return err
+	}
+
+	if len(wrapper.Output) > 0 {
+		var inner plain
+		if err := json.Unmarshal(wrapper.Output, &inner); err != nil {
+			return err
+		}
+		*o = Output(inner)
+		o.StakeableLocked = wrapper.Locktime
+		return nil
+	}
+
+	var v plain
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*o = Output(v)
+	return nil
+}
+
+// TransferableInput represents an input of a transaction, which spends the
+// output at OutputIndex of transaction TxID
+type TransferableInput struct {
+	TxID        string `json:"txID"`
+	OutputIndex uint32 `json:"outputIndex"`
+	AssetID     string `json:"assetID"`
+	FxID        string `json:"fxID,omitempty"`
+	Input       Input  `json:"input"`
+}
+
+// UTXOID returns the identifier of the spent output
+func (i *TransferableInput) UTXOID() string {
+	return UTXOID(i.TxID, i.OutputIndex)
+}
+
+// Input represents a secp256k1fx transfer input. Stakeable inputs wrap the
+// transfer input with a lock time, which UnmarshalJSON flattens.
+type Input struct {
+	Amount           FlexUint64 `json:"amount"`
+	SignatureIndices []uint32   `json:"signatureIndices"`
+	StakeableLocked  FlexUint64 `json:"-"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler for plain and stakeable inputs
+func (i *Input) UnmarshalJSON(data []byte) error {
+	type plain Input
+	var wrapper struct {
+		Locktime FlexUint64      `json:"locktime"`
+		Input    json.RawMessage `json:"input"`
+	}
+	if err := json.Unmarshal(data, &wrapper); err != nil {
+		return err
+	}
+
+	if len(wrapper.Input) > 0 {
+		var inner plain
+		if err := json.Unmarshal(wrapper.Input, &inner); err != nil {
+			return err
+		}
+		*i = Input(inner)
+		i.StakeableLocked = wrapper.Locktime
+		return nil
+	}
+
+	var v plain
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*i = Input(v)
+	return nil
+}
+
+// OutputOwners represents the owners of rewards or of a subnet
+type OutputOwners struct {
+	Locktime  FlexUint64 `json:"locktime"`
+	Threshold uint32     `json:"threshold"`
+	Addresses []string   `json:"addresses"`
+}
+
+// UTXOID formats the identifier of an output as txID:outputIndex
+func UTXOID(txID string, outputIndex uint32) string {
+	return fmt.Sprintf("%s:%d", txID, outputIndex)
+}
+
+// FlexUint64 decodes integers that AvalancheGo encodes either as JSON
+// numbers or as decimal strings
+type FlexUint64 uint64
+
+// UnmarshalJSON implements json.Unmarshaler
+func (f *FlexUint64) UnmarshalJSON(data []byte) error {
+	s := string(bytes.Trim(data, `"`))
+	if s == "" || s == "null" {
+		*f = 0
+		return nil
+	}
+
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid integer %s: %w", data, err)
+	}
+	*f = FlexUint64(v)
+	return nil
+}
@@ -0,0 +1,417 @@
+package avalanche
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/service"
+)
+
+// Ensure UTXOAdapter implements ChainAdapter interface
+var _ service.ChainAdapter = (*UTXOAdapter)(nil)
+
+// txCacheSize bounds the number of spent transactions kept for resolving
+// input owners
+const txCacheSize = 10000
+
+// UTXOAdapter implements the ChainAdapter interface for the X-Chain and
+// P-Chain, which use UTXO transactions and expose blocks through the avm and
+// platform APIs. X-Chain blocks start at the Cortina linearization; earlier
+// transactions were accepted in a DAG and are not part of any block.
+type UTXOAdapter struct {
+	config     *Config
+	client     *Client
+	normalizer *Normalizer
+	chainInfo  *models.ChainInfo
+	mu         sync.RWMutex
+	connected  bool
+	feeAssetID string
+
+	cacheMu sync.Mutex
+	txCache map[string]*Tx
+}
+
+// NewUTXOAdapter creates a new X-Chain or P-Chain adapter
+func NewUTXOAdapter(config *Config) (*UTXOAdapter, error) {
+	client, err := NewClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	normalizer := NewNormalizer(config.ChainID, config.Network, config.ChainType)
+
+	return &UTXOAdapter{
+		config:     config,
+		client:     client,
+		normalizer: normalizer,
+		chainInfo:  normalizer.NormalizeChainInfo(config.ChainName),
+		txCache:    make(map[string]*Tx),
+	}, nil
+}
+
+// GetChainType returns the chain type
+func (a *UTXOAdapter) GetChainType() models.ChainType {
+	return models.ChainTypeAvalanche
+}
+
+// GetChainID returns the chain ID
+func (a *UTXOAdapter) GetChainID() string {
+	return a.config.ChainID
+}
+
+// GetChainInfo returns chain information
+func (a *UTXOAdapter) GetChainInfo() *models.ChainInfo {
+	return a.chainInfo
+}
+
+// Capabilities reports the features the adapter supports. The avm and
+// platform APIs have no subscriptions.
+func (a *UTXOAdapter) Capabilities() models.Capabilities {
+	return models.Capabilities{
+		BlockByHash:       true,
+		TransactionByHash: true,
+		BatchFetch:        true,
+		MaxBlockRange:     uint64(a.config.BatchSize),
+	}
+}
+
+// GetLatestBlockNumber returns the height of the last accepted block
+func (a *UTXOAdapter) GetLatestBlockNumber(ctx context.Context) (uint64, error) {
+	height, err := a.client.GetHeight(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get height: %w", err)
+	}
+
+	return height, nil
+}
+
+// GetFinalityHeads returns the latest height for both heads, since accepted
+// Snowman blocks are final
+func (a *UTXOAdapter) GetFinalityHeads(ctx context.Context) (*models.FinalityHeads, error) {
+	height, err := a.GetLatestBlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.FinalityHeads{Safe: height, Finalized: height}, nil
+}
+
+// GetBlockByNumber fetches a block by height
+func (a *UTXOAdapter) GetBlockByNumber(ctx context.Context, number uint64) (*models.Block, error) {
+	block, err := a.client.GetBlockByHeight(ctx, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %d: %w", number, err)
+	}
+
+	return a.normalizeBlock(ctx, block)
+}
+
+// GetBlockByHash fetches a block by ID
+func (a *UTXOAdapter) GetBlockByHash(ctx context.Context, hash string) (*models.Block, error) {
+	block, err := a.client.GetBlock(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %s: %w", hash, err)
+	}
+
+	return a.normalizeBlock(ctx, block)
+}
+
+// GetBlocks fetches multiple blocks in a range
+func (a *UTXOAdapter) GetBlocks(ctx context.Context, start, end uint64) ([]*models.Block, error) {
+	if start > end {
+		return nil, fmt.Errorf("invalid range: start %d > end %d", start, end)
+	}
+
+	// Limit range to the batch size
+	if end-start >= uint64(a.config.BatchSize) {
+		end = start + uint64(a.config.BatchSize) - 1
+	}
+
+	count := int(end - start + 1)
+	blocks := make([]*models.Block, count)
+	errs := make([]error, count)
+
+	// Fetch blocks concurrently, bounded by the connection limit
+	semaphore := make(chan struct{}, a.config.MaxConnections)
+	var wg sync.WaitGroup
+
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			blocks[i], errs[i] = a.GetBlockByNumber(ctx, start+uint64(i))
+		}(i)
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return blocks, nil
+}
+
+// GetTransaction fetches a transaction by ID. The APIs do not report the
+// block of a transaction, so the block fields are left empty.
+func (a *UTXOAdapter) GetTransaction(ctx context.Context, hash string) (*models.Transaction, error) {
+	tx, err := a.client.GetTx(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction %s: %w", hash, err)
+	}
+
+	feeAssetID, err := a.getFeeAssetID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	owners, err := a.resolveOwners(ctx, []Tx{*tx})
+	if err != nil {
+		return nil, err
+	}
+
+	domainTx, err := a.normalizer.NormalizeTransaction(tx, 0, nil, feeAssetID, owners)
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize transaction %s: %w", hash, err)
+	}
+
+	return domainTx, nil
+}
+
+// GetTransactionsByBlock fetches all transactions in a block
+func (a *UTXOAdapter) GetTransactionsByBlock(ctx context.Context, blockNumber uint64) ([]*models.Transaction, error) {
+	block, err := a.GetBlockByNumber(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return block.Transactions, nil
+}
+
+// IsHealthy checks if the adapter is healthy
+func (a *UTXOAdapter) IsHealthy(ctx context.Context) bool {
+	if !a.IsConnected() {
+		return false
+	}
+
+	_, err := a.client.GetHeight(ctx)
+	return err == nil
+}
+
+// Connect connects to the node and resolves the fee asset
+func (a *UTXOAdapter) Connect(ctx context.Context) error {
+	if a.IsConnected() {
+		return nil
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if _, err := a.getFeeAssetID(ctx); err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+
+	a.mu.Lock()
+	a.connected = true
+	a.mu.Unlock()
+
+	return nil
+}
+
+// Disconnect closes the connection
+func (a *UTXOAdapter) Disconnect() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.connected {
+		return nil
+	}
+
+	if err := a.client.Close(); err != nil {
+		return fmt.Errorf("failed to disconnect: %w", err)
+	}
+
+	a.connected = false
+	return nil
+}
+
+// SubscribeNewBlocks is not supported, the avm and platform APIs have no subscriptions
+func (a *UTXOAdapter) SubscribeNewBlocks(ctx context.Context) (service.BlockSubscription, error) {
+	return nil, fmt.Errorf("block subscriptions for the %s: %w", a.config.ChainType, service.ErrNotSupported)
+}
+
+// SubscribeNewTransactions is not supported, the avm and platform APIs have no subscriptions
+func (a *UTXOAdapter) SubscribeNewTransactions(ctx context.Context) (service.TransactionSubscription, error) {
+	return nil, fmt.Errorf("transaction subscriptions for the %s: %w", a.config.ChainType, service.ErrNotSupported)
+}
+
+// GetConfig returns the adapter configuration
+func (a *UTXOAdapter) GetConfig() *Config {
+	return a.config
+}
+
+// IsConnected returns whether the adapter is connected
+func (a *UTXOAdapter) IsConnected() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.connected
+}
+
+// normalizeBlock resolves the owners of the block's inputs and normalizes it
+func (a *UTXOAdapter) normalizeBlock(ctx context.Context, block *Block) (*models.Block, error) {
+	feeAssetID, err := a.getFeeAssetID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	owners, err := a.resolveOwners(ctx, block.Transactions())
+	if err != nil {
+		return nil, err
+	}
+
+	domainBlock, err := a.normalizer.NormalizeBlock(block, feeAssetID, owners)
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize block %d: %w", uint64(block.Height), err)
+	}
+
+	return domainBlock, nil
+}
+
+// getFeeAssetID returns the fee asset, resolving it on first use
+func (a *UTXOAdapter) getFeeAssetID(ctx context.Context) (string, error) {
+	a.mu.RLock()
+	feeAssetID := a.feeAssetID
+	a.mu.RUnlock()
+
+	if feeAssetID != "" {
+		return feeAssetID, nil
+	}
+
+	feeAssetID, err := a.client.GetFeeAssetID(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get fee asset: %w", err)
+	}
+
+	a.mu.Lock()
+	a.feeAssetID = feeAssetID
+	a.mu.Unlock()
+
+	return feeAssetID, nil
+}
+
+// resolveOwners looks up the owners of the outputs spent by txs. Outputs
+// created in the same batch are resolved locally, the others by fetching
+// the transactions that created them. Imported inputs were created on
+// another chain and are not resolved.
+func (a *UTXOAdapter) resolveOwners(ctx context.Context, txs []Tx) (InputOwners, error) {
+	local := make(map[string]*Tx, len(txs))
+	for i := range txs {
+		local[txs[i].ID] = &txs[i]
+	}
+
+	// Collect the transactions to fetch
+	spent := make(map[string]*Tx)
+	var missing []string
+	for i := range txs {
+		for _, in := range txs[i].UnsignedTx.Inputs {
+			if _, ok := spent[in.TxID]; ok {
+				continue
+			}
+			tx, ok := local[in.TxID]
+			if !ok {
+				tx = a.cachedTx(in.TxID)
+			}
+			spent[in.TxID] = tx
+			if tx == nil {
+				missing = append(missing, in.TxID)
+			}
+		}
+	}
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		firstErr  error
+		semaphore = make(chan struct{}, a.config.MaxConnections)
+	)
+
+	for _, txID := range missing {
+		wg.Add(1)
+		go func(txID string) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			tx, err := a.client.GetTx(ctx, txID)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			var rpcErr *RPCError
+			switch {
+			case errors.As(err, &rpcErr):
+				// The node cannot serve the transaction, such as a
+				// genesis allocation; leave its outputs unresolved
+			case err != nil:
+				if firstErr == nil {
+					firstErr = fmt.Errorf("failed to get spent transaction %s: %w", txID, err)
+				}
+			default:
+				spent[txID] = tx
+				a.cacheTx(tx)
+			}
+		}(txID)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	owners := make(InputOwners)
+	for i := range txs {
+		for _, in := range txs[i].UnsignedTx.Inputs {
+			if tx := spent[in.TxID]; tx != nil {
+				if addrs := spentOutputOwners(tx, in.OutputIndex); len(addrs) > 0 {
+					owners[in.UTXOID()] = addrs
+				}
+			}
+		}
+	}
+
+	// Later blocks often spend outputs of this one
+	for i := range txs {
+		a.cacheTx(&txs[i])
+	}
+
+	return owners, nil
+}
+
+func (a *UTXOAdapter) cachedTx(txID string) *Tx {
+	a.cacheMu.Lock()
+	defer a.cacheMu.Unlock()
+	return a.txCache[txID]
+}
+
+func (a *UTXOAdapter) cacheTx(tx *Tx) {
+	a.cacheMu.Lock()
+	defer a.cacheMu.Unlock()
+
+	// Start over rather than track recency, spends are mostly recent
+	if len(a.txCache) >= txCacheSize {
+		a.txCache = make(map[string]*Tx)
+	}
+	a.txCache[tx.ID] = tx
+}
@@ -0,0 +1,354 @@
+package bitcoin
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/service"
+)
+
+// Ensure Adapter implements ChainAdapter interface
+var _ service.ChainAdapter = (*Adapter)(nil)
+
+// Adapter implements the ChainAdapter interface for Bitcoin and other
+// chains served by a bitcoind-compatible JSON-RPC API
+type Adapter struct {
+	config     *Config
+	client     *Client
+	normalizer *Normalizer
+	chainInfo  *models.ChainInfo
+	mu         sync.RWMutex
+	connected  bool
+}
+
+// NewAdapter creates a new Bitcoin chain adapter
+func NewAdapter(config *Config) (*Adapter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	client, err := NewClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	normalizer := NewNormalizer(config.ChainID, config.Network)
+
+	adapter := &Adapter{
+		config:     config,
+		client:     client,
+		normalizer: normalizer,
+		chainInfo:  normalizer.NormalizeChainInfo(config.ChainName),
+		connected:  false,
+	}
+
+	return adapter, nil
+}
+
+// GetChainType returns the chain type
+func (a *Adapter) GetChainType() models.ChainType {
+	return models.ChainTypeBitcoin
+}
+
+// GetChainID returns the chain ID
+func (a *Adapter) GetChainID() string {
+	return a.config.ChainID
+}
+
+// GetChainInfo returns chain information
+func (a *Adapter) GetChainInfo() *models.ChainInfo {
+	return a.chainInfo
+}
+
+// Capabilities reports the features the adapter supports. Confirmed
+// transactions are only found by hash if the node runs with txindex.
+func (a *Adapter) Capabilities() models.Capabilities {
+	return models.Capabilities{
+		BlockByHash:       true,
+		TransactionByHash: true,
+		BatchFetch:        true,
+		MaxBlockRange:     uint64(a.config.BatchSize),
+	}
+}
+
+// GetLatestBlockNumber returns the height of the node's best chain
+func (a *Adapter) GetLatestBlockNumber(ctx context.Context) (uint64, error) {
+	height, err := a.client.GetBlockCount(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get block count: %w", err)
+	}
+
+	return height, nil
+}
+
+// GetFinalityHeads treats blocks FinalityDepth deep as finalized, since
+// proof of work never finalizes blocks
+func (a *Adapter) GetFinalityHeads(ctx context.Context) (*models.FinalityHeads, error) {
+	height, err := a.GetLatestBlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	finalized := uint64(0)
+	if height > a.config.FinalityDepth {
+		finalized = height - a.config.FinalityDepth
+	}
+
+	return &models.FinalityHeads{Safe: finalized, Finalized: finalized}, nil
+}
+
+// GetBlockByNumber fetches the block at a height of the best chain
+func (a *Adapter) GetBlockByNumber(ctx context.Context, number uint64) (*models.Block, error) {
+	hash, err := a.client.GetBlockHash(ctx, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block hash %d: %w", number, err)
+	}
+
+	return a.GetBlockByHash(ctx, hash)
+}
+
+// GetBlockByHash fetches a block by hash
+func (a *Adapter) GetBlockByHash(ctx context.Context, hash string) (*models.Block, error) {
+	block, err := a.client.GetBlock(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %s: %w", hash, err)
+	}
+
+	txs, err := block.Transactions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode block %s: %w", hash, err)
+	}
+
+	prevouts, err := a.resolvePrevouts(ctx, txs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve inputs of block %s: %w", hash, err)
+	}
+
+	domainBlock, err := a.normalizer.NormalizeBlock(block, prevouts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize block %s: %w", hash, err)
+	}
+
+	return domainBlock, nil
+}
+
+// GetBlocks fetches multiple blocks in a range
+func (a *Adapter) GetBlocks(ctx context.Context, start, end uint64) ([]*models.Block, error) {
+	if start > end {
+		return nil, fmt.Errorf("invalid range: start %d > end %d", start, end)
+	}
+
+	// Limit range to the batch size
+	if end-start >= uint64(a.config.BatchSize) {
+		end = start + uint64(a.config.BatchSize) - 1
+	}
+
+	count := int(end - start + 1)
+	blocks := make([]*models.Block, count)
+	errs := make([]error, count)
+
+	// Fetch blocks concurrently, bounded by the connection limit
+	semaphore := make(chan struct{}, a.config.MaxConnections)
+	var wg sync.WaitGroup
+
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			blocks[i], errs[i] = a.GetBlockByNumber(ctx, start+uint64(i))
+		}(i)
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return blocks, nil
+}
+
+// GetTransaction fetches a transaction by ID. Confirmed transactions are
+// only found if the node runs with txindex; mempool transactions are
+// returned as pending.
+func (a *Adapter) GetTransaction(ctx context.Context, hash string) (*models.Transaction, error) {
+	tx, err := a.client.GetRawTransaction(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction %s: %w", hash, err)
+	}
+
+	// getrawtransaction reports the block hash but neither the height nor
+	// the position in the block
+	var index, number uint64
+	if tx.BlockHash != "" {
+		block, err := a.client.GetBlockTxIDs(ctx, tx.BlockHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get block %s: %w", tx.BlockHash, err)
+		}
+		txids, err := block.TxIDs()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode block %s: %w", tx.BlockHash, err)
+		}
+
+		number = block.Height
+		for i, txid := range txids {
+			if txid == tx.TxID {
+				index = uint64(i)
+				break
+			}
+		}
+	}
+
+	prevouts, err := a.resolvePrevouts(ctx, []*Transaction{tx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve inputs of transaction %s: %w", hash, err)
+	}
+
+	domainTx, err := a.normalizer.NormalizeTransaction(tx, index, number, tx.BlockHash, tx.BlockTime, prevouts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize transaction %s: %w", hash, err)
+	}
+
+	return domainTx, nil
+}
+
+// GetTransactionsByBlock fetches all transactions in a block
+func (a *Adapter) GetTransactionsByBlock(ctx context.Context, blockNumber uint64) ([]*models.Transaction, error) {
+	block, err := a.GetBlockByNumber(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return block.Transactions, nil
+}
+
+// resolvePrevouts returns the outputs spent by the inputs of txs that do
+// not carry their prevout. Outputs created by txs themselves are taken from
+// them; the others are fetched with getrawtransaction, each transaction
+// once. Nothing is fetched unless ResolvePrevouts is set.
+func (a *Adapter) resolvePrevouts(ctx context.Context, txs []*Transaction) (map[Outpoint]*Vout, error) {
+	prevouts := make(map[Outpoint]*Vout)
+	if !a.config.ResolvePrevouts {
+		return prevouts, nil
+	}
+
+	addOutputs := func(tx *Transaction) {
+		for _, vout := range tx.Vout {
+			prevouts[Outpoint{TxID: tx.TxID, Vout: vout.N}] = vout
+		}
+	}
+	for _, tx := range txs {
+		addOutputs(tx)
+	}
+
+	var missing []string
+	seen := make(map[string]bool)
+	for _, tx := range txs {
+		for _, vin := range tx.Vin {
+			if vin.IsCoinbase() || vin.Prevout != nil || seen[vin.TxID] {
+				continue
+			}
+			if _, ok := prevouts[Outpoint{TxID: vin.TxID, Vout: vin.Vout}]; ok {
+				continue
+			}
+			seen[vin.TxID] = true
+			missing = append(missing, vin.TxID)
+		}
+	}
+	if len(missing) == 0 {
+		return prevouts, nil
+	}
+
+	prevTxs, err := a.client.GetRawTransactions(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	for _, tx := range prevTxs {
+		addOutputs(tx)
+	}
+
+	return prevouts, nil
+}
+
+// IsHealthy checks if the adapter is healthy
+func (a *Adapter) IsHealthy(ctx context.Context) bool {
+	if !a.IsConnected() {
+		return false
+	}
+
+	info, err := a.client.GetBlockchainInfo(ctx)
+	if err != nil {
+		return false
+	}
+
+	return !info.InitialBlockDownload
+}
+
+// Connect connects to the bitcoind node
+func (a *Adapter) Connect(ctx context.Context) error {
+	if a.IsConnected() {
+		return nil
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if _, err := a.client.GetBlockchainInfo(ctx); err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+
+	a.mu.Lock()
+	a.connected = true
+	a.mu.Unlock()
+
+	return nil
+}
+
+// Disconnect closes the connection
+func (a *Adapter) Disconnect() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.connected {
+		return nil
+	}
+
+	if err := a.client.Close(); err != nil {
+		return fmt.Errorf("failed to disconnect: %w", err)
+	}
+
+	a.connected = false
+	return nil
+}
+
+// SubscribeNewBlocks is not supported: bitcoind only publishes new blocks
+// over ZMQ, so new blocks are polled for
+func (a *Adapter) SubscribeNewBlocks(ctx context.Context) (service.BlockSubscription, error) {
+	return nil, fmt.Errorf("block subscriptions for Bitcoin: %w", service.ErrNotSupported)
+}
+
+// SubscribeNewTransactions is not supported for Bitcoin
+func (a *Adapter) SubscribeNewTransactions(ctx context.Context) (service.TransactionSubscription, error) {
+	return nil, fmt.Errorf("transaction subscriptions for Bitcoin: %w", service.ErrNotSupported)
+}
+
+// GetConfig returns the adapter configuration
+func (a *Adapter) GetConfig() *Config {
+	return a.config
+}
+
+// IsConnected returns whether the adapter is connected
+func (a *Adapter) IsConnected() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.connected
+}
@@ -0,0 +1,472 @@
+package bitcoin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/storage/pebble"
+)
+
+// Transactions and blocks of the recorded regtest fixture
+const (
+	fixtureBlockHash = "835aa5064ae0747d80be6c6e44dd373ffbb2dbe411c55419de1b0d2001712cfb"
+	fixturePrevTx    = "b441be8135fc63be211195613db74f6232d4ad28c066f44a3f7376693b670dd2"
+	fixtureCoinbase  = "a63b357231206afb7478e4068e2dbcbcbc6efecc79e431c5eec44841706dc9e0"
+	fixtureAliceBob  = "78d1515b3c06cdca2c1ac63963e5e00a8e8f4636d4edc90edee88941c42b35b3"
+	fixtureBobCarol  = "7c58d3e49fcad3db324f8d1ffa55663aeb7d37c3b4cf80b648bad373f3a9fc4a"
+	fixtureUnknownTx = "b23a6a8439c0dde5515893e7c90c1e3233b8616e634470f20dc4928bcf3609bc"
+
+	fixtureUnknownBlock = "0000000000000000000000000000000000000000000000000000000000000bad"
+
+	alice = "bcrt1qalice0000000000000000000000000000000000"
+	bob   = "bcrt1qbob00000000000000000000000000000000000000"
+	carol = "bcrt1qcarol0000000000000000000000000000000000"
+	miner = "bcrt1qminer0000000000000000000000000000000000"
+)
+
+// interaction is a recorded bitcoind request and its response
+type interaction struct {
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *RPCError       `json:"error,omitempty"`
+}
+
+// fakeBitcoind serves the interactions recorded in a fixture file, like a
+// node that only knows the requests it was recorded answering
+type fakeBitcoind struct {
+	t            *testing.T
+	server       *httptest.Server
+	interactions map[string]interaction
+
+	mu       sync.Mutex
+	requests []RPCRequest
+	batches  [][]RPCRequest
+	auth     [2]string
+}
+
+func newFakeBitcoind(t *testing.T, fixture string) *fakeBitcoind {
+	t.Helper()
+
+	data, err := os.ReadFile(fixture)
+	if err != nil {
+		t.Fatalf("failed to read fixture: %v", err)
+	}
+	var recorded struct {
+		Interactions []interaction `json:"interactions"`
+	}
+	if err := json.Unmarshal(data, &recorded); err != nil {
+		t.Fatalf("failed to decode fixture: %v", err)
+	}
+
+	fake := &fakeBitcoind{t: t, interactions: make(map[string]interaction)}
+	for _, rec := range recorded.Interactions {
+		var params []interface{}
+		if err := json.Unmarshal(rec.Params, &params); err != nil {
+			t.Fatalf("invalid params in fixture: %v", err)
+		}
+		fake.interactions[requestKey(rec.Method, params)] = rec
+	}
+
+	fake.server = httptest.NewServer(http.HandlerFunc(fake.serve))
+	t.Cleanup(fake.server.Close)
+
+	return fake
+}
+
+// requestKey identifies a request by method and params
+func requestKey(method string, params []interface{}) string {
+	encoded, _ := json.Marshal(params)
+	return method + string(encoded)
+}
+
+func (f *fakeBitcoind) serve(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	f.mu.Lock()
+	if user, password, ok := r.BasicAuth(); ok {
+		f.auth = [2]string{user, password}
+	}
+	f.mu.Unlock()
+
+	var batch []RPCRequest
+	if err := json.Unmarshal(body, &batch); err == nil {
+		f.mu.Lock()
+		f.batches = append(f.batches, batch)
+		f.requests = append(f.requests, batch...)
+		f.mu.Unlock()
+
+		resps := make([]RPCResponse, len(batch))
+		for i, req := range batch {
+			resps[i] = f.respond(req)
+		}
+		// bitcoind may answer batches in any order
+		for i, j := 0, len(resps)-1; i < j; i, j = i+1, j-1 {
+			resps[i], resps[j] = resps[j], resps[i]
+		}
+		json.NewEncoder(w).Encode(resps)
+		return
+	}
+
+	var req RPCRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+	f.mu.Lock()
+	f.requests = append(f.requests, req)
+	f.mu.Unlock()
+
+	resp := f.respond(req)
+	if resp.Error != nil {
+		// bitcoind reports RPC errors with a server error status
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+func (f *fakeBitcoind) respond(req RPCRequest) RPCResponse {
+	rec, ok := f.interactions[requestKey(req.Method, req.Params)]
+	if !ok {
+		f.t.Errorf("unrecorded request %s %v", req.Method, req.Params)
+		return RPCResponse{ID: req.ID, Error: &RPCError{Code: -32601, Message: "Method not found"}}
+	}
+	return RPCResponse{ID: req.ID, Result: rec.Result, Error: rec.Error}
+}
+
+// called returns how often a method was requested
+func (f *fakeBitcoind) called(method string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	count := 0
+	for _, req := range f.requests {
+		if req.Method == method {
+			count++
+		}
+	}
+	return count
+}
+
+func newTestAdapter(t *testing.T, fake *fakeBitcoind, configure func(*Config)) *Adapter {
+	t.Helper()
+
+	config := RegtestConfig()
+	config.RPCURL = fake.server.URL
+	config.RetryAttempts = 0
+	config.Timeout = 5 * time.Second
+	if configure != nil {
+		configure(config)
+	}
+
+	adapter, err := NewAdapter(config)
+	if err != nil {
+		t.Fatalf("NewAdapter() error = %v", err)
+	}
+	t.Cleanup(func() { adapter.Disconnect() })
+
+	if err := adapter.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+
+	return adapter
+}
+
+func TestDefaultConfig(t *testing.T) {
+	config := DefaultConfig()
+
+	if config.ChainID != "bitcoin-mainnet" {
+		t.Errorf("expected chain_id to be 'bitcoin-mainnet', got '%s'", config.ChainID)
+	}
+
+	if config.Network != "mainnet" {
+		t.Errorf("expected network to be 'mainnet', got '%s'", config.Network)
+	}
+
+	if !config.ResolvePrevouts {
+		t.Error("expected resolve_prevouts to be true")
+	}
+
+	if err := config.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*Config)
+	}{
+		{name: "missing chain_id", modify: func(c *Config) { c.ChainID = "" }},
+		{name: "missing rpc_url", modify: func(c *Config) { c.RPCURL = "" }},
+		{name: "zero timeout", modify: func(c *Config) { c.Timeout = 0 }},
+		{name: "zero batch size", modify: func(c *Config) { c.BatchSize = 0 }},
+		{name: "zero prevout batch size", modify: func(c *Config) { c.PrevoutBatchSize = 0 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			tt.modify(config)
+			if err := config.Validate(); err == nil {
+				t.Error("Validate() error = nil, want an error")
+			}
+		})
+	}
+
+	// The prevout batch size only matters when prevouts are resolved
+	config := DefaultConfig()
+	config.ResolvePrevouts = false
+	config.PrevoutBatchSize = 0
+	if err := config.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+}
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		amount  json.Number
+		want    int64
+		wantErr bool
+	}{
+		{amount: "50.00000000", want: 5000000000},
+		{amount: "0.00000001", want: 1},
+		{amount: "39.99999", want: 3999999000},
+		{amount: "21000000", want: 2100000000000000},
+		{amount: "-0.5", want: -50000000},
+		{amount: "0.000000001", wantErr: true},
+		{amount: "1e-05", wantErr: true},
+		{amount: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseAmount(tt.amount)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseAmount(%q) error = %v, wantErr %v", tt.amount, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseAmount(%q) = %d, want %d", tt.amount, got, tt.want)
+		}
+	}
+}
+
+func TestAdapter_GetLatestBlockNumber(t *testing.T) {
+	fake := newFakeBitcoind(t, "testdata/regtest.json")
+	adapter := newTestAdapter(t, fake, nil)
+
+	number, err := adapter.GetLatestBlockNumber(context.Background())
+	if err != nil {
+		t.Fatalf("GetLatestBlockNumber() error = %v", err)
+	}
+	if number != 101 {
+		t.Errorf("GetLatestBlockNumber() = %d, want 101", number)
+	}
+
+	if !adapter.IsHealthy(context.Background()) {
+		t.Error("IsHealthy() = false, want true")
+	}
+}
+
+func TestAdapter_GetBlockByNumber(t *testing.T) {
+	fake := newFakeBitcoind(t, "testdata/regtest.json")
+	adapter := newTestAdapter(t, fake, nil)
+
+	block, err := adapter.GetBlockByNumber(context.Background(), 101)
+	if err != nil {
+		t.Fatalf("GetBlockByNumber() error = %v", err)
+	}
+
+	if block.ChainType != models.ChainTypeBitcoin || block.Number != 101 || block.Hash != fixtureBlockHash {
+		t.Errorf("block = %s #%d %s", block.ChainType, block.Number, block.Hash)
+	}
+	if block.TxCount != 3 || len(block.Transactions) != 3 || block.Size != 776 {
+		t.Fatalf("block has %d transactions and %d bytes, want 3 and 776", block.TxCount, block.Size)
+	}
+
+	// The coinbase creates the subsidy plus the fees
+	coinbase := block.Transactions[0]
+	if coinbase.Hash != fixtureCoinbase || !coinbase.Inputs[0].IsCoinbase() || coinbase.From != "" || coinbase.To != miner {
+		t.Errorf("coinbase = %s from %q to %q", coinbase.Hash, coinbase.From, coinbase.To)
+	}
+	if coinbase.Value != "5000002000" || coinbase.Fee != "0" {
+		t.Errorf("coinbase value %s fee %s, want 5000002000 and 0", coinbase.Value, coinbase.Fee)
+	}
+	if out := coinbase.Outputs[1]; out.Address != "" || out.ScriptType != "nulldata" || out.Value != "0" {
+		t.Errorf("witness commitment output = %+v", out)
+	}
+
+	// Spends an output of an earlier block, resolved with getrawtransaction
+	// from a node that reports addresses the pre-22 way
+	payment := block.Transactions[1]
+	if payment.Hash != fixtureAliceBob || payment.Index != 1 || payment.BlockHash != fixtureBlockHash {
+		t.Errorf("payment = %s at %d in %s", payment.Hash, payment.Index, payment.BlockHash)
+	}
+	if input := payment.Inputs[0]; input.PrevTxHash != fixturePrevTx || input.PrevOutputIndex != 0 ||
+		input.Address != alice || input.Value != "5000000000" {
+		t.Errorf("input = %+v", input)
+	}
+	if payment.From != alice || payment.To != bob || payment.Value != "1000000000" || payment.Fee != "1000" {
+		t.Errorf("payment from %s to %s of %s with fee %s", payment.From, payment.To, payment.Value, payment.Fee)
+	}
+	if len(payment.Outputs) != 2 || payment.Outputs[1].Address != alice || payment.Outputs[1].Value != "3999999000" {
+		t.Errorf("outputs = %+v", payment.Outputs)
+	}
+
+	// Spends an output created earlier in the same block
+	spend := block.Transactions[2]
+	if spend.From != bob || spend.To != carol || spend.Value != "400000000" || spend.Fee != "1000" {
+		t.Errorf("spend from %s to %s of %s with fee %s", spend.From, spend.To, spend.Value, spend.Fee)
+	}
+	if spend.Inputs[0].Value != "1000000000" {
+		t.Errorf("in-block input value = %s, want 1000000000", spend.Inputs[0].Value)
+	}
+
+	// Only the output of the earlier block is fetched, in a single batch
+	if len(fake.batches) != 1 || len(fake.batches[0]) != 1 || fake.batches[0][0].Params[0] != fixturePrevTx {
+		t.Errorf("prevout batches = %v, want one request for %s", fake.batches, fixturePrevTx)
+	}
+}
+
+func TestAdapter_BlocksAreStorable(t *testing.T) {
+	tests := []struct {
+		name      string
+		configure func(*Config)
+	}{
+		{"with prevouts", nil},
+		{"without prevouts", func(c *Config) { c.ResolvePrevouts = false }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeBitcoind(t, "testdata/regtest.json")
+			adapter := newTestAdapter(t, fake, tt.configure)
+
+			ctx := context.Background()
+			block, err := adapter.GetBlockByNumber(ctx, 101)
+			if err != nil {
+				t.Fatalf("GetBlockByNumber() error = %v", err)
+			}
+
+			storage, err := pebble.NewStorage(pebble.DefaultConfig(t.TempDir()))
+			if err != nil {
+				t.Fatalf("NewStorage() error = %v", err)
+			}
+			defer storage.Close()
+
+			batch := storage.NewBatch()
+			defer batch.Close()
+			if err := batch.SetBlock(ctx, block); err != nil {
+				t.Fatalf("SetBlock() error = %v", err)
+			}
+			if err := batch.SetTransactions(ctx, block.Transactions); err != nil {
+				t.Fatalf("SetTransactions() error = %v", err)
+			}
+			if err := batch.Commit(); err != nil {
+				t.Fatalf("Commit() error = %v", err)
+			}
+
+			coinbase, err := storage.GetTransaction(ctx, block.ChainID, fixtureCoinbase)
+			if err != nil {
+				t.Fatalf("GetTransaction() error = %v", err)
+			}
+			if coinbase.From != "" {
+				t.Errorf("stored coinbase from %q, want no sender", coinbase.From)
+			}
+		})
+	}
+}
+
+func TestAdapter_GetBlockByNumberWithoutPrevouts(t *testing.T) {
+	fake := newFakeBitcoind(t, "testdata/regtest.json")
+	adapter := newTestAdapter(t, fake, func(c *Config) { c.ResolvePrevouts = false })
+
+	block, err := adapter.GetBlockByNumber(context.Background(), 101)
+	if err != nil {
+		t.Fatalf("GetBlockByNumber() error = %v", err)
+	}
+
+	// Without resolution the fee comes from the node and there is no
+	// sender
+	payment := block.Transactions[1]
+	if payment.Inputs[0].IsResolved() || payment.From != "" || payment.Fee != "1000" {
+		t.Errorf("payment input %+v from %q with fee %s", payment.Inputs[0], payment.From, payment.Fee)
+	}
+	if payment.To != bob || payment.Value != "4999999000" {
+		t.Errorf("payment to %s of %s, want %s of the total output", payment.To, payment.Value, bob)
+	}
+
+	if n := fake.called("getrawtransaction"); n != 0 {
+		t.Errorf("getrawtransaction called %d times, want 0", n)
+	}
+}
+
+func TestAdapter_GetBlockByNumberOutOfRange(t *testing.T) {
+	fake := newFakeBitcoind(t, "testdata/regtest.json")
+	adapter := newTestAdapter(t, fake, nil)
+
+	_, err := adapter.GetBlockByNumber(context.Background(), 102)
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) || !rpcErr.IsNotFound() {
+		t.Errorf("GetBlockByNumber() error = %v, want a not found RPC error", err)
+	}
+}
+
+func TestAdapter_GetTransaction(t *testing.T) {
+	fake := newFakeBitcoind(t, "testdata/regtest.json")
+	adapter := newTestAdapter(t, fake, nil)
+
+	tx, err := adapter.GetTransaction(context.Background(), fixtureBobCarol)
+	if err != nil {
+		t.Fatalf("GetTransaction() error = %v", err)
+	}
+
+	if tx.BlockNumber != 101 || tx.Index != 2 || tx.BlockHash != fixtureBlockHash || tx.Status != models.TxStatusSuccess {
+		t.Errorf("transaction at %d/%d in %s with status %v", tx.BlockNumber, tx.Index, tx.BlockHash, tx.Status)
+	}
+	if tx.From != bob || tx.To != carol || tx.Fee != "1000" || tx.Timestamp.Unix != 1700006000 {
+		t.Errorf("transaction from %s to %s with fee %s at %d", tx.From, tx.To, tx.Fee, tx.Timestamp.Unix)
+	}
+
+	_, err = adapter.GetTransaction(context.Background(), fixtureUnknownTx)
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) || !rpcErr.IsNotFound() {
+		t.Errorf("GetTransaction() error = %v, want a not found RPC error", err)
+	}
+}
+
+func TestAdapter_RPCCredentials(t *testing.T) {
+	fake := newFakeBitcoind(t, "testdata/regtest.json")
+	newTestAdapter(t, fake, func(c *Config) {
+		c.RPCUser = "indexer"
+		c.RPCPassword = "secret"
+	})
+
+	fake.mu.Lock()
+	defer fake.mu.Unlock()
+	if fake.auth != [2]string{"indexer", "secret"} {
+		t.Errorf("basic auth = %v, want indexer:secret", fake.auth)
+	}
+}
+
+func TestAdapter_Subscriptions(t *testing.T) {
+	fake := newFakeBitcoind(t, "testdata/regtest.json")
+	adapter := newTestAdapter(t, fake, nil)
+
+	// New blocks are polled for
+	if _, err := adapter.SubscribeNewBlocks(context.Background()); err == nil {
+		t.Error("SubscribeNewBlocks() error = nil, want not supported")
+	}
+	if _, err := adapter.SubscribeNewTransactions(context.Background()); err == nil {
+		t.Error("SubscribeNewTransactions() error = nil, want not supported")
+	}
+}
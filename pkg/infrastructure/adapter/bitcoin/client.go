@@ -0,0 +1,378 @@
+package bitcoin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/endpoint"
+)
+
+// Client wraps the bitcoind JSON-RPC API
+type Client struct {
+	config     *Config
+	httpClient *http.Client
+	pool       *endpoint.Pool
+	errorCount atomic.Int32
+	mu         sync.RWMutex
+	connected  bool
+}
+
+// NewClient creates a new bitcoind RPC client
+func NewClient(config *Config) (*Client, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	client := &Client{
+		config: config,
+		httpClient: &http.Client{
+			Timeout: config.Timeout,
+			Transport: &http.Transport{
+				MaxConnsPerHost:     config.MaxConnections,
+				MaxIdleConnsPerHost: config.MaxConnections,
+			},
+		},
+		connected: true,
+	}
+
+	poolConfig := endpoint.DefaultConfig(config.ChainID)
+	poolConfig.MaxLag = config.MaxEndpointLag
+	pool, err := endpoint.NewPool(config.RPCURLs(), poolConfig, client.probeHeight)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create endpoint pool: %w", err)
+	}
+	client.pool = pool
+
+	return client, nil
+}
+
+// call makes a JSON-RPC call to bitcoind. Requests go to the healthiest
+// endpoint and fail over to the others.
+func (c *Client) call(ctx context.Context, method string, params []interface{}, result interface{}) error {
+	reqBody, err := newRequest(method, params)
+	if err != nil {
+		return err
+	}
+
+	return c.withRetries(ctx, func(ctx context.Context, e *endpoint.Endpoint) error {
+		resp, err := c.post(ctx, e.URL, reqBody)
+		if err != nil {
+			return err
+		}
+
+		var rpcResp RPCResponse
+		if err := json.Unmarshal(resp, &rpcResp); err != nil {
+			return fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+
+		return decodeResult(&rpcResp, result)
+	})
+}
+
+// batchCall sends the requests as a single JSON-RPC batch and decodes the
+// result of the i-th request into results[i]
+func (c *Client) batchCall(ctx context.Context, method string, params [][]interface{}, results []interface{}) error {
+	reqs := make([]RPCRequest, len(params))
+	for i := range params {
+		reqs[i] = RPCRequest{JSONRPC: "1.0", ID: i, Method: method, Params: params[i]}
+	}
+
+	reqBody, err := json.Marshal(reqs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal batch: %w", err)
+	}
+
+	return c.withRetries(ctx, func(ctx context.Context, e *endpoint.Endpoint) error {
+		resp, err := c.post(ctx, e.URL, reqBody)
+		if err != nil {
+			return err
+		}
+
+		var rpcResps []RPCResponse
+		if err := json.Unmarshal(resp, &rpcResps); err != nil {
+			return fmt.Errorf("failed to unmarshal batch response: %w", err)
+		}
+		if len(rpcResps) != len(reqs) {
+			return fmt.Errorf("batch returned %d responses for %d requests", len(rpcResps), len(reqs))
+		}
+
+		// Responses may come back in any order
+		for i := range rpcResps {
+			id := rpcResps[i].ID
+			if id < 0 || id >= len(results) {
+				return fmt.Errorf("batch response has unknown id %d", id)
+			}
+			if err := decodeResult(&rpcResps[i], results[id]); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// withRetries runs fn against the endpoint pool, retrying failures that are
+// not permanent
+func (c *Client) withRetries(ctx context.Context, fn func(ctx context.Context, e *endpoint.Endpoint) error) error {
+	var lastErr error
+	for attempt := 0; attempt <= c.config.RetryAttempts; attempt++ {
+		if attempt > 0 {
+			// Wait before retry
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(c.config.RetryDelay):
+			}
+		}
+
+		err := c.pool.Do(ctx, fn)
+		if err == nil {
+			// Reset error count on success
+			c.errorCount.Store(0)
+			return nil
+		}
+		if endpoint.IsPermanent(err) {
+			return err
+		}
+
+		lastErr = err
+		c.errorCount.Add(1)
+	}
+
+	return fmt.Errorf("request failed after %d retries: %w", c.config.RetryAttempts, lastErr)
+}
+
+// newRequest encodes a bitcoind JSON-RPC request
+func newRequest(method string, params []interface{}) ([]byte, error) {
+	if params == nil {
+		params = []interface{}{}
+	}
+
+	reqBody, err := json.Marshal(RPCRequest{
+		JSONRPC: "1.0",
+		ID:      1,
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	return reqBody, nil
+}
+
+// decodeResult decodes the result of a response. Errors reported by
+// bitcoind itself are marked permanent so the pool neither fails over nor
+// counts them against the endpoint.
+func decodeResult(resp *RPCResponse, result interface{}) error {
+	if resp.Error != nil {
+		return endpoint.Permanent(resp.Error)
+	}
+
+	if result != nil {
+		if err := json.Unmarshal(resp.Result, result); err != nil {
+			return endpoint.Permanent(fmt.Errorf("failed to unmarshal result: %w", err))
+		}
+	}
+
+	return nil
+}
+
+// post sends a request body to a single RPC endpoint and returns the
+// response body. bitcoind answers RPC errors with a non-200 status and a
+// JSON body, so only bodies that are not JSON-RPC responses are treated as
+// transport failures.
+func (c *Client) post(ctx context.Context, url string, reqBody []byte) ([]byte, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(reqBody))
+	if err != nil {
+		return nil, endpoint.Permanent(fmt.Errorf("failed to create request: %w", err))
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if c.config.RPCUser != "" {
+		httpReq.SetBasicAuth(c.config.RPCUser, c.config.RPCPassword)
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK && !json.Valid(body) {
+		return nil, fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	return body, nil
+}
+
+// probeHeight returns the block height of a single endpoint for the pool's
+// head tracking
+func (c *Client) probeHeight(ctx context.Context, e *endpoint.Endpoint) (uint64, error) {
+	reqBody, err := newRequest("getblockcount", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	body, err := c.post(ctx, e.URL, reqBody)
+	if err != nil {
+		return 0, err
+	}
+
+	var rpcResp RPCResponse
+	if err := json.Unmarshal(body, &rpcResp); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	var height uint64
+	if err := decodeResult(&rpcResp, &height); err != nil {
+		return 0, err
+	}
+
+	return height, nil
+}
+
+// GetBlockCount returns the height of the most-work fully-validated chain
+func (c *Client) GetBlockCount(ctx context.Context) (uint64, error) {
+	var height uint64
+	if err := c.call(ctx, "getblockcount", nil, &height); err != nil {
+		return 0, fmt.Errorf("getblockcount: %w", err)
+	}
+
+	return height, nil
+}
+
+// GetBlockHash returns the hash of the block at a height of the best chain
+func (c *Client) GetBlockHash(ctx context.Context, height uint64) (string, error) {
+	var hash string
+	if err := c.call(ctx, "getblockhash", []interface{}{height}, &hash); err != nil {
+		if isNotFound(err) {
+			return "", fmt.Errorf("getblockhash: %w: %w", models.ErrBlockNotFound, err)
+		}
+		return "", fmt.Errorf("getblockhash: %w", err)
+	}
+
+	return hash, nil
+}
+
+// GetBlock returns a block with its decoded transactions
+func (c *Client) GetBlock(ctx context.Context, hash string) (*Block, error) {
+	return c.getBlock(ctx, hash, verbosityTxs)
+}
+
+// GetBlockTxIDs returns a block with the IDs of its transactions only
+func (c *Client) GetBlockTxIDs(ctx context.Context, hash string) (*Block, error) {
+	return c.getBlock(ctx, hash, verbosityTxIDs)
+}
+
+func (c *Client) getBlock(ctx context.Context, hash string, verbosity int) (*Block, error) {
+	var block Block
+	if err := c.call(ctx, "getblock", []interface{}{hash, verbosity}, &block); err != nil {
+		if isNotFound(err) {
+			return nil, fmt.Errorf("getblock: %w: %w", models.ErrBlockNotFound, err)
+		}
+		return nil, fmt.Errorf("getblock: %w", err)
+	}
+
+	return &block, nil
+}
+
+// GetRawTransaction returns a decoded transaction by ID. Transactions that
+// are not in the mempool can only be found if the node runs with txindex.
+func (c *Client) GetRawTransaction(ctx context.Context, txid string) (*Transaction, error) {
+	var tx Transaction
+	if err := c.call(ctx, "getrawtransaction", []interface{}{txid, true}, &tx); err != nil {
+		if isNotFound(err) {
+			return nil, fmt.Errorf("getrawtransaction: %w: %w", models.ErrTransactionNotFound, err)
+		}
+		return nil, fmt.Errorf("getrawtransaction: %w", err)
+	}
+
+	return &tx, nil
+}
+
+// GetRawTransactions returns decoded transactions by ID, fetched in
+// batches of PrevoutBatchSize
+func (c *Client) GetRawTransactions(ctx context.Context, txids []string) ([]*Transaction, error) {
+	txs := make([]*Transaction, len(txids))
+
+	for start := 0; start < len(txids); start += c.config.PrevoutBatchSize {
+		end := start + c.config.PrevoutBatchSize
+		if end > len(txids) {
+			end = len(txids)
+		}
+
+		params := make([][]interface{}, 0, end-start)
+		results := make([]interface{}, 0, end-start)
+		for i := start; i < end; i++ {
+			txs[i] = &Transaction{}
+			params = append(params, []interface{}{txids[i], true})
+			results = append(results, txs[i])
+		}
+
+		if err := c.batchCall(ctx, "getrawtransaction", params, results); err != nil {
+			return nil, fmt.Errorf("getrawtransaction: %w", err)
+		}
+	}
+
+	return txs, nil
+}
+
+// isNotFound reports whether bitcoind answered that the block or
+// transaction does not exist
+func isNotFound(err error) bool {
+	var rpcErr *RPCError
+	return errors.As(err, &rpcErr) && rpcErr.IsNotFound()
+}
+
+// GetBlockchainInfo returns the state of the node's chain
+func (c *Client) GetBlockchainInfo(ctx context.Context) (*BlockchainInfo, error) {
+	var info BlockchainInfo
+	if err := c.call(ctx, "getblockchaininfo", nil, &info); err != nil {
+		return nil, fmt.Errorf("getblockchaininfo: %w", err)
+	}
+
+	return &info, nil
+}
+
+// HealthStatus returns the current health status
+type HealthStatus struct {
+	Connected  bool
+	ErrorCount int32
+}
+
+// GetHealthStatus returns the current health status
+func (c *Client) GetHealthStatus() *HealthStatus {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return &HealthStatus{
+		Connected:  c.connected,
+		ErrorCount: c.errorCount.Load(),
+	}
+}
+
+// Close closes the client
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.connected = false
+	c.pool.Close()
+	c.httpClient.CloseIdleConnections()
+
+	return nil
+}
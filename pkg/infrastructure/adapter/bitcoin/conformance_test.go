@@ -0,0 +1,23 @@
+package bitcoin
+
+import (
+	"testing"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/service"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/adaptertest"
+)
+
+func TestAdapter_Conformance(t *testing.T) {
+	adaptertest.RunConformance(t, func(t *testing.T) service.ChainAdapter {
+		return newTestAdapter(t, newFakeBitcoind(t, "testdata/regtest.json"), nil)
+	}, adaptertest.Backend{
+		ChainType:        models.ChainTypeBitcoin,
+		ChainID:          RegtestConfig().ChainID,
+		First:            101,
+		Last:             101,
+		TxHash:           fixtureBobCarol,
+		UnknownBlockHash: fixtureUnknownBlock,
+		UnknownTxHash:    fixtureUnknownTx,
+	})
+}
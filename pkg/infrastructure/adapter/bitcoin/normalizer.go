@@ -0,0 +1,273 @@
+package bitcoin
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+)
+
+// Normalizer converts bitcoind data to domain models
+type Normalizer struct {
+	chainID string
+	network string
+}
+
+// NewNormalizer creates a new Normalizer
+func NewNormalizer(chainID, network string) *Normalizer {
+	return &Normalizer{
+		chainID: chainID,
+		network: network,
+	}
+}
+
+// NormalizeChainInfo returns normalized chain info
+func (n *Normalizer) NormalizeChainInfo(name string) *models.ChainInfo {
+	return &models.ChainInfo{
+		ChainType: models.ChainTypeBitcoin,
+		ChainID:   n.chainID,
+		Name:      name,
+		Network:   n.network,
+	}
+}
+
+// NormalizeBlock converts a block fetched with verbosity 2 to a domain
+// Block. prevouts holds the outputs spent by the block's inputs that are
+// known; inputs spending other outputs are left unresolved.
+func (n *Normalizer) NormalizeBlock(block *Block, prevouts map[Outpoint]*Vout) (*models.Block, error) {
+	if block == nil {
+		return nil, fmt.Errorf("block is nil")
+	}
+
+	txs, err := block.Transactions()
+	if err != nil {
+		return nil, err
+	}
+
+	transactions := make([]*models.Transaction, 0, len(txs))
+	for i, tx := range txs {
+		domainTx, err := n.NormalizeTransaction(tx, uint64(i), block.Height, block.Hash, block.Time, prevouts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to normalize transaction %d: %w", i, err)
+		}
+		transactions = append(transactions, domainTx)
+	}
+
+	domainBlock := &models.Block{
+		ChainID:          n.chainID,
+		ChainType:        models.ChainTypeBitcoin,
+		Number:           block.Height,
+		Hash:             block.Hash,
+		ParentHash:       block.PreviousBlockHash,
+		Timestamp:        models.NewTimestamp(block.Time),
+		Transactions:     transactions,
+		TxCount:          len(transactions),
+		TxHashes:         make([]string, 0, len(transactions)),
+		Size:             block.Size,
+		TransactionsRoot: block.MerkleRoot,
+		Metadata:         make(map[string]interface{}),
+	}
+
+	for _, tx := range transactions {
+		domainBlock.TxHashes = append(domainBlock.TxHashes, tx.Hash)
+	}
+
+	// Add Bitcoin-specific data
+	domainBlock.Metadata["version"] = block.Version
+	domainBlock.Metadata["bits"] = block.Bits
+	domainBlock.Metadata["nonce"] = block.Nonce
+	domainBlock.Metadata["difficulty"] = block.Difficulty.String()
+	domainBlock.Metadata["chainwork"] = block.ChainWork
+	domainBlock.Metadata["median_time"] = block.MedianTime
+	domainBlock.Metadata["stripped_size"] = block.StrippedSize
+	domainBlock.Metadata["weight"] = block.Weight
+
+	return domainBlock, nil
+}
+
+// NormalizeTransaction converts a decoded transaction to a domain
+// Transaction.
+//
+// From is the address of the first resolved input and To the first output
+// address other than From. Value is the total paid to addresses that did
+// not fund the transaction, so change is left out, or the total output if
+// every output returns to an input address. Fee is the difference between
+// inputs and outputs once every input is resolved, otherwise the fee
+// reported by the node, if any.
+func (n *Normalizer) NormalizeTransaction(
+	tx *Transaction,
+	index uint64,
+	blockNumber uint64,
+	blockHash string,
+	blockTime int64,
+	prevouts map[Outpoint]*Vout,
+) (*models.Transaction, error) {
+	if tx == nil {
+		return nil, fmt.Errorf("transaction is nil")
+	}
+
+	domainTx := &models.Transaction{
+		ChainType:   models.ChainTypeBitcoin,
+		ChainID:     n.chainID,
+		Hash:        tx.TxID,
+		Index:       index,
+		BlockNumber: blockNumber,
+		BlockHash:   blockHash,
+		Value:       "0",
+		Fee:         "0",
+		GasPrice:    "0",
+		Status:      models.TxStatusSuccess,
+		Timestamp:   models.NewTimestamp(blockTime),
+		Inputs:      make([]*models.TxInput, 0, len(tx.Vin)),
+		Outputs:     make([]*models.TxOutput, 0, len(tx.Vout)),
+		Metadata:    make(map[string]interface{}),
+	}
+	if blockHash == "" {
+		domainTx.Status = models.TxStatusPending
+	}
+
+	// Inputs
+	var totalIn int64
+	resolved := true
+	coinbase := false
+	inputAddresses := make(map[string]bool)
+	for i, vin := range tx.Vin {
+		input, value, err := n.normalizeInput(vin, uint32(i), prevouts)
+		if err != nil {
+			return nil, fmt.Errorf("input %d: %w", i, err)
+		}
+		domainTx.Inputs = append(domainTx.Inputs, input)
+
+		switch {
+		case input.IsCoinbase():
+			coinbase = true
+		case input.IsResolved():
+			totalIn += value
+			if input.Address != "" {
+				inputAddresses[input.Address] = true
+				if domainTx.From == "" {
+					domainTx.From = input.Address
+				}
+			}
+		default:
+			resolved = false
+		}
+	}
+
+	// Outputs
+	var totalOut, paid int64
+	for _, vout := range tx.Vout {
+		output, value, err := n.normalizeOutput(vout)
+		if err != nil {
+			return nil, fmt.Errorf("output %d: %w", vout.N, err)
+		}
+		domainTx.Outputs = append(domainTx.Outputs, output)
+
+		totalOut += value
+		if !inputAddresses[output.Address] {
+			paid += value
+		}
+		if domainTx.To == "" && output.Address != "" && output.Address != domainTx.From {
+			domainTx.To = output.Address
+		}
+	}
+	if domainTx.To == "" && len(domainTx.Outputs) > 0 {
+		// Every output returns to the sender
+		domainTx.To = domainTx.Outputs[0].Address
+	}
+	if paid == 0 {
+		paid = totalOut
+	}
+	domainTx.Value = strconv.FormatInt(paid, 10)
+
+	// Fee
+	switch {
+	case coinbase:
+		domainTx.Metadata["coinbase"] = true
+	case resolved:
+		domainTx.Fee = strconv.FormatInt(totalIn-totalOut, 10)
+	case tx.Fee != "":
+		fee, err := ParseAmount(tx.Fee)
+		if err != nil {
+			return nil, fmt.Errorf("fee: %w", err)
+		}
+		domainTx.Fee = strconv.FormatInt(fee, 10)
+	}
+
+	// Add Bitcoin-specific data
+	domainTx.Metadata["total_output"] = strconv.FormatInt(totalOut, 10)
+	domainTx.Metadata["version"] = tx.Version
+	domainTx.Metadata["locktime"] = tx.LockTime
+	domainTx.Metadata["size"] = tx.Size
+	domainTx.Metadata["vsize"] = tx.VSize
+	domainTx.Metadata["weight"] = tx.Weight
+	if tx.Hash != "" && tx.Hash != tx.TxID {
+		domainTx.Metadata["wtxid"] = tx.Hash
+	}
+
+	return domainTx, nil
+}
+
+// normalizeInput converts an input and returns the value of the output it
+// spends in satoshis. The spent output comes from the input's own prevout
+// or from prevouts.
+func (n *Normalizer) normalizeInput(vin *Vin, index uint32, prevouts map[Outpoint]*Vout) (*models.TxInput, int64, error) {
+	input := &models.TxInput{
+		Index:    index,
+		Sequence: vin.Sequence,
+	}
+
+	if vin.IsCoinbase() {
+		coinbase, err := hex.DecodeString(vin.Coinbase)
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid coinbase: %w", err)
+		}
+		input.Coinbase = coinbase
+		return input, 0, nil
+	}
+
+	input.PrevTxHash = vin.TxID
+	input.PrevOutputIndex = vin.Vout
+
+	var value json.Number
+	var script *ScriptPubKey
+	if vin.Prevout != nil {
+		value, script = vin.Prevout.Value, &vin.Prevout.ScriptPubKey
+	} else if prevout, ok := prevouts[Outpoint{TxID: vin.TxID, Vout: vin.Vout}]; ok {
+		value, script = prevout.Value, &prevout.ScriptPubKey
+	} else {
+		return input, 0, nil
+	}
+
+	satoshis, err := ParseAmount(value)
+	if err != nil {
+		return nil, 0, err
+	}
+	input.Address = script.GetAddress()
+	input.Value = strconv.FormatInt(satoshis, 10)
+
+	return input, satoshis, nil
+}
+
+// normalizeOutput converts an output and returns its value in satoshis
+func (n *Normalizer) normalizeOutput(vout *Vout) (*models.TxOutput, int64, error) {
+	satoshis, err := ParseAmount(vout.Value)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	script, err := hex.DecodeString(vout.ScriptPubKey.Hex)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid script: %w", err)
+	}
+
+	return &models.TxOutput{
+		Index:      vout.N,
+		Address:    vout.ScriptPubKey.GetAddress(),
+		Value:      strconv.FormatInt(satoshis, 10),
+		ScriptType: vout.ScriptPubKey.Type,
+		Script:     script,
+	}, satoshis, nil
+}
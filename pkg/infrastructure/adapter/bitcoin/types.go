@@ -0,0 +1,239 @@
+package bitcoin
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// bitcoind API types - these match the bitcoind JSON-RPC responses
+
+// getblock verbosity levels
+const (
+	verbosityTxIDs = 1 // Transaction IDs only
+	verbosityTxs   = 2 // Decoded transactions
+)
+
+// RPC error codes returned by bitcoind
+const (
+	// RPCErrInvalidAddressOrKey is returned for unknown blocks and
+	// transactions
+	RPCErrInvalidAddressOrKey = -5
+
+	// RPCErrInvalidParameter is returned for out of range block heights
+	RPCErrInvalidParameter = -8
+)
+
+// satoshiDecimals is the number of decimal places of BTC amounts
+const satoshiDecimals = 8
+
+// RPCRequest represents a bitcoind JSON-RPC request
+type RPCRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int           `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+// RPCResponse represents a bitcoind JSON-RPC response
+type RPCResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *RPCError       `json:"error"`
+	ID     int             `json:"id"`
+}
+
+// RPCError represents an error reported by bitcoind
+type RPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Error implements the error interface
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("bitcoind error %d: %s", e.Code, e.Message)
+}
+
+// IsNotFound reports whether the error means the requested block or
+// transaction does not exist
+func (e *RPCError) IsNotFound() bool {
+	return e.Code == RPCErrInvalidAddressOrKey || e.Code == RPCErrInvalidParameter
+}
+
+// BlockchainInfo represents the result of getblockchaininfo
+type BlockchainInfo struct {
+	Chain                string  `json:"chain"` // main, test, signet or regtest
+	Blocks               uint64  `json:"blocks"`
+	Headers              uint64  `json:"headers"`
+	BestBlockHash        string  `json:"bestblockhash"`
+	VerificationProgress float64 `json:"verificationprogress"`
+	InitialBlockDownload bool    `json:"initialblockdownload"`
+	Pruned               bool    `json:"pruned"`
+}
+
+// Block represents the result of getblock with verbosity 1 or 2
+type Block struct {
+	Hash              string      `json:"hash"`
+	Confirmations     int64       `json:"confirmations"`
+	Height            uint64      `json:"height"`
+	Version           int32       `json:"version"`
+	MerkleRoot        string      `json:"merkleroot"`
+	Time              int64       `json:"time"`
+	MedianTime        int64       `json:"mediantime"`
+	Nonce             uint32      `json:"nonce"`
+	Bits              string      `json:"bits"`
+	Difficulty        json.Number `json:"difficulty"`
+	ChainWork         string      `json:"chainwork"`
+	PreviousBlockHash string      `json:"previousblockhash"`
+	Size              uint64      `json:"size"`
+	StrippedSize      uint64      `json:"strippedsize"`
+	Weight            uint64      `json:"weight"`
+
+	// Tx holds the transaction IDs with verbosity 1 and the decoded
+	// transactions with verbosity 2
+	Tx json.RawMessage `json:"tx"`
+}
+
+// TxIDs returns the transaction IDs of a block fetched with verbosity 1
+func (b *Block) TxIDs() ([]string, error) {
+	var txids []string
+	if err := json.Unmarshal(b.Tx, &txids); err != nil {
+		return nil, fmt.Errorf("failed to decode transaction IDs: %w", err)
+	}
+	return txids, nil
+}
+
+// Transactions returns the transactions of a block fetched with verbosity 2
+func (b *Block) Transactions() ([]*Transaction, error) {
+	var txs []*Transaction
+	if err := json.Unmarshal(b.Tx, &txs); err != nil {
+		return nil, fmt.Errorf("failed to decode transactions: %w", err)
+	}
+	return txs, nil
+}
+
+// Transaction represents a decoded transaction, as returned by getblock
+// with verbosity 2 and getrawtransaction with verbose set
+type Transaction struct {
+	TxID     string  `json:"txid"`
+	Hash     string  `json:"hash"` // Witness transaction ID
+	Version  int32   `json:"version"`
+	Size     uint64  `json:"size"`
+	VSize    uint64  `json:"vsize"`
+	Weight   uint64  `json:"weight"`
+	LockTime uint32  `json:"locktime"`
+	Vin      []*Vin  `json:"vin"`
+	Vout     []*Vout `json:"vout"`
+
+	// Fee is only reported by getblock when the node has the block's undo
+	// data
+	Fee json.Number `json:"fee,omitempty"`
+
+	// Only set by getrawtransaction
+	BlockHash     string `json:"blockhash,omitempty"`
+	Confirmations int64  `json:"confirmations,omitempty"`
+	BlockTime     int64  `json:"blocktime,omitempty"`
+}
+
+// Vin represents a transaction input
+type Vin struct {
+	TxID        string     `json:"txid,omitempty"`
+	Vout        uint32     `json:"vout"`
+	Coinbase    string     `json:"coinbase,omitempty"`
+	ScriptSig   *ScriptSig `json:"scriptSig,omitempty"`
+	TxInWitness []string   `json:"txinwitness,omitempty"`
+	Sequence    uint32     `json:"sequence"`
+
+	// Prevout is the spent output, only reported by getblock with
+	// verbosity 3
+	Prevout *Prevout `json:"prevout,omitempty"`
+}
+
+// IsCoinbase reports whether the input is the coinbase input
+func (v *Vin) IsCoinbase() bool {
+	return v.Coinbase != ""
+}
+
+// ScriptSig represents an unlocking script
+type ScriptSig struct {
+	Asm string `json:"asm"`
+	Hex string `json:"hex"`
+}
+
+// Prevout represents the output spent by an input
+type Prevout struct {
+	Generated    bool         `json:"generated"`
+	Height       uint64       `json:"height"`
+	Value        json.Number  `json:"value"`
+	ScriptPubKey ScriptPubKey `json:"scriptPubKey"`
+}
+
+// Vout represents a transaction output
+type Vout struct {
+	Value        json.Number  `json:"value"`
+	N            uint32       `json:"n"`
+	ScriptPubKey ScriptPubKey `json:"scriptPubKey"`
+}
+
+// ScriptPubKey represents a locking script
+type ScriptPubKey struct {
+	Asm  string `json:"asm"`
+	Hex  string `json:"hex"`
+	Type string `json:"type"`
+
+	// Address is set by bitcoind 22 and later, Addresses by earlier
+	// versions
+	Address   string   `json:"address,omitempty"`
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+// GetAddress returns the address the script pays to, or "" if it has no
+// standard address
+func (s *ScriptPubKey) GetAddress() string {
+	if s.Address != "" {
+		return s.Address
+	}
+	if len(s.Addresses) == 1 {
+		return s.Addresses[0]
+	}
+	return ""
+}
+
+// Outpoint identifies a transaction output
+type Outpoint struct {
+	TxID string
+	Vout uint32
+}
+
+// String returns the outpoint as txid:vout
+func (o Outpoint) String() string {
+	return fmt.Sprintf("%s:%d", o.TxID, o.Vout)
+}
+
+// ParseAmount converts a BTC amount as formatted by bitcoind, with up to
+// eight decimal places, to satoshis without going through floating point
+func ParseAmount(amount json.Number) (int64, error) {
+	s := string(amount)
+	if s == "" {
+		return 0, fmt.Errorf("empty amount")
+	}
+
+	negative := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+
+	whole, fraction, _ := strings.Cut(s, ".")
+	if len(fraction) > satoshiDecimals {
+		return 0, fmt.Errorf("invalid amount %q: more than %d decimal places", amount, satoshiDecimals)
+	}
+	fraction += strings.Repeat("0", satoshiDecimals-len(fraction))
+
+	satoshis, err := strconv.ParseInt(whole+fraction, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q: %w", amount, err)
+	}
+
+	if negative {
+		satoshis = -satoshis
+	}
+	return satoshis, nil
+}
@@ -2,9 +2,13 @@ package cosmos
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
+	"strings"
 	"sync"
+	"time"
 
+	tmtypes "github.com/cometbft/cometbft/types"
 	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
 	"github.com/sage-x-project/blockchain-indexer/pkg/domain/service"
 )
@@ -12,6 +16,14 @@ import (
 // Ensure Adapter implements ChainAdapter interface
 var _ service.ChainAdapter = (*Adapter)(nil)
 
+// txEventQuery selects the events CometBFT emits for every committed
+// transaction
+const txEventQuery = "tm.event='Tx'"
+
+// txSubscriptionBufferSize is the capacity of the channels of transaction
+// subscriptions
+const txSubscriptionBufferSize = 100
+
 // Adapter implements the ChainAdapter interface for Cosmos/Tendermint chains
 type Adapter struct {
 	config     *Config
@@ -86,6 +98,17 @@ func (a *Adapter) GetChainInfo() *models.ChainInfo {
 	return a.chainInfo
 }
 
+// Capabilities reports the features the adapter supports. Subscriptions
+// need WebSocket to be enabled and blocks are fetched one by one.
+func (a *Adapter) Capabilities() models.Capabilities {
+	return models.Capabilities{
+		BlockSubscription:       a.config.EnableWebSocket,
+		TransactionSubscription: a.config.EnableWebSocket,
+		BlockByHash:             true,
+		TransactionByHash:       true,
+	}
+}
+
 // GetLatestBlockNumber returns the latest block number (height)
 func (a *Adapter) GetLatestBlockNumber(ctx context.Context) (uint64, error) {
 	height, err := a.client.GetLatestBlockHeight(ctx)
@@ -96,6 +119,17 @@ func (a *Adapter) GetLatestBlockNumber(ctx context.Context) (uint64, error) {
 	return uint64(height), nil
 }
 
+// GetFinalityHeads returns the latest height for both heads, since
+// CometBFT blocks are final once committed
+func (a *Adapter) GetFinalityHeads(ctx context.Context) (*models.FinalityHeads, error) {
+	height, err := a.GetLatestBlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.FinalityHeads{Safe: height, Finalized: height}, nil
+}
+
 // GetBlockByNumber fetches a block by number (height)
 func (a *Adapter) GetBlockByNumber(ctx context.Context, number uint64) (*models.Block, error) {
 	height := int64(number)
@@ -175,11 +209,18 @@ func (a *Adapter) GetTransaction(ctx context.Context, hash string) (*models.Tran
 		blockResults = nil
 	}
 
+	// The result only holds the height of the transaction's block
+	header, err := a.client.Header(ctx, &height)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get header of block %d: %w", height, err)
+	}
+
 	// Normalize transaction
 	tx, err := a.normalizer.NormalizeTransaction(result.Tx, result.Height, result.Index, blockResults)
 	if err != nil {
 		return nil, fmt.Errorf("failed to normalize transaction %s: %w", hash, err)
 	}
+	tx.BlockHash = strings.ToUpper(hex.EncodeToString(header.Header.Hash()))
 
 	return tx, nil
 }
@@ -196,6 +237,10 @@ func (a *Adapter) GetTransactionsByBlock(ctx context.Context, number uint64) ([]
 
 // GetBlocks fetches multiple blocks in a range
 func (a *Adapter) GetBlocks(ctx context.Context, start, end uint64) ([]*models.Block, error) {
+	if start > end {
+		return nil, fmt.Errorf("invalid range: start %d > end %d", start, end)
+	}
+
 	blocks := make([]*models.Block, 0, end-start+1)
 
 	for i := start; i <= end; i++ {
@@ -246,7 +291,7 @@ func (a *Adapter) Disconnect() error {
 // SubscribeNewBlocks subscribes to new blocks
 func (a *Adapter) SubscribeNewBlocks(ctx context.Context) (service.BlockSubscription, error) {
 	if !a.config.EnableWebSocket {
-		return nil, fmt.Errorf("websocket is not enabled")
+		return nil, fmt.Errorf("websocket is not enabled: %w", service.ErrNotSupported)
 	}
 
 	return &blockSubscription{
@@ -257,10 +302,61 @@ func (a *Adapter) SubscribeNewBlocks(ctx context.Context) (service.BlockSubscrip
 	}, nil
 }
 
-// SubscribeNewTransactions subscribes to new transactions
+// SubscribeNewTransactions subscribes to transactions as they are
+// committed, through the CometBFT Tx event
 func (a *Adapter) SubscribeNewTransactions(ctx context.Context) (service.TransactionSubscription, error) {
-	// Cosmos doesn't have direct transaction subscriptions, return nil
-	return nil, fmt.Errorf("transaction subscriptions not supported for Cosmos")
+	if !a.config.EnableWebSocket {
+		return nil, fmt.Errorf("websocket is not enabled: %w", service.ErrNotSupported)
+	}
+
+	subscriber := fmt.Sprintf("indexer-txs-%d", time.Now().UnixNano())
+	eventCh, err := a.client.Subscribe(ctx, subscriber, txEventQuery, txSubscriptionBufferSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to transactions: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	sub := &transactionSubscription{
+		adapter:    a,
+		subscriber: subscriber,
+		cancel:     cancel,
+		txCh:       make(chan *models.Transaction, txSubscriptionBufferSize),
+		errCh:      make(chan error, 10),
+	}
+
+	go func() {
+		defer close(sub.txCh)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-eventCh:
+				if !ok {
+					return
+				}
+
+				data, ok := event.Data.(tmtypes.EventDataTx)
+				if !ok {
+					continue
+				}
+
+				tx, err := a.normalizer.NormalizeTxResult(&data.TxResult)
+				if err != nil {
+					sendError(sub.errCh, fmt.Errorf("failed to normalize transaction: %w", err))
+					continue
+				}
+
+				select {
+				case sub.txCh <- tx:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+
+	return sub, nil
 }
 
 // HealthCheck checks if the adapter is healthy
@@ -305,7 +401,7 @@ func (a *Adapter) Close() error {
 // Subscribe subscribes to new blocks (if WebSocket is enabled)
 func (a *Adapter) Subscribe(ctx context.Context, subscriber string) (<-chan *models.Block, error) {
 	if !a.config.EnableWebSocket {
-		return nil, fmt.Errorf("websocket is not enabled")
+		return nil, fmt.Errorf("websocket is not enabled: %w", service.ErrNotSupported)
 	}
 
 	// Subscribe to new blocks
@@ -388,6 +484,43 @@ func (s *blockSubscription) Err() <-chan error {
 	return s.errCh
 }
 
+// transactionSubscription implements service.TransactionSubscription on
+// top of a CometBFT event subscription
+type transactionSubscription struct {
+	adapter    *Adapter
+	subscriber string
+	cancel     context.CancelFunc
+	once       sync.Once
+	txCh       chan *models.Transaction
+	errCh      chan error
+}
+
+// Channel returns the channel that receives new transactions
+func (s *transactionSubscription) Channel() <-chan *models.Transaction {
+	return s.txCh
+}
+
+// Unsubscribe cancels the subscription
+func (s *transactionSubscription) Unsubscribe() {
+	s.once.Do(func() {
+		s.cancel()
+		_ = s.adapter.client.UnsubscribeAll(context.Background(), s.subscriber)
+	})
+}
+
+// Err returns any subscription error
+func (s *transactionSubscription) Err() <-chan error {
+	return s.errCh
+}
+
+// sendError reports an error without blocking when nobody is reading
+func sendError(errCh chan error, err error) {
+	select {
+	case errCh <- err:
+	default:
+	}
+}
+
 // decodeHash decodes a hex hash string to bytes
 func decodeHash(hash string) ([]byte, error) {
 	// Remove "0x" prefix if present
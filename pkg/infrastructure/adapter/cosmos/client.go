@@ -2,18 +2,27 @@ package cosmos
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	nethttp "net/http"
+	"strings"
 	"time"
 
 	"github.com/cometbft/cometbft/rpc/client/http"
 	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
 	tmtypes "github.com/cometbft/cometbft/types"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/endpoint"
 )
 
-// Client wraps the Tendermint/CometBFT RPC client
+// Client wraps the Tendermint/CometBFT RPC clients of the configured
+// endpoints. Queries go to the healthiest endpoint; event subscriptions use
+// the WebSocket connection of the primary endpoint.
 type Client struct {
-	config *Config
-	client *http.HTTP
+	config  *Config
+	clients []*http.HTTP
+	pool    *endpoint.Pool
 }
 
 // NewClient creates a new Cosmos RPC client
@@ -22,66 +31,187 @@ func NewClient(config *Config) (*Client, error) {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
-	// Create HTTP client
-	httpClient, err := http.New(config.RPCURL, "/websocket")
+	// Create an HTTP client per endpoint
+	urls := config.RPCURLs()
+	clients := make([]*http.HTTP, 0, len(urls))
+	for _, url := range urls {
+		httpClient, err := newHTTPClient(url, config.Transport)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create RPC client: %w", err)
+		}
+		clients = append(clients, httpClient)
+	}
+
+	client := &Client{
+		config:  config,
+		clients: clients,
+	}
+
+	poolConfig := endpoint.DefaultConfig(config.ChainID)
+	poolConfig.MaxLag = config.MaxEndpointLag
+	pool, err := endpoint.NewPool(urls, poolConfig, client.probeHeight)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create RPC client: %w", err)
+		return nil, fmt.Errorf("failed to create endpoint pool: %w", err)
+	}
+	client.pool = pool
+
+	return client, nil
+}
+
+// newHTTPClient creates the RPC client of an endpoint, sending requests
+// through transport if it is set
+func newHTTPClient(url string, transport nethttp.RoundTripper) (*http.HTTP, error) {
+	if transport == nil {
+		return http.New(url, "/websocket")
 	}
 
-	return &Client{
-		config: config,
-		client: httpClient,
-	}, nil
+	return http.NewWithClient(url, "/websocket", &nethttp.Client{Transport: transport})
 }
 
-// Start starts the client
+// Start starts the client. The WebSocket connection event subscriptions
+// use is only opened when WebSocket is enabled, queries go over HTTP.
 func (c *Client) Start() error {
-	return c.client.Start()
+	if !c.config.IsWebSocketEnabled() {
+		return nil
+	}
+	return c.clients[0].Start()
 }
 
 // Stop stops the client
 func (c *Client) Stop() error {
-	return c.client.Stop()
+	c.pool.Close()
+	if !c.config.IsWebSocketEnabled() {
+		return nil
+	}
+	return c.clients[0].Stop()
+}
+
+// do calls fn with the client of the healthiest endpoint, failing over to
+// the others. Errors returned by the node itself end the request.
+func (c *Client) do(ctx context.Context, fn func(ctx context.Context, client *http.HTTP) error) error {
+	return c.pool.Do(ctx, func(ctx context.Context, e *endpoint.Endpoint) error {
+		ctx, cancel := c.withTimeout(ctx)
+		defer cancel()
+
+		err := fn(ctx, c.clients[e.Index])
+
+		var rpcErr *rpctypes.RPCError
+		if errors.As(err, &rpcErr) {
+			return endpoint.Permanent(err)
+		}
+		return err
+	})
+}
+
+// probeHeight returns the latest block height of a single endpoint for the
+// pool's head tracking
+func (c *Client) probeHeight(ctx context.Context, e *endpoint.Endpoint) (uint64, error) {
+	status, err := c.clients[e.Index].Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint64(status.SyncInfo.LatestBlockHeight), nil
 }
 
 // Status returns the current node status
 func (c *Client) Status(ctx context.Context) (*coretypes.ResultStatus, error) {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
-
-	return c.client.Status(ctx)
+	var result *coretypes.ResultStatus
+	err := c.do(ctx, func(ctx context.Context, client *http.HTTP) error {
+		var err error
+		result, err = client.Status(ctx)
+		return err
+	})
+	return result, err
 }
 
-// Block returns a block at a given height
+// Block returns a block at a given height, or an error wrapping
+// models.ErrBlockNotFound above the latest block
 func (c *Client) Block(ctx context.Context, height *int64) (*coretypes.ResultBlock, error) {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
+	var result *coretypes.ResultBlock
+	err := c.do(ctx, func(ctx context.Context, client *http.HTTP) error {
+		var err error
+		result, err = client.Block(ctx, height)
+		return err
+	})
+	if isNotFound(err) {
+		return nil, fmt.Errorf("%w: %w", models.ErrBlockNotFound, err)
+	}
+	return result, err
+}
 
-	return c.client.Block(ctx, height)
+// Header returns the header of the block at a given height
+func (c *Client) Header(ctx context.Context, height *int64) (*coretypes.ResultHeader, error) {
+	var result *coretypes.ResultHeader
+	err := c.do(ctx, func(ctx context.Context, client *http.HTTP) error {
+		var err error
+		result, err = client.Header(ctx, height)
+		return err
+	})
+	if isNotFound(err) {
+		return nil, fmt.Errorf("%w: %w", models.ErrBlockNotFound, err)
+	}
+	return result, err
 }
 
-// BlockByHash returns a block by hash
+// BlockByHash returns a block by hash, or an error wrapping
+// models.ErrBlockNotFound for an unknown hash
 func (c *Client) BlockByHash(ctx context.Context, hash []byte) (*coretypes.ResultBlock, error) {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
+	var result *coretypes.ResultBlock
+	err := c.do(ctx, func(ctx context.Context, client *http.HTTP) error {
+		var err error
+		result, err = client.BlockByHash(ctx, hash)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	return c.client.BlockByHash(ctx, hash)
+	// CometBFT answers an unknown hash with an empty result
+	if result.Block == nil {
+		return nil, fmt.Errorf("block %X: %w", hash, models.ErrBlockNotFound)
+	}
+
+	return result, nil
 }
 
 // BlockResults returns block results at a given height
 func (c *Client) BlockResults(ctx context.Context, height *int64) (*coretypes.ResultBlockResults, error) {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
-
-	return c.client.BlockResults(ctx, height)
+	var result *coretypes.ResultBlockResults
+	err := c.do(ctx, func(ctx context.Context, client *http.HTTP) error {
+		var err error
+		result, err = client.BlockResults(ctx, height)
+		return err
+	})
+	return result, err
 }
 
-// Tx returns a transaction by hash
+// Tx returns a transaction by hash, or an error wrapping
+// models.ErrTransactionNotFound for an unknown hash
 func (c *Client) Tx(ctx context.Context, hash []byte, prove bool) (*coretypes.ResultTx, error) {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
+	var result *coretypes.ResultTx
+	err := c.do(ctx, func(ctx context.Context, client *http.HTTP) error {
+		var err error
+		result, err = client.Tx(ctx, hash, prove)
+		return err
+	})
+	if isNotFound(err) {
+		return nil, fmt.Errorf("%w: %w", models.ErrTransactionNotFound, err)
+	}
+	return result, err
+}
+
+// isNotFound reports whether CometBFT answered that the block or
+// transaction does not exist. Both are reported as internal errors, so only
+// the error data tells them apart.
+func isNotFound(err error) bool {
+	var rpcErr *rpctypes.RPCError
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
 
-	return c.client.Tx(ctx, hash, prove)
+	return strings.Contains(rpcErr.Data, "must be less than or equal to the current blockchain height") ||
+		strings.HasSuffix(rpcErr.Data, "not found")
 }
 
 // TxSearch searches for transactions
@@ -92,10 +222,13 @@ func (c *Client) TxSearch(
 	page, perPage *int,
 	orderBy string,
 ) (*coretypes.ResultTxSearch, error) {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
-
-	return c.client.TxSearch(ctx, query, prove, page, perPage, orderBy)
+	var result *coretypes.ResultTxSearch
+	err := c.do(ctx, func(ctx context.Context, client *http.HTTP) error {
+		var err error
+		result, err = client.TxSearch(ctx, query, prove, page, perPage, orderBy)
+		return err
+	})
+	return result, err
 }
 
 // BlockSearch searches for blocks
@@ -105,10 +238,13 @@ func (c *Client) BlockSearch(
 	page, perPage *int,
 	orderBy string,
 ) (*coretypes.ResultBlockSearch, error) {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
-
-	return c.client.BlockSearch(ctx, query, page, perPage, orderBy)
+	var result *coretypes.ResultBlockSearch
+	err := c.do(ctx, func(ctx context.Context, client *http.HTTP) error {
+		var err error
+		result, err = client.BlockSearch(ctx, query, page, perPage, orderBy)
+		return err
+	})
+	return result, err
 }
 
 // Validators returns validators at a given height
@@ -117,26 +253,35 @@ func (c *Client) Validators(
 	height *int64,
 	page, perPage *int,
 ) (*coretypes.ResultValidators, error) {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
-
-	return c.client.Validators(ctx, height, page, perPage)
+	var result *coretypes.ResultValidators
+	err := c.do(ctx, func(ctx context.Context, client *http.HTTP) error {
+		var err error
+		result, err = client.Validators(ctx, height, page, perPage)
+		return err
+	})
+	return result, err
 }
 
 // Genesis returns the genesis file
 func (c *Client) Genesis(ctx context.Context) (*coretypes.ResultGenesis, error) {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
-
-	return c.client.Genesis(ctx)
+	var result *coretypes.ResultGenesis
+	err := c.do(ctx, func(ctx context.Context, client *http.HTTP) error {
+		var err error
+		result, err = client.Genesis(ctx)
+		return err
+	})
+	return result, err
 }
 
 // ABCIInfo returns ABCI info
 func (c *Client) ABCIInfo(ctx context.Context) (*coretypes.ResultABCIInfo, error) {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
-
-	return c.client.ABCIInfo(ctx)
+	var result *coretypes.ResultABCIInfo
+	err := c.do(ctx, func(ctx context.Context, client *http.HTTP) error {
+		var err error
+		result, err = client.ABCIInfo(ctx)
+		return err
+	})
+	return result, err
 }
 
 // Subscribe subscribes to events
@@ -146,25 +291,27 @@ func (c *Client) Subscribe(
 	query string,
 	outCapacity ...int,
 ) (out <-chan coretypes.ResultEvent, err error) {
-	return c.client.Subscribe(ctx, subscriber, query, outCapacity...)
+	return c.clients[0].Subscribe(ctx, subscriber, query, outCapacity...)
 }
 
 // Unsubscribe unsubscribes from events
 func (c *Client) Unsubscribe(ctx context.Context, subscriber string, query string) error {
-	return c.client.Unsubscribe(ctx, subscriber, query)
+	return c.clients[0].Unsubscribe(ctx, subscriber, query)
 }
 
 // UnsubscribeAll unsubscribes from all events
 func (c *Client) UnsubscribeAll(ctx context.Context, subscriber string) error {
-	return c.client.UnsubscribeAll(ctx, subscriber)
+	return c.clients[0].UnsubscribeAll(ctx, subscriber)
 }
 
 // Health checks the health of the node
 func (c *Client) Health(ctx context.Context) (*coretypes.ResultHealth, error) {
-	ctx, cancel := c.withTimeout(ctx)
-	defer cancel()
-
-	result, err := c.client.Health(ctx)
+	var result *coretypes.ResultHealth
+	err := c.do(ctx, func(ctx context.Context, client *http.HTTP) error {
+		var err error
+		result, err = client.Health(ctx)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -2,6 +2,7 @@ package cosmos
 
 import (
 	"fmt"
+	"net/http"
 	"net/url"
 	"time"
 )
@@ -20,6 +21,13 @@ type Config struct {
 	// RPCURL is the Tendermint RPC endpoint URL
 	RPCURL string `yaml:"rpc_url" json:"rpc_url"`
 
+	// FallbackRPCURLs are RPC endpoints used when RPCURL is unhealthy
+	FallbackRPCURLs []string `yaml:"fallback_rpc_urls,omitempty" json:"fallback_rpc_urls,omitempty"`
+
+	// MaxEndpointLag is how many blocks an RPC endpoint may fall behind the
+	// others before requests stop being routed to it
+	MaxEndpointLag uint64 `yaml:"max_endpoint_lag" json:"max_endpoint_lag"`
+
 	// RESTURL is the Cosmos REST API endpoint URL (optional)
 	RESTURL string `yaml:"rest_url" json:"rest_url"`
 
@@ -29,6 +37,11 @@ type Config struct {
 	// Timeout is the request timeout duration
 	Timeout time.Duration `yaml:"timeout" json:"timeout"`
 
+	// Transport, if set, carries the HTTP RPC requests, e.g. to record or
+	// replay them with the transport package. WebSocket subscriptions do not
+	// use it.
+	Transport http.RoundTripper `yaml:"-" json:"-"`
+
 	// RetryAttempts is the number of retry attempts for failed requests
 	RetryAttempts int `yaml:"retry_attempts" json:"retry_attempts"`
 
@@ -78,6 +91,7 @@ func DefaultConfig() *Config {
 		RetryAttempts:           3,
 		RetryDelay:              1 * time.Second,
 		MaxConnections:          10,
+		MaxEndpointLag:          10,
 		BatchSize:               100,
 		StartBlock:              0,
 		EnableWebSocket:         false,
@@ -172,6 +186,11 @@ func (c *Config) GetRPCURL() string {
 	return c.RPCURL
 }
 
+// RPCURLs returns the RPC URL followed by the fallback URLs
+func (c *Config) RPCURLs() []string {
+	return append([]string{c.RPCURL}, c.FallbackRPCURLs...)
+}
+
 // GetRESTURL returns the REST API URL
 func (c *Config) GetRESTURL() string {
 	return c.RESTURL
@@ -0,0 +1,174 @@
+package cosmos
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	tmtypes "github.com/cometbft/cometbft/types"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/service"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/adaptertest"
+)
+
+func TestAdapter_Conformance(t *testing.T) {
+	node := newFakeNode(t, 5)
+
+	adaptertest.RunConformance(t, func(t *testing.T) service.ChainAdapter {
+		config := DefaultConfig()
+		config.ChainID = "cosmos-test"
+		config.RPCURL = node.URL
+		config.Timeout = 5 * time.Second
+
+		adapter, err := NewAdapter(config)
+		if err != nil {
+			t.Fatalf("NewAdapter() error = %v", err)
+		}
+		t.Cleanup(func() { adapter.Disconnect() })
+
+		return adapter
+	}, adaptertest.Backend{
+		ChainType:        models.ChainTypeCosmos,
+		ChainID:          "cosmos-test",
+		First:            1,
+		Last:             5,
+		TxHash:           strings.ToUpper(hex.EncodeToString(node.blocks[3].Txs[1].Hash())),
+		UnknownBlockHash: strings.Repeat("AB", 32),
+		UnknownTxHash:    strings.Repeat("CD", 32),
+	})
+}
+
+// fakeNode serves the CometBFT RPC methods the adapter queries over a
+// chain of blocks 1 to head, each holding two transactions
+type fakeNode struct {
+	*httptest.Server
+	blocks map[int64]*tmtypes.Block
+	head   int64
+}
+
+func newFakeNode(t *testing.T, head int64) *fakeNode {
+	t.Helper()
+
+	f := &fakeNode{
+		blocks: make(map[int64]*tmtypes.Block),
+		head:   head,
+	}
+	var parent tmtypes.BlockID
+	for height := int64(1); height <= head; height++ {
+		txs := []tmtypes.Tx{
+			tmtypes.Tx(fmt.Sprintf("tx-%d-0", height)),
+			tmtypes.Tx(fmt.Sprintf("tx-%d-1", height)),
+		}
+		block := tmtypes.MakeBlock(height, txs, &tmtypes.Commit{}, nil)
+		block.ChainID = "cosmos-test"
+		block.Time = time.Unix(1700000000+height, 0).UTC()
+		block.ValidatorsHash = bytes.Repeat([]byte{0x01}, 32)
+		block.LastBlockID = parent
+
+		f.blocks[height] = block
+		parent = tmtypes.BlockID{Hash: block.Hash()}
+	}
+
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(f.Close)
+
+	return f
+}
+
+func (f *fakeNode) serve(w http.ResponseWriter, r *http.Request) {
+	var req rpctypes.RPCRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var params struct {
+		Height *int64 `json:"height,string"`
+		Hash   []byte `json:"hash"`
+	}
+	if len(req.Params) > 0 {
+		if err := cmtjson.Unmarshal(req.Params, &params); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	var result interface{}
+	var err error
+	switch req.Method {
+	case "status":
+		result = &coretypes.ResultStatus{
+			SyncInfo: coretypes.SyncInfo{LatestBlockHeight: f.head},
+		}
+	case "health":
+		result = &coretypes.ResultHealth{}
+	case "block":
+		var block *tmtypes.Block
+		if block, err = f.block(params.Height); err == nil {
+			result = &coretypes.ResultBlock{BlockID: tmtypes.BlockID{Hash: block.Hash()}, Block: block}
+		}
+	case "header":
+		var block *tmtypes.Block
+		if block, err = f.block(params.Height); err == nil {
+			result = &coretypes.ResultHeader{Header: &block.Header}
+		}
+	case "block_by_hash":
+		// Unknown hashes are answered with an empty result
+		result = &coretypes.ResultBlock{}
+		for _, block := range f.blocks {
+			if bytes.Equal(block.Hash(), params.Hash) {
+				result = &coretypes.ResultBlock{BlockID: tmtypes.BlockID{Hash: block.Hash()}, Block: block}
+			}
+		}
+	case "block_results":
+		var block *tmtypes.Block
+		if block, err = f.block(params.Height); err == nil {
+			results := &coretypes.ResultBlockResults{Height: block.Height}
+			for range block.Txs {
+				results.TxsResults = append(results.TxsResults, &abci.ExecTxResult{GasUsed: 50000, GasWanted: 60000})
+			}
+			result = results
+		}
+	case "tx":
+		err = fmt.Errorf("tx (%X) not found", params.Hash)
+		for _, block := range f.blocks {
+			for i, tx := range block.Txs {
+				if bytes.Equal(tx.Hash(), params.Hash) {
+					result = &coretypes.ResultTx{Hash: tx.Hash(), Height: block.Height, Index: uint32(i), Tx: tx}
+					err = nil
+				}
+			}
+		}
+	default:
+		err = fmt.Errorf("unknown method %s", req.Method)
+	}
+
+	resp := rpctypes.NewRPCSuccessResponse(req.ID, result)
+	if err != nil {
+		resp = rpctypes.RPCInternalError(req.ID, err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(resp)
+}
+
+// block returns the block at height, or the latest block without a height,
+// failing the way CometBFT does above the latest block
+func (f *fakeNode) block(height *int64) (*tmtypes.Block, error) {
+	if height == nil {
+		return f.blocks[f.head], nil
+	}
+	if *height > f.head {
+		return nil, fmt.Errorf("height %d must be less than or equal to the current blockchain height %d", *height, f.head)
+	}
+	return f.blocks[*height], nil
+}
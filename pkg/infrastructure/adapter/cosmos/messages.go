@@ -0,0 +1,243 @@
+package cosmos
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Type URLs of the SDK messages decoded into transaction fields
+const (
+	TypeURLMsgSend       = "/cosmos.bank.v1beta1.MsgSend"
+	TypeURLMsgDelegate   = "/cosmos.staking.v1beta1.MsgDelegate"
+	TypeURLMsgUndelegate = "/cosmos.staking.v1beta1.MsgUndelegate"
+	TypeURLMsgTransfer   = "/ibc.applications.transfer.v1.MsgTransfer"
+)
+
+// Coin is an amount of a denomination
+type Coin struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
+
+// Message is a message of a transaction. From, To and Amount are only set
+// for the message types decoded by DecodeTx.
+type Message struct {
+	TypeURL string `json:"type_url"`
+	From    string `json:"from,omitempty"`
+	To      string `json:"to,omitempty"`
+	Amount  []Coin `json:"amount,omitempty"`
+
+	// Channel is the source channel of IBC transfers
+	Channel string `json:"channel,omitempty"`
+}
+
+// DecodedTx is the body and fee of an SDK transaction
+type DecodedTx struct {
+	Messages []*Message `json:"messages"`
+	Memo     string     `json:"memo,omitempty"`
+	Fee      []Coin     `json:"fee,omitempty"`
+	GasLimit uint64     `json:"gas_limit"`
+}
+
+// DecodeTx decodes a protobuf-encoded SDK transaction (TxRaw). Messages of
+// types other than bank MsgSend, staking MsgDelegate and MsgUndelegate and
+// IBC MsgTransfer only have their type URL set.
+func DecodeTx(raw []byte) (*DecodedTx, error) {
+	decoded := &DecodedTx{Messages: []*Message{}}
+
+	var body, authInfo []byte
+	err := walkFields(raw, func(num protowire.Number, value []byte, _ uint64) error {
+		switch num {
+		case 1:
+			body = value
+		case 2:
+			authInfo = value
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode tx: %w", err)
+	}
+
+	// TxBody: messages = 1, memo = 2
+	err = walkFields(body, func(num protowire.Number, value []byte, _ uint64) error {
+		switch num {
+		case 1:
+			msg, err := decodeAny(value)
+			if err != nil {
+				return err
+			}
+			decoded.Messages = append(decoded.Messages, msg)
+		case 2:
+			decoded.Memo = string(value)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode tx body: %w", err)
+	}
+
+	// AuthInfo: fee = 2
+	err = walkFields(authInfo, func(num protowire.Number, value []byte, _ uint64) error {
+		if num != 2 {
+			return nil
+		}
+		// Fee: amount = 1, gas_limit = 2
+		return walkFields(value, func(num protowire.Number, value []byte, varint uint64) error {
+			switch num {
+			case 1:
+				coin, err := decodeCoin(value)
+				if err != nil {
+					return err
+				}
+				decoded.Fee = append(decoded.Fee, coin)
+			case 2:
+				decoded.GasLimit = varint
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode auth info: %w", err)
+	}
+
+	return decoded, nil
+}
+
+// decodeAny decodes a message packed in a google.protobuf.Any
+func decodeAny(b []byte) (*Message, error) {
+	msg := &Message{}
+
+	var value []byte
+	err := walkFields(b, func(num protowire.Number, field []byte, _ uint64) error {
+		switch num {
+		case 1:
+			msg.TypeURL = string(field)
+		case 2:
+			value = field
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	switch msg.TypeURL {
+	case TypeURLMsgSend:
+		// from_address = 1, to_address = 2, amount = 3 (repeated)
+		err = walkFields(value, func(num protowire.Number, field []byte, _ uint64) error {
+			switch num {
+			case 1:
+				msg.From = string(field)
+			case 2:
+				msg.To = string(field)
+			case 3:
+				coin, err := decodeCoin(field)
+				if err != nil {
+					return err
+				}
+				msg.Amount = append(msg.Amount, coin)
+			}
+			return nil
+		})
+	case TypeURLMsgDelegate, TypeURLMsgUndelegate:
+		// delegator_address = 1, validator_address = 2, amount = 3
+		err = walkFields(value, func(num protowire.Number, field []byte, _ uint64) error {
+			switch num {
+			case 1:
+				msg.From = string(field)
+			case 2:
+				msg.To = string(field)
+			case 3:
+				coin, err := decodeCoin(field)
+				if err != nil {
+					return err
+				}
+				msg.Amount = []Coin{coin}
+			}
+			return nil
+		})
+	case TypeURLMsgTransfer:
+		// source_channel = 2, token = 3, sender = 4, receiver = 5
+		err = walkFields(value, func(num protowire.Number, field []byte, _ uint64) error {
+			switch num {
+			case 2:
+				msg.Channel = string(field)
+			case 3:
+				coin, err := decodeCoin(field)
+				if err != nil {
+					return err
+				}
+				msg.Amount = []Coin{coin}
+			case 4:
+				msg.From = string(field)
+			case 5:
+				msg.To = string(field)
+			}
+			return nil
+		})
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", msg.TypeURL, err)
+	}
+
+	return msg, nil
+}
+
+// decodeCoin decodes a cosmos.base.v1beta1.Coin
+func decodeCoin(b []byte) (Coin, error) {
+	var coin Coin
+	err := walkFields(b, func(num protowire.Number, field []byte, _ uint64) error {
+		switch num {
+		case 1:
+			coin.Denom = string(field)
+		case 2:
+			coin.Amount = string(field)
+		}
+		return nil
+	})
+	return coin, err
+}
+
+// walkFields calls fn for every field of a protobuf message with the
+// contents of length-delimited fields or the value of varint fields. Fields
+// of other wire types are skipped.
+func walkFields(b []byte, fn func(num protowire.Number, value []byte, varint uint64) error) error {
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		b = b[n:]
+
+		switch typ {
+		case protowire.BytesType:
+			value, n := protowire.ConsumeBytes(b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			if err := fn(num, value, 0); err != nil {
+				return err
+			}
+			b = b[n:]
+		case protowire.VarintType:
+			value, n := protowire.ConsumeVarint(b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			if err := fn(num, nil, value); err != nil {
+				return err
+			}
+			b = b[n:]
+		default:
+			n := protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return protowire.ParseError(n)
+			}
+			b = b[n:]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+package cosmos
+
+import (
+	"testing"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// message appends length-delimited fields to a protobuf message
+func message(fields ...[]byte) []byte {
+	var b []byte
+	for i := 0; i+1 < len(fields); i += 2 {
+		b = protowire.AppendTag(b, protowire.Number(fields[i][0]), protowire.BytesType)
+		b = protowire.AppendBytes(b, fields[i+1])
+	}
+	return b
+}
+
+func field(num byte) []byte {
+	return []byte{num}
+}
+
+func coin(denom, amount string) []byte {
+	return message(field(1), []byte(denom), field(2), []byte(amount))
+}
+
+func anyMsg(typeURL string, value []byte) []byte {
+	return message(field(1), []byte(typeURL), field(2), value)
+}
+
+func rawTx(memo string, fee []byte, gasLimit uint64, msgs ...[]byte) []byte {
+	var body []byte
+	for _, msg := range msgs {
+		body = append(body, message(field(1), msg)...)
+	}
+	body = append(body, message(field(2), []byte(memo))...)
+
+	feeMsg := message(field(1), fee)
+	feeMsg = protowire.AppendTag(feeMsg, 2, protowire.VarintType)
+	feeMsg = protowire.AppendVarint(feeMsg, gasLimit)
+	authInfo := message(field(2), feeMsg)
+
+	return message(field(1), body, field(2), authInfo, field(3), []byte("signature"))
+}
+
+func TestDecodeTx(t *testing.T) {
+	send := anyMsg(TypeURLMsgSend, message(
+		field(1), []byte("cosmos1sender"),
+		field(2), []byte("cosmos1receiver"),
+		field(3), coin("uatom", "1000000"),
+	))
+	delegate := anyMsg(TypeURLMsgDelegate, message(
+		field(1), []byte("cosmos1sender"),
+		field(2), []byte("cosmosvaloper1validator"),
+		field(3), coin("uatom", "500"),
+	))
+	transfer := anyMsg(TypeURLMsgTransfer, message(
+		field(1), []byte("transfer"),
+		field(2), []byte("channel-141"),
+		field(3), coin("uatom", "42"),
+		field(4), []byte("cosmos1sender"),
+		field(5), []byte("osmo1receiver"),
+	))
+	vote := anyMsg("/cosmos.gov.v1beta1.MsgVote", message(field(1), []byte("ignored")))
+
+	decoded, err := DecodeTx(rawTx("hello", coin("uatom", "5000"), 200000, send, delegate, transfer, vote))
+	if err != nil {
+		t.Fatalf("DecodeTx() error = %v", err)
+	}
+
+	if decoded.Memo != "hello" || decoded.GasLimit != 200000 {
+		t.Errorf("memo %q, gas limit %d", decoded.Memo, decoded.GasLimit)
+	}
+	if len(decoded.Fee) != 1 || decoded.Fee[0] != (Coin{Denom: "uatom", Amount: "5000"}) {
+		t.Errorf("fee = %v", decoded.Fee)
+	}
+	if len(decoded.Messages) != 4 {
+		t.Fatalf("got %d messages, want 4", len(decoded.Messages))
+	}
+
+	tests := []struct {
+		msg  *Message
+		want Message
+	}{
+		{decoded.Messages[0], Message{TypeURL: TypeURLMsgSend, From: "cosmos1sender", To: "cosmos1receiver", Amount: []Coin{{"uatom", "1000000"}}}},
+		{decoded.Messages[1], Message{TypeURL: TypeURLMsgDelegate, From: "cosmos1sender", To: "cosmosvaloper1validator", Amount: []Coin{{"uatom", "500"}}}},
+		{decoded.Messages[2], Message{TypeURL: TypeURLMsgTransfer, From: "cosmos1sender", To: "osmo1receiver", Amount: []Coin{{"uatom", "42"}}, Channel: "channel-141"}},
+		{decoded.Messages[3], Message{TypeURL: "/cosmos.gov.v1beta1.MsgVote"}},
+	}
+	for _, tt := range tests {
+		if tt.msg.TypeURL != tt.want.TypeURL || tt.msg.From != tt.want.From || tt.msg.To != tt.want.To ||
+			tt.msg.Channel != tt.want.Channel || len(tt.msg.Amount) != len(tt.want.Amount) {
+			t.Errorf("message = %+v, want %+v", tt.msg, tt.want)
+			continue
+		}
+		for i := range tt.want.Amount {
+			if tt.msg.Amount[i] != tt.want.Amount[i] {
+				t.Errorf("%s amount = %v, want %v", tt.want.TypeURL, tt.msg.Amount, tt.want.Amount)
+			}
+		}
+	}
+}
+
+func TestDecodeTx_Invalid(t *testing.T) {
+	if _, err := DecodeTx([]byte{0x0a, 0x05, 0x01}); err == nil {
+		t.Error("DecodeTx() of a truncated tx should fail")
+	}
+}
+
+func TestNormalizer_NormalizeTransactionDecodesMessages(t *testing.T) {
+	normalizer := NewNormalizer("cosmoshub-4", "mainnet")
+
+	send := anyMsg(TypeURLMsgSend, message(
+		field(1), []byte("cosmos1sender"),
+		field(2), []byte("cosmos1receiver"),
+		field(3), coin("uatom", "1000000"),
+	))
+	tx, err := normalizer.NormalizeTransaction(rawTx("", coin("uatom", "5000"), 200000, send), 10, 0, nil)
+	if err != nil {
+		t.Fatalf("NormalizeTransaction() error = %v", err)
+	}
+
+	if tx.ChainType != models.ChainTypeCosmos || tx.From != "cosmos1sender" || tx.To != "cosmos1receiver" {
+		t.Errorf("transaction from %s to %s on %s", tx.From, tx.To, tx.ChainType)
+	}
+	if tx.Value != "1000000" || tx.Metadata["denom"] != "uatom" {
+		t.Errorf("value = %s %v, want 1000000 uatom", tx.Value, tx.Metadata["denom"])
+	}
+	if tx.Fee != "5000" || tx.Metadata["fee_denom"] != "uatom" {
+		t.Errorf("fee = %s %v, want 5000 uatom", tx.Fee, tx.Metadata["fee_denom"])
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 
+	abci "github.com/cometbft/cometbft/abci/types"
 	coretypes "github.com/cometbft/cometbft/rpc/core/types"
 	tmtypes "github.com/cometbft/cometbft/types"
 	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
@@ -70,21 +71,27 @@ func (n *Normalizer) NormalizeBlock(block *tmtypes.Block, blockResults *coretype
 
 	// Normalize transactions
 	transactions := make([]*models.Transaction, 0, len(block.Txs))
+	txHashes := make([]string, 0, len(block.Txs))
 	for i, tx := range block.Txs {
 		normalizedTx, err := n.NormalizeTransaction(tx, block.Height, uint32(i), blockResults)
 		if err != nil {
 			// Log error but continue processing
 			continue
 		}
+		normalizedTx.BlockHash = blockHash
 		transactions = append(transactions, normalizedTx)
+		txHashes = append(txHashes, normalizedTx.Hash)
 	}
 
 	return &models.Block{
+		ChainType:    models.ChainTypeCosmos,
 		ChainID:      n.chainID,
 		Number:       uint64(block.Height),
 		Hash:         blockHash,
 		ParentHash:   parentHash,
 		Timestamp:    models.NewTimestamp(block.Time.Unix()),
+		TxCount:      len(transactions),
+		TxHashes:     txHashes,
 		Transactions: transactions,
 		Metadata:     metadata,
 	}, nil
@@ -96,6 +103,31 @@ func (n *Normalizer) NormalizeTransaction(
 	blockHeight int64,
 	txIndex uint32,
 	blockResults *coretypes.ResultBlockResults,
+) (*models.Transaction, error) {
+	var txResult *abci.ExecTxResult
+	if blockResults != nil && blockResults.TxsResults != nil && int(txIndex) < len(blockResults.TxsResults) {
+		txResult = blockResults.TxsResults[txIndex]
+	}
+
+	return n.normalizeTransaction(tx, blockHeight, txIndex, txResult)
+}
+
+// NormalizeTxResult normalizes a transaction delivered by a Tx event
+func (n *Normalizer) NormalizeTxResult(txResult *abci.TxResult) (*models.Transaction, error) {
+	if txResult == nil {
+		return nil, fmt.Errorf("transaction result is nil")
+	}
+
+	return n.normalizeTransaction(txResult.Tx, txResult.Height, txResult.Index, &txResult.Result)
+}
+
+// normalizeTransaction normalizes a transaction and, if available, its
+// execution result
+func (n *Normalizer) normalizeTransaction(
+	tx tmtypes.Tx,
+	blockHeight int64,
+	txIndex uint32,
+	txResult *abci.ExecTxResult,
 ) (*models.Transaction, error) {
 	if tx == nil {
 		return nil, fmt.Errorf("transaction is nil")
@@ -110,9 +142,7 @@ func (n *Normalizer) NormalizeTransaction(
 	metadata := make(map[string]interface{})
 
 	// Extract transaction result if available
-	if blockResults != nil && blockResults.TxsResults != nil && int(txIndex) < len(blockResults.TxsResults) {
-		txResult := blockResults.TxsResults[txIndex]
-
+	if txResult != nil {
 		// Determine status
 		if txResult.Code != 0 {
 			status = models.TxStatusFailed
@@ -151,19 +181,57 @@ func (n *Normalizer) NormalizeTransaction(
 	metadata["raw_tx"] = hex.EncodeToString(tx)
 	metadata["tx_index"] = txIndex
 
-	return &models.Transaction{
+	normalized := &models.Transaction{
+		ChainType:   models.ChainTypeCosmos,
 		ChainID:     n.chainID,
 		Hash:        txHash,
+		Index:       uint64(txIndex),
 		BlockNumber: uint64(blockHeight),
-		From:        "", // Cosmos doesn't have a simple "from" address
-		To:          "", // Will be extracted from tx decoding if needed
 		Value:       "0",
+		Fee:         "0",
 		GasUsed:     gasUsed,
 		GasPrice:    "0",
 		Status:      status,
 		Timestamp:   models.NewTimestamp(0), // Will be set from block timestamp
 		Metadata:    metadata,
-	}, nil
+	}
+
+	// Transactions that are not protobuf SDK transactions keep only the
+	// raw bytes
+	if decoded, err := DecodeTx(tx); err == nil {
+		applyDecodedTx(normalized, decoded)
+	}
+
+	return normalized, nil
+}
+
+// applyDecodedTx sets the participants, value and fee of a transaction from
+// its decoded messages. The first message with a sender determines From, To
+// and Value; all messages are kept in the metadata.
+func applyDecodedTx(tx *models.Transaction, decoded *DecodedTx) {
+	tx.Metadata["messages"] = decoded.Messages
+	tx.Metadata["gas_limit"] = decoded.GasLimit
+	if decoded.Memo != "" {
+		tx.Metadata["memo"] = decoded.Memo
+	}
+
+	for _, msg := range decoded.Messages {
+		if msg.From == "" {
+			continue
+		}
+		tx.From = msg.From
+		tx.To = msg.To
+		if len(msg.Amount) > 0 {
+			tx.Value = msg.Amount[0].Amount
+			tx.Metadata["denom"] = msg.Amount[0].Denom
+		}
+		break
+	}
+
+	if len(decoded.Fee) > 0 {
+		tx.Fee = decoded.Fee[0].Amount
+		tx.Metadata["fee_denom"] = decoded.Fee[0].Denom
+	}
 }
 
 // NormalizeBlockMeta normalizes block metadata
@@ -183,6 +251,7 @@ func (n *Normalizer) NormalizeBlockMeta(blockMeta *tmtypes.BlockMeta) (*models.B
 	metadata["chain_id"] = blockMeta.Header.ChainID
 
 	return &models.Block{
+		ChainType:    models.ChainTypeCosmos,
 		ChainID:      n.chainID,
 		Number:       uint64(blockMeta.Header.Height),
 		Hash:         blockHash,
@@ -214,11 +283,20 @@ func (n *Normalizer) NormalizeValidators(validators []*tmtypes.Validator) []map[
 	return result
 }
 
-// ExtractAddressesFromTx extracts addresses from a transaction (placeholder)
-// This would require transaction decoding which depends on the specific Cosmos chain
+// ExtractAddressesFromTx extracts the sender and receiver of the first
+// decoded message of a transaction
 func (n *Normalizer) ExtractAddressesFromTx(tx tmtypes.Tx) (from, to string, err error) {
-	// This is a placeholder - actual implementation would need to decode the transaction
-	// based on the specific Cosmos SDK version and chain configuration
+	decoded, err := DecodeTx(tx)
+	if err != nil {
+		return "", "", err
+	}
+
+	for _, msg := range decoded.Messages {
+		if msg.From != "" {
+			return msg.From, msg.To, nil
+		}
+	}
+
 	return "", "", nil
 }
 
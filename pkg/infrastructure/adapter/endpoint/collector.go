@@ -0,0 +1,123 @@
+package endpoint
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// pools holds the open pools exported by the Collector
+var pools = struct {
+	sync.RWMutex
+	set map[*Pool]struct{}
+}{set: make(map[*Pool]struct{})}
+
+func register(p *Pool) {
+	pools.Lock()
+	defer pools.Unlock()
+	pools.set[p] = struct{}{}
+}
+
+func unregister(p *Pool) {
+	pools.Lock()
+	defer pools.Unlock()
+	delete(pools.set, p)
+}
+
+var (
+	endpointLabels = []string{"chain_id", "endpoint"}
+
+	healthDesc = prometheus.NewDesc(
+		"indexer_rpc_endpoint_health",
+		"RPC endpoint health score (0-1) used to route requests",
+		endpointLabels, nil,
+	)
+	latencyDesc = prometheus.NewDesc(
+		"indexer_rpc_endpoint_latency_seconds",
+		"Moving average of successful RPC request latency per endpoint",
+		endpointLabels, nil,
+	)
+	errorRateDesc = prometheus.NewDesc(
+		"indexer_rpc_endpoint_error_rate",
+		"Moving average of the RPC request error rate (0-1) per endpoint",
+		endpointLabels, nil,
+	)
+	headDesc = prometheus.NewDesc(
+		"indexer_rpc_endpoint_head_height",
+		"Latest head height reported by the RPC endpoint",
+		endpointLabels, nil,
+	)
+	lagDesc = prometheus.NewDesc(
+		"indexer_rpc_endpoint_lag_blocks",
+		"Number of blocks the RPC endpoint is behind the highest head in its pool",
+		endpointLabels, nil,
+	)
+	quarantinedDesc = prometheus.NewDesc(
+		"indexer_rpc_endpoint_quarantined",
+		"Whether the RPC endpoint is quarantined for lagging (0=no, 1=yes)",
+		endpointLabels, nil,
+	)
+	requestsDesc = prometheus.NewDesc(
+		"indexer_rpc_endpoint_requests_total",
+		"Total number of requests sent to the RPC endpoint",
+		endpointLabels, nil,
+	)
+	errorsDesc = prometheus.NewDesc(
+		"indexer_rpc_endpoint_errors_total",
+		"Total number of failed requests to the RPC endpoint",
+		endpointLabels, nil,
+	)
+)
+
+// Collector exports the state of every open pool as Prometheus metrics
+type Collector struct{}
+
+// NewCollector creates a collector for the open pools
+func NewCollector() *Collector {
+	return &Collector{}
+}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- healthDesc
+	ch <- latencyDesc
+	ch <- errorRateDesc
+	ch <- headDesc
+	ch <- lagDesc
+	ch <- quarantinedDesc
+	ch <- requestsDesc
+	ch <- errorsDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	pools.RLock()
+	defer pools.RUnlock()
+
+	// A chain may briefly have two pools while its adapter is recreated
+	seen := make(map[[2]string]bool)
+	for p := range pools.set {
+		for _, s := range p.Status() {
+			key := [2]string{p.ChainID(), s.Name}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			labels := key[:]
+
+			quarantined := 0.0
+			if s.Quarantined {
+				quarantined = 1
+			}
+
+			ch <- prometheus.MustNewConstMetric(healthDesc, prometheus.GaugeValue, s.Health, labels...)
+			ch <- prometheus.MustNewConstMetric(latencyDesc, prometheus.GaugeValue, s.Latency.Seconds(), labels...)
+			ch <- prometheus.MustNewConstMetric(errorRateDesc, prometheus.GaugeValue, s.ErrorRate, labels...)
+			ch <- prometheus.MustNewConstMetric(headDesc, prometheus.GaugeValue, float64(s.Head), labels...)
+			ch <- prometheus.MustNewConstMetric(lagDesc, prometheus.GaugeValue, float64(s.Lag), labels...)
+			ch <- prometheus.MustNewConstMetric(quarantinedDesc, prometheus.GaugeValue, quarantined, labels...)
+			ch <- prometheus.MustNewConstMetric(requestsDesc, prometheus.CounterValue, float64(s.Requests), labels...)
+			ch <- prometheus.MustNewConstMetric(errorsDesc, prometheus.CounterValue, float64(s.Errors), labels...)
+		}
+	}
+}
@@ -0,0 +1,403 @@
+package endpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ewmaWeight is the weight of a new sample in the latency and error rate
+// moving averages
+const ewmaWeight = 0.2
+
+// Config holds the endpoint pool configuration
+type Config struct {
+	// ChainID labels the pool's metrics
+	ChainID string
+
+	// MaxLag is how many blocks an endpoint may fall behind the highest head
+	// reported by the pool before it is quarantined. Zero disables quarantine.
+	MaxLag uint64
+
+	// ProbeInterval is how often the head of every endpoint is polled
+	ProbeInterval time.Duration
+
+	// ProbeTimeout bounds a single head probe
+	ProbeTimeout time.Duration
+}
+
+// DefaultConfig returns the default pool configuration
+func DefaultConfig(chainID string) *Config {
+	return &Config{
+		ChainID:       chainID,
+		MaxLag:        10,
+		ProbeInterval: 15 * time.Second,
+		ProbeTimeout:  5 * time.Second,
+	}
+}
+
+// HeadFunc returns the head height reported by an endpoint
+type HeadFunc func(ctx context.Context, e *Endpoint) (uint64, error)
+
+// Endpoint is a single RPC endpoint of a pool
+type Endpoint struct {
+	// Index is the position of the endpoint in the configured list, which
+	// clients use to look up their per-endpoint connection
+	Index int
+
+	// URL is the endpoint URL
+	URL string
+
+	// Name identifies the endpoint in metrics and logs without exposing
+	// credentials that may be part of the URL
+	Name string
+
+	latency     time.Duration
+	errorRate   float64
+	head        uint64
+	requests    uint64
+	errors      uint64
+	quarantined bool
+	lastErr     error
+}
+
+// health scores the endpoint between 0 and 1, preferring endpoints that
+// fail less and answer faster
+func (e *Endpoint) health() float64 {
+	return (1 - e.errorRate) / (1 + e.latency.Seconds())
+}
+
+// Status is a snapshot of an endpoint's state
+type Status struct {
+	Name        string
+	URL         string
+	Health      float64
+	Latency     time.Duration
+	ErrorRate   float64
+	Head        uint64
+	Lag         uint64
+	Requests    uint64
+	Errors      uint64
+	Quarantined bool
+	LastError   string
+}
+
+// Pool routes requests to the healthiest of a chain's RPC endpoints. It
+// tracks the latency, error rate and head height of each endpoint and
+// quarantines endpoints that fall more than MaxLag blocks behind the others.
+type Pool struct {
+	config    *Config
+	endpoints []*Endpoint
+	probe     HeadFunc
+
+	mu        sync.RWMutex
+	done      chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// NewPool creates a pool over urls. If probe is not nil, the head of every
+// endpoint is polled every ProbeInterval until the pool is closed. The pool
+// is exported through the Collector while it is open.
+func NewPool(urls []string, config *Config, probe HeadFunc) (*Pool, error) {
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("no endpoints configured")
+	}
+	if config == nil {
+		config = DefaultConfig("")
+	}
+
+	p := &Pool{
+		config:    config,
+		endpoints: make([]*Endpoint, len(urls)),
+		probe:     probe,
+		done:      make(chan struct{}),
+	}
+
+	names := make(map[string]int, len(urls))
+	for i, rawURL := range urls {
+		host := redact(rawURL)
+		name := host
+		if n := names[host]; n > 0 {
+			name = fmt.Sprintf("%s#%d", host, n+1)
+		}
+		names[host]++
+
+		p.endpoints[i] = &Endpoint{Index: i, URL: rawURL, Name: name}
+	}
+
+	if probe != nil && config.ProbeInterval > 0 {
+		p.wg.Add(1)
+		go p.probeLoop()
+	}
+
+	register(p)
+	return p, nil
+}
+
+// redact strips the user info, path and query from an endpoint URL, which
+// commonly carry API keys
+func redact(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "endpoint"
+	}
+	return u.Host
+}
+
+// ChainID returns the chain the pool serves
+func (p *Pool) ChainID() string {
+	return p.config.ChainID
+}
+
+// Endpoints returns the endpoints in configured order
+func (p *Pool) Endpoints() []*Endpoint {
+	return p.endpoints
+}
+
+// Len returns the number of endpoints
+func (p *Pool) Len() int {
+	return len(p.endpoints)
+}
+
+// Select returns the healthiest endpoint that is not quarantined
+func (p *Pool) Select() *Endpoint {
+	return p.ordered()[0]
+}
+
+// ordered returns the endpoints healthiest first, followed by the
+// quarantined ones. Ties keep the configured order.
+func (p *Pool) ordered() []*Endpoint {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	ordered := make([]*Endpoint, len(p.endpoints))
+	copy(ordered, p.endpoints)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		if ordered[i].quarantined != ordered[j].quarantined {
+			return !ordered[i].quarantined
+		}
+		return ordered[i].health() > ordered[j].health()
+	})
+
+	return ordered
+}
+
+// permanentError marks an error of the request itself
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps an error that the endpoint answered correctly, such as a
+// JSON-RPC error for an unknown block. Do returns it without counting it
+// against the endpoint or trying another one.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err was wrapped with Permanent, in which case
+// retrying the request will not help
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
+
+// Do calls fn with the healthiest endpoint and fails over to the next one
+// while fn returns an error. Quarantined endpoints are only tried once all
+// others have failed. Errors wrapped with Permanent end the request at once.
+func (p *Pool) Do(ctx context.Context, fn func(ctx context.Context, e *Endpoint) error) error {
+	var lastErr error
+	for _, e := range p.ordered() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		start := time.Now()
+		err := fn(ctx, e)
+
+		if IsPermanent(err) {
+			p.Record(e, time.Since(start), nil)
+			return err
+		}
+
+		// Canceled requests say nothing about the endpoint
+		if err != nil && ctx.Err() != nil {
+			return err
+		}
+
+		p.Record(e, time.Since(start), err)
+		if err == nil {
+			return nil
+		}
+		lastErr = err
+	}
+
+	if len(p.endpoints) == 1 {
+		return lastErr
+	}
+	return fmt.Errorf("all %d endpoints failed: %w", len(p.endpoints), lastErr)
+}
+
+// Record updates the latency and error rate of e with the outcome of a
+// request
+func (p *Pool) Record(e *Endpoint, latency time.Duration, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	e.requests++
+	if err != nil {
+		e.errors++
+		e.lastErr = err
+		e.errorRate += ewmaWeight * (1 - e.errorRate)
+		return
+	}
+
+	e.errorRate -= ewmaWeight * e.errorRate
+	if e.latency == 0 {
+		e.latency = latency
+	} else {
+		e.latency += time.Duration(ewmaWeight * float64(latency-e.latency))
+	}
+}
+
+// ReportHead records the head height reported by e and re-evaluates which
+// endpoints lag too far behind
+func (p *Pool) ReportHead(e *Endpoint, head uint64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	e.head = head
+	p.updateQuarantine()
+}
+
+// updateQuarantine quarantines the endpoints that are more than MaxLag
+// blocks behind the highest head and releases the ones that caught up.
+// Endpoints that never reported a head are not quarantined.
+func (p *Pool) updateQuarantine() {
+	best := p.bestHead()
+	for _, e := range p.endpoints {
+		e.quarantined = p.config.MaxLag > 0 && e.head > 0 && best-e.head > p.config.MaxLag
+	}
+}
+
+// bestHead returns the highest head reported by any endpoint
+func (p *Pool) bestHead() uint64 {
+	var best uint64
+	for _, e := range p.endpoints {
+		if e.head > best {
+			best = e.head
+		}
+	}
+	return best
+}
+
+// Status returns a snapshot of every endpoint in configured order
+func (p *Pool) Status() []Status {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	best := p.bestHead()
+	statuses := make([]Status, len(p.endpoints))
+	for i, e := range p.endpoints {
+		statuses[i] = Status{
+			Name:        e.Name,
+			URL:         e.URL,
+			Health:      e.health(),
+			Latency:     e.latency,
+			ErrorRate:   e.errorRate,
+			Head:        e.head,
+			Requests:    e.requests,
+			Errors:      e.errors,
+			Quarantined: e.quarantined,
+		}
+		if e.head > 0 {
+			statuses[i].Lag = best - e.head
+		}
+		if e.lastErr != nil {
+			statuses[i].LastError = e.lastErr.Error()
+		}
+	}
+
+	return statuses
+}
+
+// Probe polls the head of every endpoint once. It does nothing if the pool
+// was created without a HeadFunc.
+func (p *Pool) Probe(ctx context.Context) {
+	if p.probe == nil {
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, e := range p.endpoints {
+		wg.Add(1)
+		go func(e *Endpoint) {
+			defer wg.Done()
+
+			probeCtx := ctx
+			if p.config.ProbeTimeout > 0 {
+				var cancel context.CancelFunc
+				probeCtx, cancel = context.WithTimeout(ctx, p.config.ProbeTimeout)
+				defer cancel()
+			}
+
+			start := time.Now()
+			head, err := p.probe(probeCtx, e)
+			if ctx.Err() != nil {
+				return
+			}
+			p.Record(e, time.Since(start), err)
+			if err == nil {
+				p.ReportHead(e, head)
+			}
+		}(e)
+	}
+	wg.Wait()
+}
+
+// probeLoop probes the endpoints every ProbeInterval until the pool is closed
+func (p *Pool) probeLoop() {
+	defer p.wg.Done()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-p.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	ticker := time.NewTicker(p.config.ProbeInterval)
+	defer ticker.Stop()
+
+	for {
+		p.Probe(ctx)
+
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Close stops probing and removes the pool from the Collector
+func (p *Pool) Close() {
+	p.closeOnce.Do(func() {
+		close(p.done)
+		p.wg.Wait()
+		unregister(p)
+	})
+}
@@ -2,11 +2,16 @@ package evm
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"sync"
+	"sync/atomic"
 
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
 	"github.com/sage-x-project/blockchain-indexer/pkg/domain/service"
 )
@@ -19,6 +24,9 @@ type Adapter struct {
 	chainInfo  *models.ChainInfo
 	mu         sync.RWMutex
 	connected  bool
+
+	// blockReceipts is set when the endpoints serve eth_getBlockReceipts
+	blockReceipts atomic.Bool
 }
 
 // NewAdapter creates a new EVM chain adapter
@@ -45,6 +53,7 @@ func NewAdapter(config *Config) (*Adapter, error) {
 	if err := adapter.initChainInfo(context.Background()); err != nil {
 		return nil, fmt.Errorf("failed to initialize chain info: %w", err)
 	}
+	adapter.detectBlockReceipts(context.Background())
 
 	return adapter, nil
 }
@@ -67,6 +76,19 @@ func (a *Adapter) initChainInfo(ctx context.Context) error {
 	return nil
 }
 
+// detectBlockReceipts checks whether receipts can be fetched per block with
+// eth_getBlockReceipts instead of per transaction
+func (a *Adapter) detectBlockReceipts(ctx context.Context) {
+	if !a.config.EnableReceiptFetch {
+		return
+	}
+
+	// Per transaction receipts work everywhere, so fall back to them when
+	// the endpoints could not be asked
+	supported, err := a.client.SupportsBlockReceipts(ctx)
+	a.blockReceipts.Store(err == nil && supported)
+}
+
 // GetChainType returns the chain type
 func (a *Adapter) GetChainType() models.ChainType {
 	return models.ChainTypeEVM
@@ -84,6 +106,21 @@ func (a *Adapter) GetChainInfo() *models.ChainInfo {
 	return a.chainInfo
 }
 
+// Capabilities reports the features the adapter supports. Subscriptions
+// need WebSocket and traces need the trace API to be enabled.
+func (a *Adapter) Capabilities() models.Capabilities {
+	return models.Capabilities{
+		BlockSubscription: a.config.EnableWebSocket,
+		BlockByHash:       true,
+		TransactionByHash: true,
+		Traces:            a.config.EnableTraceAPI,
+		Logs:              true,
+		FinalityTags:      true,
+		BatchFetch:        true,
+		MaxBlockRange:     a.config.MaxBlockRange + 1,
+	}
+}
+
 // GetLatestBlockNumber returns the latest block number
 func (a *Adapter) GetLatestBlockNumber(ctx context.Context) (uint64, error) {
 	blockNumber, err := a.client.BlockNumber(ctx)
@@ -94,12 +131,31 @@ func (a *Adapter) GetLatestBlockNumber(ctx context.Context) (uint64, error) {
 	return blockNumber, nil
 }
 
+// GetFinalityHeads returns the blocks the node tags as safe and finalized.
+// Nodes of chains without these tags fail.
+func (a *Adapter) GetFinalityHeads(ctx context.Context) (*models.FinalityHeads, error) {
+	safe, err := a.client.HeaderByNumber(ctx, big.NewInt(int64(rpc.SafeBlockNumber)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get safe block: %w", err)
+	}
+
+	finalized, err := a.client.HeaderByNumber(ctx, big.NewInt(int64(rpc.FinalizedBlockNumber)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get finalized block: %w", err)
+	}
+
+	return &models.FinalityHeads{
+		Safe:      safe.Number.Uint64(),
+		Finalized: finalized.Number.Uint64(),
+	}, nil
+}
+
 // GetBlockByNumber fetches a block by number
 func (a *Adapter) GetBlockByNumber(ctx context.Context, number uint64) (*models.Block, error) {
 	// Fetch block
 	block, err := a.client.BlockByNumber(ctx, big.NewInt(int64(number)))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get block %d: %w", number, err)
+		return nil, fmt.Errorf("failed to get block %d: %w", number, notFound(err, models.ErrBlockNotFound))
 	}
 
 	// Fetch receipts if enabled
@@ -119,6 +175,10 @@ func (a *Adapter) GetBlockByNumber(ctx context.Context, number uint64) (*models.
 		return nil, fmt.Errorf("failed to normalize block %d: %w", number, err)
 	}
 
+	if err := a.attachInternalTransactions(ctx, []*types.Block{block}, []*models.Block{domainBlock}); err != nil {
+		return nil, err
+	}
+
 	return domainBlock, nil
 }
 
@@ -133,7 +193,7 @@ func (a *Adapter) GetBlockByHash(ctx context.Context, hash string) (*models.Bloc
 	// Fetch block
 	block, err := a.client.BlockByHash(ctx, blockHash)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get block %s: %w", hash, err)
+		return nil, fmt.Errorf("failed to get block %s: %w", hash, notFound(err, models.ErrBlockNotFound))
 	}
 
 	// Fetch receipts if enabled
@@ -152,6 +212,10 @@ func (a *Adapter) GetBlockByHash(ctx context.Context, hash string) (*models.Bloc
 		return nil, fmt.Errorf("failed to normalize block %s: %w", hash, err)
 	}
 
+	if err := a.attachInternalTransactions(ctx, []*types.Block{block}, []*models.Block{domainBlock}); err != nil {
+		return nil, err
+	}
+
 	return domainBlock, nil
 }
 
@@ -166,6 +230,10 @@ func (a *Adapter) GetBlocks(ctx context.Context, start, end uint64) ([]*models.B
 		end = start + a.config.MaxBlockRange
 	}
 
+	if a.config.EnableBatchRequest {
+		return a.getBlocksBatched(ctx, start, end)
+	}
+
 	blocks := make([]*models.Block, 0, end-start+1)
 
 	// Fetch blocks concurrently
@@ -223,6 +291,49 @@ func (a *Adapter) GetBlocks(ctx context.Context, start, end uint64) ([]*models.B
 	return blocks, nil
 }
 
+// getBlocksBatched fetches a range of blocks and their receipts with
+// JSON-RPC batch requests
+func (a *Adapter) getBlocksBatched(ctx context.Context, start, end uint64) ([]*models.Block, error) {
+	numbers := make([]uint64, 0, end-start+1)
+	for number := start; number <= end; number++ {
+		numbers = append(numbers, number)
+	}
+
+	rawBlocks, err := a.client.BlocksByNumber(ctx, numbers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch blocks %d-%d: %w", start, end, notFound(err, models.ErrBlockNotFound))
+	}
+
+	// Fetch receipts if enabled
+	var receipts [][]*types.Receipt
+	if a.config.EnableReceiptFetch {
+		receipts, err = a.fetchBlocksReceipts(ctx, rawBlocks)
+		if err != nil {
+			// Receipts are optional, as for single blocks
+			receipts = nil
+		}
+	}
+
+	blocks := make([]*models.Block, len(rawBlocks))
+	for i, block := range rawBlocks {
+		var blockReceipts []*types.Receipt
+		if receipts != nil {
+			blockReceipts = receipts[i]
+		}
+
+		blocks[i], err = a.normalizer.NormalizeBlock(block, blockReceipts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to normalize block %d: %w", block.NumberU64(), err)
+		}
+	}
+
+	if err := a.attachInternalTransactions(ctx, rawBlocks, blocks); err != nil {
+		return nil, err
+	}
+
+	return blocks, nil
+}
+
 // GetTransaction fetches a transaction by hash
 func (a *Adapter) GetTransaction(ctx context.Context, hash string) (*models.Transaction, error) {
 	// Parse hash
@@ -234,7 +345,7 @@ func (a *Adapter) GetTransaction(ctx context.Context, hash string) (*models.Tran
 	// Fetch transaction
 	tx, isPending, err := a.client.TransactionByHash(ctx, txHash)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get transaction %s: %w", hash, err)
+		return nil, fmt.Errorf("failed to get transaction %s: %w", hash, notFound(err, models.ErrTransactionNotFound))
 	}
 
 	if isPending {
@@ -262,6 +373,15 @@ func (a *Adapter) GetTransaction(ctx context.Context, hash string) (*models.Tran
 	return domainTx, nil
 }
 
+// notFound adds the domain error for an object the node does not have to
+// err, so callers need not know about go-ethereum's NotFound
+func notFound(err, domainErr error) error {
+	if errors.Is(err, ethereum.NotFound) {
+		return fmt.Errorf("%w: %w", domainErr, err)
+	}
+	return err
+}
+
 // GetTransactionsByBlock fetches all transactions in a block
 func (a *Adapter) GetTransactionsByBlock(ctx context.Context, blockNumber uint64) ([]*models.Transaction, error) {
 	block, err := a.GetBlockByNumber(ctx, blockNumber)
@@ -291,6 +411,7 @@ func (a *Adapter) Connect(ctx context.Context) error {
 	if err := a.initChainInfo(ctx); err != nil {
 		return fmt.Errorf("failed to connect: %w", err)
 	}
+	a.detectBlockReceipts(ctx)
 
 	a.mu.Lock()
 	a.connected = true
@@ -319,7 +440,7 @@ func (a *Adapter) Disconnect() error {
 // SubscribeNewBlocks subscribes to new blocks
 func (a *Adapter) SubscribeNewBlocks(ctx context.Context) (service.BlockSubscription, error) {
 	if !a.config.EnableWebSocket {
-		return nil, fmt.Errorf("websocket not enabled")
+		return nil, fmt.Errorf("websocket not enabled: %w", service.ErrNotSupported)
 	}
 
 	headerChan := make(chan *types.Header, a.config.SubscriptionBufferSize)
@@ -328,21 +449,38 @@ func (a *Adapter) SubscribeNewBlocks(ctx context.Context) (service.BlockSubscrip
 		return nil, fmt.Errorf("failed to subscribe to new blocks: %w", err)
 	}
 
-	blockChan := make(chan *models.Block, a.config.SubscriptionBufferSize)
-	errChan := make(chan error, 1)
+	subscription := &blockSubscription{
+		sub:       sub,
+		blockChan: make(chan *models.Block, a.config.SubscriptionBufferSize),
+		errChan:   make(chan error, 1),
+		done:      make(chan struct{}),
+	}
 
 	// Start goroutine to convert headers to blocks
 	go func() {
-		defer close(blockChan)
-		defer close(errChan)
+		defer close(subscription.blockChan)
+		defer close(subscription.errChan)
+		defer sub.Unsubscribe()
+
+		// Sends give up once the subscription is cancelled so that the
+		// goroutine never blocks on a consumer that has gone away
+		sendErr := func(err error) {
+			select {
+			case subscription.errChan <- err:
+			case <-ctx.Done():
+			case <-subscription.done:
+			}
+		}
 
 		for {
 			select {
 			case <-ctx.Done():
 				return
+			case <-subscription.done:
+				return
 			case err := <-sub.Err():
 				if err != nil {
-					errChan <- err
+					sendErr(err)
 				}
 				return
 			case header := <-headerChan:
@@ -353,37 +491,118 @@ func (a *Adapter) SubscribeNewBlocks(ctx context.Context) (service.BlockSubscrip
 				// Fetch full block
 				block, err := a.GetBlockByNumber(ctx, header.Number.Uint64())
 				if err != nil {
-					errChan <- fmt.Errorf("failed to fetch block %d: %w", header.Number.Uint64(), err)
+					sendErr(fmt.Errorf("failed to fetch block %d: %w", header.Number.Uint64(), err))
 					continue
 				}
 
-				blockChan <- block
+				select {
+				case subscription.blockChan <- block:
+				case <-ctx.Done():
+					return
+				case <-subscription.done:
+					return
+				}
 			}
 		}
 	}()
 
-	return &blockSubscription{
-		sub:       sub,
-		blockChan: blockChan,
-		errChan:   errChan,
-	}, nil
+	return subscription, nil
 }
 
 // SubscribeNewTransactions subscribes to new transactions
 func (a *Adapter) SubscribeNewTransactions(ctx context.Context) (service.TransactionSubscription, error) {
 	// EVM doesn't have a built-in pending transaction subscription that works reliably
 	// This would require monitoring the mempool or watching new blocks
-	return nil, fmt.Errorf("transaction subscription not implemented")
+	return nil, fmt.Errorf("transaction subscription not implemented: %w", service.ErrNotSupported)
 }
 
 // fetchReceipts fetches receipts for all transactions in a block
 func (a *Adapter) fetchReceipts(ctx context.Context, block *types.Block) ([]*types.Receipt, error) {
-	receipts := make([]*types.Receipt, 0, len(block.Transactions()))
+	receipts, err := a.fetchBlocksReceipts(ctx, []*types.Block{block})
+	if err != nil {
+		return nil, err
+	}
 
-	for _, tx := range block.Transactions() {
-		receipt, err := a.client.TransactionReceipt(ctx, tx.Hash())
+	return receipts[0], nil
+}
+
+// fetchBlocksReceipts fetches the receipts of every block, per block with
+// eth_getBlockReceipts when the node supports it and per transaction
+// otherwise
+func (a *Adapter) fetchBlocksReceipts(ctx context.Context, blocks []*types.Block) ([][]*types.Receipt, error) {
+	receipts := make([][]*types.Receipt, len(blocks))
+
+	// Blocks without transactions have no receipts to fetch
+	pending := make([]int, 0, len(blocks))
+	for i, block := range blocks {
+		if len(block.Transactions()) == 0 {
+			receipts[i] = []*types.Receipt{}
+			continue
+		}
+		pending = append(pending, i)
+	}
+	if len(pending) == 0 {
+		return receipts, nil
+	}
+
+	if a.blockReceipts.Load() {
+		hashes := make([]common.Hash, len(pending))
+		for i, index := range pending {
+			hashes[i] = blocks[index].Hash()
+		}
+
+		blockReceipts, err := a.client.BlockReceipts(ctx, hashes)
+		if err == nil {
+			for i, index := range pending {
+				receipts[index] = blockReceipts[i]
+			}
+			return receipts, nil
+		}
+		if !isMethodNotFound(err) {
+			return nil, fmt.Errorf("failed to fetch block receipts: %w", err)
+		}
+
+		// The node stopped serving the method, such as after failing over
+		// to another endpoint
+		a.blockReceipts.Store(false)
+	}
+
+	var hashes []common.Hash
+	for _, index := range pending {
+		for _, tx := range blocks[index].Transactions() {
+			hashes = append(hashes, tx.Hash())
+		}
+	}
+
+	txReceipts, err := a.fetchTransactionReceipts(ctx, hashes)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, index := range pending {
+		count := len(blocks[index].Transactions())
+		receipts[index], txReceipts = txReceipts[:count], txReceipts[count:]
+	}
+
+	return receipts, nil
+}
+
+// fetchTransactionReceipts fetches the receipts of the given transactions,
+// in JSON-RPC batches when batch requests are enabled
+func (a *Adapter) fetchTransactionReceipts(ctx context.Context, hashes []common.Hash) ([]*types.Receipt, error) {
+	if a.config.EnableBatchRequest {
+		receipts, err := a.client.TransactionReceipts(ctx, hashes)
 		if err != nil {
-			return nil, fmt.Errorf("failed to fetch receipt for tx %s: %w", tx.Hash().Hex(), err)
+			return nil, fmt.Errorf("failed to fetch receipts: %w", err)
+		}
+		return receipts, nil
+	}
+
+	receipts := make([]*types.Receipt, 0, len(hashes))
+	for _, hash := range hashes {
+		receipt, err := a.client.TransactionReceipt(ctx, hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch receipt for tx %s: %w", hash.Hex(), err)
 		}
 		receipts = append(receipts, receipt)
 	}
@@ -391,6 +610,86 @@ func (a *Adapter) fetchReceipts(ctx context.Context, block *types.Block) ([]*typ
 	return receipts, nil
 }
 
+// attachInternalTransactions traces blocks when the trace API is enabled and
+// attaches the internal transactions to the transactions of their
+// normalized counterparts
+func (a *Adapter) attachInternalTransactions(ctx context.Context, blocks []*types.Block, normalized []*models.Block) error {
+	if !a.config.EnableTraceAPI {
+		return nil
+	}
+
+	// Blocks without transactions have nothing to trace
+	traced := make([]int, 0, len(blocks))
+	for i, block := range blocks {
+		if len(block.Transactions()) > 0 {
+			traced = append(traced, i)
+		}
+	}
+	if len(traced) == 0 {
+		return nil
+	}
+
+	internals := make([]map[string][]*models.InternalTransaction, len(blocks))
+	switch a.config.TraceAPI {
+	case TraceAPIParity:
+		numbers := make([]uint64, len(traced))
+		for i, index := range traced {
+			numbers[i] = blocks[index].NumberU64()
+		}
+
+		traces, err := a.client.ParityTraceBlocks(ctx, numbers)
+		if err != nil {
+			return fmt.Errorf("failed to trace blocks: %w", err)
+		}
+
+		for i, index := range traced {
+			// trace_block looks blocks up by number, which may have been
+			// reorganized since the block was fetched
+			for _, trace := range traces[i] {
+				if trace.BlockHash != (common.Hash{}) && trace.BlockHash != blocks[index].Hash() {
+					return fmt.Errorf("block %d changed while tracing", numbers[i])
+				}
+			}
+			internals[index] = a.normalizer.NormalizeParityTraces(traces[i])
+		}
+	default:
+		hashes := make([]common.Hash, len(traced))
+		for i, index := range traced {
+			hashes[i] = blocks[index].Hash()
+		}
+
+		traces, err := a.client.TraceBlocks(ctx, hashes)
+		if err != nil {
+			return fmt.Errorf("failed to trace blocks: %w", err)
+		}
+
+		for i, index := range traced {
+			txs := blocks[index].Transactions()
+			if len(traces[i]) != len(txs) {
+				return fmt.Errorf("got %d traces for the %d transactions of block %d", len(traces[i]), len(txs), blocks[index].NumberU64())
+			}
+
+			internals[index] = make(map[string][]*models.InternalTransaction, len(txs))
+			for j, trace := range traces[i] {
+				if trace.Error != "" {
+					return fmt.Errorf("failed to trace tx %s: %s", txs[j].Hash().Hex(), trace.Error)
+				}
+				internals[index][txs[j].Hash().Hex()] = a.normalizer.NormalizeCallFrame(trace.Result)
+			}
+		}
+	}
+
+	for _, index := range traced {
+		for _, tx := range normalized[index].Transactions {
+			if calls := internals[index][tx.Hash]; len(calls) > 0 {
+				tx.InternalTransactions = calls
+			}
+		}
+	}
+
+	return nil
+}
+
 // Helper functions
 
 func parseHash(hash string) ([32]byte, error) {
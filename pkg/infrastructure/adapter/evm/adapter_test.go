@@ -2,9 +2,12 @@ package evm
 
 import (
 	"context"
+	"math/big"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
 )
 
@@ -177,6 +180,99 @@ func TestNormalizer(t *testing.T) {
 	}
 }
 
+func TestNormalizeLogs(t *testing.T) {
+	transferSig := common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	token := common.HexToAddress("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")
+
+	logs := normalizeLogs([]*types.Log{
+		{Address: token, Topics: []common.Hash{transferSig}, Data: []byte{0x01}, Index: 7},
+	})
+
+	if len(logs) != 1 {
+		t.Fatalf("normalizeLogs() returned %d logs, want 1", len(logs))
+	}
+	log := logs[0]
+	if log.Index != 7 {
+		t.Errorf("Index = %d, want 7", log.Index)
+	}
+	if log.Address != "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48" {
+		t.Errorf("Address = %s, want checksummed token address", log.Address)
+	}
+	if len(log.Topics) != 1 || log.Topics[0] != transferSig.Hex() {
+		t.Errorf("Topics = %v, want [%s]", log.Topics, transferSig.Hex())
+	}
+	if len(log.Data) != 1 || log.Data[0] != 0x01 {
+		t.Errorf("Data = %x, want 01", log.Data)
+	}
+}
+
+func TestDecodeTokenTransfers(t *testing.T) {
+	word := func(n int64) []byte { return common.BigToHash(big.NewInt(n)).Bytes() }
+	concat := func(words ...[]byte) []byte {
+		var data []byte
+		for _, w := range words {
+			data = append(data, w...)
+		}
+		return data
+	}
+
+	alice := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	bob := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	operator := common.HexToAddress("0x3333333333333333333333333333333333333333")
+	topic := func(a common.Address) string { return common.BytesToHash(a.Bytes()).Hex() }
+	approvalSig := "0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925"
+
+	tx := &models.Transaction{
+		ChainID:     "ethereum",
+		Hash:        "0xtx",
+		BlockNumber: 100,
+		BlockHash:   "0xblock",
+		Index:       3,
+		Logs: []*models.Log{
+			// ERC-20 Transfer
+			{Index: 0, Address: "0xToken", Topics: []string{transferEventTopic.Hex(), topic(alice), topic(bob)}, Data: word(500)},
+			// ERC-721 Transfer
+			{Index: 1, Address: "0xNFT", Topics: []string{transferEventTopic.Hex(), topic(alice), topic(bob), common.BigToHash(big.NewInt(42)).Hex()}},
+			// ERC-1155 TransferSingle
+			{Index: 2, Address: "0xMulti", Topics: []string{transferSingleEventTopic.Hex(), topic(operator), topic(alice), topic(bob)}, Data: concat(word(7), word(3))},
+			// ERC-1155 TransferBatch with ids [1, 2] and values [10, 20]
+			{Index: 3, Address: "0xMulti", Topics: []string{transferBatchEventTopic.Hex(), topic(operator), topic(bob), topic(alice)},
+				Data: concat(word(64), word(160), word(2), word(1), word(2), word(2), word(10), word(20))},
+			// TransferBatch whose array runs past the data
+			{Index: 4, Address: "0xMulti", Topics: []string{transferBatchEventTopic.Hex(), topic(operator), topic(bob), topic(alice)},
+				Data: concat(word(64), word(96), word(5), word(1))},
+			// Not a transfer
+			{Index: 5, Address: "0xToken", Topics: []string{approvalSig, topic(alice), topic(bob)}, Data: word(1)},
+			// Transfer without indexed parties
+			{Index: 6, Address: "0xToken", Topics: []string{transferEventTopic.Hex()}, Data: concat(word(1), word(2), word(3))},
+		},
+	}
+
+	transfers := NewNormalizer("ethereum", "mainnet").DecodeTokenTransfers(tx)
+
+	want := []models.TokenTransfer{
+		{Standard: models.TokenStandardERC20, Token: "0xToken", From: alice.Hex(), To: bob.Hex(), Value: "500", LogIndex: 0},
+		{Standard: models.TokenStandardERC721, Token: "0xNFT", From: alice.Hex(), To: bob.Hex(), Value: "1", TokenID: "42", LogIndex: 1},
+		{Standard: models.TokenStandardERC1155, Token: "0xMulti", Operator: operator.Hex(), From: alice.Hex(), To: bob.Hex(), Value: "3", TokenID: "7", LogIndex: 2},
+		{Standard: models.TokenStandardERC1155, Token: "0xMulti", Operator: operator.Hex(), From: bob.Hex(), To: alice.Hex(), Value: "10", TokenID: "1", LogIndex: 3},
+		{Standard: models.TokenStandardERC1155, Token: "0xMulti", Operator: operator.Hex(), From: bob.Hex(), To: alice.Hex(), Value: "20", TokenID: "2", LogIndex: 3, BatchIndex: 1},
+	}
+
+	if len(transfers) != len(want) {
+		t.Fatalf("DecodeTokenTransfers() returned %d transfers, want %d", len(transfers), len(want))
+	}
+	for i, transfer := range transfers {
+		if transfer.ChainID != "ethereum" || transfer.TxHash != "0xtx" || transfer.BlockNumber != 100 || transfer.BlockHash != "0xblock" || transfer.TxIndex != 3 {
+			t.Errorf("transfer %d position = %+v", i, transfer)
+		}
+		got := *transfer
+		got.ChainID, got.TxHash, got.BlockNumber, got.BlockHash, got.TxIndex = "", "", 0, "", 0
+		if got != want[i] {
+			t.Errorf("transfer %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+}
+
 func TestParseHash(t *testing.T) {
 	tests := []struct {
 		name    string
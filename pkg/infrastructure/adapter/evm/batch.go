@@ -0,0 +1,266 @@
+package evm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// methodNotFoundCode is the JSON-RPC error code for an unknown method
+const methodNotFoundCode = -32601
+
+// rpcBlockBody holds the parts of a JSON-RPC block that are not part of the
+// header
+type rpcBlockBody struct {
+	Transactions []*types.Transaction `json:"transactions"`
+	UncleHashes  []common.Hash        `json:"uncles"`
+	Withdrawals  []*types.Withdrawal  `json:"withdrawals,omitempty"`
+}
+
+// batchCall sends elems as JSON-RPC batches of at most size calls, running
+// up to ConcurrentFetches batches at once. Each batch goes through the
+// endpoint pool with retry logic. The first error returned for any call
+// fails the whole request.
+func (c *Client) batchCall(ctx context.Context, elems []rpc.BatchElem, size int) error {
+	if size <= 0 {
+		size = len(elems)
+	}
+
+	concurrency := c.config.ConcurrentFetches
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	var wg sync.WaitGroup
+	var errOnce sync.Once
+	var firstErr error
+	semaphore := make(chan struct{}, concurrency)
+
+	for offset := 0; offset < len(elems); offset += size {
+		end := offset + size
+		if end > len(elems) {
+			end = len(elems)
+		}
+
+		wg.Add(1)
+		go func(batch []rpc.BatchElem) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			err := c.executeRPCWithRetry(ctx, func(ctx context.Context, rpcClient *rpc.Client) error {
+				for i := range batch {
+					batch[i].Error = nil
+				}
+				if err := rpcClient.BatchCallContext(ctx, batch); err != nil {
+					return err
+				}
+				for i := range batch {
+					if batch[i].Error != nil {
+						return batch[i].Error
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				errOnce.Do(func() { firstErr = err })
+			}
+		}(elems[offset:end])
+	}
+
+	wg.Wait()
+	return firstErr
+}
+
+// BlocksByNumber fetches the blocks with the given numbers, including their
+// transactions, in JSON-RPC batches
+func (c *Client) BlocksByNumber(ctx context.Context, numbers []uint64) ([]*types.Block, error) {
+	raws := make([]json.RawMessage, len(numbers))
+	elems := make([]rpc.BatchElem, len(numbers))
+	for i, number := range numbers {
+		elems[i] = rpc.BatchElem{
+			Method: "eth_getBlockByNumber",
+			Args:   []interface{}{hexutil.EncodeUint64(number), true},
+			Result: &raws[i],
+		}
+	}
+
+	if err := c.batchCall(ctx, elems, c.config.BatchRequestSize); err != nil {
+		return nil, err
+	}
+
+	blocks := make([]*types.Block, len(numbers))
+	bodies := make([]*rpcBlockBody, len(numbers))
+	for i, raw := range raws {
+		header, body, err := decodeBlock(raw)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %w", numbers[i], err)
+		}
+		blocks[i] = types.NewBlockWithHeader(header)
+		bodies[i] = body
+	}
+
+	uncles, err := c.unclesOf(ctx, blocks, bodies)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, block := range blocks {
+		blocks[i] = block.WithBody(types.Body{
+			Transactions: bodies[i].Transactions,
+			Uncles:       uncles[i],
+			Withdrawals:  bodies[i].Withdrawals,
+		})
+	}
+
+	return blocks, nil
+}
+
+// decodeBlock decodes a JSON-RPC block with full transactions
+func decodeBlock(raw json.RawMessage) (*types.Header, *rpcBlockBody, error) {
+	var header *types.Header
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return nil, nil, err
+	}
+	// Unknown blocks are returned as JSON null
+	if header == nil {
+		return nil, nil, ethereum.NotFound
+	}
+
+	var body rpcBlockBody
+	if err := json.Unmarshal(raw, &body); err != nil {
+		return nil, nil, err
+	}
+
+	if header.TxHash == types.EmptyTxsHash && len(body.Transactions) > 0 {
+		return nil, nil, errors.New("server returned non-empty transaction list but block header indicates no transactions")
+	}
+	if header.TxHash != types.EmptyTxsHash && len(body.Transactions) == 0 {
+		return nil, nil, errors.New("server returned empty transaction list but block header indicates transactions")
+	}
+
+	return header, &body, nil
+}
+
+// unclesOf fetches the uncle headers of blocks in a single batch, since
+// blocks only list the hashes of their uncles
+func (c *Client) unclesOf(ctx context.Context, blocks []*types.Block, bodies []*rpcBlockBody) ([][]*types.Header, error) {
+	uncles := make([][]*types.Header, len(blocks))
+	var elems []rpc.BatchElem
+	for i, body := range bodies {
+		if len(body.UncleHashes) == 0 {
+			continue
+		}
+
+		uncles[i] = make([]*types.Header, len(body.UncleHashes))
+		for j := range body.UncleHashes {
+			elems = append(elems, rpc.BatchElem{
+				Method: "eth_getUncleByBlockHashAndIndex",
+				Args:   []interface{}{blocks[i].Hash(), hexutil.EncodeUint64(uint64(j))},
+				Result: &uncles[i][j],
+			})
+		}
+	}
+
+	if len(elems) == 0 {
+		return uncles, nil
+	}
+	if err := c.batchCall(ctx, elems, c.config.BatchRequestSize); err != nil {
+		return nil, fmt.Errorf("failed to fetch uncles: %w", err)
+	}
+
+	for i := range uncles {
+		for j, uncle := range uncles[i] {
+			if uncle == nil {
+				return nil, fmt.Errorf("got null header for uncle %d of block %d", j, blocks[i].NumberU64())
+			}
+		}
+	}
+
+	return uncles, nil
+}
+
+// TransactionReceipts fetches the receipts of the given transactions in
+// JSON-RPC batches
+func (c *Client) TransactionReceipts(ctx context.Context, hashes []common.Hash) ([]*types.Receipt, error) {
+	receipts := make([]*types.Receipt, len(hashes))
+	elems := make([]rpc.BatchElem, len(hashes))
+	for i, hash := range hashes {
+		elems[i] = rpc.BatchElem{
+			Method: "eth_getTransactionReceipt",
+			Args:   []interface{}{hash},
+			Result: &receipts[i],
+		}
+	}
+
+	if err := c.batchCall(ctx, elems, c.config.BatchRequestSize); err != nil {
+		return nil, err
+	}
+
+	for i, receipt := range receipts {
+		if receipt == nil {
+			return nil, fmt.Errorf("receipt for tx %s: %w", hashes[i].Hex(), ethereum.NotFound)
+		}
+	}
+
+	return receipts, nil
+}
+
+// BlockReceipts fetches all receipts of the blocks with the given hashes
+// with eth_getBlockReceipts, in JSON-RPC batches
+func (c *Client) BlockReceipts(ctx context.Context, hashes []common.Hash) ([][]*types.Receipt, error) {
+	receipts := make([][]*types.Receipt, len(hashes))
+	elems := make([]rpc.BatchElem, len(hashes))
+	for i, hash := range hashes {
+		elems[i] = rpc.BatchElem{
+			Method: "eth_getBlockReceipts",
+			Args:   []interface{}{hash},
+			Result: &receipts[i],
+		}
+	}
+
+	if err := c.batchCall(ctx, elems, c.config.BatchRequestSize); err != nil {
+		return nil, err
+	}
+
+	for i, blockReceipts := range receipts {
+		if blockReceipts == nil {
+			return nil, fmt.Errorf("receipts for block %s: %w", hashes[i].Hex(), ethereum.NotFound)
+		}
+	}
+
+	return receipts, nil
+}
+
+// SupportsBlockReceipts reports whether the endpoints serve
+// eth_getBlockReceipts. Only connection failures are returned as errors; a
+// node that rejects the call does not support it.
+func (c *Client) SupportsBlockReceipts(ctx context.Context) (bool, error) {
+	err := c.executeRPCWithRetry(ctx, func(ctx context.Context, rpcClient *rpc.Client) error {
+		var receipts []*types.Receipt
+		return rpcClient.CallContext(ctx, &receipts, "eth_getBlockReceipts", "latest")
+	})
+	if err == nil {
+		return true, nil
+	}
+	if c.isNodeError(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+// isMethodNotFound checks if the node rejected a call because it does not
+// know the method
+func isMethodNotFound(err error) bool {
+	var rpcErr rpc.Error
+	return errors.As(err, &rpcErr) && rpcErr.ErrorCode() == methodNotFoundCode
+}
@@ -0,0 +1,531 @@
+package evm
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+)
+
+const (
+	fakeChainBlocks = 100
+	fakeChainTxs    = 5
+
+	// fakeFutureBlocks are generated past the head for produce
+	fakeFutureBlocks = 10
+)
+
+// fakeNode serves a chain of fakeChainBlocks blocks with fakeChainTxs
+// transactions each over JSON-RPC, counting the HTTP requests per method.
+// New heads are streamed over WebSocket at /ws.
+type fakeNode struct {
+	*httptest.Server
+
+	blocks   map[uint64]*types.Block
+	receipts map[common.Hash]*types.Receipt
+
+	mu            sync.Mutex
+	head          uint64
+	blockReceipts bool
+	requests      map[string]int
+	calls         map[string]int
+	subscribers   map[chan *types.Header]struct{}
+}
+
+type fakeRequest struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+type fakeResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  interface{}     `json:"result,omitempty"`
+	Error   *fakeError      `json:"error,omitempty"`
+}
+
+type fakeError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func newFakeNode(t *testing.T, blockReceipts bool) *fakeNode {
+	t.Helper()
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+	chainID := big.NewInt(1)
+	signer := types.NewEIP155Signer(chainID)
+	to := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+	f := &fakeNode{
+		blocks:        make(map[uint64]*types.Block),
+		receipts:      make(map[common.Hash]*types.Receipt),
+		head:          fakeChainBlocks,
+		blockReceipts: blockReceipts,
+		requests:      make(map[string]int),
+		calls:         make(map[string]int),
+		subscribers:   make(map[chan *types.Header]struct{}),
+	}
+
+	parent := common.Hash{}
+	for number := uint64(0); number <= fakeChainBlocks+fakeFutureBlocks; number++ {
+		header := &types.Header{
+			ParentHash: parent,
+			Number:     new(big.Int).SetUint64(number),
+			UncleHash:  types.EmptyUncleHash,
+			Difficulty: big.NewInt(1),
+			GasLimit:   30000000,
+			Time:       1700000000 + number*12,
+		}
+
+		var txs []*types.Transaction
+		var receipts []*types.Receipt
+		if number > 0 {
+			for i := 0; i < fakeChainTxs; i++ {
+				nonce := (number-1)*fakeChainTxs + uint64(i)
+				tx, err := types.SignTx(types.NewTransaction(nonce, to, big.NewInt(1), 21000, big.NewInt(1), nil), signer, key)
+				if err != nil {
+					t.Fatalf("SignTx() error = %v", err)
+				}
+				txs = append(txs, tx)
+				receipts = append(receipts, &types.Receipt{
+					Status:            types.ReceiptStatusSuccessful,
+					CumulativeGasUsed: 21000 * uint64(i+1),
+					Logs:              []*types.Log{},
+					TxHash:            tx.Hash(),
+					GasUsed:           21000,
+					EffectiveGasPrice: big.NewInt(1),
+					TransactionIndex:  uint(i),
+				})
+			}
+		}
+
+		// Clients only check the transaction root against an empty list
+		header.TxHash = types.EmptyTxsHash
+		if len(txs) > 0 {
+			header.TxHash = crypto.Keccak256Hash(header.Number.Bytes())
+		}
+		block := types.NewBlockWithHeader(header).WithBody(types.Body{Transactions: txs})
+		for _, receipt := range receipts {
+			receipt.BlockHash = block.Hash()
+			receipt.BlockNumber = block.Number()
+			f.receipts[receipt.TxHash] = receipt
+		}
+		f.blocks[number] = block
+		parent = block.Hash()
+	}
+
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", &fakeEthAPI{node: f}); err != nil {
+		t.Fatalf("RegisterName() error = %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", f.serveRPC)
+	mux.Handle("/ws", server.WebsocketHandler([]string{"*"}))
+	f.Server = httptest.NewServer(mux)
+	t.Cleanup(f.Close)
+	t.Cleanup(server.Stop)
+
+	return f
+}
+
+func (f *fakeNode) serveRPC(w http.ResponseWriter, r *http.Request) {
+	var raw json.RawMessage
+	if err := json.NewDecoder(r.Body).Decode(&raw); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var reqs []fakeRequest
+	batch := len(raw) > 0 && raw[0] == '['
+	if batch {
+		json.Unmarshal(raw, &reqs)
+	} else {
+		var req fakeRequest
+		json.Unmarshal(raw, &req)
+		reqs = []fakeRequest{req}
+	}
+
+	f.mu.Lock()
+	seen := make(map[string]bool)
+	for _, req := range reqs {
+		f.calls[req.Method]++
+		if !seen[req.Method] {
+			f.requests[req.Method]++
+			seen[req.Method] = true
+		}
+	}
+	f.mu.Unlock()
+
+	resps := make([]fakeResponse, len(reqs))
+	for i, req := range reqs {
+		resps[i] = fakeResponse{JSONRPC: "2.0", ID: req.ID}
+		resps[i].Result, resps[i].Error = f.call(req)
+		// Unknown blocks and transactions are a null result
+		if resps[i].Result == nil && resps[i].Error == nil {
+			resps[i].Result = json.RawMessage("null")
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if batch {
+		json.NewEncoder(w).Encode(resps)
+	} else {
+		json.NewEncoder(w).Encode(resps[0])
+	}
+}
+
+func (f *fakeNode) call(req fakeRequest) (interface{}, *fakeError) {
+	switch req.Method {
+	case "eth_chainId":
+		return "0x1", nil
+	case "eth_blockNumber":
+		return hexutil.EncodeUint64(f.headNumber()), nil
+	case "eth_getBlockByNumber":
+		var number hexutil.Uint64
+		if err := json.Unmarshal(req.Params[0], &number); err != nil {
+			number = hexutil.Uint64(f.taggedNumber(req.Params[0]))
+		}
+		block, ok := f.blocks[uint64(number)]
+		if !ok || uint64(number) > f.headNumber() {
+			return nil, nil
+		}
+		return marshalBlock(block), nil
+	case "eth_getBlockByHash":
+		var hash common.Hash
+		json.Unmarshal(req.Params[0], &hash)
+		for number, block := range f.blocks {
+			if block.Hash() == hash && number <= f.headNumber() {
+				return marshalBlock(block), nil
+			}
+		}
+		return nil, nil
+	case "eth_getTransactionByHash":
+		var hash common.Hash
+		json.Unmarshal(req.Params[0], &hash)
+		receipt, ok := f.receipts[hash]
+		if !ok || receipt.BlockNumber.Uint64() > f.headNumber() {
+			return nil, nil
+		}
+		return marshalTransaction(f.blocks[receipt.BlockNumber.Uint64()], receipt.TransactionIndex), nil
+	case "eth_getTransactionReceipt":
+		var hash common.Hash
+		json.Unmarshal(req.Params[0], &hash)
+		return f.receipts[hash], nil
+	case "eth_getBlockReceipts":
+		f.mu.Lock()
+		supported := f.blockReceipts
+		f.mu.Unlock()
+		if !supported {
+			return nil, &fakeError{Code: methodNotFoundCode, Message: "the method eth_getBlockReceipts does not exist/is not available"}
+		}
+
+		var param string
+		json.Unmarshal(req.Params[0], &param)
+		block := f.blocks[fakeChainBlocks]
+		for _, b := range f.blocks {
+			if b.Hash().Hex() == param {
+				block = b
+			}
+		}
+		receipts := make([]*types.Receipt, 0)
+		for _, tx := range block.Transactions() {
+			receipts = append(receipts, f.receipts[tx.Hash()])
+		}
+		return receipts, nil
+	case "debug_traceBlockByHash":
+		var hash common.Hash
+		json.Unmarshal(req.Params[0], &hash)
+		return f.debugTraces(hash), nil
+	case "trace_block":
+		var number hexutil.Uint64
+		json.Unmarshal(req.Params[0], &number)
+		return f.parityTraces(uint64(number)), nil
+	default:
+		return nil, &fakeError{Code: methodNotFoundCode, Message: "method not found"}
+	}
+}
+
+// taggedNumber resolves a block tag, with the safe head one block and the
+// finalized head two blocks behind the latest
+func (f *fakeNode) taggedNumber(param json.RawMessage) uint64 {
+	var tag string
+	json.Unmarshal(param, &tag)
+
+	head := f.headNumber()
+	behind := uint64(0)
+	switch tag {
+	case "safe":
+		behind = 1
+	case "finalized":
+		behind = 2
+	}
+	if head < behind {
+		return 0
+	}
+	return head - behind
+}
+
+// marshalBlock encodes block the way eth_getBlockByNumber returns it with
+// full transactions
+func marshalBlock(block *types.Block) map[string]interface{} {
+	var fields map[string]interface{}
+	data, _ := json.Marshal(block.Header())
+	json.Unmarshal(data, &fields)
+
+	fields["hash"] = block.Hash()
+	fields["transactions"] = block.Transactions()
+	fields["uncles"] = []common.Hash{}
+	return fields
+}
+
+// headNumber returns the number of the head block
+func (f *fakeNode) headNumber() uint64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.head
+}
+
+// produce moves the head to the next block and streams it to subscribers
+func (f *fakeNode) produce() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.head == fakeChainBlocks+fakeFutureBlocks {
+		panic("fake node ran out of future blocks")
+	}
+	f.head++
+	for ch := range f.subscribers {
+		ch <- f.blocks[f.head].Header()
+	}
+}
+
+// fakeEthAPI serves eth_subscribe("newHeads") for the fake node
+type fakeEthAPI struct {
+	node *fakeNode
+}
+
+// NewHeads streams the heads the node produces
+func (api *fakeEthAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+
+	heads := make(chan *types.Header, fakeFutureBlocks)
+	api.node.mu.Lock()
+	api.node.subscribers[heads] = struct{}{}
+	api.node.mu.Unlock()
+
+	go func() {
+		defer func() {
+			api.node.mu.Lock()
+			delete(api.node.subscribers, heads)
+			api.node.mu.Unlock()
+		}()
+
+		for {
+			select {
+			case header := <-heads:
+				notifier.Notify(sub.ID, header)
+			case <-sub.Err():
+				return
+			}
+		}
+	}()
+
+	return sub, nil
+}
+
+// wsURL returns the WebSocket endpoint of the node
+func (f *fakeNode) wsURL() string {
+	return "ws" + strings.TrimPrefix(f.URL, "http") + "/ws"
+}
+
+// marshalTransaction encodes the transaction at index of block the way
+// eth_getTransactionByHash returns it
+func marshalTransaction(block *types.Block, index uint) map[string]interface{} {
+	tx := block.Transactions()[index]
+
+	var fields map[string]interface{}
+	data, _ := json.Marshal(tx)
+	json.Unmarshal(data, &fields)
+
+	from, _ := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	fields["from"] = from
+	fields["blockHash"] = block.Hash()
+	fields["blockNumber"] = hexutil.EncodeBig(block.Number())
+	fields["transactionIndex"] = hexutil.EncodeUint64(uint64(index))
+	return fields
+}
+
+// requestCount returns the number of HTTP requests that carried method
+func (f *fakeNode) requestCount(method string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.requests[method]
+}
+
+// callCount returns the number of calls of method, batched or not
+func (f *fakeNode) callCount(method string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls[method]
+}
+
+func newFakeNodeAdapter(t *testing.T, node *fakeNode) *Adapter {
+	t.Helper()
+
+	config := DefaultConfig()
+	config.ChainID = "ethereum-test"
+	config.RPCEndpoints = []string{node.URL}
+	config.RetryDelay = 10 * time.Millisecond
+
+	adapter, err := NewAdapter(config)
+	if err != nil {
+		t.Fatalf("NewAdapter() error = %v", err)
+	}
+	t.Cleanup(func() { adapter.Disconnect() })
+
+	return adapter
+}
+
+// fetchRequests returns the HTTP requests spent fetching blocks and receipts
+func fetchRequests(node *fakeNode) int {
+	return node.requestCount("eth_getBlockByNumber") +
+		node.requestCount("eth_getBlockReceipts") +
+		node.requestCount("eth_getTransactionReceipt")
+}
+
+func checkFakeBlocks(t *testing.T, blocks []*models.Block) {
+	t.Helper()
+
+	if len(blocks) != fakeChainBlocks {
+		t.Fatalf("got %d blocks, want %d", len(blocks), fakeChainBlocks)
+	}
+	for i, block := range blocks {
+		if block.Number != uint64(i+1) {
+			t.Fatalf("block %d has number %d", i, block.Number)
+		}
+		if len(block.Transactions) != fakeChainTxs {
+			t.Fatalf("block %d has %d transactions, want %d", block.Number, len(block.Transactions), fakeChainTxs)
+		}
+		for _, tx := range block.Transactions {
+			if tx.Status != models.TxStatusSuccess || tx.GasUsed != 21000 {
+				t.Fatalf("tx %s has status %v and gas used %d, want the receipt applied", tx.Hash, tx.Status, tx.GasUsed)
+			}
+		}
+	}
+}
+
+func TestAdapter_GetBlocksUsesBlockReceipts(t *testing.T) {
+	node := newFakeNode(t, true)
+	adapter := newFakeNodeAdapter(t, node)
+
+	if !adapter.blockReceipts.Load() {
+		t.Fatal("eth_getBlockReceipts support was not detected")
+	}
+
+	blocks, err := adapter.GetBlocks(context.Background(), 1, fakeChainBlocks)
+	if err != nil {
+		t.Fatalf("GetBlocks() error = %v", err)
+	}
+	checkFakeBlocks(t, blocks)
+
+	// One request per block and per transaction without batching
+	unbatched := fakeChainBlocks * (1 + fakeChainTxs)
+	if got := fetchRequests(node); got*10 > unbatched {
+		t.Errorf("fetching %d blocks took %d requests, want at most %d", fakeChainBlocks, got, unbatched/10)
+	}
+	if got := node.callCount("eth_getTransactionReceipt"); got != 0 {
+		t.Errorf("eth_getTransactionReceipt called %d times, want 0", got)
+	}
+}
+
+func TestAdapter_GetBlocksFallsBackToTransactionReceipts(t *testing.T) {
+	node := newFakeNode(t, false)
+	adapter := newFakeNodeAdapter(t, node)
+
+	if adapter.blockReceipts.Load() {
+		t.Fatal("eth_getBlockReceipts reported as supported")
+	}
+
+	blocks, err := adapter.GetBlocks(context.Background(), 1, fakeChainBlocks)
+	if err != nil {
+		t.Fatalf("GetBlocks() error = %v", err)
+	}
+	checkFakeBlocks(t, blocks)
+
+	unbatched := fakeChainBlocks * (1 + fakeChainTxs)
+	if got := fetchRequests(node); got*10 > unbatched {
+		t.Errorf("fetching %d blocks took %d requests, want at most %d", fakeChainBlocks, got, unbatched/10)
+	}
+	if got := node.callCount("eth_getTransactionReceipt"); got != fakeChainBlocks*fakeChainTxs {
+		t.Errorf("eth_getTransactionReceipt called %d times, want %d", got, fakeChainBlocks*fakeChainTxs)
+	}
+}
+
+func TestAdapter_FetchReceiptsDisablesBlockReceipts(t *testing.T) {
+	node := newFakeNode(t, true)
+	adapter := newFakeNodeAdapter(t, node)
+
+	// A failover endpoint may not serve the method
+	node.mu.Lock()
+	node.blockReceipts = false
+	node.mu.Unlock()
+
+	block, err := adapter.GetBlockByNumber(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetBlockByNumber() error = %v", err)
+	}
+	if tx := block.Transactions[0]; tx.Status != models.TxStatusSuccess {
+		t.Errorf("tx status = %v, want the receipt applied", tx.Status)
+	}
+	if adapter.blockReceipts.Load() {
+		t.Error("eth_getBlockReceipts should be disabled after the node rejected it")
+	}
+}
+
+func TestAdapter_GetBlocksWithoutBatching(t *testing.T) {
+	node := newFakeNode(t, false)
+
+	config := DefaultConfig()
+	config.ChainID = "ethereum-test"
+	config.RPCEndpoints = []string{node.URL}
+	config.EnableBatchRequest = false
+
+	adapter, err := NewAdapter(config)
+	if err != nil {
+		t.Fatalf("NewAdapter() error = %v", err)
+	}
+	defer adapter.Disconnect()
+
+	blocks, err := adapter.GetBlocks(context.Background(), 1, 10)
+	if err != nil {
+		t.Fatalf("GetBlocks() error = %v", err)
+	}
+	if len(blocks) != 10 {
+		t.Fatalf("got %d blocks, want 10", len(blocks))
+	}
+	if got := node.requestCount("eth_getTransactionReceipt"); got != 10*fakeChainTxs {
+		t.Errorf("eth_getTransactionReceipt requests = %d, want %d", got, 10*fakeChainTxs)
+	}
+}
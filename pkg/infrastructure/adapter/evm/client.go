@@ -2,8 +2,10 @@ package evm
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"net/http"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -13,6 +15,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
+	pool "github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/endpoint"
 )
 
 // Client wraps Ethereum RPC client with connection management and retry logic
@@ -20,7 +23,7 @@ type Client struct {
 	config     *Config
 	clients    []*ethclient.Client
 	rpcClients []*rpc.Client
-	current    uint32 // atomic counter for round-robin
+	pool       *pool.Pool
 	mu         sync.RWMutex
 	connected  bool
 	lastError  error
@@ -53,6 +56,16 @@ func NewClient(config *Config) (*Client, error) {
 	return client, nil
 }
 
+// dial creates an RPC client for an endpoint, sending HTTP requests through
+// the configured transport if any
+func (c *Client) dial(ctx context.Context, endpoint string) (*rpc.Client, error) {
+	if c.config.Transport == nil {
+		return rpc.DialContext(ctx, endpoint)
+	}
+
+	return rpc.DialOptions(ctx, endpoint, rpc.WithHTTPClient(&http.Client{Transport: c.config.Transport}))
+}
+
 // connect establishes connections to all RPC endpoints
 func (c *Client) connect() error {
 	c.mu.Lock()
@@ -65,13 +78,14 @@ func (c *Client) connect() error {
 
 	clients := make([]*ethclient.Client, 0, len(endpoints))
 	rpcClients := make([]*rpc.Client, 0, len(endpoints))
+	urls := make([]string, 0, len(endpoints))
 
 	for _, endpoint := range endpoints {
 		ctx, cancel := context.WithTimeout(context.Background(), c.config.ConnectionTimeout)
 		defer cancel()
 
 		// Create RPC client
-		rpcClient, err := rpc.DialContext(ctx, endpoint)
+		rpcClient, err := c.dial(ctx, endpoint)
 		if err != nil {
 			continue
 		}
@@ -87,14 +101,27 @@ func (c *Client) connect() error {
 
 		clients = append(clients, ethClient)
 		rpcClients = append(rpcClients, rpcClient)
+		urls = append(urls, endpoint)
 	}
 
 	if len(clients) == 0 {
 		return fmt.Errorf("failed to connect to any RPC endpoint")
 	}
 
+	// The pool indexes the connected endpoints in the same order as clients
+	poolConfig := pool.DefaultConfig(c.config.ChainID)
+	poolConfig.MaxLag = c.config.MaxEndpointLag
+	endpointPool, err := pool.NewPool(urls, poolConfig, c.probeBlockNumber)
+	if err != nil {
+		for _, rpcClient := range rpcClients {
+			rpcClient.Close()
+		}
+		return fmt.Errorf("failed to create endpoint pool: %w", err)
+	}
+
 	c.clients = clients
 	c.rpcClients = rpcClients
+	c.pool = endpointPool
 	c.connected = true
 	c.errorCount = 0
 	c.lastError = nil
@@ -102,21 +129,60 @@ func (c *Client) connect() error {
 	return nil
 }
 
-// getClient returns a client using round-robin selection
-func (c *Client) getClient() *ethclient.Client {
+// clientsFor returns the connections of a pool endpoint
+func (c *Client) clientsFor(e *pool.Endpoint) (*ethclient.Client, *rpc.Client, error) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	if len(c.clients) == 0 {
-		return nil
+	if e.Index >= len(c.clients) {
+		return nil, nil, pool.Permanent(fmt.Errorf("no available clients"))
 	}
 
-	idx := atomic.AddUint32(&c.current, 1) % uint32(len(c.clients))
-	return c.clients[idx]
+	return c.clients[e.Index], c.rpcClients[e.Index], nil
 }
 
-// executeWithRetry executes a function with retry logic
+// probeBlockNumber returns the latest block number of a single endpoint for
+// the pool's head tracking
+func (c *Client) probeBlockNumber(ctx context.Context, e *pool.Endpoint) (uint64, error) {
+	client, _, err := c.clientsFor(e)
+	if err != nil {
+		return 0, err
+	}
+
+	return client.BlockNumber(ctx)
+}
+
+// executeWithRetry executes a function against the healthiest endpoint with
+// retry logic
 func (c *Client) executeWithRetry(ctx context.Context, fn func(context.Context, *ethclient.Client) error) error {
+	return c.execute(ctx, func(ctx context.Context, e *pool.Endpoint) error {
+		client, _, err := c.clientsFor(e)
+		if err != nil {
+			return err
+		}
+		return fn(ctx, client)
+	})
+}
+
+// executeRPCWithRetry executes a raw JSON-RPC call against the healthiest
+// endpoint with retry logic
+func (c *Client) executeRPCWithRetry(ctx context.Context, fn func(context.Context, *rpc.Client) error) error {
+	return c.execute(ctx, func(ctx context.Context, e *pool.Endpoint) error {
+		_, rpcClient, err := c.clientsFor(e)
+		if err != nil {
+			return err
+		}
+		return fn(ctx, rpcClient)
+	})
+}
+
+// execute runs fn through the endpoint pool, which fails over to the next
+// healthiest endpoint, and retries with backoff while the error is retryable
+func (c *Client) execute(ctx context.Context, fn func(context.Context, *pool.Endpoint) error) error {
+	if c.pool == nil {
+		return fmt.Errorf("no available clients")
+	}
+
 	atomic.AddUint64(&c.requestCount, 1)
 	start := time.Now()
 
@@ -136,14 +202,18 @@ func (c *Client) executeWithRetry(ctx context.Context, fn func(context.Context,
 			}
 		}
 
-		client := c.getClient()
-		if client == nil {
-			return fmt.Errorf("no available clients")
-		}
+		err := c.pool.Do(ctx, func(ctx context.Context, e *pool.Endpoint) error {
+			reqCtx, cancel := context.WithTimeout(ctx, c.config.RequestTimeout)
+			defer cancel()
 
-		reqCtx, cancel := context.WithTimeout(ctx, c.config.RequestTimeout)
-		err := fn(reqCtx, client)
-		cancel()
+			err := fn(reqCtx, e)
+			// Another endpoint would answer the same, such as for an
+			// unknown block
+			if c.isNodeError(err) && !c.isRetryableError(err) {
+				return pool.Permanent(err)
+			}
+			return err
+		})
 
 		if err == nil {
 			atomic.AddUint64(&c.successCount, 1)
@@ -166,6 +236,17 @@ func (c *Client) executeWithRetry(ctx context.Context, fn func(context.Context,
 	return lastErr
 }
 
+// isNodeError checks if an error was returned by the node rather than caused
+// by the connection to it
+func (c *Client) isNodeError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	var rpcErr rpc.Error
+	return errors.Is(err, ethereum.NotFound) || errors.As(err, &rpcErr)
+}
+
 // isRetryableError checks if an error is retryable
 func (c *Client) isRetryableError(err error) bool {
 	if err == nil {
@@ -282,15 +363,52 @@ func (c *Client) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]
 	return logs, err
 }
 
-// SubscribeNewHead subscribes to new block headers
+// SubscribeNewHead subscribes to new block headers over the first reachable
+// WebSocket endpoint, or over an RPC endpoint when none is configured
 func (c *Client) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
-	client := c.getClient()
-	if client == nil {
+	var lastErr error
+	for _, endpoint := range c.config.WSEndpoints {
+		rpcClient, err := rpc.DialContext(ctx, endpoint)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		sub, err := ethclient.NewClient(rpcClient).SubscribeNewHead(ctx, ch)
+		if err != nil {
+			rpcClient.Close()
+			lastErr = err
+			continue
+		}
+
+		return &wsSubscription{Subscription: sub, client: rpcClient}, nil
+	}
+	if lastErr != nil {
+		return nil, fmt.Errorf("failed to subscribe on any websocket endpoint: %w", lastErr)
+	}
+
+	if c.pool == nil {
 		return nil, fmt.Errorf("no available clients")
 	}
+	client, _, err := c.clientsFor(c.pool.Select())
+	if err != nil {
+		return nil, err
+	}
 	return client.SubscribeNewHead(ctx, ch)
 }
 
+// wsSubscription is a subscription that owns its WebSocket connection
+type wsSubscription struct {
+	ethereum.Subscription
+	client *rpc.Client
+}
+
+// Unsubscribe cancels the subscription and closes its connection
+func (s *wsSubscription) Unsubscribe() {
+	s.Subscription.Unsubscribe()
+	s.client.Close()
+}
+
 // SyncProgress retrieves the current synchronization progress
 func (c *Client) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, error) {
 	var progress *ethereum.SyncProgress
@@ -305,9 +423,8 @@ func (c *Client) SyncProgress(ctx context.Context) (*ethereum.SyncProgress, erro
 // PeerCount returns the number of connected peers
 func (c *Client) PeerCount(ctx context.Context) (uint64, error) {
 	var count uint64
-	err := c.executeWithRetry(ctx, func(ctx context.Context, client *ethclient.Client) error {
+	err := c.executeRPCWithRetry(ctx, func(ctx context.Context, rpcClient *rpc.Client) error {
 		// Call RPC method directly
-		rpcClient := c.rpcClients[atomic.LoadUint32(&c.current)%uint32(len(c.rpcClients))]
 		var hexCount string
 		err := rpcClient.CallContext(ctx, &hexCount, "net_peerCount")
 		if err != nil {
@@ -369,15 +486,20 @@ func (c *Client) HealthStatus(ctx context.Context) (*HealthStatus, error) {
 // Close closes all client connections
 func (c *Client) Close() error {
 	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	for _, client := range c.rpcClients {
-		client.Close()
-	}
-
+	endpointPool := c.pool
+	rpcClients := c.rpcClients
 	c.clients = nil
 	c.rpcClients = nil
 	c.connected = false
+	c.mu.Unlock()
+
+	// The pool's probes take mu, so it is closed without holding it
+	if endpointPool != nil {
+		endpointPool.Close()
+	}
+	for _, client := range rpcClients {
+		client.Close()
+	}
 
 	return nil
 }
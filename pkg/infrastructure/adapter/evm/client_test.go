@@ -0,0 +1,35 @@
+package evm
+
+import (
+	"testing"
+	"time"
+)
+
+func TestClient_CloseWhileProbing(t *testing.T) {
+	node := newFakeNode(t, true)
+
+	config := DefaultConfig()
+	config.ChainID = "ethereum-test"
+	config.RPCEndpoints = []string{node.URL, node.URL}
+
+	// The pool probes the endpoints as soon as it is created, so closing
+	// right after connecting races with the probes
+	for i := 0; i < 50; i++ {
+		client, err := NewClient(config)
+		if err != nil {
+			t.Fatalf("NewClient() error = %v", err)
+		}
+
+		closed := make(chan struct{})
+		go func() {
+			client.Close()
+			close(closed)
+		}()
+
+		select {
+		case <-closed:
+		case <-time.After(5 * time.Second):
+			t.Fatal("Close() did not return while the pool was probing")
+		}
+	}
+}
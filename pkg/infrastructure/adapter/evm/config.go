@@ -1,9 +1,21 @@
 package evm
 
 import (
+	"net/http"
 	"time"
 )
 
+// Trace APIs for internal transactions
+const (
+	// TraceAPIDebug traces blocks with debug_traceBlockByHash and geth's
+	// callTracer
+	TraceAPIDebug = "debug"
+
+	// TraceAPIParity traces blocks with the Parity-style trace_block, as
+	// served by Erigon, Nethermind and Reth
+	TraceAPIParity = "parity"
+)
+
 // Config represents EVM adapter configuration
 type Config struct {
 	// Chain configuration
@@ -15,12 +27,20 @@ type Config struct {
 	RPCEndpoints []string
 	WSEndpoints  []string
 
+	// Blocks an endpoint may lag behind the others before it is quarantined
+	MaxEndpointLag uint64
+
 	// Connection settings
 	MaxConnections    int
 	ConnectionTimeout time.Duration
 	RequestTimeout    time.Duration
 	IdleTimeout       time.Duration
 
+	// Transport, if set, carries the HTTP JSON-RPC requests, e.g. to record
+	// or replay them with the transport package. WebSocket subscriptions
+	// do not use it.
+	Transport http.RoundTripper
+
 	// Retry settings
 	MaxRetries    int
 	RetryDelay    time.Duration
@@ -39,6 +59,8 @@ type Config struct {
 	MaxBlockRange        uint64 // max blocks per batch request
 	EnableReceiptFetch   bool
 	EnableTraceAPI       bool
+	TraceAPI             string // debug (callTracer) or parity (trace_block)
+	TraceBatchSize       int    // blocks traced per batch request
 
 	// Subscription settings
 	EnableWebSocket        bool
@@ -85,6 +107,9 @@ type Config struct {
 // DefaultConfig returns default EVM adapter configuration
 func DefaultConfig() *Config {
 	return &Config{
+		// Endpoint pool
+		MaxEndpointLag: 10,
+
 		// Connection settings
 		MaxConnections:    10,
 		ConnectionTimeout: 30 * time.Second,
@@ -109,6 +134,8 @@ func DefaultConfig() *Config {
 		MaxBlockRange:        1000,
 		EnableReceiptFetch:   true,
 		EnableTraceAPI:       false,
+		TraceAPI:             TraceAPIDebug,
+		TraceBatchSize:       10,
 
 		// Subscription settings
 		EnableWebSocket:        true,
@@ -178,6 +205,10 @@ func (c *Config) Validate() error {
 		return &ConfigError{Field: "RetryBackoff", Message: "retry backoff must be greater than 1.0"}
 	}
 
+	if c.EnableTraceAPI && c.TraceAPI != TraceAPIDebug && c.TraceAPI != TraceAPIParity {
+		return &ConfigError{Field: "TraceAPI", Message: "trace API must be debug or parity"}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,41 @@
+package evm
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/service"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/adaptertest"
+)
+
+func TestAdapter_Conformance(t *testing.T) {
+	node := newFakeNode(t, true)
+
+	adaptertest.RunConformance(t, func(t *testing.T) service.ChainAdapter {
+		config := DefaultConfig()
+		config.ChainID = "ethereum-test"
+		config.RPCEndpoints = []string{node.URL}
+		config.WSEndpoints = []string{node.wsURL()}
+		config.EnableWebSocket = true
+		config.RetryDelay = 10 * time.Millisecond
+
+		adapter, err := NewAdapter(config)
+		if err != nil {
+			t.Fatalf("NewAdapter() error = %v", err)
+		}
+		t.Cleanup(func() { adapter.Disconnect() })
+
+		return adapter
+	}, adaptertest.Backend{
+		ChainType:        models.ChainTypeEVM,
+		ChainID:          "ethereum-test",
+		First:            1,
+		Last:             5,
+		TxHash:           node.blocks[3].Transactions()[2].Hash().Hex(),
+		UnknownBlockHash: "0x" + strings.Repeat("ab", 32),
+		UnknownTxHash:    "0x" + strings.Repeat("cd", 32),
+		Produce:          node.produce,
+	})
+}
@@ -0,0 +1,94 @@
+package evm
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/transport"
+)
+
+// newTransportAdapter creates an adapter whose requests go through rt
+func newTransportAdapter(t *testing.T, chainID, network, url string, rt http.RoundTripper) *Adapter {
+	t.Helper()
+
+	config := DefaultConfig()
+	config.ChainID = chainID
+	config.Network = network
+	config.RPCEndpoints = []string{url}
+	config.RetryDelay = 10 * time.Millisecond
+	config.Transport = rt
+
+	adapter, err := NewAdapter(config)
+	if err != nil {
+		t.Fatalf("NewAdapter() error = %v", err)
+	}
+	t.Cleanup(func() { adapter.Disconnect() })
+
+	return adapter
+}
+
+func TestAdapter_RecordAndReplay(t *testing.T) {
+	ctx := context.Background()
+	node := newFakeNode(t, true)
+
+	// Record blocks 10 to 12 from the fake node
+	recorder := transport.NewRecorder(nil)
+	adapter := newTransportAdapter(t, "ethereum-test", "mainnet", node.URL, recorder)
+	blocks, err := transport.FetchBlocks(ctx, adapter, 10, 12)
+	if err != nil {
+		t.Fatalf("FetchBlocks() error = %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "ethereum-test-10-12.json")
+	fixture := &transport.Fixture{ChainType: "evm", ChainID: "ethereum-test", Network: "mainnet", StartBlock: 10, EndBlock: 12, Interactions: recorder.Interactions()}
+	if err := fixture.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := transport.WriteGolden(transport.GoldenPath(path), blocks); err != nil {
+		t.Fatalf("WriteGolden() error = %v", err)
+	}
+
+	// Replay them with the node gone
+	node.Close()
+	checkGoldenFixture(t, path)
+}
+
+// TestGoldenFixtures replays the fixtures recorded with `blockchain-indexer
+// fixtures record` and compares the normalized blocks with their golden files
+func TestGoldenFixtures(t *testing.T) {
+	paths, err := transport.Fixtures(filepath.Join("testdata", "fixtures"))
+	if err != nil {
+		t.Fatalf("Fixtures() error = %v", err)
+	}
+	if len(paths) == 0 {
+		t.Fatal("no fixtures in testdata/fixtures")
+	}
+
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			checkGoldenFixture(t, path)
+		})
+	}
+}
+
+func checkGoldenFixture(t *testing.T, path string) {
+	t.Helper()
+
+	fixture, err := transport.LoadFixture(path)
+	if err != nil {
+		t.Fatalf("LoadFixture() error = %v", err)
+	}
+
+	adapter := newTransportAdapter(t, fixture.ChainID, fixture.Network, "http://replay.invalid", transport.NewReplayer(fixture))
+	blocks, err := transport.FetchBlocks(context.Background(), adapter, fixture.StartBlock, fixture.EndBlock)
+	if err != nil {
+		t.Fatalf("FetchBlocks() error = %v", err)
+	}
+
+	if err := transport.CheckGolden(transport.GoldenPath(path), blocks); err != nil {
+		t.Error(err)
+	}
+}
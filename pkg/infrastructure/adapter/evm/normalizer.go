@@ -4,8 +4,10 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math/big"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
 )
@@ -40,12 +42,14 @@ func (n *Normalizer) NormalizeBlock(block *types.Block, receipts []*types.Receip
 
 	// Normalize transactions
 	transactions := make([]*models.Transaction, 0, len(block.Transactions()))
+	txHashes := make([]string, 0, len(block.Transactions()))
 	for i, tx := range block.Transactions() {
 		domainTx, err := n.NormalizeTransaction(tx, block, receiptsMap[tx.Hash()], uint64(i))
 		if err != nil {
 			return nil, fmt.Errorf("failed to normalize transaction %s: %w", tx.Hash().Hex(), err)
 		}
 		transactions = append(transactions, domainTx)
+		txHashes = append(txHashes, domainTx.Hash)
 	}
 
 	// Build metadata
@@ -72,6 +76,7 @@ func (n *Normalizer) NormalizeBlock(block *types.Block, receipts []*types.Receip
 		ParentHash:   block.ParentHash().Hex(),
 		Timestamp:    models.NewTimestamp(int64(block.Time())),
 		TxCount:      len(transactions),
+		TxHashes:     txHashes,
 		Transactions: transactions,
 		Size:         block.Size(),
 		GasLimit:     block.GasLimit(),
@@ -152,20 +157,6 @@ func (n *Normalizer) NormalizeTransaction(
 			metadata["contract_address"] = receipt.ContractAddress.Hex()
 		}
 
-		// Add logs
-		if len(receipt.Logs) > 0 {
-			logs := make([]map[string]interface{}, 0, len(receipt.Logs))
-			for _, log := range receipt.Logs {
-				logData := map[string]interface{}{
-					"address": log.Address.Hex(),
-					"topics":  formatTopics(log.Topics),
-					"data":    "0x" + hex.EncodeToString(log.Data),
-					"index":   log.Index,
-				}
-				logs = append(logs, logData)
-			}
-			metadata["logs"] = logs
-		}
 	}
 
 	// Signature components
@@ -205,18 +196,260 @@ func (n *Normalizer) NormalizeTransaction(
 		Status:      status,
 		Input:       tx.Data(),
 		Timestamp:   models.NewTimestamp(int64(block.Time())),
+		Logs:        make([]*models.Log, 0),
 		Metadata:    metadata,
 	}
 
+	if receipt != nil {
+		domainTx.Logs = normalizeLogs(receipt.Logs)
+	}
+
 	return domainTx, nil
 }
 
+// normalizeLogs converts receipt logs to domain logs, keeping the
+// block-wide log index
+func normalizeLogs(logs []*types.Log) []*models.Log {
+	result := make([]*models.Log, 0, len(logs))
+	for _, log := range logs {
+		domainLog := models.NewLog(uint64(log.Index), log.Address.Hex())
+		domainLog.Topics = formatTopics(log.Topics)
+		domainLog.Data = log.Data
+		result = append(result, domainLog)
+	}
+	return result
+}
+
+// DecodeTokenTransfers decodes the ERC-20, ERC-721 and ERC-1155 transfer
+// events among the logs of tx. ERC-20 and ERC-721 share the Transfer event
+// and are told apart by whether the amount or the token ID is indexed;
+// events that follow neither layout are skipped.
+func (n *Normalizer) DecodeTokenTransfers(tx *models.Transaction) []*models.TokenTransfer {
+	transfers := make([]*models.TokenTransfer, 0)
+	for _, log := range tx.Logs {
+		if log == nil {
+			continue
+		}
+
+		for _, transfer := range decodeTransferLog(log) {
+			transfer.ChainID = tx.ChainID
+			transfer.Token = log.Address
+			transfer.BlockNumber = tx.BlockNumber
+			transfer.BlockHash = tx.BlockHash
+			transfer.TxHash = tx.Hash
+			transfer.TxIndex = tx.Index
+			transfer.LogIndex = log.Index
+			transfer.Timestamp = tx.Timestamp
+			transfers = append(transfers, transfer)
+		}
+	}
+	return transfers
+}
+
+// decodeTransferLog decodes the transfers of a single log, returning nil if
+// it is not a token transfer event
+func decodeTransferLog(log *models.Log) []*models.TokenTransfer {
+	if len(log.Topics) == 0 {
+		return nil
+	}
+
+	topics := make([]common.Hash, len(log.Topics))
+	for i, topic := range log.Topics {
+		topics[i] = common.HexToHash(topic)
+	}
+
+	switch topics[0] {
+	case transferEventTopic:
+		switch {
+		case len(topics) == 3 && len(log.Data) == 32:
+			return []*models.TokenTransfer{{
+				Standard: models.TokenStandardERC20,
+				From:     topicAddress(topics[1]),
+				To:       topicAddress(topics[2]),
+				Value:    new(big.Int).SetBytes(log.Data).String(),
+			}}
+		case len(topics) == 4 && len(log.Data) == 0:
+			return []*models.TokenTransfer{{
+				Standard: models.TokenStandardERC721,
+				From:     topicAddress(topics[1]),
+				To:       topicAddress(topics[2]),
+				Value:    "1",
+				TokenID:  topics[3].Big().String(),
+			}}
+		}
+
+	case transferSingleEventTopic:
+		if len(topics) != 4 || len(log.Data) != 64 {
+			return nil
+		}
+		return []*models.TokenTransfer{{
+			Standard: models.TokenStandardERC1155,
+			Operator: topicAddress(topics[1]),
+			From:     topicAddress(topics[2]),
+			To:       topicAddress(topics[3]),
+			TokenID:  new(big.Int).SetBytes(log.Data[:32]).String(),
+			Value:    new(big.Int).SetBytes(log.Data[32:]).String(),
+		}}
+
+	case transferBatchEventTopic:
+		if len(topics) != 4 {
+			return nil
+		}
+		ids, ok := decodeUint256Array(log.Data, 0)
+		if !ok {
+			return nil
+		}
+		values, ok := decodeUint256Array(log.Data, 32)
+		if !ok || len(values) != len(ids) {
+			return nil
+		}
+
+		transfers := make([]*models.TokenTransfer, len(ids))
+		for i := range ids {
+			transfers[i] = &models.TokenTransfer{
+				Standard:   models.TokenStandardERC1155,
+				Operator:   topicAddress(topics[1]),
+				From:       topicAddress(topics[2]),
+				To:         topicAddress(topics[3]),
+				TokenID:    ids[i].String(),
+				Value:      values[i].String(),
+				BatchIndex: uint64(i),
+			}
+		}
+		return transfers
+	}
+
+	return nil
+}
+
+// NormalizeCallFrame flattens the calls below the top-level call of a
+// transaction's callTracer trace into internal transactions in execution
+// order. Only calls that moved value, created or destroyed a contract, or
+// failed are kept; plain calls between contracts are left out.
+func (n *Normalizer) NormalizeCallFrame(frame *CallFrame) []*models.InternalTransaction {
+	internals := make([]*models.InternalTransaction, 0)
+	if frame == nil {
+		return internals
+	}
+
+	var walk func(frame *CallFrame, traceAddress []uint64)
+	walk = func(frame *CallFrame, traceAddress []uint64) {
+		for i, call := range frame.Calls {
+			if call == nil {
+				continue
+			}
+
+			position := append(append([]uint64{}, traceAddress...), uint64(i))
+			internal := &models.InternalTransaction{
+				Type:         models.CallType(strings.ToLower(call.Type)),
+				From:         call.From.Hex(),
+				Value:        formatBig(call.Value),
+				Gas:          uint64(call.Gas),
+				GasUsed:      uint64(call.GasUsed),
+				Input:        call.Input,
+				Error:        call.Error,
+				TraceAddress: position,
+			}
+			if call.To != nil {
+				internal.To = call.To.Hex()
+			}
+
+			if isNotableCall(internal) {
+				internals = append(internals, internal)
+			}
+			walk(call, position)
+		}
+	}
+	walk(frame, nil)
+
+	return internals
+}
+
+// NormalizeParityTraces converts the Parity-style traces of a block into
+// internal transactions keyed by transaction hash, keeping the same calls
+// as NormalizeCallFrame. Block rewards and the top-level calls are skipped.
+func (n *Normalizer) NormalizeParityTraces(traces []*ParityTrace) map[string][]*models.InternalTransaction {
+	internals := make(map[string][]*models.InternalTransaction)
+	for _, trace := range traces {
+		if trace == nil || trace.TransactionHash == nil || len(trace.TraceAddress) == 0 {
+			continue
+		}
+
+		internal := &models.InternalTransaction{
+			Error:        trace.Error,
+			TraceAddress: trace.TraceAddress,
+		}
+		action := trace.Action
+
+		switch trace.Type {
+		case "call":
+			internal.Type = models.CallType(action.CallType)
+			internal.From = action.From.Hex()
+			if action.To != nil {
+				internal.To = action.To.Hex()
+			}
+			internal.Value = formatBig(action.Value)
+			internal.Gas = uint64(action.Gas)
+			internal.Input = action.Input
+		case "create":
+			internal.Type = models.CallTypeCreate
+			internal.From = action.From.Hex()
+			internal.Value = formatBig(action.Value)
+			internal.Gas = uint64(action.Gas)
+			internal.Input = action.Init
+			if trace.Result != nil && trace.Result.Address != nil {
+				internal.To = trace.Result.Address.Hex()
+			}
+		case "suicide":
+			internal.Type = models.CallTypeSelfDestruct
+			if action.Address != nil {
+				internal.From = action.Address.Hex()
+			}
+			if action.RefundAddress != nil {
+				internal.To = action.RefundAddress.Hex()
+			}
+			internal.Value = formatBig(action.Balance)
+		default:
+			continue
+		}
+
+		if trace.Result != nil {
+			internal.GasUsed = uint64(trace.Result.GasUsed)
+		}
+
+		if isNotableCall(internal) {
+			hash := trace.TransactionHash.Hex()
+			internals[hash] = append(internals[hash], internal)
+		}
+	}
+
+	return internals
+}
+
+// isNotableCall reports whether an internal call is worth indexing: it moved
+// value, created or destroyed a contract, or failed
+func isNotableCall(internal *models.InternalTransaction) bool {
+	switch internal.Type {
+	case models.CallTypeCreate, models.CallTypeCreate2, models.CallTypeSelfDestruct:
+		return true
+	}
+	return internal.Value != "0" || internal.Failed()
+}
+
 // NormalizeEVMBlock and NormalizeEVMTransaction are not currently used
 // as we use go-ethereum's native types directly. They can be implemented
 // if needed for custom EVM block/transaction types in the future.
 
 // Helper functions
 
+// formatBig formats an optional hex quantity as a decimal string
+func formatBig(value *hexutil.Big) string {
+	if value == nil {
+		return "0"
+	}
+	return value.ToInt().String()
+}
+
 func formatUncles(uncles []*types.Header) []string {
 	if len(uncles) == 0 {
 		return []string{}
@@ -239,6 +472,45 @@ func formatTopics(topics []common.Hash) []string {
 	return result
 }
 
+// topicAddress returns the address held in an indexed address topic
+func topicAddress(topic common.Hash) string {
+	return common.BytesToAddress(topic.Bytes()).Hex()
+}
+
+// decodeUint256Array decodes an ABI-encoded uint256[] whose offset is held
+// in the head word at head, reporting false if data is malformed
+func decodeUint256Array(data []byte, head uint64) ([]*big.Int, bool) {
+	offset, ok := abiWord(data, head)
+	if !ok || !offset.IsUint64() {
+		return nil, false
+	}
+
+	length, ok := abiWord(data, offset.Uint64())
+	if !ok || !length.IsUint64() || length.Uint64() > uint64(len(data))/32 {
+		return nil, false
+	}
+
+	values := make([]*big.Int, length.Uint64())
+	start := offset.Uint64() + 32
+	for i := range values {
+		value, ok := abiWord(data, start+uint64(i)*32)
+		if !ok {
+			return nil, false
+		}
+		values[i] = value
+	}
+
+	return values, true
+}
+
+// abiWord returns the 32-byte word at offset in data
+func abiWord(data []byte, offset uint64) (*big.Int, bool) {
+	if offset > uint64(len(data)) || uint64(len(data))-offset < 32 {
+		return nil, false
+	}
+	return new(big.Int).SetBytes(data[offset : offset+32]), true
+}
+
 func formatAccessList(accessList types.AccessList) []map[string]interface{} {
 	if len(accessList) == 0 {
 		return []map[string]interface{}{}
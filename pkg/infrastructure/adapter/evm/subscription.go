@@ -1,6 +1,8 @@
 package evm
 
 import (
+	"sync"
+
 	"github.com/ethereum/go-ethereum"
 	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
 )
@@ -10,6 +12,8 @@ type blockSubscription struct {
 	sub       ethereum.Subscription
 	blockChan chan *models.Block
 	errChan   chan error
+	done      chan struct{}
+	once      sync.Once
 }
 
 // Channel returns the channel that receives new blocks
@@ -17,11 +21,14 @@ func (s *blockSubscription) Channel() <-chan *models.Block {
 	return s.blockChan
 }
 
-// Unsubscribe cancels the subscription
+// Unsubscribe cancels the subscription and closes its channels
 func (s *blockSubscription) Unsubscribe() {
-	if s.sub != nil {
-		s.sub.Unsubscribe()
-	}
+	s.once.Do(func() {
+		close(s.done)
+		if s.sub != nil {
+			s.sub.Unsubscribe()
+		}
+	})
 }
 
 // Err returns any subscription error
@@ -0,0 +1,67 @@
+package evm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// callTracerConfig selects geth's built-in call tracer
+var callTracerConfig = map[string]interface{}{"tracer": "callTracer"}
+
+// TraceBlocks traces every transaction of the blocks with the given hashes
+// with debug_traceBlockByHash and the callTracer, in batches of
+// TraceBatchSize blocks
+func (c *Client) TraceBlocks(ctx context.Context, hashes []common.Hash) ([][]*TxTrace, error) {
+	traces := make([][]*TxTrace, len(hashes))
+	elems := make([]rpc.BatchElem, len(hashes))
+	for i, hash := range hashes {
+		elems[i] = rpc.BatchElem{
+			Method: "debug_traceBlockByHash",
+			Args:   []interface{}{hash, callTracerConfig},
+			Result: &traces[i],
+		}
+	}
+
+	if err := c.batchCall(ctx, elems, c.config.TraceBatchSize); err != nil {
+		return nil, err
+	}
+
+	for i, blockTraces := range traces {
+		if blockTraces == nil {
+			return nil, fmt.Errorf("traces for block %s: %w", hashes[i].Hex(), ethereum.NotFound)
+		}
+	}
+
+	return traces, nil
+}
+
+// ParityTraceBlocks returns the Parity-style traces of the blocks with the
+// given numbers from trace_block, in batches of TraceBatchSize blocks
+func (c *Client) ParityTraceBlocks(ctx context.Context, numbers []uint64) ([][]*ParityTrace, error) {
+	traces := make([][]*ParityTrace, len(numbers))
+	elems := make([]rpc.BatchElem, len(numbers))
+	for i, number := range numbers {
+		elems[i] = rpc.BatchElem{
+			Method: "trace_block",
+			Args:   []interface{}{hexutil.EncodeUint64(number)},
+			Result: &traces[i],
+		}
+	}
+
+	if err := c.batchCall(ctx, elems, c.config.TraceBatchSize); err != nil {
+		return nil, err
+	}
+
+	for i, blockTraces := range traces {
+		if blockTraces == nil {
+			return nil, fmt.Errorf("traces for block %d: %w", numbers[i], ethereum.NotFound)
+		}
+	}
+
+	return traces, nil
+}
@@ -0,0 +1,258 @@
+package evm
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+)
+
+var (
+	fakeContract    = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	fakeRecipient   = common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	fakeOracle      = common.HexToAddress("0x00000000000000000000000000000000000000cc")
+	fakeTraceSigner = types.LatestSignerForChainID(big.NewInt(1))
+)
+
+// debugTraces returns the callTracer traces of the block with the given
+// hash. Every transaction calls fakeContract, which forwards one wei to
+// fakeRecipient and reads fakeOracle.
+func (f *fakeNode) debugTraces(hash common.Hash) []*TxTrace {
+	var block *types.Block
+	for _, b := range f.blocks {
+		if b.Hash() == hash {
+			block = b
+		}
+	}
+	if block == nil {
+		return nil
+	}
+
+	traces := make([]*TxTrace, 0)
+	for _, tx := range block.Transactions() {
+		from, _ := types.Sender(fakeTraceSigner, tx)
+		contract, recipient, oracle := fakeContract, fakeRecipient, fakeOracle
+		traces = append(traces, &TxTrace{
+			TxHash: tx.Hash(),
+			Result: &CallFrame{
+				Type:  "CALL",
+				From:  from,
+				To:    &contract,
+				Value: (*hexutil.Big)(big.NewInt(1)),
+				Gas:   21000,
+				Calls: []*CallFrame{
+					{Type: "CALL", From: contract, To: &recipient, Value: (*hexutil.Big)(big.NewInt(1)), Gas: 2300, Input: hexutil.Bytes{0xab}},
+					{Type: "STATICCALL", From: contract, To: &oracle, Gas: 5000, GasUsed: 2100},
+				},
+			},
+		})
+	}
+	return traces
+}
+
+// parityTraces returns the trace_block traces of the block with the given
+// number, describing the same calls as debugTraces
+func (f *fakeNode) parityTraces(number uint64) []*ParityTrace {
+	block, ok := f.blocks[number]
+	if !ok {
+		return nil
+	}
+
+	hash := block.Hash()
+	traces := make([]*ParityTrace, 0)
+	for _, tx := range block.Transactions() {
+		from, _ := types.Sender(fakeTraceSigner, tx)
+		txHash := tx.Hash()
+		contract, recipient, oracle := fakeContract, fakeRecipient, fakeOracle
+		traces = append(traces,
+			&ParityTrace{
+				Type:            "call",
+				Action:          ParityTraceAction{CallType: "call", From: from, To: &contract, Value: (*hexutil.Big)(big.NewInt(1)), Gas: 21000},
+				Result:          &ParityTraceResult{},
+				TraceAddress:    []uint64{},
+				BlockHash:       hash,
+				TransactionHash: &txHash,
+			},
+			&ParityTrace{
+				Type:            "call",
+				Action:          ParityTraceAction{CallType: "call", From: contract, To: &recipient, Value: (*hexutil.Big)(big.NewInt(1)), Gas: 2300, Input: hexutil.Bytes{0xab}},
+				Result:          &ParityTraceResult{},
+				TraceAddress:    []uint64{0},
+				BlockHash:       hash,
+				TransactionHash: &txHash,
+			},
+			&ParityTrace{
+				Type:            "call",
+				Action:          ParityTraceAction{CallType: "staticcall", From: contract, To: &oracle, Value: (*hexutil.Big)(big.NewInt(0)), Gas: 5000},
+				Result:          &ParityTraceResult{GasUsed: 2100},
+				TraceAddress:    []uint64{1},
+				BlockHash:       hash,
+				TransactionHash: &txHash,
+			},
+		)
+	}
+	traces = append(traces, &ParityTrace{
+		Type:         "reward",
+		Action:       ParityTraceAction{Value: (*hexutil.Big)(big.NewInt(2))},
+		TraceAddress: []uint64{},
+		BlockHash:    hash,
+	})
+	return traces
+}
+
+func TestNormalizer_NormalizeCallFrame(t *testing.T) {
+	normalizer := NewNormalizer("ethereum", "mainnet")
+
+	sender := common.HexToAddress("0x1111111111111111111111111111111111111111")
+	contract := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	created := common.HexToAddress("0x3333333333333333333333333333333333333333")
+	beneficiary := common.HexToAddress("0x4444444444444444444444444444444444444444")
+
+	frame := &CallFrame{
+		Type: "CALL",
+		From: sender,
+		To:   &contract,
+		Calls: []*CallFrame{
+			{Type: "DELEGATECALL", From: contract, To: &created, Gas: 1000},
+			{
+				Type:  "CREATE2",
+				From:  contract,
+				To:    &created,
+				Value: (*hexutil.Big)(big.NewInt(0)),
+				Input: []byte{0x60, 0x80},
+				Calls: []*CallFrame{
+					{Type: "CALL", From: created, To: &beneficiary, Value: (*hexutil.Big)(big.NewInt(7)), Error: "out of gas"},
+					{Type: "SELFDESTRUCT", From: created, To: &beneficiary, Value: (*hexutil.Big)(big.NewInt(5))},
+				},
+			},
+		},
+	}
+
+	internals := normalizer.NormalizeCallFrame(frame)
+
+	want := []*models.InternalTransaction{
+		{Type: models.CallTypeCreate2, From: contract.Hex(), To: created.Hex(), Value: "0", Input: []byte{0x60, 0x80}, TraceAddress: []uint64{1}},
+		{Type: models.CallTypeCall, From: created.Hex(), To: beneficiary.Hex(), Value: "7", Error: "out of gas", TraceAddress: []uint64{1, 0}},
+		{Type: models.CallTypeSelfDestruct, From: created.Hex(), To: beneficiary.Hex(), Value: "5", TraceAddress: []uint64{1, 1}},
+	}
+	if !reflect.DeepEqual(internals, want) {
+		for _, internal := range internals {
+			t.Logf("got %+v", internal)
+		}
+		t.Fatal("NormalizeCallFrame() returned unexpected internal transactions")
+	}
+
+	if got := normalizer.NormalizeCallFrame(nil); len(got) != 0 {
+		t.Errorf("NormalizeCallFrame(nil) = %v, want none", got)
+	}
+}
+
+func TestNormalizer_NormalizeParityTraces(t *testing.T) {
+	normalizer := NewNormalizer("ethereum", "mainnet")
+
+	txHash := common.HexToHash("0x01")
+	contract := common.HexToAddress("0x2222222222222222222222222222222222222222")
+	created := common.HexToAddress("0x3333333333333333333333333333333333333333")
+	beneficiary := common.HexToAddress("0x4444444444444444444444444444444444444444")
+
+	traces := []*ParityTrace{
+		{Type: "call", Action: ParityTraceAction{CallType: "call", From: beneficiary, To: &contract, Value: (*hexutil.Big)(big.NewInt(9))}, TraceAddress: []uint64{}, TransactionHash: &txHash},
+		{Type: "create", Action: ParityTraceAction{From: contract, Value: (*hexutil.Big)(big.NewInt(0)), Init: []byte{0x60}}, Result: &ParityTraceResult{GasUsed: 100, Address: &created}, TraceAddress: []uint64{0}, TransactionHash: &txHash},
+		{Type: "suicide", Action: ParityTraceAction{Address: &created, RefundAddress: &beneficiary, Balance: (*hexutil.Big)(big.NewInt(3))}, TraceAddress: []uint64{0, 0}, TransactionHash: &txHash},
+		{Type: "call", Action: ParityTraceAction{CallType: "staticcall", From: contract, To: &created, Value: (*hexutil.Big)(big.NewInt(0))}, TraceAddress: []uint64{1}, TransactionHash: &txHash},
+		{Type: "reward", Action: ParityTraceAction{Value: (*hexutil.Big)(big.NewInt(2))}, TraceAddress: []uint64{}},
+	}
+
+	internals := normalizer.NormalizeParityTraces(traces)
+
+	want := map[string][]*models.InternalTransaction{
+		txHash.Hex(): {
+			{Type: models.CallTypeCreate, From: contract.Hex(), To: created.Hex(), Value: "0", Input: []byte{0x60}, GasUsed: 100, TraceAddress: []uint64{0}},
+			{Type: models.CallTypeSelfDestruct, From: created.Hex(), To: beneficiary.Hex(), Value: "3", TraceAddress: []uint64{0, 0}},
+		},
+	}
+	if !reflect.DeepEqual(internals, want) {
+		for _, internal := range internals[txHash.Hex()] {
+			t.Logf("got %+v", internal)
+		}
+		t.Fatal("NormalizeParityTraces() returned unexpected internal transactions")
+	}
+}
+
+func TestAdapter_GetBlocksAttachesInternalTransactions(t *testing.T) {
+	for _, traceAPI := range []string{TraceAPIDebug, TraceAPIParity} {
+		t.Run(traceAPI, func(t *testing.T) {
+			node := newFakeNode(t, true)
+
+			config := DefaultConfig()
+			config.ChainID = "ethereum-test"
+			config.RPCEndpoints = []string{node.URL}
+			config.EnableTraceAPI = true
+			config.TraceAPI = traceAPI
+
+			adapter, err := NewAdapter(config)
+			if err != nil {
+				t.Fatalf("NewAdapter() error = %v", err)
+			}
+			defer adapter.Disconnect()
+
+			blocks, err := adapter.GetBlocks(context.Background(), 1, fakeChainBlocks)
+			if err != nil {
+				t.Fatalf("GetBlocks() error = %v", err)
+			}
+			checkFakeBlocks(t, blocks)
+
+			for _, block := range blocks {
+				for _, tx := range block.Transactions {
+					want := []*models.InternalTransaction{{
+						Type:         models.CallTypeCall,
+						From:         fakeContract.Hex(),
+						To:           fakeRecipient.Hex(),
+						Value:        "1",
+						Gas:          2300,
+						Input:        []byte{0xab},
+						TraceAddress: []uint64{0},
+					}}
+					if !reflect.DeepEqual(tx.InternalTransactions, want) {
+						t.Fatalf("tx %s has internal transactions %+v, want %+v", tx.Hash, tx.InternalTransactions, want)
+					}
+					if addresses := tx.InternalAddresses(); !reflect.DeepEqual(addresses, []string{fakeRecipient.Hex()}) {
+						t.Fatalf("tx %s has internal addresses %v", tx.Hash, addresses)
+					}
+				}
+			}
+
+			method := "debug_traceBlockByHash"
+			if traceAPI == TraceAPIParity {
+				method = "trace_block"
+			}
+			if got := node.callCount(method); got != fakeChainBlocks {
+				t.Errorf("%s called %d times, want %d", method, got, fakeChainBlocks)
+			}
+			if got := node.requestCount(method); got != fakeChainBlocks/config.TraceBatchSize {
+				t.Errorf("%s sent in %d requests, want %d", method, got, fakeChainBlocks/config.TraceBatchSize)
+			}
+		})
+	}
+}
+
+func TestAdapter_GetBlockByNumberWithoutTraceAPI(t *testing.T) {
+	node := newFakeNode(t, true)
+	adapter := newFakeNodeAdapter(t, node)
+
+	block, err := adapter.GetBlockByNumber(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetBlockByNumber() error = %v", err)
+	}
+	if internals := block.Transactions[0].InternalTransactions; internals != nil {
+		t.Errorf("InternalTransactions = %v, want none without a trace API", internals)
+	}
+	if got := node.callCount("debug_traceBlockByHash") + node.callCount("trace_block"); got != 0 {
+		t.Errorf("blocks traced %d times, want 0", got)
+	}
+}
@@ -4,9 +4,25 @@ import (
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
+// Token transfer event signatures
+var (
+	// transferEventTopic is Transfer(address,address,uint256), emitted by
+	// both ERC-20 and ERC-721 tokens
+	transferEventTopic = common.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+
+	// transferSingleEventTopic is the ERC-1155
+	// TransferSingle(address,address,address,uint256,uint256)
+	transferSingleEventTopic = common.HexToHash("0xc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f62")
+
+	// transferBatchEventTopic is the ERC-1155
+	// TransferBatch(address,address,address,uint256[],uint256[])
+	transferBatchEventTopic = common.HexToHash("0x4a39dc06d4c0dbc64b70af90fd698a233a518aa5d07e595d983b8c0526c8f7fb")
+)
+
 // EVMBlock represents an Ethereum-compatible block with all its data
 type EVMBlock struct {
 	Number           *big.Int
@@ -137,6 +153,62 @@ type Subscription struct {
 	ErrCh  chan error
 }
 
+// CallFrame is a call reported by geth's callTracer, with the calls it made
+// nested below it
+type CallFrame struct {
+	Type    string          `json:"type"` // CALL, DELEGATECALL, CREATE, SELFDESTRUCT, ...
+	From    common.Address  `json:"from"`
+	To      *common.Address `json:"to,omitempty"`
+	Value   *hexutil.Big    `json:"value,omitempty"`
+	Gas     hexutil.Uint64  `json:"gas"`
+	GasUsed hexutil.Uint64  `json:"gasUsed"`
+	Input   hexutil.Bytes   `json:"input"`
+	Error   string          `json:"error,omitempty"`
+	Calls   []*CallFrame    `json:"calls,omitempty"`
+}
+
+// TxTrace is the callTracer trace of one transaction of a traced block.
+// Nodes before geth 1.11 do not report the transaction hash.
+type TxTrace struct {
+	TxHash common.Hash `json:"txHash"`
+	Result *CallFrame  `json:"result"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// ParityTrace is a single call of a Parity-style trace_block response
+type ParityTrace struct {
+	Type            string             `json:"type"` // call, create, suicide or reward
+	Action          ParityTraceAction  `json:"action"`
+	Result          *ParityTraceResult `json:"result"`
+	Error           string             `json:"error,omitempty"`
+	TraceAddress    []uint64           `json:"traceAddress"`
+	BlockHash       common.Hash        `json:"blockHash"`
+	TransactionHash *common.Hash       `json:"transactionHash"`
+}
+
+// ParityTraceAction describes what a Parity-style trace did
+type ParityTraceAction struct {
+	CallType string          `json:"callType"` // call, delegatecall, staticcall or callcode
+	From     common.Address  `json:"from"`
+	To       *common.Address `json:"to"`
+	Value    *hexutil.Big    `json:"value"`
+	Gas      hexutil.Uint64  `json:"gas"`
+	Input    hexutil.Bytes   `json:"input"`
+	Init     hexutil.Bytes   `json:"init"` // creation code
+
+	// Self-destructed contract, the beneficiary of its balance and the
+	// balance itself
+	Address       *common.Address `json:"address"`
+	RefundAddress *common.Address `json:"refundAddress"`
+	Balance       *hexutil.Big    `json:"balance"`
+}
+
+// ParityTraceResult holds the outcome of a successful Parity-style trace
+type ParityTraceResult struct {
+	GasUsed hexutil.Uint64  `json:"gasUsed"`
+	Address *common.Address `json:"address"` // created contract
+}
+
 // RPCError represents an RPC error
 type RPCError struct {
 	Code    int
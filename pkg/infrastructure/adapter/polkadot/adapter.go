@@ -2,17 +2,25 @@ package polkadot
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
 	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/repository"
 	"github.com/sage-x-project/blockchain-indexer/pkg/domain/service"
 )
 
 // Ensure Adapter implements ChainAdapter interface
 var _ service.ChainAdapter = (*Adapter)(nil)
 
+// ExtrinsicIndex looks up indexed extrinsics by hash, which Substrate nodes
+// cannot do. The transaction repository implements it.
+type ExtrinsicIndex interface {
+	GetTransaction(ctx context.Context, chainID string, hash string) (*models.Transaction, error)
+}
+
 // Adapter implements the ChainAdapter interface for Polkadot/Substrate chains
 type Adapter struct {
 	config     *Config
@@ -21,6 +29,13 @@ type Adapter struct {
 	chainInfo  *models.ChainInfo
 	mu         sync.RWMutex
 	connected  bool
+
+	// Extrinsics indexed so far, for GetTransaction
+	extrinsics ExtrinsicIndex
+
+	// Event registries by runtime spec version
+	runtimes   map[uint32]*eventRuntime
+	runtimesMu sync.Mutex
 }
 
 // NewAdapter creates a new Polkadot chain adapter
@@ -34,7 +49,7 @@ func NewAdapter(config *Config) (*Adapter, error) {
 		return nil, fmt.Errorf("failed to create client: %w", err)
 	}
 
-	normalizer := NewNormalizer(config.ChainID, config.Network)
+	normalizer := NewNormalizer(config.ChainID, config.Network, config.SS58Format)
 
 	adapter := &Adapter{
 		config:     config,
@@ -42,6 +57,7 @@ func NewAdapter(config *Config) (*Adapter, error) {
 		normalizer: normalizer,
 		chainInfo:  normalizer.NormalizeChainInfo(),
 		connected:  false,
+		runtimes:   make(map[uint32]*eventRuntime),
 	}
 
 	// Verify connection
@@ -82,6 +98,22 @@ func (a *Adapter) GetChainInfo() *models.ChainInfo {
 	return a.chainInfo
 }
 
+// Capabilities reports the features the adapter supports. Extrinsics can
+// only be looked up by hash once an extrinsic index is set, and logs are
+// only attached when events are included.
+func (a *Adapter) Capabilities() models.Capabilities {
+	a.mu.RLock()
+	extrinsics := a.extrinsics
+	a.mu.RUnlock()
+
+	return models.Capabilities{
+		BlockByHash:       true,
+		TransactionByHash: extrinsics != nil,
+		Logs:              a.config.IncludeEvents,
+		FinalityTags:      true,
+	}
+}
+
 // GetLatestBlockNumber returns the latest finalized block number
 func (a *Adapter) GetLatestBlockNumber(ctx context.Context) (uint64, error) {
 	blockNumber, err := a.client.GetLatestBlockNumber(ctx)
@@ -92,6 +124,17 @@ func (a *Adapter) GetLatestBlockNumber(ctx context.Context) (uint64, error) {
 	return blockNumber, nil
 }
 
+// GetFinalityHeads returns the finalized head for both heads, since GRANDPA
+// has no separate safe head
+func (a *Adapter) GetFinalityHeads(ctx context.Context) (*models.FinalityHeads, error) {
+	finalized, err := a.GetLatestBlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.FinalityHeads{Safe: finalized, Finalized: finalized}, nil
+}
+
 // GetBlockByNumber fetches a block by number
 func (a *Adapter) GetBlockByNumber(ctx context.Context, number uint64) (*models.Block, error) {
 	// Fetch block
@@ -112,6 +155,10 @@ func (a *Adapter) GetBlockByNumber(ctx context.Context, number uint64) (*models.
 		return nil, fmt.Errorf("failed to normalize block %d: %w", number, err)
 	}
 
+	if err := a.applyEvents(ctx, domainBlock, blockHash); err != nil {
+		return nil, fmt.Errorf("failed to get events of block %d: %w", number, err)
+	}
+
 	return domainBlock, nil
 }
 
@@ -135,11 +182,19 @@ func (a *Adapter) GetBlockByHash(ctx context.Context, hash string) (*models.Bloc
 		return nil, fmt.Errorf("failed to normalize block %s: %w", hash, err)
 	}
 
+	if err := a.applyEvents(ctx, domainBlock, blockHash); err != nil {
+		return nil, fmt.Errorf("failed to get events of block %s: %w", hash, err)
+	}
+
 	return domainBlock, nil
 }
 
 // GetBlocks fetches multiple blocks in a range
 func (a *Adapter) GetBlocks(ctx context.Context, start, end uint64) ([]*models.Block, error) {
+	if start > end {
+		return nil, fmt.Errorf("invalid range: start %d > end %d", start, end)
+	}
+
 	blocks := make([]*models.Block, 0, end-start+1)
 
 	for i := start; i <= end; i++ {
@@ -153,11 +208,66 @@ func (a *Adapter) GetBlocks(ctx context.Context, start, end uint64) ([]*models.B
 	return blocks, nil
 }
 
-// GetTransaction fetches a transaction by hash
+// applyEvents decodes the events of a block and applies them to its
+// extrinsics when events are enabled
+func (a *Adapter) applyEvents(ctx context.Context, block *models.Block, blockHash types.Hash) error {
+	if !a.config.IncludeEvents {
+		return nil
+	}
+
+	events, err := a.fetchEvents(ctx, blockHash)
+	if err != nil {
+		return err
+	}
+
+	a.normalizer.ApplyEvents(block, events)
+	return nil
+}
+
+// SetExtrinsicIndex sets the index GetTransaction finds extrinsics in
+func (a *Adapter) SetExtrinsicIndex(index ExtrinsicIndex) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.extrinsics = index
+}
+
+// GetTransaction fetches an extrinsic by hash. Substrate nodes cannot look
+// extrinsics up by hash, so the block holding it is found through the
+// extrinsic index and fetched again.
 func (a *Adapter) GetTransaction(ctx context.Context, hash string) (*models.Transaction, error) {
-	// Polkadot doesn't have a direct transaction-by-hash lookup
-	// This would require maintaining an index or scanning blocks
-	return nil, fmt.Errorf("GetTransaction not implemented for Polkadot - extrinsics must be retrieved from blocks")
+	extHash, err := parseHash(hash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid hash: %w", err)
+	}
+
+	a.mu.RLock()
+	extrinsics := a.extrinsics
+	a.mu.RUnlock()
+	if extrinsics == nil {
+		return nil, fmt.Errorf("no extrinsic index set for Polkadot - extrinsics must be retrieved from blocks: %w", service.ErrNotSupported)
+	}
+
+	indexed, err := extrinsics.GetTransaction(ctx, a.config.ChainID, extHash.Hex())
+	if errors.Is(err, repository.ErrTransactionNotFound) {
+		return nil, fmt.Errorf("extrinsic %s is not indexed: %w: %w", extHash.Hex(), models.ErrTransactionNotFound, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up extrinsic %s: %w", extHash.Hex(), err)
+	}
+
+	block, err := a.GetBlockByNumber(ctx, indexed.BlockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, tx := range block.Transactions {
+		if tx.Hash == extHash.Hex() {
+			return tx, nil
+		}
+	}
+
+	// The block was replaced since the extrinsic was indexed
+	return nil, fmt.Errorf("extrinsic %s not found in block %d: %w", extHash.Hex(), indexed.BlockNumber, models.ErrTransactionNotFound)
 }
 
 // GetTransactionsByBlock fetches all transactions in a block
@@ -231,13 +341,13 @@ func (a *Adapter) Disconnect() error {
 // SubscribeNewBlocks subscribes to new blocks
 func (a *Adapter) SubscribeNewBlocks(ctx context.Context) (service.BlockSubscription, error) {
 	// WebSocket subscriptions not yet fully implemented
-	return nil, fmt.Errorf("block subscriptions not yet implemented for Polkadot")
+	return nil, fmt.Errorf("block subscriptions not yet implemented for Polkadot: %w", service.ErrNotSupported)
 }
 
 // SubscribeNewTransactions subscribes to new transactions
 func (a *Adapter) SubscribeNewTransactions(ctx context.Context) (service.TransactionSubscription, error) {
 	// Polkadot doesn't have direct transaction subscriptions
-	return nil, fmt.Errorf("transaction subscriptions not supported for Polkadot")
+	return nil, fmt.Errorf("transaction subscriptions for Polkadot: %w", service.ErrNotSupported)
 }
 
 // GetConfig returns the adapter configuration
@@ -1,10 +1,14 @@
 package polkadot
 
 import (
+	"context"
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/service"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -148,7 +152,7 @@ func TestConfig_Validate(t *testing.T) {
 }
 
 func TestNormalizer_NormalizeChainInfo(t *testing.T) {
-	normalizer := NewNormalizer("polkadot", "mainnet")
+	normalizer := NewNormalizer("polkadot", "mainnet", 0)
 
 	chainInfo := normalizer.NormalizeChainInfo()
 
@@ -239,7 +243,7 @@ func TestConfig_GetMethods(t *testing.T) {
 
 // Benchmark tests
 func BenchmarkNormalizer_NormalizeChainInfo(b *testing.B) {
-	normalizer := NewNormalizer("polkadot", "mainnet")
+	normalizer := NewNormalizer("polkadot", "mainnet", 0)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -255,3 +259,38 @@ func BenchmarkParseHash(b *testing.B) {
 		_, _ = parseHash(hash)
 	}
 }
+
+func TestAdapter_Capabilities(t *testing.T) {
+	ctx := context.Background()
+	adapter := &Adapter{config: DefaultConfig()}
+
+	caps := adapter.Capabilities()
+	if caps.BlockSubscription || caps.TransactionSubscription {
+		t.Errorf("Capabilities() subscriptions = %v/%v, want none", caps.BlockSubscription, caps.TransactionSubscription)
+	}
+	if caps.TransactionByHash {
+		t.Error("Capabilities().TransactionByHash should be false without an extrinsic index")
+	}
+	if caps.Logs != adapter.config.IncludeEvents {
+		t.Errorf("Capabilities().Logs = %v, want %v", caps.Logs, adapter.config.IncludeEvents)
+	}
+
+	if _, err := adapter.SubscribeNewBlocks(ctx); !errors.Is(err, service.ErrNotSupported) {
+		t.Errorf("SubscribeNewBlocks() error = %v, want ErrNotSupported", err)
+	}
+	if _, err := adapter.SubscribeNewTransactions(ctx); !errors.Is(err, service.ErrNotSupported) {
+		t.Errorf("SubscribeNewTransactions() error = %v, want ErrNotSupported", err)
+	}
+
+	hash := "0x" + strings.Repeat("ab", 32)
+	if _, err := adapter.GetTransaction(ctx, hash); !errors.Is(err, service.ErrNotSupported) {
+		t.Errorf("GetTransaction() without an extrinsic index error = %v, want ErrNotSupported", err)
+	}
+
+	adapter.SetExtrinsicIndex(extrinsicIndexFunc(func(ctx context.Context, chainID string, hash string) (*models.Transaction, error) {
+		return nil, models.ErrTransactionNotFound
+	}))
+	if !adapter.Capabilities().TransactionByHash {
+		t.Error("Capabilities().TransactionByHash should be true with an extrinsic index")
+	}
+}
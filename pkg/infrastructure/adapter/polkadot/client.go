@@ -2,73 +2,196 @@ package polkadot
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 
 	gsrpc "github.com/centrifuge/go-substrate-rpc-client/v4"
+	gethrpc "github.com/centrifuge/go-substrate-rpc-client/v4/gethrpc"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/rpc"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/endpoint"
 )
 
-// Client wraps the Substrate RPC client
+// Client wraps the Substrate RPC clients of the configured endpoints and
+// sends each request to the healthiest one
 type Client struct {
 	config *Config
-	api    *gsrpc.SubstrateAPI
+	apis   []*gsrpc.SubstrateAPI
+	pool   *endpoint.Pool
 }
 
-// NewClient creates a new Polkadot RPC client
+// NewClient creates a new Polkadot RPC client. Endpoints that cannot be
+// reached are left out as long as one of them connects.
 func NewClient(config *Config) (*Client, error) {
 	if err := config.Validate(); err != nil {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
-	// Create API client
-	api, err := gsrpc.NewSubstrateAPI(config.RPCURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create Substrate API: %w", err)
+	// Create an API client per endpoint
+	var apis []*gsrpc.SubstrateAPI
+	var urls []string
+	var lastErr error
+	for _, url := range config.RPCURLs() {
+		api, err := newSubstrateAPI(url, config.Transport)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		apis = append(apis, api)
+		urls = append(urls, url)
+	}
+	if len(apis) == 0 {
+		return nil, fmt.Errorf("failed to create Substrate API: %w", lastErr)
 	}
 
-	return &Client{
+	client := &Client{
 		config: config,
-		api:    api,
-	}, nil
+		apis:   apis,
+	}
+
+	poolConfig := endpoint.DefaultConfig(config.ChainID)
+	poolConfig.MaxLag = config.MaxEndpointLag
+	pool, err := endpoint.NewPool(urls, poolConfig, client.probeHead)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create endpoint pool: %w", err)
+	}
+	client.pool = pool
+
+	return client, nil
+}
+
+// newSubstrateAPI creates the API client of an endpoint, sending requests
+// through transport if it is set
+func newSubstrateAPI(url string, transport http.RoundTripper) (*gsrpc.SubstrateAPI, error) {
+	if transport == nil {
+		return gsrpc.NewSubstrateAPI(url)
+	}
+
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return nil, fmt.Errorf("custom transport requires an http(s) RPC URL, got %s", url)
+	}
+
+	cl, err := gethrpc.DialHTTPWithClient(url, &http.Client{Transport: transport})
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := &httpRPCClient{Client: cl, url: url}
+	api, err := rpc.NewRPC(httpClient)
+	if err != nil {
+		cl.Close()
+		return nil, err
+	}
+
+	return &gsrpc.SubstrateAPI{RPC: api, Client: httpClient}, nil
+}
+
+// httpRPCClient is a Substrate RPC client over HTTP with a custom
+// http.Client, which gsrpc only offers for its underlying geth client
+type httpRPCClient struct {
+	*gethrpc.Client
+
+	url string
+}
+
+// URL returns the URL the client connects to
+func (c *httpRPCClient) URL() string {
+	return c.url
 }
 
 // Close closes the client connection
 func (c *Client) Close() error {
-	// Substrate RPC client doesn't require explicit close
+	// Substrate RPC client doesn't require explicit close, only the
+	// endpoint pool's head probes are stopped
+	c.pool.Close()
 	return nil
 }
 
-// GetLatestBlockNumber returns the latest finalized block number
-func (c *Client) GetLatestBlockNumber(ctx context.Context) (uint64, error) {
-	hash, err := c.api.RPC.Chain.GetFinalizedHead()
+// do calls fn with the API of the healthiest endpoint, failing over to the
+// others. Errors returned by the node itself end the request.
+func (c *Client) do(ctx context.Context, fn func(api *gsrpc.SubstrateAPI) error) error {
+	return c.pool.Do(ctx, func(ctx context.Context, e *endpoint.Endpoint) error {
+		err := fn(c.apis[e.Index])
+
+		var rpcErr interface{ ErrorCode() int }
+		if errors.As(err, &rpcErr) {
+			return endpoint.Permanent(err)
+		}
+		return err
+	})
+}
+
+// probeHead returns the best block number of a single endpoint for the
+// pool's head tracking
+func (c *Client) probeHead(ctx context.Context, e *endpoint.Endpoint) (uint64, error) {
+	header, err := c.apis[e.Index].RPC.Chain.GetHeaderLatest()
 	if err != nil {
-		return 0, fmt.Errorf("failed to get finalized head: %w", err)
+		return 0, err
 	}
 
-	header, err := c.api.RPC.Chain.GetHeader(hash)
+	return uint64(header.Number), nil
+}
+
+// GetLatestBlockNumber returns the latest finalized block number
+func (c *Client) GetLatestBlockNumber(ctx context.Context) (uint64, error) {
+	var number uint64
+	err := c.do(ctx, func(api *gsrpc.SubstrateAPI) error {
+		hash, err := api.RPC.Chain.GetFinalizedHead()
+		if err != nil {
+			return fmt.Errorf("failed to get finalized head: %w", err)
+		}
+
+		header, err := api.RPC.Chain.GetHeader(hash)
+		if err != nil {
+			return fmt.Errorf("failed to get header: %w", err)
+		}
+
+		number = uint64(header.Number)
+		return nil
+	})
 	if err != nil {
-		return 0, fmt.Errorf("failed to get header: %w", err)
+		return 0, err
 	}
 
-	return uint64(header.Number), nil
+	return number, nil
 }
 
-// GetBlockHash returns the block hash for a given block number
+// GetBlockHash returns the block hash for a given block number, or an error
+// wrapping models.ErrBlockNotFound above the best block
 func (c *Client) GetBlockHash(ctx context.Context, blockNumber uint64) (types.Hash, error) {
-	hash, err := c.api.RPC.Chain.GetBlockHash(blockNumber)
+	var hash *string
+	err := c.do(ctx, func(api *gsrpc.SubstrateAPI) error {
+		// Nodes answer null for numbers they have no block at, which gsrpc
+		// cannot tell from a malformed hash
+		return api.Client.CallContext(ctx, &hash, "chain_getBlockHash", blockNumber)
+	})
 	if err != nil {
 		return types.Hash{}, fmt.Errorf("failed to get block hash for block %d: %w", blockNumber, err)
 	}
+	if hash == nil {
+		return types.Hash{}, fmt.Errorf("block %d: %w", blockNumber, models.ErrBlockNotFound)
+	}
 
-	return hash, nil
+	return types.NewHashFromHexString(*hash)
 }
 
-// GetBlock returns a block by hash
+// GetBlock returns a block by hash, or an error wrapping
+// models.ErrBlockNotFound for an unknown hash
 func (c *Client) GetBlock(ctx context.Context, hash types.Hash) (*types.SignedBlock, error) {
-	block, err := c.api.RPC.Chain.GetBlock(hash)
+	var block *types.SignedBlock
+	err := c.do(ctx, func(api *gsrpc.SubstrateAPI) error {
+		// gsrpc decodes the null of an unknown hash into an empty block
+		return api.Client.CallContext(ctx, &block, "chain_getBlock", hash.Hex())
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get block %s: %w", hash.Hex(), err)
 	}
+	if block == nil {
+		return nil, fmt.Errorf("block %s: %w", hash.Hex(), models.ErrBlockNotFound)
+	}
 
 	return block, nil
 }
@@ -85,7 +208,12 @@ func (c *Client) GetBlockByNumber(ctx context.Context, blockNumber uint64) (*typ
 
 // GetHeader returns a block header by hash
 func (c *Client) GetHeader(ctx context.Context, hash types.Hash) (*types.Header, error) {
-	header, err := c.api.RPC.Chain.GetHeader(hash)
+	var header *types.Header
+	err := c.do(ctx, func(api *gsrpc.SubstrateAPI) error {
+		var err error
+		header, err = api.RPC.Chain.GetHeader(hash)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get header %s: %w", hash.Hex(), err)
 	}
@@ -105,7 +233,12 @@ func (c *Client) GetHeaderByNumber(ctx context.Context, blockNumber uint64) (*ty
 
 // GetMetadata returns the chain metadata
 func (c *Client) GetMetadata(ctx context.Context, blockHash types.Hash) (*types.Metadata, error) {
-	meta, err := c.api.RPC.State.GetMetadata(blockHash)
+	var meta *types.Metadata
+	err := c.do(ctx, func(api *gsrpc.SubstrateAPI) error {
+		var err error
+		meta, err = api.RPC.State.GetMetadata(blockHash)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get metadata: %w", err)
 	}
@@ -115,7 +248,12 @@ func (c *Client) GetMetadata(ctx context.Context, blockHash types.Hash) (*types.
 
 // GetRuntimeVersion returns the runtime version
 func (c *Client) GetRuntimeVersion(ctx context.Context, blockHash types.Hash) (*types.RuntimeVersion, error) {
-	rv, err := c.api.RPC.State.GetRuntimeVersion(blockHash)
+	var rv *types.RuntimeVersion
+	err := c.do(ctx, func(api *gsrpc.SubstrateAPI) error {
+		var err error
+		rv, err = api.RPC.State.GetRuntimeVersion(blockHash)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get runtime version: %w", err)
 	}
@@ -125,7 +263,12 @@ func (c *Client) GetRuntimeVersion(ctx context.Context, blockHash types.Hash) (*
 
 // GetStorageRaw returns raw storage value
 func (c *Client) GetStorageRaw(ctx context.Context, key types.StorageKey, blockHash types.Hash) (*types.StorageDataRaw, error) {
-	data, err := c.api.RPC.State.GetStorageRaw(key, blockHash)
+	var data *types.StorageDataRaw
+	err := c.do(ctx, func(api *gsrpc.SubstrateAPI) error {
+		var err error
+		data, err = api.RPC.State.GetStorageRaw(key, blockHash)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get storage: %w", err)
 	}
@@ -135,7 +278,12 @@ func (c *Client) GetStorageRaw(ctx context.Context, key types.StorageKey, blockH
 
 // QueryStorage queries storage changes
 func (c *Client) QueryStorage(ctx context.Context, keys []types.StorageKey, startBlock, endBlock types.Hash) ([]types.StorageChangeSet, error) {
-	changes, err := c.api.RPC.State.QueryStorage(keys, startBlock, endBlock)
+	var changes []types.StorageChangeSet
+	err := c.do(ctx, func(api *gsrpc.SubstrateAPI) error {
+		var err error
+		changes, err = api.RPC.State.QueryStorage(keys, startBlock, endBlock)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to query storage: %w", err)
 	}
@@ -145,7 +293,12 @@ func (c *Client) QueryStorage(ctx context.Context, keys []types.StorageKey, star
 
 // GetSystemHealth returns the system health
 func (c *Client) GetSystemHealth(ctx context.Context) (*types.Health, error) {
-	health, err := c.api.RPC.System.Health()
+	var health types.Health
+	err := c.do(ctx, func(api *gsrpc.SubstrateAPI) error {
+		var err error
+		health, err = api.RPC.System.Health()
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get system health: %w", err)
 	}
@@ -155,7 +308,12 @@ func (c *Client) GetSystemHealth(ctx context.Context) (*types.Health, error) {
 
 // GetSystemChain returns the chain name
 func (c *Client) GetSystemChain(ctx context.Context) (string, error) {
-	chain, err := c.api.RPC.System.Chain()
+	var chain types.Text
+	err := c.do(ctx, func(api *gsrpc.SubstrateAPI) error {
+		var err error
+		chain, err = api.RPC.System.Chain()
+		return err
+	})
 	if err != nil {
 		return "", fmt.Errorf("failed to get system chain: %w", err)
 	}
@@ -165,7 +323,12 @@ func (c *Client) GetSystemChain(ctx context.Context) (string, error) {
 
 // GetSystemName returns the node name
 func (c *Client) GetSystemName(ctx context.Context) (string, error) {
-	name, err := c.api.RPC.System.Name()
+	var name types.Text
+	err := c.do(ctx, func(api *gsrpc.SubstrateAPI) error {
+		var err error
+		name, err = api.RPC.System.Name()
+		return err
+	})
 	if err != nil {
 		return "", fmt.Errorf("failed to get system name: %w", err)
 	}
@@ -175,7 +338,12 @@ func (c *Client) GetSystemName(ctx context.Context) (string, error) {
 
 // GetSystemVersion returns the node version
 func (c *Client) GetSystemVersion(ctx context.Context) (string, error) {
-	version, err := c.api.RPC.System.Version()
+	var version types.Text
+	err := c.do(ctx, func(api *gsrpc.SubstrateAPI) error {
+		var err error
+		version, err = api.RPC.System.Version()
+		return err
+	})
 	if err != nil {
 		return "", fmt.Errorf("failed to get system version: %w", err)
 	}
@@ -185,7 +353,12 @@ func (c *Client) GetSystemVersion(ctx context.Context) (string, error) {
 
 // GetSystemProperties returns the system properties
 func (c *Client) GetSystemProperties(ctx context.Context) (types.ChainProperties, error) {
-	props, err := c.api.RPC.System.Properties()
+	var props types.ChainProperties
+	err := c.do(ctx, func(api *gsrpc.SubstrateAPI) error {
+		var err error
+		props, err = api.RPC.System.Properties()
+		return err
+	})
 	if err != nil {
 		return types.ChainProperties{}, fmt.Errorf("failed to get system properties: %w", err)
 	}
@@ -206,7 +379,12 @@ func (c *Client) GetAccountInfo(ctx context.Context, accountID types.AccountID,
 	}
 
 	var accountInfo types.AccountInfo
-	ok, err := c.api.RPC.State.GetStorageLatest(key, &accountInfo)
+	var ok bool
+	err = c.do(ctx, func(api *gsrpc.SubstrateAPI) error {
+		var err error
+		ok, err = api.RPC.State.GetStorageLatest(key, &accountInfo)
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to get account info: %w", err)
 	}
@@ -242,7 +420,7 @@ func (c *Client) SubscribeFinalizedHeads(ctx context.Context) error {
 	return fmt.Errorf("subscription not yet implemented")
 }
 
-// GetAPI returns the underlying Substrate API
+// GetAPI returns the Substrate API of the healthiest endpoint
 func (c *Client) GetAPI() *gsrpc.SubstrateAPI {
-	return c.api
+	return c.apis[c.pool.Select().Index]
 }
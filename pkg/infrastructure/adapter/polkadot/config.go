@@ -2,6 +2,7 @@ package polkadot
 
 import (
 	"fmt"
+	"net/http"
 	"net/url"
 	"time"
 )
@@ -20,12 +21,23 @@ type Config struct {
 	// RPCURL is the Substrate RPC endpoint URL
 	RPCURL string `yaml:"rpc_url" json:"rpc_url"`
 
+	// FallbackRPCURLs are RPC endpoints used when RPCURL is unhealthy
+	FallbackRPCURLs []string `yaml:"fallback_rpc_urls,omitempty" json:"fallback_rpc_urls,omitempty"`
+
+	// MaxEndpointLag is how many blocks an RPC endpoint may fall behind the
+	// others before requests stop being routed to it
+	MaxEndpointLag uint64 `yaml:"max_endpoint_lag" json:"max_endpoint_lag"`
+
 	// WSURL is the WebSocket endpoint URL
 	WSURL string `yaml:"ws_url" json:"ws_url"`
 
 	// Timeout is the request timeout duration
 	Timeout time.Duration `yaml:"timeout" json:"timeout"`
 
+	// Transport, if set, carries the HTTP RPC requests, e.g. to record or
+	// replay them with the transport package. It requires http(s) RPC URLs.
+	Transport http.RoundTripper `yaml:"-" json:"-"`
+
 	// RetryAttempts is the number of retry attempts for failed requests
 	RetryAttempts int `yaml:"retry_attempts" json:"retry_attempts"`
 
@@ -75,6 +87,7 @@ func DefaultConfig() *Config {
 		RetryAttempts:      3,
 		RetryDelay:         1 * time.Second,
 		MaxConnections:     10,
+		MaxEndpointLag:     10,
 		BatchSize:          100,
 		StartBlock:         0,
 		EnableWebSocket:    false,
@@ -99,6 +112,7 @@ func KusamaConfig() *Config {
 		RetryAttempts:      3,
 		RetryDelay:         1 * time.Second,
 		MaxConnections:     10,
+		MaxEndpointLag:     10,
 		BatchSize:          100,
 		StartBlock:         0,
 		EnableWebSocket:    false,
@@ -176,6 +190,11 @@ func (c *Config) GetRPCURL() string {
 	return c.RPCURL
 }
 
+// RPCURLs returns the RPC URL followed by the fallback URLs
+func (c *Config) RPCURLs() []string {
+	return append([]string{c.RPCURL}, c.FallbackRPCURLs...)
+}
+
 // GetWSURL returns the WebSocket URL
 func (c *Config) GetWSURL() string {
 	return c.WSURL
@@ -0,0 +1,187 @@
+package polkadot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/repository"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/service"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/adaptertest"
+)
+
+func TestAdapter_Conformance(t *testing.T) {
+	node := newFakeNode(t, 5)
+
+	adaptertest.RunConformance(t, func(t *testing.T) service.ChainAdapter {
+		config := DefaultConfig()
+		config.ChainID = "polkadot-test"
+		config.RPCURL = node.URL
+		config.IncludeEvents = false
+
+		adapter, err := NewAdapter(config)
+		if err != nil {
+			t.Fatalf("NewAdapter() error = %v", err)
+		}
+		adapter.SetExtrinsicIndex(node)
+		t.Cleanup(func() { adapter.Disconnect() })
+
+		return adapter
+	}, adaptertest.Backend{
+		ChainType:        models.ChainTypePolkadot,
+		ChainID:          "polkadot-test",
+		First:            1,
+		Last:             5,
+		TxHash:           node.txHash(t, 3, 1),
+		UnknownBlockHash: "0x" + strings.Repeat("ab", 32),
+		UnknownTxHash:    "0x" + strings.Repeat("cd", 32),
+	})
+}
+
+// fakeNode serves a Substrate JSON-RPC API over a chain of blocks 0 to
+// head, each holding a timestamp.set inherent and a signed extrinsic and
+// emitting no events. It also stands in for the extrinsic index.
+type fakeNode struct {
+	*httptest.Server
+	blocks []*types.SignedBlock
+	hashes []types.Hash
+}
+
+func newFakeNode(t *testing.T, head int) *fakeNode {
+	t.Helper()
+
+	f := &fakeNode{}
+	for number := 0; number <= head; number++ {
+		header := types.Header{Number: types.BlockNumber(number)}
+		if number > 0 {
+			header.ParentHash = f.hashes[number-1]
+		}
+		f.blocks = append(f.blocks, &types.SignedBlock{
+			Block: types.Block{
+				Header: header,
+				Extrinsics: []types.Extrinsic{
+					{
+						Version: types.ExtrinsicVersion4,
+						Method: types.Call{
+							CallIndex: types.CallIndex{SectionIndex: 3, MethodIndex: 0},
+							Args:      types.Args{byte(number)},
+						},
+					},
+					{
+						Version: types.ExtrinsicVersion4 | types.ExtrinsicBitSigned,
+						Signature: types.ExtrinsicSignatureV4{
+							Signer:    types.MultiAddress{IsAddress20: true, AsAddress20: [20]byte{byte(number)}},
+							Signature: types.MultiSignature{IsSr25519: true},
+							Era:       types.ExtrinsicEra{IsImmortalEra: true},
+						},
+						Method: types.Call{CallIndex: types.CallIndex{SectionIndex: 5, MethodIndex: 0}},
+					},
+				},
+			},
+		})
+		f.hashes = append(f.hashes, types.Hash{0xb1, byte(number)})
+	}
+
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(f.Close)
+
+	return f
+}
+
+func (f *fakeNode) serve(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     json.RawMessage   `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	head := len(f.blocks) - 1
+	var result interface{}
+	switch req.Method {
+	case "state_getMetadata":
+		result = types.MetadataV14Data
+	case "state_getRuntimeVersion":
+		result = types.RuntimeVersion{SpecName: "polkadot-test", SpecVersion: 1}
+	case "state_getStorage":
+		// Blocks emit no events
+	case "system_health":
+		result = map[string]interface{}{"peers": 1, "isSyncing": false, "shouldHavePeers": true}
+	case "chain_getFinalizedHead":
+		result = f.hashes[head].Hex()
+	case "chain_getBlockHash":
+		var number int
+		if len(req.Params) > 0 {
+			json.Unmarshal(req.Params[0], &number)
+		}
+		if number <= head {
+			result = f.hashes[number].Hex()
+		}
+	case "chain_getHeader", "chain_getBlock":
+		number := head
+		if len(req.Params) > 0 {
+			number = f.blockNumber(req.Params[0])
+		}
+		switch {
+		case number < 0:
+		case req.Method == "chain_getHeader":
+			result = f.blocks[number].Block.Header
+		default:
+			result = f.blocks[number]
+		}
+	default:
+		http.Error(w, "unknown method "+req.Method, http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"jsonrpc": "2.0",
+		"id":      req.ID,
+		"result":  result,
+	})
+}
+
+// blockNumber returns the number of the block with a JSON encoded hash, or
+// -1 for an unknown hash
+func (f *fakeNode) blockNumber(param json.RawMessage) int {
+	var hash string
+	json.Unmarshal(param, &hash)
+	for number, h := range f.hashes {
+		if h.Hex() == hash {
+			return number
+		}
+	}
+	return -1
+}
+
+// txHash returns the hash of an extrinsic of a block
+func (f *fakeNode) txHash(t *testing.T, number, index int) string {
+	t.Helper()
+
+	hash, err := ExtrinsicHash(&f.blocks[number].Block.Extrinsics[index])
+	if err != nil {
+		t.Fatalf("ExtrinsicHash() error = %v", err)
+	}
+	return hash
+}
+
+// GetTransaction implements ExtrinsicIndex over the chain's extrinsics
+func (f *fakeNode) GetTransaction(ctx context.Context, chainID string, hash string) (*models.Transaction, error) {
+	for number, block := range f.blocks {
+		for i := range block.Block.Extrinsics {
+			if h, _ := ExtrinsicHash(&block.Block.Extrinsics[i]); h == hash {
+				return &models.Transaction{ChainID: chainID, Hash: hash, BlockNumber: uint64(number)}, nil
+			}
+		}
+	}
+	return nil, repository.ErrTransactionNotFound
+}
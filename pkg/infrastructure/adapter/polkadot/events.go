@@ -0,0 +1,302 @@
+package polkadot
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+)
+
+// Runtime events applied to the extrinsics that emitted them
+const (
+	eventExtrinsicSuccess   = "System.ExtrinsicSuccess"
+	eventExtrinsicFailed    = "System.ExtrinsicFailed"
+	eventTransactionFeePaid = "TransactionPayment.TransactionFeePaid"
+	eventBalancesTransfer   = "Balances.Transfer"
+)
+
+// eventRuntime holds what is needed to decode the events of the blocks of
+// one runtime version
+type eventRuntime struct {
+	registry registry.EventRegistry // nil if the metadata cannot be decoded
+	key      types.StorageKey       // System.Events
+}
+
+// fetchEvents fetches and decodes the System.Events of a block. Blocks of
+// runtimes whose metadata predates V14 have no decodable events.
+func (a *Adapter) fetchEvents(ctx context.Context, blockHash types.Hash) ([]*parser.Event, error) {
+	version, err := a.client.GetRuntimeVersion(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	runtime, err := a.eventRuntime(ctx, blockHash, uint32(version.SpecVersion))
+	if err != nil {
+		return nil, err
+	}
+	if runtime.registry == nil {
+		return nil, nil
+	}
+
+	raw, err := a.client.GetStorageRaw(ctx, runtime.key, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	if raw == nil || len(*raw) == 0 {
+		return nil, nil
+	}
+
+	events, err := parser.NewEventParser().ParseEvents(runtime.registry, raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode events: %w", err)
+	}
+
+	return events, nil
+}
+
+// eventRuntime returns the event registry of a runtime version, building it
+// from the metadata at blockHash the first time the version is seen
+func (a *Adapter) eventRuntime(ctx context.Context, blockHash types.Hash, specVersion uint32) (*eventRuntime, error) {
+	a.runtimesMu.Lock()
+	runtime, ok := a.runtimes[specVersion]
+	a.runtimesMu.Unlock()
+	if ok {
+		return runtime, nil
+	}
+
+	meta, err := a.client.GetMetadata(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	runtime = &eventRuntime{}
+	if eventRegistry, err := registry.NewFactory().CreateEventRegistry(meta); err == nil {
+		key, err := types.CreateStorageKey(meta, "System", "Events")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create storage key: %w", err)
+		}
+		runtime.registry = eventRegistry
+		runtime.key = key
+	}
+
+	a.runtimesMu.Lock()
+	a.runtimes[specVersion] = runtime
+	a.runtimesMu.Unlock()
+
+	return runtime, nil
+}
+
+// ApplyEvents applies the runtime events of a block to its extrinsics:
+// System.ExtrinsicSuccess and System.ExtrinsicFailed set the status,
+// TransactionPayment.TransactionFeePaid the fee and the signer's
+// Balances.Transfer the receiver and value. Every event emitted while an
+// extrinsic was applied is added to its logs.
+func (n *Normalizer) ApplyEvents(block *models.Block, events []*parser.Event) {
+	txs := make(map[uint32]*models.Transaction, len(block.Transactions))
+	for _, tx := range block.Transactions {
+		txs[uint32(tx.Index)] = tx
+	}
+
+	for i, event := range events {
+		// Events of block initialization and finalization belong to no
+		// extrinsic
+		if event == nil || event.Phase == nil || !event.Phase.IsApplyExtrinsic {
+			continue
+		}
+		tx, ok := txs[event.Phase.AsApplyExtrinsic]
+		if !ok {
+			continue
+		}
+
+		tx.Logs = append(tx.Logs, n.normalizeEvent(event, uint64(i)))
+
+		switch event.Name {
+		case eventExtrinsicSuccess:
+			tx.Status = models.TxStatusSuccess
+		case eventExtrinsicFailed:
+			tx.Status = models.TxStatusFailed
+			if field := eventField(event.Fields, "dispatch_error"); field != nil {
+				if tx.Metadata == nil {
+					tx.Metadata = make(map[string]interface{})
+				}
+				tx.Metadata["dispatch_error"] = n.decodedValue(field.Name, field.Value)
+			}
+		case eventTransactionFeePaid:
+			if fee, ok := decodedAmount(eventField(event.Fields, "actual_fee")); ok {
+				tx.Fee = fee
+			}
+		case eventBalancesTransfer:
+			from, _ := n.decodedAccount(eventField(event.Fields, "from"))
+			to, ok := n.decodedAccount(eventField(event.Fields, "to"))
+			amount, _ := decodedAmount(eventField(event.Fields, "amount"))
+			if ok && tx.To == "" && (tx.From == "" || tx.From == from) {
+				tx.To = to
+				tx.Value = amount
+			}
+		}
+	}
+}
+
+// normalizeEvent converts a runtime event to a log. The pallet is used as
+// the address and the event name as the first topic, followed by the
+// event's own topics. The data holds the decoded fields as JSON.
+func (n *Normalizer) normalizeEvent(event *parser.Event, index uint64) *models.Log {
+	pallet := event.Name
+	if i := strings.Index(pallet, "."); i >= 0 {
+		pallet = pallet[:i]
+	}
+
+	topics := make([]string, 0, len(event.Topics)+1)
+	topics = append(topics, event.Name)
+	for _, topic := range event.Topics {
+		topics = append(topics, topic.Hex())
+	}
+
+	data, err := json.Marshal(n.decodedValue("", event.Fields))
+	if err != nil {
+		data = nil
+	}
+
+	return &models.Log{
+		Index:   index,
+		Address: pallet,
+		Topics:  topics,
+		Data:    data,
+	}
+}
+
+// decodedValue converts a value decoded with the runtime metadata to plain
+// JSON values: accounts become SS58 addresses, byte arrays hex strings and
+// balances decimal strings
+func (n *Normalizer) decodedValue(name string, value interface{}) interface{} {
+	if strings.Contains(name, "AccountId32") {
+		if address, ok := n.decodedAccount(&registry.DecodedField{Name: name, Value: value}); ok {
+			return address
+		}
+	}
+
+	switch v := value.(type) {
+	case registry.DecodedFields:
+		fields := make(map[string]interface{}, len(v))
+		for _, field := range v {
+			fields[fieldName(field.Name)] = n.decodedValue(field.Name, field.Value)
+		}
+		return fields
+	case []interface{}:
+		if bytes, ok := decodedBytes(v); ok {
+			return "0x" + hex.EncodeToString(bytes)
+		}
+		items := make([]interface{}, len(v))
+		for i, item := range v {
+			items[i] = n.decodedValue("", item)
+		}
+		return items
+	case types.U128, types.U256, types.UCompact:
+		amount, _ := decodedAmount(&registry.DecodedField{Value: value})
+		return amount
+	default:
+		return value
+	}
+}
+
+// decodedAccount returns the SS58 address of a decoded AccountId32 field
+func (n *Normalizer) decodedAccount(field *registry.DecodedField) (string, bool) {
+	if field == nil {
+		return "", false
+	}
+
+	value := field.Value
+	// AccountId32 is a composite of a single [u8; 32]
+	for {
+		fields, ok := value.(registry.DecodedFields)
+		if !ok || len(fields) != 1 {
+			break
+		}
+		value = fields[0].Value
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case types.AccountID:
+		bytes = v.ToBytes()
+	case []interface{}:
+		bytes, _ = decodedBytes(v)
+	}
+	if len(bytes) != 32 {
+		return "", false
+	}
+
+	return EncodeSS58(bytes, n.ss58Format), true
+}
+
+// decodedAmount returns a decoded integer field as a decimal string
+func decodedAmount(field *registry.DecodedField) (string, bool) {
+	if field == nil {
+		return "", false
+	}
+
+	switch v := field.Value.(type) {
+	case types.U128:
+		if v.Int == nil {
+			return "0", true
+		}
+		return v.String(), true
+	case types.U256:
+		if v.Int == nil {
+			return "0", true
+		}
+		return v.String(), true
+	case types.UCompact:
+		return (*big.Int)(&v).String(), true
+	case types.U64:
+		return fmt.Sprintf("%d", v), true
+	case types.U32:
+		return fmt.Sprintf("%d", v), true
+	default:
+		return "", false
+	}
+}
+
+// decodedBytes returns a decoded array or sequence of u8 as bytes
+func decodedBytes(items []interface{}) ([]byte, bool) {
+	if len(items) == 0 {
+		return nil, false
+	}
+
+	bytes := make([]byte, len(items))
+	for i, item := range items {
+		b, ok := item.(types.U8)
+		if !ok {
+			return nil, false
+		}
+		bytes[i] = byte(b)
+	}
+
+	return bytes, true
+}
+
+// eventField returns the field of an event with the given name
+func eventField(fields registry.DecodedFields, name string) *registry.DecodedField {
+	for _, field := range fields {
+		if fieldName(field.Name) == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// fieldName strips the type path the registry puts in front of field names,
+// e.g. "sp_core.crypto.AccountId32.from" becomes "from"
+func fieldName(name string) string {
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
@@ -0,0 +1,275 @@
+package polkadot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/registry/parser"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/repository"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/storage/pebble"
+)
+
+// alice is the public key of the well-known //Alice development account
+var alice = []byte{
+	0xd4, 0x35, 0x93, 0xc7, 0x15, 0xfd, 0xd3, 0x1c, 0x61, 0x14, 0x1a, 0xbd, 0x04, 0xa9, 0x9f, 0xd6,
+	0x82, 0x2c, 0x85, 0x58, 0x85, 0x4c, 0xcd, 0xe3, 0x9a, 0x56, 0x84, 0xe7, 0xa5, 0x6d, 0xa2, 0x7d,
+}
+
+func TestEncodeSS58(t *testing.T) {
+	tests := []struct {
+		format uint16
+		want   string
+	}{
+		{format: 0, want: "15oF4uVJwmo4TdGW7VfQxNLavjCXviqxT9S1MgbjMNHr6Sp5"},
+		{format: 2, want: "HNZata7iMYWmk5RvZRTiAsSDhV8366zq2YGb3tLH5Upf74F"},
+		{format: 42, want: "5GrwvaEF5zXb26Fz9rcQpDWS57CtERHpNehXCPcNoHGKutQY"},
+	}
+
+	for _, tt := range tests {
+		if got := EncodeSS58(alice, tt.format); got != tt.want {
+			t.Errorf("EncodeSS58(alice, %d) = %s, want %s", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestExtrinsicHash(t *testing.T) {
+	ext := types.Extrinsic{
+		Version: types.ExtrinsicVersion4,
+		Method: types.Call{
+			CallIndex: types.CallIndex{SectionIndex: 3, MethodIndex: 0},
+			Args:      types.Args{0x0b, 0x00, 0x10, 0x5e, 0x5f, 0x8e, 0x01},
+		},
+	}
+
+	hash, err := ExtrinsicHash(&ext)
+	if err != nil {
+		t.Fatalf("ExtrinsicHash() error = %v", err)
+	}
+	if !strings.HasPrefix(hash, "0x") || len(hash) != 66 {
+		t.Errorf("ExtrinsicHash() = %s, want a 0x-prefixed 32-byte hex hash", hash)
+	}
+
+	ext.Method.Args = append(ext.Method.Args, 0x00)
+	other, err := ExtrinsicHash(&ext)
+	if err != nil {
+		t.Fatalf("ExtrinsicHash() error = %v", err)
+	}
+	if other == hash {
+		t.Error("different extrinsics have the same hash")
+	}
+}
+
+func TestNormalizer_BlockWithInherentIsStorable(t *testing.T) {
+	normalizer := NewNormalizer("polkadot", "mainnet", 42)
+
+	aliceID, err := types.NewAccountID(alice)
+	if err != nil {
+		t.Fatalf("NewAccountID() error = %v", err)
+	}
+	signed := func(signer types.MultiAddress) types.Extrinsic {
+		return types.Extrinsic{
+			Version: types.ExtrinsicVersion4 | types.ExtrinsicBitSigned,
+			Signature: types.ExtrinsicSignatureV4{
+				Signer:    signer,
+				Signature: types.MultiSignature{IsSr25519: true},
+				Era:       types.ExtrinsicEra{IsImmortalEra: true},
+			},
+			Method: types.Call{CallIndex: types.CallIndex{SectionIndex: 5, MethodIndex: 0}},
+		}
+	}
+
+	signedBlock := &types.SignedBlock{
+		Block: types.Block{
+			Header: types.Header{Number: 7},
+			Extrinsics: []types.Extrinsic{
+				// timestamp.set
+				{
+					Version: types.ExtrinsicVersion4,
+					Method: types.Call{
+						CallIndex: types.CallIndex{SectionIndex: 3, MethodIndex: 0},
+						Args:      types.Args{0x0b, 0x00, 0x10, 0x5e, 0x5f, 0x8e, 0x01},
+					},
+				},
+				signed(types.MultiAddress{IsID: true, AsID: *aliceID}),
+				signed(types.MultiAddress{IsAddress20: true, AsAddress20: [20]byte{0x01}}),
+			},
+		},
+	}
+
+	block, err := normalizer.NormalizeBlock(signedBlock, types.Hash{0x07})
+	if err != nil {
+		t.Fatalf("NormalizeBlock() error = %v", err)
+	}
+
+	wantFrom := []string{"", EncodeSS58(alice, 42), "0x01" + strings.Repeat("00", 19)}
+	if len(block.Transactions) != len(wantFrom) {
+		t.Fatalf("got %d transactions, want %d", len(block.Transactions), len(wantFrom))
+	}
+	for i, tx := range block.Transactions {
+		if tx.From != wantFrom[i] {
+			t.Errorf("transaction %d from %q, want %q", i, tx.From, wantFrom[i])
+		}
+	}
+
+	storage, err := pebble.NewStorage(pebble.DefaultConfig(t.TempDir()))
+	if err != nil {
+		t.Fatalf("NewStorage() error = %v", err)
+	}
+	defer storage.Close()
+
+	ctx := context.Background()
+	batch := storage.NewBatch()
+	defer batch.Close()
+	if err := batch.SetBlock(ctx, block); err != nil {
+		t.Fatalf("SetBlock() error = %v", err)
+	}
+	if err := batch.SetTransactions(ctx, block.Transactions); err != nil {
+		t.Fatalf("SetTransactions() error = %v", err)
+	}
+	if err := batch.Commit(); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+
+	inherent, err := storage.GetTransaction(ctx, block.ChainID, block.Transactions[0].Hash)
+	if err != nil {
+		t.Fatalf("GetTransaction() error = %v", err)
+	}
+	if inherent.From != "" {
+		t.Errorf("stored inherent from %q, want no sender", inherent.From)
+	}
+}
+
+// accountField returns an AccountId32 field the way the event registry
+// decodes it
+func accountField(name string, account []byte) *registry.DecodedField {
+	bytes := make([]interface{}, len(account))
+	for i, b := range account {
+		bytes[i] = types.U8(b)
+	}
+	return &registry.DecodedField{
+		Name:  "sp_core.crypto.AccountId32." + name,
+		Value: registry.DecodedFields{{Name: "[u8; 32]", Value: bytes}},
+	}
+}
+
+func amountField(name string, amount int64) *registry.DecodedField {
+	return &registry.DecodedField{Name: name, Value: types.NewU128(*big.NewInt(amount))}
+}
+
+func extrinsicPhase(index uint32) *types.Phase {
+	return &types.Phase{IsApplyExtrinsic: true, AsApplyExtrinsic: index}
+}
+
+func TestNormalizer_ApplyEvents(t *testing.T) {
+	normalizer := NewNormalizer("polkadot", "mainnet", 42)
+
+	bob := make([]byte, 32)
+	bob[0] = 0x8e
+	signer := EncodeSS58(alice, 42)
+
+	block := &models.Block{
+		Transactions: []*models.Transaction{
+			{Index: 0, Status: models.TxStatusSuccess, Fee: "0", Value: "0"},
+			{Index: 1, From: signer, Status: models.TxStatusSuccess, Fee: "0", Value: "0"},
+			{Index: 2, From: signer, Status: models.TxStatusSuccess, Fee: "0", Value: "0"},
+		},
+	}
+
+	events := []*parser.Event{
+		{Name: "System.ExtrinsicSuccess", Phase: extrinsicPhase(0)},
+		{
+			Name:  "Balances.Transfer",
+			Phase: extrinsicPhase(1),
+			Fields: registry.DecodedFields{
+				accountField("from", alice),
+				accountField("to", bob),
+				amountField("amount", 1000000000000),
+			},
+		},
+		{
+			Name:   "TransactionPayment.TransactionFeePaid",
+			Phase:  extrinsicPhase(1),
+			Fields: registry.DecodedFields{accountField("who", alice), amountField("actual_fee", 15600000), amountField("tip", 0)},
+		},
+		{Name: "System.ExtrinsicSuccess", Phase: extrinsicPhase(1)},
+		{
+			Name:   "System.ExtrinsicFailed",
+			Phase:  extrinsicPhase(2),
+			Fields: registry.DecodedFields{{Name: "dispatch_error", Value: types.U8(3)}},
+		},
+		{Name: "Treasury.Deposit", Phase: &types.Phase{IsFinalization: true}},
+	}
+
+	normalizer.ApplyEvents(block, events)
+
+	transfer := block.Transactions[1]
+	if transfer.To != EncodeSS58(bob, 42) || transfer.Value != "1000000000000" {
+		t.Errorf("transfer to %s of %s, want %s of 1000000000000", transfer.To, transfer.Value, EncodeSS58(bob, 42))
+	}
+	if transfer.Fee != "15600000" {
+		t.Errorf("Fee = %s, want 15600000", transfer.Fee)
+	}
+	if len(transfer.Logs) != 3 {
+		t.Fatalf("got %d logs, want 3", len(transfer.Logs))
+	}
+
+	log := transfer.Logs[0]
+	if log.Index != 1 || log.Address != "Balances" || len(log.Topics) != 1 || log.Topics[0] != "Balances.Transfer" {
+		t.Errorf("log = %+v, want event 1 of Balances with topic Balances.Transfer", log)
+	}
+	var data map[string]interface{}
+	if err := json.Unmarshal(log.Data, &data); err != nil {
+		t.Fatalf("log data is not JSON: %v", err)
+	}
+	if data["from"] != signer || data["amount"] != "1000000000000" {
+		t.Errorf("log data = %v", data)
+	}
+
+	failed := block.Transactions[2]
+	if failed.Status != models.TxStatusFailed {
+		t.Errorf("Status = %v, want failed", failed.Status)
+	}
+	if failed.To != "" || failed.Metadata["dispatch_error"] == nil {
+		t.Errorf("failed extrinsic has to %q and metadata %v", failed.To, failed.Metadata)
+	}
+
+	if logs := block.Transactions[0].Logs; len(logs) != 1 {
+		t.Errorf("got %d logs for the first extrinsic, want 1", len(logs))
+	}
+}
+
+type extrinsicIndexFunc func(ctx context.Context, chainID string, hash string) (*models.Transaction, error)
+
+func (f extrinsicIndexFunc) GetTransaction(ctx context.Context, chainID string, hash string) (*models.Transaction, error) {
+	return f(ctx, chainID, hash)
+}
+
+func TestAdapter_GetTransactionUsesExtrinsicIndex(t *testing.T) {
+	adapter := &Adapter{config: DefaultConfig()}
+	hash := "0x1234567890abcdef1234567890abcdef1234567890abcdef1234567890abcdef"
+
+	if _, err := adapter.GetTransaction(context.Background(), hash); err == nil {
+		t.Error("GetTransaction() without an extrinsic index should fail")
+	}
+
+	var lookedUp string
+	adapter.SetExtrinsicIndex(extrinsicIndexFunc(func(ctx context.Context, chainID string, hash string) (*models.Transaction, error) {
+		lookedUp = hash
+		return nil, repository.ErrTransactionNotFound
+	}))
+
+	_, err := adapter.GetTransaction(context.Background(), strings.ToUpper(hash[2:]))
+	if !errors.Is(err, repository.ErrTransactionNotFound) {
+		t.Errorf("GetTransaction() error = %v, want ErrTransactionNotFound", err)
+	}
+	if lookedUp != hash {
+		t.Errorf("looked up %s, want %s", lookedUp, hash)
+	}
+}
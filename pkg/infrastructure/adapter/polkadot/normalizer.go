@@ -3,23 +3,29 @@ package polkadot
 import (
 	"encoding/hex"
 	"fmt"
+	"math/big"
 	"strings"
 
+	"github.com/centrifuge/go-substrate-rpc-client/v4/hash"
 	"github.com/centrifuge/go-substrate-rpc-client/v4/types"
+	"github.com/centrifuge/go-substrate-rpc-client/v4/types/codec"
 	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
 )
 
 // Normalizer normalizes Polkadot/Substrate data to domain models
 type Normalizer struct {
-	chainID string
-	network string
+	chainID    string
+	network    string
+	ss58Format uint16
 }
 
-// NewNormalizer creates a new Normalizer
-func NewNormalizer(chainID, network string) *Normalizer {
+// NewNormalizer creates a new Normalizer that formats accounts as SS58
+// addresses of the given network format
+func NewNormalizer(chainID, network string, ss58Format uint16) *Normalizer {
 	return &Normalizer{
-		chainID: chainID,
-		network: network,
+		chainID:    chainID,
+		network:    network,
+		ss58Format: ss58Format,
 	}
 }
 
@@ -69,21 +75,27 @@ func (n *Normalizer) NormalizeBlock(signedBlock *types.SignedBlock, blockHash ty
 
 	// Normalize extrinsics (transactions)
 	transactions := make([]*models.Transaction, 0, len(block.Extrinsics))
+	txHashes := make([]string, 0, len(block.Extrinsics))
 	for i, ext := range block.Extrinsics {
 		tx, err := n.NormalizeExtrinsic(&ext, uint64(header.Number), uint32(i))
 		if err != nil {
 			// Log error but continue
 			continue
 		}
+		tx.BlockHash = blockHashHex
 		transactions = append(transactions, tx)
+		txHashes = append(txHashes, tx.Hash)
 	}
 
 	return &models.Block{
+		ChainType:    models.ChainTypePolkadot,
 		ChainID:      n.chainID,
 		Number:       uint64(header.Number),
 		Hash:         blockHashHex,
 		ParentHash:   parentHashHex,
 		Timestamp:    models.NewTimestamp(0), // Will need to extract from extrinsics
+		TxCount:      len(transactions),
+		TxHashes:     txHashes,
 		Transactions: transactions,
 		Metadata:     metadata,
 	}, nil
@@ -95,15 +107,19 @@ func (n *Normalizer) NormalizeExtrinsic(ext *types.Extrinsic, blockNumber uint64
 		return nil, fmt.Errorf("extrinsic is nil")
 	}
 
-	// Calculate extrinsic hash (simplified - would need proper hashing)
-	txHash := fmt.Sprintf("EXT-%d-%d", blockNumber, txIndex)
+	txHash, err := ExtrinsicHash(ext)
+	if err != nil {
+		return nil, err
+	}
 
 	// Default values
 	var status models.TxStatus = models.TxStatusSuccess
 	metadata := make(map[string]interface{})
 
-	// Extract signature information
+	// Extract signature information. Unsigned extrinsics, such as the
+	// timestamp.set inherent every block starts with, have no sender.
 	var from string
+	var nonce uint64
 	metadata["has_signature"] = ext.IsSigned()
 
 	// Add extrinsic details to metadata
@@ -114,26 +130,70 @@ func (n *Normalizer) NormalizeExtrinsic(ext *types.Extrinsic, blockNumber uint64
 	if ext.IsSigned() {
 		metadata["era"] = fmt.Sprintf("%v", ext.Signature.Era)
 		metadata["signer_type"] = "MultiAddress"
+
+		from = n.signerAddress(ext.Signature.Signer)
+		nonce = (*big.Int)(&ext.Signature.Nonce).Uint64()
 	}
 
 	// Extract method information
 	metadata["call_index"] = fmt.Sprintf("%d-%d", ext.Method.CallIndex.SectionIndex, ext.Method.CallIndex.MethodIndex)
 
 	return &models.Transaction{
+		ChainType:   models.ChainTypePolkadot,
 		ChainID:     n.chainID,
 		Hash:        txHash,
+		Index:       uint64(txIndex),
 		BlockNumber: blockNumber,
 		From:        from,
-		To:          "", // Polkadot doesn't have a simple "to" address
+		To:          "", // Set from Balances.Transfer events
 		Value:       "0",
+		Fee:         "0",
 		GasUsed:     0,
 		GasPrice:    "0",
+		Nonce:       nonce,
 		Status:      status,
 		Timestamp:   models.NewTimestamp(0),
 		Metadata:    metadata,
 	}, nil
 }
 
+// signerAddress formats the signer of an extrinsic: accounts as SS58
+// addresses, raw and 20-byte addresses as hex and account indices as
+// "index:<n>"
+func (n *Normalizer) signerAddress(signer types.MultiAddress) string {
+	switch {
+	case signer.IsID:
+		return EncodeSS58(signer.AsID.ToBytes(), n.ss58Format)
+	case signer.IsAddress32:
+		return EncodeSS58(signer.AsAddress32[:], n.ss58Format)
+	case signer.IsAddress20:
+		return "0x" + hex.EncodeToString(signer.AsAddress20[:])
+	case signer.IsRaw:
+		return "0x" + hex.EncodeToString(signer.AsRaw)
+	case signer.IsIndex:
+		return fmt.Sprintf("index:%d", signer.AsIndex)
+	default:
+		return ""
+	}
+}
+
+// ExtrinsicHash returns the hash of an extrinsic, the blake2b-256 hash of its
+// SCALE encoding, as a 0x-prefixed hex string
+func ExtrinsicHash(ext *types.Extrinsic) (string, error) {
+	encoded, err := codec.Encode(ext)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode extrinsic: %w", err)
+	}
+
+	hasher, err := hash.NewBlake2b256(nil)
+	if err != nil {
+		return "", err
+	}
+	hasher.Write(encoded)
+
+	return types.NewHash(hasher.Sum(nil)).Hex(), nil
+}
+
 // NormalizeHeader normalizes a block header
 func (n *Normalizer) NormalizeHeader(header *types.Header, blockHash types.Hash) (*models.Block, error) {
 	if header == nil {
@@ -149,6 +209,7 @@ func (n *Normalizer) NormalizeHeader(header *types.Header, blockHash types.Hash)
 	metadata["number"] = uint64(header.Number)
 
 	return &models.Block{
+		ChainType:    models.ChainTypePolkadot,
 		ChainID:      n.chainID,
 		Number:       uint64(header.Number),
 		Hash:         blockHashHex,
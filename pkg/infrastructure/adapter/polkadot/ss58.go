@@ -0,0 +1,64 @@
+package polkadot
+
+import (
+	"math/big"
+
+	"github.com/centrifuge/go-substrate-rpc-client/v4/hash"
+)
+
+// base58Alphabet is the Bitcoin base58 alphabet used by SS58 addresses
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// ss58Prefix is prepended to the payload of an SS58 address before hashing
+// it for the checksum
+var ss58Prefix = []byte("SS58PRE")
+
+// EncodeSS58 encodes a 32-byte account ID as an SS58 address of the given
+// network format, e.g. 0 for Polkadot or 2 for Kusama
+func EncodeSS58(accountID []byte, format uint16) string {
+	var payload []byte
+	if format < 64 {
+		payload = []byte{byte(format)}
+	} else {
+		// Formats from 64 on take two bytes
+		payload = []byte{
+			byte((format&0xfc)>>2) | 0x40,
+			byte(format>>8) | byte(format&0x03)<<6,
+		}
+	}
+	payload = append(payload, accountID...)
+
+	hasher, _ := hash.NewBlake2b512(nil)
+	hasher.Write(ss58Prefix)
+	hasher.Write(payload)
+	checksum := hasher.Sum(nil)
+
+	return encodeBase58(append(payload, checksum[:2]...))
+}
+
+// encodeBase58 encodes data with the base58 alphabet, keeping leading zero
+// bytes as leading ones
+func encodeBase58(data []byte) string {
+	value := new(big.Int).SetBytes(data)
+	base := big.NewInt(58)
+	mod := new(big.Int)
+
+	var encoded []byte
+	for value.Sign() > 0 {
+		value.DivMod(value, base, mod)
+		encoded = append(encoded, base58Alphabet[mod.Int64()])
+	}
+	for _, b := range data {
+		if b != 0 {
+			break
+		}
+		encoded = append(encoded, base58Alphabet[0])
+	}
+
+	// Digits were produced least significant first
+	for i, j := 0, len(encoded)-1; i < j; i, j = i+1, j-1 {
+		encoded[i], encoded[j] = encoded[j], encoded[i]
+	}
+
+	return string(encoded)
+}
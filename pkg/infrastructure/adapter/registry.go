@@ -7,10 +7,12 @@ import (
 	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
 	"github.com/sage-x-project/blockchain-indexer/pkg/domain/service"
 	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/avalanche"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/bitcoin"
 	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/cosmos"
 	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/evm"
 	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/polkadot"
 	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/ripple"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/sim"
 	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/solana"
 )
 
@@ -96,6 +98,24 @@ func (r *Registry) registerDefaults() {
 		}
 		return ripple.NewAdapter(rippleConfig)
 	})
+
+	// Bitcoin adapter
+	r.Register(models.ChainTypeBitcoin, func(config interface{}) (service.ChainAdapter, error) {
+		bitcoinConfig, ok := config.(*bitcoin.Config)
+		if !ok {
+			return nil, fmt.Errorf("invalid config type for Bitcoin adapter, expected *bitcoin.Config")
+		}
+		return bitcoin.NewAdapter(bitcoinConfig)
+	})
+
+	// Simulated chain adapter
+	r.Register(models.ChainTypeSim, func(config interface{}) (service.ChainAdapter, error) {
+		simConfig, ok := config.(*sim.Config)
+		if !ok {
+			return nil, fmt.Errorf("invalid config type for simulated chain adapter, expected *sim.Config")
+		}
+		return sim.NewAdapter(simConfig)
+	})
 }
 
 // Register registers a new adapter factory for a chain type
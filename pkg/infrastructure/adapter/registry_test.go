@@ -284,8 +284,8 @@ func TestRegistry_SupportedChainTypes(t *testing.T) {
 
 	supportedTypes := registry.SupportedChainTypes()
 
-	if len(supportedTypes) != 6 {
-		t.Errorf("Expected 6 supported chain types, got %d", len(supportedTypes))
+	if len(supportedTypes) != 8 {
+		t.Errorf("Expected 8 supported chain types, got %d", len(supportedTypes))
 	}
 
 	// Check that all expected types are present
@@ -296,6 +296,8 @@ func TestRegistry_SupportedChainTypes(t *testing.T) {
 		models.ChainTypePolkadot:  false,
 		models.ChainTypeAvalanche: false,
 		models.ChainTypeRipple:    false,
+		models.ChainTypeBitcoin:   false,
+		models.ChainTypeSim:       false,
 	}
 
 	for _, chainType := range supportedTypes {
@@ -354,8 +356,8 @@ func TestIsChainSupported(t *testing.T) {
 func TestSupportedChains(t *testing.T) {
 	chains := SupportedChains()
 
-	if len(chains) != 6 {
-		t.Errorf("Expected 6 supported chains, got %d", len(chains))
+	if len(chains) != 8 {
+		t.Errorf("Expected 8 supported chains, got %d", len(chains))
 	}
 }
 
@@ -3,6 +3,7 @@ package ripple
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
 	"github.com/sage-x-project/blockchain-indexer/pkg/domain/service"
@@ -13,9 +14,12 @@ var _ service.ChainAdapter = (*Adapter)(nil)
 
 // Adapter implements the ChainAdapter interface for Ripple (XRP Ledger)
 type Adapter struct {
-	config    *Config
-	chainInfo *models.ChainInfo
-	connected bool
+	config     *Config
+	client     *Client
+	normalizer *Normalizer
+	chainInfo  *models.ChainInfo
+	mu         sync.RWMutex
+	connected  bool
 }
 
 // NewAdapter creates a new Ripple chain adapter
@@ -24,17 +28,19 @@ func NewAdapter(config *Config) (*Adapter, error) {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
-	chainInfo := &models.ChainInfo{
-		ChainType: models.ChainTypeRipple,
-		ChainID:   config.ChainID,
-		Name:      config.ChainName,
-		Network:   config.Network,
+	client, err := NewClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
 	}
 
+	normalizer := NewNormalizer(config.ChainID, config.Network)
+
 	adapter := &Adapter{
-		config:    config,
-		chainInfo: chainInfo,
-		connected: false,
+		config:     config,
+		client:     client,
+		normalizer: normalizer,
+		chainInfo:  normalizer.NormalizeChainInfo(config.ChainName),
+		connected:  false,
 	}
 
 	return adapter, nil
@@ -55,74 +61,291 @@ func (a *Adapter) GetChainInfo() *models.ChainInfo {
 	return a.chainInfo
 }
 
-// GetLatestBlockNumber returns the latest ledger index
+// Capabilities reports the features the adapter supports. Subscriptions
+// need WebSocket to be enabled.
+func (a *Adapter) Capabilities() models.Capabilities {
+	return models.Capabilities{
+		BlockSubscription:       a.config.EnableWebSocket,
+		TransactionSubscription: a.config.EnableWebSocket,
+		BlockByHash:             true,
+		TransactionByHash:       true,
+		FinalityTags:            true,
+		BatchFetch:              true,
+		MaxBlockRange:           uint64(a.config.BatchSize),
+	}
+}
+
+// GetLatestBlockNumber returns the latest validated ledger index
 func (a *Adapter) GetLatestBlockNumber(ctx context.Context) (uint64, error) {
-	// Placeholder - would need to implement rippled RPC call
-	// ledger method with "validated" parameter
-	return 0, fmt.Errorf("GetLatestBlockNumber not yet fully implemented for Ripple")
+	ledger, err := a.client.GetLedgerHeader(ctx, LedgerIndexValidated)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get validated ledger: %w", err)
+	}
+
+	return ledger.LedgerIndex, nil
+}
+
+// GetFinalityHeads returns the latest validated ledger for both heads, since
+// validated ledgers are final
+func (a *Adapter) GetFinalityHeads(ctx context.Context) (*models.FinalityHeads, error) {
+	validated, err := a.GetLatestBlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.FinalityHeads{Safe: validated, Finalized: validated}, nil
 }
 
 // GetBlockByNumber fetches a ledger by index
 func (a *Adapter) GetBlockByNumber(ctx context.Context, number uint64) (*models.Block, error) {
-	// Placeholder - would need to implement ledger RPC call
-	// with transactions and expand parameters
-	return nil, fmt.Errorf("GetBlockByNumber not yet fully implemented for Ripple")
+	ledger, err := a.client.GetLedger(ctx, number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ledger %d: %w", number, err)
+	}
+
+	block, err := a.normalizer.NormalizeBlock(ledger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize ledger %d: %w", number, err)
+	}
+
+	return block, nil
 }
 
 // GetBlockByHash fetches a ledger by hash
 func (a *Adapter) GetBlockByHash(ctx context.Context, hash string) (*models.Block, error) {
-	// Placeholder - would need to implement ledger RPC call with hash
-	return nil, fmt.Errorf("GetBlockByHash not yet fully implemented for Ripple")
+	ledger, err := a.client.GetLedgerByHash(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ledger %s: %w", hash, err)
+	}
+
+	block, err := a.normalizer.NormalizeBlock(ledger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize ledger %s: %w", hash, err)
+	}
+
+	return block, nil
 }
 
 // GetBlocks fetches multiple ledgers in a range
 func (a *Adapter) GetBlocks(ctx context.Context, start, end uint64) ([]*models.Block, error) {
-	// Placeholder - would iterate and call GetBlockByNumber
-	return nil, fmt.Errorf("GetBlocks not yet fully implemented for Ripple")
+	if start > end {
+		return nil, fmt.Errorf("invalid range: start %d > end %d", start, end)
+	}
+
+	// Limit range to the batch size
+	if end-start >= uint64(a.config.BatchSize) {
+		end = start + uint64(a.config.BatchSize) - 1
+	}
+
+	count := int(end - start + 1)
+	blocks := make([]*models.Block, count)
+	errs := make([]error, count)
+
+	// Fetch ledgers concurrently, bounded by the connection limit
+	semaphore := make(chan struct{}, a.config.MaxConnections)
+	var wg sync.WaitGroup
+
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			blocks[i], errs[i] = a.GetBlockByNumber(ctx, start+uint64(i))
+		}(i)
+	}
+
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return blocks, nil
 }
 
 // GetTransaction fetches a transaction by hash
 func (a *Adapter) GetTransaction(ctx context.Context, hash string) (*models.Transaction, error) {
-	// Placeholder - would need to implement tx RPC call
-	return nil, fmt.Errorf("GetTransaction not yet fully implemented for Ripple")
+	tx, err := a.client.GetTransaction(ctx, hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction %s: %w", hash, err)
+	}
+
+	// The tx method only returns the ledger hash from API version 2
+	ledgerHash := tx.LedgerHash
+	if ledgerHash == "" {
+		ledger, err := a.client.GetLedgerHeader(ctx, tx.LedgerIndex)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ledger %d of transaction %s: %w", tx.LedgerIndex, hash, err)
+		}
+		ledgerHash = ledger.LedgerHash
+	}
+
+	domainTx, err := a.normalizer.NormalizeTransaction(tx, tx.Meta, tx.LedgerIndex, ledgerHash, rippleTime(tx.Date))
+	if err != nil {
+		return nil, fmt.Errorf("failed to normalize transaction %s: %w", hash, err)
+	}
+
+	return domainTx, nil
 }
 
 // GetTransactionsByBlock fetches all transactions in a ledger
 func (a *Adapter) GetTransactionsByBlock(ctx context.Context, blockNumber uint64) ([]*models.Transaction, error) {
-	// Placeholder - would get from GetBlockByNumber result
-	return nil, fmt.Errorf("GetTransactionsByBlock not yet fully implemented for Ripple")
+	block, err := a.GetBlockByNumber(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return block.Transactions, nil
 }
 
 // IsHealthy checks if the adapter is healthy
 func (a *Adapter) IsHealthy(ctx context.Context) bool {
-	// Placeholder - would need to implement server_info RPC call
-	return a.connected
+	if !a.IsConnected() {
+		return false
+	}
+
+	info, err := a.client.GetServerInfo(ctx)
+	if err != nil {
+		return false
+	}
+
+	return info.IsSynced()
 }
 
 // Connect connects to the Ripple node
 func (a *Adapter) Connect(ctx context.Context) error {
-	// Placeholder - would establish connection to rippled
+	if a.IsConnected() {
+		return nil
+	}
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if _, err := a.client.GetServerInfo(ctx); err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+
+	a.mu.Lock()
 	a.connected = true
+	a.mu.Unlock()
+
 	return nil
 }
 
 // Disconnect closes the connection
 func (a *Adapter) Disconnect() error {
-	// Placeholder - would close connection
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.connected {
+		return nil
+	}
+
+	if err := a.client.Close(); err != nil {
+		return fmt.Errorf("failed to disconnect: %w", err)
+	}
+
 	a.connected = false
 	return nil
 }
 
-// SubscribeNewBlocks subscribes to new ledgers
+// SubscribeNewBlocks subscribes to new ledgers. Each ledgerClosed message
+// is followed by a ledger request so subscribers receive full blocks.
 func (a *Adapter) SubscribeNewBlocks(ctx context.Context) (service.BlockSubscription, error) {
-	// Placeholder - would use WebSocket to subscribe to ledger stream
-	return nil, fmt.Errorf("block subscriptions not yet implemented for Ripple")
+	if !a.config.EnableWebSocket {
+		return nil, fmt.Errorf("websocket is not enabled: %w", service.ErrNotSupported)
+	}
+
+	stream, err := a.client.Subscribe(ctx, StreamLedger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to ledgers: %w", err)
+	}
+
+	sub := &blockSubscription{
+		stream:    stream,
+		blockChan: make(chan *models.Block, 10),
+		errChan:   make(chan error, 10),
+	}
+
+	go func() {
+		defer close(sub.blockChan)
+
+		for msg := range stream.Messages() {
+			if msg.Type != MessageTypeLedgerClosed {
+				continue
+			}
+
+			block, err := a.GetBlockByNumber(ctx, msg.LedgerIndex)
+			if err != nil {
+				sendError(sub.errChan, err)
+				continue
+			}
+
+			select {
+			case sub.blockChan <- block:
+			case <-stream.done:
+				return
+			}
+		}
+
+		if err := stream.Err(); err != nil {
+			sendError(sub.errChan, err)
+		}
+	}()
+
+	return sub, nil
 }
 
-// SubscribeNewTransactions subscribes to new transactions
+// SubscribeNewTransactions subscribes to validated transactions
 func (a *Adapter) SubscribeNewTransactions(ctx context.Context) (service.TransactionSubscription, error) {
-	// Placeholder - would use WebSocket to subscribe to transactions stream
-	return nil, fmt.Errorf("transaction subscriptions not yet implemented for Ripple")
+	if !a.config.EnableWebSocket {
+		return nil, fmt.Errorf("websocket is not enabled: %w", service.ErrNotSupported)
+	}
+
+	stream, err := a.client.Subscribe(ctx, StreamTransactions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to transactions: %w", err)
+	}
+
+	sub := &transactionSubscription{
+		stream:  stream,
+		txChan:  make(chan *models.Transaction, 100),
+		errChan: make(chan error, 10),
+	}
+
+	go func() {
+		defer close(sub.txChan)
+
+		for msg := range stream.Messages() {
+			if msg.Type != MessageTypeTransaction || !msg.Validated {
+				continue
+			}
+
+			tx, err := a.normalizer.NormalizeStreamTransaction(msg)
+			if err != nil {
+				sendError(sub.errChan, err)
+				continue
+			}
+
+			select {
+			case sub.txChan <- tx:
+			case <-stream.done:
+				return
+			}
+		}
+
+		if err := stream.Err(); err != nil {
+			sendError(sub.errChan, err)
+		}
+	}()
+
+	return sub, nil
 }
 
 // GetConfig returns the adapter configuration
@@ -132,5 +355,7 @@ func (a *Adapter) GetConfig() *Config {
 
 // IsConnected returns whether the adapter is connected
 func (a *Adapter) IsConnected() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
 	return a.connected
 }
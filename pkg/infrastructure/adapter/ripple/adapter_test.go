@@ -1,9 +1,17 @@
 package ripple
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
 )
 
@@ -189,11 +197,8 @@ func TestNewAdapter(t *testing.T) {
 }
 
 func TestAdapter_ConnectDisconnect(t *testing.T) {
-	config := DefaultConfig()
-	adapter, err := NewAdapter(config)
-	if err != nil {
-		t.Fatalf("NewAdapter() error = %v", err)
-	}
+	server := newFakeRippled(t)
+	adapter := newTestAdapter(t, server)
 
 	// Initially not connected
 	if adapter.IsConnected() {
@@ -201,7 +206,7 @@ func TestAdapter_ConnectDisconnect(t *testing.T) {
 	}
 
 	// Connect
-	if err := adapter.Connect(nil); err != nil {
+	if err := adapter.Connect(context.Background()); err != nil {
 		t.Errorf("Connect() error = %v", err)
 	}
 
@@ -209,6 +214,10 @@ func TestAdapter_ConnectDisconnect(t *testing.T) {
 		t.Error("IsConnected() = false, want true after Connect()")
 	}
 
+	if !adapter.IsHealthy(context.Background()) {
+		t.Error("IsHealthy() = false, want true for a synced server")
+	}
+
 	// Disconnect
 	if err := adapter.Disconnect(); err != nil {
 		t.Errorf("Disconnect() error = %v", err)
@@ -219,6 +228,674 @@ func TestAdapter_ConnectDisconnect(t *testing.T) {
 	}
 }
 
+// testLedger is an API version 1 ledger with expanded transactions, listed
+// by hash rather than in applied order
+const testLedger = `{
+	"ledger": {
+		"account_hash": "ACCOUNTHASH",
+		"close_flags": 0,
+		"close_time": 750000000,
+		"close_time_resolution": 10,
+		"closed": true,
+		"ledger_hash": "LEDGERHASH100",
+		"ledger_index": "100",
+		"parent_close_time": 749999990,
+		"parent_hash": "LEDGERHASH99",
+		"total_coins": "99999999999999999",
+		"transaction_hash": "TXROOT",
+		"transactions": [
+			{
+				"hash": "PAYMENT",
+				"TransactionType": "Payment",
+				"Account": "rSender",
+				"Destination": "rReceiver",
+				"DestinationTag": 42,
+				"Amount": {"currency": "USD", "issuer": "rIssuer", "value": "100"},
+				"SendMax": "20000000",
+				"Fee": "12",
+				"Sequence": 7,
+				"Flags": 131072,
+				"Memos": [{"Memo": {"MemoType": "74657874", "MemoData": "68656C6C6F"}}],
+				"metaData": {
+					"TransactionIndex": 1,
+					"TransactionResult": "tesSUCCESS",
+					"delivered_amount": {"currency": "USD", "issuer": "rIssuer", "value": "42.5"}
+				}
+			},
+			{
+				"hash": "OFFER",
+				"TransactionType": "OfferCreate",
+				"Account": "rTrader",
+				"TakerGets": "1000000",
+				"TakerPays": {"currency": "USD", "issuer": "rIssuer", "value": "0.5"},
+				"Fee": "10",
+				"Sequence": 3,
+				"Flags": 0,
+				"metaData": {"TransactionIndex": 0, "TransactionResult": "tesSUCCESS"}
+			},
+			{
+				"hash": "TRUST",
+				"TransactionType": "TrustSet",
+				"Account": "rHolder",
+				"LimitAmount": {"currency": "534F4C4F00000000000000000000000000000000", "issuer": "rSologenic", "value": "1000"},
+				"Fee": "15",
+				"Sequence": 11,
+				"Flags": 0,
+				"metaData": {"TransactionIndex": 2, "TransactionResult": "tecNO_LINE_INSUF_RESERVE"}
+			}
+		]
+	},
+	"ledger_hash": "LEDGERHASH100",
+	"ledger_index": 100,
+	"validated": true,
+	"status": "success"
+}`
+
+const testTx = `{
+	"hash": "XRPPAYMENT",
+	"TransactionType": "Payment",
+	"Account": "rSender",
+	"Destination": "rReceiver",
+	"Amount": "2500000",
+	"Fee": "12",
+	"Sequence": 8,
+	"Flags": 0,
+	"ledger_index": 100,
+	"date": 750000000,
+	"validated": true,
+	"meta": {
+		"TransactionIndex": 3,
+		"TransactionResult": "tesSUCCESS",
+		"delivered_amount": "2500000"
+	},
+	"status": "success"
+}`
+
+// fakeLastLedger is the last ledger the fake rippled has closed. Ledger 100
+// is the latest validated one and holds testLedger; the others are empty.
+const fakeLastLedger = 110
+
+// fakeRippled serves the subset of the rippled JSON-RPC and WebSocket APIs
+// used by the adapter
+type fakeRippled struct {
+	*httptest.Server
+	streams chan []string
+	push    chan interface{}
+}
+
+func newFakeRippled(t *testing.T) *fakeRippled {
+	t.Helper()
+
+	f := &fakeRippled{
+		streams: make(chan []string, 10),
+		push:    make(chan interface{}, 10),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", f.serveRPC)
+	mux.HandleFunc("/ws", f.serveWebSocket)
+	f.Server = httptest.NewServer(mux)
+	t.Cleanup(f.Close)
+	// Release WebSocket handlers before the server waits for them
+	t.Cleanup(func() { close(f.push) })
+
+	return f
+}
+
+func (f *fakeRippled) serveRPC(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Method string                   `json:"method"`
+		Params []map[string]interface{} `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	params := map[string]interface{}{}
+	if len(req.Params) > 0 {
+		params = req.Params[0]
+	}
+
+	var result string
+	switch req.Method {
+	case "server_info":
+		result = `{"info": {"build_version": "2.2.0", "complete_ledgers": "1-100", "server_state": "full",
+			"validated_ledger": {"age": 2, "hash": "LEDGERHASH100", "seq": 100}}, "status": "success"}`
+	case "ledger_closed":
+		result = `{"ledger_hash": "LEDGERHASH101", "ledger_index": 101, "status": "success"}`
+	case "ledger":
+		// Ledgers are looked up by index or by their LEDGERHASH<index> hash
+		var index uint64
+		found := true
+		switch ledger := params["ledger_index"].(type) {
+		case float64:
+			index = uint64(ledger)
+		case nil:
+			_, err := fmt.Sscanf(fmt.Sprint(params["ledger_hash"]), "LEDGERHASH%d", &index)
+			found = err == nil
+		}
+
+		switch {
+		case params["ledger_index"] == LedgerIndexValidated && params["transactions"] == nil:
+			result = `{"ledger": {"ledger_hash": "LEDGERHASH100", "ledger_index": "100", "close_time": 750000000},
+				"ledger_hash": "LEDGERHASH100", "ledger_index": 100, "validated": true, "status": "success"}`
+		case !found || index > fakeLastLedger:
+			result = `{"error": "lgrNotFound", "error_code": 21, "error_message": "ledgerNotFound", "status": "error"}`
+		case index == 100:
+			result = testLedger
+		default:
+			result = fmt.Sprintf(`{"ledger": {"ledger_hash": "LEDGERHASH%[1]d", "ledger_index": "%[1]d",
+				"parent_hash": "LEDGERHASH%[2]d"}, "ledger_hash": "LEDGERHASH%[1]d", "ledger_index": %[1]d,
+				"validated": %[3]t, "status": "success"}`, index, index-1, index <= 100)
+		}
+	case "tx":
+		hash := fmt.Sprint(params["transaction"])
+		if hash == "XRPPAYMENT" {
+			result = testTx
+		} else if tx, ok := testLedgerTransaction(hash); ok {
+			result = tx
+		} else {
+			result = `{"error": "txnNotFound", "error_code": 29, "error_message": "Transaction not found.", "status": "error"}`
+		}
+	default:
+		result = `{"error": "unknownCmd", "error_code": 32, "status": "error"}`
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"result": %s}`, result)
+}
+
+// testLedgerTransaction returns the tx method result for a transaction of
+// testLedger
+func testLedgerTransaction(hash string) (string, bool) {
+	var response struct {
+		Ledger struct {
+			Transactions []map[string]interface{} `json:"transactions"`
+		} `json:"ledger"`
+	}
+	if err := json.Unmarshal([]byte(testLedger), &response); err != nil {
+		panic(err)
+	}
+
+	for _, tx := range response.Ledger.Transactions {
+		if tx["hash"] != hash {
+			continue
+		}
+		tx["meta"] = tx["metaData"]
+		delete(tx, "metaData")
+		tx["ledger_index"] = 100
+		tx["date"] = 750000000
+		tx["validated"] = true
+		tx["status"] = "success"
+
+		data, err := json.Marshal(tx)
+		if err != nil {
+			panic(err)
+		}
+		return string(data), true
+	}
+
+	return "", false
+}
+
+func (f *fakeRippled) serveWebSocket(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	var req struct {
+		ID      int      `json:"id"`
+		Command string   `json:"command"`
+		Streams []string `json:"streams"`
+	}
+	if err := conn.ReadJSON(&req); err != nil {
+		return
+	}
+	f.streams <- req.Streams
+
+	if err := conn.WriteJSON(map[string]interface{}{
+		"id":     req.ID,
+		"type":   MessageTypeResponse,
+		"status": "success",
+		"result": map[string]interface{}{},
+	}); err != nil {
+		return
+	}
+
+	for msg := range f.push {
+		if err := conn.WriteJSON(msg); err != nil {
+			return
+		}
+	}
+}
+
+func (f *fakeRippled) wsURL() string {
+	return "ws" + strings.TrimPrefix(f.URL, "http") + "/ws"
+}
+
+func newTestAdapter(t *testing.T, server *fakeRippled) *Adapter {
+	t.Helper()
+
+	config := DefaultConfig()
+	config.RPCURL = server.URL
+	config.WebSocketURL = server.wsURL()
+	config.EnableWebSocket = true
+	config.Timeout = 5 * time.Second
+	config.RetryAttempts = 1
+	config.RetryDelay = 10 * time.Millisecond
+	config.BatchSize = 5
+
+	adapter, err := NewAdapter(config)
+	if err != nil {
+		t.Fatalf("NewAdapter() error = %v", err)
+	}
+
+	return adapter
+}
+
+func TestAdapter_GetLatestBlockNumber(t *testing.T) {
+	adapter := newTestAdapter(t, newFakeRippled(t))
+
+	number, err := adapter.GetLatestBlockNumber(context.Background())
+	if err != nil {
+		t.Fatalf("GetLatestBlockNumber() error = %v", err)
+	}
+
+	if number != 100 {
+		t.Errorf("GetLatestBlockNumber() = %d, want 100", number)
+	}
+}
+
+func TestAdapter_GetBlockByNumber(t *testing.T) {
+	adapter := newTestAdapter(t, newFakeRippled(t))
+
+	block, err := adapter.GetBlockByNumber(context.Background(), 100)
+	if err != nil {
+		t.Fatalf("GetBlockByNumber() error = %v", err)
+	}
+
+	t.Run("header", func(t *testing.T) {
+		if block.Number != 100 || block.Hash != "LEDGERHASH100" || block.ParentHash != "LEDGERHASH99" {
+			t.Errorf("unexpected header: number=%d hash=%s parent=%s", block.Number, block.Hash, block.ParentHash)
+		}
+
+		want := int64(750000000 + rippleEpochOffset)
+		if block.Timestamp.Unix != want {
+			t.Errorf("Timestamp = %d, want %d", block.Timestamp.Unix, want)
+		}
+
+		if block.StateRoot != "ACCOUNTHASH" || block.TransactionsRoot != "TXROOT" {
+			t.Errorf("unexpected roots: state=%s transactions=%s", block.StateRoot, block.TransactionsRoot)
+		}
+
+		if block.Metadata["validated"] != true {
+			t.Error("expected block to be validated")
+		}
+	})
+
+	t.Run("transactions in applied order", func(t *testing.T) {
+		wantHashes := []string{"OFFER", "PAYMENT", "TRUST"}
+		if block.TxCount != len(wantHashes) {
+			t.Fatalf("TxCount = %d, want %d", block.TxCount, len(wantHashes))
+		}
+
+		for i, hash := range wantHashes {
+			if block.TxHashes[i] != hash {
+				t.Errorf("TxHashes[%d] = %s, want %s", i, block.TxHashes[i], hash)
+			}
+			if block.Transactions[i].Index != uint64(i) {
+				t.Errorf("Transactions[%d].Index = %d, want %d", i, block.Transactions[i].Index, i)
+			}
+		}
+	})
+
+	t.Run("payment uses delivered amount", func(t *testing.T) {
+		tx := block.Transactions[1]
+
+		if tx.From != "rSender" || tx.To != "rReceiver" {
+			t.Errorf("unexpected parties: from=%s to=%s", tx.From, tx.To)
+		}
+		if tx.Value != "42.5" {
+			t.Errorf("Value = %s, want 42.5", tx.Value)
+		}
+		if tx.Metadata["currency"] != "USD" || tx.Metadata["issuer"] != "rIssuer" {
+			t.Errorf("unexpected currency: %v %v", tx.Metadata["currency"], tx.Metadata["issuer"])
+		}
+		if tx.Status != models.TxStatusSuccess {
+			t.Errorf("Status = %s, want %s", tx.Status, models.TxStatusSuccess)
+		}
+		if tx.Fee != "12" || tx.Nonce != 7 {
+			t.Errorf("unexpected fee or sequence: fee=%s sequence=%d", tx.Fee, tx.Nonce)
+		}
+		if tx.Metadata["destination_tag"] != uint64(42) {
+			t.Errorf("destination_tag = %v, want 42", tx.Metadata["destination_tag"])
+		}
+
+		sendMax := tx.Metadata["send_max"].(map[string]interface{})
+		if sendMax["currency"] != CurrencyXRP || sendMax["value"] != "20000000" {
+			t.Errorf("unexpected send_max: %v", sendMax)
+		}
+
+		memos := tx.Metadata["memos"].([]map[string]interface{})
+		if len(memos) != 1 || memos[0]["type"] != "text" || memos[0]["data"] != "hello" {
+			t.Errorf("unexpected memos: %v", memos)
+		}
+	})
+
+	t.Run("offer create", func(t *testing.T) {
+		tx := block.Transactions[0]
+
+		takerGets := tx.Metadata["taker_gets"].(map[string]interface{})
+		if takerGets["currency"] != CurrencyXRP || takerGets["value"] != "1000000" {
+			t.Errorf("unexpected taker_gets: %v", takerGets)
+		}
+
+		takerPays := tx.Metadata["taker_pays"].(map[string]interface{})
+		if takerPays["currency"] != "USD" || takerPays["issuer"] != "rIssuer" || takerPays["value"] != "0.5" {
+			t.Errorf("unexpected taker_pays: %v", takerPays)
+		}
+	})
+
+	t.Run("failed trust set", func(t *testing.T) {
+		tx := block.Transactions[2]
+
+		if tx.Status != models.TxStatusFailed {
+			t.Errorf("Status = %s, want %s", tx.Status, models.TxStatusFailed)
+		}
+		if tx.Metadata["result"] != "tecNO_LINE_INSUF_RESERVE" {
+			t.Errorf("result = %v", tx.Metadata["result"])
+		}
+		if tx.To != "rSologenic" || tx.Value != "1000" {
+			t.Errorf("unexpected trust line: to=%s value=%s", tx.To, tx.Value)
+		}
+		if tx.Metadata["currency"] != "SOLO" {
+			t.Errorf("currency = %v, want SOLO", tx.Metadata["currency"])
+		}
+	})
+}
+
+func TestAdapter_GetBlockByHash(t *testing.T) {
+	adapter := newTestAdapter(t, newFakeRippled(t))
+
+	block, err := adapter.GetBlockByHash(context.Background(), "LEDGERHASH100")
+	if err != nil {
+		t.Fatalf("GetBlockByHash() error = %v", err)
+	}
+
+	if block.Number != 100 {
+		t.Errorf("Number = %d, want 100", block.Number)
+	}
+
+	_, err = adapter.GetBlockByHash(context.Background(), "MISSING")
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) || !rpcErr.IsNotFound() {
+		t.Errorf("GetBlockByHash() error = %v, want lgrNotFound", err)
+	}
+}
+
+func TestAdapter_GetBlocks(t *testing.T) {
+	adapter := newTestAdapter(t, newFakeRippled(t))
+
+	// The range is capped at the batch size
+	blocks, err := adapter.GetBlocks(context.Background(), 98, 110)
+	if err != nil {
+		t.Fatalf("GetBlocks() error = %v", err)
+	}
+
+	if len(blocks) != 5 {
+		t.Fatalf("len(blocks) = %d, want 5", len(blocks))
+	}
+
+	for i, block := range blocks {
+		if block.Number != uint64(98+i) {
+			t.Errorf("blocks[%d].Number = %d, want %d", i, block.Number, 98+i)
+		}
+	}
+
+	if _, err := adapter.GetBlocks(context.Background(), 10, 5); err == nil {
+		t.Error("GetBlocks() with start > end should fail")
+	}
+}
+
+func TestAdapter_GetTransaction(t *testing.T) {
+	adapter := newTestAdapter(t, newFakeRippled(t))
+
+	tx, err := adapter.GetTransaction(context.Background(), "XRPPAYMENT")
+	if err != nil {
+		t.Fatalf("GetTransaction() error = %v", err)
+	}
+
+	if tx.BlockNumber != 100 || tx.Index != 3 {
+		t.Errorf("unexpected position: block=%d index=%d", tx.BlockNumber, tx.Index)
+	}
+	if tx.Value != "2500000" || tx.Metadata["currency"] != CurrencyXRP {
+		t.Errorf("unexpected value: %s %v", tx.Value, tx.Metadata["currency"])
+	}
+	if _, ok := tx.Metadata["issuer"]; ok {
+		t.Error("XRP payments should not have an issuer")
+	}
+	if tx.Status != models.TxStatusSuccess {
+		t.Errorf("Status = %s, want %s", tx.Status, models.TxStatusSuccess)
+	}
+
+	_, err = adapter.GetTransaction(context.Background(), "MISSING")
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) || rpcErr.Code != "txnNotFound" {
+		t.Errorf("GetTransaction() error = %v, want txnNotFound", err)
+	}
+}
+
+func TestClient_GetLedgerClosed(t *testing.T) {
+	server := newFakeRippled(t)
+	adapter := newTestAdapter(t, server)
+
+	closed, err := adapter.client.GetLedgerClosed(context.Background())
+	if err != nil {
+		t.Fatalf("GetLedgerClosed() error = %v", err)
+	}
+
+	if closed.LedgerIndex != 101 || closed.LedgerHash != "LEDGERHASH101" {
+		t.Errorf("unexpected closed ledger: %+v", closed)
+	}
+}
+
+func TestClient_FailsOverToFallbackURL(t *testing.T) {
+	server := newFakeRippled(t)
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	config := DefaultConfig()
+	config.RPCURL = down.URL
+	config.FallbackRPCURLs = []string{server.URL}
+	config.RetryAttempts = 0
+
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	defer client.Close()
+
+	closed, err := client.GetLedgerClosed(context.Background())
+	if err != nil {
+		t.Fatalf("GetLedgerClosed() error = %v", err)
+	}
+	if closed.LedgerIndex != 101 {
+		t.Errorf("LedgerIndex = %d, want 101", closed.LedgerIndex)
+	}
+
+	if client.pool.Status()[0].Errors == 0 {
+		t.Error("the unreachable primary URL should have recorded an error")
+	}
+	if client.pool.Select().URL != server.URL {
+		t.Error("the fallback URL should be preferred after the primary failed")
+	}
+}
+
+func TestAdapter_SubscribeNewBlocks(t *testing.T) {
+	server := newFakeRippled(t)
+	adapter := newTestAdapter(t, server)
+
+	sub, err := adapter.SubscribeNewBlocks(context.Background())
+	if err != nil {
+		t.Fatalf("SubscribeNewBlocks() error = %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	if streams := <-server.streams; len(streams) != 1 || streams[0] != StreamLedger {
+		t.Errorf("subscribed to %v, want [%s]", streams, StreamLedger)
+	}
+
+	server.push <- map[string]interface{}{
+		"type":         MessageTypeLedgerClosed,
+		"ledger_hash":  "LEDGERHASH100",
+		"ledger_index": 100,
+		"txn_count":    3,
+	}
+
+	select {
+	case block := <-sub.Channel():
+		if block.Number != 100 || block.TxCount != 3 {
+			t.Errorf("unexpected block: number=%d txs=%d", block.Number, block.TxCount)
+		}
+	case err := <-sub.Err():
+		t.Fatalf("subscription error = %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for block")
+	}
+}
+
+func TestAdapter_SubscribeNewTransactions(t *testing.T) {
+	server := newFakeRippled(t)
+	adapter := newTestAdapter(t, server)
+
+	sub, err := adapter.SubscribeNewTransactions(context.Background())
+	if err != nil {
+		t.Fatalf("SubscribeNewTransactions() error = %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	if streams := <-server.streams; len(streams) != 1 || streams[0] != StreamTransactions {
+		t.Errorf("subscribed to %v, want [%s]", streams, StreamTransactions)
+	}
+
+	message := func(hash string, validated bool) map[string]interface{} {
+		return map[string]interface{}{
+			"type":          MessageTypeTransaction,
+			"engine_result": ResultSuccess,
+			"ledger_hash":   "LEDGERHASH101",
+			"ledger_index":  101,
+			"validated":     validated,
+			"transaction": map[string]interface{}{
+				"hash":            hash,
+				"TransactionType": TxTypePayment,
+				"Account":         "rSender",
+				"Destination":     "rReceiver",
+				"Amount":          "1000",
+				"Fee":             "12",
+				"date":            750000010,
+			},
+			"meta": map[string]interface{}{
+				"TransactionIndex":  0,
+				"TransactionResult": ResultSuccess,
+				"delivered_amount":  "1000",
+			},
+		}
+	}
+
+	// Unvalidated transactions are skipped
+	server.push <- message("PROPOSED", false)
+	server.push <- message("VALIDATED", true)
+
+	select {
+	case tx := <-sub.Channel():
+		if tx.Hash != "VALIDATED" {
+			t.Errorf("Hash = %s, want VALIDATED", tx.Hash)
+		}
+		if tx.BlockNumber != 101 || tx.BlockHash != "LEDGERHASH101" || tx.Value != "1000" {
+			t.Errorf("unexpected transaction: %+v", tx)
+		}
+	case err := <-sub.Err():
+		t.Fatalf("subscription error = %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for transaction")
+	}
+}
+
+func TestAdapter_SubscribeWebSocketDisabled(t *testing.T) {
+	adapter, err := NewAdapter(DefaultConfig())
+	if err != nil {
+		t.Fatalf("NewAdapter() error = %v", err)
+	}
+
+	if _, err := adapter.SubscribeNewBlocks(context.Background()); err == nil {
+		t.Error("SubscribeNewBlocks() should fail when websocket is disabled")
+	}
+	if _, err := adapter.SubscribeNewTransactions(context.Background()); err == nil {
+		t.Error("SubscribeNewTransactions() should fail when websocket is disabled")
+	}
+}
+
+func TestAmount_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Amount
+		wantErr bool
+	}{
+		{
+			name:  "xrp drops",
+			input: `"1000000"`,
+			want:  Amount{Currency: CurrencyXRP, Value: "1000000"},
+		},
+		{
+			name:  "issued currency",
+			input: `{"currency": "USD", "issuer": "rIssuer", "value": "1.5"}`,
+			want:  Amount{Currency: "USD", Issuer: "rIssuer", Value: "1.5"},
+		},
+		{
+			name:    "unavailable",
+			input:   `"unavailable"`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got Amount
+			err := json.Unmarshal([]byte(tt.input), &got)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("UnmarshalJSON() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurrencyCode(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"USD", "USD"},
+		{"XRP", "XRP"},
+		{"534F4C4F00000000000000000000000000000000", "SOLO"},
+		// LP token codes are not text and are kept as hex
+		{"03930D02208264E2E40EC1B0C09E4DB96EE197B1", "03930D02208264E2E40EC1B0C09E4DB96EE197B1"},
+	}
+
+	for _, tt := range tests {
+		if got := currencyCode(tt.code); got != tt.want {
+			t.Errorf("currencyCode(%s) = %s, want %s", tt.code, got, tt.want)
+		}
+	}
+}
+
 // Benchmark tests
 func BenchmarkConfig_Validate(b *testing.B) {
 	config := DefaultConfig()
@@ -0,0 +1,297 @@
+package ripple
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/endpoint"
+)
+
+// Client wraps the rippled JSON-RPC API
+type Client struct {
+	config     *Config
+	httpClient *http.Client
+	pool       *endpoint.Pool
+	errorCount atomic.Int32
+	mu         sync.RWMutex
+	connected  bool
+}
+
+// NewClient creates a new rippled RPC client
+func NewClient(config *Config) (*Client, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	client := &Client{
+		config: config,
+		httpClient: &http.Client{
+			Timeout: config.Timeout,
+			Transport: &http.Transport{
+				MaxConnsPerHost:     config.MaxConnections,
+				MaxIdleConnsPerHost: config.MaxConnections,
+			},
+		},
+		connected: true,
+	}
+
+	poolConfig := endpoint.DefaultConfig(config.ChainID)
+	poolConfig.MaxLag = config.MaxEndpointLag
+	pool, err := endpoint.NewPool(config.RPCURLs(), poolConfig, client.probeLedger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create endpoint pool: %w", err)
+	}
+	client.pool = pool
+
+	return client, nil
+}
+
+// call makes a JSON-RPC call to rippled. rippled reports failures inside the
+// result object rather than as a JSON-RPC error, so the status is checked
+// before the result is decoded. Requests go to the healthiest endpoint and
+// fail over to the others.
+func (c *Client) call(ctx context.Context, method string, params map[string]interface{}, result interface{}) error {
+	reqBody, err := newRequest(method, params)
+	if err != nil {
+		return err
+	}
+
+	// Execute request with retries
+	var lastErr error
+	for attempt := 0; attempt <= c.config.RetryAttempts; attempt++ {
+		if attempt > 0 {
+			// Wait before retry
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(c.config.RetryDelay):
+			}
+		}
+
+		err := c.pool.Do(ctx, func(ctx context.Context, e *endpoint.Endpoint) error {
+			return c.post(ctx, e.URL, reqBody, result)
+		})
+		if err == nil {
+			// Reset error count on success
+			c.errorCount.Store(0)
+			return nil
+		}
+		if endpoint.IsPermanent(err) {
+			return err
+		}
+
+		lastErr = err
+		c.errorCount.Add(1)
+	}
+
+	return fmt.Errorf("request failed after %d retries: %w", c.config.RetryAttempts, lastErr)
+}
+
+// newRequest encodes a rippled JSON-RPC request
+func newRequest(method string, params map[string]interface{}) ([]byte, error) {
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+
+	reqBody, err := json.Marshal(RPCRequest{
+		Method: method,
+		Params: []interface{}{params},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	return reqBody, nil
+}
+
+// post sends a request body to a single RPC endpoint and decodes the result.
+// Errors reported by rippled itself are marked permanent so the pool neither
+// fails over nor counts them against the endpoint.
+func (c *Client) post(ctx context.Context, url string, reqBody []byte, result interface{}) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(reqBody))
+	if err != nil {
+		return endpoint.Permanent(fmt.Errorf("failed to create request: %w", err))
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	// Parse response
+	var rpcResp RPCResponse
+	if err := json.Unmarshal(body, &rpcResp); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	var status RPCStatus
+	if err := json.Unmarshal(rpcResp.Result, &status); err != nil {
+		return fmt.Errorf("failed to unmarshal status: %w", err)
+	}
+
+	// Check for rippled error
+	if status.Status != "success" {
+		return endpoint.Permanent(&RPCError{
+			Code:    status.Error,
+			Number:  status.ErrorCode,
+			Message: status.ErrorMessage,
+		})
+	}
+
+	// Unmarshal result
+	if result != nil {
+		if err := json.Unmarshal(rpcResp.Result, result); err != nil {
+			return endpoint.Permanent(fmt.Errorf("failed to unmarshal result: %w", err))
+		}
+	}
+
+	return nil
+}
+
+// probeLedger returns the most recently closed ledger index of a single
+// endpoint for the pool's head tracking
+func (c *Client) probeLedger(ctx context.Context, e *endpoint.Endpoint) (uint64, error) {
+	reqBody, err := newRequest("ledger_closed", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var result LedgerClosedResponse
+	if err := c.post(ctx, e.URL, reqBody, &result); err != nil {
+		return 0, err
+	}
+
+	return result.LedgerIndex, nil
+}
+
+// GetLedger returns a ledger by index or shortcut with its transactions expanded
+func (c *Client) GetLedger(ctx context.Context, ledgerIndex interface{}) (*LedgerResponse, error) {
+	return c.getLedger(ctx, map[string]interface{}{
+		"ledger_index": ledgerIndex,
+		"transactions": true,
+		"expand":       true,
+	})
+}
+
+// GetLedgerByHash returns a ledger by hash with its transactions expanded
+func (c *Client) GetLedgerByHash(ctx context.Context, hash string) (*LedgerResponse, error) {
+	return c.getLedger(ctx, map[string]interface{}{
+		"ledger_hash":  hash,
+		"transactions": true,
+		"expand":       true,
+	})
+}
+
+// GetLedgerHeader returns a ledger without its transactions
+func (c *Client) GetLedgerHeader(ctx context.Context, ledgerIndex interface{}) (*LedgerResponse, error) {
+	return c.getLedger(ctx, map[string]interface{}{
+		"ledger_index": ledgerIndex,
+	})
+}
+
+func (c *Client) getLedger(ctx context.Context, params map[string]interface{}) (*LedgerResponse, error) {
+	var result LedgerResponse
+	if err := c.call(ctx, "ledger", params, &result); err != nil {
+		if isNotFound(err) {
+			return nil, fmt.Errorf("ledger: %w: %w", models.ErrBlockNotFound, err)
+		}
+		return nil, fmt.Errorf("ledger: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetLedgerClosed returns the most recently closed ledger, which may not be
+// validated yet
+func (c *Client) GetLedgerClosed(ctx context.Context) (*LedgerClosedResponse, error) {
+	var result LedgerClosedResponse
+	if err := c.call(ctx, "ledger_closed", nil, &result); err != nil {
+		return nil, fmt.Errorf("ledger_closed: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetTransaction returns a transaction with its metadata by hash
+func (c *Client) GetTransaction(ctx context.Context, hash string) (*Transaction, error) {
+	var result Transaction
+	params := map[string]interface{}{
+		"transaction": hash,
+		"binary":      false,
+	}
+
+	if err := c.call(ctx, "tx", params, &result); err != nil {
+		if isNotFound(err) {
+			return nil, fmt.Errorf("tx: %w: %w", models.ErrTransactionNotFound, err)
+		}
+		return nil, fmt.Errorf("tx: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetServerInfo returns the state of the rippled server
+func (c *Client) GetServerInfo(ctx context.Context) (*ServerInfo, error) {
+	var result ServerInfoResponse
+	if err := c.call(ctx, "server_info", nil, &result); err != nil {
+		return nil, fmt.Errorf("server_info: %w", err)
+	}
+
+	return &result.Info, nil
+}
+
+// isNotFound reports whether rippled answered that the ledger or
+// transaction does not exist
+func isNotFound(err error) bool {
+	var rpcErr *RPCError
+	return errors.As(err, &rpcErr) && rpcErr.IsNotFound()
+}
+
+// HealthStatus returns the current health status
+type HealthStatus struct {
+	Connected  bool
+	ErrorCount int32
+}
+
+// GetHealthStatus returns the current health status
+func (c *Client) GetHealthStatus() *HealthStatus {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return &HealthStatus{
+		Connected:  c.connected,
+		ErrorCount: c.errorCount.Load(),
+	}
+}
+
+// Close closes the client
+func (c *Client) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.connected = false
+	c.pool.Close()
+	c.httpClient.CloseIdleConnections()
+
+	return nil
+}
@@ -20,6 +20,13 @@ type Config struct {
 	// RPCURL is the rippled RPC endpoint URL
 	RPCURL string `yaml:"rpc_url" json:"rpc_url"`
 
+	// FallbackRPCURLs are RPC endpoints used when RPCURL is unhealthy
+	FallbackRPCURLs []string `yaml:"fallback_rpc_urls,omitempty" json:"fallback_rpc_urls,omitempty"`
+
+	// MaxEndpointLag is how many ledgers an RPC endpoint may fall behind the
+	// others before requests stop being routed to it
+	MaxEndpointLag uint64 `yaml:"max_endpoint_lag" json:"max_endpoint_lag"`
+
 	// WebSocketURL is the WebSocket endpoint URL
 	WebSocketURL string `yaml:"websocket_url" json:"websocket_url"`
 
@@ -60,6 +67,7 @@ func DefaultConfig() *Config {
 		RetryAttempts:              3,
 		RetryDelay:                 1 * time.Second,
 		MaxConnections:             10,
+		MaxEndpointLag:             10,
 		BatchSize:                  100,
 		StartLedger:                0,
 		EnableWebSocket:            false,
@@ -79,6 +87,7 @@ func TestnetConfig() *Config {
 		RetryAttempts:              3,
 		RetryDelay:                 1 * time.Second,
 		MaxConnections:             10,
+		MaxEndpointLag:             10,
 		BatchSize:                  100,
 		StartLedger:                0,
 		EnableWebSocket:            false,
@@ -98,6 +107,7 @@ func DevnetConfig() *Config {
 		RetryAttempts:              3,
 		RetryDelay:                 1 * time.Second,
 		MaxConnections:             10,
+		MaxEndpointLag:             10,
 		BatchSize:                  100,
 		StartLedger:                0,
 		EnableWebSocket:            false,
@@ -166,6 +176,11 @@ func (c *Config) GetRPCURL() string {
 	return c.RPCURL
 }
 
+// RPCURLs returns the RPC URL followed by the fallback URLs
+func (c *Config) RPCURLs() []string {
+	return append([]string{c.RPCURL}, c.FallbackRPCURLs...)
+}
+
 // GetWebSocketURL returns the WebSocket URL
 func (c *Config) GetWebSocketURL() string {
 	return c.WebSocketURL
@@ -0,0 +1,27 @@
+package ripple
+
+import (
+	"testing"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/service"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/adaptertest"
+)
+
+func TestAdapter_Conformance(t *testing.T) {
+	server := newFakeRippled(t)
+
+	adaptertest.RunConformance(t, func(t *testing.T) service.ChainAdapter {
+		adapter := newTestAdapter(t, server)
+		t.Cleanup(func() { adapter.Disconnect() })
+		return adapter
+	}, adaptertest.Backend{
+		ChainType:        models.ChainTypeRipple,
+		ChainID:          DefaultConfig().ChainID,
+		First:            96,
+		Last:             100,
+		TxHash:           "PAYMENT",
+		UnknownBlockHash: "MISSING",
+		UnknownTxHash:    "MISSING",
+	})
+}
@@ -0,0 +1,323 @@
+package ripple
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+)
+
+// Normalizer converts XRPL-specific types to domain models
+type Normalizer struct {
+	chainID string
+	network string
+}
+
+// NewNormalizer creates a new XRPL normalizer
+func NewNormalizer(chainID, network string) *Normalizer {
+	return &Normalizer{
+		chainID: chainID,
+		network: network,
+	}
+}
+
+// NormalizeChainInfo returns the chain information
+func (n *Normalizer) NormalizeChainInfo(name string) *models.ChainInfo {
+	return &models.ChainInfo{
+		ChainType: models.ChainTypeRipple,
+		ChainID:   n.chainID,
+		Name:      name,
+		Network:   n.network,
+	}
+}
+
+// NormalizeBlock converts a ledger to a domain Block
+func (n *Normalizer) NormalizeBlock(resp *LedgerResponse) (*models.Block, error) {
+	if resp == nil {
+		return nil, fmt.Errorf("ledger is nil")
+	}
+
+	ledger := &resp.Ledger
+	number := uint64(ledger.LedgerIndex)
+	if number == 0 {
+		number = resp.LedgerIndex
+	}
+	hash := ledger.LedgerHash
+	if hash == "" {
+		hash = resp.LedgerHash
+	}
+
+	closeTime := rippleTime(ledger.CloseTime)
+
+	// Extract transactions
+	transactions := make([]*models.Transaction, 0, len(ledger.Transactions))
+	for i := range ledger.Transactions {
+		entry := &ledger.Transactions[i]
+		domainTx, err := n.NormalizeTransaction(entry.Tx(), entry.Metadata(), number, hash, closeTime)
+		if err != nil {
+			return nil, fmt.Errorf("failed to normalize transaction %d: %w", i, err)
+		}
+		transactions = append(transactions, domainTx)
+	}
+
+	// Ledgers list transactions by hash, the metadata holds the applied order
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].Index < transactions[j].Index
+	})
+
+	domainBlock := &models.Block{
+		ChainID:          n.chainID,
+		ChainType:        models.ChainTypeRipple,
+		Number:           number,
+		Hash:             hash,
+		ParentHash:       ledger.ParentHash,
+		Timestamp:        models.NewTimestamp(closeTime.Unix()),
+		Transactions:     transactions,
+		TxCount:          len(transactions),
+		TxHashes:         make([]string, 0, len(transactions)),
+		StateRoot:        ledger.AccountHash,
+		TransactionsRoot: ledger.TransactionHash,
+		Metadata:         make(map[string]interface{}),
+	}
+
+	for _, tx := range transactions {
+		domainBlock.TxHashes = append(domainBlock.TxHashes, tx.Hash)
+	}
+
+	// Add XRPL-specific data
+	domainBlock.Metadata["total_coins"] = ledger.TotalCoins
+	domainBlock.Metadata["close_time_resolution"] = ledger.CloseTimeResolution
+	domainBlock.Metadata["close_flags"] = ledger.CloseFlags
+	domainBlock.Metadata["parent_close_time"] = rippleTime(ledger.ParentCloseTime).Unix()
+	domainBlock.Metadata["validated"] = resp.Validated
+
+	return domainBlock, nil
+}
+
+// NormalizeTransaction converts an XRPL transaction and its metadata to a
+// domain Transaction
+func (n *Normalizer) NormalizeTransaction(
+	tx *Transaction,
+	meta *TransactionMeta,
+	ledgerIndex uint64,
+	ledgerHash string,
+	closeTime time.Time,
+) (*models.Transaction, error) {
+	if tx == nil {
+		return nil, fmt.Errorf("transaction is nil")
+	}
+	if tx.Hash == "" {
+		return nil, fmt.Errorf("transaction hash is empty")
+	}
+	if meta == nil {
+		meta = tx.Meta
+	}
+
+	domainTx := &models.Transaction{
+		ChainID:     n.chainID,
+		ChainType:   models.ChainTypeRipple,
+		Hash:        tx.Hash,
+		BlockNumber: ledgerIndex,
+		BlockHash:   ledgerHash,
+		From:        tx.Account,
+		To:          tx.Destination,
+		Fee:         tx.Fee,
+		Nonce:       tx.Sequence,
+		Status:      models.TxStatusPending,
+		Timestamp:   models.NewTimestamp(closeTime.Unix()),
+		Logs:        make([]*models.Log, 0),
+		Metadata:    make(map[string]interface{}),
+	}
+
+	if tx.TxnSignature != "" {
+		if sig, err := hex.DecodeString(tx.TxnSignature); err == nil {
+			domainTx.Signature = sig
+		}
+	}
+
+	domainTx.Metadata["transaction_type"] = tx.TransactionType
+	domainTx.Metadata["flags"] = tx.Flags
+	if tx.TicketSequence != 0 {
+		domainTx.Metadata["ticket_sequence"] = tx.TicketSequence
+	}
+	if tx.SourceTag != nil {
+		domainTx.Metadata["source_tag"] = *tx.SourceTag
+	}
+	if tx.DestinationTag != nil {
+		domainTx.Metadata["destination_tag"] = *tx.DestinationTag
+	}
+	if len(tx.Memos) > 0 {
+		domainTx.Metadata["memos"] = normalizeMemos(tx.Memos)
+	}
+
+	// Transactions without metadata have not been applied to a ledger yet
+	if meta != nil {
+		domainTx.Index = meta.TransactionIndex
+		domainTx.Metadata["result"] = meta.TransactionResult
+		if meta.TransactionResult == ResultSuccess {
+			domainTx.Status = models.TxStatusSuccess
+		} else if meta.TransactionResult != "" {
+			domainTx.Status = models.TxStatusFailed
+		}
+	}
+
+	switch tx.TransactionType {
+	case TxTypePayment:
+		n.normalizePayment(domainTx, tx, meta)
+	case TxTypeOfferCreate:
+		if tx.TakerGets != nil {
+			domainTx.Metadata["taker_gets"] = normalizeAmount(tx.TakerGets)
+		}
+		if tx.TakerPays != nil {
+			domainTx.Metadata["taker_pays"] = normalizeAmount(tx.TakerPays)
+		}
+		if tx.OfferSequence != 0 {
+			domainTx.Metadata["offer_sequence"] = tx.OfferSequence
+		}
+	case TxTypeOfferCancel:
+		domainTx.Metadata["offer_sequence"] = tx.OfferSequence
+	case TxTypeTrustSet:
+		if tx.LimitAmount != nil {
+			// The trust line limit is towards the issuer of the currency
+			domainTx.Metadata["limit_amount"] = normalizeAmount(tx.LimitAmount)
+			domainTx.To = tx.LimitAmount.Issuer
+			n.setValue(domainTx, tx.LimitAmount)
+		}
+	default:
+		// Other transaction types that move value (escrows, checks,
+		// payment channels) carry a plain Amount
+		if tx.Amount != nil {
+			n.setValue(domainTx, tx.Amount)
+		}
+	}
+
+	return domainTx, nil
+}
+
+// normalizePayment sets the value of a payment to the delivered amount. For
+// partial payments the Amount field is only an upper bound, so the
+// delivered amount from the metadata is preferred whenever it is available.
+func (n *Normalizer) normalizePayment(domainTx *models.Transaction, tx *Transaction, meta *TransactionMeta) {
+	amount := tx.Amount
+	if amount == nil {
+		// API version 2 renames Amount to DeliverMax
+		amount = tx.DeliverMax
+	}
+	if amount != nil {
+		domainTx.Metadata["amount"] = normalizeAmount(amount)
+	}
+
+	if delivered := meta.Delivered(); delivered != nil {
+		domainTx.Metadata["delivered_amount"] = normalizeAmount(delivered)
+		amount = delivered
+	}
+	if amount != nil {
+		n.setValue(domainTx, amount)
+	}
+
+	if tx.SendMax != nil {
+		domainTx.Metadata["send_max"] = normalizeAmount(tx.SendMax)
+	}
+	if tx.DeliverMin != nil {
+		domainTx.Metadata["deliver_min"] = normalizeAmount(tx.DeliverMin)
+	}
+}
+
+// setValue sets the transaction value and records the currency it is in.
+// XRP values are in drops, issued currency values are decimal strings.
+func (n *Normalizer) setValue(domainTx *models.Transaction, amount *Amount) {
+	domainTx.Value = amount.Value
+	domainTx.Metadata["currency"] = currencyCode(amount.Currency)
+	if !amount.IsXRP() {
+		domainTx.Metadata["issuer"] = amount.Issuer
+	}
+}
+
+// NormalizeStreamTransaction converts a transaction from the transactions
+// stream to a domain Transaction
+func (n *Normalizer) NormalizeStreamTransaction(msg *StreamMessage) (*models.Transaction, error) {
+	if msg.Transaction == nil {
+		return nil, fmt.Errorf("stream message has no transaction")
+	}
+
+	meta := msg.Meta
+	if meta == nil && msg.EngineResult != "" {
+		meta = &TransactionMeta{TransactionResult: msg.EngineResult}
+	}
+
+	ledgerIndex := msg.LedgerIndex
+	if ledgerIndex == 0 {
+		ledgerIndex = msg.Transaction.LedgerIndex
+	}
+
+	return n.NormalizeTransaction(msg.Transaction, meta, ledgerIndex, msg.LedgerHash, rippleTime(msg.Transaction.Date))
+}
+
+// normalizeAmount converts an amount to a metadata map
+func normalizeAmount(amount *Amount) map[string]interface{} {
+	result := map[string]interface{}{
+		"currency": currencyCode(amount.Currency),
+		"value":    amount.Value,
+	}
+	if !amount.IsXRP() {
+		result["issuer"] = amount.Issuer
+	}
+	return result
+}
+
+// normalizeMemos converts memos to metadata, decoding hex fields when they
+// hold printable text
+func normalizeMemos(memos []Memo) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(memos))
+	for _, m := range memos {
+		memo := make(map[string]interface{})
+		if m.Memo.MemoType != "" {
+			memo["type"] = decodeHexText(m.Memo.MemoType)
+		}
+		if m.Memo.MemoFormat != "" {
+			memo["format"] = decodeHexText(m.Memo.MemoFormat)
+		}
+		if m.Memo.MemoData != "" {
+			memo["data"] = decodeHexText(m.Memo.MemoData)
+		}
+		result = append(result, memo)
+	}
+	return result
+}
+
+// currencyCode returns a readable currency code. Non-standard codes are
+// 40 hex characters and usually hold ASCII text padded with zero bytes.
+func currencyCode(code string) string {
+	if len(code) != 40 {
+		return code
+	}
+	return decodeHexText(code)
+}
+
+// decodeHexText decodes hex into text, returning the input unchanged when it
+// is not hex or does not decode to printable ASCII
+func decodeHexText(s string) string {
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		return s
+	}
+
+	text := strings.TrimRight(string(data), "\x00")
+	if text == "" {
+		return s
+	}
+	for i := 0; i < len(text); i++ {
+		if text[i] < 0x20 || text[i] > 0x7e {
+			return s
+		}
+	}
+	return text
+}
+
+// rippleTime converts seconds since the Ripple epoch to a time
+func rippleTime(seconds int64) time.Time {
+	return time.Unix(seconds+rippleEpochOffset, 0).UTC()
+}
@@ -0,0 +1,188 @@
+package ripple
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+)
+
+// Stream is a WebSocket connection subscribed to one or more rippled streams
+type Stream struct {
+	conn      *websocket.Conn
+	messages  chan *StreamMessage
+	done      chan struct{}
+	closeOnce sync.Once
+
+	mu  sync.Mutex
+	err error
+}
+
+// Subscribe opens a WebSocket connection and subscribes to the given streams.
+// The stream is closed when ctx is done or Close is called.
+func (c *Client) Subscribe(ctx context.Context, streams ...string) (*Stream, error) {
+	if c.config.WebSocketURL == "" {
+		return nil, fmt.Errorf("websocket_url is not configured")
+	}
+
+	dialer := websocket.Dialer{HandshakeTimeout: c.config.Timeout}
+	conn, _, err := dialer.DialContext(ctx, c.config.WebSocketURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect websocket: %w", err)
+	}
+
+	request := map[string]interface{}{
+		"id":      1,
+		"command": "subscribe",
+		"streams": streams,
+	}
+	if err := conn.WriteJSON(request); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to send subscribe request: %w", err)
+	}
+
+	// Wait for the subscribe response before streaming
+	conn.SetReadDeadline(time.Now().Add(c.config.Timeout))
+	var resp StreamMessage
+	if err := conn.ReadJSON(&resp); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to read subscribe response: %w", err)
+	}
+	if resp.Type == MessageTypeResponse && resp.Status != "success" {
+		conn.Close()
+		return nil, fmt.Errorf("subscribe failed: %s", resp.Error)
+	}
+	conn.SetReadDeadline(time.Time{})
+
+	s := &Stream{
+		conn:     conn,
+		messages: make(chan *StreamMessage, 100),
+		done:     make(chan struct{}),
+	}
+
+	go s.readLoop()
+	go func() {
+		select {
+		case <-ctx.Done():
+			s.Close()
+		case <-s.done:
+		}
+	}()
+
+	return s, nil
+}
+
+// readLoop delivers stream messages until the connection fails or is closed
+func (s *Stream) readLoop() {
+	defer close(s.messages)
+
+	for {
+		var msg StreamMessage
+		if err := s.conn.ReadJSON(&msg); err != nil {
+			select {
+			case <-s.done:
+				// Closed by the subscriber
+			default:
+				s.setErr(fmt.Errorf("websocket read failed: %w", err))
+			}
+			return
+		}
+
+		if msg.Type == MessageTypeResponse {
+			continue
+		}
+
+		select {
+		case s.messages <- &msg:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// Messages returns the channel of stream messages. It is closed when the
+// stream ends; Err then reports why.
+func (s *Stream) Messages() <-chan *StreamMessage {
+	return s.messages
+}
+
+// Err returns the error that ended the stream, or nil if it was closed
+func (s *Stream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+func (s *Stream) setErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+// Close closes the WebSocket connection
+func (s *Stream) Close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+		s.conn.WriteControl(
+			websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+			time.Now().Add(time.Second),
+		)
+		s.conn.Close()
+	})
+}
+
+// blockSubscription implements service.BlockSubscription for the XRP Ledger
+type blockSubscription struct {
+	stream    *Stream
+	blockChan chan *models.Block
+	errChan   chan error
+}
+
+// Channel returns the channel that receives new blocks
+func (s *blockSubscription) Channel() <-chan *models.Block {
+	return s.blockChan
+}
+
+// Unsubscribe cancels the subscription
+func (s *blockSubscription) Unsubscribe() {
+	s.stream.Close()
+}
+
+// Err returns any subscription error
+func (s *blockSubscription) Err() <-chan error {
+	return s.errChan
+}
+
+// transactionSubscription implements service.TransactionSubscription for the XRP Ledger
+type transactionSubscription struct {
+	stream  *Stream
+	txChan  chan *models.Transaction
+	errChan chan error
+}
+
+// Channel returns the channel that receives new transactions
+func (s *transactionSubscription) Channel() <-chan *models.Transaction {
+	return s.txChan
+}
+
+// Unsubscribe cancels the subscription
+func (s *transactionSubscription) Unsubscribe() {
+	s.stream.Close()
+}
+
+// Err returns any subscription error
+func (s *transactionSubscription) Err() <-chan error {
+	return s.errChan
+}
+
+// sendError reports an error without blocking when nobody is reading
+func sendError(errChan chan error, err error) {
+	select {
+	case errChan <- err:
+	default:
+	}
+}
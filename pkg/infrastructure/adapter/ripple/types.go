@@ -0,0 +1,318 @@
+package ripple
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+// rippled API types - these match the rippled JSON-RPC and WebSocket responses
+
+// Ledger index shortcuts accepted by rippled
+const (
+	LedgerIndexValidated = "validated"
+	LedgerIndexClosed    = "closed"
+	LedgerIndexCurrent   = "current"
+)
+
+// Subscription stream names
+const (
+	StreamLedger       = "ledger"
+	StreamTransactions = "transactions"
+)
+
+// WebSocket message types
+const (
+	MessageTypeLedgerClosed = "ledgerClosed"
+	MessageTypeTransaction  = "transaction"
+	MessageTypeResponse     = "response"
+)
+
+// Transaction result codes
+const (
+	ResultSuccess = "tesSUCCESS"
+)
+
+// Transaction types with dedicated normalization
+const (
+	TxTypePayment     = "Payment"
+	TxTypeOfferCreate = "OfferCreate"
+	TxTypeOfferCancel = "OfferCancel"
+	TxTypeTrustSet    = "TrustSet"
+)
+
+// CurrencyXRP is the currency code used for native XRP amounts
+const CurrencyXRP = "XRP"
+
+// rippleEpochOffset is the number of seconds between the Unix epoch and the
+// Ripple epoch (2000-01-01T00:00:00Z), which ledger close times count from
+const rippleEpochOffset = 946684800
+
+// RPCRequest represents a rippled JSON-RPC request
+type RPCRequest struct {
+	Method string        `json:"method"`
+	Params []interface{} `json:"params"`
+}
+
+// RPCResponse represents a rippled JSON-RPC response
+type RPCResponse struct {
+	Result json.RawMessage `json:"result"`
+}
+
+// RPCStatus holds the status fields rippled adds to every result
+type RPCStatus struct {
+	Status       string `json:"status"`
+	Error        string `json:"error,omitempty"`
+	ErrorCode    int    `json:"error_code,omitempty"`
+	ErrorMessage string `json:"error_message,omitempty"`
+}
+
+// RPCError represents an error returned by rippled
+type RPCError struct {
+	Code    string
+	Number  int
+	Message string
+}
+
+// Error implements the error interface
+func (e *RPCError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("rippled error %s (%d): %s", e.Code, e.Number, e.Message)
+	}
+	return fmt.Sprintf("rippled error %s (%d)", e.Code, e.Number)
+}
+
+// IsNotFound reports whether the error means the ledger or transaction does not exist
+func (e *RPCError) IsNotFound() bool {
+	return e.Code == "lgrNotFound" || e.Code == "txnNotFound"
+}
+
+// LedgerResponse represents the result of the ledger method
+type LedgerResponse struct {
+	Ledger      Ledger `json:"ledger"`
+	LedgerHash  string `json:"ledger_hash"`
+	LedgerIndex uint64 `json:"ledger_index"`
+	Validated   bool   `json:"validated"`
+}
+
+// Ledger represents a ledger header with optional expanded transactions
+type Ledger struct {
+	AccountHash         string              `json:"account_hash"`
+	CloseFlags          int                 `json:"close_flags"`
+	CloseTime           int64               `json:"close_time"`
+	CloseTimeHuman      string              `json:"close_time_human"`
+	CloseTimeResolution int                 `json:"close_time_resolution"`
+	Closed              bool                `json:"closed"`
+	LedgerHash          string              `json:"ledger_hash"`
+	LedgerIndex         FlexUint64          `json:"ledger_index"`
+	ParentCloseTime     int64               `json:"parent_close_time"`
+	ParentHash          string              `json:"parent_hash"`
+	TotalCoins          string              `json:"total_coins"`
+	TransactionHash     string              `json:"transaction_hash"`
+	Transactions        []LedgerTransaction `json:"transactions,omitempty"`
+}
+
+// LedgerTransaction represents an expanded transaction in a ledger. API
+// version 1 inlines the transaction fields with the metadata in metaData,
+// while version 2 nests them in tx_json and meta.
+type LedgerTransaction struct {
+	Transaction
+	TxJSON   *Transaction     `json:"tx_json,omitempty"`
+	MetaData *TransactionMeta `json:"metaData,omitempty"`
+}
+
+// Tx returns the transaction fields regardless of API version
+func (t *LedgerTransaction) Tx() *Transaction {
+	if t.TxJSON != nil {
+		tx := *t.TxJSON
+		if tx.Hash == "" {
+			tx.Hash = t.Hash
+		}
+		return &tx
+	}
+	return &t.Transaction
+}
+
+// Metadata returns the transaction metadata regardless of API version
+func (t *LedgerTransaction) Metadata() *TransactionMeta {
+	if t.MetaData != nil {
+		return t.MetaData
+	}
+	return t.Meta
+}
+
+// Transaction represents the common fields of an XRPL transaction
+type Transaction struct {
+	Hash            string  `json:"hash"`
+	TransactionType string  `json:"TransactionType"`
+	Account         string  `json:"Account"`
+	Destination     string  `json:"Destination,omitempty"`
+	Amount          *Amount `json:"Amount,omitempty"`
+	DeliverMax      *Amount `json:"DeliverMax,omitempty"`
+	SendMax         *Amount `json:"SendMax,omitempty"`
+	DeliverMin      *Amount `json:"DeliverMin,omitempty"`
+	TakerGets       *Amount `json:"TakerGets,omitempty"`
+	TakerPays       *Amount `json:"TakerPays,omitempty"`
+	LimitAmount     *Amount `json:"LimitAmount,omitempty"`
+	Fee             string  `json:"Fee"`
+	Sequence        uint64  `json:"Sequence"`
+	TicketSequence  uint64  `json:"TicketSequence,omitempty"`
+	OfferSequence   uint64  `json:"OfferSequence,omitempty"`
+	Flags           uint64  `json:"Flags"`
+	SourceTag       *uint64 `json:"SourceTag,omitempty"`
+	DestinationTag  *uint64 `json:"DestinationTag,omitempty"`
+	SigningPubKey   string  `json:"SigningPubKey,omitempty"`
+	TxnSignature    string  `json:"TxnSignature,omitempty"`
+	Memos           []Memo  `json:"Memos,omitempty"`
+
+	// Fields added by the tx method and the transactions stream; the tx
+	// method only returns the ledger hash from API version 2
+	LedgerIndex uint64           `json:"ledger_index,omitempty"`
+	LedgerHash  string           `json:"ledger_hash,omitempty"`
+	Date        int64            `json:"date,omitempty"`
+	Validated   bool             `json:"validated,omitempty"`
+	Meta        *TransactionMeta `json:"meta,omitempty"`
+}
+
+// Memo represents a transaction memo
+type Memo struct {
+	Memo struct {
+		MemoData   string `json:"MemoData,omitempty"`
+		MemoFormat string `json:"MemoFormat,omitempty"`
+		MemoType   string `json:"MemoType,omitempty"`
+	} `json:"Memo"`
+}
+
+// TransactionMeta represents transaction metadata
+type TransactionMeta struct {
+	TransactionIndex  uint64          `json:"TransactionIndex"`
+	TransactionResult string          `json:"TransactionResult"`
+	DeliveredAmount   json.RawMessage `json:"delivered_amount,omitempty"`
+}
+
+// Delivered returns the amount actually delivered by a payment, or nil when
+// rippled reports it as unavailable
+func (m *TransactionMeta) Delivered() *Amount {
+	if m == nil || len(m.DeliveredAmount) == 0 {
+		return nil
+	}
+
+	var amount Amount
+	if err := json.Unmarshal(m.DeliveredAmount, &amount); err != nil {
+		// "unavailable" for ledgers before the field was introduced
+		return nil
+	}
+	return &amount
+}
+
+// Amount represents an XRPL amount. XRP amounts are strings of drops, while
+// issued currency amounts are objects with a currency, issuer and value.
+type Amount struct {
+	Currency string `json:"currency"`
+	Issuer   string `json:"issuer,omitempty"`
+	Value    string `json:"value"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler for both amount encodings
+func (a *Amount) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		var drops string
+		if err := json.Unmarshal(data, &drops); err != nil {
+			return err
+		}
+		if _, err := strconv.ParseUint(drops, 10, 64); err != nil {
+			return fmt.Errorf("invalid XRP amount %q", drops)
+		}
+		*a = Amount{Currency: CurrencyXRP, Value: drops}
+		return nil
+	}
+
+	type issued Amount
+	var v issued
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*a = Amount(v)
+	return nil
+}
+
+// IsXRP returns true for native XRP amounts, whose value is in drops
+func (a *Amount) IsXRP() bool {
+	return a.Currency == CurrencyXRP && a.Issuer == ""
+}
+
+// FlexUint64 decodes integers that rippled encodes either as JSON numbers
+// or as decimal strings
+type FlexUint64 uint64
+
+// UnmarshalJSON implements json.Unmarshaler
+func (f *FlexUint64) UnmarshalJSON(data []byte) error {
+	s := string(bytes.Trim(data, `"`))
+	v, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid integer %s: %w", data, err)
+	}
+	*f = FlexUint64(v)
+	return nil
+}
+
+// LedgerClosedResponse represents the result of the ledger_closed method
+type LedgerClosedResponse struct {
+	LedgerHash  string `json:"ledger_hash"`
+	LedgerIndex uint64 `json:"ledger_index"`
+}
+
+// ServerInfoResponse represents the result of the server_info method
+type ServerInfoResponse struct {
+	Info ServerInfo `json:"info"`
+}
+
+// ServerInfo represents the state of a rippled server
+type ServerInfo struct {
+	BuildVersion    string           `json:"build_version"`
+	CompleteLedgers string           `json:"complete_ledgers"`
+	NetworkID       uint32           `json:"network_id,omitempty"`
+	Peers           int              `json:"peers"`
+	ServerState     string           `json:"server_state"`
+	ValidatedLedger *ValidatedLedger `json:"validated_ledger,omitempty"`
+}
+
+// ValidatedLedger represents the latest validated ledger in server_info
+type ValidatedLedger struct {
+	Age  uint64 `json:"age"`
+	Hash string `json:"hash"`
+	Seq  uint64 `json:"seq"`
+}
+
+// IsSynced reports whether the server is tracking the network
+func (s *ServerInfo) IsSynced() bool {
+	switch s.ServerState {
+	case "full", "proposing", "validating":
+		return true
+	default:
+		return false
+	}
+}
+
+// StreamMessage represents a message received on a WebSocket subscription
+type StreamMessage struct {
+	Type string `json:"type"`
+
+	// ledgerClosed fields
+	LedgerHash  string `json:"ledger_hash,omitempty"`
+	LedgerIndex uint64 `json:"ledger_index,omitempty"`
+	LedgerTime  int64  `json:"ledger_time,omitempty"`
+	TxnCount    int    `json:"txn_count,omitempty"`
+
+	// transaction fields
+	Transaction  *Transaction     `json:"transaction,omitempty"`
+	Meta         *TransactionMeta `json:"meta,omitempty"`
+	EngineResult string           `json:"engine_result,omitempty"`
+	Validated    bool             `json:"validated,omitempty"`
+
+	// response fields
+	Status string `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
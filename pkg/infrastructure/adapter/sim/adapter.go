@@ -0,0 +1,279 @@
+package sim
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/service"
+)
+
+// Ensure Adapter implements ChainAdapter interface
+var _ service.ChainAdapter = (*Adapter)(nil)
+
+// Adapter implements the ChainAdapter interface for an in-process simulated
+// chain. It needs no network, so it can drive the indexer end to end in
+// tests and demos.
+type Adapter struct {
+	config    *Config
+	chain     *Chain
+	faults    *faultInjector
+	chainInfo *models.ChainInfo
+	mu        sync.RWMutex
+	connected bool
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// NewAdapter creates a new simulated chain adapter
+func NewAdapter(config *Config) (*Adapter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	adapter := &Adapter{
+		config: config,
+		chain:  NewChain(config),
+		faults: newFaultInjector(config.Faults, config.Seed),
+		chainInfo: &models.ChainInfo{
+			ChainType: models.ChainTypeSim,
+			ChainID:   config.ChainID,
+			Name:      config.ChainName,
+			Network:   config.Network,
+		},
+		connected: false,
+	}
+
+	return adapter, nil
+}
+
+// GetChainType returns the chain type
+func (a *Adapter) GetChainType() models.ChainType {
+	return models.ChainTypeSim
+}
+
+// GetChainID returns the chain ID
+func (a *Adapter) GetChainID() string {
+	return a.config.ChainID
+}
+
+// GetChainInfo returns chain information
+func (a *Adapter) GetChainInfo() *models.ChainInfo {
+	return a.chainInfo
+}
+
+// Capabilities reports the features the adapter supports
+func (a *Adapter) Capabilities() models.Capabilities {
+	return models.Capabilities{
+		BlockSubscription:       true,
+		TransactionSubscription: true,
+		BlockByHash:             true,
+		TransactionByHash:       true,
+		BatchFetch:              true,
+		MaxBlockRange:           uint64(a.config.BatchSize),
+	}
+}
+
+// GetLatestBlockNumber returns the height of the head block
+func (a *Adapter) GetLatestBlockNumber(ctx context.Context) (uint64, error) {
+	if err := a.faults.call(ctx, "GetLatestBlockNumber"); err != nil {
+		return 0, err
+	}
+
+	return a.chain.Height(), nil
+}
+
+// GetFinalityHeads treats the blocks below the last ReorgDepth as finalized,
+// since injected reorgs never replace them
+func (a *Adapter) GetFinalityHeads(ctx context.Context) (*models.FinalityHeads, error) {
+	if err := a.faults.call(ctx, "GetFinalityHeads"); err != nil {
+		return nil, err
+	}
+
+	finalized := uint64(0)
+	if height, depth := a.chain.Height(), a.config.Faults.ReorgDepth; height > depth {
+		finalized = height - depth
+	}
+
+	return &models.FinalityHeads{Safe: finalized, Finalized: finalized}, nil
+}
+
+// GetBlockByNumber fetches the canonical block at a height
+func (a *Adapter) GetBlockByNumber(ctx context.Context, number uint64) (*models.Block, error) {
+	if err := a.faults.call(ctx, "GetBlockByNumber"); err != nil {
+		return nil, err
+	}
+
+	return a.block(number)
+}
+
+// GetBlockByHash fetches a canonical block by hash
+func (a *Adapter) GetBlockByHash(ctx context.Context, hash string) (*models.Block, error) {
+	if err := a.faults.call(ctx, "GetBlockByHash"); err != nil {
+		return nil, err
+	}
+
+	return a.chain.BlockByHash(hash)
+}
+
+// GetBlocks fetches multiple blocks in a range
+func (a *Adapter) GetBlocks(ctx context.Context, start, end uint64) ([]*models.Block, error) {
+	if start > end {
+		return nil, fmt.Errorf("invalid range: start %d > end %d", start, end)
+	}
+
+	if err := a.faults.call(ctx, "GetBlocks"); err != nil {
+		return nil, err
+	}
+
+	// Limit range to the batch size
+	if end-start >= uint64(a.config.BatchSize) {
+		end = start + uint64(a.config.BatchSize) - 1
+	}
+
+	blocks := make([]*models.Block, 0, end-start+1)
+	for number := start; number <= end; number++ {
+		block, err := a.block(number)
+		if err != nil {
+			return nil, err
+		}
+		blocks = append(blocks, block)
+	}
+
+	return blocks, nil
+}
+
+// block returns the canonical block at a height, unless the fault injector
+// makes it missing
+func (a *Adapter) block(number uint64) (*models.Block, error) {
+	if a.faults.missingBlock() {
+		return nil, fmt.Errorf("block %d: %w", number, models.ErrBlockNotFound)
+	}
+
+	return a.chain.Block(number)
+}
+
+// GetTransaction fetches a canonical transaction by hash
+func (a *Adapter) GetTransaction(ctx context.Context, hash string) (*models.Transaction, error) {
+	if err := a.faults.call(ctx, "GetTransaction"); err != nil {
+		return nil, err
+	}
+
+	return a.chain.Transaction(hash)
+}
+
+// GetTransactionsByBlock fetches all transactions in a block
+func (a *Adapter) GetTransactionsByBlock(ctx context.Context, blockNumber uint64) ([]*models.Transaction, error) {
+	block, err := a.GetBlockByNumber(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	return block.Transactions, nil
+}
+
+// IsHealthy checks if the adapter is healthy
+func (a *Adapter) IsHealthy(ctx context.Context) bool {
+	return a.IsConnected()
+}
+
+// Connect starts block production every BlockTime
+func (a *Adapter) Connect(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.connected {
+		return nil
+	}
+
+	if a.config.BlockTime > 0 {
+		a.stop = make(chan struct{})
+		a.done = make(chan struct{})
+		go a.produce(a.stop, a.done)
+	}
+
+	a.connected = true
+	return nil
+}
+
+// produce adds a block every BlockTime until stop is closed
+func (a *Adapter) produce(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(a.config.BlockTime)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			a.chain.Produce()
+		}
+	}
+}
+
+// Disconnect stops block production
+func (a *Adapter) Disconnect() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.connected {
+		return nil
+	}
+
+	if a.stop != nil {
+		close(a.stop)
+		<-a.done
+		a.stop, a.done = nil, nil
+	}
+
+	a.connected = false
+	return nil
+}
+
+// SubscribeNewBlocks subscribes to the blocks produced from now on. After a
+// reorg the blocks of the new branch are delivered in order.
+func (a *Adapter) SubscribeNewBlocks(ctx context.Context) (service.BlockSubscription, error) {
+	if !a.IsConnected() {
+		return nil, fmt.Errorf("not connected")
+	}
+
+	return a.chain.Subscribe(ctx), nil
+}
+
+// SubscribeNewTransactions subscribes to the transactions of the blocks
+// produced from now on
+func (a *Adapter) SubscribeNewTransactions(ctx context.Context) (service.TransactionSubscription, error) {
+	blocks, err := a.SubscribeNewBlocks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return newTransactionSubscription(blocks), nil
+}
+
+// Mine produces n blocks on top of the head and returns them
+func (a *Adapter) Mine(n int) []*models.Block {
+	return a.chain.Mine(n)
+}
+
+// Reorg replaces the last depth blocks with a new branch and returns the
+// new blocks
+func (a *Adapter) Reorg(depth uint64) []*models.Block {
+	return a.chain.Reorg(depth)
+}
+
+// GetConfig returns the adapter configuration
+func (a *Adapter) GetConfig() *Config {
+	return a.config
+}
+
+// IsConnected returns whether the adapter is connected
+func (a *Adapter) IsConnected() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.connected
+}
@@ -0,0 +1,286 @@
+package sim
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+)
+
+func newTestAdapter(t *testing.T, configure func(*Config)) *Adapter {
+	t.Helper()
+
+	config := DefaultConfig()
+	config.BlockTime = 0
+	config.InitialBlocks = 20
+	if configure != nil {
+		configure(config)
+	}
+
+	adapter, err := NewAdapter(config)
+	if err != nil {
+		t.Fatalf("NewAdapter() error = %v", err)
+	}
+	if err := adapter.Connect(context.Background()); err != nil {
+		t.Fatalf("Connect() error = %v", err)
+	}
+	t.Cleanup(func() { adapter.Disconnect() })
+
+	return adapter
+}
+
+func TestConfig_Validate(t *testing.T) {
+	if err := DefaultConfig().Validate(); err != nil {
+		t.Errorf("DefaultConfig().Validate() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*Config)
+	}{
+		{name: "missing chain_id", modify: func(c *Config) { c.ChainID = "" }},
+		{name: "negative block time", modify: func(c *Config) { c.BlockTime = -time.Second }},
+		{name: "max below min txs", modify: func(c *Config) { c.MinTxsPerBlock, c.MaxTxsPerBlock = 5, 4 }},
+		{name: "single account", modify: func(c *Config) { c.Accounts = 1 }},
+		{name: "rate above one", modify: func(c *Config) { c.Faults.ErrorRate = 1.5 }},
+		{name: "reorgs without depth", modify: func(c *Config) { c.Faults.ReorgRate, c.Faults.ReorgDepth = 0.1, 0 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := DefaultConfig()
+			tt.modify(config)
+			if err := config.Validate(); err == nil {
+				t.Error("Validate() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestAdapter_Deterministic(t *testing.T) {
+	produce := func() []*models.Block {
+		adapter := newTestAdapter(t, func(c *Config) {
+			c.Seed = 42
+			c.Faults.ReorgRate = 0.3
+		})
+		for i := 0; i < 30; i++ {
+			adapter.chain.Produce()
+		}
+
+		blocks, err := adapter.GetBlocks(context.Background(), 0, adapter.chain.Height())
+		if err != nil {
+			t.Fatalf("GetBlocks() error = %v", err)
+		}
+		return blocks
+	}
+
+	first, second := produce(), produce()
+	if len(first) != 51 || len(second) != 51 {
+		t.Fatalf("produced %d and %d blocks, want 51", len(first), len(second))
+	}
+	for i := range first {
+		if first[i].Hash != second[i].Hash || first[i].TxCount != second[i].TxCount {
+			t.Fatalf("block %d differs between runs: %s and %s", i, first[i].Hash, second[i].Hash)
+		}
+		for j, tx := range first[i].Transactions {
+			other := second[i].Transactions[j]
+			if tx.Hash != other.Hash || tx.From != other.From || tx.Value != other.Value {
+				t.Fatalf("transaction %d of block %d differs between runs", j, i)
+			}
+		}
+	}
+
+	// Another seed gives another chain
+	adapter := newTestAdapter(t, func(c *Config) { c.Seed = 43 })
+	block, _ := adapter.GetBlockByNumber(context.Background(), 1)
+	if block.Hash == first[1].Hash {
+		t.Error("different seeds produced the same block")
+	}
+}
+
+func TestAdapter_Blocks(t *testing.T) {
+	adapter := newTestAdapter(t, func(c *Config) {
+		c.MinTxsPerBlock = 2
+		c.MaxTxsPerBlock = 4
+	})
+	ctx := context.Background()
+
+	head, err := adapter.GetLatestBlockNumber(ctx)
+	if err != nil || head != 20 {
+		t.Fatalf("GetLatestBlockNumber() = %d, %v, want 20", head, err)
+	}
+
+	blocks, err := adapter.GetBlocks(ctx, 0, head)
+	if err != nil {
+		t.Fatalf("GetBlocks() error = %v", err)
+	}
+	for i, block := range blocks {
+		if err := block.Validate(); err != nil {
+			t.Errorf("block %d: %v", i, err)
+		}
+		if i > 0 && block.ParentHash != blocks[i-1].Hash {
+			t.Errorf("block %d parent = %s, want %s", i, block.ParentHash, blocks[i-1].Hash)
+		}
+		if block.TxCount < 2 || block.TxCount > 4 || len(block.TxHashes) != block.TxCount {
+			t.Errorf("block %d has %d transactions, want 2 to 4", i, block.TxCount)
+		}
+		for _, tx := range block.Transactions {
+			if err := tx.Validate(); err != nil {
+				t.Errorf("block %d transaction %d: %v", i, tx.Index, err)
+			}
+		}
+	}
+
+	byHash, err := adapter.GetBlockByHash(ctx, blocks[7].Hash)
+	if err != nil || byHash.Number != 7 {
+		t.Errorf("GetBlockByHash() = %v, %v, want block 7", byHash, err)
+	}
+
+	tx, err := adapter.GetTransaction(ctx, blocks[7].TxHashes[1])
+	if err != nil || tx.BlockNumber != 7 || tx.Index != 1 {
+		t.Errorf("GetTransaction() = %v, %v, want transaction 1 of block 7", tx, err)
+	}
+
+	if _, err := adapter.GetBlockByNumber(ctx, head+1); !errors.Is(err, models.ErrBlockNotFound) {
+		t.Errorf("GetBlockByNumber(head+1) error = %v, want ErrBlockNotFound", err)
+	}
+
+	mined := adapter.Mine(2)
+	if len(mined) != 2 || mined[0].Number != 21 || mined[0].ParentHash != blocks[20].Hash {
+		t.Errorf("Mine(2) = %d blocks starting at %d", len(mined), mined[0].Number)
+	}
+}
+
+func TestAdapter_Reorg(t *testing.T) {
+	adapter := newTestAdapter(t, func(c *Config) { c.MinTxsPerBlock = 1 })
+	ctx := context.Background()
+
+	ancestor, _ := adapter.GetBlockByNumber(ctx, 17)
+	orphaned, _ := adapter.GetBlockByNumber(ctx, 18)
+
+	sub, err := adapter.SubscribeNewBlocks(ctx)
+	if err != nil {
+		t.Fatalf("SubscribeNewBlocks() error = %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	branch := adapter.Reorg(3)
+	if len(branch) != 3 || branch[0].Number != 18 || branch[0].ParentHash != ancestor.Hash {
+		t.Fatalf("Reorg(3) = %d blocks starting at %d", len(branch), branch[0].Number)
+	}
+	if branch[0].Hash == orphaned.Hash {
+		t.Error("reorg kept the orphaned block")
+	}
+
+	// The head stays at the same height
+	if head, _ := adapter.GetLatestBlockNumber(ctx); head != 20 {
+		t.Errorf("head after reorg = %d, want 20", head)
+	}
+
+	// Orphaned blocks and their transactions are gone
+	if _, err := adapter.GetBlockByHash(ctx, orphaned.Hash); !errors.Is(err, models.ErrBlockNotFound) {
+		t.Errorf("GetBlockByHash(orphaned) error = %v, want ErrBlockNotFound", err)
+	}
+	if _, err := adapter.GetTransaction(ctx, orphaned.TxHashes[0]); !errors.Is(err, models.ErrTransactionNotFound) {
+		t.Errorf("GetTransaction(orphaned) error = %v, want ErrTransactionNotFound", err)
+	}
+
+	// Subscribers receive the new branch in order
+	for _, want := range branch {
+		select {
+		case block := <-sub.Channel():
+			if block.Hash != want.Hash {
+				t.Errorf("subscription delivered %d %s, want %s", block.Number, block.Hash, want.Hash)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("subscription delivered no block")
+		}
+	}
+}
+
+func TestAdapter_Faults(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("rpc errors", func(t *testing.T) {
+		adapter := newTestAdapter(t, func(c *Config) { c.Faults.ErrorRate = 1 })
+		if _, err := adapter.GetLatestBlockNumber(ctx); !errors.Is(err, ErrRPC) {
+			t.Errorf("GetLatestBlockNumber() error = %v, want ErrRPC", err)
+		}
+	})
+
+	t.Run("missing blocks", func(t *testing.T) {
+		adapter := newTestAdapter(t, func(c *Config) { c.Faults.MissingBlockRate = 1 })
+		if _, err := adapter.GetBlockByNumber(ctx, 1); !errors.Is(err, models.ErrBlockNotFound) {
+			t.Errorf("GetBlockByNumber() error = %v, want ErrBlockNotFound", err)
+		}
+	})
+
+	t.Run("latency spikes", func(t *testing.T) {
+		adapter := newTestAdapter(t, func(c *Config) {
+			c.Faults.LatencySpikeRate = 1
+			c.Faults.LatencySpike = time.Minute
+		})
+
+		ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		if _, err := adapter.GetLatestBlockNumber(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("GetLatestBlockNumber() error = %v, want DeadlineExceeded", err)
+		}
+	})
+
+	t.Run("seeded error sequence", func(t *testing.T) {
+		failures := func() []bool {
+			adapter := newTestAdapter(t, func(c *Config) { c.Faults.ErrorRate = 0.5 })
+			var failed []bool
+			for i := 0; i < 20; i++ {
+				_, err := adapter.GetLatestBlockNumber(ctx)
+				failed = append(failed, err != nil)
+			}
+			return failed
+		}
+
+		first, second := failures(), failures()
+		for i := range first {
+			if first[i] != second[i] {
+				t.Fatalf("call %d failed in one run only", i)
+			}
+		}
+	})
+}
+
+func TestAdapter_Production(t *testing.T) {
+	adapter := newTestAdapter(t, func(c *Config) { c.BlockTime = 5 * time.Millisecond })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sub, err := adapter.SubscribeNewTransactions(ctx)
+	if err != nil {
+		t.Fatalf("SubscribeNewTransactions() error = %v", err)
+	}
+
+	select {
+	case tx := <-sub.Channel():
+		if tx.BlockNumber <= 20 {
+			t.Errorf("transaction of block %d, want a new block", tx.BlockNumber)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no transaction produced")
+	}
+
+	// Cancelling the context ends the subscription
+	cancel()
+	deadline := time.After(time.Second)
+	for {
+		select {
+		case _, ok := <-sub.Channel():
+			if !ok {
+				return
+			}
+		case <-deadline:
+			t.Fatal("subscription not closed after cancel")
+		}
+	}
+}
@@ -0,0 +1,293 @@
+package sim
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+)
+
+// Parameters of generated blocks and transactions
+const (
+	blockGasLimit   = 30_000_000
+	transferGas     = 21_000
+	baseBlockSize   = 500
+	txSize          = 110
+	failedTxPercent = 5
+)
+
+// header is a block of the canonical chain. Blocks are regenerated from
+// their header on every request, so callers never share a block.
+type header struct {
+	hash   string
+	branch uint64 // Reorgs before the block was produced
+}
+
+// txLocation is the position of a transaction in the canonical chain
+type txLocation struct {
+	height uint64
+	index  uint64
+}
+
+// Chain is a deterministic chain. Every block is derived from the seed, its
+// height, its parent and the number of reorgs before it was produced.
+type Chain struct {
+	config   *Config
+	accounts []string
+
+	// rand decides reorgs during block production
+	rand *rand.Rand
+
+	mu          sync.RWMutex
+	headers     []header // Indexed by height
+	heights     map[string]uint64
+	txs         map[string]txLocation
+	branches    uint64
+	subscribers map[*blockSubscription]struct{}
+}
+
+// NewChain creates a chain with the genesis block and config.InitialBlocks
+// blocks after it
+func NewChain(config *Config) *Chain {
+	c := &Chain{
+		config:      config,
+		accounts:    make([]string, config.Accounts),
+		rand:        rand.New(rand.NewSource(config.Seed)),
+		heights:     make(map[string]uint64),
+		txs:         make(map[string]txLocation),
+		subscribers: make(map[*blockSubscription]struct{}),
+	}
+
+	for i := range c.accounts {
+		sum := sha256.Sum256([]byte(fmt.Sprintf("%d/account/%d", config.Seed, i)))
+		c.accounts[i] = "0x" + hex.EncodeToString(sum[:20])
+	}
+
+	c.append(0)
+	for i := uint64(0); i < config.InitialBlocks; i++ {
+		c.append(0)
+	}
+
+	return c
+}
+
+// Height returns the height of the head block
+func (c *Chain) Height() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return uint64(len(c.headers) - 1)
+}
+
+// Block returns the canonical block at a height
+func (c *Chain) Block(height uint64) (*models.Block, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if height >= uint64(len(c.headers)) {
+		return nil, fmt.Errorf("block %d: %w", height, models.ErrBlockNotFound)
+	}
+	return c.generate(height), nil
+}
+
+// BlockByHash returns a canonical block by hash. Orphaned blocks are not
+// found.
+func (c *Chain) BlockByHash(hash string) (*models.Block, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	height, ok := c.heights[hash]
+	if !ok {
+		return nil, fmt.Errorf("block %s: %w", hash, models.ErrBlockNotFound)
+	}
+	return c.generate(height), nil
+}
+
+// Transaction returns a transaction of the canonical chain by hash
+func (c *Chain) Transaction(hash string) (*models.Transaction, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	loc, ok := c.txs[hash]
+	if !ok {
+		return nil, fmt.Errorf("transaction %s: %w", hash, models.ErrTransactionNotFound)
+	}
+	return c.generate(loc.height).Transactions[loc.index], nil
+}
+
+// Mine produces n blocks on top of the head and returns them
+func (c *Chain) Mine(n int) []*models.Block {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	blocks := make([]*models.Block, 0, n)
+	for i := 0; i < n; i++ {
+		blocks = append(blocks, c.generate(c.append(c.branches)))
+	}
+	c.notify(blocks)
+
+	return blocks
+}
+
+// Reorg replaces the last depth blocks with a new branch of the same length
+// and returns the new blocks. The genesis block is never replaced.
+func (c *Chain) Reorg(depth uint64) []*models.Block {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	blocks := c.reorg(depth)
+	c.notify(blocks)
+
+	return blocks
+}
+
+// Produce adds the next block, first replacing the last ReorgDepth blocks
+// with probability ReorgRate, and returns the new blocks
+func (c *Chain) Produce() []*models.Block {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var blocks []*models.Block
+	if c.config.Faults.ReorgRate > 0 && c.rand.Float64() < c.config.Faults.ReorgRate {
+		blocks = c.reorg(c.config.Faults.ReorgDepth)
+	}
+	blocks = append(blocks, c.generate(c.append(c.branches)))
+	c.notify(blocks)
+
+	return blocks
+}
+
+// reorg drops the last depth blocks and produces as many on a new branch.
+// The caller must hold the write lock.
+func (c *Chain) reorg(depth uint64) []*models.Block {
+	height := uint64(len(c.headers) - 1)
+	if depth > height {
+		depth = height
+	}
+	if depth == 0 {
+		return nil
+	}
+
+	ancestor := height - depth
+	for h := height; h > ancestor; h-- {
+		for _, tx := range c.generate(h).Transactions {
+			delete(c.txs, tx.Hash)
+		}
+		delete(c.heights, c.headers[h].hash)
+	}
+	c.headers = c.headers[:ancestor+1]
+	c.branches++
+
+	blocks := make([]*models.Block, 0, depth)
+	for i := uint64(0); i < depth; i++ {
+		blocks = append(blocks, c.generate(c.append(c.branches)))
+	}
+
+	return blocks
+}
+
+// append adds a block on the given branch to the head and returns its
+// height. The caller must hold the write lock.
+func (c *Chain) append(branch uint64) uint64 {
+	height := uint64(len(c.headers))
+	parent := ""
+	if height > 0 {
+		parent = c.headers[height-1].hash
+	}
+
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d/block/%d/%d/%s", c.config.Seed, height, branch, parent)))
+	hash := "0x" + hex.EncodeToString(sum[:])
+
+	c.headers = append(c.headers, header{hash: hash, branch: branch})
+	c.heights[hash] = height
+	for i, tx := range c.generate(height).Transactions {
+		c.txs[tx.Hash] = txLocation{height: height, index: uint64(i)}
+	}
+
+	return height
+}
+
+// generate builds the block at a height from its header. The caller must
+// hold the lock.
+func (c *Chain) generate(height uint64) *models.Block {
+	h := c.headers[height]
+	hashBytes, _ := hex.DecodeString(h.hash[2:])
+	rng := rand.New(rand.NewSource(int64(binary.BigEndian.Uint64(hashBytes))))
+
+	parentHash := ""
+	if height > 0 {
+		parentHash = c.headers[height-1].hash
+	}
+
+	interval := c.config.BlockTime
+	if interval <= 0 {
+		interval = time.Second
+	}
+	timestamp := models.NewTimestamp(c.config.GenesisTime.Add(time.Duration(height) * interval).Unix())
+
+	block := &models.Block{
+		ChainType:  models.ChainTypeSim,
+		ChainID:    c.config.ChainID,
+		Number:     height,
+		Hash:       h.hash,
+		ParentHash: parentHash,
+		Timestamp:  timestamp,
+		Proposer:   c.accounts[rng.Intn(len(c.accounts))],
+		GasLimit:   blockGasLimit,
+		Metadata: map[string]interface{}{
+			"branch": h.branch,
+		},
+	}
+
+	count := c.config.MinTxsPerBlock + rng.Intn(c.config.MaxTxsPerBlock-c.config.MinTxsPerBlock+1)
+	block.Transactions = make([]*models.Transaction, 0, count)
+	block.TxHashes = make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		tx := c.generateTx(rng, block, uint64(i))
+		block.Transactions = append(block.Transactions, tx)
+		block.TxHashes = append(block.TxHashes, tx.Hash)
+		block.GasUsed += tx.GasUsed
+	}
+	block.TxCount = count
+	block.Size = uint64(baseBlockSize + txSize*count)
+
+	return block
+}
+
+// generateTx builds the transaction at an index of a block
+func (c *Chain) generateTx(rng *rand.Rand, block *models.Block, index uint64) *models.Transaction {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s/tx/%d", block.Hash, index)))
+
+	from := rng.Intn(len(c.accounts))
+	to := (from + 1 + rng.Intn(len(c.accounts)-1)) % len(c.accounts)
+	value := rng.Int63()
+	gasPrice := 1_000_000_000 + rng.Int63n(99_000_000_000)
+
+	status := models.TxStatusSuccess
+	if rng.Intn(100) < failedTxPercent {
+		status = models.TxStatusFailed
+	}
+
+	return &models.Transaction{
+		ChainType:   models.ChainTypeSim,
+		ChainID:     c.config.ChainID,
+		Hash:        "0x" + hex.EncodeToString(sum[:]),
+		Index:       index,
+		BlockNumber: block.Number,
+		BlockHash:   block.Hash,
+		From:        c.accounts[from],
+		To:          c.accounts[to],
+		Value:       strconv.FormatInt(value, 10),
+		Fee:         strconv.FormatInt(gasPrice*transferGas, 10),
+		GasUsed:     transferGas,
+		GasPrice:    strconv.FormatInt(gasPrice, 10),
+		Status:      status,
+		Timestamp:   models.NewTimestamp(block.Timestamp.Unix),
+		Metadata:    make(map[string]interface{}),
+	}
+}
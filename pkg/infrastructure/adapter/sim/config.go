@@ -0,0 +1,148 @@
+package sim
+
+import (
+	"fmt"
+	"time"
+)
+
+// Config represents the configuration for the simulated chain adapter
+type Config struct {
+	// ChainID is the unique identifier for the chain
+	ChainID string `yaml:"chain_id" json:"chain_id"`
+
+	// ChainName is the human-readable name of the chain
+	ChainName string `yaml:"chain_name" json:"chain_name"`
+
+	// Network is the network type
+	Network string `yaml:"network" json:"network"`
+
+	// Seed determines every block, transaction and fault. Two adapters with
+	// the same configuration produce the same chain.
+	Seed int64 `yaml:"seed" json:"seed"`
+
+	// BlockTime is how often a new block is produced once connected. Zero
+	// disables block production; blocks are then only added with Mine.
+	BlockTime time.Duration `yaml:"block_time" json:"block_time"`
+
+	// InitialBlocks is the number of blocks after genesis that exist when
+	// the adapter is created
+	InitialBlocks uint64 `yaml:"initial_blocks" json:"initial_blocks"`
+
+	// GenesisTime is the timestamp of block 0; block n is BlockTime later
+	// than block n-1, or one second if BlockTime is zero
+	GenesisTime time.Time `yaml:"genesis_time" json:"genesis_time"`
+
+	// MinTxsPerBlock and MaxTxsPerBlock bound the number of transactions in
+	// each block
+	MinTxsPerBlock int `yaml:"min_txs_per_block" json:"min_txs_per_block"`
+	MaxTxsPerBlock int `yaml:"max_txs_per_block" json:"max_txs_per_block"`
+
+	// Accounts is the number of addresses transactions are sent between
+	Accounts int `yaml:"accounts" json:"accounts"`
+
+	// BatchSize is the number of blocks to fetch in a single batch
+	BatchSize int `yaml:"batch_size" json:"batch_size"`
+
+	// Faults are injected into block production and RPC calls
+	Faults FaultConfig `yaml:"faults" json:"faults"`
+}
+
+// FaultConfig configures the faults injected by the simulated chain. Rates
+// are probabilities between 0 and 1.
+type FaultConfig struct {
+	// ReorgRate is the probability that producing a block first replaces
+	// the last ReorgDepth blocks with a new branch
+	ReorgRate float64 `yaml:"reorg_rate" json:"reorg_rate"`
+
+	// ReorgDepth is the number of blocks replaced by a reorg
+	ReorgDepth uint64 `yaml:"reorg_depth" json:"reorg_depth"`
+
+	// ErrorRate is the probability that an RPC call fails with ErrRPC
+	ErrorRate float64 `yaml:"error_rate" json:"error_rate"`
+
+	// Latency is added to every RPC call
+	Latency time.Duration `yaml:"latency" json:"latency"`
+
+	// LatencySpikeRate is the probability that an RPC call takes
+	// LatencySpike longer
+	LatencySpikeRate float64       `yaml:"latency_spike_rate" json:"latency_spike_rate"`
+	LatencySpike     time.Duration `yaml:"latency_spike" json:"latency_spike"`
+
+	// MissingBlockRate is the probability that fetching an existing block
+	// fails with models.ErrBlockNotFound, like a node that has not caught up
+	// with the head it reports
+	MissingBlockRate float64 `yaml:"missing_block_rate" json:"missing_block_rate"`
+}
+
+// DefaultConfig returns a default configuration without faults
+func DefaultConfig() *Config {
+	return &Config{
+		ChainID:        "sim",
+		ChainName:      "Simulated Chain",
+		Network:        "simnet",
+		Seed:           1,
+		BlockTime:      1 * time.Second,
+		InitialBlocks:  100,
+		GenesisTime:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		MinTxsPerBlock: 0,
+		MaxTxsPerBlock: 10,
+		Accounts:       20,
+		BatchSize:      100,
+		Faults: FaultConfig{
+			ReorgDepth: 3,
+		},
+	}
+}
+
+// Validate validates the configuration
+func (c *Config) Validate() error {
+	if c.ChainID == "" {
+		return fmt.Errorf("chain_id is required")
+	}
+
+	if c.BlockTime < 0 {
+		return fmt.Errorf("block_time must be non-negative")
+	}
+
+	if c.MinTxsPerBlock < 0 || c.MaxTxsPerBlock < c.MinTxsPerBlock {
+		return fmt.Errorf("txs per block must satisfy 0 <= min_txs_per_block <= max_txs_per_block")
+	}
+
+	if c.Accounts < 2 {
+		return fmt.Errorf("accounts must be at least 2")
+	}
+
+	if c.BatchSize <= 0 {
+		return fmt.Errorf("batch_size must be positive")
+	}
+
+	return c.Faults.Validate()
+}
+
+// Validate validates the fault configuration
+func (f *FaultConfig) Validate() error {
+	rates := []struct {
+		name string
+		rate float64
+	}{
+		{"reorg_rate", f.ReorgRate},
+		{"error_rate", f.ErrorRate},
+		{"latency_spike_rate", f.LatencySpikeRate},
+		{"missing_block_rate", f.MissingBlockRate},
+	}
+	for _, r := range rates {
+		if r.rate < 0 || r.rate > 1 {
+			return fmt.Errorf("%s must be between 0 and 1", r.name)
+		}
+	}
+
+	if f.ReorgRate > 0 && f.ReorgDepth == 0 {
+		return fmt.Errorf("reorg_depth must be positive when reorg_rate is set")
+	}
+
+	if f.Latency < 0 || f.LatencySpike < 0 {
+		return fmt.Errorf("latency must be non-negative")
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package sim
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/service"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/adaptertest"
+)
+
+func TestAdapter_Conformance(t *testing.T) {
+	config := func(c *Config) { c.MinTxsPerBlock = 1 }
+
+	// Every adapter of the suite serves the same seeded chain
+	reference := newTestAdapter(t, config)
+	tx := reference.chain.generate(5).Transactions[0]
+
+	var adapter *Adapter
+	adaptertest.RunConformance(t, func(t *testing.T) service.ChainAdapter {
+		adapter = newTestAdapter(t, config)
+		return adapter
+	}, adaptertest.Backend{
+		ChainType:        models.ChainTypeSim,
+		ChainID:          DefaultConfig().ChainID,
+		First:            1,
+		Last:             10,
+		TxHash:           tx.Hash,
+		UnknownBlockHash: "0x" + strings.Repeat("ab", 32),
+		UnknownTxHash:    "0x" + strings.Repeat("cd", 32),
+		Produce:          func() { adapter.Mine(1) },
+	})
+}
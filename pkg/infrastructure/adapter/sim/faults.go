@@ -0,0 +1,82 @@
+package sim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// ErrRPC is returned by RPC calls failed by fault injection
+var ErrRPC = errors.New("simulated RPC error")
+
+// faultInjector decides which RPC calls are slowed down or failed. Its
+// decisions are seeded, so a sequence of calls made one at a time sees the
+// same faults on every run.
+type faultInjector struct {
+	config FaultConfig
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+func newFaultInjector(config FaultConfig, seed int64) *faultInjector {
+	return &faultInjector{
+		config: config,
+		// Independent of block production so faults do not change the chain
+		rand: rand.New(rand.NewSource(seed ^ 0x5eed)),
+	}
+}
+
+// call delays an RPC call by the configured latency and fails it with
+// probability ErrorRate
+func (f *faultInjector) call(ctx context.Context, method string) error {
+	f.mu.Lock()
+	delay := f.config.Latency
+	if f.hit(f.config.LatencySpikeRate) {
+		delay += f.config.LatencySpike
+	}
+	fail := f.hit(f.config.ErrorRate)
+	f.mu.Unlock()
+
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	// Like a request that is never sent
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if fail {
+		return fmt.Errorf("%s: %w", method, ErrRPC)
+	}
+
+	return nil
+}
+
+// missingBlock reports whether a block fetch fails as if the node did not
+// have the block yet
+func (f *faultInjector) missingBlock() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.hit(f.config.MissingBlockRate)
+}
+
+// hit reports true with probability rate; disabled faults draw nothing. The
+// caller must hold the lock.
+func (f *faultInjector) hit(rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+	return f.rand.Float64() < rate
+}
@@ -0,0 +1,169 @@
+package sim
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/service"
+)
+
+// subscriptionBufferSize is the number of blocks a subscriber may fall
+// behind before its subscription fails
+const subscriptionBufferSize = 100
+
+// blockSubscription delivers the blocks produced by a chain, including the
+// blocks of new branches after a reorg
+type blockSubscription struct {
+	chain   *Chain
+	blockCh chan *models.Block
+	errCh   chan error
+	done    chan struct{}
+	once    sync.Once
+}
+
+// Subscribe returns a subscription to the blocks produced from now on. It
+// ends when ctx is done or it is unsubscribed.
+func (c *Chain) Subscribe(ctx context.Context) service.BlockSubscription {
+	sub := &blockSubscription{
+		chain:   c,
+		blockCh: make(chan *models.Block, subscriptionBufferSize),
+		errCh:   make(chan error, 1),
+		done:    make(chan struct{}),
+	}
+
+	c.mu.Lock()
+	c.subscribers[sub] = struct{}{}
+	c.mu.Unlock()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			sub.Unsubscribe()
+		case <-sub.done:
+		}
+	}()
+
+	return sub
+}
+
+// notify delivers blocks to every subscriber. A subscriber whose buffer is
+// full is dropped with an error, like a node closing a slow websocket. The
+// caller must hold the write lock.
+func (c *Chain) notify(blocks []*models.Block) {
+	for sub := range c.subscribers {
+		for _, block := range blocks {
+			select {
+			case sub.blockCh <- block:
+				continue
+			default:
+			}
+
+			sub.errCh <- fmt.Errorf("subscriber fell behind at block %d", block.Number)
+			c.unsubscribe(sub)
+			break
+		}
+	}
+}
+
+// unsubscribe removes and closes a subscription. The caller must hold the
+// write lock.
+func (c *Chain) unsubscribe(sub *blockSubscription) {
+	sub.once.Do(func() {
+		delete(c.subscribers, sub)
+		close(sub.blockCh)
+		close(sub.done)
+	})
+}
+
+// Channel returns the channel that receives new blocks
+func (s *blockSubscription) Channel() <-chan *models.Block {
+	return s.blockCh
+}
+
+// Unsubscribe cancels the subscription
+func (s *blockSubscription) Unsubscribe() {
+	s.chain.mu.Lock()
+	defer s.chain.mu.Unlock()
+	s.chain.unsubscribe(s)
+}
+
+// Err returns any subscription error
+func (s *blockSubscription) Err() <-chan error {
+	return s.errCh
+}
+
+// transactionSubscription delivers the transactions of the blocks of a block
+// subscription
+type transactionSubscription struct {
+	blocks service.BlockSubscription
+	txCh   chan *models.Transaction
+	errCh  chan error
+	done   chan struct{}
+	once   sync.Once
+}
+
+func newTransactionSubscription(blocks service.BlockSubscription) *transactionSubscription {
+	sub := &transactionSubscription{
+		blocks: blocks,
+		txCh:   make(chan *models.Transaction, subscriptionBufferSize),
+		errCh:  make(chan error, 1),
+		done:   make(chan struct{}),
+	}
+
+	go sub.forward()
+
+	return sub
+}
+
+// forward delivers the transactions of every block until the block
+// subscription ends
+func (s *transactionSubscription) forward() {
+	defer close(s.txCh)
+
+	for {
+		select {
+		case block, ok := <-s.blocks.Channel():
+			if !ok {
+				// The error that ended the block subscription, if any
+				select {
+				case err := <-s.blocks.Err():
+					s.errCh <- err
+				default:
+				}
+				return
+			}
+			for _, tx := range block.Transactions {
+				select {
+				case s.txCh <- tx:
+				case <-s.done:
+					return
+				}
+			}
+		case err := <-s.blocks.Err():
+			s.errCh <- err
+			return
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// Channel returns the channel that receives new transactions
+func (s *transactionSubscription) Channel() <-chan *models.Transaction {
+	return s.txCh
+}
+
+// Unsubscribe cancels the subscription
+func (s *transactionSubscription) Unsubscribe() {
+	s.once.Do(func() {
+		close(s.done)
+		s.blocks.Unsubscribe()
+	})
+}
+
+// Err returns any subscription error
+func (s *transactionSubscription) Err() <-chan error {
+	return s.errCh
+}
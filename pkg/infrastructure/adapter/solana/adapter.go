@@ -2,11 +2,14 @@ package solana
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sync"
 
 	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
 	"github.com/sage-x-project/blockchain-indexer/pkg/domain/service"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/logger"
+	"go.uber.org/zap"
 )
 
 // Adapter implements the ChainAdapter interface for Solana
@@ -15,6 +18,7 @@ type Adapter struct {
 	client     *Client
 	normalizer *Normalizer
 	chainInfo  *models.ChainInfo
+	logger     *logger.Logger
 	mu         sync.RWMutex
 	connected  bool
 }
@@ -32,11 +36,17 @@ func NewAdapter(config *Config) (*Adapter, error) {
 
 	normalizer := NewNormalizer(config.ChainID, config.Network)
 
+	log := config.Logger
+	if log == nil {
+		log = logger.Global()
+	}
+
 	adapter := &Adapter{
 		config:     config,
 		client:     client,
 		normalizer: normalizer,
 		chainInfo:  normalizer.NormalizeChainInfo(),
+		logger:     log,
 		connected:  true,
 	}
 
@@ -76,6 +86,20 @@ func (a *Adapter) GetChainInfo() *models.ChainInfo {
 	return a.chainInfo
 }
 
+// Capabilities reports the features the adapter supports. Solana has no
+// blockhash lookup and subscriptions need WebSocket to be enabled.
+func (a *Adapter) Capabilities() models.Capabilities {
+	return models.Capabilities{
+		BlockSubscription:       a.config.EnableWebSocket,
+		TransactionSubscription: a.config.EnableWebSocket,
+		TransactionByHash:       true,
+		Logs:                    true,
+		FinalityTags:            true,
+		BatchFetch:              true,
+		MaxBlockRange:           a.config.MaxBlockRange + 1,
+	}
+}
+
 // GetLatestBlockNumber returns the latest slot (block) number
 func (a *Adapter) GetLatestBlockNumber(ctx context.Context) (uint64, error) {
 	slot, err := a.client.GetSlot(ctx)
@@ -86,6 +110,17 @@ func (a *Adapter) GetLatestBlockNumber(ctx context.Context) (uint64, error) {
 	return slot, nil
 }
 
+// GetFinalityHeads returns the latest slot for both heads, since the adapter
+// reads the chain at finalized commitment
+func (a *Adapter) GetFinalityHeads(ctx context.Context) (*models.FinalityHeads, error) {
+	slot, err := a.GetLatestBlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.FinalityHeads{Safe: slot, Finalized: slot}, nil
+}
+
 // GetBlockByNumber fetches a block by slot number
 func (a *Adapter) GetBlockByNumber(ctx context.Context, number uint64) (*models.Block, error) {
 	// Fetch block from Solana
@@ -108,8 +143,7 @@ func (a *Adapter) GetBlockByNumber(ctx context.Context, number uint64) (*models.
 func (a *Adapter) GetBlockByHash(ctx context.Context, hash string) (*models.Block, error) {
 	// Solana doesn't have a direct blockhash lookup API
 	// We would need to scan blocks or maintain an index
-	// For now, return an error
-	return nil, fmt.Errorf("GetBlockByHash not efficiently supported in Solana - use GetBlockByNumber instead")
+	return nil, fmt.Errorf("GetBlockByHash not efficiently supported in Solana - use GetBlockByNumber instead: %w", service.ErrNotSupported)
 }
 
 // GetBlocks fetches multiple blocks in a range
@@ -130,6 +164,17 @@ func (a *Adapter) GetBlocks(ctx context.Context, start, end uint64) ([]*models.B
 		return nil, fmt.Errorf("failed to get blocks in range: %w", err)
 	}
 
+	// An empty range is either skipped slots or not finalized yet
+	if len(availableSlots) == 0 {
+		latest, err := a.client.GetSlot(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest slot: %w", err)
+		}
+		if start > latest {
+			return nil, fmt.Errorf("%w: slot %d is past the latest slot %d", models.ErrBlockNotFound, start, latest)
+		}
+	}
+
 	blocks := make([]*models.Block, 0, len(availableSlots))
 
 	// Fetch blocks concurrently
@@ -195,8 +240,12 @@ func (a *Adapter) GetTransaction(ctx context.Context, hash string) (*models.Tran
 		return nil, fmt.Errorf("failed to get transaction %s: %w", hash, err)
 	}
 
-	// Get block to extract blockhash and timestamp
-	var blockhash string
+	// getTransaction does not return the blockhash, so look it up
+	blockhash, err := a.client.GetBlockhash(ctx, tx.Slot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %d of transaction %s: %w", tx.Slot, hash, err)
+	}
+
 	var blockTime int64
 	if tx.BlockTime != nil {
 		blockTime = *tx.BlockTime
@@ -280,26 +329,223 @@ func (a *Adapter) Disconnect() error {
 	return nil
 }
 
-// SubscribeNewBlocks subscribes to new blocks
-// Note: This requires WebSocket support which is not implemented in this basic version
+// SubscribeNewBlocks subscribes to new finalized blocks. Blocks are streamed
+// with blockSubscribe when BlockSubscribe is set, which the node must enable
+// with --rpc-pubsub-enable-block-subscription. Otherwise the adapter follows
+// slotSubscribe and fetches each block as its slot is rooted.
 func (a *Adapter) SubscribeNewBlocks(ctx context.Context) (service.BlockSubscription, error) {
 	if !a.config.EnableWebSocket {
-		return nil, fmt.Errorf("websocket not enabled")
+		return nil, fmt.Errorf("websocket not enabled: %w", service.ErrNotSupported)
 	}
 
-	// WebSocket subscription would be implemented here
-	// For now, return not implemented error
-	return nil, fmt.Errorf("websocket subscription not yet implemented for Solana")
+	if a.config.BlockSubscribe {
+		return a.subscribeBlocks(ctx)
+	}
+	return a.subscribeSlots(ctx)
 }
 
-// SubscribeNewTransactions subscribes to new transactions
-// Note: This requires WebSocket support which is not implemented in this basic version
+// subscribeSlots delivers the blocks of the slots rooted since the
+// subscription started, fetching them as roots advance
+func (a *Adapter) subscribeSlots(ctx context.Context) (service.BlockSubscription, error) {
+	stream, err := a.client.Subscribe(ctx, "slotSubscribe")
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to slots: %w", err)
+	}
+
+	sub := &blockSubscription{
+		stream:    stream,
+		blockChan: make(chan *models.Block, a.config.SubscriptionBufferSize),
+		errChan:   make(chan error, 10),
+	}
+
+	go func() {
+		defer close(sub.blockChan)
+
+		// next is the first slot not yet delivered
+		var next uint64
+		for {
+			select {
+			case err := <-stream.Errors():
+				a.logRecovered("slot subscription reconnecting", err)
+			case result, ok := <-stream.Notifications():
+				if !ok {
+					if err := stream.Err(); err != nil {
+						sendError(sub.errChan, err)
+					}
+					return
+				}
+
+				var update SlotUpdate
+				if err := json.Unmarshal(result, &update); err != nil {
+					a.logRecovered("failed to decode slot notification", err)
+					continue
+				}
+				if next == 0 {
+					next = update.Root
+				}
+				if update.Root < next {
+					continue
+				}
+
+				// Only finalized blocks are returned, so slots past the
+				// finalized root are picked up by a later notification
+				// Slots that failed to load are fetched with the next root
+				blocks, err := a.GetBlocks(ctx, next, update.Root)
+				if err != nil {
+					a.logRecovered("failed to fetch rooted blocks", err, zap.Uint64("start", next), zap.Uint64("end", update.Root))
+					continue
+				}
+
+				for _, block := range blocks {
+					select {
+					case sub.blockChan <- block:
+					case <-stream.done:
+						return
+					}
+					next = block.Number + 1
+				}
+			}
+		}
+	}()
+
+	return sub, nil
+}
+
+// subscribeBlocks delivers the blocks streamed by blockSubscribe
+func (a *Adapter) subscribeBlocks(ctx context.Context) (service.BlockSubscription, error) {
+	config := map[string]interface{}{
+		"commitment":                     CommitmentFinalized,
+		"maxSupportedTransactionVersion": 0,
+		"showRewards":                    a.config.EnableRewards,
+	}
+	setTransactionDetails(config, a.config.TransactionDetails)
+
+	stream, err := a.client.Subscribe(ctx, "blockSubscribe", "all", config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to blocks: %w", err)
+	}
+
+	sub := &blockSubscription{
+		stream:    stream,
+		blockChan: make(chan *models.Block, a.config.SubscriptionBufferSize),
+		errChan:   make(chan error, 10),
+	}
+
+	go func() {
+		defer close(sub.blockChan)
+
+		// Blocks left out here are fetched by the indexer once it sees the
+		// parent of the next block is missing
+		for {
+			select {
+			case err := <-stream.Errors():
+				a.logRecovered("block subscription reconnecting", err)
+			case result, ok := <-stream.Notifications():
+				if !ok {
+					if err := stream.Err(); err != nil {
+						sendError(sub.errChan, err)
+					}
+					return
+				}
+
+				var notification BlockNotification
+				if err := json.Unmarshal(result, &notification); err != nil {
+					a.logRecovered("failed to decode block notification", err)
+					continue
+				}
+				if notification.Value.Block == nil {
+					if notification.Value.Err != nil {
+						a.logRecovered("block notification without block", fmt.Errorf("%v", notification.Value.Err),
+							zap.Uint64("slot", notification.Value.Slot))
+					}
+					continue
+				}
+
+				block, err := a.normalizer.NormalizeBlock(notification.Value.Slot, notification.Value.Block)
+				if err != nil {
+					a.logRecovered("failed to normalize block", err, zap.Uint64("slot", notification.Value.Slot))
+					continue
+				}
+
+				select {
+				case sub.blockChan <- block:
+				case <-stream.done:
+					return
+				}
+			}
+		}
+	}()
+
+	return sub, nil
+}
+
+// SubscribeNewTransactions subscribes to new finalized transactions through
+// logsSubscribe, fetching each transaction by its signature. Vote
+// transactions are only included when EnableVotes is set.
 func (a *Adapter) SubscribeNewTransactions(ctx context.Context) (service.TransactionSubscription, error) {
 	if !a.config.EnableWebSocket {
-		return nil, fmt.Errorf("websocket not enabled")
+		return nil, fmt.Errorf("websocket not enabled: %w", service.ErrNotSupported)
 	}
 
-	// WebSocket subscription would be implemented here
-	// For now, return not implemented error
-	return nil, fmt.Errorf("websocket subscription not yet implemented for Solana")
+	filter := LogsFilterAll
+	if a.config.EnableVotes {
+		filter = LogsFilterAllWithVotes
+	}
+
+	config := map[string]string{"commitment": CommitmentFinalized}
+	stream, err := a.client.Subscribe(ctx, "logsSubscribe", filter, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to logs: %w", err)
+	}
+
+	sub := &transactionSubscription{
+		stream:  stream,
+		txChan:  make(chan *models.Transaction, a.config.SubscriptionBufferSize),
+		errChan: make(chan error, 10),
+	}
+
+	go func() {
+		defer close(sub.txChan)
+
+		for {
+			select {
+			case err := <-stream.Errors():
+				a.logRecovered("logs subscription reconnecting", err)
+			case result, ok := <-stream.Notifications():
+				if !ok {
+					if err := stream.Err(); err != nil {
+						sendError(sub.errChan, err)
+					}
+					return
+				}
+
+				var notification LogsNotification
+				if err := json.Unmarshal(result, &notification); err != nil {
+					a.logRecovered("failed to decode logs notification", err)
+					continue
+				}
+
+				tx, err := a.GetTransaction(ctx, notification.Value.Signature)
+				if err != nil {
+					a.logRecovered("failed to fetch transaction", err, zap.String("signature", notification.Value.Signature))
+					continue
+				}
+
+				select {
+				case sub.txChan <- tx:
+				case <-stream.done:
+					return
+				}
+			}
+		}
+	}()
+
+	return sub, nil
+}
+
+// logRecovered logs an error a subscription recovered from. Only the error
+// that ends a subscription is reported on its Err channel.
+func (a *Adapter) logRecovered(msg string, err error, fields ...zap.Field) {
+	fields = append(fields, zap.String("chain_id", a.config.ChainID), zap.Error(err))
+	a.logger.Warn(msg, fields...)
 }
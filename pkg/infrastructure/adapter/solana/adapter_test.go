@@ -2,10 +2,19 @@ package solana
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 func TestNewAdapter(t *testing.T) {
@@ -141,6 +150,15 @@ func TestConfigValidation(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "websocket without ws_endpoint",
+			config: func() *Config {
+				config := DefaultConfig("solana-devnet", "devnet", "https://api.devnet.solana.com")
+				config.EnableWebSocket = true
+				return config
+			}(),
+			wantErr: true,
+		},
 		{
 			name: "invalid transaction details",
 			config: &Config{
@@ -163,3 +181,382 @@ func TestConfigValidation(t *testing.T) {
 		})
 	}
 }
+
+// fakeSlots maps the slots of the fake validator's finalized blocks to their
+// parent slots. Slot 102 is skipped and 105 is the latest slot.
+var fakeSlots = map[uint64]uint64{101: 100, 103: 101, 105: 103}
+
+// fakeValidator serves the JSON-RPC methods the adapter uses over HTTP and a
+// pubsub endpoint at /ws. Finalized blocks exist at the slots of fakeSlots.
+type fakeValidator struct {
+	*httptest.Server
+	// subscriptions receives the method and params of each subscribe request
+	subscriptions chan []interface{}
+	// push sends a notification result to the current subscriber; a nil
+	// value drops the connection instead
+	push chan interface{}
+}
+
+func newFakeValidator(t *testing.T) *fakeValidator {
+	t.Helper()
+
+	f := &fakeValidator{
+		subscriptions: make(chan []interface{}, 10),
+		push:          make(chan interface{}, 10),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", f.serveRPC)
+	mux.HandleFunc("/ws", f.serveWebSocket)
+	f.Server = httptest.NewServer(mux)
+	t.Cleanup(f.Close)
+	// Release WebSocket handlers before the server waits for them
+	t.Cleanup(func() { close(f.push) })
+
+	return f
+}
+
+func testBlock(slot, parent uint64) string {
+	return fmt.Sprintf(`{"blockHeight": %[1]d, "blockTime": 1700000000, "blockhash": "hash%[1]d",
+		"parentSlot": %[2]d, "previousBlockhash": "hash%[2]d", "transactions": [
+			{"meta": {"err": null, "fee": 5000, "preBalances": [], "postBalances": []},
+			 "transaction": {"signatures": ["sig%[1]d"], "message": {"accountKeys": ["payer", "program"],
+				"recentBlockhash": "hash%[2]d", "instructions": [], "header": {}}}}
+		]}`, slot, parent)
+}
+
+func (f *fakeValidator) serveRPC(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		ID     int               `json:"id"`
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var result string
+	switch req.Method {
+	case "getVersion":
+		result = `{"solana-core": "1.18.0", "feature-set": 1}`
+	case "getSlot":
+		result = "105"
+	case "getBlocks":
+		var start, end uint64
+		json.Unmarshal(req.Params[0], &start)
+		json.Unmarshal(req.Params[1], &end)
+		slots := make([]string, 0)
+		for _, slot := range []uint64{101, 103, 105} {
+			if slot >= start && slot <= end {
+				slots = append(slots, fmt.Sprint(slot))
+			}
+		}
+		result = "[" + strings.Join(slots, ",") + "]"
+	case "getBlock":
+		var slot uint64
+		json.Unmarshal(req.Params[0], &slot)
+		parent, ok := fakeSlots[slot]
+		if !ok {
+			code, message := ErrCodeSlotSkipped, fmt.Sprintf("Slot %d was skipped, or missing due to ledger jump to recent snapshot", slot)
+			if slot > 105 {
+				code, message = ErrCodeBlockNotAvailable, fmt.Sprintf("Block not available for slot %d", slot)
+			}
+			fmt.Fprintf(w, `{"jsonrpc": "2.0", "id": %d, "error": {"code": %d, "message": %q}}`, req.ID, code, message)
+			return
+		}
+		result = testBlock(slot, parent)
+	case "getTransaction":
+		var signature string
+		json.Unmarshal(req.Params[0], &signature)
+		var slot uint64
+		fmt.Sscanf(signature, "sig%d", &slot)
+		parent, ok := fakeSlots[slot]
+		if !ok {
+			result = "null"
+			break
+		}
+		result = fmt.Sprintf(`{"slot": %d, "blockTime": 1700000000, "meta": {"err": null, "fee": 5000},
+			"transaction": {"signatures": [%q], "message": {"accountKeys": ["payer", "program"],
+				"recentBlockhash": "hash%d", "instructions": [], "header": {}}}}`, slot, signature, parent)
+	default:
+		fmt.Fprintf(w, `{"jsonrpc": "2.0", "id": %d, "error": {"code": -32601, "message": "Method not found"}}`, req.ID)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	fmt.Fprintf(w, `{"jsonrpc": "2.0", "id": %d, "result": %s}`, req.ID, result)
+}
+
+func (f *fakeValidator) serveWebSocket(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	var req struct {
+		ID     int           `json:"id"`
+		Method string        `json:"method"`
+		Params []interface{} `json:"params"`
+	}
+	if err := conn.ReadJSON(&req); err != nil {
+		return
+	}
+	f.subscriptions <- append([]interface{}{req.Method}, req.Params...)
+
+	if err := conn.WriteJSON(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": 7}); err != nil {
+		return
+	}
+
+	notification := strings.TrimSuffix(req.Method, "Subscribe") + "Notification"
+	for result := range f.push {
+		if result == nil {
+			return
+		}
+		msg := map[string]interface{}{
+			"jsonrpc": "2.0",
+			"method":  notification,
+			"params":  map[string]interface{}{"result": result, "subscription": 7},
+		}
+		if err := conn.WriteJSON(msg); err != nil {
+			return
+		}
+	}
+}
+
+func newSubscriptionAdapter(t *testing.T, server *fakeValidator) *Adapter {
+	t.Helper()
+
+	config := DefaultConfig("solana-test", "devnet", server.URL)
+	config.WSEndpoint = "ws" + strings.TrimPrefix(server.URL, "http") + "/ws"
+	config.EnableWebSocket = true
+	config.RetryDelay = 10 * time.Millisecond
+
+	adapter, err := NewAdapter(config)
+	if err != nil {
+		t.Fatalf("NewAdapter() error = %v", err)
+	}
+	return adapter
+}
+
+func receiveBlock(t *testing.T, sub interface {
+	Channel() <-chan *models.Block
+	Err() <-chan error
+}) *models.Block {
+	t.Helper()
+
+	for {
+		select {
+		case block, ok := <-sub.Channel():
+			if !ok {
+				t.Fatal("subscription closed")
+			}
+			return block
+		case err := <-sub.Err():
+			t.Logf("subscription error: %v", err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for block")
+		}
+	}
+}
+
+func TestAdapter_SubscribeNewBlocks(t *testing.T) {
+	server := newFakeValidator(t)
+	adapter := newSubscriptionAdapter(t, server)
+
+	sub, err := adapter.SubscribeNewBlocks(context.Background())
+	if err != nil {
+		t.Fatalf("SubscribeNewBlocks() error = %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	if request := <-server.subscriptions; request[0] != "slotSubscribe" {
+		t.Errorf("subscribed with %v, want slotSubscribe", request)
+	}
+
+	// The first root marks where the subscription starts
+	server.push <- map[string]interface{}{"parent": 109, "root": 100, "slot": 110}
+	server.push <- map[string]interface{}{"parent": 112, "root": 103, "slot": 113}
+
+	for _, want := range []uint64{101, 103} {
+		block := receiveBlock(t, sub)
+		if block.Number != want || block.Hash != fmt.Sprintf("hash%d", want) {
+			t.Errorf("block = %d %s, want %d", block.Number, block.Hash, want)
+		}
+	}
+
+	// Roots that were already delivered are skipped
+	server.push <- map[string]interface{}{"parent": 113, "root": 103, "slot": 114}
+	select {
+	case block := <-sub.Channel():
+		t.Errorf("unexpected block %d", block.Number)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestAdapter_SubscribeNewBlocks_BlockSubscribe(t *testing.T) {
+	server := newFakeValidator(t)
+	adapter := newSubscriptionAdapter(t, server)
+	adapter.config.BlockSubscribe = true
+
+	sub, err := adapter.SubscribeNewBlocks(context.Background())
+	if err != nil {
+		t.Fatalf("SubscribeNewBlocks() error = %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	request := <-server.subscriptions
+	if request[0] != "blockSubscribe" || request[1] != "all" {
+		t.Fatalf("subscribed with %v, want blockSubscribe all", request)
+	}
+	if config := request[2].(map[string]interface{}); config["commitment"] != CommitmentFinalized || config["transactionDetails"] != "full" {
+		t.Errorf("blockSubscribe config = %v", config)
+	}
+
+	var block map[string]interface{}
+	if err := json.Unmarshal([]byte(testBlock(120, 119)), &block); err != nil {
+		t.Fatal(err)
+	}
+	server.push <- map[string]interface{}{
+		"context": map[string]interface{}{"slot": 120},
+		"value":   map[string]interface{}{"slot": 120, "block": block, "err": nil},
+	}
+
+	got := receiveBlock(t, sub)
+	if got.Number != 120 || got.ParentHash != "hash119" || got.TxCount != 1 {
+		t.Errorf("block = %+v", got)
+	}
+	if got.Transactions[0].Hash != "sig120" {
+		t.Errorf("transaction hash = %s, want sig120", got.Transactions[0].Hash)
+	}
+}
+
+func TestAdapter_SubscribeNewTransactions(t *testing.T) {
+	server := newFakeValidator(t)
+	adapter := newSubscriptionAdapter(t, server)
+
+	sub, err := adapter.SubscribeNewTransactions(context.Background())
+	if err != nil {
+		t.Fatalf("SubscribeNewTransactions() error = %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	request := <-server.subscriptions
+	if request[0] != "logsSubscribe" || request[1] != LogsFilterAll {
+		t.Errorf("subscribed with %v, want logsSubscribe all", request)
+	}
+
+	server.push <- map[string]interface{}{
+		"context": map[string]interface{}{"slot": 105},
+		"value":   map[string]interface{}{"signature": "sig105", "err": nil, "logs": []string{"Program log: hi"}},
+	}
+
+	select {
+	case tx := <-sub.Channel():
+		if tx.Hash != "sig105" || tx.BlockNumber != 105 || tx.BlockHash != "hash105" || tx.From != "payer" {
+			t.Errorf("unexpected transaction: %+v", tx)
+		}
+	case err := <-sub.Err():
+		t.Fatalf("subscription error = %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for transaction")
+	}
+}
+
+func TestAdapter_SubscribeResubscribes(t *testing.T) {
+	server := newFakeValidator(t)
+	adapter := newSubscriptionAdapter(t, server)
+	adapter.config.BlockSubscribe = true
+
+	core, logs := observer.New(zap.WarnLevel)
+	adapter.logger = &logger.Logger{Logger: zap.New(core)}
+
+	sub, err := adapter.SubscribeNewBlocks(context.Background())
+	if err != nil {
+		t.Fatalf("SubscribeNewBlocks() error = %v", err)
+	}
+	defer sub.Unsubscribe()
+	<-server.subscriptions
+
+	// Drop the connection; the stream logs the error and subscribes again
+	server.push <- nil
+
+	select {
+	case request := <-server.subscriptions:
+		if request[0] != "blockSubscribe" {
+			t.Errorf("resubscribed with %v, want blockSubscribe", request)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for resubscription")
+	}
+
+	// A connection the stream recovered is not a subscription error
+	select {
+	case err := <-sub.Err():
+		t.Errorf("subscription error = %v, want none for a recovered connection", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	if logs.FilterMessage("block subscription reconnecting").Len() == 0 {
+		t.Error("expected the dropped connection to be logged")
+	}
+
+	var block map[string]interface{}
+	json.Unmarshal([]byte(testBlock(130, 129)), &block)
+	server.push <- map[string]interface{}{
+		"context": map[string]interface{}{"slot": 130},
+		"value":   map[string]interface{}{"slot": 130, "block": block, "err": nil},
+	}
+
+	if got := receiveBlock(t, sub); got.Number != 130 {
+		t.Errorf("block = %d, want 130", got.Number)
+	}
+}
+
+func TestAdapter_SubscribeWebSocketDisabled(t *testing.T) {
+	server := newFakeValidator(t)
+	adapter, err := NewAdapter(DefaultConfig("solana-test", "devnet", server.URL))
+	if err != nil {
+		t.Fatalf("NewAdapter() error = %v", err)
+	}
+
+	if _, err := adapter.SubscribeNewBlocks(context.Background()); err == nil {
+		t.Error("SubscribeNewBlocks() should fail when websocket is disabled")
+	}
+	if _, err := adapter.SubscribeNewTransactions(context.Background()); err == nil {
+		t.Error("SubscribeNewTransactions() should fail when websocket is disabled")
+	}
+}
+
+func TestAdapter_FailsOverToFallbackEndpoint(t *testing.T) {
+	server := newFakeValidator(t)
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+
+	config := DefaultConfig("solana-test", "devnet", down.URL)
+	config.FallbackRPCEndpoints = []string{server.URL}
+	config.MaxRetries = 0
+
+	adapter, err := NewAdapter(config)
+	if err != nil {
+		t.Fatalf("NewAdapter() error = %v", err)
+	}
+	defer adapter.client.Close()
+
+	block, err := adapter.GetBlockByNumber(context.Background(), 101)
+	if err != nil {
+		t.Fatalf("GetBlockByNumber() error = %v", err)
+	}
+	if block.Hash != "hash101" {
+		t.Errorf("block hash = %s, want hash101", block.Hash)
+	}
+
+	status := adapter.client.pool.Status()
+	if status[0].Errors == 0 {
+		t.Error("the unreachable primary endpoint should have recorded an error")
+	}
+	if adapter.client.pool.Select().URL != server.URL {
+		t.Error("the fallback endpoint should be preferred after the primary failed")
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/endpoint"
 	"golang.org/x/time/rate"
 )
 
@@ -18,6 +20,7 @@ import (
 type Client struct {
 	config      *Config
 	httpClient  *http.Client
+	pool        *endpoint.Pool
 	rateLimiter *rate.Limiter
 	requestID   atomic.Int64
 	errorCount  atomic.Int32
@@ -34,44 +37,37 @@ func NewClient(config *Config) (*Client, error) {
 	client := &Client{
 		config: config,
 		httpClient: &http.Client{
-			Timeout: config.RequestTimeout,
+			Transport: config.Transport,
+			Timeout:   config.RequestTimeout,
 		},
 		rateLimiter: rate.NewLimiter(rate.Limit(config.RateLimitPerSecond), config.RateLimitPerSecond),
 		connected:   true,
 	}
 
+	poolConfig := endpoint.DefaultConfig(config.ChainID)
+	poolConfig.MaxLag = config.MaxEndpointLag
+	pool, err := endpoint.NewPool(config.RPCEndpoints(), poolConfig, client.probeSlot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create endpoint pool: %w", err)
+	}
+	client.pool = pool
+
 	return client, nil
 }
 
-// call makes a JSON-RPC call to the Solana node
+// call makes a JSON-RPC call to the healthiest Solana node, failing over to
+// the other endpoints
 func (c *Client) call(ctx context.Context, method string, params []interface{}, result interface{}) error {
 	// Wait for rate limiter
 	if err := c.rateLimiter.Wait(ctx); err != nil {
 		return fmt.Errorf("rate limiter: %w", err)
 	}
 
-	// Create request
-	reqID := int(c.requestID.Add(1))
-	rpcReq := RPCRequest{
-		JSONRPC: "2.0",
-		ID:      reqID,
-		Method:  method,
-		Params:  params,
-	}
-
-	reqBody, err := json.Marshal(rpcReq)
-	if err != nil {
-		return fmt.Errorf("failed to marshal request: %w", err)
-	}
-
-	// Create HTTP request
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", c.config.RPCEndpoint, bytes.NewReader(reqBody))
+	reqBody, err := c.newRequest(method, params)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return err
 	}
 
-	httpReq.Header.Set("Content-Type", "application/json")
-
 	// Execute request with retries
 	var lastErr error
 	for attempt := 0; attempt <= c.config.MaxRetries; attempt++ {
@@ -84,54 +80,104 @@ func (c *Client) call(ctx context.Context, method string, params []interface{},
 			}
 		}
 
-		resp, err := c.httpClient.Do(httpReq)
-		if err != nil {
-			lastErr = err
-			c.errorCount.Add(1)
-			continue
+		err := c.pool.Do(ctx, func(ctx context.Context, e *endpoint.Endpoint) error {
+			return c.post(ctx, e.URL, reqBody, result)
+		})
+		if err == nil {
+			// Reset error count on success
+			c.errorCount.Store(0)
+			return nil
+		}
+		if endpoint.IsPermanent(err) {
+			return err
 		}
 
-		body, err := io.ReadAll(resp.Body)
-		resp.Body.Close()
+		lastErr = err
+		c.errorCount.Add(1)
+	}
 
-		if err != nil {
-			lastErr = err
-			c.errorCount.Add(1)
-			continue
-		}
+	return fmt.Errorf("request failed after %d retries: %w", c.config.MaxRetries, lastErr)
+}
 
-		if resp.StatusCode != http.StatusOK {
-			lastErr = fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
-			c.errorCount.Add(1)
-			continue
-		}
+// newRequest encodes a JSON-RPC request
+func (c *Client) newRequest(method string, params []interface{}) ([]byte, error) {
+	reqBody, err := json.Marshal(RPCRequest{
+		JSONRPC: "2.0",
+		ID:      int(c.requestID.Add(1)),
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
 
-		// Parse response
-		var rpcResp RPCResponse
-		if err := json.Unmarshal(body, &rpcResp); err != nil {
-			lastErr = fmt.Errorf("failed to unmarshal response: %w", err)
-			c.errorCount.Add(1)
-			continue
-		}
+	return reqBody, nil
+}
 
-		// Check for RPC error
-		if rpcResp.Error != nil {
-			return fmt.Errorf("RPC error %d: %s", rpcResp.Error.Code, rpcResp.Error.Message)
-		}
+// post sends a request body to a single RPC endpoint and decodes the result.
+// Errors reported by the node itself are marked permanent so the pool
+// neither fails over nor counts them against the endpoint.
+func (c *Client) post(ctx context.Context, url string, reqBody []byte, result interface{}) error {
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(reqBody))
+	if err != nil {
+		return endpoint.Permanent(fmt.Errorf("failed to create request: %w", err))
+	}
 
-		// Unmarshal result
-		if result != nil && len(rpcResp.Result) > 0 {
-			if err := json.Unmarshal(rpcResp.Result, result); err != nil {
-				return fmt.Errorf("failed to unmarshal result: %w", err)
-			}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	// Parse response
+	var rpcResp RPCResponse
+	if err := json.Unmarshal(body, &rpcResp); err != nil {
+		return fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	// Check for RPC error
+	if rpcResp.Error != nil {
+		return endpoint.Permanent(rpcResp.Error)
+	}
+
+	// Unmarshal result
+	if result != nil && len(rpcResp.Result) > 0 {
+		if err := json.Unmarshal(rpcResp.Result, result); err != nil {
+			return endpoint.Permanent(fmt.Errorf("failed to unmarshal result: %w", err))
 		}
+	}
+
+	return nil
+}
 
-		// Reset error count on success
-		c.errorCount.Store(0)
-		return nil
+// probeSlot returns the finalized slot of a single endpoint for the pool's
+// head tracking
+func (c *Client) probeSlot(ctx context.Context, e *endpoint.Endpoint) (uint64, error) {
+	reqBody, err := c.newRequest("getSlot", []interface{}{
+		map[string]string{"commitment": CommitmentFinalized},
+	})
+	if err != nil {
+		return 0, err
 	}
 
-	return fmt.Errorf("request failed after %d retries: %w", c.config.MaxRetries, lastErr)
+	var result GetSlotResponse
+	if err := c.post(ctx, e.URL, reqBody, &result); err != nil {
+		return 0, err
+	}
+
+	return uint64(result), nil
 }
 
 // GetSlot returns the current slot
@@ -162,9 +208,10 @@ func (c *Client) GetBlockHeight(ctx context.Context) (uint64, error) {
 	return uint64(result), nil
 }
 
-// GetBlock returns a block at the specified slot
+// GetBlock returns a block at the specified slot, failing with
+// models.ErrBlockNotFound for a slot without a block
 func (c *Client) GetBlock(ctx context.Context, slot uint64) (*GetBlockResponse, error) {
-	var result GetBlockResponse
+	var result *GetBlockResponse
 
 	// Build params based on configuration
 	config := map[string]interface{}{
@@ -174,7 +221,54 @@ func (c *Client) GetBlock(ctx context.Context, slot uint64) (*GetBlockResponse,
 	}
 
 	// Set transaction detail level
-	switch c.config.TransactionDetails {
+	setTransactionDetails(config, c.config.TransactionDetails)
+
+	params := []interface{}{slot, config}
+
+	if err := c.call(ctx, "getBlock", params, &result); err != nil {
+		if IsBlockNotAvailable(err) {
+			return nil, fmt.Errorf("getBlock: %w: %w", models.ErrBlockNotFound, err)
+		}
+		return nil, fmt.Errorf("getBlock: %w", err)
+	}
+	if result == nil {
+		return nil, fmt.Errorf("getBlock: %w: slot %d", models.ErrBlockNotFound, slot)
+	}
+
+	return result, nil
+}
+
+// GetBlockhash returns the blockhash of the block at the specified slot
+// without its transactions
+func (c *Client) GetBlockhash(ctx context.Context, slot uint64) (string, error) {
+	var result *GetBlockResponse
+
+	config := map[string]interface{}{
+		"commitment":                     CommitmentFinalized,
+		"maxSupportedTransactionVersion": 0,
+		"transactionDetails":             "none",
+		"rewards":                        false,
+	}
+
+	params := []interface{}{slot, config}
+
+	if err := c.call(ctx, "getBlock", params, &result); err != nil {
+		if IsBlockNotAvailable(err) {
+			return "", fmt.Errorf("getBlock: %w: %w", models.ErrBlockNotFound, err)
+		}
+		return "", fmt.Errorf("getBlock: %w", err)
+	}
+	if result == nil {
+		return "", fmt.Errorf("getBlock: %w: slot %d", models.ErrBlockNotFound, slot)
+	}
+
+	return result.Blockhash, nil
+}
+
+// setTransactionDetails sets the transaction detail level and its encoding
+// in a getBlock or blockSubscribe configuration
+func setTransactionDetails(config map[string]interface{}, details string) {
+	switch details {
 	case "full":
 		config["encoding"] = EncodingJSON
 		config["transactionDetails"] = "full"
@@ -186,19 +280,12 @@ func (c *Client) GetBlock(ctx context.Context, slot uint64) (*GetBlockResponse,
 	case "none":
 		config["transactionDetails"] = "none"
 	}
-
-	params := []interface{}{slot, config}
-
-	if err := c.call(ctx, "getBlock", params, &result); err != nil {
-		return nil, fmt.Errorf("getBlock: %w", err)
-	}
-
-	return &result, nil
 }
 
-// GetTransaction returns a transaction by signature
+// GetTransaction returns a transaction by signature, failing with
+// models.ErrTransactionNotFound for an unknown signature
 func (c *Client) GetTransaction(ctx context.Context, signature string) (*GetTransactionResponse, error) {
-	var result GetTransactionResponse
+	var result *GetTransactionResponse
 
 	config := map[string]interface{}{
 		"commitment":                     CommitmentFinalized,
@@ -211,8 +298,11 @@ func (c *Client) GetTransaction(ctx context.Context, signature string) (*GetTran
 	if err := c.call(ctx, "getTransaction", params, &result); err != nil {
 		return nil, fmt.Errorf("getTransaction: %w", err)
 	}
+	if result == nil {
+		return nil, fmt.Errorf("getTransaction: %w: %s", models.ErrTransactionNotFound, signature)
+	}
 
-	return &result, nil
+	return result, nil
 }
 
 // GetHealth returns the health status
@@ -275,6 +365,7 @@ func (c *Client) Close() error {
 	defer c.mu.Unlock()
 
 	c.connected = false
+	c.pool.Close()
 	c.httpClient.CloseIdleConnections()
 
 	return nil
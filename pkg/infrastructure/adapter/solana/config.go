@@ -2,7 +2,10 @@ package solana
 
 import (
 	"errors"
+	"net/http"
 	"time"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/logger"
 )
 
 // Config holds the configuration for the Solana adapter
@@ -13,8 +16,10 @@ type Config struct {
 	Network   string `json:"network"` // mainnet-beta, testnet, devnet
 
 	// RPC configuration
-	RPCEndpoint string `json:"rpc_endpoint"`
-	WSEndpoint  string `json:"ws_endpoint,omitempty"`
+	RPCEndpoint          string   `json:"rpc_endpoint"`
+	FallbackRPCEndpoints []string `json:"fallback_rpc_endpoints,omitempty"` // Used when rpc_endpoint is unhealthy
+	WSEndpoint           string   `json:"ws_endpoint,omitempty"`
+	MaxEndpointLag       uint64   `json:"max_endpoint_lag"` // Slots an endpoint may lag before it is quarantined
 
 	// Request configuration
 	RequestTimeout      time.Duration `json:"request_timeout"`       // Timeout for RPC requests
@@ -23,6 +28,15 @@ type Config struct {
 	MaxConcurrentReqs   int           `json:"max_concurrent_reqs"`   // Max concurrent requests
 	RateLimitPerSecond  int           `json:"rate_limit_per_second"` // Rate limit (requests/sec)
 
+	// Transport, if set, carries the HTTP JSON-RPC requests, e.g. to record
+	// or replay them with the transport package
+	Transport http.RoundTripper `json:"-"`
+
+	// Logger, if set, logs the errors subscriptions recover from, such as
+	// dropped connections and blocks that failed to load. The global logger
+	// is used otherwise.
+	Logger *logger.Logger `json:"-"`
+
 	// Fetching configuration
 	ConcurrentFetches  int    `json:"concurrent_fetches"`  // Number of concurrent block fetches
 	MaxBlockRange      uint64 `json:"max_block_range"`     // Max block range per request
@@ -32,7 +46,8 @@ type Config struct {
 	TransactionDetails string `json:"transaction_details"` // Transaction detail level: full, accounts, signatures, none
 
 	// Subscription configuration
-	SubscriptionBufferSize int `json:"subscription_buffer_size"` // Buffer size for subscriptions
+	SubscriptionBufferSize int  `json:"subscription_buffer_size"` // Buffer size for subscriptions
+	BlockSubscribe         bool `json:"block_subscribe"`          // Stream blocks with blockSubscribe instead of slotSubscribe
 
 	// Error handling
 	MaxErrorCount int `json:"max_error_count"` // Max errors before marking unhealthy
@@ -45,6 +60,7 @@ func DefaultConfig(chainID, network, rpcEndpoint string) *Config {
 		ChainName:              "Solana",
 		Network:                network,
 		RPCEndpoint:            rpcEndpoint,
+		MaxEndpointLag:         50,
 		RequestTimeout:         30 * time.Second,
 		MaxRetries:             3,
 		RetryDelay:             2 * time.Second,
@@ -57,6 +73,7 @@ func DefaultConfig(chainID, network, rpcEndpoint string) *Config {
 		EnableRewards:          true,
 		TransactionDetails:     "full",
 		SubscriptionBufferSize: 100,
+		BlockSubscribe:         false,
 		MaxErrorCount:          10,
 	}
 }
@@ -75,6 +92,9 @@ func (c *Config) Validate() error {
 	if c.RPCEndpoint == "" {
 		return errors.New("rpc_endpoint is required")
 	}
+	if c.EnableWebSocket && c.WSEndpoint == "" {
+		return errors.New("ws_endpoint is required when enable_websocket is true")
+	}
 	if c.RequestTimeout <= 0 {
 		c.RequestTimeout = 30 * time.Second
 	}
@@ -110,3 +130,8 @@ func (c *Config) Validate() error {
 
 	return nil
 }
+
+// RPCEndpoints returns the primary RPC endpoint followed by the fallbacks
+func (c *Config) RPCEndpoints() []string {
+	return append([]string{c.RPCEndpoint}, c.FallbackRPCEndpoints...)
+}
@@ -0,0 +1,28 @@
+package solana
+
+import (
+	"testing"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/service"
+	"github.com/sage-x-project/blockchain-indexer/pkg/infrastructure/adapter/adaptertest"
+)
+
+func TestAdapter_Conformance(t *testing.T) {
+	server := newFakeValidator(t)
+
+	adaptertest.RunConformance(t, func(t *testing.T) service.ChainAdapter {
+		adapter := newSubscriptionAdapter(t, server)
+		t.Cleanup(func() { adapter.Disconnect() })
+		return adapter
+	}, adaptertest.Backend{
+		ChainType:        models.ChainTypeSolana,
+		ChainID:          "solana-test",
+		First:            101,
+		Last:             105,
+		Skipped:          []uint64{102, 104},
+		TxHash:           "sig103",
+		UnknownBlockHash: "hash999",
+		UnknownTxHash:    "sig999",
+	})
+}
@@ -0,0 +1,293 @@
+package solana
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+)
+
+// Stream is a pubsub subscription over the WebSocket endpoint. When the
+// connection drops the stream reconnects and subscribes again, giving up
+// after MaxErrorCount consecutive failed attempts.
+type Stream struct {
+	config        *Config
+	method        string
+	params        []interface{}
+	notifications chan json.RawMessage
+	errors        chan error
+	done          chan struct{}
+	closeOnce     sync.Once
+
+	mu   sync.Mutex
+	conn *websocket.Conn
+	err  error
+}
+
+// Subscribe opens a WebSocket connection and calls the subscribe method,
+// such as slotSubscribe, with params. The stream is closed when ctx is done
+// or Close is called.
+func (c *Client) Subscribe(ctx context.Context, method string, params ...interface{}) (*Stream, error) {
+	if c.config.WSEndpoint == "" {
+		return nil, fmt.Errorf("ws_endpoint is not configured")
+	}
+
+	s := &Stream{
+		config:        c.config,
+		method:        method,
+		params:        params,
+		notifications: make(chan json.RawMessage, c.config.SubscriptionBufferSize),
+		errors:        make(chan error, 10),
+		done:          make(chan struct{}),
+	}
+
+	conn, err := s.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.conn = conn
+
+	go s.run(ctx, conn)
+	go func() {
+		select {
+		case <-ctx.Done():
+			s.Close()
+		case <-s.done:
+		}
+	}()
+
+	return s, nil
+}
+
+// connect dials the WebSocket endpoint and subscribes, waiting for the
+// subscription ID before returning the connection
+func (s *Stream) connect(ctx context.Context) (*websocket.Conn, error) {
+	dialer := websocket.Dialer{HandshakeTimeout: s.config.RequestTimeout}
+	conn, _, err := dialer.DialContext(ctx, s.config.WSEndpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect websocket: %w", err)
+	}
+
+	request := RPCRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  s.method,
+		Params:  s.params,
+	}
+	if err := conn.WriteJSON(request); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to send %s request: %w", s.method, err)
+	}
+
+	conn.SetReadDeadline(time.Now().Add(s.config.RequestTimeout))
+	var resp SubscriptionMessage
+	if err := conn.ReadJSON(&resp); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to read %s response: %w", s.method, err)
+	}
+	if resp.Error != nil {
+		conn.Close()
+		return nil, fmt.Errorf("%s: RPC error %d: %s", s.method, resp.Error.Code, resp.Error.Message)
+	}
+	var subscriptionID uint64
+	if err := json.Unmarshal(resp.Result, &subscriptionID); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("%s: invalid subscription id: %w", s.method, err)
+	}
+	conn.SetReadDeadline(time.Time{})
+
+	return conn, nil
+}
+
+// run delivers notifications from conn and reconnects whenever the
+// connection fails, until the stream is closed or reconnecting gives up
+func (s *Stream) run(ctx context.Context, conn *websocket.Conn) {
+	defer close(s.notifications)
+
+	for {
+		err := s.readLoop(conn)
+		if s.isClosed() {
+			return
+		}
+		s.sendError(err)
+
+		conn = s.reconnect(ctx)
+		if conn == nil {
+			return
+		}
+	}
+}
+
+// readLoop delivers the notifications received on conn until it fails
+func (s *Stream) readLoop(conn *websocket.Conn) error {
+	notification := strings.TrimSuffix(s.method, "Subscribe") + "Notification"
+
+	for {
+		var msg SubscriptionMessage
+		if err := conn.ReadJSON(&msg); err != nil {
+			return fmt.Errorf("websocket read failed: %w", err)
+		}
+
+		if msg.Method != notification || msg.Params == nil {
+			continue
+		}
+
+		select {
+		case s.notifications <- msg.Params.Result:
+		case <-s.done:
+			return nil
+		}
+	}
+}
+
+// reconnect subscribes again on a new connection, returning nil if the
+// stream was closed or MaxErrorCount attempts in a row failed
+func (s *Stream) reconnect(ctx context.Context) *websocket.Conn {
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-s.done:
+			return nil
+		case <-time.After(s.config.RetryDelay):
+		}
+
+		conn, err := s.connect(ctx)
+		if err != nil {
+			if attempt >= s.config.MaxErrorCount {
+				s.setErr(fmt.Errorf("%s: giving up after %d reconnect attempts: %w", s.method, attempt, err))
+				return nil
+			}
+			s.sendError(err)
+			continue
+		}
+
+		s.mu.Lock()
+		s.conn = conn
+		s.mu.Unlock()
+
+		// Close may have raced with the new connection
+		if s.isClosed() {
+			conn.Close()
+			return nil
+		}
+
+		return conn
+	}
+}
+
+// Notifications returns the channel of notification payloads. It is closed
+// when the stream ends; Err then reports why.
+func (s *Stream) Notifications() <-chan json.RawMessage {
+	return s.notifications
+}
+
+// Errors returns the channel of connection errors the stream recovered from
+func (s *Stream) Errors() <-chan error {
+	return s.errors
+}
+
+// Err returns the error that ended the stream, or nil if it was closed
+func (s *Stream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+func (s *Stream) setErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+// sendError reports a connection error without blocking when nobody is reading
+func (s *Stream) sendError(err error) {
+	select {
+	case s.errors <- err:
+	default:
+	}
+}
+
+func (s *Stream) isClosed() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Close closes the WebSocket connection and stops reconnecting
+func (s *Stream) Close() {
+	s.closeOnce.Do(func() {
+		close(s.done)
+
+		s.mu.Lock()
+		conn := s.conn
+		s.mu.Unlock()
+
+		conn.WriteControl(
+			websocket.CloseMessage,
+			websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+			time.Now().Add(time.Second),
+		)
+		conn.Close()
+	})
+}
+
+// blockSubscription implements service.BlockSubscription for Solana
+type blockSubscription struct {
+	stream    *Stream
+	blockChan chan *models.Block
+	errChan   chan error
+}
+
+// Channel returns the channel that receives new blocks
+func (s *blockSubscription) Channel() <-chan *models.Block {
+	return s.blockChan
+}
+
+// Unsubscribe cancels the subscription
+func (s *blockSubscription) Unsubscribe() {
+	s.stream.Close()
+}
+
+// Err returns the channel that receives the error that ended the
+// subscription
+func (s *blockSubscription) Err() <-chan error {
+	return s.errChan
+}
+
+// transactionSubscription implements service.TransactionSubscription for Solana
+type transactionSubscription struct {
+	stream  *Stream
+	txChan  chan *models.Transaction
+	errChan chan error
+}
+
+// Channel returns the channel that receives new transactions
+func (s *transactionSubscription) Channel() <-chan *models.Transaction {
+	return s.txChan
+}
+
+// Unsubscribe cancels the subscription
+func (s *transactionSubscription) Unsubscribe() {
+	s.stream.Close()
+}
+
+// Err returns the channel that receives the error that ended the
+// subscription
+func (s *transactionSubscription) Err() <-chan error {
+	return s.errChan
+}
+
+// sendError reports an error without blocking when nobody is reading
+func sendError(errChan chan error, err error) {
+	select {
+	case errChan <- err:
+	default:
+	}
+}
@@ -1,6 +1,10 @@
 package solana
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
 
 // Solana RPC types - these match the Solana JSON-RPC API responses
 
@@ -160,6 +164,33 @@ type RPCError struct {
 	Data    interface{} `json:"data,omitempty"`
 }
 
+// Error implements the error interface
+func (e *RPCError) Error() string {
+	return fmt.Sprintf("RPC error %d: %s", e.Code, e.Message)
+}
+
+// JSON-RPC error codes of slots that have no block
+const (
+	ErrCodeBlockNotAvailable          = -32004
+	ErrCodeSlotSkipped                = -32007
+	ErrCodeLongTermStorageSlotSkipped = -32009
+)
+
+// IsBlockNotAvailable reports whether err is the node's answer for a slot
+// that has no block, because it was skipped or is not produced yet
+func IsBlockNotAvailable(err error) bool {
+	var rpcErr *RPCError
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+
+	switch rpcErr.Code {
+	case ErrCodeBlockNotAvailable, ErrCodeSlotSkipped, ErrCodeLongTermStorageSlotSkipped:
+		return true
+	}
+	return false
+}
+
 // SlotUpdate represents a slot update notification
 type SlotUpdate struct {
 	Parent uint64 `json:"parent"`
@@ -182,3 +213,51 @@ const (
 	CommitmentConfirmed = "confirmed"
 	CommitmentFinalized = "finalized"
 )
+
+// SubscriptionMessage is a message received on a pubsub WebSocket: either
+// the response to a subscribe request or a subscription notification
+type SubscriptionMessage struct {
+	JSONRPC string              `json:"jsonrpc"`
+	ID      *int                `json:"id,omitempty"`
+	Result  json.RawMessage     `json:"result,omitempty"`
+	Error   *RPCError           `json:"error,omitempty"`
+	Method  string              `json:"method,omitempty"`
+	Params  *NotificationParams `json:"params,omitempty"`
+}
+
+// NotificationParams holds the payload of a subscription notification
+type NotificationParams struct {
+	Result       json.RawMessage `json:"result"`
+	Subscription uint64          `json:"subscription"`
+}
+
+// NotificationContext is the context attached to block and logs notifications
+type NotificationContext struct {
+	Slot uint64 `json:"slot"`
+}
+
+// BlockNotification represents a blockSubscribe notification
+type BlockNotification struct {
+	Context NotificationContext `json:"context"`
+	Value   struct {
+		Slot  uint64            `json:"slot"`
+		Block *GetBlockResponse `json:"block"`
+		Err   interface{}       `json:"err"`
+	} `json:"value"`
+}
+
+// LogsNotification represents a logsSubscribe notification
+type LogsNotification struct {
+	Context NotificationContext `json:"context"`
+	Value   struct {
+		Signature string      `json:"signature"`
+		Err       interface{} `json:"err"`
+		Logs      []string    `json:"logs"`
+	} `json:"value"`
+}
+
+// Logs subscription filters
+const (
+	LogsFilterAll          = "all"
+	LogsFilterAllWithVotes = "allWithVotes"
+)
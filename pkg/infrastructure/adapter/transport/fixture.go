@@ -0,0 +1,192 @@
+// Package transport records the HTTP JSON-RPC traffic of chain adapters to
+// fixture files and replays it without a network, so blocks that expose a
+// normalizer bug can be captured once and kept as regression tests.
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Fixture is the recorded RPC traffic of an adapter
+type Fixture struct {
+	// Chain the traffic was recorded from
+	ChainType string `json:"chain_type"`
+	ChainID   string `json:"chain_id"`
+	Network   string `json:"network,omitempty"`
+
+	// StartBlock and EndBlock are the blocks fetched while recording
+	StartBlock uint64 `json:"start_block"`
+	EndBlock   uint64 `json:"end_block"`
+
+	RecordedAt time.Time `json:"recorded_at"`
+
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded HTTP request and its response. Bodies are kept
+// as JSON when they are JSON and as JSON strings otherwise. Endpoint URLs are
+// not recorded since they often carry API keys.
+type Interaction struct {
+	Method   string          `json:"method"`
+	Request  json.RawMessage `json:"request"`
+	Status   int             `json:"status"`
+	Response json.RawMessage `json:"response"`
+}
+
+// LoadFixture reads a fixture file
+func LoadFixture(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fixture: %w", err)
+	}
+
+	var fixture Fixture
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		return nil, fmt.Errorf("failed to decode fixture %s: %w", path, err)
+	}
+
+	return &fixture, nil
+}
+
+// Save writes the fixture to path, creating its directory
+func (f *Fixture) Save(path string) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode fixture: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create fixture directory: %w", err)
+	}
+
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write fixture: %w", err)
+	}
+
+	return nil
+}
+
+// GoldenPath returns the path of the golden file holding the normalized
+// output of the fixture at path
+func GoldenPath(path string) string {
+	return path[:len(path)-len(filepath.Ext(path))] + ".golden.json"
+}
+
+// encodeBody returns a body as JSON: unchanged if it is JSON, as a string
+// otherwise
+func encodeBody(body []byte) json.RawMessage {
+	if len(bytes.TrimSpace(body)) > 0 && json.Valid(body) {
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, body); err == nil {
+			return compact.Bytes()
+		}
+	}
+
+	encoded, _ := json.Marshal(string(body))
+	return encoded
+}
+
+// decodeBody reverses encodeBody
+func decodeBody(raw json.RawMessage) []byte {
+	var s string
+	if len(raw) > 0 && raw[0] == '"' && json.Unmarshal(raw, &s) == nil {
+		return []byte(s)
+	}
+	return raw
+}
+
+// requestKey identifies a request independently of its JSON-RPC ids, which
+// clients number differently on every run
+func requestKey(method string, body []byte) string {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return method + " " + string(body)
+	}
+
+	switch msg := v.(type) {
+	case map[string]interface{}:
+		delete(msg, "id")
+	case []interface{}:
+		for _, elem := range msg {
+			if m, ok := elem.(map[string]interface{}); ok {
+				delete(m, "id")
+			}
+		}
+	}
+
+	// Maps are encoded with sorted keys
+	canonical, _ := json.Marshal(v)
+	return method + " " + string(canonical)
+}
+
+// requestIDs returns the JSON-RPC ids of a request in order
+func requestIDs(body []byte) []json.RawMessage {
+	var batch []struct {
+		ID json.RawMessage `json:"id"`
+	}
+	if err := json.Unmarshal(body, &batch); err == nil {
+		ids := make([]json.RawMessage, len(batch))
+		for i := range batch {
+			ids[i] = batch[i].ID
+		}
+		return ids
+	}
+
+	var single struct {
+		ID json.RawMessage `json:"id"`
+	}
+	if err := json.Unmarshal(body, &single); err == nil && single.ID != nil {
+		return []json.RawMessage{single.ID}
+	}
+
+	return nil
+}
+
+// rewriteIDs replaces the ids of a recorded response with the ids of the
+// request being replayed, matching recorded and replayed ids by position in
+// the request
+func rewriteIDs(response []byte, recorded, replayed []json.RawMessage) []byte {
+	if len(recorded) == 0 || len(recorded) != len(replayed) {
+		return response
+	}
+
+	ids := make(map[string]json.RawMessage, len(recorded))
+	for i := range recorded {
+		ids[string(recorded[i])] = replayed[i]
+	}
+
+	rewrite := func(msg map[string]json.RawMessage) {
+		if id, ok := ids[string(msg["id"])]; ok {
+			msg["id"] = id
+		}
+	}
+
+	var batch []map[string]json.RawMessage
+	if err := json.Unmarshal(response, &batch); err == nil {
+		for _, msg := range batch {
+			rewrite(msg)
+		}
+		if out, err := json.Marshal(batch); err == nil {
+			return out
+		}
+		return response
+	}
+
+	var single map[string]json.RawMessage
+	if err := json.Unmarshal(response, &single); err == nil {
+		rewrite(single)
+		if out, err := json.Marshal(single); err == nil {
+			return out
+		}
+	}
+
+	return response
+}
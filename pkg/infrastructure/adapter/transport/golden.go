@@ -0,0 +1,118 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/service"
+)
+
+// FetchBlocks fetches the blocks from start to end one by one, the way the
+// blocks of a fixture are recorded and replayed. Numbers without a block,
+// like Solana's skipped slots, are left out.
+func FetchBlocks(ctx context.Context, adapter service.ChainAdapter, start, end uint64) ([]*models.Block, error) {
+	if start > end {
+		return nil, fmt.Errorf("invalid range: start %d > end %d", start, end)
+	}
+
+	blocks := make([]*models.Block, 0, end-start+1)
+	for number := start; number <= end; number++ {
+		block, err := adapter.GetBlockByNumber(ctx, number)
+		if errors.Is(err, models.ErrBlockNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get block %d: %w", number, err)
+		}
+		blocks = append(blocks, block)
+	}
+
+	return blocks, nil
+}
+
+// EncodeGolden encodes normalized blocks the way golden files hold them
+func EncodeGolden(blocks []*models.Block) ([]byte, error) {
+	data, err := json.MarshalIndent(blocks, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode blocks: %w", err)
+	}
+
+	return append(data, '\n'), nil
+}
+
+// WriteGolden writes normalized blocks to a golden file, creating its
+// directory
+func WriteGolden(path string, blocks []*models.Block) error {
+	data, err := EncodeGolden(blocks)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create golden directory: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write golden file: %w", err)
+	}
+
+	return nil
+}
+
+// CheckGolden compares normalized blocks with a golden file and reports the
+// first line that differs
+func CheckGolden(path string, blocks []*models.Block) error {
+	want, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read golden file: %w", err)
+	}
+
+	got, err := EncodeGolden(blocks)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(got, want) {
+		return nil
+	}
+
+	gotLines := strings.Split(string(got), "\n")
+	wantLines := strings.Split(string(want), "\n")
+	for i := 0; i < len(gotLines) || i < len(wantLines); i++ {
+		var gotLine, wantLine string
+		if i < len(gotLines) {
+			gotLine = gotLines[i]
+		}
+		if i < len(wantLines) {
+			wantLine = wantLines[i]
+		}
+		if gotLine != wantLine {
+			return fmt.Errorf("%s:%d: got %q, want %q", path, i+1, strings.TrimSpace(gotLine), strings.TrimSpace(wantLine))
+		}
+	}
+
+	return nil
+}
+
+// Fixtures returns the fixture files in dir, leaving out their golden files
+func Fixtures(dir string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	fixtures := paths[:0]
+	for _, path := range paths {
+		if !strings.HasSuffix(path, ".golden.json") {
+			fixtures = append(fixtures, path)
+		}
+	}
+
+	return fixtures, nil
+}
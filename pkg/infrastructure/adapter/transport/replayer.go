@@ -0,0 +1,94 @@
+package transport
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// ErrNotRecorded is returned for requests that are not in the fixture
+var ErrNotRecorded = errors.New("request not recorded in fixture")
+
+// Replayer is an http.RoundTripper that answers requests from a fixture
+// without a network. Requests match recorded ones by HTTP method and body,
+// ignoring JSON-RPC ids; identical requests get the recorded responses in
+// order, and the last one once those run out.
+type Replayer struct {
+	mu        sync.Mutex
+	responses map[string][]*Interaction
+	served    map[string]int
+}
+
+// NewReplayer creates a Replayer serving the interactions of fixture
+func NewReplayer(fixture *Fixture) *Replayer {
+	r := &Replayer{
+		responses: make(map[string][]*Interaction),
+		served:    make(map[string]int),
+	}
+
+	for _, interaction := range fixture.Interactions {
+		key := requestKey(interaction.Method, decodeBody(interaction.Request))
+		r.responses[key] = append(r.responses[key], interaction)
+	}
+
+	return r
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read request body: %w", err)
+		}
+	}
+
+	key := requestKey(req.Method, reqBody)
+
+	r.mu.Lock()
+	recorded := r.responses[key]
+	n := r.served[key]
+	if n < len(recorded) {
+		r.served[key]++
+	} else {
+		n = len(recorded) - 1
+	}
+	r.mu.Unlock()
+
+	if len(recorded) == 0 {
+		return nil, fmt.Errorf("%w: %s %s", ErrNotRecorded, req.Method, truncate(reqBody, 200))
+	}
+	interaction := recorded[n]
+
+	respBody := rewriteIDs(
+		decodeBody(interaction.Response),
+		requestIDs(decodeBody(interaction.Request)),
+		requestIDs(reqBody),
+	)
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Status, http.StatusText(interaction.Status)),
+		StatusCode:    interaction.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(respBody)),
+		ContentLength: int64(len(respBody)),
+		Request:       req,
+	}, nil
+}
+
+// truncate shortens a body for error messages
+func truncate(body []byte, n int) string {
+	if len(body) <= n {
+		return string(body)
+	}
+	return string(body[:n]) + "..."
+}
@@ -0,0 +1,168 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+// newCounterServer answers JSON-RPC requests, single or batched, with the
+// number of requests served so far, so repeated requests get new results
+func newCounterServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	var count atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		respond := func(req map[string]json.RawMessage) map[string]interface{} {
+			return map[string]interface{}{
+				"jsonrpc": "2.0",
+				"id":      req["id"],
+				"result":  map[string]interface{}{"method": req["method"], "count": count.Add(1)},
+			}
+		}
+
+		var batch []map[string]json.RawMessage
+		if json.Unmarshal(body, &batch) == nil {
+			resps := make([]interface{}, len(batch))
+			for i := range batch {
+				resps[i] = respond(batch[i])
+			}
+			// Servers may answer batches in any order
+			for i, j := 0, len(resps)-1; i < j; i, j = i+1, j-1 {
+				resps[i], resps[j] = resps[j], resps[i]
+			}
+			json.NewEncoder(w).Encode(resps)
+			return
+		}
+
+		var req map[string]json.RawMessage
+		if err := json.Unmarshal(body, &req); err != nil {
+			http.Error(w, "not json-rpc", http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(respond(req))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func post(t *testing.T, client *http.Client, url, body string) (int, string) {
+	t.Helper()
+
+	resp, err := client.Post(url, "application/json", bytes.NewReader([]byte(body)))
+	if err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	data, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, string(bytes.TrimSpace(data))
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	server := newCounterServer(t)
+	recorder := NewRecorder(nil)
+	live := &http.Client{Transport: recorder}
+
+	requests := []string{
+		`{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber","params":[]}`,
+		`{"jsonrpc":"2.0","id":2,"method":"eth_blockNumber","params":[]}`,
+		`[{"jsonrpc":"2.0","id":3,"method":"eth_getBlockByNumber","params":["0x1",true]},{"jsonrpc":"2.0","id":4,"method":"eth_getBlockReceipts","params":["0x1"]}]`,
+		`not json`,
+	}
+	var recorded []string
+	for _, req := range requests {
+		_, body := post(t, live, server.URL, req)
+		recorded = append(recorded, body)
+	}
+
+	fixture := &Fixture{ChainType: "evm", ChainID: "ethereum", StartBlock: 1, EndBlock: 1, Interactions: recorder.Interactions()}
+	path := filepath.Join(t.TempDir(), "ethereum-1-1.json")
+	if err := fixture.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if GoldenPath(path) != filepath.Join(filepath.Dir(path), "ethereum-1-1.golden.json") {
+		t.Errorf("GoldenPath() = %s", GoldenPath(path))
+	}
+
+	loaded, err := LoadFixture(path)
+	if err != nil {
+		t.Fatalf("LoadFixture() error = %v", err)
+	}
+	if len(loaded.Interactions) != len(requests) {
+		t.Fatalf("fixture has %d interactions, want %d", len(loaded.Interactions), len(requests))
+	}
+
+	// Replay with the server gone and with other JSON-RPC ids
+	server.Close()
+	replay := &http.Client{Transport: NewReplayer(loaded)}
+
+	_, first := post(t, replay, "http://replay.invalid", `{"jsonrpc":"2.0","id":7,"method":"eth_blockNumber","params":[]}`)
+	_, second := post(t, replay, "http://replay.invalid", `{"method":"eth_blockNumber","params":[],"jsonrpc":"2.0","id":8}`)
+	_, third := post(t, replay, "http://replay.invalid", `{"jsonrpc":"2.0","id":9,"method":"eth_blockNumber","params":[]}`)
+	assertResult(t, first, recorded[0], `7`)
+	assertResult(t, second, recorded[1], `8`)
+	// Repeats the last response once the recorded ones run out
+	assertResult(t, third, recorded[1], `9`)
+
+	_, batch := post(t, replay, "http://replay.invalid",
+		`[{"jsonrpc":"2.0","id":"a","method":"eth_getBlockByNumber","params":["0x1",true]},{"jsonrpc":"2.0","id":"b","method":"eth_getBlockReceipts","params":["0x1"]}]`)
+	var resps []struct {
+		ID     json.RawMessage            `json:"id"`
+		Result map[string]json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal([]byte(batch), &resps); err != nil || len(resps) != 2 {
+		t.Fatalf("batch response = %s", batch)
+	}
+	for _, resp := range resps {
+		switch string(resp.Result["method"]) {
+		case `"eth_getBlockByNumber"`:
+			if string(resp.ID) != `"a"` {
+				t.Errorf("eth_getBlockByNumber id = %s, want \"a\"", resp.ID)
+			}
+		case `"eth_getBlockReceipts"`:
+			if string(resp.ID) != `"b"` {
+				t.Errorf("eth_getBlockReceipts id = %s, want \"b\"", resp.ID)
+			}
+		}
+	}
+
+	status, body := post(t, replay, "http://replay.invalid", `not json`)
+	if status != http.StatusBadRequest || body != recorded[3] {
+		t.Errorf("non-JSON replay = %d %q, want 400 %q", status, body, recorded[3])
+	}
+
+	_, err = replay.Post("http://replay.invalid", "application/json",
+		bytes.NewReader([]byte(`{"jsonrpc":"2.0","id":1,"method":"eth_chainId","params":[]}`)))
+	if !errors.Is(err, ErrNotRecorded) {
+		t.Errorf("unrecorded request error = %v, want ErrNotRecorded", err)
+	}
+}
+
+// assertResult checks that a replayed response is the recorded one with the
+// id of the replayed request
+func assertResult(t *testing.T, got, recorded, id string) {
+	t.Helper()
+
+	var gotResp, wantResp map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(got), &gotResp); err != nil {
+		t.Fatalf("invalid response %s", got)
+	}
+	json.Unmarshal([]byte(recorded), &wantResp)
+
+	if string(gotResp["id"]) != id {
+		t.Errorf("response id = %s, want %s", gotResp["id"], id)
+	}
+	if string(gotResp["result"]) != string(wantResp["result"]) {
+		t.Errorf("response result = %s, want %s", gotResp["result"], wantResp["result"])
+	}
+}
@@ -59,6 +59,8 @@ type PebbleConfig struct {
 	MaxConcurrentMem int    `yaml:"max_concurrent_mem"`
 	DisableWAL       bool   `yaml:"disable_wal"`
 	BytesPerSync     int    `yaml:"bytes_per_sync"` // bytes
+	Codec            string `yaml:"codec"`          // cbor, json
+	Compression      string `yaml:"compression"`    // zstd, none
 }
 
 // PostgresConfig contains PostgreSQL specific settings
@@ -89,6 +91,28 @@ type ChainConfig struct {
 	ConfirmationBlocks uint64   `yaml:"confirmation_blocks"`
 	RetryAttempts      int      `yaml:"retry_attempts"`
 	RetryDelay         string   `yaml:"retry_delay"`
+
+	// AvalancheChainType selects the Avalanche chain (C-Chain, X-Chain or
+	// P-Chain) for chains of type avalanche. Defaults to C-Chain.
+	AvalancheChainType string `yaml:"avalanche_chain_type,omitempty"`
+
+	// Realtime follows new heads through a block subscription once the
+	// indexer has caught up, falling back to polling when the subscription
+	// fails. Defaults to true.
+	Realtime *bool `yaml:"realtime,omitempty"`
+
+	// IndexPending indexes new blocks before they are confirmation_blocks
+	// deep. They are marked pending and promoted as the chain finalizes them.
+	IndexPending bool `yaml:"index_pending,omitempty"`
+
+	// MaxEndpointLag is how many blocks one of the RPC endpoints may fall
+	// behind the others before requests stop being routed to it. Defaults to
+	// the adapter's own setting.
+	MaxEndpointLag uint64 `yaml:"max_endpoint_lag,omitempty"`
+
+	// Config holds chain type specific settings, such as trace_api for EVM
+	// chains
+	Config map[string]interface{} `yaml:"config,omitempty"`
 }
 
 // ServerConfig contains server configuration
@@ -101,6 +125,9 @@ type ServerConfig struct {
 
 	// GraphQL server
 	GraphQL GraphQLConfig `yaml:"graphql"`
+
+	// Admin API
+	Admin AdminConfig `yaml:"admin"`
 }
 
 // HTTPConfig contains HTTP server settings
@@ -133,6 +160,15 @@ type GraphQLConfig struct {
 	Playground bool   `yaml:"playground"`
 }
 
+// AdminConfig contains admin API settings. The admin API controls the
+// indexer, so enabling it also runs the indexer in the server process.
+type AdminConfig struct {
+	Enabled bool `yaml:"enabled"`
+
+	// Token is the bearer token admin requests have to carry
+	Token string `yaml:"token"`
+}
+
 // LoggingConfig contains logging settings
 type LoggingConfig struct {
 	Level      string `yaml:"level"`       // debug, info, warn, error
@@ -204,6 +240,16 @@ func (c *Config) Validate() error {
 		if c.Storage.Pebble.Path == "" {
 			return fmt.Errorf("storage.pebble.path is required")
 		}
+		switch c.Storage.Pebble.Codec {
+		case "", "cbor", "json":
+		default:
+			return fmt.Errorf("unsupported storage.pebble.codec: %s", c.Storage.Pebble.Codec)
+		}
+		switch c.Storage.Pebble.Compression {
+		case "", "zstd", "none":
+		default:
+			return fmt.Errorf("unsupported storage.pebble.compression: %s", c.Storage.Pebble.Compression)
+		}
 	case "postgres":
 		if c.Storage.Postgres.Host == "" {
 			return fmt.Errorf("storage.postgres.host is required")
@@ -226,6 +272,11 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// Validate admin API
+	if c.Server.Admin.Enabled && c.Server.Admin.Token == "" {
+		return fmt.Errorf("server.admin.token is required when the admin API is enabled")
+	}
+
 	// Validate logging
 	if c.Logging.Level == "" {
 		c.Logging.Level = "info" // default
@@ -253,7 +304,8 @@ func (c *ChainConfig) Validate() error {
 		return fmt.Errorf("name is required")
 	}
 
-	if len(c.RPCEndpoints) == 0 {
+	// The simulated chain runs in-process
+	if len(c.RPCEndpoints) == 0 && chainType != models.ChainTypeSim {
 		return fmt.Errorf("at least one rpc_endpoint is required")
 	}
 
@@ -289,6 +341,12 @@ func (c *Config) GetChainByID(chainID string) (*ChainConfig, bool) {
 	return nil, false
 }
 
+// IsRealtimeEnabled reports whether the chain follows new heads through a
+// block subscription
+func (c *ChainConfig) IsRealtimeEnabled() bool {
+	return c.Realtime == nil || *c.Realtime
+}
+
 // GetRetryDelay parses retry delay duration
 func (c *ChainConfig) GetRetryDelay() time.Duration {
 	if c.RetryDelay == "" {
@@ -363,6 +421,8 @@ func Default() *Config {
 				MaxConcurrentMem: 2,
 				DisableWAL:       false,
 				BytesPerSync:     512 << 10, // 512KB
+				Codec:            "cbor",
+				Compression:      "zstd",
 			},
 		},
 		Server: ServerConfig{
@@ -172,6 +172,16 @@ func TestConfig_Validate(t *testing.T) {
 			t.Error("Validate() should return error for no chains")
 		}
 	})
+
+	t.Run("admin API without token", func(t *testing.T) {
+		cfg := Default()
+		cfg.Server.Admin.Enabled = true
+
+		err := cfg.Validate()
+		if err == nil {
+			t.Error("Validate() should return error for admin API without token")
+		}
+	})
 }
 
 func TestChainConfig_Validate(t *testing.T) {
@@ -238,6 +248,21 @@ func TestChainConfig_Validate(t *testing.T) {
 		}
 	})
 
+	t.Run("sim chain without rpc endpoints", func(t *testing.T) {
+		chain := ChainConfig{
+			ChainType: "sim",
+			ChainID:   "sim",
+			Name:      "Simulated Chain",
+			BatchSize: 100,
+			Workers:   10,
+		}
+
+		err := chain.Validate()
+		if err != nil {
+			t.Errorf("Validate() error = %v", err)
+		}
+	})
+
 	t.Run("invalid batch size", func(t *testing.T) {
 		chain := ChainConfig{
 			ChainType:    "evm",
@@ -342,6 +367,29 @@ func TestChainConfig_GetRetryDelay(t *testing.T) {
 	})
 }
 
+func TestChainConfig_IsRealtimeEnabled(t *testing.T) {
+	enabled, disabled := true, false
+
+	tests := []struct {
+		name     string
+		realtime *bool
+		want     bool
+	}{
+		{name: "unset defaults to enabled", realtime: nil, want: true},
+		{name: "enabled", realtime: &enabled, want: true},
+		{name: "disabled", realtime: &disabled, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain := ChainConfig{Realtime: tt.realtime}
+			if got := chain.IsRealtimeEnabled(); got != tt.want {
+				t.Errorf("IsRealtimeEnabled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestDefault(t *testing.T) {
 	cfg := Default()
 
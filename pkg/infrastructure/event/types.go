@@ -36,6 +36,9 @@ const (
 
 	// EventTypeGapRecovered is emitted when a gap is recovered
 	EventTypeGapRecovered EventType = "gap.recovered"
+
+	// EventTypeChainReorg is emitted when a chain reorganization is handled
+	EventTypeChainReorg EventType = "chain.reorg"
 )
 
 // String returns the string representation of EventType
@@ -105,6 +108,17 @@ type GapPayload struct {
 	Size       uint64
 }
 
+// ReorgPayload is the payload for chain reorganization events
+type ReorgPayload struct {
+	ChainID        string
+	Depth          uint64 // Number of orphaned blocks
+	CommonAncestor uint64 // Last block shared by both branches
+	StartBlock     uint64 // First orphaned block
+	EndBlock       uint64 // Last orphaned block
+	OldHeadHash    string // Hash of the orphaned head
+	NewHeadHash    string // Hash of the canonical head after recovery
+}
+
 // ErrorPayload is the payload for error events
 type ErrorPayload struct {
 	Error   error
@@ -302,6 +302,17 @@ func (m *Metrics) register() {
 	)
 }
 
+// Register registers collectors of other packages, such as the RPC endpoint
+// pools, with the metrics registry
+func (m *Metrics) Register(collectors ...prometheus.Collector) error {
+	for _, c := range collectors {
+		if err := m.registry.Register(c); err != nil {
+			return fmt.Errorf("failed to register collector: %w", err)
+		}
+	}
+	return nil
+}
+
 // RecordBlockIndexed records a block being indexed
 func (m *Metrics) RecordBlockIndexed(chainID string) {
 	m.BlocksIndexed.WithLabelValues(chainID).Inc()
@@ -3,6 +3,8 @@ package metrics
 import (
 	"testing"
 	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 func TestNew(t *testing.T) {
@@ -153,6 +155,21 @@ func TestMetrics_Handler(t *testing.T) {
 	}
 }
 
+func TestMetrics_Register(t *testing.T) {
+	m := New(nil)
+
+	gauge := prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "indexer_test_gauge",
+		Help: "Gauge registered by the test",
+	})
+	if err := m.Register(gauge); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	if err := m.Register(gauge); err == nil {
+		t.Error("Register() should fail for a collector that is already registered")
+	}
+}
+
 func TestGlobalMetrics(t *testing.T) {
 	t.Run("init global", func(t *testing.T) {
 		cfg := DefaultConfig()
@@ -13,16 +13,36 @@ type PebbleBatch struct {
 	db      *pebble.DB
 	batch   *pebble.Batch
 	encoder *Encoder
+	ranges  *RangeRepo
 	count   int
+
+	// Highest block number set per chain, applied to the latest height on commit
+	latestHeights map[string]uint64
+
+	// Block numbers set per chain, added to the indexed ranges on commit
+	blockNumbers map[string][]uint64
+
+	// Block numbers deleted per chain, removed from the indexed ranges and
+	// the latest height on commit
+	deletedNumbers map[string][]uint64
+
+	// Ranges marked as indexed per chain, added to the indexed ranges on
+	// commit whether or not they hold blocks
+	indexedRanges map[string][]*models.BlockRange
 }
 
 // NewBatch creates a new batch instance
-func NewBatch(db *pebble.DB, encoder *Encoder) *PebbleBatch {
+func NewBatch(db *pebble.DB, encoder *Encoder, ranges *RangeRepo) *PebbleBatch {
 	return &PebbleBatch{
-		db:      db,
-		batch:   db.NewBatch(),
-		encoder: encoder,
-		count:   0,
+		db:             db,
+		batch:          db.NewBatch(),
+		encoder:        encoder,
+		ranges:         ranges,
+		count:          0,
+		latestHeights:  make(map[string]uint64),
+		blockNumbers:   make(map[string][]uint64),
+		deletedNumbers: make(map[string][]uint64),
+		indexedRanges:  make(map[string][]*models.BlockRange),
 	}
 }
 
@@ -57,6 +77,12 @@ func (b *PebbleBatch) SetBlock(ctx context.Context, block *models.Block) error {
 	}
 	b.count++
 
+	// Track latest height, written on commit
+	if current, exists := b.latestHeights[block.ChainID]; !exists || block.Number > current {
+		b.latestHeights[block.ChainID] = block.Number
+	}
+	b.blockNumbers[block.ChainID] = append(b.blockNumbers[block.ChainID], block.Number)
+
 	return nil
 }
 
@@ -67,25 +93,6 @@ func (b *PebbleBatch) SetBlocks(ctx context.Context, blocks []*models.Block) err
 			return fmt.Errorf("failed to batch set block %d: %w", block.Number, err)
 		}
 	}
-
-	// Update latest heights for all chains
-	latestHeights := make(map[string]uint64)
-	for _, block := range blocks {
-		if currentLatest, exists := latestHeights[block.ChainID]; !exists || block.Number > currentLatest {
-			latestHeights[block.ChainID] = block.Number
-		}
-	}
-
-	// Add latest height updates to batch
-	for chainID, height := range latestHeights {
-		heightKey := LatestHeightKey(chainID)
-		heightData := b.encoder.EncodeUint64(height)
-		if err := b.batch.Set(heightKey, heightData, pebble.Sync); err != nil {
-			return fmt.Errorf("failed to batch set latest height: %w", err)
-		}
-		b.count++
-	}
-
 	return nil
 }
 
@@ -120,12 +127,14 @@ func (b *PebbleBatch) SetTransaction(ctx context.Context, tx *models.Transaction
 	}
 	b.count++
 
-	// Add from address index to batch
-	fromAddrKey := AddressTxKey(tx.ChainID, tx.From, tx.BlockNumber, tx.Index)
-	if err := b.batch.Set(fromAddrKey, txHashData, pebble.Sync); err != nil {
-		return fmt.Errorf("failed to batch set from address index: %w", err)
+	// Add from address index to batch (if the transaction has a sender)
+	if tx.From != "" {
+		fromAddrKey := AddressTxKey(tx.ChainID, tx.From, tx.BlockNumber, tx.Index)
+		if err := b.batch.Set(fromAddrKey, txHashData, pebble.Sync); err != nil {
+			return fmt.Errorf("failed to batch set from address index: %w", err)
+		}
+		b.count++
 	}
-	b.count++
 
 	// Add to address index to batch (if not contract creation)
 	if tx.To != "" {
@@ -136,6 +145,27 @@ func (b *PebbleBatch) SetTransaction(ctx context.Context, tx *models.Transaction
 		b.count++
 	}
 
+	// Add internal transaction and output address indexes to batch
+	n, err := setExtraAddressIndexes(b.batch, b.encoder, tx)
+	b.count += n
+	if err != nil {
+		return fmt.Errorf("failed to batch set extra address indexes: %w", err)
+	}
+
+	// Add logs and their indexes to batch
+	n, err = setLogs(b.batch, b.encoder, tx)
+	b.count += n
+	if err != nil {
+		return fmt.Errorf("failed to batch set logs: %w", err)
+	}
+
+	// Add token transfers and their indexes to batch
+	n, err = setTokenTransfers(b.batch, b.encoder, tx)
+	b.count += n
+	if err != nil {
+		return fmt.Errorf("failed to batch set token transfers: %w", err)
+	}
+
 	return nil
 }
 
@@ -149,12 +179,79 @@ func (b *PebbleBatch) SetTransactions(ctx context.Context, txs []*models.Transac
 	return nil
 }
 
+// SetIndexedRange marks the blocks from start to end of a chain as indexed
+// on commit, including heights that hold no block
+func (b *PebbleBatch) SetIndexedRange(ctx context.Context, chainID string, start, end uint64) error {
+	if start > end {
+		return fmt.Errorf("invalid block range: %d-%d", start, end)
+	}
+
+	b.indexedRanges[chainID] = append(b.indexedRanges[chainID], &models.BlockRange{Start: start, End: end})
+	return nil
+}
+
+// DeleteBlock adds the removal of a stored block and its hash index to the
+// batch. Operations apply in the order they were added, so a block deleted
+// and then set again at the same height ends up stored.
+func (b *PebbleBatch) DeleteBlock(ctx context.Context, block *models.Block) error {
+	if block == nil {
+		return fmt.Errorf("block cannot be nil")
+	}
+
+	before := b.batch.Count()
+
+	if err := b.batch.Delete(BlockKey(block.ChainID, block.Number), pebble.Sync); err != nil {
+		return fmt.Errorf("failed to batch delete block: %w", err)
+	}
+
+	if err := b.batch.Delete(BlockHashKey(block.ChainID, block.Hash), pebble.Sync); err != nil {
+		return fmt.Errorf("failed to batch delete block hash index: %w", err)
+	}
+
+	b.count += int(b.batch.Count() - before)
+	b.deletedNumbers[block.ChainID] = append(b.deletedNumbers[block.ChainID], block.Number)
+
+	return nil
+}
+
+// DeleteTransaction adds the removal of a stored transaction and all of its
+// index entries to the batch
+func (b *PebbleBatch) DeleteTransaction(ctx context.Context, tx *models.Transaction) error {
+	if tx == nil {
+		return fmt.Errorf("transaction cannot be nil")
+	}
+
+	before := b.batch.Count()
+	if err := deleteTransaction(b.batch, tx); err != nil {
+		return err
+	}
+	b.count += int(b.batch.Count() - before)
+
+	return nil
+}
+
 // Commit writes all batched operations atomically
 func (b *PebbleBatch) Commit() error {
 	if b.batch == nil {
 		return fmt.Errorf("batch is nil")
 	}
 
+	b.ranges.mu.Lock()
+	defer b.ranges.mu.Unlock()
+
+	// Move latest heights and indexed ranges in the same write so they
+	// never point at blocks that were not committed
+	if err := b.setLatestHeights(); err != nil {
+		return err
+	}
+	for chainID := range b.changedChains() {
+		added := append(toRanges(b.blockNumbers[chainID]), b.indexedRanges[chainID]...)
+		removed := toRanges(b.deletedNumbers[chainID])
+		if err := b.ranges.updateRanges(b.batch, chainID, added, removed); err != nil {
+			return fmt.Errorf("failed to update indexed ranges: %w", err)
+		}
+	}
+
 	if err := b.batch.Commit(pebble.Sync); err != nil {
 		return fmt.Errorf("failed to commit batch: %w", err)
 	}
@@ -162,16 +259,136 @@ func (b *PebbleBatch) Commit() error {
 	// Reset the batch after successful commit
 	b.batch = b.db.NewBatch()
 	b.count = 0
+	b.latestHeights = make(map[string]uint64)
+	b.blockNumbers = make(map[string][]uint64)
+	b.deletedNumbers = make(map[string][]uint64)
+	b.indexedRanges = make(map[string][]*models.BlockRange)
+
+	return nil
+}
+
+// changedChains returns the chains that had blocks set or deleted or
+// ranges marked as indexed
+func (b *PebbleBatch) changedChains() map[string]struct{} {
+	chains := make(map[string]struct{}, len(b.blockNumbers)+len(b.deletedNumbers))
+	for chainID := range b.blockNumbers {
+		chains[chainID] = struct{}{}
+	}
+	for chainID := range b.deletedNumbers {
+		chains[chainID] = struct{}{}
+	}
+	for chainID := range b.indexedRanges {
+		chains[chainID] = struct{}{}
+	}
+	return chains
+}
+
+// setLatestHeights adds a latest height update for every chain whose stored
+// height is below the highest block in the batch, or whose stored height
+// points at a block the batch deletes
+func (b *PebbleBatch) setLatestHeights() error {
+	for chainID := range b.changedChains() {
+		heightKey := LatestHeightKey(chainID)
+
+		current, found, err := b.storedLatestHeight(heightKey)
+		if err != nil {
+			return err
+		}
+
+		height, ok := b.latestHeights[chainID]
+		if len(b.deletedNumbers[chainID]) == 0 {
+			// Setting blocks only ever raises the stored height
+			if found && current >= height {
+				continue
+			}
+		} else {
+			// The tip may be deleted, so fall back to the highest block left
+			below, remaining, err := b.highestRemainingBlock(chainID)
+			if err != nil {
+				return err
+			}
+			if remaining && (!ok || below > height) {
+				height, ok = below, true
+			}
+		}
+
+		switch {
+		case !ok && found:
+			if err := b.batch.Delete(heightKey, pebble.Sync); err != nil {
+				return fmt.Errorf("failed to batch delete latest height: %w", err)
+			}
+			b.count++
+		case ok && (!found || current != height):
+			heightData := b.encoder.EncodeUint64(height)
+			if err := b.batch.Set(heightKey, heightData, pebble.Sync); err != nil {
+				return fmt.Errorf("failed to batch set latest height: %w", err)
+			}
+			b.count++
+		}
+	}
 
 	return nil
 }
 
+// storedLatestHeight reads the committed latest height at heightKey
+func (b *PebbleBatch) storedLatestHeight(heightKey []byte) (uint64, bool, error) {
+	value, closer, err := b.db.Get(heightKey)
+	if err == pebble.ErrNotFound {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to get latest height: %w", err)
+	}
+	defer closer.Close()
+
+	height, err := b.encoder.DecodeUint64(value)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to decode latest height: %w", err)
+	}
+	return height, true, nil
+}
+
+// highestRemainingBlock returns the highest committed block of a chain that
+// the batch does not delete
+func (b *PebbleBatch) highestRemainingBlock(chainID string) (uint64, bool, error) {
+	deleted := make(map[uint64]bool, len(b.deletedNumbers[chainID]))
+	for _, number := range b.deletedNumbers[chainID] {
+		deleted[number] = true
+	}
+
+	prefix := BlockRangePrefix(chainID)
+	iter, err := b.db.NewIter(&pebble.IterOptions{
+		LowerBound: prefix,
+		UpperBound: keyUpperBound(prefix),
+	})
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+
+	for valid := iter.Last(); valid; valid = iter.Prev() {
+		_, number, err := ParseBlockKey(iter.Key())
+		if err != nil {
+			return 0, false, err
+		}
+		if !deleted[number] {
+			return number, true, nil
+		}
+	}
+
+	return 0, false, iter.Error()
+}
+
 // Reset clears all operations in the batch without committing
 func (b *PebbleBatch) Reset() {
 	if b.batch != nil {
 		b.batch.Reset()
 	}
 	b.count = 0
+	b.latestHeights = make(map[string]uint64)
+	b.blockNumbers = make(map[string][]uint64)
+	b.deletedNumbers = make(map[string][]uint64)
+	b.indexedRanges = make(map[string][]*models.BlockRange)
 }
 
 // Count returns the number of operations in the batch
@@ -188,5 +405,9 @@ func (b *PebbleBatch) Close() error {
 		b.batch = nil
 	}
 	b.count = 0
+	b.latestHeights = make(map[string]uint64)
+	b.blockNumbers = make(map[string][]uint64)
+	b.deletedNumbers = make(map[string][]uint64)
+	b.indexedRanges = make(map[string][]*models.BlockRange)
 	return nil
 }
@@ -2,9 +2,11 @@ package pebble
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/repository"
 )
 
 func TestBatch_SetBlock(t *testing.T) {
@@ -411,6 +413,235 @@ func TestBatch_AtomicCommit(t *testing.T) {
 	})
 }
 
+func TestBatch_LatestHeight(t *testing.T) {
+	storage, tmpDir := setupTestDB(t)
+	defer cleanupTestDB(t, storage, tmpDir)
+
+	ctx := context.Background()
+
+	t.Run("commit raises latest height", func(t *testing.T) {
+		batch := storage.NewBatch()
+		defer batch.Close()
+
+		for i := 10; i <= 20; i++ {
+			block := models.NewBlock(models.ChainTypeEVM, "ethereum", uint64(i), "0xhash")
+			if err := batch.SetBlock(ctx, block); err != nil {
+				t.Fatalf("SetBlock() error = %v", err)
+			}
+		}
+
+		// Latest height is not visible before commit
+		if _, err := storage.GetLatestHeight(ctx, "ethereum"); err != repository.ErrBlockNotFound {
+			t.Errorf("GetLatestHeight() before commit error = %v, want %v", err, repository.ErrBlockNotFound)
+		}
+
+		if err := batch.Commit(); err != nil {
+			t.Fatalf("Commit() error = %v", err)
+		}
+
+		height, err := storage.GetLatestHeight(ctx, "ethereum")
+		if err != nil {
+			t.Fatalf("GetLatestHeight() error = %v", err)
+		}
+		if height != 20 {
+			t.Errorf("GetLatestHeight() = %v, want 20", height)
+		}
+	})
+
+	t.Run("commit of lower blocks keeps latest height", func(t *testing.T) {
+		batch := storage.NewBatch()
+		defer batch.Close()
+
+		block := models.NewBlock(models.ChainTypeEVM, "ethereum", 5, "0xhash5")
+		if err := batch.SetBlock(ctx, block); err != nil {
+			t.Fatalf("SetBlock() error = %v", err)
+		}
+		if err := batch.Commit(); err != nil {
+			t.Fatalf("Commit() error = %v", err)
+		}
+
+		height, err := storage.GetLatestHeight(ctx, "ethereum")
+		if err != nil {
+			t.Fatalf("GetLatestHeight() error = %v", err)
+		}
+		if height != 20 {
+			t.Errorf("GetLatestHeight() = %v, want 20", height)
+		}
+	})
+
+	t.Run("closed batch leaves latest height untouched", func(t *testing.T) {
+		batch := storage.NewBatch()
+
+		block := models.NewBlock(models.ChainTypeEVM, "ethereum", 30, "0xhash30")
+		if err := batch.SetBlock(ctx, block); err != nil {
+			t.Fatalf("SetBlock() error = %v", err)
+		}
+		if err := batch.Close(); err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+
+		height, err := storage.GetLatestHeight(ctx, "ethereum")
+		if err != nil {
+			t.Fatalf("GetLatestHeight() error = %v", err)
+		}
+		if height != 20 {
+			t.Errorf("GetLatestHeight() = %v, want 20", height)
+		}
+	})
+}
+
+func TestBatch_Delete(t *testing.T) {
+	storage, tmpDir := setupTestDB(t)
+	defer cleanupTestDB(t, storage, tmpDir)
+
+	ctx := context.Background()
+
+	newBlock := func(number uint64, hash string) *models.Block {
+		block := models.NewBlock(models.ChainTypeEVM, "ethereum", number, hash)
+		tx := models.NewTransaction(models.ChainTypeEVM, "ethereum", hash+"-tx")
+		tx.BlockNumber = number
+		tx.BlockHash = hash
+		tx.From = "0xfrom"
+		tx.To = "0xto"
+		block.Transactions = []*models.Transaction{tx}
+		block.TxCount = 1
+		return block
+	}
+
+	blocks := make(map[uint64]*models.Block)
+	batch := storage.NewBatch()
+	for n := uint64(1); n <= 10; n++ {
+		blocks[n] = newBlock(n, fmt.Sprintf("0xhash%d", n))
+		if err := batch.SetBlock(ctx, blocks[n]); err != nil {
+			t.Fatalf("SetBlock() error = %v", err)
+		}
+		if err := batch.SetTransactions(ctx, blocks[n].Transactions); err != nil {
+			t.Fatalf("SetTransactions() error = %v", err)
+		}
+	}
+	if err := batch.Commit(); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+	batch.Close()
+
+	assertLatestHeight := func(t *testing.T, want uint64) {
+		t.Helper()
+		height, err := storage.GetLatestHeight(ctx, "ethereum")
+		if err != nil {
+			t.Fatalf("GetLatestHeight() error = %v", err)
+		}
+		if height != want {
+			t.Errorf("GetLatestHeight() = %d, want %d", height, want)
+		}
+	}
+
+	t.Run("deleting the tip lowers the latest height on commit", func(t *testing.T) {
+		batch := storage.NewBatch()
+		defer batch.Close()
+
+		for n := uint64(10); n >= 8; n-- {
+			if err := batch.DeleteTransaction(ctx, blocks[n].Transactions[0]); err != nil {
+				t.Fatalf("DeleteTransaction() error = %v", err)
+			}
+			if err := batch.DeleteBlock(ctx, blocks[n]); err != nil {
+				t.Fatalf("DeleteBlock() error = %v", err)
+			}
+		}
+
+		// Nothing is removed before commit
+		if _, err := storage.GetBlock(ctx, "ethereum", 10); err != nil {
+			t.Errorf("GetBlock(10) before commit error = %v", err)
+		}
+		assertLatestHeight(t, 10)
+
+		if err := batch.Commit(); err != nil {
+			t.Fatalf("Commit() error = %v", err)
+		}
+
+		for n := uint64(8); n <= 10; n++ {
+			if _, err := storage.GetBlock(ctx, "ethereum", n); err != repository.ErrBlockNotFound {
+				t.Errorf("GetBlock(%d) error = %v, want %v", n, err, repository.ErrBlockNotFound)
+			}
+			if _, err := storage.GetBlockByHash(ctx, "ethereum", blocks[n].Hash); err != repository.ErrBlockNotFound {
+				t.Errorf("GetBlockByHash(%s) error = %v, want %v", blocks[n].Hash, err, repository.ErrBlockNotFound)
+			}
+			if _, err := storage.GetTransaction(ctx, "ethereum", blocks[n].Transactions[0].Hash); err != repository.ErrTransactionNotFound {
+				t.Errorf("GetTransaction(%d) error = %v, want %v", n, err, repository.ErrTransactionNotFound)
+			}
+		}
+
+		txs, err := storage.GetTransactionsByAddress(ctx, "ethereum", "0xfrom", &models.PaginationOptions{Limit: 100})
+		if err != nil {
+			t.Fatalf("GetTransactionsByAddress() error = %v", err)
+		}
+		if len(txs) != 7 {
+			t.Errorf("GetTransactionsByAddress() returned %d transactions, want 7", len(txs))
+		}
+
+		assertLatestHeight(t, 7)
+		assertRanges(t, storage, "ethereum", []models.BlockRange{{Start: 1, End: 7}})
+	})
+
+	t.Run("block deleted and set again stays stored", func(t *testing.T) {
+		batch := storage.NewBatch()
+		defer batch.Close()
+
+		replacement := newBlock(7, "0xreplaced7")
+		if err := batch.DeleteTransaction(ctx, blocks[7].Transactions[0]); err != nil {
+			t.Fatalf("DeleteTransaction() error = %v", err)
+		}
+		if err := batch.DeleteBlock(ctx, blocks[7]); err != nil {
+			t.Fatalf("DeleteBlock() error = %v", err)
+		}
+		if err := batch.SetBlock(ctx, replacement); err != nil {
+			t.Fatalf("SetBlock() error = %v", err)
+		}
+		if err := batch.SetTransactions(ctx, replacement.Transactions); err != nil {
+			t.Fatalf("SetTransactions() error = %v", err)
+		}
+		if err := batch.Commit(); err != nil {
+			t.Fatalf("Commit() error = %v", err)
+		}
+
+		stored, err := storage.GetBlock(ctx, "ethereum", 7)
+		if err != nil {
+			t.Fatalf("GetBlock(7) error = %v", err)
+		}
+		if stored.Hash != replacement.Hash {
+			t.Errorf("GetBlock(7).Hash = %s, want %s", stored.Hash, replacement.Hash)
+		}
+		if _, err := storage.GetBlockByHash(ctx, "ethereum", blocks[7].Hash); err != repository.ErrBlockNotFound {
+			t.Errorf("GetBlockByHash(old) error = %v, want %v", err, repository.ErrBlockNotFound)
+		}
+
+		assertLatestHeight(t, 7)
+		assertRanges(t, storage, "ethereum", []models.BlockRange{{Start: 1, End: 7}})
+	})
+
+	t.Run("deleting every block removes the latest height", func(t *testing.T) {
+		batch := storage.NewBatch()
+		defer batch.Close()
+
+		for n := uint64(1); n <= 7; n++ {
+			block, err := storage.GetBlock(ctx, "ethereum", n)
+			if err != nil {
+				t.Fatalf("GetBlock(%d) error = %v", n, err)
+			}
+			if err := batch.DeleteBlock(ctx, block); err != nil {
+				t.Fatalf("DeleteBlock() error = %v", err)
+			}
+		}
+		if err := batch.Commit(); err != nil {
+			t.Fatalf("Commit() error = %v", err)
+		}
+
+		if _, err := storage.GetLatestHeight(ctx, "ethereum"); err != repository.ErrBlockNotFound {
+			t.Errorf("GetLatestHeight() error = %v, want %v", err, repository.ErrBlockNotFound)
+		}
+		assertRanges(t, storage, "ethereum", []models.BlockRange{})
+	})
+}
+
 // Benchmark tests
 func BenchmarkBatch_SetBlock(b *testing.B) {
 	storage, tmpDir := setupTestDB(&testing.T{})
@@ -451,3 +682,88 @@ func BenchmarkBatch_Commit(b *testing.B) {
 		batch.Close()
 	}
 }
+
+func TestBatch_SetTransactionLogs(t *testing.T) {
+	storage, tmpDir := setupTestDB(t)
+	defer cleanupTestDB(t, storage, tmpDir)
+
+	ctx := context.Background()
+	batch := storage.NewBatch()
+	defer batch.Close()
+
+	tx := newLogTransaction(10, 0, &models.Log{Index: 0, Address: testToken, Topics: []string{testTransferSig}})
+	if err := batch.SetTransaction(ctx, tx); err != nil {
+		t.Fatalf("SetTransaction() error = %v", err)
+	}
+
+	// Transaction, block and from indexes plus the log and its two indexes
+	if got := batch.Count(); got != 6 {
+		t.Errorf("Count() = %d, want 6", got)
+	}
+
+	if err := batch.Commit(); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+
+	logs, err := storage.QueryLogs(ctx, &models.LogFilter{ChainID: "ethereum", Topics: [][]string{{testTransferSig}}}, nil)
+	if err != nil {
+		t.Fatalf("QueryLogs() error = %v", err)
+	}
+	if len(logs) != 1 || logs[0].TxHash != tx.Hash {
+		t.Errorf("QueryLogs() = %v, want the batched log", logs)
+	}
+}
+
+func TestBatch_SetIndexedRange(t *testing.T) {
+	storage, tmpDir := setupTestDB(t)
+	defer cleanupTestDB(t, storage, tmpDir)
+
+	ctx := context.Background()
+
+	batch := storage.NewBatch()
+	defer batch.Close()
+
+	// Blocks 3 and 4 were skipped, block 7 is the last of the range
+	for _, number := range []uint64{1, 2, 5, 6, 7} {
+		block := models.NewBlock(models.ChainTypeSolana, "solana", number, fmt.Sprintf("hash%d", number))
+		if err := batch.SetBlock(ctx, block); err != nil {
+			t.Fatalf("SetBlock() error = %v", err)
+		}
+	}
+	if err := batch.SetIndexedRange(ctx, "solana", 1, 8); err != nil {
+		t.Fatalf("SetIndexedRange() error = %v", err)
+	}
+	if err := batch.SetIndexedRange(ctx, "solana", 9, 8); err == nil {
+		t.Error("SetIndexedRange() with start above end error = nil, want error")
+	}
+
+	// Nothing is marked before commit
+	ranges, err := storage.GetIndexedRanges(ctx, "solana")
+	if err != nil {
+		t.Fatalf("GetIndexedRanges() error = %v", err)
+	}
+	if len(ranges) != 0 {
+		t.Errorf("GetIndexedRanges() before commit = %v, want none", ranges)
+	}
+
+	if err := batch.Commit(); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+
+	ranges, err = storage.GetIndexedRanges(ctx, "solana")
+	if err != nil {
+		t.Fatalf("GetIndexedRanges() error = %v", err)
+	}
+	if len(ranges) != 1 || ranges[0].Start != 1 || ranges[0].End != 8 {
+		t.Errorf("GetIndexedRanges() = %v, want [1-8]", ranges)
+	}
+
+	// Empty heights do not move the latest height
+	height, err := storage.GetLatestHeight(ctx, "solana")
+	if err != nil {
+		t.Fatalf("GetLatestHeight() error = %v", err)
+	}
+	if height != 7 {
+		t.Errorf("GetLatestHeight() = %d, want 7", height)
+	}
+}
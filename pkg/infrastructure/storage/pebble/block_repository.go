@@ -13,13 +13,16 @@ import (
 type BlockRepo struct {
 	db      *pebble.DB
 	encoder *Encoder
+	ranges  *RangeRepo
 }
 
-// NewBlockRepo creates a new block repository
-func NewBlockRepo(db *pebble.DB, encoder *Encoder) *BlockRepo {
+// NewBlockRepo creates a new block repository that keeps ranges up to date
+// as blocks are saved and deleted
+func NewBlockRepo(db *pebble.DB, encoder *Encoder, ranges *RangeRepo) *BlockRepo {
 	return &BlockRepo{
 		db:      db,
 		encoder: encoder,
+		ranges:  ranges,
 	}
 }
 
@@ -69,17 +72,17 @@ func (r *BlockRepo) GetBlockByHash(ctx context.Context, chainID string, hash str
 	return r.GetBlock(ctx, chainID, blockNumber)
 }
 
-// GetBlocks retrieves blocks in a range
+// GetBlocks retrieves blocks in a range, ordered by block number
 func (r *BlockRepo) GetBlocks(ctx context.Context, chainID string, start, end uint64) ([]*models.Block, error) {
 	if start > end {
 		return nil, fmt.Errorf("start block number must be less than or equal to end")
 	}
 
-	blocks := make([]*models.Block, 0, end-start+1)
+	blocks := make([]*models.Block, 0)
 
 	// Create iterator for the range
 	startKey := BlockKey(chainID, start)
-	endKey := BlockKey(chainID, end+1) // Exclusive upper bound
+	endKey := keyUpperBound(BlockKey(chainID, end)) // Exclusive upper bound
 
 	iter, err := r.db.NewIter(&pebble.IterOptions{
 		LowerBound: startKey,
@@ -106,6 +109,62 @@ func (r *BlockRepo) GetBlocks(ctx context.Context, chainID string, start, end ui
 	return blocks, nil
 }
 
+// GetRecentBlocks retrieves up to limit of the highest stored blocks,
+// newest first, by iterating the block keys in reverse
+func (r *BlockRepo) GetRecentBlocks(ctx context.Context, chainID string, limit int) ([]*models.Block, error) {
+	if limit <= 0 {
+		return nil, fmt.Errorf("limit must be positive")
+	}
+
+	prefix := BlockRangePrefix(chainID)
+
+	iter, err := r.db.NewIter(&pebble.IterOptions{
+		LowerBound: prefix,
+		UpperBound: keyUpperBound(prefix),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+
+	blocks := make([]*models.Block, 0, limit)
+	for iter.Last(); iter.Valid() && len(blocks) < limit; iter.Prev() {
+		block, err := r.encoder.DecodeBlock(iter.Value())
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode block: %w", err)
+		}
+		blocks = append(blocks, block)
+	}
+
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("iterator error: %w", err)
+	}
+
+	return blocks, nil
+}
+
+// highestBlockBelow returns the highest stored block number below number
+func (r *BlockRepo) highestBlockBelow(chainID string, number uint64) (uint64, bool, error) {
+	iter, err := r.db.NewIter(&pebble.IterOptions{
+		LowerBound: BlockRangePrefix(chainID),
+		UpperBound: BlockKey(chainID, number),
+	})
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+
+	if !iter.Last() {
+		return 0, false, iter.Error()
+	}
+
+	_, below, err := ParseBlockKey(iter.Key())
+	if err != nil {
+		return 0, false, err
+	}
+	return below, true, nil
+}
+
 // GetLatestBlock retrieves the latest block for a chain
 func (r *BlockRepo) GetLatestBlock(ctx context.Context, chainID string) (*models.Block, error) {
 	height, err := r.GetLatestHeight(ctx, chainID)
@@ -247,19 +306,25 @@ func (r *BlockRepo) SaveBlock(ctx context.Context, block *models.Block) error {
 		return fmt.Errorf("failed to encode block: %w", err)
 	}
 
+	batch := r.db.NewBatch()
+	defer batch.Close()
+
 	// Save the block by number
 	blockKey := BlockKey(block.ChainID, block.Number)
-	if err := r.db.Set(blockKey, data, pebble.Sync); err != nil {
+	if err := batch.Set(blockKey, data, pebble.Sync); err != nil {
 		return fmt.Errorf("failed to save block: %w", err)
 	}
 
 	// Save the hash index
 	hashKey := BlockHashKey(block.ChainID, block.Hash)
 	numberData := r.encoder.EncodeUint64(block.Number)
-	if err := r.db.Set(hashKey, numberData, pebble.Sync); err != nil {
+	if err := batch.Set(hashKey, numberData, pebble.Sync); err != nil {
 		return fmt.Errorf("failed to save block hash index: %w", err)
 	}
 
+	r.ranges.mu.Lock()
+	defer r.ranges.mu.Unlock()
+
 	// Update latest height if this is the latest block
 	currentHeight, err := r.GetLatestHeight(ctx, block.ChainID)
 	if err != nil && err != repository.ErrBlockNotFound {
@@ -269,11 +334,20 @@ func (r *BlockRepo) SaveBlock(ctx context.Context, block *models.Block) error {
 	if err == repository.ErrBlockNotFound || block.Number > currentHeight {
 		heightKey := LatestHeightKey(block.ChainID)
 		heightData := r.encoder.EncodeUint64(block.Number)
-		if err := r.db.Set(heightKey, heightData, pebble.Sync); err != nil {
+		if err := batch.Set(heightKey, heightData, pebble.Sync); err != nil {
 			return fmt.Errorf("failed to update latest height: %w", err)
 		}
 	}
 
+	// Record the block in the chain's indexed ranges
+	if err := r.ranges.addBlocks(batch, block.ChainID, []uint64{block.Number}); err != nil {
+		return fmt.Errorf("failed to update indexed ranges: %w", err)
+	}
+
+	if err := batch.Commit(pebble.Sync); err != nil {
+		return fmt.Errorf("failed to commit batch: %w", err)
+	}
+
 	return nil
 }
 
@@ -287,7 +361,8 @@ func (r *BlockRepo) SaveBlocks(ctx context.Context, blocks []*models.Block) erro
 	batch := r.db.NewBatch()
 	defer batch.Close()
 
-	latestHeights := make(map[string]uint64) // Track latest height per chain
+	latestHeights := make(map[string]uint64)  // Track latest height per chain
+	blockNumbers := make(map[string][]uint64) // Track saved blocks per chain
 
 	for _, block := range blocks {
 		if block == nil {
@@ -321,6 +396,7 @@ func (r *BlockRepo) SaveBlocks(ctx context.Context, blocks []*models.Block) erro
 		if currentLatest, exists := latestHeights[block.ChainID]; !exists || block.Number > currentLatest {
 			latestHeights[block.ChainID] = block.Number
 		}
+		blockNumbers[block.ChainID] = append(blockNumbers[block.ChainID], block.Number)
 	}
 
 	// Update latest heights for all chains
@@ -332,6 +408,16 @@ func (r *BlockRepo) SaveBlocks(ctx context.Context, blocks []*models.Block) erro
 		}
 	}
 
+	r.ranges.mu.Lock()
+	defer r.ranges.mu.Unlock()
+
+	// Record the blocks in the indexed ranges of every chain
+	for chainID, numbers := range blockNumbers {
+		if err := r.ranges.addBlocks(batch, chainID, numbers); err != nil {
+			return fmt.Errorf("failed to update indexed ranges: %w", err)
+		}
+	}
+
 	// Commit the batch
 	if err := batch.Commit(pebble.Sync); err != nil {
 		return fmt.Errorf("failed to commit batch: %w", err)
@@ -354,18 +440,56 @@ func (r *BlockRepo) DeleteBlock(ctx context.Context, chainID string, number uint
 		return err
 	}
 
+	batch := r.db.NewBatch()
+	defer batch.Close()
+
 	// Delete the block
 	blockKey := BlockKey(chainID, number)
-	if err := r.db.Delete(blockKey, pebble.Sync); err != nil {
+	if err := batch.Delete(blockKey, pebble.Sync); err != nil {
 		return fmt.Errorf("failed to delete block: %w", err)
 	}
 
 	// Delete the hash index
 	hashKey := BlockHashKey(chainID, block.Hash)
-	if err := r.db.Delete(hashKey, pebble.Sync); err != nil {
+	if err := batch.Delete(hashKey, pebble.Sync); err != nil {
 		return fmt.Errorf("failed to delete block hash index: %w", err)
 	}
 
+	r.ranges.mu.Lock()
+	defer r.ranges.mu.Unlock()
+
+	// Remove the block from the chain's indexed ranges
+	if err := r.ranges.removeBlock(batch, chainID, number); err != nil {
+		return fmt.Errorf("failed to update indexed ranges: %w", err)
+	}
+
+	// Move the latest height down to the next stored block if the tip was
+	// removed (e.g. reorg rollback)
+	currentHeight, err := r.GetLatestHeight(ctx, chainID)
+	if err != nil && err != repository.ErrBlockNotFound {
+		return fmt.Errorf("failed to get current height: %w", err)
+	}
+
+	if err == nil && currentHeight == number {
+		below, found, err := r.highestBlockBelow(chainID, number)
+		if err != nil {
+			return fmt.Errorf("failed to find previous block: %w", err)
+		}
+
+		heightKey := LatestHeightKey(chainID)
+		if !found {
+			if err := batch.Delete(heightKey, pebble.Sync); err != nil {
+				return fmt.Errorf("failed to delete latest height: %w", err)
+			}
+		} else if err := batch.Set(heightKey, r.encoder.EncodeUint64(below), pebble.Sync); err != nil {
+			return fmt.Errorf("failed to update latest height: %w", err)
+		}
+	}
+
+	if err := batch.Commit(pebble.Sync); err != nil {
+		return fmt.Errorf("failed to commit batch: %w", err)
+	}
+
 	return nil
 }
 
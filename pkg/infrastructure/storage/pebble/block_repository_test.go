@@ -2,6 +2,7 @@ package pebble
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
@@ -371,6 +372,145 @@ func TestBlockRepo_DeleteBlock(t *testing.T) {
 	})
 }
 
+func TestBlockRepo_DeleteBlock_RewindsLatestHeight(t *testing.T) {
+	storage, tmpDir := setupTestDB(t)
+	defer cleanupTestDB(t, storage, tmpDir)
+
+	ctx := context.Background()
+
+	for i := uint64(10); i <= 12; i++ {
+		block := models.NewBlock(models.ChainTypeEVM, "ethereum", i, fmt.Sprintf("0xhash%d", i))
+		if err := storage.SaveBlock(ctx, block); err != nil {
+			t.Fatalf("setup failed: %v", err)
+		}
+	}
+
+	// Deleting a block below the tip keeps the latest height
+	if err := storage.DeleteBlock(ctx, "ethereum", 10); err != nil {
+		t.Fatalf("DeleteBlock() error = %v", err)
+	}
+	height, err := storage.GetLatestHeight(ctx, "ethereum")
+	if err != nil {
+		t.Fatalf("GetLatestHeight() error = %v", err)
+	}
+	if height != 12 {
+		t.Errorf("GetLatestHeight() = %d, want 12", height)
+	}
+
+	// Deleting the tip moves the latest height down
+	if err := storage.DeleteBlock(ctx, "ethereum", 12); err != nil {
+		t.Fatalf("DeleteBlock() error = %v", err)
+	}
+	height, err = storage.GetLatestHeight(ctx, "ethereum")
+	if err != nil {
+		t.Fatalf("GetLatestHeight() error = %v", err)
+	}
+	if height != 11 {
+		t.Errorf("GetLatestHeight() = %d, want 11", height)
+	}
+
+	// Deleting the last block removes the latest height
+	if err := storage.DeleteBlock(ctx, "ethereum", 11); err != nil {
+		t.Fatalf("DeleteBlock() error = %v", err)
+	}
+	if _, err := storage.GetLatestHeight(ctx, "ethereum"); err != repository.ErrBlockNotFound {
+		t.Errorf("GetLatestHeight() error = %v, want %v", err, repository.ErrBlockNotFound)
+	}
+}
+
+func TestBlockRepo_GetBlocks_NumericOrder(t *testing.T) {
+	storage, tmpDir := setupTestDB(t)
+	defer cleanupTestDB(t, storage, tmpDir)
+
+	ctx := context.Background()
+
+	// Numbers whose decimal strings do not sort numerically
+	for _, n := range []uint64{9, 10, 99, 100, 1000, 2} {
+		block := models.NewBlock(models.ChainTypeEVM, "ethereum", n, fmt.Sprintf("0xhash%d", n))
+		if err := storage.SaveBlock(ctx, block); err != nil {
+			t.Fatalf("setup failed: %v", err)
+		}
+	}
+
+	// A chain whose ID extends another must not leak into its range
+	other := models.NewBlock(models.ChainTypeEVM, "ethereum2", 50, "0xother")
+	if err := storage.SaveBlock(ctx, other); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+
+	blocks, err := storage.GetBlocks(ctx, "ethereum", 5, 100)
+	if err != nil {
+		t.Fatalf("GetBlocks() error = %v", err)
+	}
+
+	want := []uint64{9, 10, 99, 100}
+	if len(blocks) != len(want) {
+		t.Fatalf("GetBlocks() returned %d blocks, want %d", len(blocks), len(want))
+	}
+	for i, block := range blocks {
+		if block.Number != want[i] {
+			t.Errorf("GetBlocks()[%d] = %d, want %d", i, block.Number, want[i])
+		}
+	}
+}
+
+func TestBlockRepo_GetRecentBlocks(t *testing.T) {
+	storage, tmpDir := setupTestDB(t)
+	defer cleanupTestDB(t, storage, tmpDir)
+
+	ctx := context.Background()
+
+	for i := uint64(1); i <= 20; i++ {
+		block := models.NewBlock(models.ChainTypeEVM, "ethereum", i, fmt.Sprintf("0xhash%d", i))
+		if err := storage.SaveBlock(ctx, block); err != nil {
+			t.Fatalf("setup failed: %v", err)
+		}
+	}
+
+	t.Run("newest first", func(t *testing.T) {
+		blocks, err := storage.GetRecentBlocks(ctx, "ethereum", 3)
+		if err != nil {
+			t.Fatalf("GetRecentBlocks() error = %v", err)
+		}
+
+		want := []uint64{20, 19, 18}
+		if len(blocks) != len(want) {
+			t.Fatalf("GetRecentBlocks() returned %d blocks, want %d", len(blocks), len(want))
+		}
+		for i, block := range blocks {
+			if block.Number != want[i] {
+				t.Errorf("GetRecentBlocks()[%d] = %d, want %d", i, block.Number, want[i])
+			}
+		}
+	})
+
+	t.Run("limit above stored count", func(t *testing.T) {
+		blocks, err := storage.GetRecentBlocks(ctx, "ethereum", 100)
+		if err != nil {
+			t.Fatalf("GetRecentBlocks() error = %v", err)
+		}
+		if len(blocks) != 20 {
+			t.Errorf("GetRecentBlocks() returned %d blocks, want 20", len(blocks))
+		}
+	})
+
+	t.Run("unknown chain", func(t *testing.T) {
+		blocks, err := storage.GetRecentBlocks(ctx, "unknown", 5)
+		if err != nil {
+			t.Fatalf("GetRecentBlocks() error = %v", err)
+		}
+		if len(blocks) != 0 {
+			t.Errorf("GetRecentBlocks() returned %d blocks, want 0", len(blocks))
+		}
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		if _, err := storage.GetRecentBlocks(ctx, "ethereum", 0); err == nil {
+			t.Error("GetRecentBlocks() with zero limit should error")
+		}
+	})
+}
+
 func TestBlockRepo_QueryBlocks(t *testing.T) {
 	storage, tmpDir := setupTestDB(t)
 	defer cleanupTestDB(t, storage, tmpDir)
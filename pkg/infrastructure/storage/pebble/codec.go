@@ -0,0 +1,239 @@
+package pebble
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Codec names accepted in configuration
+const (
+	CodecNameJSON = "json"
+	CodecNameCBOR = "cbor"
+)
+
+// Compression names accepted in configuration
+const (
+	CompressionNone = "none"
+	CompressionZstd = "zstd"
+)
+
+// Default record format for blocks and transactions
+const (
+	DefaultCodec       = CodecNameCBOR
+	DefaultCompression = CompressionZstd
+)
+
+// Record format byte written in front of encoded blocks and transactions.
+// The low bits identify the codec and the high bit marks zstd compression.
+// Records written before the format byte was introduced are plain JSON
+// objects and are recognized by their leading '{'.
+const (
+	formatCodecMask byte = 0x7f
+	formatZstd      byte = 0x80
+
+	formatLegacyJSON byte = 0x00 // No format byte, plain JSON
+	formatCodecJSON  byte = 0x01
+	formatCodecCBOR  byte = 0x02
+
+	legacyJSONPrefix byte = '{'
+)
+
+// Codec serializes models for storage
+type Codec interface {
+	// Name returns the configuration name of the codec
+	Name() string
+
+	// Marshal encodes v
+	Marshal(v interface{}) ([]byte, error)
+
+	// Unmarshal decodes data into v
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// jsonCodec encodes records as JSON
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string { return CodecNameJSON }
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) { return json.Marshal(v) }
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error { return json.Unmarshal(data, v) }
+
+// cborCodec encodes records as CBOR using the models' json field names
+type cborCodec struct {
+	enc cbor.EncMode
+	dec cbor.DecMode
+}
+
+func newCBORCodec() (*cborCodec, error) {
+	enc, err := cbor.EncOptions{
+		Time: cbor.TimeRFC3339Nano,
+	}.EncMode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cbor encoder: %w", err)
+	}
+
+	// Decode untyped maps the same way encoding/json does so metadata keeps
+	// its map[string]interface{} shape
+	dec, err := cbor.DecOptions{
+		DefaultMapType: reflect.TypeOf(map[string]interface{}(nil)),
+	}.DecMode()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cbor decoder: %w", err)
+	}
+
+	return &cborCodec{enc: enc, dec: dec}, nil
+}
+
+func (c *cborCodec) Name() string { return CodecNameCBOR }
+
+func (c *cborCodec) Marshal(v interface{}) ([]byte, error) { return c.enc.Marshal(v) }
+
+func (c *cborCodec) Unmarshal(data []byte, v interface{}) error { return c.dec.Unmarshal(data, v) }
+
+var (
+	codecsOnce sync.Once
+	codecsErr  error
+	codecs     map[byte]Codec
+
+	zstdEncoder *zstd.Encoder
+	zstdDecoder *zstd.Decoder
+)
+
+// initCodecs creates the shared codecs and zstd coders once. EncodeAll and
+// DecodeAll are safe for concurrent use.
+func initCodecs() error {
+	codecsOnce.Do(func() {
+		cborC, err := newCBORCodec()
+		if err != nil {
+			codecsErr = err
+			return
+		}
+
+		codecs = map[byte]Codec{
+			formatCodecJSON: jsonCodec{},
+			formatCodecCBOR: cborC,
+		}
+
+		zstdEncoder, err = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault))
+		if err != nil {
+			codecsErr = fmt.Errorf("failed to create zstd encoder: %w", err)
+			return
+		}
+
+		zstdDecoder, err = zstd.NewReader(nil)
+		if err != nil {
+			codecsErr = fmt.Errorf("failed to create zstd decoder: %w", err)
+		}
+	})
+	return codecsErr
+}
+
+// codecFormat returns the format byte for a codec name
+func codecFormat(name string) (byte, error) {
+	switch name {
+	case "", DefaultCodec:
+		return formatCodecCBOR, nil
+	case CodecNameJSON:
+		return formatCodecJSON, nil
+	default:
+		return 0, fmt.Errorf("unsupported codec: %s", name)
+	}
+}
+
+// compressionFormat returns the format flag for a compression name
+func compressionFormat(name string) (byte, error) {
+	switch name {
+	case "", DefaultCompression:
+		return formatZstd, nil
+	case CompressionNone:
+		return 0, nil
+	default:
+		return 0, fmt.Errorf("unsupported compression: %s", name)
+	}
+}
+
+// marshalRecord encodes v with the given format byte
+func marshalRecord(format byte, v interface{}) ([]byte, error) {
+	if err := initCodecs(); err != nil {
+		return nil, err
+	}
+
+	codec, ok := codecs[format&formatCodecMask]
+	if !ok {
+		return nil, fmt.Errorf("unknown record codec %#x", format&formatCodecMask)
+	}
+
+	payload, err := codec.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]byte, 1, len(payload)+1)
+	data[0] = format
+	if format&formatZstd != 0 {
+		return zstdEncoder.EncodeAll(payload, data), nil
+	}
+	return append(data, payload...), nil
+}
+
+// unmarshalRecord decodes a record written in any supported format
+func unmarshalRecord(data []byte, v interface{}) error {
+	if data[0] == legacyJSONPrefix {
+		return json.Unmarshal(data, v)
+	}
+
+	if err := initCodecs(); err != nil {
+		return err
+	}
+
+	format := data[0]
+	codec, ok := codecs[format&formatCodecMask]
+	if !ok {
+		return fmt.Errorf("unknown record format %#x", format)
+	}
+
+	payload := data[1:]
+	if format&formatZstd != 0 {
+		var err error
+		payload, err = zstdDecoder.DecodeAll(payload, nil)
+		if err != nil {
+			return fmt.Errorf("failed to decompress record: %w", err)
+		}
+	}
+
+	return codec.Unmarshal(payload, v)
+}
+
+// recordFormat returns the format byte of an encoded record, or
+// formatLegacyJSON for records written without one
+func recordFormat(data []byte) byte {
+	if len(data) == 0 || data[0] == legacyJSONPrefix {
+		return formatLegacyJSON
+	}
+	return data[0]
+}
+
+// formatName returns a readable name for a format byte, e.g. "cbor+zstd"
+func formatName(format byte) string {
+	if format == formatLegacyJSON {
+		return "json (legacy)"
+	}
+
+	name := fmt.Sprintf("unknown(%#x)", format&formatCodecMask)
+	if err := initCodecs(); err == nil {
+		if codec, ok := codecs[format&formatCodecMask]; ok {
+			name = codec.Name()
+		}
+	}
+
+	if format&formatZstd != 0 {
+		name += "+" + CompressionZstd
+	}
+	return name
+}
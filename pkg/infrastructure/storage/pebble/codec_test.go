@@ -0,0 +1,152 @@
+package pebble
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+)
+
+func newTestTransaction() *models.Transaction {
+	tx := models.NewTransaction(models.ChainTypeEVM, "ethereum", "0x"+strings.Repeat("ab", 32))
+	tx.BlockNumber = 12345
+	tx.From = "0x" + strings.Repeat("01", 20)
+	tx.To = "0x" + strings.Repeat("02", 20)
+	tx.Value = "1000000000000000000"
+	tx.Input = bytes.Repeat([]byte{0xa9, 0x05, 0x9c, 0xbb, 0x00, 0x00}, 20)
+	tx.Logs = []*models.Log{{
+		Index:   3,
+		Address: "0x" + strings.Repeat("03", 20),
+		Topics:  []string{"0x" + strings.Repeat("dd", 32)},
+		Data:    []byte{0x01, 0x02},
+	}}
+	tx.Metadata["effective_gas_price"] = "0x" + strings.Repeat("0", 20)
+	tx.Metadata["nested"] = map[string]interface{}{"key": "value"}
+	return tx
+}
+
+func TestNewEncoderWithFormat(t *testing.T) {
+	tests := []struct {
+		name        string
+		codec       string
+		compression string
+		wantFormat  string
+		wantErr     bool
+	}{
+		{name: "defaults", wantFormat: "cbor+zstd"},
+		{name: "cbor uncompressed", codec: CodecNameCBOR, compression: CompressionNone, wantFormat: "cbor"},
+		{name: "json compressed", codec: CodecNameJSON, compression: CompressionZstd, wantFormat: "json+zstd"},
+		{name: "json uncompressed", codec: CodecNameJSON, compression: CompressionNone, wantFormat: "json"},
+		{name: "unknown codec", codec: "xml", wantErr: true},
+		{name: "unknown compression", compression: "lz4", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encoder, err := NewEncoderWithFormat(tt.codec, tt.compression)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewEncoderWithFormat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && encoder.Format() != tt.wantFormat {
+				t.Errorf("Format() = %v, want %v", encoder.Format(), tt.wantFormat)
+			}
+		})
+	}
+}
+
+func TestEncoder_FormatsRoundTrip(t *testing.T) {
+	formats := []struct{ codec, compression string }{
+		{CodecNameCBOR, CompressionZstd},
+		{CodecNameCBOR, CompressionNone},
+		{CodecNameJSON, CompressionZstd},
+		{CodecNameJSON, CompressionNone},
+	}
+
+	for _, f := range formats {
+		encoder, err := NewEncoderWithFormat(f.codec, f.compression)
+		if err != nil {
+			t.Fatalf("NewEncoderWithFormat() error = %v", err)
+		}
+
+		t.Run(encoder.Format(), func(t *testing.T) {
+			original := newTestTransaction()
+
+			data, err := encoder.EncodeTransaction(original)
+			if err != nil {
+				t.Fatalf("EncodeTransaction() error = %v", err)
+			}
+
+			// Any encoder decodes any format
+			decoded, err := NewEncoder().DecodeTransaction(data)
+			if err != nil {
+				t.Fatalf("DecodeTransaction() error = %v", err)
+			}
+
+			if decoded.Hash != original.Hash || decoded.To != original.To || decoded.Value != original.Value {
+				t.Errorf("decoded transaction = %+v, want %+v", decoded, original)
+			}
+			if decoded.ChainType != original.ChainType {
+				t.Errorf("ChainType = %v, want %v", decoded.ChainType, original.ChainType)
+			}
+			if !bytes.Equal(decoded.Input, original.Input) {
+				t.Errorf("Input = %x, want %x", decoded.Input, original.Input)
+			}
+			if len(decoded.Logs) != 1 || decoded.Logs[0].Topics[0] != original.Logs[0].Topics[0] {
+				t.Errorf("Logs = %+v, want %+v", decoded.Logs, original.Logs)
+			}
+			if !decoded.IndexedAt.Equal(original.IndexedAt) {
+				t.Errorf("IndexedAt = %v, want %v", decoded.IndexedAt, original.IndexedAt)
+			}
+			if decoded.Metadata["effective_gas_price"] != original.Metadata["effective_gas_price"] {
+				t.Errorf("Metadata = %v, want %v", decoded.Metadata, original.Metadata)
+			}
+			if nested, ok := decoded.Metadata["nested"].(map[string]interface{}); !ok || nested["key"] != "value" {
+				t.Errorf("Metadata[nested] = %#v, want map[string]interface{}", decoded.Metadata["nested"])
+			}
+		})
+	}
+}
+
+func TestEncoder_DecodeLegacyJSON(t *testing.T) {
+	block := models.NewBlock(models.ChainTypeEVM, "ethereum", 7, "0xlegacy")
+	block.Timestamp = models.NewTimestamp(time.Now().Unix())
+
+	// Records written before the format byte are plain JSON
+	data, err := json.Marshal(block)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	decoded, err := NewEncoder().DecodeBlock(data)
+	if err != nil {
+		t.Fatalf("DecodeBlock() error = %v", err)
+	}
+	if decoded.Hash != block.Hash || decoded.Number != block.Number {
+		t.Errorf("DecodeBlock() = %+v, want %+v", decoded, block)
+	}
+}
+
+func TestEncoder_CompactFormatIsSmaller(t *testing.T) {
+	jsonEncoder, err := NewEncoderWithFormat(CodecNameJSON, CompressionNone)
+	if err != nil {
+		t.Fatalf("NewEncoderWithFormat() error = %v", err)
+	}
+
+	tx := newTestTransaction()
+
+	jsonData, err := jsonEncoder.EncodeTransaction(tx)
+	if err != nil {
+		t.Fatalf("EncodeTransaction() error = %v", err)
+	}
+	compactData, err := NewEncoder().EncodeTransaction(tx)
+	if err != nil {
+		t.Fatalf("EncodeTransaction() error = %v", err)
+	}
+
+	if len(compactData) >= len(jsonData) {
+		t.Errorf("compact record is %d bytes, want less than JSON %d bytes", len(compactData), len(jsonData))
+	}
+}
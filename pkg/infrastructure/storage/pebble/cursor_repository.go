@@ -0,0 +1,69 @@
+package pebble
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/repository"
+)
+
+// CursorRepo implements the CursorRepository interface using PebbleDB
+type CursorRepo struct {
+	db      *pebble.DB
+	encoder *Encoder
+}
+
+// NewCursorRepo creates a new cursor repository
+func NewCursorRepo(db *pebble.DB, encoder *Encoder) *CursorRepo {
+	return &CursorRepo{
+		db:      db,
+		encoder: encoder,
+	}
+}
+
+// GetCursor retrieves the contiguously committed block height for a chain
+func (r *CursorRepo) GetCursor(ctx context.Context, chainID string) (uint64, error) {
+	key := CursorKey(chainID)
+
+	value, closer, err := r.db.Get(key)
+	if err != nil {
+		if err == pebble.ErrNotFound {
+			return 0, repository.ErrCursorNotFound
+		}
+		return 0, fmt.Errorf("failed to get cursor: %w", err)
+	}
+	defer closer.Close()
+
+	height, err := r.encoder.DecodeUint64(value)
+	if err != nil {
+		return 0, fmt.Errorf("failed to decode cursor: %w", err)
+	}
+
+	return height, nil
+}
+
+// SaveCursor persists the contiguously committed block height for a chain
+func (r *CursorRepo) SaveCursor(ctx context.Context, chainID string, blockNumber uint64) error {
+	if chainID == "" {
+		return fmt.Errorf("chain ID cannot be empty")
+	}
+
+	key := CursorKey(chainID)
+	if err := r.db.Set(key, r.encoder.EncodeUint64(blockNumber), pebble.Sync); err != nil {
+		return fmt.Errorf("failed to save cursor: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteCursor removes the cursor for a chain
+func (r *CursorRepo) DeleteCursor(ctx context.Context, chainID string) error {
+	key := CursorKey(chainID)
+
+	if err := r.db.Delete(key, pebble.Sync); err != nil {
+		return fmt.Errorf("failed to delete cursor: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package pebble
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/repository"
+)
+
+func TestCursorRepo_GetCursor(t *testing.T) {
+	storage, tmpDir := setupTestDB(t)
+	defer cleanupTestDB(t, storage, tmpDir)
+
+	ctx := context.Background()
+
+	t.Run("get missing cursor", func(t *testing.T) {
+		_, err := storage.GetCursor(ctx, "ethereum")
+		if err != repository.ErrCursorNotFound {
+			t.Errorf("GetCursor() error = %v, want ErrCursorNotFound", err)
+		}
+	})
+
+	t.Run("get saved cursor", func(t *testing.T) {
+		if err := storage.SaveCursor(ctx, "ethereum", 1234); err != nil {
+			t.Fatalf("SaveCursor() error = %v", err)
+		}
+
+		cursor, err := storage.GetCursor(ctx, "ethereum")
+		if err != nil {
+			t.Fatalf("GetCursor() error = %v", err)
+		}
+
+		if cursor != 1234 {
+			t.Errorf("GetCursor() = %d, want 1234", cursor)
+		}
+	})
+
+	t.Run("cursor is independent of latest height", func(t *testing.T) {
+		if _, err := storage.GetLatestHeight(ctx, "ethereum"); err != repository.ErrBlockNotFound {
+			t.Errorf("GetLatestHeight() error = %v, want ErrBlockNotFound", err)
+		}
+	})
+}
+
+func TestCursorRepo_SaveCursor(t *testing.T) {
+	storage, tmpDir := setupTestDB(t)
+	defer cleanupTestDB(t, storage, tmpDir)
+
+	ctx := context.Background()
+
+	t.Run("overwrite cursor", func(t *testing.T) {
+		for _, height := range []uint64{10, 20, 30} {
+			if err := storage.SaveCursor(ctx, "ethereum", height); err != nil {
+				t.Fatalf("SaveCursor() error = %v", err)
+			}
+		}
+
+		cursor, err := storage.GetCursor(ctx, "ethereum")
+		if err != nil {
+			t.Fatalf("GetCursor() error = %v", err)
+		}
+
+		if cursor != 30 {
+			t.Errorf("GetCursor() = %d, want 30", cursor)
+		}
+	})
+
+	t.Run("empty chain ID", func(t *testing.T) {
+		if err := storage.SaveCursor(ctx, "", 1); err == nil {
+			t.Error("SaveCursor() with empty chain ID should return error")
+		}
+	})
+}
+
+func TestCursorRepo_DeleteCursor(t *testing.T) {
+	storage, tmpDir := setupTestDB(t)
+	defer cleanupTestDB(t, storage, tmpDir)
+
+	ctx := context.Background()
+
+	if err := storage.SaveCursor(ctx, "ethereum", 42); err != nil {
+		t.Fatalf("setup failed: %v", err)
+	}
+
+	if err := storage.DeleteCursor(ctx, "ethereum"); err != nil {
+		t.Fatalf("DeleteCursor() error = %v", err)
+	}
+
+	if _, err := storage.GetCursor(ctx, "ethereum"); err != repository.ErrCursorNotFound {
+		t.Errorf("GetCursor() error = %v, want ErrCursorNotFound", err)
+	}
+}
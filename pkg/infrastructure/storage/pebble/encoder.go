@@ -8,12 +8,48 @@ import (
 	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
 )
 
-// Encoder handles encoding and decoding of data for storage
-type Encoder struct{}
+// Encoder handles encoding and decoding of data for storage.
+// Blocks, transactions, logs and token transfers are written with the
+// configured codec and compression behind a format byte; any supported
+// format, including JSON records written before formats existed, can be
+// decoded.
+type Encoder struct {
+	format byte // Format byte for newly encoded blocks and transactions
+}
 
-// NewEncoder creates a new encoder instance
+// NewEncoder creates a new encoder instance using the default record format
 func NewEncoder() *Encoder {
-	return &Encoder{}
+	return &Encoder{
+		format: formatCodecCBOR | formatZstd,
+	}
+}
+
+// NewEncoderWithFormat creates an encoder writing blocks and transactions
+// with the named codec ("json", "cbor") and compression ("none", "zstd").
+// Empty names select the defaults.
+func NewEncoderWithFormat(codec string, compression string) (*Encoder, error) {
+	codecFlag, err := codecFormat(codec)
+	if err != nil {
+		return nil, err
+	}
+
+	compressionFlag, err := compressionFormat(compression)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := initCodecs(); err != nil {
+		return nil, err
+	}
+
+	return &Encoder{
+		format: codecFlag | compressionFlag,
+	}, nil
+}
+
+// Format returns a readable name of the record format, e.g. "cbor+zstd"
+func (e *Encoder) Format() string {
+	return formatName(e.format)
 }
 
 // EncodeBlock encodes a Block model to bytes
@@ -22,7 +58,7 @@ func (e *Encoder) EncodeBlock(block *models.Block) ([]byte, error) {
 		return nil, fmt.Errorf("block cannot be nil")
 	}
 
-	data, err := json.Marshal(block)
+	data, err := marshalRecord(e.format, block)
 	if err != nil {
 		return nil, fmt.Errorf("failed to encode block: %w", err)
 	}
@@ -37,7 +73,7 @@ func (e *Encoder) DecodeBlock(data []byte) (*models.Block, error) {
 	}
 
 	var block models.Block
-	if err := json.Unmarshal(data, &block); err != nil {
+	if err := unmarshalRecord(data, &block); err != nil {
 		return nil, fmt.Errorf("failed to decode block: %w", err)
 	}
 
@@ -50,7 +86,7 @@ func (e *Encoder) EncodeTransaction(tx *models.Transaction) ([]byte, error) {
 		return nil, fmt.Errorf("transaction cannot be nil")
 	}
 
-	data, err := json.Marshal(tx)
+	data, err := marshalRecord(e.format, tx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to encode transaction: %w", err)
 	}
@@ -65,13 +101,69 @@ func (e *Encoder) DecodeTransaction(data []byte) (*models.Transaction, error) {
 	}
 
 	var tx models.Transaction
-	if err := json.Unmarshal(data, &tx); err != nil {
+	if err := unmarshalRecord(data, &tx); err != nil {
 		return nil, fmt.Errorf("failed to decode transaction: %w", err)
 	}
 
 	return &tx, nil
 }
 
+// EncodeLog encodes a Log model to bytes
+func (e *Encoder) EncodeLog(log *models.Log) ([]byte, error) {
+	if log == nil {
+		return nil, fmt.Errorf("log cannot be nil")
+	}
+
+	data, err := marshalRecord(e.format, log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode log: %w", err)
+	}
+
+	return data, nil
+}
+
+// DecodeLog decodes bytes to a Log model
+func (e *Encoder) DecodeLog(data []byte) (*models.Log, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("data cannot be empty")
+	}
+
+	var log models.Log
+	if err := unmarshalRecord(data, &log); err != nil {
+		return nil, fmt.Errorf("failed to decode log: %w", err)
+	}
+
+	return &log, nil
+}
+
+// EncodeTokenTransfer encodes a TokenTransfer model to bytes
+func (e *Encoder) EncodeTokenTransfer(transfer *models.TokenTransfer) ([]byte, error) {
+	if transfer == nil {
+		return nil, fmt.Errorf("token transfer cannot be nil")
+	}
+
+	data, err := marshalRecord(e.format, transfer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode token transfer: %w", err)
+	}
+
+	return data, nil
+}
+
+// DecodeTokenTransfer decodes bytes to a TokenTransfer model
+func (e *Encoder) DecodeTokenTransfer(data []byte) (*models.TokenTransfer, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("data cannot be empty")
+	}
+
+	var transfer models.TokenTransfer
+	if err := unmarshalRecord(data, &transfer); err != nil {
+		return nil, fmt.Errorf("failed to decode token transfer: %w", err)
+	}
+
+	return &transfer, nil
+}
+
 // EncodeChain encodes a Chain model to bytes
 func (e *Encoder) EncodeChain(chain *models.Chain) ([]byte, error) {
 	if chain == nil {
@@ -0,0 +1,327 @@
+package pebble
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+)
+
+// LogRepo implements the LogRepository interface using PebbleDB. Each log is
+// stored once under its position in the chain; the address and topic
+// indexes are bare keys that end in the same position, so index scans come
+// back in chain order and point straight at the stored log.
+type LogRepo struct {
+	db      *pebble.DB
+	encoder *Encoder
+}
+
+// NewLogRepo creates a new log repository
+func NewLogRepo(db *pebble.DB, encoder *Encoder) *LogRepo {
+	return &LogRepo{
+		db:      db,
+		encoder: encoder,
+	}
+}
+
+// QueryLogs returns the logs matching the filter in chain order. Logs are
+// looked up through the address index when an address is given, through
+// the topic index when the first topic is given, and otherwise by scanning
+// the chain's logs in the block range.
+func (r *LogRepo) QueryLogs(ctx context.Context, filter *models.LogFilter, pagination *models.PaginationOptions) ([]*models.Log, error) {
+	if filter == nil {
+		return nil, fmt.Errorf("filter cannot be nil")
+	}
+
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	if pagination == nil {
+		pagination = models.DefaultPaginationOptions()
+	}
+
+	if err := pagination.Validate(); err != nil {
+		return nil, err
+	}
+
+	logs := make([]*models.Log, 0)
+	skipped := 0
+	visit := func(log *models.Log) bool {
+		if !filter.Matches(log) {
+			return true
+		}
+		if skipped < pagination.Offset {
+			skipped++
+			return true
+		}
+		logs = append(logs, log)
+		return len(logs) < pagination.Limit
+	}
+
+	var err error
+	switch {
+	case filter.Address != nil:
+		prefix := LogAddressPrefix(filter.ChainID, indexValue(*filter.Address))
+		err = r.scanIndex(ctx, filter, [][]byte{prefix}, visit)
+	case len(filter.Topics) > 0 && len(filter.Topics[0]) > 0:
+		seen := make(map[string]bool)
+		prefixes := make([][]byte, 0, len(filter.Topics[0]))
+		for _, topic := range filter.Topics[0] {
+			topic = indexValue(topic)
+			if seen[topic] {
+				continue
+			}
+			seen[topic] = true
+			prefixes = append(prefixes, LogTopicPrefix(filter.ChainID, topic))
+		}
+		err = r.scanIndex(ctx, filter, prefixes, visit)
+	default:
+		err = r.scanLogs(ctx, filter, visit)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
+
+// scanLogs visits the chain's stored logs in the filter's block range until
+// visit returns false
+func (r *LogRepo) scanLogs(ctx context.Context, filter *models.LogFilter, visit func(*models.Log) bool) error {
+	lower, upper := blockBounds(LogPrefix(filter.ChainID), filter.FromBlock, filter.ToBlock)
+
+	iter, err := r.db.NewIter(&pebble.IterOptions{
+		LowerBound: lower,
+		UpperBound: upper,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+
+	for iter.First(); iter.Valid(); iter.Next() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		log, err := r.encoder.DecodeLog(iter.Value())
+		if err != nil {
+			return fmt.Errorf("failed to decode log: %w", err)
+		}
+		if !visit(log) {
+			break
+		}
+	}
+
+	if err := iter.Error(); err != nil {
+		return fmt.Errorf("iterator error: %w", err)
+	}
+
+	return nil
+}
+
+// scanIndex visits the logs referenced by the index entries under prefixes
+// in the filter's block range until visit returns false. Entries of several
+// prefixes are merged by position so logs are still visited in chain order.
+func (r *LogRepo) scanIndex(ctx context.Context, filter *models.LogFilter, prefixes [][]byte, visit func(*models.Log) bool) error {
+	iters := make([]*pebble.Iterator, 0, len(prefixes))
+	defer func() {
+		for _, iter := range iters {
+			iter.Close()
+		}
+	}()
+
+	for _, prefix := range prefixes {
+		lower, upper := blockBounds(prefix, filter.FromBlock, filter.ToBlock)
+		iter, err := r.db.NewIter(&pebble.IterOptions{
+			LowerBound: lower,
+			UpperBound: upper,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create iterator: %w", err)
+		}
+		iter.First()
+		iters = append(iters, iter)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		// Take the entry with the lowest position across the prefixes
+		next := -1
+		for i, iter := range iters {
+			if !iter.Valid() {
+				continue
+			}
+			if next < 0 || bytes.Compare(logPosition(iter.Key()), logPosition(iters[next].Key())) < 0 {
+				next = i
+			}
+		}
+		if next < 0 {
+			break
+		}
+
+		_, _, blockNumber, txIndex, logIndex, err := ParseLogIndexKey(iters[next].Key())
+		if err != nil {
+			return err
+		}
+		iters[next].Next()
+
+		log, err := r.getLog(filter.ChainID, blockNumber, txIndex, logIndex)
+		if err != nil {
+			return err
+		}
+		if log == nil {
+			// Index entry left behind by a deleted log
+			continue
+		}
+		if !visit(log) {
+			break
+		}
+	}
+
+	for _, iter := range iters {
+		if err := iter.Error(); err != nil {
+			return fmt.Errorf("iterator error: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// getLog reads a stored log, returning nil if it does not exist
+func (r *LogRepo) getLog(chainID string, blockNumber, txIndex, logIndex uint64) (*models.Log, error) {
+	value, closer, err := r.db.Get(LogKey(chainID, blockNumber, txIndex, logIndex))
+	if err != nil {
+		if err == pebble.ErrNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get log: %w", err)
+	}
+	defer closer.Close()
+
+	log, err := r.encoder.DecodeLog(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode log: %w", err)
+	}
+
+	return log, nil
+}
+
+// setLogs stages the logs of tx and their index entries into w and returns
+// the number of keys written. Only logs emitted by an address are stored;
+// chains such as Solana report program output as logs without one.
+func setLogs(w pebble.Writer, encoder *Encoder, tx *models.Transaction) (int, error) {
+	count := 0
+	for _, log := range tx.Logs {
+		if log == nil || log.Address == "" {
+			continue
+		}
+
+		// Stored logs carry the position of the transaction that emitted them
+		record := *log
+		record.BlockNumber = tx.BlockNumber
+		record.BlockHash = tx.BlockHash
+		record.TxHash = tx.Hash
+		record.TxIndex = tx.Index
+
+		data, err := encoder.EncodeLog(&record)
+		if err != nil {
+			return count, fmt.Errorf("failed to encode log %d: %w", log.Index, err)
+		}
+
+		logKey := LogKey(tx.ChainID, tx.BlockNumber, tx.Index, log.Index)
+		if err := w.Set(logKey, data, pebble.Sync); err != nil {
+			return count, fmt.Errorf("failed to set log %d: %w", log.Index, err)
+		}
+		count++
+
+		addrKey := LogAddressKey(tx.ChainID, indexValue(log.Address), tx.BlockNumber, tx.Index, log.Index)
+		if err := w.Set(addrKey, nil, pebble.Sync); err != nil {
+			return count, fmt.Errorf("failed to set log address index: %w", err)
+		}
+		count++
+
+		if len(log.Topics) > 0 {
+			topicKey := LogTopicKey(tx.ChainID, indexValue(log.Topics[0]), tx.BlockNumber, tx.Index, log.Index)
+			if err := w.Set(topicKey, nil, pebble.Sync); err != nil {
+				return count, fmt.Errorf("failed to set log topic index: %w", err)
+			}
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// deleteLogs stages the removal of the logs of tx and their index entries into w
+func deleteLogs(w pebble.Writer, tx *models.Transaction) error {
+	for _, log := range tx.Logs {
+		if log == nil || log.Address == "" {
+			continue
+		}
+
+		if err := w.Delete(LogKey(tx.ChainID, tx.BlockNumber, tx.Index, log.Index), pebble.Sync); err != nil {
+			return fmt.Errorf("failed to delete log %d: %w", log.Index, err)
+		}
+
+		addrKey := LogAddressKey(tx.ChainID, indexValue(log.Address), tx.BlockNumber, tx.Index, log.Index)
+		if err := w.Delete(addrKey, pebble.Sync); err != nil {
+			return fmt.Errorf("failed to delete log address index: %w", err)
+		}
+
+		if len(log.Topics) > 0 {
+			topicKey := LogTopicKey(tx.ChainID, indexValue(log.Topics[0]), tx.BlockNumber, tx.Index, log.Index)
+			if err := w.Delete(topicKey, pebble.Sync); err != nil {
+				return fmt.Errorf("failed to delete log topic index: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// blockBounds returns the iterator bounds that limit a scan under prefix to
+// the given block range. Keys under prefix continue with the block number.
+func blockBounds(prefix []byte, fromBlock, toBlock *uint64) (lower, upper []byte) {
+	lower = prefix
+	if fromBlock != nil {
+		lower = appendUint64(append([]byte(nil), prefix...), *fromBlock)
+	}
+
+	upper = keyUpperBound(prefix)
+	if toBlock != nil && *toBlock < math.MaxUint64 {
+		upper = appendUint64(append([]byte(nil), prefix...), *toBlock+1)
+	}
+
+	return lower, upper
+}
+
+// logPosition returns the block number, transaction index and log index
+// that end every log key
+func logPosition(key []byte) []byte {
+	if len(key) < 3*uint64KeySize {
+		return key
+	}
+	return key[len(key)-3*uint64KeySize:]
+}
+
+// indexValue returns the form of an address or topic used in index keys.
+// Hex values are lowercased so lookups do not depend on checksum casing.
+func indexValue(s string) string {
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		return strings.ToLower(s)
+	}
+	return s
+}
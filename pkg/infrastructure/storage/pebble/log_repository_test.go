@@ -0,0 +1,205 @@
+package pebble
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+)
+
+const (
+	testToken       = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+	testOtherToken  = "0xdAC17F958D2ee523a2206206994597C13D831ec7"
+	testTransferSig = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+	testApprovalSig = "0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925"
+	testHolder      = "0x000000000000000000000000" + "1111111111111111111111111111111111111111"
+)
+
+// newLogTransaction creates a transaction in block emitting logs
+func newLogTransaction(block, index uint64, logs ...*models.Log) *models.Transaction {
+	tx := models.NewTransaction(models.ChainTypeEVM, "ethereum", fmt.Sprintf("0xtx%d_%d", block, index))
+	tx.BlockNumber = block
+	tx.BlockHash = fmt.Sprintf("0xblock%d", block)
+	tx.Index = index
+	tx.From = "0xfrom"
+	tx.Logs = logs
+	return tx
+}
+
+// logPositions returns the block, tx index and log index of each log
+func logPositions(logs []*models.Log) [][3]uint64 {
+	positions := make([][3]uint64, len(logs))
+	for i, log := range logs {
+		positions[i] = [3]uint64{log.BlockNumber, log.TxIndex, log.Index}
+	}
+	return positions
+}
+
+func TestLogRepo_QueryLogs(t *testing.T) {
+	storage, tmpDir := setupTestDB(t)
+	defer cleanupTestDB(t, storage, tmpDir)
+
+	ctx := context.Background()
+
+	txs := []*models.Transaction{
+		newLogTransaction(10, 0,
+			&models.Log{Index: 0, Address: testToken, Topics: []string{testTransferSig, testHolder}},
+			&models.Log{Index: 1, Address: testOtherToken, Topics: []string{testApprovalSig}},
+		),
+		newLogTransaction(10, 1,
+			&models.Log{Index: 2, Address: testOtherToken, Topics: []string{testTransferSig}},
+		),
+		newLogTransaction(11, 0,
+			&models.Log{Index: 0, Address: testToken, Topics: []string{testApprovalSig, testHolder}},
+			// Program output without an emitting address is not indexed
+			&models.Log{Index: 1, Data: []byte("Program log: hello")},
+		),
+		newLogTransaction(12, 3,
+			&models.Log{Index: 5, Address: testToken, Topics: []string{testTransferSig}, Data: []byte{0x01}},
+		),
+	}
+	if err := storage.SaveTransactions(ctx, txs[:2]); err != nil {
+		t.Fatalf("SaveTransactions() error = %v", err)
+	}
+	for _, tx := range txs[2:] {
+		if err := storage.SaveTransaction(ctx, tx); err != nil {
+			t.Fatalf("SaveTransaction() error = %v", err)
+		}
+	}
+
+	str := func(s string) *string { return &s }
+	num := func(n uint64) *uint64 { return &n }
+
+	tests := []struct {
+		name       string
+		filter     *models.LogFilter
+		pagination *models.PaginationOptions
+		want       [][3]uint64
+		wantErr    bool
+	}{
+		{
+			name:   "all logs",
+			filter: &models.LogFilter{ChainID: "ethereum"},
+			want:   [][3]uint64{{10, 0, 0}, {10, 0, 1}, {10, 1, 2}, {11, 0, 0}, {12, 3, 5}},
+		},
+		{
+			name:   "block range",
+			filter: &models.LogFilter{ChainID: "ethereum", FromBlock: num(11), ToBlock: num(12)},
+			want:   [][3]uint64{{11, 0, 0}, {12, 3, 5}},
+		},
+		{
+			name:   "address ignores case",
+			filter: &models.LogFilter{ChainID: "ethereum", Address: str("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")},
+			want:   [][3]uint64{{10, 0, 0}, {11, 0, 0}, {12, 3, 5}},
+		},
+		{
+			name: "address and topic0",
+			filter: &models.LogFilter{
+				ChainID: "ethereum",
+				Address: str(testToken),
+				Topics:  [][]string{{testTransferSig}},
+			},
+			want: [][3]uint64{{10, 0, 0}, {12, 3, 5}},
+		},
+		{
+			name:   "any of several topic0 values",
+			filter: &models.LogFilter{ChainID: "ethereum", Topics: [][]string{{testApprovalSig, testTransferSig}}},
+			want:   [][3]uint64{{10, 0, 0}, {10, 0, 1}, {10, 1, 2}, {11, 0, 0}, {12, 3, 5}},
+		},
+		{
+			name:   "topic0 in block range",
+			filter: &models.LogFilter{ChainID: "ethereum", Topics: [][]string{{testTransferSig}}, ToBlock: num(10)},
+			want:   [][3]uint64{{10, 0, 0}, {10, 1, 2}},
+		},
+		{
+			name:   "wildcard topic0 with topic1",
+			filter: &models.LogFilter{ChainID: "ethereum", Topics: [][]string{nil, {testHolder}}},
+			want:   [][3]uint64{{10, 0, 0}, {11, 0, 0}},
+		},
+		{
+			name:       "pagination",
+			filter:     &models.LogFilter{ChainID: "ethereum", Topics: [][]string{{testTransferSig}}},
+			pagination: &models.PaginationOptions{Limit: 1, Offset: 1},
+			want:       [][3]uint64{{10, 1, 2}},
+		},
+		{
+			name:   "other chain",
+			filter: &models.LogFilter{ChainID: "polygon"},
+			want:   [][3]uint64{},
+		},
+		{
+			name:    "missing chain",
+			filter:  &models.LogFilter{},
+			wantErr: true,
+		},
+		{
+			name:    "inverted range",
+			filter:  &models.LogFilter{ChainID: "ethereum", FromBlock: num(12), ToBlock: num(11)},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs, err := storage.QueryLogs(ctx, tt.filter, tt.pagination)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("QueryLogs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := logPositions(logs); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("QueryLogs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("logs carry their transaction", func(t *testing.T) {
+		logs, err := storage.QueryLogs(ctx, &models.LogFilter{ChainID: "ethereum", FromBlock: num(12)}, nil)
+		if err != nil {
+			t.Fatalf("QueryLogs() error = %v", err)
+		}
+		if len(logs) != 1 {
+			t.Fatalf("QueryLogs() returned %d logs, want 1", len(logs))
+		}
+		log := logs[0]
+		if log.TxHash != "0xtx12_3" || log.BlockHash != "0xblock12" || log.Address != testToken {
+			t.Errorf("QueryLogs() log = %+v", log)
+		}
+		if !reflect.DeepEqual(log.Data, []byte{0x01}) {
+			t.Errorf("QueryLogs() Data = %x, want 01", log.Data)
+		}
+	})
+}
+
+func TestLogRepo_DeleteTransaction(t *testing.T) {
+	storage, tmpDir := setupTestDB(t)
+	defer cleanupTestDB(t, storage, tmpDir)
+
+	ctx := context.Background()
+
+	tx := newLogTransaction(10, 0, &models.Log{Index: 0, Address: testToken, Topics: []string{testTransferSig}})
+	if err := storage.SaveTransaction(ctx, tx); err != nil {
+		t.Fatalf("SaveTransaction() error = %v", err)
+	}
+	if err := storage.DeleteTransaction(ctx, "ethereum", tx.Hash); err != nil {
+		t.Fatalf("DeleteTransaction() error = %v", err)
+	}
+
+	filters := []*models.LogFilter{
+		{ChainID: "ethereum"},
+		{ChainID: "ethereum", Address: &tx.Logs[0].Address},
+		{ChainID: "ethereum", Topics: [][]string{{testTransferSig}}},
+	}
+	for _, filter := range filters {
+		logs, err := storage.QueryLogs(ctx, filter, nil)
+		if err != nil {
+			t.Fatalf("QueryLogs() error = %v", err)
+		}
+		if len(logs) != 0 {
+			t.Errorf("QueryLogs(%+v) returned %d logs after delete, want 0", filter, len(logs))
+		}
+	}
+}
@@ -0,0 +1,457 @@
+package pebble
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/pebble"
+)
+
+// Schema versions of the on-disk key layout
+const (
+	// SchemaVersionText is the original layout with text keys and decimal numbers
+	SchemaVersionText uint64 = 1
+
+	// SchemaVersionBinary is the order-preserving binary key layout
+	SchemaVersionBinary uint64 = 2
+
+	// SchemaVersionIndexedRanges adds the per-chain indexed block ranges
+	SchemaVersionIndexedRanges uint64 = 3
+
+	// SchemaVersion is the layout written by this version of the storage
+	SchemaVersion = SchemaVersionIndexedRanges
+)
+
+// migrationBatchSize is the number of keys rewritten per committed batch
+const migrationBatchSize = 1000
+
+// migration upgrades the database from the previous schema version
+type migration struct {
+	version     uint64
+	description string
+	apply       func(db *pebble.DB) error
+}
+
+// migrations lists every schema upgrade in version order
+var migrations = []migration{
+	{
+		version:     SchemaVersionBinary,
+		description: "rewrite text keys to order-preserving binary keys",
+		apply:       migrateToBinaryKeys,
+	},
+	{
+		version:     SchemaVersionIndexedRanges,
+		description: "build indexed block ranges from stored blocks",
+		apply:       buildIndexedRanges,
+	},
+}
+
+// Migrate brings the database up to SchemaVersion, rewriting keys in place.
+// Each migration is idempotent, so an interrupted run is completed on the
+// next open.
+func (s *PebbleStorage) Migrate() error {
+	version, err := s.SchemaVersion()
+	if err != nil {
+		return err
+	}
+
+	if version > SchemaVersion {
+		return fmt.Errorf("database schema version %d is newer than supported version %d", version, SchemaVersion)
+	}
+
+	for _, m := range migrations {
+		if m.version <= version {
+			continue
+		}
+
+		if err := m.apply(s.db); err != nil {
+			return fmt.Errorf("failed to migrate to schema version %d (%s): %w", m.version, m.description, err)
+		}
+
+		if err := s.setSchemaVersion(m.version); err != nil {
+			return err
+		}
+		version = m.version
+	}
+
+	return nil
+}
+
+// SchemaVersion returns the schema version of the database. A database
+// without a version key is either empty and written with the current
+// layout, or was created before versioning and uses the text layout.
+func (s *PebbleStorage) SchemaVersion() (uint64, error) {
+	value, closer, err := s.db.Get(SchemaVersionKey())
+	if err == nil {
+		defer closer.Close()
+		version, err := s.encoder.DecodeUint64(value)
+		if err != nil {
+			return 0, fmt.Errorf("failed to decode schema version: %w", err)
+		}
+		return version, nil
+	}
+	if err != pebble.ErrNotFound {
+		return 0, fmt.Errorf("failed to get schema version: %w", err)
+	}
+
+	empty, err := s.isEmpty()
+	if err != nil {
+		return 0, err
+	}
+	if !empty {
+		return SchemaVersionText, nil
+	}
+
+	if err := s.setSchemaVersion(SchemaVersion); err != nil {
+		return 0, err
+	}
+	return SchemaVersion, nil
+}
+
+// setSchemaVersion stores the schema version
+func (s *PebbleStorage) setSchemaVersion(version uint64) error {
+	if err := s.db.Set(SchemaVersionKey(), s.encoder.EncodeUint64(version), pebble.Sync); err != nil {
+		return fmt.Errorf("failed to set schema version: %w", err)
+	}
+	return nil
+}
+
+// isEmpty reports whether the database holds no keys at all
+func (s *PebbleStorage) isEmpty() (bool, error) {
+	iter, err := s.db.NewIter(nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+
+	empty := !iter.First()
+	if err := iter.Error(); err != nil {
+		return false, fmt.Errorf("iterator error: %w", err)
+	}
+	return empty, nil
+}
+
+// migrateToBinaryKeys rewrites every text-layout composite key to the binary
+// layout. Binary keys start with the high byte of a chain ID length, which is
+// zero for any realistic chain ID, while text keys start with a printable
+// character, so keys that were already rewritten are skipped.
+func migrateToBinaryKeys(db *pebble.DB) error {
+	// Chain IDs are found before block keys are rewritten
+	chains, err := findTextChains(db)
+	if err != nil {
+		return err
+	}
+
+	converters := []struct {
+		prefix  string
+		convert func(rest string) ([]byte, error)
+	}{
+		{PrefixBlock, convertTextBlockKey},
+		{PrefixBlockHash, convertTextBlockHashKey},
+		{PrefixTx, convertTextTransactionKey},
+		{PrefixTxByBlock, convertTextTransactionByBlockKey},
+		{PrefixAddrTx, chains.convertAddressTxKey},
+	}
+
+	for _, c := range converters {
+		if err := rewriteKeys(db, c.prefix, c.convert); err != nil {
+			return fmt.Errorf("failed to rewrite %s keys: %w", c.prefix, err)
+		}
+	}
+
+	return nil
+}
+
+// rewriteKeys moves every text key under prefix to the key returned by convert
+func rewriteKeys(db *pebble.DB, prefix string, convert func(rest string) ([]byte, error)) error {
+	lower := []byte(prefix)
+
+	iter, err := db.NewIter(&pebble.IterOptions{
+		LowerBound: lower,
+		UpperBound: keyUpperBound(lower),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+
+	batch := db.NewBatch()
+	defer func() { batch.Close() }()
+	count := 0
+
+	for iter.First(); iter.Valid(); iter.Next() {
+		key := iter.Key()
+		rest := key[len(prefix):]
+		if len(rest) == 0 || rest[0] == 0 {
+			// Already in the binary layout
+			continue
+		}
+
+		newKey, err := convert(string(rest))
+		if err != nil {
+			return fmt.Errorf("failed to convert key %q: %w", key, err)
+		}
+
+		if err := batch.Set(newKey, iter.Value(), pebble.NoSync); err != nil {
+			return fmt.Errorf("failed to batch set key: %w", err)
+		}
+		if err := batch.Delete(key, pebble.NoSync); err != nil {
+			return fmt.Errorf("failed to batch delete key: %w", err)
+		}
+		count++
+
+		// Commit in batches to avoid memory issues
+		if count >= migrationBatchSize {
+			if err := batch.Commit(pebble.Sync); err != nil {
+				return fmt.Errorf("failed to commit batch: %w", err)
+			}
+			batch.Close()
+			batch = db.NewBatch()
+			count = 0
+		}
+	}
+
+	if err := iter.Error(); err != nil {
+		return fmt.Errorf("iterator error: %w", err)
+	}
+
+	if count > 0 {
+		if err := batch.Commit(pebble.Sync); err != nil {
+			return fmt.Errorf("failed to commit final batch: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// buildIndexedRanges writes the indexed ranges of every chain from the stored
+// block keys. Block keys sort by chain and then number, so runs are found in
+// a single pass without decoding any block. Existing ranges are replaced,
+// which makes an interrupted run safe to repeat.
+func buildIndexedRanges(db *pebble.DB) error {
+	encoder := NewEncoder()
+
+	prefix := []byte(PrefixIndexedRange)
+	if err := db.DeleteRange(prefix, keyUpperBound(prefix), pebble.Sync); err != nil {
+		return fmt.Errorf("failed to clear indexed ranges: %w", err)
+	}
+
+	lower := []byte(PrefixBlock)
+	iter, err := db.NewIter(&pebble.IterOptions{
+		LowerBound: lower,
+		UpperBound: keyUpperBound(lower),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+
+	batch := db.NewBatch()
+	defer func() { batch.Close() }()
+	count := 0
+
+	var (
+		runChain string
+		runStart uint64
+		runEnd   uint64
+		inRun    bool
+	)
+
+	flush := func() error {
+		if !inRun {
+			return nil
+		}
+		if err := batch.Set(IndexedRangeKey(runChain, runStart), encoder.EncodeUint64(runEnd), pebble.NoSync); err != nil {
+			return fmt.Errorf("failed to batch set indexed range: %w", err)
+		}
+		count++
+
+		// Commit in batches to avoid memory issues
+		if count >= migrationBatchSize {
+			if err := batch.Commit(pebble.Sync); err != nil {
+				return fmt.Errorf("failed to commit batch: %w", err)
+			}
+			batch.Close()
+			batch = db.NewBatch()
+			count = 0
+		}
+		return nil
+	}
+
+	for iter.First(); iter.Valid(); iter.Next() {
+		chainID, number, err := ParseBlockKey(iter.Key())
+		if err != nil {
+			return err
+		}
+
+		if inRun && chainID == runChain && number == runEnd+1 {
+			runEnd = number
+			continue
+		}
+
+		if err := flush(); err != nil {
+			return err
+		}
+		runChain, runStart, runEnd, inRun = chainID, number, number, true
+	}
+
+	if err := iter.Error(); err != nil {
+		return fmt.Errorf("iterator error: %w", err)
+	}
+
+	if err := flush(); err != nil {
+		return err
+	}
+
+	if count > 0 {
+		if err := batch.Commit(pebble.Sync); err != nil {
+			return fmt.Errorf("failed to commit final batch: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// convertTextBlockKey converts {chainID}:{blockNumber}
+func convertTextBlockKey(rest string) ([]byte, error) {
+	i := strings.LastIndex(rest, KeySeparator)
+	if i < 0 {
+		return nil, fmt.Errorf("invalid block key format")
+	}
+
+	number, err := strconv.ParseUint(rest[i+1:], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid block number: %w", err)
+	}
+
+	return BlockKey(rest[:i], number), nil
+}
+
+// convertTextBlockHashKey converts {chainID}:{hash}. Hashes hold no
+// separator, unlike chain IDs such as eip155:1, so the key is split at its
+// last one.
+func convertTextBlockHashKey(rest string) ([]byte, error) {
+	i := strings.LastIndex(rest, KeySeparator)
+	if i < 0 {
+		return nil, fmt.Errorf("invalid block hash key format")
+	}
+	return BlockHashKey(rest[:i], rest[i+1:]), nil
+}
+
+// convertTextTransactionKey converts {chainID}:{txHash}, split like block
+// hash keys
+func convertTextTransactionKey(rest string) ([]byte, error) {
+	i := strings.LastIndex(rest, KeySeparator)
+	if i < 0 {
+		return nil, fmt.Errorf("invalid transaction key format")
+	}
+	return TransactionKey(rest[:i], rest[i+1:]), nil
+}
+
+// convertTextTransactionByBlockKey converts {chainID}:{blockNumber}:{txIndex}
+func convertTextTransactionByBlockKey(rest string) ([]byte, error) {
+	head, blockNumber, txIndex, err := splitTextPosition(rest)
+	if err != nil {
+		return nil, err
+	}
+	return TransactionByBlockKey(head, blockNumber, txIndex), nil
+}
+
+// textChains lists the chain IDs of a text-layout database, longest first.
+// Both chain IDs, such as eip155:1, and addresses may contain the key
+// separator, so keys holding both are split at the end of a known chain ID.
+type textChains []string
+
+// findTextChains collects the chain IDs of the stored chains and of the
+// blocks still under text keys
+func findTextChains(db *pebble.DB) (textChains, error) {
+	found := make(map[string]bool)
+	for _, prefix := range []string{PrefixChain, PrefixBlock} {
+		lower := []byte(prefix)
+		iter, err := db.NewIter(&pebble.IterOptions{
+			LowerBound: lower,
+			UpperBound: keyUpperBound(lower),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create iterator: %w", err)
+		}
+
+		for iter.First(); iter.Valid(); iter.Next() {
+			rest := string(iter.Key()[len(prefix):])
+			switch {
+			case prefix == PrefixChain:
+				found[rest] = true
+			case len(rest) > 0 && rest[0] != 0:
+				if i := strings.LastIndex(rest, KeySeparator); i > 0 {
+					found[rest[:i]] = true
+				}
+			}
+		}
+
+		err = iter.Error()
+		iter.Close()
+		if err != nil {
+			return nil, fmt.Errorf("iterator error: %w", err)
+		}
+	}
+
+	chains := make(textChains, 0, len(found))
+	for chainID := range found {
+		chains = append(chains, chainID)
+	}
+	sort.Slice(chains, func(i, j int) bool {
+		if len(chains[i]) != len(chains[j]) {
+			return len(chains[i]) > len(chains[j])
+		}
+		return chains[i] < chains[j]
+	})
+
+	return chains, nil
+}
+
+// split splits {chainID}:{value} after the longest known chain ID it starts
+// with, or at the first separator if it starts with none
+func (c textChains) split(key string) (chainID string, value string, ok bool) {
+	for _, id := range c {
+		if strings.HasPrefix(key, id+KeySeparator) {
+			return id, key[len(id)+1:], true
+		}
+	}
+	return strings.Cut(key, KeySeparator)
+}
+
+// convertAddressTxKey converts {chainID}:{address}:{blockNumber}:{txIndex}
+func (c textChains) convertAddressTxKey(rest string) ([]byte, error) {
+	head, blockNumber, txIndex, err := splitTextPosition(rest)
+	if err != nil {
+		return nil, err
+	}
+
+	chainID, address, ok := c.split(head)
+	if !ok {
+		return nil, fmt.Errorf("invalid address-tx key format")
+	}
+	return AddressTxKey(chainID, address, blockNumber, txIndex), nil
+}
+
+// splitTextPosition splits a trailing :{blockNumber}:{txIndex} off a text key
+func splitTextPosition(rest string) (head string, blockNumber uint64, txIndex uint64, err error) {
+	parts := strings.Split(rest, KeySeparator)
+	if len(parts) < 3 {
+		return "", 0, 0, fmt.Errorf("invalid key format")
+	}
+
+	blockNumber, err = strconv.ParseUint(parts[len(parts)-2], 10, 64)
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("invalid block number: %w", err)
+	}
+
+	txIndex, err = strconv.ParseUint(parts[len(parts)-1], 10, 64)
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("invalid transaction index: %w", err)
+	}
+
+	return strings.Join(parts[:len(parts)-2], KeySeparator), blockNumber, txIndex, nil
+}
@@ -0,0 +1,210 @@
+package pebble
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+)
+
+// writeTextLayout writes a block and transaction using the original text key layout
+func writeTextLayout(t *testing.T, storage *PebbleStorage, block *models.Block, tx *models.Transaction) {
+	t.Helper()
+
+	blockData, err := storage.encoder.EncodeBlock(block)
+	if err != nil {
+		t.Fatalf("EncodeBlock() error = %v", err)
+	}
+	txData, err := storage.encoder.EncodeTransaction(tx)
+	if err != nil {
+		t.Fatalf("EncodeTransaction() error = %v", err)
+	}
+	txHash := storage.encoder.EncodeString(tx.Hash)
+
+	chainID := block.ChainID
+	entries := map[string][]byte{
+		"block:" + chainID + ":12":                     blockData,
+		"block_hash:" + chainID + ":" + block.Hash:     storage.encoder.EncodeUint64(block.Number),
+		"tx:" + chainID + ":" + tx.Hash:                txData,
+		"tx_block:" + chainID + ":12:0":                txHash,
+		"addr_tx:" + chainID + ":" + tx.From + ":12:0": txHash,
+		"addr_tx:" + chainID + ":" + tx.To + ":12:0":   txHash,
+		string(LatestHeightKey(chainID)):               storage.encoder.EncodeUint64(block.Number),
+	}
+	for key, value := range entries {
+		if err := storage.db.Set([]byte(key), value, pebble.Sync); err != nil {
+			t.Fatalf("Set() error = %v", err)
+		}
+	}
+
+	if err := storage.db.Delete(SchemaVersionKey(), pebble.Sync); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+}
+
+func TestMigrate_FreshDatabase(t *testing.T) {
+	storage, tmpDir := setupTestDB(t)
+	defer cleanupTestDB(t, storage, tmpDir)
+
+	version, err := storage.SchemaVersion()
+	if err != nil {
+		t.Fatalf("SchemaVersion() error = %v", err)
+	}
+	if version != SchemaVersion {
+		t.Errorf("SchemaVersion() = %d, want %d", version, SchemaVersion)
+	}
+}
+
+func TestMigrate_TextToBinaryKeys(t *testing.T) {
+	storage, tmpDir := setupTestDB(t)
+	defer cleanupTestDB(t, storage, tmpDir)
+
+	ctx := context.Background()
+
+	block := models.NewBlock(models.ChainTypeEVM, "ethereum", 12, "0xblock12")
+	tx := models.NewTransaction(models.ChainTypeEVM, "ethereum", "0xtx1")
+	tx.BlockNumber = 12
+	tx.From = "0xfrom"
+	tx.To = "0xto"
+	writeTextLayout(t, storage, block, tx)
+
+	version, err := storage.SchemaVersion()
+	if err != nil {
+		t.Fatalf("SchemaVersion() error = %v", err)
+	}
+	if version != SchemaVersionText {
+		t.Fatalf("SchemaVersion() = %d, want %d", version, SchemaVersionText)
+	}
+
+	if err := storage.Migrate(); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+
+	version, err = storage.SchemaVersion()
+	if err != nil {
+		t.Fatalf("SchemaVersion() error = %v", err)
+	}
+	if version != SchemaVersion {
+		t.Errorf("SchemaVersion() after migration = %d, want %d", version, SchemaVersion)
+	}
+
+	t.Run("block readable by number and hash", func(t *testing.T) {
+		got, err := storage.GetBlock(ctx, "ethereum", 12)
+		if err != nil {
+			t.Fatalf("GetBlock() error = %v", err)
+		}
+		if got.Hash != block.Hash {
+			t.Errorf("GetBlock() hash = %v, want %v", got.Hash, block.Hash)
+		}
+
+		got, err = storage.GetBlockByHash(ctx, "ethereum", block.Hash)
+		if err != nil {
+			t.Fatalf("GetBlockByHash() error = %v", err)
+		}
+		if got.Number != 12 {
+			t.Errorf("GetBlockByHash() number = %v, want 12", got.Number)
+		}
+	})
+
+	t.Run("transaction indexes readable", func(t *testing.T) {
+		txs, err := storage.GetTransactionsByBlock(ctx, "ethereum", 12)
+		if err != nil {
+			t.Fatalf("GetTransactionsByBlock() error = %v", err)
+		}
+		if len(txs) != 1 || txs[0].Hash != tx.Hash {
+			t.Errorf("GetTransactionsByBlock() = %v, want [%s]", txs, tx.Hash)
+		}
+
+		for _, address := range []string{tx.From, tx.To} {
+			hashes, err := storage.GetAddressTransactions(ctx, "ethereum", address, nil)
+			if err != nil {
+				t.Fatalf("GetAddressTransactions() error = %v", err)
+			}
+			if len(hashes) != 1 || hashes[0] != tx.Hash {
+				t.Errorf("GetAddressTransactions(%s) = %v, want [%s]", address, hashes, tx.Hash)
+			}
+		}
+	})
+
+	t.Run("indexed ranges built", func(t *testing.T) {
+		assertRanges(t, storage, "ethereum", []models.BlockRange{{Start: 12, End: 12}})
+	})
+
+	t.Run("text keys removed", func(t *testing.T) {
+		_, closer, err := storage.db.Get([]byte("block:ethereum:12"))
+		if err == nil {
+			closer.Close()
+			t.Error("text block key should be removed after migration")
+		}
+	})
+
+	t.Run("migration is idempotent", func(t *testing.T) {
+		if err := storage.db.Delete(SchemaVersionKey(), pebble.Sync); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if err := storage.Migrate(); err != nil {
+			t.Fatalf("second Migrate() error = %v", err)
+		}
+		if _, err := storage.GetBlock(ctx, "ethereum", 12); err != nil {
+			t.Errorf("GetBlock() after second migration error = %v", err)
+		}
+	})
+}
+
+func TestMigrate_ChainIDWithSeparator(t *testing.T) {
+	storage, tmpDir := setupTestDB(t)
+	defer cleanupTestDB(t, storage, tmpDir)
+
+	ctx := context.Background()
+
+	// Both the CAIP-2 chain ID and the account index sender contain the
+	// key separator
+	block := models.NewBlock(models.ChainTypeEVM, "eip155:1", 12, "0xblock12")
+	tx := models.NewTransaction(models.ChainTypeEVM, "eip155:1", "0xtx1")
+	tx.BlockNumber = 12
+	tx.From = "index:7"
+	tx.To = "0xto"
+	writeTextLayout(t, storage, block, tx)
+
+	if err := storage.Migrate(); err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+
+	got, err := storage.GetBlockByHash(ctx, "eip155:1", block.Hash)
+	if err != nil {
+		t.Fatalf("GetBlockByHash() error = %v", err)
+	}
+	if got.Number != 12 {
+		t.Errorf("GetBlockByHash() number = %v, want 12", got.Number)
+	}
+
+	if _, err := storage.GetTransaction(ctx, "eip155:1", tx.Hash); err != nil {
+		t.Errorf("GetTransaction() error = %v", err)
+	}
+
+	for _, address := range []string{tx.From, tx.To} {
+		hashes, err := storage.GetAddressTransactions(ctx, "eip155:1", address, nil)
+		if err != nil {
+			t.Fatalf("GetAddressTransactions() error = %v", err)
+		}
+		if len(hashes) != 1 || hashes[0] != tx.Hash {
+			t.Errorf("GetAddressTransactions(%s) = %v, want [%s]", address, hashes, tx.Hash)
+		}
+	}
+
+	assertRanges(t, storage, "eip155:1", []models.BlockRange{{Start: 12, End: 12}})
+}
+
+func TestMigrate_NewerVersion(t *testing.T) {
+	storage, tmpDir := setupTestDB(t)
+	defer cleanupTestDB(t, storage, tmpDir)
+
+	if err := storage.setSchemaVersion(SchemaVersion + 1); err != nil {
+		t.Fatalf("setSchemaVersion() error = %v", err)
+	}
+
+	if err := storage.Migrate(); err == nil {
+		t.Error("Migrate() should refuse a newer schema version")
+	}
+}
@@ -0,0 +1,258 @@
+package pebble
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+)
+
+// RangeRepo implements the IndexedRangeRepository interface using PebbleDB.
+// Each run of contiguously stored blocks is one key holding the run's last
+// block, so a chain with a handful of gaps needs only a handful of keys.
+type RangeRepo struct {
+	db      *pebble.DB
+	encoder *Encoder
+
+	// Updates read the stored ranges before writing new ones, so writers
+	// hold mu from the read until their batch is committed
+	mu sync.Mutex
+}
+
+// NewRangeRepo creates a new indexed range repository
+func NewRangeRepo(db *pebble.DB, encoder *Encoder) *RangeRepo {
+	return &RangeRepo{
+		db:      db,
+		encoder: encoder,
+	}
+}
+
+// GetIndexedRanges retrieves the runs of stored blocks for a chain, lowest first
+func (r *RangeRepo) GetIndexedRanges(ctx context.Context, chainID string) ([]*models.BlockRange, error) {
+	prefix := IndexedRangePrefix(chainID)
+
+	iter, err := r.db.NewIter(&pebble.IterOptions{
+		LowerBound: prefix,
+		UpperBound: keyUpperBound(prefix),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+
+	ranges := make([]*models.BlockRange, 0)
+	for iter.First(); iter.Valid(); iter.Next() {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		default:
+		}
+
+		rng, err := r.decodeRange(iter.Key(), iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, rng)
+	}
+
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("iterator error: %w", err)
+	}
+
+	return ranges, nil
+}
+
+// addBlocks stages the changes that add numbers to a chain's indexed ranges
+// into batch. The caller must hold r.mu until batch is committed.
+func (r *RangeRepo) addBlocks(batch *pebble.Batch, chainID string, numbers []uint64) error {
+	return r.updateRanges(batch, chainID, toRanges(numbers), nil)
+}
+
+// removeBlock stages the changes that remove number from a chain's indexed
+// ranges into batch, splitting the run that contains it. The caller must
+// hold r.mu until batch is committed.
+func (r *RangeRepo) removeBlock(batch *pebble.Batch, chainID string, number uint64) error {
+	return r.updateRanges(batch, chainID, nil, toRanges([]uint64{number}))
+}
+
+// updateRanges stages the changes that remove removed from a chain's indexed
+// ranges and then add added into batch, so a block removed and stored again
+// in the same batch stays indexed. The caller must hold r.mu until batch is
+// committed.
+func (r *RangeRepo) updateRanges(batch *pebble.Batch, chainID string, added, removed []*models.BlockRange) error {
+	changed := mergeRanges(append(append([]*models.BlockRange{}, added...), removed...))
+	if len(changed) == 0 {
+		return nil
+	}
+
+	stored, err := r.loadRanges(chainID, changed[0].Start, changed[len(changed)-1].End)
+	if err != nil {
+		return err
+	}
+
+	for _, rng := range stored {
+		if err := batch.Delete(IndexedRangeKey(chainID, rng.Start), pebble.Sync); err != nil {
+			return fmt.Errorf("failed to batch delete indexed range: %w", err)
+		}
+	}
+
+	remaining := subtractRanges(stored, mergeRanges(removed))
+	for _, rng := range mergeRanges(append(remaining, added...)) {
+		if err := r.setRange(batch, chainID, rng); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadRanges returns the stored ranges of a chain that overlap or touch
+// [start, end], lowest first
+func (r *RangeRepo) loadRanges(chainID string, start, end uint64) ([]*models.BlockRange, error) {
+	prefix := IndexedRangePrefix(chainID)
+
+	iter, err := r.db.NewIter(&pebble.IterOptions{
+		LowerBound: prefix,
+		UpperBound: keyUpperBound(prefix),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+
+	ranges := make([]*models.BlockRange, 0)
+
+	// The run starting below start may reach into the range
+	if iter.SeekLT(IndexedRangeKey(chainID, start)) {
+		rng, err := r.decodeRange(iter.Key(), iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		if rng.End >= start-1 {
+			ranges = append(ranges, rng)
+		}
+	}
+
+	for iter.SeekGE(IndexedRangeKey(chainID, start)); iter.Valid(); iter.Next() {
+		rng, err := r.decodeRange(iter.Key(), iter.Value())
+		if err != nil {
+			return nil, err
+		}
+		if end < math.MaxUint64 && rng.Start > end+1 {
+			break
+		}
+		ranges = append(ranges, rng)
+	}
+
+	if err := iter.Error(); err != nil {
+		return nil, fmt.Errorf("iterator error: %w", err)
+	}
+
+	return ranges, nil
+}
+
+// setRange stages a range write into batch
+func (r *RangeRepo) setRange(batch *pebble.Batch, chainID string, rng *models.BlockRange) error {
+	key := IndexedRangeKey(chainID, rng.Start)
+	if err := batch.Set(key, r.encoder.EncodeUint64(rng.End), pebble.Sync); err != nil {
+		return fmt.Errorf("failed to batch set indexed range: %w", err)
+	}
+	return nil
+}
+
+// decodeRange decodes a stored range key and value
+func (r *RangeRepo) decodeRange(key, value []byte) (*models.BlockRange, error) {
+	_, start, err := ParseIndexedRangeKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	end, err := r.encoder.DecodeUint64(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode indexed range end: %w", err)
+	}
+
+	return &models.BlockRange{Start: start, End: end}, nil
+}
+
+// toRanges compresses block numbers into runs of contiguous numbers
+func toRanges(numbers []uint64) []*models.BlockRange {
+	if len(numbers) == 0 {
+		return nil
+	}
+
+	sorted := make([]uint64, len(numbers))
+	copy(sorted, numbers)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i] < sorted[j]
+	})
+
+	ranges := []*models.BlockRange{{Start: sorted[0], End: sorted[0]}}
+	for _, n := range sorted[1:] {
+		last := ranges[len(ranges)-1]
+		if n-last.End <= 1 {
+			last.End = n
+			continue
+		}
+		ranges = append(ranges, &models.BlockRange{Start: n, End: n})
+	}
+
+	return ranges
+}
+
+// subtractRanges returns the parts of ranges not covered by removed. Both
+// must be sorted and must not overlap themselves.
+func subtractRanges(ranges, removed []*models.BlockRange) []*models.BlockRange {
+	result := make([]*models.BlockRange, 0, len(ranges))
+	for _, rng := range ranges {
+		start := rng.Start
+		covered := false
+		for _, rm := range removed {
+			if rm.End < start || rm.Start > rng.End {
+				continue
+			}
+			if rm.Start > start {
+				result = append(result, &models.BlockRange{Start: start, End: rm.Start - 1})
+			}
+			if rm.End >= rng.End {
+				covered = true
+				break
+			}
+			start = rm.End + 1
+		}
+		if !covered {
+			result = append(result, &models.BlockRange{Start: start, End: rng.End})
+		}
+	}
+
+	return result
+}
+
+// mergeRanges sorts ranges and joins those that overlap or touch
+func mergeRanges(ranges []*models.BlockRange) []*models.BlockRange {
+	if len(ranges) == 0 {
+		return nil
+	}
+
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].Start < ranges[j].Start
+	})
+
+	merged := []*models.BlockRange{{Start: ranges[0].Start, End: ranges[0].End}}
+	for _, rng := range ranges[1:] {
+		last := merged[len(merged)-1]
+		if last.End == math.MaxUint64 || rng.Start <= last.End+1 {
+			if rng.End > last.End {
+				last.End = rng.End
+			}
+			continue
+		}
+		merged = append(merged, &models.BlockRange{Start: rng.Start, End: rng.End})
+	}
+
+	return merged
+}
@@ -0,0 +1,194 @@
+package pebble
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+)
+
+// assertRanges checks the indexed ranges of a chain against want
+func assertRanges(t *testing.T, storage *PebbleStorage, chainID string, want []models.BlockRange) {
+	t.Helper()
+
+	ranges, err := storage.GetIndexedRanges(context.Background(), chainID)
+	if err != nil {
+		t.Fatalf("GetIndexedRanges() error = %v", err)
+	}
+
+	got := make([]models.BlockRange, len(ranges))
+	for i, rng := range ranges {
+		got[i] = *rng
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetIndexedRanges() = %v, want %v", got, want)
+	}
+}
+
+func TestRangeRepo_SaveBlock(t *testing.T) {
+	storage, tmpDir := setupTestDB(t)
+	defer cleanupTestDB(t, storage, tmpDir)
+
+	ctx := context.Background()
+
+	save := func(numbers ...uint64) {
+		for _, n := range numbers {
+			block := models.NewBlock(models.ChainTypeEVM, "ethereum", n, fmt.Sprintf("0xhash%d", n))
+			if err := storage.SaveBlock(ctx, block); err != nil {
+				t.Fatalf("SaveBlock() error = %v", err)
+			}
+		}
+	}
+
+	t.Run("no blocks", func(t *testing.T) {
+		assertRanges(t, storage, "ethereum", []models.BlockRange{})
+	})
+
+	t.Run("separate runs", func(t *testing.T) {
+		save(1, 2, 3, 10, 11)
+		assertRanges(t, storage, "ethereum", []models.BlockRange{{Start: 1, End: 3}, {Start: 10, End: 11}})
+	})
+
+	t.Run("saving a block twice", func(t *testing.T) {
+		save(2)
+		assertRanges(t, storage, "ethereum", []models.BlockRange{{Start: 1, End: 3}, {Start: 10, End: 11}})
+	})
+
+	t.Run("filling a gap joins runs", func(t *testing.T) {
+		save(5, 4, 9, 7, 8, 6)
+		assertRanges(t, storage, "ethereum", []models.BlockRange{{Start: 1, End: 11}})
+	})
+
+	t.Run("block below the first run", func(t *testing.T) {
+		save(0)
+		assertRanges(t, storage, "ethereum", []models.BlockRange{{Start: 0, End: 11}})
+	})
+
+	t.Run("chains are independent", func(t *testing.T) {
+		block := models.NewBlock(models.ChainTypeEVM, "ethereum2", 5, "0xother")
+		if err := storage.SaveBlock(ctx, block); err != nil {
+			t.Fatalf("SaveBlock() error = %v", err)
+		}
+		assertRanges(t, storage, "ethereum2", []models.BlockRange{{Start: 5, End: 5}})
+		assertRanges(t, storage, "ethereum", []models.BlockRange{{Start: 0, End: 11}})
+	})
+}
+
+func TestRangeRepo_SaveBlocks(t *testing.T) {
+	storage, tmpDir := setupTestDB(t)
+	defer cleanupTestDB(t, storage, tmpDir)
+
+	ctx := context.Background()
+
+	blocks := make([]*models.Block, 0)
+	for _, n := range []uint64{20, 21, 22, 30, 31, 40} {
+		blocks = append(blocks, models.NewBlock(models.ChainTypeEVM, "ethereum", n, fmt.Sprintf("0xhash%d", n)))
+	}
+	if err := storage.SaveBlocks(ctx, blocks); err != nil {
+		t.Fatalf("SaveBlocks() error = %v", err)
+	}
+
+	assertRanges(t, storage, "ethereum", []models.BlockRange{
+		{Start: 20, End: 22},
+		{Start: 30, End: 31},
+		{Start: 40, End: 40},
+	})
+
+	t.Run("batch spanning stored runs", func(t *testing.T) {
+		batch := storage.NewBatch()
+		defer batch.Close()
+
+		for n := uint64(23); n <= 29; n++ {
+			block := models.NewBlock(models.ChainTypeEVM, "ethereum", n, fmt.Sprintf("0xhash%d", n))
+			if err := batch.SetBlock(ctx, block); err != nil {
+				t.Fatalf("SetBlock() error = %v", err)
+			}
+		}
+		for n := uint64(32); n <= 39; n++ {
+			block := models.NewBlock(models.ChainTypeEVM, "ethereum", n, fmt.Sprintf("0xhash%d", n))
+			if err := batch.SetBlock(ctx, block); err != nil {
+				t.Fatalf("SetBlock() error = %v", err)
+			}
+		}
+
+		if err := batch.Commit(); err != nil {
+			t.Fatalf("Commit() error = %v", err)
+		}
+
+		assertRanges(t, storage, "ethereum", []models.BlockRange{{Start: 20, End: 40}})
+	})
+
+	t.Run("uncommitted batch leaves ranges unchanged", func(t *testing.T) {
+		batch := storage.NewBatch()
+
+		block := models.NewBlock(models.ChainTypeEVM, "ethereum", 41, "0xhash41")
+		if err := batch.SetBlock(ctx, block); err != nil {
+			t.Fatalf("SetBlock() error = %v", err)
+		}
+		batch.Close()
+
+		assertRanges(t, storage, "ethereum", []models.BlockRange{{Start: 20, End: 40}})
+	})
+}
+
+func TestRangeRepo_DeleteBlock(t *testing.T) {
+	storage, tmpDir := setupTestDB(t)
+	defer cleanupTestDB(t, storage, tmpDir)
+
+	ctx := context.Background()
+
+	for n := uint64(1); n <= 10; n++ {
+		block := models.NewBlock(models.ChainTypeEVM, "ethereum", n, fmt.Sprintf("0xhash%d", n))
+		if err := storage.SaveBlock(ctx, block); err != nil {
+			t.Fatalf("SaveBlock() error = %v", err)
+		}
+	}
+
+	t.Run("delete inside a run splits it", func(t *testing.T) {
+		if err := storage.DeleteBlock(ctx, "ethereum", 5); err != nil {
+			t.Fatalf("DeleteBlock() error = %v", err)
+		}
+		assertRanges(t, storage, "ethereum", []models.BlockRange{{Start: 1, End: 4}, {Start: 6, End: 10}})
+	})
+
+	t.Run("delete the ends of runs", func(t *testing.T) {
+		for _, n := range []uint64{1, 4, 10} {
+			if err := storage.DeleteBlock(ctx, "ethereum", n); err != nil {
+				t.Fatalf("DeleteBlock(%d) error = %v", n, err)
+			}
+		}
+		assertRanges(t, storage, "ethereum", []models.BlockRange{{Start: 2, End: 3}, {Start: 6, End: 9}})
+	})
+
+	t.Run("delete a single block run", func(t *testing.T) {
+		for _, n := range []uint64{2, 3} {
+			if err := storage.DeleteBlock(ctx, "ethereum", n); err != nil {
+				t.Fatalf("DeleteBlock(%d) error = %v", n, err)
+			}
+		}
+		assertRanges(t, storage, "ethereum", []models.BlockRange{{Start: 6, End: 9}})
+	})
+}
+
+func TestToRanges(t *testing.T) {
+	got := toRanges([]uint64{7, 3, 1, 2, 3, 9, 8})
+	want := []*models.BlockRange{{Start: 1, End: 3}, {Start: 7, End: 9}}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("toRanges() = %v, want %v", got, want)
+	}
+}
+
+func TestSubtractRanges(t *testing.T) {
+	ranges := []*models.BlockRange{{Start: 1, End: 10}, {Start: 20, End: 30}}
+	removed := []*models.BlockRange{{Start: 1, End: 2}, {Start: 5, End: 5}, {Start: 9, End: 22}, {Start: 30, End: 40}}
+
+	got := subtractRanges(ranges, removed)
+	want := []*models.BlockRange{{Start: 3, End: 4}, {Start: 6, End: 8}, {Start: 23, End: 29}}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("subtractRanges() = %v, want %v", got, want)
+	}
+}
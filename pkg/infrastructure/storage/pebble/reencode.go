@@ -0,0 +1,149 @@
+package pebble
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/pebble"
+)
+
+// ReencodeStats summarizes a re-encoding run
+type ReencodeStats struct {
+	Blocks       uint64 // Blocks rewritten
+	Transactions uint64 // Transactions rewritten
+	Logs         uint64 // Logs rewritten
+	Transfers    uint64 // Token transfers rewritten
+	Skipped      uint64 // Records already in the target format
+	BytesBefore  uint64 // Size of rewritten records before
+	BytesAfter   uint64 // Size of rewritten records after
+}
+
+// Reencode rewrites every stored block, transaction, log and token transfer
+// that is not already in the storage's record format. It is safe to
+// interrupt and run again.
+func (s *PebbleStorage) Reencode(ctx context.Context) (*ReencodeStats, error) {
+	stats := &ReencodeStats{}
+
+	err := s.reencodePrefix(ctx, PrefixBlock, stats, func(data []byte) ([]byte, error) {
+		block, err := s.encoder.DecodeBlock(data)
+		if err != nil {
+			return nil, err
+		}
+		stats.Blocks++
+		return s.encoder.EncodeBlock(block)
+	})
+	if err != nil {
+		return stats, fmt.Errorf("failed to re-encode blocks: %w", err)
+	}
+
+	err = s.reencodePrefix(ctx, PrefixTx, stats, func(data []byte) ([]byte, error) {
+		tx, err := s.encoder.DecodeTransaction(data)
+		if err != nil {
+			return nil, err
+		}
+		stats.Transactions++
+		return s.encoder.EncodeTransaction(tx)
+	})
+	if err != nil {
+		return stats, fmt.Errorf("failed to re-encode transactions: %w", err)
+	}
+
+	err = s.reencodePrefix(ctx, PrefixLog, stats, func(data []byte) ([]byte, error) {
+		log, err := s.encoder.DecodeLog(data)
+		if err != nil {
+			return nil, err
+		}
+		stats.Logs++
+		return s.encoder.EncodeLog(log)
+	})
+	if err != nil {
+		return stats, fmt.Errorf("failed to re-encode logs: %w", err)
+	}
+
+	err = s.reencodePrefix(ctx, PrefixTransfer, stats, func(data []byte) ([]byte, error) {
+		transfer, err := s.encoder.DecodeTokenTransfer(data)
+		if err != nil {
+			return nil, err
+		}
+		stats.Transfers++
+		return s.encoder.EncodeTokenTransfer(transfer)
+	})
+	if err != nil {
+		return stats, fmt.Errorf("failed to re-encode token transfers: %w", err)
+	}
+
+	return stats, nil
+}
+
+// reencodePrefix rewrites the values under prefix with recode, committing
+// in batches
+func (s *PebbleStorage) reencodePrefix(ctx context.Context, prefix string, stats *ReencodeStats, recode func(data []byte) ([]byte, error)) error {
+	lower := []byte(prefix)
+
+	iter, err := s.db.NewIter(&pebble.IterOptions{
+		LowerBound: lower,
+		UpperBound: keyUpperBound(lower),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+
+	batch := s.db.NewBatch()
+	defer func() { batch.Close() }()
+	count := 0
+
+	for iter.First(); iter.Valid(); iter.Next() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		value := iter.Value()
+		if recordFormat(value) == s.encoder.format {
+			stats.Skipped++
+			continue
+		}
+
+		data, err := recode(value)
+		if err != nil {
+			return fmt.Errorf("failed to re-encode key %q: %w", iter.Key(), err)
+		}
+
+		if err := batch.Set(iter.Key(), data, pebble.NoSync); err != nil {
+			return fmt.Errorf("failed to batch set record: %w", err)
+		}
+		stats.BytesBefore += uint64(len(value))
+		stats.BytesAfter += uint64(len(data))
+		count++
+
+		// Commit in batches to avoid memory issues
+		if count >= migrationBatchSize {
+			if err := batch.Commit(pebble.Sync); err != nil {
+				return fmt.Errorf("failed to commit batch: %w", err)
+			}
+			batch.Close()
+			batch = s.db.NewBatch()
+			count = 0
+		}
+	}
+
+	if err := iter.Error(); err != nil {
+		return fmt.Errorf("iterator error: %w", err)
+	}
+
+	if count > 0 {
+		if err := batch.Commit(pebble.Sync); err != nil {
+			return fmt.Errorf("failed to commit final batch: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// RecordFormat returns a readable name of the format new blocks and
+// transactions are written in
+func (s *PebbleStorage) RecordFormat() string {
+	return s.encoder.Format()
+}
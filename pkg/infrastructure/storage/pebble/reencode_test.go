@@ -0,0 +1,77 @@
+package pebble
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+)
+
+func TestStorage_Reencode(t *testing.T) {
+	storage, tmpDir := setupTestDB(t)
+	defer cleanupTestDB(t, storage, tmpDir)
+
+	ctx := context.Background()
+
+	// Write records in the JSON format
+	jsonEncoder, err := NewEncoderWithFormat(CodecNameJSON, CompressionNone)
+	if err != nil {
+		t.Fatalf("NewEncoderWithFormat() error = %v", err)
+	}
+	jsonRepo := NewBlockRepo(storage.db, jsonEncoder, storage.RangeRepo)
+	jsonTxRepo := NewTransactionRepo(storage.db, jsonEncoder)
+
+	for i := uint64(1); i <= 5; i++ {
+		block := models.NewBlock(models.ChainTypeEVM, "ethereum", i, "0xhash")
+		if err := jsonRepo.SaveBlock(ctx, block); err != nil {
+			t.Fatalf("SaveBlock() error = %v", err)
+		}
+	}
+	tx := newTestTransaction()
+	if err := jsonTxRepo.SaveTransaction(ctx, tx); err != nil {
+		t.Fatalf("SaveTransaction() error = %v", err)
+	}
+
+	stats, err := storage.Reencode(ctx)
+	if err != nil {
+		t.Fatalf("Reencode() error = %v", err)
+	}
+	if stats.Blocks != 5 || stats.Transactions != 1 || stats.Logs != 1 {
+		t.Errorf("Reencode() rewrote %d blocks, %d transactions and %d logs, want 5, 1 and 1", stats.Blocks, stats.Transactions, stats.Logs)
+	}
+
+	// Records are now in the storage format and still readable
+	value, closer, err := storage.db.Get(BlockKey("ethereum", 3))
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	format := recordFormat(value)
+	closer.Close()
+	if format != storage.encoder.format {
+		t.Errorf("record format = %s, want %s", formatName(format), storage.RecordFormat())
+	}
+
+	got, err := storage.GetTransaction(ctx, "ethereum", tx.Hash)
+	if err != nil {
+		t.Fatalf("GetTransaction() error = %v", err)
+	}
+	if got.From != tx.From {
+		t.Errorf("GetTransaction() From = %v, want %v", got.From, tx.From)
+	}
+
+	// A second run has nothing to do
+	stats, err = storage.Reencode(ctx)
+	if err != nil {
+		t.Fatalf("second Reencode() error = %v", err)
+	}
+	if stats.Blocks != 0 || stats.Transactions != 0 || stats.Logs != 0 || stats.Skipped != 7 {
+		t.Errorf("second Reencode() = %+v, want only 7 skipped", stats)
+	}
+
+	// Index entries are left untouched
+	if _, closer, err := storage.db.Get(TransactionByBlockKey("ethereum", tx.BlockNumber, tx.Index)); err != nil {
+		t.Errorf("transaction-by-block index missing: %v", err)
+	} else {
+		closer.Close()
+	}
+}
@@ -1,80 +1,228 @@
 package pebble
 
 import (
+	"encoding/binary"
 	"fmt"
-	"strconv"
-	"strings"
 )
 
 // Key prefixes for different data types
+//
+// Composite keys use an order-preserving binary layout after the prefix:
+// chain IDs and addresses are written as a 2-byte big-endian length followed
+// by the raw bytes, and block numbers and transaction indexes as 8-byte
+// big-endian integers. Keys for the same chain therefore sort numerically and
+// one chain's keys can never be a prefix of another's.
 const (
 	// Block data prefixes
-	PrefixBlock     = "block:"      // block:{chainID}:{blockNumber}
-	PrefixBlockHash = "block_hash:" // block_hash:{chainID}:{hash}
+	PrefixBlock     = "block:"      // block:{chainID}{blockNumber}
+	PrefixBlockHash = "block_hash:" // block_hash:{chainID}{hash}
 
 	// Transaction data prefixes
-	PrefixTx          = "tx:"        // tx:{chainID}:{txHash}
-	PrefixTxByBlock   = "tx_block:"  // tx_block:{chainID}:{blockNumber}:{txIndex}
-	PrefixAddrTx      = "addr_tx:"   // addr_tx:{chainID}:{address}:{blockNumber}:{txIndex}
+	PrefixTx        = "tx:"       // tx:{chainID}{txHash}
+	PrefixTxByBlock = "tx_block:" // tx_block:{chainID}{blockNumber}{txIndex}
+	PrefixAddrTx    = "addr_tx:"  // addr_tx:{chainID}{address}{blockNumber}{txIndex}
+
+	// Event log prefixes
+	PrefixLog        = "log:"       // log:{chainID}{blockNumber}{txIndex}{logIndex}
+	PrefixLogAddress = "log_addr:"  // log_addr:{chainID}{address}{blockNumber}{txIndex}{logIndex}
+	PrefixLogTopic   = "log_topic:" // log_topic:{chainID}{topic0}{blockNumber}{txIndex}{logIndex}
+
+	// Token transfer prefixes
+	PrefixTransfer        = "transfer:"       // transfer:{chainID}{blockNumber}{txIndex}{logIndex}{batchIndex}
+	PrefixTransferAddress = "transfer_addr:"  // transfer_addr:{chainID}{address}{blockNumber}{txIndex}{logIndex}{batchIndex}
+	PrefixTransferToken   = "transfer_token:" // transfer_token:{chainID}{token}{blockNumber}{txIndex}{logIndex}{batchIndex}
+
+	// Indexed block ranges prefix
+	PrefixIndexedRange = "ranges:" // ranges:{chainID}{startBlock} -> endBlock
 
 	// Chain configuration prefix
 	PrefixChain = "chain:" // chain:{chainID}
 
 	// Metadata prefixes
-	PrefixLatestHeight = "latest:"  // latest:{chainID}
-	PrefixStats        = "stats:"   // stats:{chainID}
+	PrefixLatestHeight = "latest:" // latest:{chainID}
+	PrefixCursor       = "cursor:" // cursor:{chainID}
+	PrefixStats        = "stats:"  // stats:{chainID}
+	PrefixMeta         = "meta:"   // meta:{name}
 
-	// Separator for key components
+	// Separator between key components in the legacy text layout
 	KeySeparator = ":"
 )
 
+// Sizes of binary key components
+const (
+	lengthPrefixSize = 2 // uint16 length before chain IDs and addresses
+	uint64KeySize    = 8 // big-endian block numbers and transaction indexes
+)
+
+// appendString appends a length-prefixed string to a key
+func appendString(key []byte, s string) []byte {
+	key = binary.BigEndian.AppendUint16(key, uint16(len(s)))
+	return append(key, s...)
+}
+
+// appendUint64 appends a fixed-width big-endian integer to a key
+func appendUint64(key []byte, v uint64) []byte {
+	return binary.BigEndian.AppendUint64(key, v)
+}
+
+// newKey allocates a key starting with prefix and room for size more bytes
+func newKey(prefix string, size int) []byte {
+	key := make([]byte, 0, len(prefix)+size)
+	return append(key, prefix...)
+}
+
 // BlockKey generates a key for storing block data by block number
-// Format: block:{chainID}:{blockNumber}
+// Format: block:{chainID}{blockNumber}
 func BlockKey(chainID string, blockNumber uint64) []byte {
-	return []byte(fmt.Sprintf("%s%s%s%d",
-		PrefixBlock, chainID, KeySeparator, blockNumber))
+	key := newKey(PrefixBlock, lengthPrefixSize+len(chainID)+uint64KeySize)
+	key = appendString(key, chainID)
+	return appendUint64(key, blockNumber)
 }
 
 // BlockHashKey generates a key for storing block hash to number mapping
-// Format: block_hash:{chainID}:{hash}
+// Format: block_hash:{chainID}{hash}
 func BlockHashKey(chainID string, hash string) []byte {
-	return []byte(fmt.Sprintf("%s%s%s%s",
-		PrefixBlockHash, chainID, KeySeparator, hash))
+	key := newKey(PrefixBlockHash, lengthPrefixSize+len(chainID)+len(hash))
+	key = appendString(key, chainID)
+	return append(key, hash...)
 }
 
 // TransactionKey generates a key for storing transaction data
-// Format: tx:{chainID}:{txHash}
+// Format: tx:{chainID}{txHash}
 func TransactionKey(chainID string, txHash string) []byte {
-	return []byte(fmt.Sprintf("%s%s%s%s",
-		PrefixTx, chainID, KeySeparator, txHash))
+	key := newKey(PrefixTx, lengthPrefixSize+len(chainID)+len(txHash))
+	key = appendString(key, chainID)
+	return append(key, txHash...)
 }
 
 // TransactionByBlockKey generates a key for indexing transactions by block
-// Format: tx_block:{chainID}:{blockNumber}:{txIndex}
+// Format: tx_block:{chainID}{blockNumber}{txIndex}
 func TransactionByBlockKey(chainID string, blockNumber uint64, txIndex uint64) []byte {
-	return []byte(fmt.Sprintf("%s%s%s%d%s%d",
-		PrefixTxByBlock, chainID, KeySeparator, blockNumber, KeySeparator, txIndex))
+	key := TransactionByBlockPrefix(chainID, blockNumber)
+	return appendUint64(key, txIndex)
 }
 
 // TransactionByBlockPrefix generates a prefix for scanning all transactions in a block
-// Format: tx_block:{chainID}:{blockNumber}:
+// Format: tx_block:{chainID}{blockNumber}
 func TransactionByBlockPrefix(chainID string, blockNumber uint64) []byte {
-	return []byte(fmt.Sprintf("%s%s%s%d%s",
-		PrefixTxByBlock, chainID, KeySeparator, blockNumber, KeySeparator))
+	key := newKey(PrefixTxByBlock, lengthPrefixSize+len(chainID)+2*uint64KeySize)
+	key = appendString(key, chainID)
+	return appendUint64(key, blockNumber)
 }
 
 // AddressTxKey generates a key for indexing transactions by address
-// Format: addr_tx:{chainID}:{address}:{blockNumber}:{txIndex}
+// Format: addr_tx:{chainID}{address}{blockNumber}{txIndex}
 func AddressTxKey(chainID string, address string, blockNumber uint64, txIndex uint64) []byte {
-	return []byte(fmt.Sprintf("%s%s%s%s%s%d%s%d",
-		PrefixAddrTx, chainID, KeySeparator, address, KeySeparator, blockNumber, KeySeparator, txIndex))
+	key := AddressTxPrefix(chainID, address)
+	key = appendUint64(key, blockNumber)
+	return appendUint64(key, txIndex)
 }
 
 // AddressTxPrefix generates a prefix for scanning all transactions for an address
-// Format: addr_tx:{chainID}:{address}:
+// Format: addr_tx:{chainID}{address}
 func AddressTxPrefix(chainID string, address string) []byte {
-	return []byte(fmt.Sprintf("%s%s%s%s%s",
-		PrefixAddrTx, chainID, KeySeparator, address, KeySeparator))
+	key := newKey(PrefixAddrTx, 2*lengthPrefixSize+len(chainID)+len(address)+2*uint64KeySize)
+	key = appendString(key, chainID)
+	return appendString(key, address)
+}
+
+// LogKey generates a key for storing a log by its position in the chain
+// Format: log:{chainID}{blockNumber}{txIndex}{logIndex}
+func LogKey(chainID string, blockNumber uint64, txIndex uint64, logIndex uint64) []byte {
+	key := LogPrefix(chainID)
+	key = appendUint64(key, blockNumber)
+	key = appendUint64(key, txIndex)
+	return appendUint64(key, logIndex)
+}
+
+// LogPrefix generates a prefix for scanning all logs of a chain
+// Format: log:{chainID}
+func LogPrefix(chainID string) []byte {
+	key := newKey(PrefixLog, lengthPrefixSize+len(chainID)+3*uint64KeySize)
+	return appendString(key, chainID)
+}
+
+// LogAddressKey generates a key for indexing logs by emitting address
+// Format: log_addr:{chainID}{address}{blockNumber}{txIndex}{logIndex}
+func LogAddressKey(chainID string, address string, blockNumber uint64, txIndex uint64, logIndex uint64) []byte {
+	key := LogAddressPrefix(chainID, address)
+	key = appendUint64(key, blockNumber)
+	key = appendUint64(key, txIndex)
+	return appendUint64(key, logIndex)
+}
+
+// LogAddressPrefix generates a prefix for scanning all logs emitted by an address
+// Format: log_addr:{chainID}{address}
+func LogAddressPrefix(chainID string, address string) []byte {
+	key := newKey(PrefixLogAddress, 2*lengthPrefixSize+len(chainID)+len(address)+3*uint64KeySize)
+	key = appendString(key, chainID)
+	return appendString(key, address)
+}
+
+// LogTopicKey generates a key for indexing logs by their first topic
+// Format: log_topic:{chainID}{topic0}{blockNumber}{txIndex}{logIndex}
+func LogTopicKey(chainID string, topic string, blockNumber uint64, txIndex uint64, logIndex uint64) []byte {
+	key := LogTopicPrefix(chainID, topic)
+	key = appendUint64(key, blockNumber)
+	key = appendUint64(key, txIndex)
+	return appendUint64(key, logIndex)
+}
+
+// LogTopicPrefix generates a prefix for scanning all logs with a first topic
+// Format: log_topic:{chainID}{topic0}
+func LogTopicPrefix(chainID string, topic string) []byte {
+	key := newKey(PrefixLogTopic, 2*lengthPrefixSize+len(chainID)+len(topic)+3*uint64KeySize)
+	key = appendString(key, chainID)
+	return appendString(key, topic)
+}
+
+// TransferKey generates a key for storing a token transfer by its position in the chain
+// Format: transfer:{chainID}{blockNumber}{txIndex}{logIndex}{batchIndex}
+func TransferKey(chainID string, blockNumber uint64, txIndex uint64, logIndex uint64, batchIndex uint64) []byte {
+	return appendTransferPosition(TransferPrefix(chainID), blockNumber, txIndex, logIndex, batchIndex)
+}
+
+// TransferPrefix generates a prefix for scanning all token transfers of a chain
+// Format: transfer:{chainID}
+func TransferPrefix(chainID string) []byte {
+	key := newKey(PrefixTransfer, lengthPrefixSize+len(chainID)+4*uint64KeySize)
+	return appendString(key, chainID)
+}
+
+// TransferAddressKey generates a key for indexing token transfers by sending or receiving address
+// Format: transfer_addr:{chainID}{address}{blockNumber}{txIndex}{logIndex}{batchIndex}
+func TransferAddressKey(chainID string, address string, blockNumber uint64, txIndex uint64, logIndex uint64, batchIndex uint64) []byte {
+	return appendTransferPosition(TransferAddressPrefix(chainID, address), blockNumber, txIndex, logIndex, batchIndex)
+}
+
+// TransferAddressPrefix generates a prefix for scanning all token transfers of an address
+// Format: transfer_addr:{chainID}{address}
+func TransferAddressPrefix(chainID string, address string) []byte {
+	key := newKey(PrefixTransferAddress, 2*lengthPrefixSize+len(chainID)+len(address)+4*uint64KeySize)
+	key = appendString(key, chainID)
+	return appendString(key, address)
+}
+
+// TransferTokenKey generates a key for indexing token transfers by token contract
+// Format: transfer_token:{chainID}{token}{blockNumber}{txIndex}{logIndex}{batchIndex}
+func TransferTokenKey(chainID string, token string, blockNumber uint64, txIndex uint64, logIndex uint64, batchIndex uint64) []byte {
+	return appendTransferPosition(TransferTokenPrefix(chainID, token), blockNumber, txIndex, logIndex, batchIndex)
+}
+
+// TransferTokenPrefix generates a prefix for scanning all transfers of a token
+// Format: transfer_token:{chainID}{token}
+func TransferTokenPrefix(chainID string, token string) []byte {
+	key := newKey(PrefixTransferToken, 2*lengthPrefixSize+len(chainID)+len(token)+4*uint64KeySize)
+	key = appendString(key, chainID)
+	return appendString(key, token)
+}
+
+// appendTransferPosition appends the position of a token transfer to a key
+func appendTransferPosition(key []byte, blockNumber uint64, txIndex uint64, logIndex uint64, batchIndex uint64) []byte {
+	key = appendUint64(key, blockNumber)
+	key = appendUint64(key, txIndex)
+	key = appendUint64(key, logIndex)
+	return appendUint64(key, batchIndex)
 }
 
 // ChainKey generates a key for storing chain configuration
@@ -89,6 +237,12 @@ func LatestHeightKey(chainID string) []byte {
 	return []byte(fmt.Sprintf("%s%s", PrefixLatestHeight, chainID))
 }
 
+// CursorKey generates a key for storing the contiguously committed block height
+// Format: cursor:{chainID}
+func CursorKey(chainID string) []byte {
+	return []byte(fmt.Sprintf("%s%s", PrefixCursor, chainID))
+}
+
 // StatsKey generates a key for storing chain statistics
 // Format: stats:{chainID}
 func StatsKey(chainID string) []byte {
@@ -100,10 +254,32 @@ func ChainStatsKey(chainID string) []byte {
 	return StatsKey(chainID)
 }
 
+// SchemaVersionKey generates the key holding the on-disk key layout version
+// Format: meta:schema_version
+func SchemaVersionKey() []byte {
+	return []byte(PrefixMeta + "schema_version")
+}
+
 // BlockRangePrefix generates a prefix for scanning blocks in a range
-// Format: block:{chainID}:
+// Format: block:{chainID}
 func BlockRangePrefix(chainID string) []byte {
-	return []byte(fmt.Sprintf("%s%s%s", PrefixBlock, chainID, KeySeparator))
+	key := newKey(PrefixBlock, lengthPrefixSize+len(chainID)+uint64KeySize)
+	return appendString(key, chainID)
+}
+
+// IndexedRangeKey generates a key for a run of contiguously stored blocks
+// starting at startBlock. The value holds the last block of the run.
+// Format: ranges:{chainID}{startBlock}
+func IndexedRangeKey(chainID string, startBlock uint64) []byte {
+	key := IndexedRangePrefix(chainID)
+	return appendUint64(key, startBlock)
+}
+
+// IndexedRangePrefix generates a prefix for scanning a chain's indexed ranges
+// Format: ranges:{chainID}
+func IndexedRangePrefix(chainID string) []byte {
+	key := newKey(PrefixIndexedRange, lengthPrefixSize+len(chainID)+uint64KeySize)
+	return appendString(key, chainID)
 }
 
 // ChainPrefix generates a prefix for scanning all chains
@@ -112,48 +288,98 @@ func ChainPrefix() []byte {
 	return []byte(PrefixChain)
 }
 
-// ParseBlockKey parses a block key and extracts chainID and blockNumber
-func ParseBlockKey(key []byte) (chainID string, blockNumber uint64, err error) {
-	keyStr := string(key)
-	if !strings.HasPrefix(keyStr, PrefixBlock) {
-		return "", 0, fmt.Errorf("invalid block key prefix")
-	}
+// keyReader reads binary key components in order
+type keyReader struct {
+	key []byte
+	err error
+}
 
-	parts := strings.Split(keyStr[len(PrefixBlock):], KeySeparator)
-	if len(parts) != 2 {
-		return "", 0, fmt.Errorf("invalid block key format")
+// newKeyReader checks the key prefix and returns a reader positioned after it
+func newKeyReader(key []byte, prefix string, name string) *keyReader {
+	if len(key) < len(prefix) || string(key[:len(prefix)]) != prefix {
+		return &keyReader{err: fmt.Errorf("invalid %s key prefix", name)}
 	}
+	return &keyReader{key: key[len(prefix):]}
+}
 
-	chainID = parts[0]
-	blockNumber, err = strconv.ParseUint(parts[1], 10, 64)
-	if err != nil {
-		return "", 0, fmt.Errorf("invalid block number: %w", err)
+// string reads a length-prefixed string
+func (r *keyReader) string() string {
+	if r.err != nil {
+		return ""
+	}
+	if len(r.key) < lengthPrefixSize {
+		r.err = fmt.Errorf("key too short for length prefix")
+		return ""
 	}
+	n := int(binary.BigEndian.Uint16(r.key))
+	r.key = r.key[lengthPrefixSize:]
+	if len(r.key) < n {
+		r.err = fmt.Errorf("key too short for %d byte component", n)
+		return ""
+	}
+	s := string(r.key[:n])
+	r.key = r.key[n:]
+	return s
+}
 
-	return chainID, blockNumber, nil
+// uint64 reads a fixed-width big-endian integer
+func (r *keyReader) uint64() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	if len(r.key) < uint64KeySize {
+		r.err = fmt.Errorf("key too short for integer component")
+		return 0
+	}
+	v := binary.BigEndian.Uint64(r.key)
+	r.key = r.key[uint64KeySize:]
+	return v
 }
 
-// ParseTransactionByBlockKey parses a transaction-by-block key
-func ParseTransactionByBlockKey(key []byte) (chainID string, blockNumber uint64, txIndex uint64, err error) {
-	keyStr := string(key)
-	if !strings.HasPrefix(keyStr, PrefixTxByBlock) {
-		return "", 0, 0, fmt.Errorf("invalid transaction-by-block key prefix")
+// done returns the first read error, or an error if bytes are left over
+func (r *keyReader) done() error {
+	if r.err != nil {
+		return r.err
+	}
+	if len(r.key) != 0 {
+		return fmt.Errorf("unexpected %d trailing key bytes", len(r.key))
 	}
+	return nil
+}
 
-	parts := strings.Split(keyStr[len(PrefixTxByBlock):], KeySeparator)
-	if len(parts) != 3 {
-		return "", 0, 0, fmt.Errorf("invalid transaction-by-block key format")
+// ParseBlockKey parses a block key and extracts chainID and blockNumber
+func ParseBlockKey(key []byte) (chainID string, blockNumber uint64, err error) {
+	r := newKeyReader(key, PrefixBlock, "block")
+	chainID = r.string()
+	blockNumber = r.uint64()
+	if err := r.done(); err != nil {
+		return "", 0, fmt.Errorf("invalid block key format: %w", err)
 	}
 
-	chainID = parts[0]
-	blockNumber, err = strconv.ParseUint(parts[1], 10, 64)
-	if err != nil {
-		return "", 0, 0, fmt.Errorf("invalid block number: %w", err)
+	return chainID, blockNumber, nil
+}
+
+// ParseIndexedRangeKey parses an indexed range key and extracts chainID and
+// the first block of the range
+func ParseIndexedRangeKey(key []byte) (chainID string, startBlock uint64, err error) {
+	r := newKeyReader(key, PrefixIndexedRange, "indexed range")
+	chainID = r.string()
+	startBlock = r.uint64()
+	if err := r.done(); err != nil {
+		return "", 0, fmt.Errorf("invalid indexed range key format: %w", err)
 	}
 
-	txIndex, err = strconv.ParseUint(parts[2], 10, 64)
-	if err != nil {
-		return "", 0, 0, fmt.Errorf("invalid transaction index: %w", err)
+	return chainID, startBlock, nil
+}
+
+// ParseTransactionByBlockKey parses a transaction-by-block key
+func ParseTransactionByBlockKey(key []byte) (chainID string, blockNumber uint64, txIndex uint64, err error) {
+	r := newKeyReader(key, PrefixTxByBlock, "transaction-by-block")
+	chainID = r.string()
+	blockNumber = r.uint64()
+	txIndex = r.uint64()
+	if err := r.done(); err != nil {
+		return "", 0, 0, fmt.Errorf("invalid transaction-by-block key format: %w", err)
 	}
 
 	return chainID, blockNumber, txIndex, nil
@@ -161,27 +387,71 @@ func ParseTransactionByBlockKey(key []byte) (chainID string, blockNumber uint64,
 
 // ParseAddressTxKey parses an address transaction key
 func ParseAddressTxKey(key []byte) (chainID string, address string, blockNumber uint64, txIndex uint64, err error) {
-	keyStr := string(key)
-	if !strings.HasPrefix(keyStr, PrefixAddrTx) {
-		return "", "", 0, 0, fmt.Errorf("invalid address-tx key prefix")
+	r := newKeyReader(key, PrefixAddrTx, "address-tx")
+	chainID = r.string()
+	address = r.string()
+	blockNumber = r.uint64()
+	txIndex = r.uint64()
+	if err := r.done(); err != nil {
+		return "", "", 0, 0, fmt.Errorf("invalid address-tx key format: %w", err)
+	}
+
+	return chainID, address, blockNumber, txIndex, nil
+}
+
+// ParseLogKey parses a log key and extracts chainID and the log's position
+func ParseLogKey(key []byte) (chainID string, blockNumber uint64, txIndex uint64, logIndex uint64, err error) {
+	r := newKeyReader(key, PrefixLog, "log")
+	chainID = r.string()
+	blockNumber = r.uint64()
+	txIndex = r.uint64()
+	logIndex = r.uint64()
+	if err := r.done(); err != nil {
+		return "", 0, 0, 0, fmt.Errorf("invalid log key format: %w", err)
+	}
+
+	return chainID, blockNumber, txIndex, logIndex, nil
+}
+
+// ParseLogIndexKey parses a log address or topic index key and extracts
+// chainID, the indexed value and the log's position
+func ParseLogIndexKey(key []byte) (chainID string, value string, blockNumber uint64, txIndex uint64, logIndex uint64, err error) {
+	prefix := PrefixLogAddress
+	if len(key) >= len(PrefixLogTopic) && string(key[:len(PrefixLogTopic)]) == PrefixLogTopic {
+		prefix = PrefixLogTopic
 	}
 
-	parts := strings.Split(keyStr[len(PrefixAddrTx):], KeySeparator)
-	if len(parts) != 4 {
-		return "", "", 0, 0, fmt.Errorf("invalid address-tx key format")
+	r := newKeyReader(key, prefix, "log index")
+	chainID = r.string()
+	value = r.string()
+	blockNumber = r.uint64()
+	txIndex = r.uint64()
+	logIndex = r.uint64()
+	if err := r.done(); err != nil {
+		return "", "", 0, 0, 0, fmt.Errorf("invalid log index key format: %w", err)
 	}
 
-	chainID = parts[0]
-	address = parts[1]
-	blockNumber, err = strconv.ParseUint(parts[2], 10, 64)
-	if err != nil {
-		return "", "", 0, 0, fmt.Errorf("invalid block number: %w", err)
+	return chainID, value, blockNumber, txIndex, logIndex, nil
+}
+
+// ParseTransferIndexKey parses a token transfer address or token index key
+// and extracts chainID, the indexed value and the transfer's position
+func ParseTransferIndexKey(key []byte) (chainID string, value string, blockNumber uint64, txIndex uint64, logIndex uint64, batchIndex uint64, err error) {
+	prefix := PrefixTransferAddress
+	if len(key) >= len(PrefixTransferToken) && string(key[:len(PrefixTransferToken)]) == PrefixTransferToken {
+		prefix = PrefixTransferToken
 	}
 
-	txIndex, err = strconv.ParseUint(parts[3], 10, 64)
-	if err != nil {
-		return "", "", 0, 0, fmt.Errorf("invalid transaction index: %w", err)
+	r := newKeyReader(key, prefix, "transfer index")
+	chainID = r.string()
+	value = r.string()
+	blockNumber = r.uint64()
+	txIndex = r.uint64()
+	logIndex = r.uint64()
+	batchIndex = r.uint64()
+	if err := r.done(); err != nil {
+		return "", "", 0, 0, 0, 0, fmt.Errorf("invalid transfer index key format: %w", err)
 	}
 
-	return chainID, address, blockNumber, txIndex, nil
+	return chainID, value, blockNumber, txIndex, logIndex, batchIndex, nil
 }
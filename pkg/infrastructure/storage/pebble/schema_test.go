@@ -16,19 +16,19 @@ func TestBlockKey(t *testing.T) {
 			name:        "ethereum block 0",
 			chainID:     "ethereum",
 			blockNumber: 0,
-			want:        "block:ethereum:0",
+			want:        "block:\x00\x08ethereum\x00\x00\x00\x00\x00\x00\x00\x00",
 		},
 		{
 			name:        "ethereum block 12345",
 			chainID:     "ethereum",
 			blockNumber: 12345,
-			want:        "block:ethereum:12345",
+			want:        "block:\x00\x08ethereum\x00\x00\x00\x00\x00\x0009",
 		},
 		{
 			name:        "solana block 1000000",
 			chainID:     "solana",
 			blockNumber: 1000000,
-			want:        "block:solana:1000000",
+			want:        "block:\x00\x06solana\x00\x00\x00\x00\x00\x0fB@",
 		},
 	}
 
@@ -42,6 +42,22 @@ func TestBlockKey(t *testing.T) {
 	}
 }
 
+func TestBlockKey_Ordering(t *testing.T) {
+	numbers := []uint64{0, 1, 9, 10, 99, 100, 255, 256, 1 << 32, 1<<64 - 1}
+	for i := 1; i < len(numbers); i++ {
+		prev := BlockKey("ethereum", numbers[i-1])
+		next := BlockKey("ethereum", numbers[i])
+		if bytes.Compare(prev, next) >= 0 {
+			t.Errorf("BlockKey(%d) should sort before BlockKey(%d)", numbers[i-1], numbers[i])
+		}
+	}
+
+	// A chain ID extending another must not fall under its prefix
+	if bytes.HasPrefix(BlockKey("ethereum2", 1), BlockRangePrefix("ethereum")) {
+		t.Error("block key of ethereum2 should not have the ethereum range prefix")
+	}
+}
+
 func TestBlockHashKey(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -53,7 +69,7 @@ func TestBlockHashKey(t *testing.T) {
 			name:    "ethereum block hash",
 			chainID: "ethereum",
 			hash:    "0xabc123",
-			want:    "block_hash:ethereum:0xabc123",
+			want:    "block_hash:\x00\x08ethereum0xabc123",
 		},
 	}
 
@@ -78,7 +94,7 @@ func TestTransactionKey(t *testing.T) {
 			name:    "ethereum transaction",
 			chainID: "ethereum",
 			txHash:  "0xtx123",
-			want:    "tx:ethereum:0xtx123",
+			want:    "tx:\x00\x08ethereum0xtx123",
 		},
 	}
 
@@ -105,14 +121,14 @@ func TestTransactionByBlockKey(t *testing.T) {
 			chainID:     "ethereum",
 			blockNumber: 12345,
 			txIndex:     0,
-			want:        "tx_block:ethereum:12345:0",
+			want:        "tx_block:\x00\x08ethereum\x00\x00\x00\x00\x00\x0009\x00\x00\x00\x00\x00\x00\x00\x00",
 		},
 		{
 			name:        "tenth transaction",
 			chainID:     "ethereum",
 			blockNumber: 12345,
 			txIndex:     9,
-			want:        "tx_block:ethereum:12345:9",
+			want:        "tx_block:\x00\x08ethereum\x00\x00\x00\x00\x00\x0009\x00\x00\x00\x00\x00\x00\x00\x09",
 		},
 	}
 
@@ -129,7 +145,7 @@ func TestTransactionByBlockKey(t *testing.T) {
 func TestTransactionByBlockPrefix(t *testing.T) {
 	chainID := "ethereum"
 	blockNumber := uint64(12345)
-	want := "tx_block:ethereum:12345:"
+	want := "tx_block:\x00\x08ethereum\x00\x00\x00\x00\x00\x0009"
 
 	got := TransactionByBlockPrefix(chainID, blockNumber)
 	if string(got) != want {
@@ -158,7 +174,7 @@ func TestAddressTxKey(t *testing.T) {
 			address:     "0xabc",
 			blockNumber: 12345,
 			txIndex:     0,
-			want:        "addr_tx:ethereum:0xabc:12345:0",
+			want:        "addr_tx:\x00\x08ethereum\x00\x050xabc\x00\x00\x00\x00\x00\x0009\x00\x00\x00\x00\x00\x00\x00\x00",
 		},
 	}
 
@@ -175,7 +191,7 @@ func TestAddressTxKey(t *testing.T) {
 func TestAddressTxPrefix(t *testing.T) {
 	chainID := "ethereum"
 	address := "0xabc"
-	want := "addr_tx:ethereum:0xabc:"
+	want := "addr_tx:\x00\x08ethereum\x00\x050xabc"
 
 	got := AddressTxPrefix(chainID, address)
 	if string(got) != want {
@@ -209,6 +225,16 @@ func TestLatestHeightKey(t *testing.T) {
 	}
 }
 
+func TestCursorKey(t *testing.T) {
+	chainID := "ethereum"
+	want := "cursor:ethereum"
+
+	got := CursorKey(chainID)
+	if string(got) != want {
+		t.Errorf("CursorKey() = %v, want %v", string(got), want)
+	}
+}
+
 func TestParseBlockKey(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -376,3 +402,93 @@ func BenchmarkParseBlockKey(b *testing.B) {
 		_, _, _ = ParseBlockKey(key)
 	}
 }
+
+func TestParseLogIndexKey(t *testing.T) {
+	tests := []struct {
+		name      string
+		key       []byte
+		wantValue string
+		wantErr   bool
+	}{
+		{
+			name:      "address index key",
+			key:       LogAddressKey("ethereum", "0xabc", 12345, 9, 4),
+			wantValue: "0xabc",
+		},
+		{
+			name:      "topic index key",
+			key:       LogTopicKey("ethereum", "0xddf2", 12345, 9, 4),
+			wantValue: "0xddf2",
+		},
+		{
+			name:    "log key",
+			key:     LogKey("ethereum", 12345, 9, 4),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chainID, value, block, txIndex, logIndex, err := ParseLogIndexKey(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseLogIndexKey() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if chainID != "ethereum" || value != tt.wantValue || block != 12345 || txIndex != 9 || logIndex != 4 {
+				t.Errorf("ParseLogIndexKey() = %v, %v, %v, %v, %v", chainID, value, block, txIndex, logIndex)
+			}
+		})
+	}
+
+	chainID, block, txIndex, logIndex, err := ParseLogKey(LogKey("ethereum", 12345, 9, 4))
+	if err != nil {
+		t.Fatalf("ParseLogKey() error = %v", err)
+	}
+	if chainID != "ethereum" || block != 12345 || txIndex != 9 || logIndex != 4 {
+		t.Errorf("ParseLogKey() = %v, %v, %v, %v", chainID, block, txIndex, logIndex)
+	}
+}
+
+func TestParseTransferIndexKey(t *testing.T) {
+	tests := []struct {
+		name      string
+		key       []byte
+		wantValue string
+		wantErr   bool
+	}{
+		{
+			name:      "address index key",
+			key:       TransferAddressKey("ethereum", "0xabc", 12345, 9, 4, 2),
+			wantValue: "0xabc",
+		},
+		{
+			name:      "token index key",
+			key:       TransferTokenKey("ethereum", "0xdef", 12345, 9, 4, 2),
+			wantValue: "0xdef",
+		},
+		{
+			name:    "transfer key",
+			key:     TransferKey("ethereum", 12345, 9, 4, 2),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chainID, value, block, txIndex, logIndex, batchIndex, err := ParseTransferIndexKey(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseTransferIndexKey() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if chainID != "ethereum" || value != tt.wantValue || block != 12345 || txIndex != 9 || logIndex != 4 || batchIndex != 2 {
+				t.Errorf("ParseTransferIndexKey() = %v, %v, %v, %v, %v, %v", chainID, value, block, txIndex, logIndex, batchIndex)
+			}
+		})
+	}
+}
@@ -21,7 +21,11 @@ type PebbleStorage struct {
 	// Embedded repository implementations
 	*BlockRepo
 	*TransactionRepo
+	*LogRepo
+	*TokenTransferRepo
 	*ChainRepo
+	*CursorRepo
+	*RangeRepo
 }
 
 // Config holds PebbleDB configuration
@@ -36,6 +40,10 @@ type Config struct {
 	DisableWAL        bool  // Disable write-ahead log (default: false)
 	BytesPerSync      int   // Bytes to write before syncing (default: 512KB)
 
+	// Record format for blocks and transactions
+	Codec       string // "cbor" or "json" (default: cbor)
+	Compression string // "zstd" or "none" (default: zstd)
+
 	// Custom logger
 	Logger pebble.Logger
 }
@@ -95,6 +103,12 @@ func NewStorage(config *Config) (*PebbleStorage, error) {
 		return nil, fmt.Errorf("failed to create database directory: %w", err)
 	}
 
+	// Create encoder
+	encoder, err := NewEncoderWithFormat(config.Codec, config.Compression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid record format: %w", err)
+	}
+
 	// Configure PebbleDB options
 	opts := &pebble.Options{
 		Cache:                       pebble.NewCache(config.CacheSize),
@@ -112,9 +126,6 @@ func NewStorage(config *Config) (*PebbleStorage, error) {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	// Create encoder
-	encoder := NewEncoder()
-
 	// Create storage instance
 	storage := &PebbleStorage{
 		db:         db,
@@ -124,9 +135,19 @@ func NewStorage(config *Config) (*PebbleStorage, error) {
 	}
 
 	// Initialize repositories
-	storage.BlockRepo = NewBlockRepo(db, encoder)
+	storage.RangeRepo = NewRangeRepo(db, encoder)
+	storage.BlockRepo = NewBlockRepo(db, encoder, storage.RangeRepo)
 	storage.TransactionRepo = NewTransactionRepo(db, encoder)
+	storage.LogRepo = NewLogRepo(db, encoder)
+	storage.TokenTransferRepo = NewTokenTransferRepo(db, encoder)
 	storage.ChainRepo = NewChainRepo(db, encoder)
+	storage.CursorRepo = NewCursorRepo(db, encoder)
+
+	// Bring older databases up to the current key layout
+	if err := storage.Migrate(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
 
 	return storage, nil
 }
@@ -153,7 +174,7 @@ func (s *PebbleStorage) Close() error {
 
 // NewBatch creates a new batch for atomic operations
 func (s *PebbleStorage) NewBatch() repository.Batch {
-	return NewBatch(s.db, s.encoder)
+	return NewBatch(s.db, s.encoder, s.RangeRepo)
 }
 
 // GetStats returns storage statistics
@@ -0,0 +1,258 @@
+package pebble
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+)
+
+// TokenTransferRepo implements the TokenTransferRepository interface using
+// PebbleDB. Like logs, each transfer is stored once under its position in
+// the chain and the address and token indexes are bare keys that end in the
+// same position.
+type TokenTransferRepo struct {
+	db      *pebble.DB
+	encoder *Encoder
+}
+
+// NewTokenTransferRepo creates a new token transfer repository
+func NewTokenTransferRepo(db *pebble.DB, encoder *Encoder) *TokenTransferRepo {
+	return &TokenTransferRepo{
+		db:      db,
+		encoder: encoder,
+	}
+}
+
+// QueryTokenTransfers returns the transfers matching the filter in chain
+// order. Transfers are looked up through the address index when an address
+// is given, through the token index when a token is given, and otherwise by
+// scanning the chain's transfers in the block range.
+func (r *TokenTransferRepo) QueryTokenTransfers(ctx context.Context, filter *models.TokenTransferFilter, pagination *models.PaginationOptions) ([]*models.TokenTransfer, error) {
+	if filter == nil {
+		return nil, fmt.Errorf("filter cannot be nil")
+	}
+
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	if pagination == nil {
+		pagination = models.DefaultPaginationOptions()
+	}
+
+	if err := pagination.Validate(); err != nil {
+		return nil, err
+	}
+
+	transfers := make([]*models.TokenTransfer, 0)
+	skipped := 0
+	visit := func(transfer *models.TokenTransfer) bool {
+		if !filter.Matches(transfer) {
+			return true
+		}
+		if skipped < pagination.Offset {
+			skipped++
+			return true
+		}
+		transfers = append(transfers, transfer)
+		return len(transfers) < pagination.Limit
+	}
+
+	var err error
+	switch {
+	case filter.Address != nil:
+		err = r.scanIndex(ctx, filter, TransferAddressPrefix(filter.ChainID, indexValue(*filter.Address)), visit)
+	case filter.Token != nil:
+		err = r.scanIndex(ctx, filter, TransferTokenPrefix(filter.ChainID, indexValue(*filter.Token)), visit)
+	default:
+		err = r.scanTransfers(ctx, filter, visit)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return transfers, nil
+}
+
+// scanTransfers visits the chain's stored transfers in the filter's block
+// range until visit returns false
+func (r *TokenTransferRepo) scanTransfers(ctx context.Context, filter *models.TokenTransferFilter, visit func(*models.TokenTransfer) bool) error {
+	lower, upper := blockBounds(TransferPrefix(filter.ChainID), filter.FromBlock, filter.ToBlock)
+
+	iter, err := r.db.NewIter(&pebble.IterOptions{
+		LowerBound: lower,
+		UpperBound: upper,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+
+	for iter.First(); iter.Valid(); iter.Next() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		transfer, err := r.encoder.DecodeTokenTransfer(iter.Value())
+		if err != nil {
+			return fmt.Errorf("failed to decode token transfer: %w", err)
+		}
+		if !visit(transfer) {
+			break
+		}
+	}
+
+	if err := iter.Error(); err != nil {
+		return fmt.Errorf("iterator error: %w", err)
+	}
+
+	return nil
+}
+
+// scanIndex visits the transfers referenced by the index entries under
+// prefix in the filter's block range until visit returns false
+func (r *TokenTransferRepo) scanIndex(ctx context.Context, filter *models.TokenTransferFilter, prefix []byte, visit func(*models.TokenTransfer) bool) error {
+	lower, upper := blockBounds(prefix, filter.FromBlock, filter.ToBlock)
+
+	iter, err := r.db.NewIter(&pebble.IterOptions{
+		LowerBound: lower,
+		UpperBound: upper,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create iterator: %w", err)
+	}
+	defer iter.Close()
+
+	for iter.First(); iter.Valid(); iter.Next() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+
+		_, _, blockNumber, txIndex, logIndex, batchIndex, err := ParseTransferIndexKey(iter.Key())
+		if err != nil {
+			return err
+		}
+
+		transfer, err := r.getTransfer(filter.ChainID, blockNumber, txIndex, logIndex, batchIndex)
+		if err != nil {
+			return err
+		}
+		if transfer == nil {
+			// Index entry left behind by a deleted transfer
+			continue
+		}
+		if !visit(transfer) {
+			break
+		}
+	}
+
+	if err := iter.Error(); err != nil {
+		return fmt.Errorf("iterator error: %w", err)
+	}
+
+	return nil
+}
+
+// getTransfer reads a stored transfer, returning nil if it does not exist
+func (r *TokenTransferRepo) getTransfer(chainID string, blockNumber, txIndex, logIndex, batchIndex uint64) (*models.TokenTransfer, error) {
+	value, closer, err := r.db.Get(TransferKey(chainID, blockNumber, txIndex, logIndex, batchIndex))
+	if err != nil {
+		if err == pebble.ErrNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get token transfer: %w", err)
+	}
+	defer closer.Close()
+
+	transfer, err := r.encoder.DecodeTokenTransfer(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode token transfer: %w", err)
+	}
+
+	return transfer, nil
+}
+
+// setTokenTransfers stages the token transfers of tx and their index
+// entries into w and returns the number of keys written
+func setTokenTransfers(w pebble.Writer, encoder *Encoder, tx *models.Transaction) (int, error) {
+	count := 0
+	for _, transfer := range tx.TokenTransfers {
+		if transfer == nil {
+			continue
+		}
+
+		// Stored transfers carry the position of the transaction that emitted them
+		record := *transfer
+		record.ChainID = tx.ChainID
+		record.BlockNumber = tx.BlockNumber
+		record.BlockHash = tx.BlockHash
+		record.TxHash = tx.Hash
+		record.TxIndex = tx.Index
+
+		data, err := encoder.EncodeTokenTransfer(&record)
+		if err != nil {
+			return count, fmt.Errorf("failed to encode token transfer %d/%d: %w", transfer.LogIndex, transfer.BatchIndex, err)
+		}
+
+		key := TransferKey(tx.ChainID, tx.BlockNumber, tx.Index, transfer.LogIndex, transfer.BatchIndex)
+		if err := w.Set(key, data, pebble.Sync); err != nil {
+			return count, fmt.Errorf("failed to set token transfer %d/%d: %w", transfer.LogIndex, transfer.BatchIndex, err)
+		}
+		count++
+
+		for _, key := range transferIndexKeys(tx, transfer) {
+			if err := w.Set(key, nil, pebble.Sync); err != nil {
+				return count, fmt.Errorf("failed to set token transfer index: %w", err)
+			}
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// deleteTokenTransfers stages the removal of the token transfers of tx and
+// their index entries into w
+func deleteTokenTransfers(w pebble.Writer, tx *models.Transaction) error {
+	for _, transfer := range tx.TokenTransfers {
+		if transfer == nil {
+			continue
+		}
+
+		key := TransferKey(tx.ChainID, tx.BlockNumber, tx.Index, transfer.LogIndex, transfer.BatchIndex)
+		if err := w.Delete(key, pebble.Sync); err != nil {
+			return fmt.Errorf("failed to delete token transfer %d/%d: %w", transfer.LogIndex, transfer.BatchIndex, err)
+		}
+
+		for _, key := range transferIndexKeys(tx, transfer) {
+			if err := w.Delete(key, pebble.Sync); err != nil {
+				return fmt.Errorf("failed to delete token transfer index: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// transferIndexKeys returns the index keys of a transfer: one for the token
+// and one for each distinct party
+func transferIndexKeys(tx *models.Transaction, transfer *models.TokenTransfer) [][]byte {
+	keys := make([][]byte, 0, 3)
+	if transfer.Token != "" {
+		keys = append(keys, TransferTokenKey(tx.ChainID, indexValue(transfer.Token), tx.BlockNumber, tx.Index, transfer.LogIndex, transfer.BatchIndex))
+	}
+	if transfer.From != "" {
+		keys = append(keys, TransferAddressKey(tx.ChainID, indexValue(transfer.From), tx.BlockNumber, tx.Index, transfer.LogIndex, transfer.BatchIndex))
+	}
+	if transfer.To != "" && !strings.EqualFold(transfer.To, transfer.From) {
+		keys = append(keys, TransferAddressKey(tx.ChainID, indexValue(transfer.To), tx.BlockNumber, tx.Index, transfer.LogIndex, transfer.BatchIndex))
+	}
+	return keys
+}
@@ -0,0 +1,189 @@
+package pebble
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+)
+
+const (
+	testAlice = "0x1111111111111111111111111111111111111111"
+	testBob   = "0x2222222222222222222222222222222222222222"
+	testNFT   = "0xBC4CA0EdA7647A8aB7C2061c2E118A18a936f13D"
+)
+
+// newTransferTransaction creates a transaction in block carrying token transfers
+func newTransferTransaction(block, index uint64, transfers ...*models.TokenTransfer) *models.Transaction {
+	tx := newLogTransaction(block, index)
+	tx.TokenTransfers = transfers
+	return tx
+}
+
+// transferPositions returns the block, tx index, log index and batch index of each transfer
+func transferPositions(transfers []*models.TokenTransfer) [][4]uint64 {
+	positions := make([][4]uint64, len(transfers))
+	for i, transfer := range transfers {
+		positions[i] = [4]uint64{transfer.BlockNumber, transfer.TxIndex, transfer.LogIndex, transfer.BatchIndex}
+	}
+	return positions
+}
+
+func TestTokenTransferRepo_QueryTokenTransfers(t *testing.T) {
+	storage, tmpDir := setupTestDB(t)
+	defer cleanupTestDB(t, storage, tmpDir)
+
+	ctx := context.Background()
+
+	txs := []*models.Transaction{
+		newTransferTransaction(10, 0,
+			&models.TokenTransfer{Standard: models.TokenStandardERC20, Token: testToken, From: testAlice, To: testBob, Value: "100", LogIndex: 0},
+		),
+		newTransferTransaction(10, 1,
+			&models.TokenTransfer{Standard: models.TokenStandardERC721, Token: testNFT, From: testBob, To: testAlice, Value: "1", TokenID: "7", LogIndex: 1},
+		),
+		newTransferTransaction(11, 0,
+			&models.TokenTransfer{Standard: models.TokenStandardERC1155, Token: testOtherToken, From: testAlice, To: testAlice, Value: "2", TokenID: "1", LogIndex: 0},
+			&models.TokenTransfer{Standard: models.TokenStandardERC1155, Token: testOtherToken, From: testAlice, To: testAlice, Value: "3", TokenID: "2", LogIndex: 0, BatchIndex: 1},
+		),
+		newTransferTransaction(12, 2,
+			&models.TokenTransfer{Standard: models.TokenStandardERC20, Token: testToken, From: testBob, To: testBob, Value: "5", LogIndex: 4},
+		),
+	}
+	if err := storage.SaveTransactions(ctx, txs[:2]); err != nil {
+		t.Fatalf("SaveTransactions() error = %v", err)
+	}
+	batch := storage.NewBatch()
+	defer batch.Close()
+	if err := batch.SetTransactions(ctx, txs[2:]); err != nil {
+		t.Fatalf("SetTransactions() error = %v", err)
+	}
+	if err := batch.Commit(); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+
+	str := func(s string) *string { return &s }
+	num := func(n uint64) *uint64 { return &n }
+	standard := func(s models.TokenStandard) *models.TokenStandard { return &s }
+
+	tests := []struct {
+		name       string
+		filter     *models.TokenTransferFilter
+		pagination *models.PaginationOptions
+		want       [][4]uint64
+		wantErr    bool
+	}{
+		{
+			name:   "all transfers",
+			filter: &models.TokenTransferFilter{ChainID: "ethereum"},
+			want:   [][4]uint64{{10, 0, 0, 0}, {10, 1, 1, 0}, {11, 0, 0, 0}, {11, 0, 0, 1}, {12, 2, 4, 0}},
+		},
+		{
+			name:   "sent or received by address",
+			filter: &models.TokenTransferFilter{ChainID: "ethereum", Address: str(testAlice)},
+			want:   [][4]uint64{{10, 0, 0, 0}, {10, 1, 1, 0}, {11, 0, 0, 0}, {11, 0, 0, 1}},
+		},
+		{
+			name:   "token ignores case",
+			filter: &models.TokenTransferFilter{ChainID: "ethereum", Token: str("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")},
+			want:   [][4]uint64{{10, 0, 0, 0}, {12, 2, 4, 0}},
+		},
+		{
+			name:   "address and token",
+			filter: &models.TokenTransferFilter{ChainID: "ethereum", Address: str(testBob), Token: str(testToken)},
+			want:   [][4]uint64{{10, 0, 0, 0}, {12, 2, 4, 0}},
+		},
+		{
+			name:   "standard",
+			filter: &models.TokenTransferFilter{ChainID: "ethereum", Standard: standard(models.TokenStandardERC721)},
+			want:   [][4]uint64{{10, 1, 1, 0}},
+		},
+		{
+			name:   "address in block range",
+			filter: &models.TokenTransferFilter{ChainID: "ethereum", Address: str(testBob), FromBlock: num(11)},
+			want:   [][4]uint64{{12, 2, 4, 0}},
+		},
+		{
+			name:       "pagination",
+			filter:     &models.TokenTransferFilter{ChainID: "ethereum", Address: str(testAlice)},
+			pagination: &models.PaginationOptions{Limit: 2, Offset: 1},
+			want:       [][4]uint64{{10, 1, 1, 0}, {11, 0, 0, 0}},
+		},
+		{
+			name:   "other chain",
+			filter: &models.TokenTransferFilter{ChainID: "polygon", Address: str(testAlice)},
+			want:   [][4]uint64{},
+		},
+		{
+			name:    "invalid standard",
+			filter:  &models.TokenTransferFilter{ChainID: "ethereum", Standard: standard("bep20")},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transfers, err := storage.QueryTokenTransfers(ctx, tt.filter, tt.pagination)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("QueryTokenTransfers() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := transferPositions(transfers); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("QueryTokenTransfers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("transfers carry their transaction", func(t *testing.T) {
+		transfers, err := storage.QueryTokenTransfers(ctx, &models.TokenTransferFilter{ChainID: "ethereum", Token: str(testNFT)}, nil)
+		if err != nil {
+			t.Fatalf("QueryTokenTransfers() error = %v", err)
+		}
+		if len(transfers) != 1 {
+			t.Fatalf("QueryTokenTransfers() returned %d transfers, want 1", len(transfers))
+		}
+		transfer := transfers[0]
+		if transfer.ChainID != "ethereum" || transfer.TxHash != "0xtx10_1" || transfer.BlockHash != "0xblock10" {
+			t.Errorf("QueryTokenTransfers() transfer = %+v", transfer)
+		}
+		if transfer.TokenID != "7" || transfer.Value != "1" {
+			t.Errorf("QueryTokenTransfers() TokenID = %s, Value = %s, want 7 and 1", transfer.TokenID, transfer.Value)
+		}
+	})
+}
+
+func TestTokenTransferRepo_DeleteTransaction(t *testing.T) {
+	storage, tmpDir := setupTestDB(t)
+	defer cleanupTestDB(t, storage, tmpDir)
+
+	ctx := context.Background()
+
+	tx := newTransferTransaction(10, 0,
+		&models.TokenTransfer{Standard: models.TokenStandardERC20, Token: testToken, From: testAlice, To: testBob, Value: "100"},
+	)
+	if err := storage.SaveTransaction(ctx, tx); err != nil {
+		t.Fatalf("SaveTransaction() error = %v", err)
+	}
+	if err := storage.DeleteTransaction(ctx, "ethereum", tx.Hash); err != nil {
+		t.Fatalf("DeleteTransaction() error = %v", err)
+	}
+
+	address, token := testBob, testToken
+	filters := []*models.TokenTransferFilter{
+		{ChainID: "ethereum"},
+		{ChainID: "ethereum", Address: &address},
+		{ChainID: "ethereum", Token: &token},
+	}
+	for _, filter := range filters {
+		transfers, err := storage.QueryTokenTransfers(ctx, filter, nil)
+		if err != nil {
+			t.Fatalf("QueryTokenTransfers() error = %v", err)
+		}
+		if len(transfers) != 0 {
+			t.Errorf("QueryTokenTransfers(%+v) returned %d transfers after delete, want 0", filter, len(transfers))
+		}
+	}
+}
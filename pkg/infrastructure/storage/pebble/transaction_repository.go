@@ -90,7 +90,12 @@ func (r *TransactionRepo) GetTransactionsByAddress(ctx context.Context, chainID
 		return nil, err
 	}
 
-	// Fetch full transactions
+	return r.getTransactions(ctx, chainID, txHashes)
+}
+
+// getTransactions fetches the transactions with the given hashes, skipping
+// those that are missing
+func (r *TransactionRepo) getTransactions(ctx context.Context, chainID string, txHashes []string) ([]*models.Transaction, error) {
 	transactions := make([]*models.Transaction, 0, len(txHashes))
 	for _, txHash := range txHashes {
 		tx, err := r.GetTransaction(ctx, chainID, txHash)
@@ -129,13 +134,24 @@ func (r *TransactionRepo) QueryTransactions(ctx context.Context, filter *models.
 		return nil, fmt.Errorf("filter cannot be nil")
 	}
 
-	// If filtering by address, use address index
-	if filter.From != nil {
-		return r.GetTransactionsByAddress(ctx, *filter.ChainID, *filter.From, pagination)
+	// If filtering by address, scan the address index in the block range
+	address := filter.Address
+	if address == nil {
+		address = filter.From
 	}
+	if address == nil {
+		address = filter.To
+	}
+	if address != nil {
+		if filter.ChainID == nil {
+			return nil, fmt.Errorf("chain ID is required")
+		}
 
-	if filter.To != nil {
-		return r.GetTransactionsByAddress(ctx, *filter.ChainID, *filter.To, pagination)
+		txHashes, err := r.addressTransactions(*filter.ChainID, *address, filter.BlockNumberMin, filter.BlockNumberMax, pagination)
+		if err != nil {
+			return nil, err
+		}
+		return r.getTransactions(ctx, *filter.ChainID, txHashes)
 	}
 
 	// If filtering by block range, iterate through blocks
@@ -232,8 +248,10 @@ func (r *TransactionRepo) SaveTransaction(ctx context.Context, tx *models.Transa
 	}
 
 	// Save address indexes
-	if err := r.AddAddressIndex(ctx, tx.ChainID, tx.From, tx.Hash); err != nil {
-		return fmt.Errorf("failed to save from address index: %w", err)
+	if tx.From != "" {
+		if err := r.AddAddressIndex(ctx, tx.ChainID, tx.From, tx.Hash); err != nil {
+			return fmt.Errorf("failed to save from address index: %w", err)
+		}
 	}
 
 	if tx.To != "" {
@@ -242,6 +260,21 @@ func (r *TransactionRepo) SaveTransaction(ctx context.Context, tx *models.Transa
 		}
 	}
 
+	// List the transaction in the history of its internal transactions' and outputs' addresses
+	if _, err := setExtraAddressIndexes(r.db, r.encoder, tx); err != nil {
+		return fmt.Errorf("failed to save extra address indexes: %w", err)
+	}
+
+	// Save logs and their indexes
+	if _, err := setLogs(r.db, r.encoder, tx); err != nil {
+		return fmt.Errorf("failed to save logs: %w", err)
+	}
+
+	// Save token transfers and their indexes
+	if _, err := setTokenTransfers(r.db, r.encoder, tx); err != nil {
+		return fmt.Errorf("failed to save token transfers: %w", err)
+	}
+
 	return nil
 }
 
@@ -284,9 +317,11 @@ func (r *TransactionRepo) SaveTransactions(ctx context.Context, txs []*models.Tr
 		}
 
 		// Save address indexes
-		fromAddrKey := AddressTxKey(tx.ChainID, tx.From, tx.BlockNumber, tx.Index)
-		if err := batch.Set(fromAddrKey, txHashData, pebble.Sync); err != nil {
-			return fmt.Errorf("failed to batch set from address index: %w", err)
+		if tx.From != "" {
+			fromAddrKey := AddressTxKey(tx.ChainID, tx.From, tx.BlockNumber, tx.Index)
+			if err := batch.Set(fromAddrKey, txHashData, pebble.Sync); err != nil {
+				return fmt.Errorf("failed to batch set from address index: %w", err)
+			}
 		}
 
 		if tx.To != "" {
@@ -295,6 +330,21 @@ func (r *TransactionRepo) SaveTransactions(ctx context.Context, txs []*models.Tr
 				return fmt.Errorf("failed to batch set to address index: %w", err)
 			}
 		}
+
+		// List the transaction in the history of its internal transactions' and outputs' addresses
+		if _, err := setExtraAddressIndexes(batch, r.encoder, tx); err != nil {
+			return fmt.Errorf("failed to batch set extra address indexes of %s: %w", tx.Hash, err)
+		}
+
+		// Save logs and their indexes
+		if _, err := setLogs(batch, r.encoder, tx); err != nil {
+			return fmt.Errorf("failed to batch set logs of %s: %w", tx.Hash, err)
+		}
+
+		// Save token transfers and their indexes
+		if _, err := setTokenTransfers(batch, r.encoder, tx); err != nil {
+			return fmt.Errorf("failed to batch set token transfers of %s: %w", tx.Hash, err)
+		}
 	}
 
 	// Commit the batch
@@ -319,31 +369,65 @@ func (r *TransactionRepo) DeleteTransaction(ctx context.Context, chainID string,
 		return err
 	}
 
+	batch := r.db.NewBatch()
+	defer batch.Close()
+
+	if err := deleteTransaction(batch, tx); err != nil {
+		return err
+	}
+
+	if err := batch.Commit(pebble.Sync); err != nil {
+		return fmt.Errorf("failed to commit batch: %w", err)
+	}
+
+	return nil
+}
+
+// deleteTransaction stages the removal of a stored transaction and every
+// index entry it was written with
+func deleteTransaction(w pebble.Writer, tx *models.Transaction) error {
 	// Delete the transaction
-	txKey := TransactionKey(chainID, hash)
-	if err := r.db.Delete(txKey, pebble.Sync); err != nil {
+	txKey := TransactionKey(tx.ChainID, tx.Hash)
+	if err := w.Delete(txKey, pebble.Sync); err != nil {
 		return fmt.Errorf("failed to delete transaction: %w", err)
 	}
 
 	// Delete transaction-by-block index
-	txBlockKey := TransactionByBlockKey(chainID, tx.BlockNumber, tx.Index)
-	if err := r.db.Delete(txBlockKey, pebble.Sync); err != nil {
+	txBlockKey := TransactionByBlockKey(tx.ChainID, tx.BlockNumber, tx.Index)
+	if err := w.Delete(txBlockKey, pebble.Sync); err != nil {
 		return fmt.Errorf("failed to delete transaction-by-block index: %w", err)
 	}
 
 	// Delete address indexes
-	fromAddrKey := AddressTxKey(chainID, tx.From, tx.BlockNumber, tx.Index)
-	if err := r.db.Delete(fromAddrKey, pebble.Sync); err != nil {
-		return fmt.Errorf("failed to delete from address index: %w", err)
+	if tx.From != "" {
+		fromAddrKey := AddressTxKey(tx.ChainID, tx.From, tx.BlockNumber, tx.Index)
+		if err := w.Delete(fromAddrKey, pebble.Sync); err != nil {
+			return fmt.Errorf("failed to delete from address index: %w", err)
+		}
 	}
 
 	if tx.To != "" {
-		toAddrKey := AddressTxKey(chainID, tx.To, tx.BlockNumber, tx.Index)
-		if err := r.db.Delete(toAddrKey, pebble.Sync); err != nil {
+		toAddrKey := AddressTxKey(tx.ChainID, tx.To, tx.BlockNumber, tx.Index)
+		if err := w.Delete(toAddrKey, pebble.Sync); err != nil {
 			return fmt.Errorf("failed to delete to address index: %w", err)
 		}
 	}
 
+	// Delete internal transaction and output address indexes
+	if err := deleteExtraAddressIndexes(w, tx); err != nil {
+		return fmt.Errorf("failed to delete extra address indexes: %w", err)
+	}
+
+	// Delete logs and their indexes
+	if err := deleteLogs(w, tx); err != nil {
+		return fmt.Errorf("failed to delete logs: %w", err)
+	}
+
+	// Delete token transfers and their indexes
+	if err := deleteTokenTransfers(w, tx); err != nil {
+		return fmt.Errorf("failed to delete token transfers: %w", err)
+	}
+
 	return nil
 }
 
@@ -366,14 +450,57 @@ func (r *TransactionRepo) AddAddressIndex(ctx context.Context, chainID string, a
 	return nil
 }
 
+// extraAddresses returns the addresses tx is listed under besides its
+// sender and receiver: those its internal transactions involved and those
+// of its spent and created outputs
+func extraAddresses(tx *models.Transaction) []string {
+	return append(tx.InternalAddresses(), tx.UTXOAddresses()...)
+}
+
+// setExtraAddressIndexes stages address index entries for tx under its
+// extra addresses, so that internal transfers and every output show up in
+// their history, and returns the number of keys written
+func setExtraAddressIndexes(w pebble.Writer, encoder *Encoder, tx *models.Transaction) (int, error) {
+	count := 0
+	txHashData := encoder.EncodeString(tx.Hash)
+	for _, address := range extraAddresses(tx) {
+		key := AddressTxKey(tx.ChainID, address, tx.BlockNumber, tx.Index)
+		if err := w.Set(key, txHashData, pebble.Sync); err != nil {
+			return count, fmt.Errorf("failed to set address index of %s: %w", address, err)
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// deleteExtraAddressIndexes stages the removal of the address index
+// entries written by setExtraAddressIndexes into w
+func deleteExtraAddressIndexes(w pebble.Writer, tx *models.Transaction) error {
+	for _, address := range extraAddresses(tx) {
+		key := AddressTxKey(tx.ChainID, address, tx.BlockNumber, tx.Index)
+		if err := w.Delete(key, pebble.Sync); err != nil {
+			return fmt.Errorf("failed to delete address index of %s: %w", address, err)
+		}
+	}
+
+	return nil
+}
+
 // GetAddressTransactions retrieves transaction hashes for an address
 func (r *TransactionRepo) GetAddressTransactions(ctx context.Context, chainID string, address string, pagination *models.PaginationOptions) ([]string, error) {
+	return r.addressTransactions(chainID, address, nil, nil, pagination)
+}
+
+// addressTransactions returns the hashes of the transactions in the history
+// of address whose blocks are in the given range, both ends included
+func (r *TransactionRepo) addressTransactions(chainID string, address string, fromBlock, toBlock *uint64, pagination *models.PaginationOptions) ([]string, error) {
 	// Create iterator for address prefix
-	prefix := AddressTxPrefix(chainID, address)
+	lower, upper := blockBounds(AddressTxPrefix(chainID, address), fromBlock, toBlock)
 
 	iter, err := r.db.NewIter(&pebble.IterOptions{
-		LowerBound: prefix,
-		UpperBound: keyUpperBound(prefix),
+		LowerBound: lower,
+		UpperBound: upper,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create iterator: %w", err)
@@ -205,6 +205,31 @@ func TestTransactionRepo_GetTransactionsByAddress(t *testing.T) {
 			t.Errorf("len(txs) = %v, want 2", len(txs))
 		}
 	})
+
+	t.Run("query transactions by address in a block range", func(t *testing.T) {
+		chainID := "ethereum"
+		min, max := uint64(101), uint64(200)
+		filter := &models.TransactionFilter{
+			ChainID:        &chainID,
+			Address:        &address,
+			BlockNumberMin: &min,
+			BlockNumberMax: &max,
+		}
+		pagination := &models.PaginationOptions{
+			Limit:  2,
+			Offset: 1,
+		}
+
+		txs, err := storage.QueryTransactions(ctx, filter, pagination)
+		if err != nil {
+			t.Fatalf("QueryTransactions() error = %v", err)
+		}
+
+		// The page is taken from the transactions in blocks 101 to 200
+		if len(txs) != 2 || txs[0].Hash != "0xtxC" || txs[1].Hash != "0xtxD" {
+			t.Errorf("QueryTransactions() = %v, want 0xtxC and 0xtxD", txs)
+		}
+	})
 }
 
 func TestTransactionRepo_HasTransaction(t *testing.T) {
@@ -363,6 +388,61 @@ func TestTransactionRepo_AddressIndex(t *testing.T) {
 	})
 }
 
+func TestTransactionRepo_InternalAddressIndex(t *testing.T) {
+	storage, tmpDir := setupTestDB(t)
+	defer cleanupTestDB(t, storage, tmpDir)
+
+	ctx := context.Background()
+
+	// A contract call that forwarded value to another account
+	tx := models.NewTransaction(models.ChainTypeEVM, "ethereum", "0xtx123")
+	tx.BlockNumber = 100
+	tx.From = "0xfrom"
+	tx.To = "0xcontract"
+	tx.InternalTransactions = []*models.InternalTransaction{
+		{Type: models.CallTypeCall, From: "0xcontract", To: "0xrecipient", Value: "1000", TraceAddress: []uint64{0}},
+	}
+
+	batch := storage.NewBatch()
+	if err := batch.SetTransaction(ctx, tx); err != nil {
+		t.Fatalf("SetTransaction() error = %v", err)
+	}
+	if err := batch.Commit(); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+
+	txs, err := storage.GetTransactionsByAddress(ctx, "ethereum", "0xrecipient", nil)
+	if err != nil {
+		t.Fatalf("GetTransactionsByAddress() error = %v", err)
+	}
+	if len(txs) != 1 || txs[0].Hash != "0xtx123" {
+		t.Fatalf("GetTransactionsByAddress() = %v, want the parent transaction", txs)
+	}
+	if len(txs[0].InternalTransactions) != 1 || txs[0].InternalTransactions[0].Value != "1000" {
+		t.Errorf("internal transactions = %v, want them stored with the transaction", txs[0].InternalTransactions)
+	}
+
+	// The contract is listed once although it is both receiver and caller
+	hashes, err := storage.GetAddressTransactions(ctx, "ethereum", "0xcontract", nil)
+	if err != nil {
+		t.Fatalf("GetAddressTransactions() error = %v", err)
+	}
+	if len(hashes) != 1 {
+		t.Errorf("contract history = %v, want one entry", hashes)
+	}
+
+	if err := storage.DeleteTransaction(ctx, "ethereum", "0xtx123"); err != nil {
+		t.Fatalf("DeleteTransaction() error = %v", err)
+	}
+	hashes, err = storage.GetAddressTransactions(ctx, "ethereum", "0xrecipient", nil)
+	if err != nil {
+		t.Fatalf("GetAddressTransactions() error = %v", err)
+	}
+	if len(hashes) != 0 {
+		t.Errorf("recipient history after delete = %v, want empty", hashes)
+	}
+}
+
 // Benchmark tests
 func BenchmarkTransactionRepo_SaveTransaction(b *testing.B) {
 	storage, tmpDir := setupTestDB(&testing.T{})
@@ -400,3 +480,92 @@ func BenchmarkTransactionRepo_GetTransaction(b *testing.B) {
 		_, _ = storage.GetTransaction(ctx, "ethereum", "0xtx"+string(rune(i%1000)))
 	}
 }
+
+func TestTransactionRepo_OutputAddressIndex(t *testing.T) {
+	storage, tmpDir := setupTestDB(t)
+	defer cleanupTestDB(t, storage, tmpDir)
+
+	ctx := context.Background()
+
+	// A payment spending two outputs with change and a second payee
+	tx := models.NewTransaction(models.ChainTypeBitcoin, "bitcoin", "txbtc")
+	tx.BlockNumber = 800000
+	tx.From = "bc1qsender"
+	tx.To = "bc1qpayee"
+	tx.Inputs = []*models.TxInput{
+		{Index: 0, PrevTxHash: "prev1", Address: "bc1qsender", Value: "60000"},
+		{Index: 1, PrevTxHash: "prev2", Address: "3OtherInput", Value: "50000"},
+	}
+	tx.Outputs = []*models.TxOutput{
+		{Index: 0, Address: "bc1qpayee", Value: "70000"},
+		{Index: 1, Address: "bc1qsecondpayee", Value: "20000"},
+		{Index: 2, Address: "bc1qsender", Value: "19000"},
+	}
+
+	if err := storage.SaveTransaction(ctx, tx); err != nil {
+		t.Fatalf("SaveTransaction() error = %v", err)
+	}
+
+	for _, address := range []string{"bc1qsender", "3OtherInput", "bc1qpayee", "bc1qsecondpayee"} {
+		txs, err := storage.GetTransactionsByAddress(ctx, "bitcoin", address, nil)
+		if err != nil {
+			t.Fatalf("GetTransactionsByAddress(%s) error = %v", address, err)
+		}
+		if len(txs) != 1 || txs[0].Hash != "txbtc" {
+			t.Errorf("history of %s = %v, want the payment", address, txs)
+			continue
+		}
+		if len(txs[0].Inputs) != 2 || len(txs[0].Outputs) != 3 || txs[0].Inputs[1].Value != "50000" {
+			t.Errorf("inputs and outputs = %v %v, want them stored with the transaction", txs[0].Inputs, txs[0].Outputs)
+		}
+	}
+
+	if err := storage.DeleteTransaction(ctx, "bitcoin", "txbtc"); err != nil {
+		t.Fatalf("DeleteTransaction() error = %v", err)
+	}
+	hashes, err := storage.GetAddressTransactions(ctx, "bitcoin", "bc1qsecondpayee", nil)
+	if err != nil {
+		t.Fatalf("GetAddressTransactions() error = %v", err)
+	}
+	if len(hashes) != 0 {
+		t.Errorf("payee history after delete = %v, want empty", hashes)
+	}
+}
+
+func TestTransactionRepo_NoSenderAddressIndex(t *testing.T) {
+	storage, tmpDir := setupTestDB(t)
+	defer cleanupTestDB(t, storage, tmpDir)
+
+	ctx := context.Background()
+
+	// A coinbase spends no outputs, so it has no sender
+	coinbase := models.NewTransaction(models.ChainTypeBitcoin, "bitcoin", "txcoinbase")
+	coinbase.BlockNumber = 800000
+	coinbase.To = "bc1qminer"
+	coinbase.Inputs = []*models.TxInput{{Index: 0, Coinbase: []byte{0x03}}}
+	coinbase.Outputs = []*models.TxOutput{{Index: 0, Address: "bc1qminer", Value: "625000000"}}
+
+	batch := storage.NewBatch()
+	defer batch.Close()
+	if err := batch.SetTransaction(ctx, coinbase); err != nil {
+		t.Fatalf("SetTransaction() error = %v", err)
+	}
+	if err := batch.Commit(); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+
+	hashes, err := storage.GetAddressTransactions(ctx, "bitcoin", "", nil)
+	if err != nil {
+		t.Fatalf("GetAddressTransactions() error = %v", err)
+	}
+	if len(hashes) != 0 {
+		t.Errorf("history of the empty address = %v, want empty", hashes)
+	}
+	hashes, err = storage.GetAddressTransactions(ctx, "bitcoin", "bc1qminer", nil)
+	if err != nil {
+		t.Fatalf("GetAddressTransactions() error = %v", err)
+	}
+	if len(hashes) != 1 || hashes[0] != "txcoinbase" {
+		t.Errorf("miner history = %v, want the coinbase", hashes)
+	}
+}
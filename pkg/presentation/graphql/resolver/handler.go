@@ -2,9 +2,12 @@ package resolver
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
 	"github.com/graphql-go/handler"
+	gql "github.com/sage-x-project/blockchain-indexer/pkg/presentation/graphql"
 )
 
 // NewGraphQLHandler creates an HTTP handler for GraphQL requests
@@ -38,6 +41,26 @@ func buildSchema(r *Resolver) graphql.Schema {
 		Serialize: func(value interface{}) interface{} {
 			return value
 		},
+		ParseValue: func(value interface{}) interface{} {
+			switch v := value.(type) {
+			case string:
+				return v
+			case int:
+				return strconv.Itoa(v)
+			case float64:
+				return strconv.FormatFloat(v, 'f', 0, 64)
+			}
+			return nil
+		},
+		ParseLiteral: func(valueAST ast.Value) interface{} {
+			switch v := valueAST.(type) {
+			case *ast.StringValue:
+				return v.Value
+			case *ast.IntValue:
+				return v.Value
+			}
+			return nil
+		},
 	})
 
 	// Define Log type
@@ -56,6 +79,104 @@ func buildSchema(r *Resolver) graphql.Schema {
 			"logIndex": &graphql.Field{
 				Type: graphql.Int,
 			},
+			"blockNumber": &graphql.Field{
+				Type: bigIntScalar,
+			},
+			"blockHash": &graphql.Field{
+				Type: graphql.String,
+			},
+			"txHash": &graphql.Field{
+				Type: graphql.String,
+			},
+			"txIndex": &graphql.Field{
+				Type: graphql.Int,
+			},
+		},
+	})
+
+	// Define TokenTransfer type
+	tokenTransferType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "TokenTransfer",
+		Fields: graphql.Fields{
+			"chainID": &graphql.Field{
+				Type: graphql.String,
+			},
+			"standard": &graphql.Field{
+				Type: graphql.String,
+			},
+			"token": &graphql.Field{
+				Type: graphql.String,
+			},
+			"from": &graphql.Field{
+				Type: graphql.String,
+			},
+			"to": &graphql.Field{
+				Type: graphql.String,
+			},
+			"operator": &graphql.Field{
+				Type: graphql.String,
+			},
+			"value": &graphql.Field{
+				Type: bigIntScalar,
+			},
+			"tokenID": &graphql.Field{
+				Type: bigIntScalar,
+			},
+			"blockNumber": &graphql.Field{
+				Type: bigIntScalar,
+			},
+			"blockHash": &graphql.Field{
+				Type: graphql.String,
+			},
+			"blockTimestamp": &graphql.Field{
+				Type: timestampScalar,
+			},
+			"txHash": &graphql.Field{
+				Type: graphql.String,
+			},
+			"txIndex": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"logIndex": &graphql.Field{
+				Type: graphql.Int,
+			},
+			"batchIndex": &graphql.Field{
+				Type: graphql.Int,
+			},
+		},
+	})
+
+	// Define InternalTransaction type
+	internalTransactionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "InternalTransaction",
+		Fields: graphql.Fields{
+			"type": &graphql.Field{
+				Type: graphql.String,
+			},
+			"from": &graphql.Field{
+				Type: graphql.String,
+			},
+			"to": &graphql.Field{
+				Type: graphql.String,
+			},
+			"value": &graphql.Field{
+				Type: bigIntScalar,
+			},
+			"gas": &graphql.Field{
+				Type: bigIntScalar,
+			},
+			"gasUsed": &graphql.Field{
+				Type: bigIntScalar,
+			},
+			"input": &graphql.Field{
+				Type: graphql.String,
+			},
+			"error": &graphql.Field{
+				Type: graphql.String,
+			},
+			"traceAddress": &graphql.Field{
+				Type: graphql.NewList(graphql.Int),
+			},
 		},
 	})
 
@@ -111,6 +232,16 @@ func buildSchema(r *Resolver) graphql.Schema {
 			"logs": &graphql.Field{
 				Type: graphql.NewList(logType),
 			},
+			"internalTransactions": &graphql.Field{
+				Type: graphql.NewList(internalTransactionType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					tx, ok := p.Source.(*gql.Transaction)
+					if !ok {
+						return nil, nil
+					}
+					return r.InternalTransactions(p.Context, tx)
+				},
+			},
 			"createdAt": &graphql.Field{
 				Type: timestampScalar,
 			},
@@ -157,6 +288,9 @@ func buildSchema(r *Resolver) graphql.Schema {
 			"createdAt": &graphql.Field{
 				Type: timestampScalar,
 			},
+			"finality": &graphql.Field{
+				Type: graphql.String,
+			},
 		},
 	})
 
@@ -191,6 +325,9 @@ func buildSchema(r *Resolver) graphql.Schema {
 			"lastUpdated": &graphql.Field{
 				Type: timestampScalar,
 			},
+			"capabilities": &graphql.Field{
+				Type: graphql.NewList(graphql.String),
+			},
 		},
 	})
 
@@ -250,6 +387,9 @@ func buildSchema(r *Resolver) graphql.Schema {
 					"number": &graphql.ArgumentConfig{
 						Type: graphql.NewNonNull(bigIntScalar),
 					},
+					"finality": &graphql.ArgumentConfig{
+						Type: graphql.String,
+					},
 				},
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
 					// TODO: Implement resolver call
@@ -265,6 +405,9 @@ func buildSchema(r *Resolver) graphql.Schema {
 					"hash": &graphql.ArgumentConfig{
 						Type: graphql.NewNonNull(graphql.String),
 					},
+					"finality": &graphql.ArgumentConfig{
+						Type: graphql.String,
+					},
 				},
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
 					// TODO: Implement resolver call
@@ -283,6 +426,67 @@ func buildSchema(r *Resolver) graphql.Schema {
 					return nil, nil
 				},
 			},
+			"logs": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(logType))),
+				Args: graphql.FieldConfigArgument{
+					"chainID": &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.String),
+					},
+					"address": &graphql.ArgumentConfig{
+						Type: graphql.String,
+					},
+					"topics": &graphql.ArgumentConfig{
+						Type: graphql.NewList(graphql.NewList(graphql.NewNonNull(graphql.String))),
+					},
+					"fromBlock": &graphql.ArgumentConfig{
+						Type: bigIntScalar,
+					},
+					"toBlock": &graphql.ArgumentConfig{
+						Type: bigIntScalar,
+					},
+					"first": &graphql.ArgumentConfig{
+						Type: graphql.Int,
+					},
+					"skip": &graphql.ArgumentConfig{
+						Type: graphql.Int,
+					},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return r.Logs(p.Context, logsArgs(p.Args))
+				},
+			},
+			"tokenTransfers": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(tokenTransferType))),
+				Args: graphql.FieldConfigArgument{
+					"chainID": &graphql.ArgumentConfig{
+						Type: graphql.NewNonNull(graphql.String),
+					},
+					"address": &graphql.ArgumentConfig{
+						Type: graphql.String,
+					},
+					"token": &graphql.ArgumentConfig{
+						Type: graphql.String,
+					},
+					"standard": &graphql.ArgumentConfig{
+						Type: graphql.String,
+					},
+					"fromBlock": &graphql.ArgumentConfig{
+						Type: bigIntScalar,
+					},
+					"toBlock": &graphql.ArgumentConfig{
+						Type: bigIntScalar,
+					},
+					"first": &graphql.ArgumentConfig{
+						Type: graphql.Int,
+					},
+					"skip": &graphql.ArgumentConfig{
+						Type: graphql.Int,
+					},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return r.TokenTransfers(p.Context, tokenTransfersArgs(p.Args))
+				},
+			},
 			"progress": &graphql.Field{
 				Type: progressType,
 				Args: graphql.FieldConfigArgument{
@@ -304,3 +508,85 @@ func buildSchema(r *Resolver) graphql.Schema {
 
 	return schema
 }
+
+// logsArgs reads the arguments of the logs query
+func logsArgs(args map[string]interface{}) LogsArgs {
+	result := LogsArgs{}
+	result.ChainID, _ = args["chainID"].(string)
+
+	if address, ok := args["address"].(string); ok {
+		result.Address = &address
+	}
+
+	if topics, ok := args["topics"].([]interface{}); ok {
+		for _, position := range topics {
+			var values []string
+			if list, ok := position.([]interface{}); ok {
+				for _, value := range list {
+					if topic, ok := value.(string); ok {
+						values = append(values, topic)
+					}
+				}
+			}
+			result.Topics = append(result.Topics, values)
+		}
+	}
+
+	if fromBlock, ok := args["fromBlock"].(string); ok {
+		block := gql.BigInt(fromBlock)
+		result.FromBlock = &block
+	}
+
+	if toBlock, ok := args["toBlock"].(string); ok {
+		block := gql.BigInt(toBlock)
+		result.ToBlock = &block
+	}
+
+	if first, ok := args["first"].(int); ok {
+		result.First = &first
+	}
+
+	if skip, ok := args["skip"].(int); ok {
+		result.Skip = &skip
+	}
+
+	return result
+}
+
+// tokenTransfersArgs reads the arguments of the tokenTransfers query
+func tokenTransfersArgs(args map[string]interface{}) TokenTransfersArgs {
+	result := TokenTransfersArgs{}
+	result.ChainID, _ = args["chainID"].(string)
+
+	if address, ok := args["address"].(string); ok {
+		result.Address = &address
+	}
+
+	if token, ok := args["token"].(string); ok {
+		result.Token = &token
+	}
+
+	if standard, ok := args["standard"].(string); ok {
+		result.Standard = &standard
+	}
+
+	if fromBlock, ok := args["fromBlock"].(string); ok {
+		block := gql.BigInt(fromBlock)
+		result.FromBlock = &block
+	}
+
+	if toBlock, ok := args["toBlock"].(string); ok {
+		block := gql.BigInt(toBlock)
+		result.ToBlock = &block
+	}
+
+	if first, ok := args["first"].(int); ok {
+		result.First = &first
+	}
+
+	if skip, ok := args["skip"].(int); ok {
+		result.Skip = &skip
+	}
+
+	return result
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/sage-x-project/blockchain-indexer/pkg/application/indexer"
@@ -21,6 +22,8 @@ import (
 type Resolver struct {
 	blockRepo       repository.BlockRepository
 	txRepo          repository.TransactionRepository
+	logRepo         repository.LogRepository
+	transferRepo    repository.TokenTransferRepository
 	chainRepo       repository.ChainRepository
 	progressTracker *indexer.ProgressTracker
 	statsCollector  *statistics.Collector
@@ -33,6 +36,8 @@ type Resolver struct {
 func NewResolver(
 	blockRepo repository.BlockRepository,
 	txRepo repository.TransactionRepository,
+	logRepo repository.LogRepository,
+	transferRepo repository.TokenTransferRepository,
 	chainRepo repository.ChainRepository,
 	progressTracker *indexer.ProgressTracker,
 	statsCollector *statistics.Collector,
@@ -43,6 +48,8 @@ func NewResolver(
 	return &Resolver{
 		blockRepo:       blockRepo,
 		txRepo:          txRepo,
+		logRepo:         logRepo,
+		transferRepo:    transferRepo,
 		chainRepo:       chainRepo,
 		progressTracker: progressTracker,
 		statsCollector:  statsCollector,
@@ -79,6 +86,7 @@ func (r *Resolver) Chain(ctx context.Context, chainID string) (*gql.Chain, error
 		LatestIndexedBlock: gql.BigInt(fmt.Sprintf("%d", chain.LatestIndexedBlock)),
 		LatestChainBlock:   gql.BigInt(fmt.Sprintf("%d", chain.LatestChainBlock)),
 		LastUpdated:        gql.Time(chain.LastUpdated),
+		Capabilities:       gql.ToGraphQLCapabilities(chain.Capabilities),
 	}, nil
 }
 
@@ -102,6 +110,7 @@ func (r *Resolver) Chains(ctx context.Context) ([]*gql.Chain, error) {
 			LatestIndexedBlock: gql.BigInt(fmt.Sprintf("%d", chain.LatestIndexedBlock)),
 			LatestChainBlock:   gql.BigInt(fmt.Sprintf("%d", chain.LatestChainBlock)),
 			LastUpdated:        gql.Time(chain.LastUpdated),
+			Capabilities:       gql.ToGraphQLCapabilities(chain.Capabilities),
 		}
 	}
 
@@ -110,12 +119,20 @@ func (r *Resolver) Chains(ctx context.Context) ([]*gql.Chain, error) {
 }
 
 // Block resolves a single block by number
-func (r *Resolver) Block(ctx context.Context, chainID string, number gql.BigInt) (*gql.Block, error) {
+func (r *Resolver) Block(ctx context.Context, chainID string, number gql.BigInt, finality *string) (*gql.Block, error) {
 	blockNum, err := strconv.ParseUint(string(number), 10, 64)
 	if err != nil {
 		return nil, fmt.Errorf("invalid block number: %w", err)
 	}
 
+	limit, err := r.parseFinality(ctx, chainID, finality)
+	if err != nil {
+		return nil, err
+	}
+	if !limit.Allows(blockNum) {
+		return nil, nil
+	}
+
 	block, err := r.blockRepo.GetBlock(ctx, chainID, blockNum)
 	if err != nil {
 		r.logger.Error("failed to get block",
@@ -130,7 +147,12 @@ func (r *Resolver) Block(ctx context.Context, chainID string, number gql.BigInt)
 }
 
 // BlockByHash resolves a block by hash
-func (r *Resolver) BlockByHash(ctx context.Context, chainID string, hash string) (*gql.Block, error) {
+func (r *Resolver) BlockByHash(ctx context.Context, chainID string, hash string, finality *string) (*gql.Block, error) {
+	limit, err := r.parseFinality(ctx, chainID, finality)
+	if err != nil {
+		return nil, err
+	}
+
 	block, err := r.blockRepo.GetBlockByHash(ctx, chainID, hash)
 	if err != nil {
 		r.logger.Error("failed to get block by hash",
@@ -140,6 +162,9 @@ func (r *Resolver) BlockByHash(ctx context.Context, chainID string, hash string)
 		)
 		return nil, err
 	}
+	if !limit.Allows(block.Number) {
+		return nil, nil
+	}
 
 	return gql.ToGraphQLBlock(block), nil
 }
@@ -164,13 +189,25 @@ func (r *Resolver) Blocks(ctx context.Context, args BlocksArgs) (*gql.BlockConne
 		}
 	}
 
-	// Get blocks
-	endBlock := startBlock + uint64(first)
-	blocks, err := r.blockRepo.GetBlocks(ctx, args.ChainID, startBlock, endBlock)
+	limit, err := r.parseFinality(ctx, args.ChainID, args.Finality)
 	if err != nil {
 		return nil, err
 	}
 
+	// Get blocks
+	endBlock := startBlock + uint64(first)
+	if limit.Limited {
+		endBlock = min(endBlock, limit.Height)
+	}
+
+	var blocks []*models.Block
+	if startBlock <= endBlock {
+		blocks, err = r.blockRepo.GetBlocks(ctx, args.ChainID, startBlock, endBlock)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Build connection
 	edges := make([]*gql.BlockEdge, 0, len(blocks))
 	for _, block := range blocks {
@@ -225,17 +262,34 @@ func (r *Resolver) BlockRange(ctx context.Context, chainID string, startBlock gq
 }
 
 // LatestBlock resolves the latest indexed block
-func (r *Resolver) LatestBlock(ctx context.Context, chainID string) (*gql.Block, error) {
+func (r *Resolver) LatestBlock(ctx context.Context, chainID string, finality *string) (*gql.Block, error) {
+	limit, err := r.parseFinality(ctx, chainID, finality)
+	if err != nil {
+		return nil, err
+	}
+
 	block, err := r.blockRepo.GetLatestBlock(ctx, chainID)
 	if err != nil {
 		return nil, err
 	}
+	if !limit.Allows(block.Number) {
+		// The latest block at the finality level is its head
+		block, err = r.blockRepo.GetBlock(ctx, chainID, limit.Height)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	return gql.ToGraphQLBlock(block), nil
 }
 
 // Transaction resolves a transaction by hash
-func (r *Resolver) Transaction(ctx context.Context, chainID string, hash string) (*gql.Transaction, error) {
+func (r *Resolver) Transaction(ctx context.Context, chainID string, hash string, finality *string) (*gql.Transaction, error) {
+	limit, err := r.parseFinality(ctx, chainID, finality)
+	if err != nil {
+		return nil, err
+	}
+
 	tx, err := r.txRepo.GetTransaction(ctx, chainID, hash)
 	if err != nil {
 		r.logger.Error("failed to get transaction",
@@ -245,6 +299,9 @@ func (r *Resolver) Transaction(ctx context.Context, chainID string, hash string)
 		)
 		return nil, err
 	}
+	if !limit.Allows(tx.BlockNumber) {
+		return nil, nil
+	}
 
 	return gql.ToGraphQLTransaction(tx), nil
 }
@@ -273,12 +330,20 @@ func (r *Resolver) Transactions(ctx context.Context, args TransactionsArgs) (*gq
 }
 
 // TransactionsByBlock resolves transactions by block number
-func (r *Resolver) TransactionsByBlock(ctx context.Context, chainID string, blockNumber gql.BigInt) ([]*gql.Transaction, error) {
+func (r *Resolver) TransactionsByBlock(ctx context.Context, chainID string, blockNumber gql.BigInt, finality *string) ([]*gql.Transaction, error) {
 	blockNum, err := strconv.ParseUint(string(blockNumber), 10, 64)
 	if err != nil {
 		return nil, fmt.Errorf("invalid block number: %w", err)
 	}
 
+	limit, err := r.parseFinality(ctx, chainID, finality)
+	if err != nil {
+		return nil, err
+	}
+	if !limit.Allows(blockNum) {
+		return []*gql.Transaction{}, nil
+	}
+
 	txs, err := r.txRepo.GetTransactionsByBlock(ctx, chainID, blockNum)
 	if err != nil {
 		return nil, err
@@ -303,11 +368,21 @@ func (r *Resolver) TransactionsByAddress(ctx context.Context, args TransactionsB
 		}
 	}
 
+	limit, err := r.parseFinality(ctx, args.ChainID, args.Finality)
+	if err != nil {
+		return nil, err
+	}
+
 	paginationOpts := &models.PaginationOptions{
 		Limit:  first,
 		Offset: 0,
 	}
-	txs, err := r.txRepo.GetTransactionsByAddress(ctx, args.ChainID, args.Address, paginationOpts)
+	filter := &models.TransactionFilter{
+		ChainID:        &args.ChainID,
+		Address:        &args.Address,
+		BlockNumberMax: limit.MaxHeight(),
+	}
+	txs, err := r.txRepo.QueryTransactions(ctx, filter, paginationOpts)
 	if err != nil {
 		return nil, err
 	}
@@ -339,6 +414,147 @@ func (r *Resolver) TransactionsByAddress(ctx context.Context, args TransactionsB
 	}, nil
 }
 
+// Logs resolves the logs matching a filter in chain order
+func (r *Resolver) Logs(ctx context.Context, args LogsArgs) ([]*gql.Log, error) {
+	// Chains whose adapter reports no logs have none to query
+	if err := r.requireCapability(ctx, args.ChainID, models.CapabilityLogs); err != nil {
+		return nil, err
+	}
+
+	// Default pagination
+	first := 10
+	if args.First != nil && *args.First > 0 {
+		first = *args.First
+		if first > 100 {
+			first = 100
+		}
+	}
+
+	skip := 0
+	if args.Skip != nil && *args.Skip > 0 {
+		skip = *args.Skip
+	}
+
+	filter := &models.LogFilter{
+		ChainID: args.ChainID,
+		Address: args.Address,
+		Topics:  args.Topics,
+	}
+
+	if args.FromBlock != nil {
+		fromBlock, err := strconv.ParseUint(string(*args.FromBlock), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fromBlock: %w", err)
+		}
+		filter.FromBlock = &fromBlock
+	}
+
+	if args.ToBlock != nil {
+		toBlock, err := strconv.ParseUint(string(*args.ToBlock), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid toBlock: %w", err)
+		}
+		filter.ToBlock = &toBlock
+	}
+
+	logs, err := r.logRepo.QueryLogs(ctx, filter, &models.PaginationOptions{
+		Limit:  first,
+		Offset: skip,
+	})
+	if err != nil {
+		r.logger.Error("failed to query logs",
+			zap.String("chain_id", args.ChainID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	result := make([]*gql.Log, len(logs))
+	for i, log := range logs {
+		result[i] = gql.ToGraphQLLog(log)
+	}
+
+	return result, nil
+}
+
+// TokenTransfers resolves token transfers, optionally sent or received by an address
+func (r *Resolver) TokenTransfers(ctx context.Context, args TokenTransfersArgs) ([]*gql.TokenTransfer, error) {
+	// Token transfers are decoded from logs
+	if err := r.requireCapability(ctx, args.ChainID, models.CapabilityLogs); err != nil {
+		return nil, err
+	}
+
+	// Default pagination
+	first := 10
+	if args.First != nil && *args.First > 0 {
+		first = *args.First
+		if first > 100 {
+			first = 100
+		}
+	}
+
+	skip := 0
+	if args.Skip != nil && *args.Skip > 0 {
+		skip = *args.Skip
+	}
+
+	filter := &models.TokenTransferFilter{
+		ChainID: args.ChainID,
+		Address: args.Address,
+		Token:   args.Token,
+	}
+
+	if args.Standard != nil {
+		standard := models.TokenStandard(strings.ToLower(*args.Standard))
+		filter.Standard = &standard
+	}
+
+	if args.FromBlock != nil {
+		fromBlock, err := strconv.ParseUint(string(*args.FromBlock), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fromBlock: %w", err)
+		}
+		filter.FromBlock = &fromBlock
+	}
+
+	if args.ToBlock != nil {
+		toBlock, err := strconv.ParseUint(string(*args.ToBlock), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid toBlock: %w", err)
+		}
+		filter.ToBlock = &toBlock
+	}
+
+	transfers, err := r.transferRepo.QueryTokenTransfers(ctx, filter, &models.PaginationOptions{
+		Limit:  first,
+		Offset: skip,
+	})
+	if err != nil {
+		r.logger.Error("failed to query token transfers",
+			zap.String("chain_id", args.ChainID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	result := make([]*gql.TokenTransfer, len(transfers))
+	for i, transfer := range transfers {
+		result[i] = gql.ToGraphQLTokenTransfer(transfer)
+	}
+
+	return result, nil
+}
+
+// InternalTransactions resolves the internal transactions of a transaction.
+// Chains whose adapter reports no traces have none to return.
+func (r *Resolver) InternalTransactions(ctx context.Context, tx *gql.Transaction) ([]*gql.InternalTransaction, error) {
+	if err := r.requireCapability(ctx, tx.ChainID, models.CapabilityTraces); err != nil {
+		return nil, err
+	}
+
+	return tx.InternalTransactions, nil
+}
+
 // Progress resolves indexing progress for a chain
 func (r *Resolver) Progress(ctx context.Context, chainID string) (*gql.Progress, error) {
 	if r.progressTracker == nil {
@@ -387,14 +603,18 @@ func (r *Resolver) Gaps(ctx context.Context, chainID string) ([]*gql.Gap, error)
 		return []*gql.Gap{}, nil
 	}
 
-	// Detect gaps
-	gaps, err := recovery.DetectGaps(ctx, chainID)
+	// List gaps from the indexed ranges, starting at the chain's start block
+	var startBlock uint64
+	if chain, err := r.chainRepo.GetChain(ctx, chainID); err == nil && chain != nil {
+		startBlock = chain.StartBlock
+	}
+	gaps, err := recovery.ListGaps(ctx, chainID, startBlock)
 	if err != nil {
-		r.logger.Error("failed to detect gaps",
+		r.logger.Error("failed to list gaps",
 			zap.String("chain_id", chainID),
 			zap.Error(err),
 		)
-		return nil, fmt.Errorf("failed to detect gaps: %w", err)
+		return nil, fmt.Errorf("failed to list gaps: %w", err)
 	}
 
 	// Convert to GraphQL type
@@ -491,6 +711,40 @@ func (r *Resolver) GlobalStats(ctx context.Context) (*gql.Stats, error) {
 	}, nil
 }
 
+// requireCapability returns an error if the adapter of the chain does not
+// support capability. Unknown chains are left to the query itself.
+func (r *Resolver) requireCapability(ctx context.Context, chainID string, capability models.Capability) error {
+	chain, err := r.chainRepo.GetChain(ctx, chainID)
+	if err != nil || chain == nil || chain.Supports(capability) {
+		return nil
+	}
+
+	return fmt.Errorf("chain %s does not support %s", chainID, capability)
+}
+
+// parseFinality resolves the finality argument of a query against the heads
+// recorded on the chain
+func (r *Resolver) parseFinality(ctx context.Context, chainID string, finality *string) (models.FinalityLimit, error) {
+	if finality == nil {
+		return models.FinalityLimit{}, nil
+	}
+
+	level, err := models.ParseFinality(*finality)
+	if err != nil {
+		return models.FinalityLimit{}, err
+	}
+	if level == models.FinalityLatest {
+		return models.NewFinalityLimit(level, nil), nil
+	}
+
+	chain, err := r.chainRepo.GetChain(ctx, chainID)
+	if err != nil {
+		// Unknown chains have no finalized blocks
+		return models.NewFinalityLimit(level, nil), nil
+	}
+	return models.NewFinalityLimit(level, chain), nil
+}
+
 // Argument types for resolvers
 
 // BlocksArgs represents arguments for the blocks query
@@ -501,6 +755,9 @@ type BlocksArgs struct {
 	Last    *int
 	Before  *string
 	OrderBy *string
+
+	// Finality is "finalized", "safe" or "latest", nil for latest
+	Finality *string
 }
 
 // TransactionsArgs represents arguments for the transactions query
@@ -521,6 +778,32 @@ type TransactionsByAddressArgs struct {
 	Address string
 	First   *int
 	After   *string
+
+	// Finality is "finalized", "safe" or "latest", nil for latest
+	Finality *string
+}
+
+// LogsArgs represents arguments for the logs query
+type LogsArgs struct {
+	ChainID   string
+	Address   *string
+	Topics    [][]string
+	FromBlock *gql.BigInt
+	ToBlock   *gql.BigInt
+	First     *int
+	Skip      *int
+}
+
+// TokenTransfersArgs represents arguments for the tokenTransfers query
+type TokenTransfersArgs struct {
+	ChainID   string
+	Address   *string
+	Token     *string
+	Standard  *string
+	FromBlock *gql.BigInt
+	ToBlock   *gql.BigInt
+	First     *int
+	Skip      *int
 }
 
 // Subscription Resolvers
@@ -50,6 +50,7 @@ type Chain struct {
 	LatestIndexedBlock BigInt
 	LatestChainBlock   BigInt
 	LastUpdated        Time
+	Capabilities       []string
 }
 
 // Block represents a blockchain block
@@ -69,6 +70,7 @@ type Block struct {
 	TxCount        int
 	Transactions   []*Transaction
 	CreatedAt      Time
+	Finality       *string
 }
 
 // Transaction represents a blockchain transaction
@@ -91,14 +93,53 @@ type Transaction struct {
 	ContractAddress *string
 	Logs            []*Log
 	CreatedAt       Time
+
+	InternalTransactions []*InternalTransaction
+}
+
+// InternalTransaction represents a call made by a contract while a
+// transaction executed
+type InternalTransaction struct {
+	Type         string
+	From         string
+	To           *string
+	Value        BigInt
+	Gas          BigInt
+	GasUsed      BigInt
+	Input        *string
+	Error        *string
+	TraceAddress []int
 }
 
 // Log represents a transaction log/event
 type Log struct {
-	Address  string
-	Topics   []string
-	Data     string
-	LogIndex int
+	Address     string
+	Topics      []string
+	Data        string
+	LogIndex    int
+	BlockNumber *BigInt
+	BlockHash   *string
+	TxHash      *string
+	TxIndex     *int
+}
+
+// TokenTransfer represents a decoded token transfer
+type TokenTransfer struct {
+	ChainID        string
+	Standard       string
+	Token          string
+	From           string
+	To             string
+	Operator       *string
+	Value          BigInt
+	TokenID        *BigInt
+	BlockNumber    BigInt
+	BlockHash      string
+	BlockTimestamp *Time
+	TxHash         string
+	TxIndex        int
+	LogIndex       int
+	BatchIndex     int
 }
 
 // Progress represents indexing progress
@@ -183,6 +224,21 @@ func ToGraphQLChainType(ct models.ChainType) ChainType {
 	}
 }
 
+// ToGraphQLCapabilities converts recorded adapter capabilities to their names
+func ToGraphQLCapabilities(capabilities *models.Capabilities) []string {
+	if capabilities == nil {
+		return nil
+	}
+
+	supported := capabilities.List()
+	names := make([]string, len(supported))
+	for i, capability := range supported {
+		names[i] = string(capability)
+	}
+
+	return names
+}
+
 // ToGraphQLTxStatus converts domain TxStatus to GraphQL TransactionStatus
 func ToGraphQLTxStatus(status models.TxStatus) TransactionStatus {
 	switch status {
@@ -225,6 +281,10 @@ func ToGraphQLBlock(block *models.Block) *Block {
 	if block.Proposer != "" {
 		gqlBlock.Miner = &block.Proposer
 	}
+	if block.Finality != "" {
+		finality := string(block.Finality)
+		gqlBlock.Finality = &finality
+	}
 
 	// Convert transactions
 	for _, tx := range block.Transactions {
@@ -280,17 +340,115 @@ func ToGraphQLTransaction(tx *models.Transaction) *Transaction {
 
 	// Convert logs
 	for _, log := range tx.Logs {
-		gqlTx.Logs = append(gqlTx.Logs, &Log{
-			Address:  log.Address,
-			Topics:   log.Topics,
-			Data:     fmt.Sprintf("0x%x", log.Data),
-			LogIndex: int(log.Index),
-		})
+		gqlTx.Logs = append(gqlTx.Logs, ToGraphQLLog(log))
+	}
+
+	// Convert internal transactions
+	gqlTx.InternalTransactions = make([]*InternalTransaction, 0, len(tx.InternalTransactions))
+	for _, internal := range tx.InternalTransactions {
+		gqlTx.InternalTransactions = append(gqlTx.InternalTransactions, ToGraphQLInternalTransaction(internal))
 	}
 
 	return gqlTx
 }
 
+// ToGraphQLInternalTransaction converts a domain internal transaction to a
+// GraphQL internal transaction
+func ToGraphQLInternalTransaction(internal *models.InternalTransaction) *InternalTransaction {
+	if internal == nil {
+		return nil
+	}
+
+	gqlInternal := &InternalTransaction{
+		Type:         internal.Type.String(),
+		From:         internal.From,
+		Value:        BigInt(internal.Value),
+		Gas:          BigInt(uint64ToString(internal.Gas)),
+		GasUsed:      BigInt(uint64ToString(internal.GasUsed)),
+		TraceAddress: make([]int, len(internal.TraceAddress)),
+	}
+
+	if internal.To != "" {
+		gqlInternal.To = &internal.To
+	}
+	if len(internal.Input) > 0 {
+		input := fmt.Sprintf("0x%x", internal.Input)
+		gqlInternal.Input = &input
+	}
+	if internal.Error != "" {
+		gqlInternal.Error = &internal.Error
+	}
+	for i, index := range internal.TraceAddress {
+		gqlInternal.TraceAddress[i] = int(index)
+	}
+
+	return gqlInternal
+}
+
+// ToGraphQLLog converts a domain log to a GraphQL log
+func ToGraphQLLog(log *models.Log) *Log {
+	if log == nil {
+		return nil
+	}
+
+	gqlLog := &Log{
+		Address:  log.Address,
+		Topics:   log.Topics,
+		Data:     fmt.Sprintf("0x%x", log.Data),
+		LogIndex: int(log.Index),
+	}
+
+	// Logs queried on their own carry their transaction
+	if log.TxHash != "" {
+		blockNumber := BigInt(uint64ToString(log.BlockNumber))
+		txIndex := int(log.TxIndex)
+		gqlLog.BlockNumber = &blockNumber
+		gqlLog.BlockHash = &log.BlockHash
+		gqlLog.TxHash = &log.TxHash
+		gqlLog.TxIndex = &txIndex
+	}
+
+	return gqlLog
+}
+
+// ToGraphQLTokenTransfer converts a domain token transfer to a GraphQL token transfer
+func ToGraphQLTokenTransfer(transfer *models.TokenTransfer) *TokenTransfer {
+	if transfer == nil {
+		return nil
+	}
+
+	gqlTransfer := &TokenTransfer{
+		ChainID:     transfer.ChainID,
+		Standard:    string(transfer.Standard),
+		Token:       transfer.Token,
+		From:        transfer.From,
+		To:          transfer.To,
+		Value:       BigInt(transfer.Value),
+		BlockNumber: BigInt(uint64ToString(transfer.BlockNumber)),
+		BlockHash:   transfer.BlockHash,
+		TxHash:      transfer.TxHash,
+		TxIndex:     int(transfer.TxIndex),
+		LogIndex:    int(transfer.LogIndex),
+		BatchIndex:  int(transfer.BatchIndex),
+	}
+
+	if transfer.Operator != "" {
+		gqlTransfer.Operator = &transfer.Operator
+	}
+
+	if transfer.TokenID != "" {
+		tokenID := BigInt(transfer.TokenID)
+		gqlTransfer.TokenID = &tokenID
+	}
+
+	if transfer.Timestamp != nil {
+		timestamp := Time(transfer.Timestamp.Time)
+		gqlTransfer.BlockTimestamp = &timestamp
+	}
+
+	return gqlTransfer
+}
+
 // Helper function to convert uint64 to string
 func uint64ToString(n uint64) string {
 	return fmt.Sprintf("%d", n)
@@ -0,0 +1,242 @@
+package server
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	indexerv1 "github.com/sage-x-project/blockchain-indexer/api/proto/indexer/v1"
+	"github.com/sage-x-project/blockchain-indexer/pkg/application/indexer"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/repository"
+)
+
+// adminServer implements the admin service on top of an indexer admin
+type adminServer struct {
+	indexerv1.UnimplementedIndexerAdminServiceServer
+
+	admin *indexer.Admin
+}
+
+// PauseChain pauses indexing a chain
+func (s *adminServer) PauseChain(ctx context.Context, req *indexerv1.PauseChainRequest) (*indexerv1.PauseChainResponse, error) {
+	if req.ChainId == "" {
+		return nil, status.Error(codes.InvalidArgument, "chain_id is required")
+	}
+
+	chain, err := s.admin.PauseChain(ctx, req.ChainId)
+	if err != nil {
+		return nil, adminError("failed to pause chain", err)
+	}
+
+	return &indexerv1.PauseChainResponse{
+		Chain: convertChainToProto(chain),
+	}, nil
+}
+
+// ResumeChain resumes indexing a paused chain
+func (s *adminServer) ResumeChain(ctx context.Context, req *indexerv1.ResumeChainRequest) (*indexerv1.ResumeChainResponse, error) {
+	if req.ChainId == "" {
+		return nil, status.Error(codes.InvalidArgument, "chain_id is required")
+	}
+
+	chain, err := s.admin.ResumeChain(ctx, req.ChainId)
+	if err != nil {
+		return nil, adminError("failed to resume chain", err)
+	}
+
+	return &indexerv1.ResumeChainResponse{
+		Chain: convertChainToProto(chain),
+	}, nil
+}
+
+// AddChain adds a chain and starts indexing it
+func (s *adminServer) AddChain(ctx context.Context, req *indexerv1.AddChainRequest) (*indexerv1.AddChainResponse, error) {
+	if req.ChainId == "" {
+		return nil, status.Error(codes.InvalidArgument, "chain_id is required")
+	}
+
+	chain := models.NewChain(models.ChainType(req.ChainType), req.ChainId, req.Name)
+	chain.Network = req.Network
+	chain.RPCEndpoints = req.RpcEndpoints
+	chain.WSEndpoints = req.WsEndpoints
+	chain.StartBlock = req.StartBlock
+	chain.ConfirmationBlocks = req.ConfirmationBlocks
+	if req.BatchSize > 0 {
+		chain.BatchSize = int(req.BatchSize)
+	}
+	if req.Workers > 0 {
+		chain.Workers = int(req.Workers)
+	}
+
+	added, err := s.admin.AddChain(ctx, chain)
+	if err != nil {
+		return nil, adminError("failed to add chain", err)
+	}
+
+	return &indexerv1.AddChainResponse{
+		Chain: convertChainToProto(added),
+	}, nil
+}
+
+// RemoveChain stops indexing a chain and deletes it. Its indexed blocks
+// are kept.
+func (s *adminServer) RemoveChain(ctx context.Context, req *indexerv1.RemoveChainRequest) (*indexerv1.RemoveChainResponse, error) {
+	if req.ChainId == "" {
+		return nil, status.Error(codes.InvalidArgument, "chain_id is required")
+	}
+
+	if err := s.admin.RemoveChain(ctx, req.ChainId); err != nil {
+		return nil, adminError("failed to remove chain", err)
+	}
+
+	return &indexerv1.RemoveChainResponse{}, nil
+}
+
+// ReindexRange starts reindexing a range of blocks
+func (s *adminServer) ReindexRange(ctx context.Context, req *indexerv1.ReindexRangeRequest) (*indexerv1.ReindexRangeResponse, error) {
+	if req.ChainId == "" {
+		return nil, status.Error(codes.InvalidArgument, "chain_id is required")
+	}
+
+	op, err := s.admin.ReindexRange(req.ChainId, req.StartBlock, req.EndBlock)
+	if err != nil {
+		return nil, adminError("failed to start reindexing", err)
+	}
+
+	return &indexerv1.ReindexRangeResponse{
+		Operation: convertOperationToProto(op),
+	}, nil
+}
+
+// RecoverGaps starts recovering the gaps of a chain
+func (s *adminServer) RecoverGaps(ctx context.Context, req *indexerv1.RecoverGapsRequest) (*indexerv1.RecoverGapsResponse, error) {
+	if req.ChainId == "" {
+		return nil, status.Error(codes.InvalidArgument, "chain_id is required")
+	}
+
+	op, err := s.admin.RecoverGaps(req.ChainId)
+	if err != nil {
+		return nil, adminError("failed to start gap recovery", err)
+	}
+
+	return &indexerv1.RecoverGapsResponse{
+		Operation: convertOperationToProto(op),
+	}, nil
+}
+
+// GetOperation gets an operation by ID
+func (s *adminServer) GetOperation(ctx context.Context, req *indexerv1.GetOperationRequest) (*indexerv1.GetOperationResponse, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	op, err := s.admin.GetOperation(req.Id)
+	if err != nil {
+		return nil, adminError("failed to get operation", err)
+	}
+
+	return &indexerv1.GetOperationResponse{
+		Operation: convertOperationToProto(op),
+	}, nil
+}
+
+// ListOperations lists the operations of a chain, newest first
+func (s *adminServer) ListOperations(ctx context.Context, req *indexerv1.ListOperationsRequest) (*indexerv1.ListOperationsResponse, error) {
+	ops := s.admin.ListOperations(req.ChainId)
+
+	protoOps := make([]*indexerv1.Operation, len(ops))
+	for i, op := range ops {
+		protoOps[i] = convertOperationToProto(op)
+	}
+
+	return &indexerv1.ListOperationsResponse{
+		Operations: protoOps,
+	}, nil
+}
+
+// WatchOperation streams an operation every time it changes until it
+// finished
+func (s *adminServer) WatchOperation(req *indexerv1.WatchOperationRequest, stream indexerv1.IndexerAdminService_WatchOperationServer) error {
+	if req.Id == "" {
+		return status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	updates, err := s.admin.WatchOperation(stream.Context(), req.Id)
+	if err != nil {
+		return adminError("failed to watch operation", err)
+	}
+
+	for op := range updates {
+		if err := stream.Send(convertOperationToProto(op)); err != nil {
+			return status.Errorf(codes.Internal, "failed to send operation: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// adminError converts an admin error to a gRPC status error
+func adminError(msg string, err error) error {
+	switch {
+	case errors.Is(err, repository.ErrChainNotFound), errors.Is(err, indexer.ErrOperationNotFound):
+		return status.Errorf(codes.NotFound, "%s: %v", msg, err)
+	case errors.Is(err, repository.ErrAlreadyExists):
+		return status.Errorf(codes.AlreadyExists, "%s: %v", msg, err)
+	case errors.Is(err, models.ErrInvalidChain), errors.Is(err, models.ErrInvalidChainID), errors.Is(err, models.ErrInvalidChainType), errors.Is(err, models.ErrInvalidBlockRange):
+		return status.Errorf(codes.InvalidArgument, "%s: %v", msg, err)
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return status.FromContextError(err).Err()
+	default:
+		return status.Errorf(codes.Internal, "%s: %v", msg, err)
+	}
+}
+
+// adminMethod returns true if method belongs to the admin service
+func adminMethod(method string) bool {
+	return strings.HasPrefix(method, "/"+indexerv1.IndexerAdminService_ServiceDesc.ServiceName+"/")
+}
+
+// authorizeAdmin checks that the call carries the admin token as an
+// "authorization: Bearer <token>" header
+func authorizeAdmin(ctx context.Context, token string) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get("authorization") {
+		given, ok := strings.CutPrefix(value, "Bearer ")
+		if ok && subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1 {
+			return nil
+		}
+	}
+
+	return status.Error(codes.Unauthenticated, "missing or invalid admin token")
+}
+
+// adminUnaryInterceptor rejects admin calls without the admin token
+func adminUnaryInterceptor(token string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if adminMethod(info.FullMethod) {
+			if err := authorizeAdmin(ctx, token); err != nil {
+				return nil, err
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// adminStreamInterceptor rejects admin streams without the admin token
+func adminStreamInterceptor(token string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if adminMethod(info.FullMethod) {
+			if err := authorizeAdmin(stream.Context(), token); err != nil {
+				return err
+			}
+		}
+		return handler(srv, stream)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	indexerv1 "github.com/sage-x-project/blockchain-indexer/api/proto/indexer/v1"
+	"github.com/sage-x-project/blockchain-indexer/pkg/application/indexer"
 	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
 )
 
@@ -19,9 +20,25 @@ func convertChainToProto(chain *models.Chain) *indexerv1.Chain {
 		LatestIndexedBlock: chain.LatestIndexedBlock,
 		LatestChainBlock:   chain.LatestChainBlock,
 		LastUpdated:        timestamppb.New(chain.LastUpdated),
+		Capabilities:       convertCapabilitiesToProto(chain.Capabilities),
 	}
 }
 
+// convertCapabilitiesToProto converts recorded adapter capabilities to their names
+func convertCapabilitiesToProto(capabilities *models.Capabilities) []string {
+	if capabilities == nil {
+		return nil
+	}
+
+	supported := capabilities.List()
+	names := make([]string, len(supported))
+	for i, capability := range supported {
+		names[i] = string(capability)
+	}
+
+	return names
+}
+
 // convertBlockToProto converts a domain Block to proto Block
 func convertBlockToProto(block *models.Block) *indexerv1.Block {
 	protoBlock := &indexerv1.Block{
@@ -37,6 +54,7 @@ func convertBlockToProto(block *models.Block) *indexerv1.Block {
 		TxCount:      int32(block.TxCount),
 		IndexedAt:    timestamppb.New(block.IndexedAt),
 		Transactions: make([]*indexerv1.Transaction, 0),
+		Finality:     string(block.Finality),
 	}
 
 	// Convert transactions if present
@@ -84,16 +102,116 @@ func convertTransactionToProto(tx *models.Transaction) *indexerv1.Transaction {
 		}
 	}
 
+	// Convert internal transactions if present
+	if len(tx.InternalTransactions) > 0 {
+		protoTx.InternalTransactions = make([]*indexerv1.InternalTransaction, len(tx.InternalTransactions))
+		for i, internal := range tx.InternalTransactions {
+			protoTx.InternalTransactions[i] = convertInternalTransactionToProto(internal)
+		}
+	}
+
 	return protoTx
 }
 
+// convertInternalTransactionToProto converts a domain InternalTransaction to
+// proto InternalTransaction
+func convertInternalTransactionToProto(internal *models.InternalTransaction) *indexerv1.InternalTransaction {
+	return &indexerv1.InternalTransaction{
+		Type:         internal.Type.String(),
+		From:         internal.From,
+		To:           internal.To,
+		Value:        internal.Value,
+		Gas:          internal.Gas,
+		GasUsed:      internal.GasUsed,
+		Input:        internal.Input,
+		Error:        internal.Error,
+		TraceAddress: internal.TraceAddress,
+	}
+}
+
 // convertLogToProto converts a domain Log to proto Log
 func convertLogToProto(log *models.Log) *indexerv1.Log {
 	return &indexerv1.Log{
-		Address:  log.Address,
-		Topics:   log.Topics,
-		Data:     log.Data,
-		LogIndex: uint32(log.Index),
+		Address:     log.Address,
+		Topics:      log.Topics,
+		Data:        log.Data,
+		LogIndex:    uint32(log.Index),
+		BlockNumber: log.BlockNumber,
+		BlockHash:   log.BlockHash,
+		TxHash:      log.TxHash,
+		TxIndex:     uint32(log.TxIndex),
+	}
+}
+
+// convertTokenTransferToProto converts a domain TokenTransfer to proto TokenTransfer
+func convertTokenTransferToProto(transfer *models.TokenTransfer) *indexerv1.TokenTransfer {
+	protoTransfer := &indexerv1.TokenTransfer{
+		ChainId:     transfer.ChainID,
+		Standard:    string(transfer.Standard),
+		Token:       transfer.Token,
+		From:        transfer.From,
+		To:          transfer.To,
+		Operator:    transfer.Operator,
+		Value:       transfer.Value,
+		TokenId:     transfer.TokenID,
+		BlockNumber: transfer.BlockNumber,
+		BlockHash:   transfer.BlockHash,
+		TxHash:      transfer.TxHash,
+		TxIndex:     uint32(transfer.TxIndex),
+		LogIndex:    uint32(transfer.LogIndex),
+		BatchIndex:  uint32(transfer.BatchIndex),
+	}
+
+	if transfer.Timestamp != nil {
+		protoTransfer.BlockTimestamp = timestamppb.New(transfer.Timestamp.Time)
+	}
+
+	return protoTransfer
+}
+
+// convertOperationToProto converts an admin operation to proto Operation
+func convertOperationToProto(op *indexer.Operation) *indexerv1.Operation {
+	protoOp := &indexerv1.Operation{
+		Id:        op.ID,
+		Kind:      convertOperationKindToProto(op.Kind),
+		ChainId:   op.ChainID,
+		State:     convertOperationStateToProto(op.State),
+		Done:      op.Done,
+		Total:     op.Total,
+		Error:     op.Error,
+		CreatedAt: timestamppb.New(op.CreatedAt),
+		UpdatedAt: timestamppb.New(op.UpdatedAt),
+	}
+	if op.FinishedAt != nil {
+		protoOp.FinishedAt = timestamppb.New(*op.FinishedAt)
+	}
+
+	return protoOp
+}
+
+// convertOperationKindToProto converts an operation kind to proto OperationKind
+func convertOperationKindToProto(kind indexer.OperationKind) indexerv1.OperationKind {
+	switch kind {
+	case indexer.OperationReindexRange:
+		return indexerv1.OperationKind_OPERATION_KIND_REINDEX_RANGE
+	case indexer.OperationRecoverGaps:
+		return indexerv1.OperationKind_OPERATION_KIND_RECOVER_GAPS
+	default:
+		return indexerv1.OperationKind_OPERATION_KIND_UNSPECIFIED
+	}
+}
+
+// convertOperationStateToProto converts an operation state to proto OperationState
+func convertOperationStateToProto(state indexer.OperationState) indexerv1.OperationState {
+	switch state {
+	case indexer.OperationRunning:
+		return indexerv1.OperationState_OPERATION_STATE_RUNNING
+	case indexer.OperationSucceeded:
+		return indexerv1.OperationState_OPERATION_STATE_SUCCEEDED
+	case indexer.OperationFailed:
+		return indexerv1.OperationState_OPERATION_STATE_FAILED
+	default:
+		return indexerv1.OperationState_OPERATION_STATE_UNSPECIFIED
 	}
 }
 
@@ -123,7 +241,7 @@ func convertChainStatusToProto(status models.ChainStatus) indexerv1.ChainStatus
 	case models.ChainStatusError:
 		return indexerv1.ChainStatus_CHAIN_STATUS_ERROR
 	case models.ChainStatusPaused:
-		return indexerv1.ChainStatus_CHAIN_STATUS_INACTIVE
+		return indexerv1.ChainStatus_CHAIN_STATUS_PAUSED
 	default:
 		return indexerv1.ChainStatus_CHAIN_STATUS_UNSPECIFIED
 	}
@@ -2,6 +2,8 @@ package server
 
 import (
 	"context"
+	"strconv"
+	"strings"
 	"time"
 
 	"google.golang.org/grpc/codes"
@@ -56,6 +58,14 @@ func (s *Server) GetBlock(ctx context.Context, req *indexerv1.GetBlockRequest) (
 		return nil, status.Error(codes.InvalidArgument, "chain_id is required")
 	}
 
+	limit, err := s.parseFinality(ctx, req.ChainId, req.Finality)
+	if err != nil {
+		return nil, err
+	}
+	if !limit.Allows(req.Number) {
+		return nil, status.Errorf(codes.NotFound, "block %d is not %s", req.Number, limit.Finality)
+	}
+
 	block, err := s.blockRepo.GetBlock(ctx, req.ChainId, req.Number)
 	if err != nil {
 		if err == repository.ErrNotFound {
@@ -78,6 +88,11 @@ func (s *Server) GetBlockByHash(ctx context.Context, req *indexerv1.GetBlockByHa
 		return nil, status.Error(codes.InvalidArgument, "hash is required")
 	}
 
+	limit, err := s.parseFinality(ctx, req.ChainId, req.Finality)
+	if err != nil {
+		return nil, err
+	}
+
 	block, err := s.blockRepo.GetBlockByHash(ctx, req.ChainId, req.Hash)
 	if err != nil {
 		if err == repository.ErrNotFound {
@@ -85,6 +100,9 @@ func (s *Server) GetBlockByHash(ctx context.Context, req *indexerv1.GetBlockByHa
 		}
 		return nil, status.Errorf(codes.Internal, "failed to get block: %v", err)
 	}
+	if !limit.Allows(block.Number) {
+		return nil, status.Errorf(codes.NotFound, "block %s is not %s", req.Hash, limit.Finality)
+	}
 
 	return &indexerv1.GetBlockByHashResponse{
 		Block: convertBlockToProto(block),
@@ -105,15 +123,24 @@ func (s *Server) ListBlocks(ctx context.Context, req *indexerv1.ListBlocksReques
 		pageSize = 1000
 	}
 
-	var blocks []*models.Block
-	var err error
+	limit, err := s.parseFinality(ctx, req.ChainId, req.Finality)
+	if err != nil {
+		return nil, err
+	}
 
+	start, end := uint64(0), uint64(pageSize)
 	if req.StartBlock > 0 && req.EndBlock > 0 {
-		blocks, err = s.blockRepo.GetBlocks(ctx, req.ChainId, req.StartBlock, req.EndBlock)
-	} else {
-		// For now, implement simple pagination without cursor
-		// In production, you would implement proper cursor-based pagination
-		blocks, err = s.blockRepo.GetBlocks(ctx, req.ChainId, 0, uint64(pageSize))
+		start, end = req.StartBlock, req.EndBlock
+	}
+	// For now, implement simple pagination without cursor
+	// In production, you would implement proper cursor-based pagination
+	if limit.Limited {
+		end = min(end, limit.Height)
+	}
+
+	var blocks []*models.Block
+	if start <= end {
+		blocks, err = s.blockRepo.GetBlocks(ctx, req.ChainId, start, end)
 	}
 
 	if err != nil {
@@ -137,14 +164,25 @@ func (s *Server) GetLatestBlock(ctx context.Context, req *indexerv1.GetLatestBlo
 		return nil, status.Error(codes.InvalidArgument, "chain_id is required")
 	}
 
+	limit, err := s.parseFinality(ctx, req.ChainId, req.Finality)
+	if err != nil {
+		return nil, err
+	}
+
 	latestHeight, err := s.blockRepo.GetLatestHeight(ctx, req.ChainId)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get latest height: %v", err)
 	}
+	if !limit.Allows(latestHeight) {
+		latestHeight = limit.Height
+	}
 
 	block, err := s.blockRepo.GetBlock(ctx, req.ChainId, latestHeight)
 	if err != nil {
 		if err == repository.ErrNotFound {
+			if limit.Limited {
+				return nil, status.Errorf(codes.NotFound, "no %s blocks indexed yet", limit.Finality)
+			}
 			return nil, status.Error(codes.NotFound, "no blocks indexed yet")
 		}
 		return nil, status.Errorf(codes.Internal, "failed to get block: %v", err)
@@ -164,6 +202,11 @@ func (s *Server) GetTransaction(ctx context.Context, req *indexerv1.GetTransacti
 		return nil, status.Error(codes.InvalidArgument, "hash is required")
 	}
 
+	limit, err := s.parseFinality(ctx, req.ChainId, req.Finality)
+	if err != nil {
+		return nil, err
+	}
+
 	tx, err := s.transactionRepo.GetTransaction(ctx, req.ChainId, req.Hash)
 	if err != nil {
 		if err == repository.ErrNotFound {
@@ -171,6 +214,9 @@ func (s *Server) GetTransaction(ctx context.Context, req *indexerv1.GetTransacti
 		}
 		return nil, status.Errorf(codes.Internal, "failed to get transaction: %v", err)
 	}
+	if !limit.Allows(tx.BlockNumber) {
+		return nil, status.Errorf(codes.NotFound, "transaction %s is not %s", req.Hash, limit.Finality)
+	}
 
 	return &indexerv1.GetTransactionResponse{
 		Transaction: convertTransactionToProto(tx),
@@ -183,9 +229,17 @@ func (s *Server) ListTransactionsByBlock(ctx context.Context, req *indexerv1.Lis
 		return nil, status.Error(codes.InvalidArgument, "chain_id is required")
 	}
 
-	txs, err := s.transactionRepo.GetTransactionsByBlock(ctx, req.ChainId, req.BlockNumber)
+	limit, err := s.parseFinality(ctx, req.ChainId, req.Finality)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get transactions: %v", err)
+		return nil, err
+	}
+
+	var txs []*models.Transaction
+	if limit.Allows(req.BlockNumber) {
+		txs, err = s.transactionRepo.GetTransactionsByBlock(ctx, req.ChainId, req.BlockNumber)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get transactions: %v", err)
+		}
 	}
 
 	protoTxs := make([]*indexerv1.Transaction, len(txs))
@@ -220,14 +274,24 @@ func (s *Server) ListTransactionsByAddress(ctx context.Context, req *indexerv1.L
 		Offset: 0,
 	}
 
-	txs, err := s.transactionRepo.GetTransactionsByAddress(ctx, req.ChainId, req.Address, pagination)
+	limit, err := s.parseFinality(ctx, req.ChainId, req.Finality)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := &models.TransactionFilter{
+		ChainID:        &req.ChainId,
+		Address:        &req.Address,
+		BlockNumberMax: limit.MaxHeight(),
+	}
+	txs, err := s.transactionRepo.QueryTransactions(ctx, filter, pagination)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get transactions: %v", err)
 	}
 
-	protoTxs := make([]*indexerv1.Transaction, len(txs))
-	for i, tx := range txs {
-		protoTxs[i] = convertTransactionToProto(tx)
+	protoTxs := make([]*indexerv1.Transaction, 0, len(txs))
+	for _, tx := range txs {
+		protoTxs = append(protoTxs, convertTransactionToProto(tx))
 	}
 
 	return &indexerv1.ListTransactionsByAddressResponse{
@@ -236,6 +300,151 @@ func (s *Server) ListTransactionsByAddress(ctx context.Context, req *indexerv1.L
 	}, nil
 }
 
+// ListLogs lists the logs matching a filter in chain order. The page token
+// is the number of matching logs already returned.
+func (s *Server) ListLogs(ctx context.Context, req *indexerv1.ListLogsRequest) (*indexerv1.ListLogsResponse, error) {
+	if req.ChainId == "" {
+		return nil, status.Error(codes.InvalidArgument, "chain_id is required")
+	}
+
+	if err := s.requireCapability(ctx, req.ChainId, models.CapabilityLogs); err != nil {
+		return nil, err
+	}
+
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+	if pageSize > 1000 {
+		pageSize = 1000
+	}
+
+	offset := 0
+	if req.PageToken != "" {
+		parsed, err := strconv.Atoi(req.PageToken)
+		if err != nil || parsed < 0 {
+			return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+		}
+		offset = parsed
+	}
+
+	filter := &models.LogFilter{
+		ChainID:   req.ChainId,
+		FromBlock: req.FromBlock,
+		ToBlock:   req.ToBlock,
+	}
+	if req.Address != "" {
+		filter.Address = &req.Address
+	}
+	for _, topic := range req.Topics {
+		filter.Topics = append(filter.Topics, topic.GetValues())
+	}
+
+	if err := filter.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	pagination := &models.PaginationOptions{
+		Limit:  int(pageSize),
+		Offset: offset,
+	}
+
+	logs, err := s.logRepo.QueryLogs(ctx, filter, pagination)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get logs: %v", err)
+	}
+
+	protoLogs := make([]*indexerv1.Log, len(logs))
+	for i, log := range logs {
+		protoLogs[i] = convertLogToProto(log)
+	}
+
+	var nextPageToken string
+	if len(logs) == int(pageSize) {
+		nextPageToken = strconv.Itoa(offset + len(logs))
+	}
+
+	return &indexerv1.ListLogsResponse{
+		Logs:          protoLogs,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
+// ListTokenTransfers lists token transfers, optionally sent or received by
+// an address. Token transfers are decoded from logs, so chains without logs
+// answer Unimplemented.
+func (s *Server) ListTokenTransfers(ctx context.Context, req *indexerv1.ListTokenTransfersRequest) (*indexerv1.ListTokenTransfersResponse, error) {
+	if req.ChainId == "" {
+		return nil, status.Error(codes.InvalidArgument, "chain_id is required")
+	}
+
+	if err := s.requireCapability(ctx, req.ChainId, models.CapabilityLogs); err != nil {
+		return nil, err
+	}
+
+	pageSize := req.PageSize
+	if pageSize <= 0 {
+		pageSize = 100
+	}
+	if pageSize > 1000 {
+		pageSize = 1000
+	}
+
+	offset := 0
+	if req.PageToken != "" {
+		parsed, err := strconv.Atoi(req.PageToken)
+		if err != nil || parsed < 0 {
+			return nil, status.Error(codes.InvalidArgument, "invalid page_token")
+		}
+		offset = parsed
+	}
+
+	filter := &models.TokenTransferFilter{
+		ChainID:   req.ChainId,
+		FromBlock: req.FromBlock,
+		ToBlock:   req.ToBlock,
+	}
+	if req.Address != "" {
+		filter.Address = &req.Address
+	}
+	if req.Token != "" {
+		filter.Token = &req.Token
+	}
+	if req.Standard != "" {
+		standard := models.TokenStandard(strings.ToLower(req.Standard))
+		filter.Standard = &standard
+	}
+
+	if err := filter.Validate(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	pagination := &models.PaginationOptions{
+		Limit:  int(pageSize),
+		Offset: offset,
+	}
+
+	transfers, err := s.transferRepo.QueryTokenTransfers(ctx, filter, pagination)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get token transfers: %v", err)
+	}
+
+	protoTransfers := make([]*indexerv1.TokenTransfer, len(transfers))
+	for i, transfer := range transfers {
+		protoTransfers[i] = convertTokenTransferToProto(transfer)
+	}
+
+	var nextPageToken string
+	if len(transfers) == int(pageSize) {
+		nextPageToken = strconv.Itoa(offset + len(transfers))
+	}
+
+	return &indexerv1.ListTokenTransfersResponse{
+		Transfers:     protoTransfers,
+		NextPageToken: nextPageToken,
+	}, nil
+}
+
 // GetProgress retrieves indexing progress for a chain
 func (s *Server) GetProgress(ctx context.Context, req *indexerv1.GetProgressRequest) (*indexerv1.GetProgressResponse, error) {
 	if req.ChainId == "" {
@@ -300,10 +509,14 @@ func (s *Server) ListGaps(ctx context.Context, req *indexerv1.ListGapsRequest) (
 		}, nil
 	}
 
-	// Detect gaps
-	gaps, err := recovery.DetectGaps(ctx, req.ChainId)
+	// List gaps from the indexed ranges, starting at the chain's start block
+	var startBlock uint64
+	if chain, err := s.chainRepo.GetChain(ctx, req.ChainId); err == nil && chain != nil {
+		startBlock = chain.StartBlock
+	}
+	gaps, err := recovery.ListGaps(ctx, req.ChainId, startBlock)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to detect gaps: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to list gaps: %v", err)
 	}
 
 	// Convert to proto
@@ -491,3 +704,33 @@ func (s *Server) StreamProgress(req *indexerv1.StreamProgressRequest, stream ind
 		}
 	}
 }
+
+// requireCapability returns an Unimplemented error if the adapter of the
+// chain does not support capability. Unknown chains are left to the query.
+func (s *Server) requireCapability(ctx context.Context, chainID string, capability models.Capability) error {
+	chain, err := s.chainRepo.GetChain(ctx, chainID)
+	if err != nil || chain == nil || chain.Supports(capability) {
+		return nil
+	}
+
+	return status.Errorf(codes.Unimplemented, "chain %s does not support %s", chainID, capability)
+}
+
+// parseFinality resolves the finality of a request against the heads
+// recorded on the chain
+func (s *Server) parseFinality(ctx context.Context, chainID, finality string) (models.FinalityLimit, error) {
+	level, err := models.ParseFinality(finality)
+	if err != nil {
+		return models.FinalityLimit{}, status.Error(codes.InvalidArgument, "finality must be finalized, safe or latest")
+	}
+	if level == models.FinalityLatest {
+		return models.NewFinalityLimit(level, nil), nil
+	}
+
+	chain, err := s.chainRepo.GetChain(ctx, chainID)
+	if err != nil {
+		// Unknown chains have no finalized blocks
+		return models.NewFinalityLimit(level, nil), nil
+	}
+	return models.NewFinalityLimit(level, chain), nil
+}
@@ -23,6 +23,8 @@ type Server struct {
 	listener         net.Listener
 	blockRepo        repository.BlockRepository
 	transactionRepo  repository.TransactionRepository
+	logRepo          repository.LogRepository
+	transferRepo     repository.TokenTransferRepository
 	chainRepo        repository.ChainRepository
 	gapRecovery      map[string]*indexer.GapRecovery
 	statsCollector   *statistics.Collector
@@ -35,11 +37,18 @@ type Config struct {
 	Port             int
 	BlockRepo        repository.BlockRepository
 	TransactionRepo  repository.TransactionRepository
+	LogRepo          repository.LogRepository
+	TransferRepo     repository.TokenTransferRepository
 	ChainRepo        repository.ChainRepository
 	GapRecovery      map[string]*indexer.GapRecovery
 	StatsCollector   *statistics.Collector
 	EventBus         event.EventBus
 	EnableReflection bool
+
+	// Admin serves the admin service when set, to calls that carry
+	// AdminToken
+	Admin      *indexer.Admin
+	AdminToken string
 }
 
 // NewServer creates a new gRPC server
@@ -53,16 +62,26 @@ func NewServer(cfg Config) (*Server, error) {
 		return nil, fmt.Errorf("failed to listen: %w", err)
 	}
 
-	grpcServer := grpc.NewServer(
+	opts := []grpc.ServerOption{
 		grpc.MaxRecvMsgSize(10 * 1024 * 1024), // 10MB
 		grpc.MaxSendMsgSize(10 * 1024 * 1024), // 10MB
-	)
+	}
+	if cfg.Admin != nil {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(adminUnaryInterceptor(cfg.AdminToken)),
+			grpc.ChainStreamInterceptor(adminStreamInterceptor(cfg.AdminToken)),
+		)
+	}
+
+	grpcServer := grpc.NewServer(opts...)
 
 	s := &Server{
 		grpcServer:      grpcServer,
 		listener:        listener,
 		blockRepo:       cfg.BlockRepo,
 		transactionRepo: cfg.TransactionRepo,
+		logRepo:         cfg.LogRepo,
+		transferRepo:    cfg.TransferRepo,
 		chainRepo:       cfg.ChainRepo,
 		gapRecovery:     cfg.GapRecovery,
 		statsCollector:  cfg.StatsCollector,
@@ -72,6 +91,9 @@ func NewServer(cfg Config) (*Server, error) {
 
 	// Register the service
 	indexerv1.RegisterIndexerServiceServer(grpcServer, s)
+	if cfg.Admin != nil {
+		indexerv1.RegisterIndexerAdminServiceServer(grpcServer, &adminServer{admin: cfg.Admin})
+	}
 
 	// Enable reflection for tools like grpcurl
 	if cfg.EnableReflection {
@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/sage-x-project/blockchain-indexer/pkg/application/indexer"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/models"
+	"github.com/sage-x-project/blockchain-indexer/pkg/domain/repository"
+	"go.uber.org/zap"
+)
+
+// Admin handlers
+
+// PauseChain handles POST /admin/chains/{chainID}/pause
+func (h *Handler) PauseChain(w http.ResponseWriter, r *http.Request) {
+	chainID := chi.URLParam(r, "chainID")
+
+	chain, err := h.admin.PauseChain(r.Context(), chainID)
+	if err != nil {
+		h.respondAdminError(w, "failed to pause chain", chainID, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, chainResponse(chain))
+}
+
+// ResumeChain handles POST /admin/chains/{chainID}/resume
+func (h *Handler) ResumeChain(w http.ResponseWriter, r *http.Request) {
+	chainID := chi.URLParam(r, "chainID")
+
+	chain, err := h.admin.ResumeChain(r.Context(), chainID)
+	if err != nil {
+		h.respondAdminError(w, "failed to resume chain", chainID, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, chainResponse(chain))
+}
+
+// AddChain handles POST /admin/chains
+func (h *Handler) AddChain(w http.ResponseWriter, r *http.Request) {
+	var req AddChainRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.ChainID == "" {
+		h.respondError(w, http.StatusBadRequest, "chain_id is required")
+		return
+	}
+
+	chain := models.NewChain(models.ChainType(req.ChainType), req.ChainID, req.Name)
+	chain.Network = req.Network
+	chain.RPCEndpoints = req.RPCEndpoints
+	chain.WSEndpoints = req.WSEndpoints
+	chain.StartBlock = req.StartBlock
+	chain.ConfirmationBlocks = req.ConfirmationBlocks
+	if req.BatchSize > 0 {
+		chain.BatchSize = req.BatchSize
+	}
+	if req.Workers > 0 {
+		chain.Workers = req.Workers
+	}
+
+	added, err := h.admin.AddChain(r.Context(), chain)
+	if err != nil {
+		h.respondAdminError(w, "failed to add chain", req.ChainID, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusCreated, chainResponse(added))
+}
+
+// RemoveChain handles DELETE /admin/chains/{chainID}
+func (h *Handler) RemoveChain(w http.ResponseWriter, r *http.Request) {
+	chainID := chi.URLParam(r, "chainID")
+
+	if err := h.admin.RemoveChain(r.Context(), chainID); err != nil {
+		h.respondAdminError(w, "failed to remove chain", chainID, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ReindexRange handles POST /admin/chains/{chainID}/reindex
+func (h *Handler) ReindexRange(w http.ResponseWriter, r *http.Request) {
+	chainID := chi.URLParam(r, "chainID")
+
+	var req ReindexRangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	op, err := h.admin.ReindexRange(chainID, req.StartBlock, req.EndBlock)
+	if err != nil {
+		h.respondAdminError(w, "failed to start reindexing", chainID, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusAccepted, op)
+}
+
+// RecoverGaps handles POST /admin/chains/{chainID}/recover-gaps
+func (h *Handler) RecoverGaps(w http.ResponseWriter, r *http.Request) {
+	chainID := chi.URLParam(r, "chainID")
+
+	op, err := h.admin.RecoverGaps(chainID)
+	if err != nil {
+		h.respondAdminError(w, "failed to start gap recovery", chainID, err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusAccepted, op)
+}
+
+// ListOperations handles GET /admin/operations
+func (h *Handler) ListOperations(w http.ResponseWriter, r *http.Request) {
+	ops := h.admin.ListOperations(r.URL.Query().Get("chain_id"))
+
+	h.respondJSON(w, http.StatusOK, map[string]interface{}{
+		"operations": ops,
+		"count":      len(ops),
+	})
+}
+
+// GetOperation handles GET /admin/operations/{operationID}
+func (h *Handler) GetOperation(w http.ResponseWriter, r *http.Request) {
+	op, err := h.admin.GetOperation(chi.URLParam(r, "operationID"))
+	if err != nil {
+		h.respondAdminError(w, "failed to get operation", "", err)
+		return
+	}
+
+	h.respondJSON(w, http.StatusOK, op)
+}
+
+// WatchOperation handles GET /admin/operations/{operationID}/watch. It
+// streams the operation as server-sent events every time it changes, until
+// it finished or the request times out.
+func (h *Handler) WatchOperation(w http.ResponseWriter, r *http.Request) {
+	updates, err := h.admin.WatchOperation(r.Context(), chi.URLParam(r, "operationID"))
+	if err != nil {
+		h.respondAdminError(w, "failed to watch operation", "", err)
+		return
+	}
+
+	// The stream outlives the server's write timeout
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		h.logger.Warn("failed to clear write deadline", zap.Error(err))
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+
+	for op := range updates {
+		data, err := json.Marshal(op)
+		if err != nil {
+			h.logger.Error("failed to encode operation", zap.String("operation_id", op.ID), zap.Error(err))
+			return
+		}
+		if _, err := fmt.Fprintf(w, "event: operation\ndata: %s\n\n", data); err != nil {
+			return
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// respondAdminError responds with the status that matches an admin error
+func (h *Handler) respondAdminError(w http.ResponseWriter, msg, chainID string, err error) {
+	switch {
+	case errors.Is(err, repository.ErrChainNotFound):
+		h.respondError(w, http.StatusNotFound, "Chain not found")
+	case errors.Is(err, indexer.ErrOperationNotFound):
+		h.respondError(w, http.StatusNotFound, "Operation not found")
+	case errors.Is(err, repository.ErrAlreadyExists):
+		h.respondError(w, http.StatusConflict, "Chain already exists")
+	case errors.Is(err, models.ErrInvalidChain), errors.Is(err, models.ErrInvalidChainID), errors.Is(err, models.ErrInvalidChainType), errors.Is(err, models.ErrInvalidBlockRange):
+		h.respondError(w, http.StatusBadRequest, err.Error())
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		h.respondError(w, http.StatusServiceUnavailable, "Request canceled")
+	default:
+		h.logger.Error(msg, zap.String("chain_id", chainID), zap.Error(err))
+		h.respondError(w, http.StatusInternalServerError, msg)
+	}
+}
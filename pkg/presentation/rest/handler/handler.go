@@ -2,9 +2,11 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -20,10 +22,13 @@ import (
 type Handler struct {
 	blockRepo       repository.BlockRepository
 	txRepo          repository.TransactionRepository
+	logRepo         repository.LogRepository
+	transferRepo    repository.TokenTransferRepository
 	chainRepo       repository.ChainRepository
 	progressTracker *indexer.ProgressTracker
 	gapRecovery     map[string]*indexer.GapRecovery
 	statsCollector  *statistics.Collector
+	admin           *indexer.Admin
 	logger          *logger.Logger
 	startTime       time.Time
 }
@@ -32,6 +37,8 @@ type Handler struct {
 func NewHandler(
 	blockRepo repository.BlockRepository,
 	txRepo repository.TransactionRepository,
+	logRepo repository.LogRepository,
+	transferRepo repository.TokenTransferRepository,
 	chainRepo repository.ChainRepository,
 	progressTracker *indexer.ProgressTracker,
 	gapRecovery map[string]*indexer.GapRecovery,
@@ -41,6 +48,8 @@ func NewHandler(
 	return &Handler{
 		blockRepo:       blockRepo,
 		txRepo:          txRepo,
+		logRepo:         logRepo,
+		transferRepo:    transferRepo,
 		chainRepo:       chainRepo,
 		progressTracker: progressTracker,
 		gapRecovery:     gapRecovery,
@@ -50,6 +59,11 @@ func NewHandler(
 	}
 }
 
+// SetAdmin sets the admin that serves the admin routes
+func (h *Handler) SetAdmin(admin *indexer.Admin) {
+	h.admin = admin
+}
+
 // Helper functions
 
 func (h *Handler) respondJSON(w http.ResponseWriter, status int, data interface{}) {
@@ -66,6 +80,73 @@ func (h *Handler) respondError(w http.ResponseWriter, status int, message string
 	})
 }
 
+// requireCapability responds with 501 Not Implemented and returns false if
+// the adapter of the chain does not support capability. Unknown chains are
+// left to the query itself.
+func (h *Handler) requireCapability(w http.ResponseWriter, r *http.Request, chainID string, capability models.Capability) bool {
+	chain, err := h.chainRepo.GetChain(r.Context(), chainID)
+	if err != nil || chain == nil || chain.Supports(capability) {
+		return true
+	}
+
+	h.respondError(w, http.StatusNotImplemented, fmt.Sprintf("Chain %s does not support %s", chainID, capability))
+	return false
+}
+
+// parseFinality reads the finality query parameter and resolves it against
+// the heads recorded on the chain. It responds with 400 Bad Request and
+// returns false for an invalid value.
+func (h *Handler) parseFinality(w http.ResponseWriter, r *http.Request, chainID string) (models.FinalityLimit, bool) {
+	finality, err := models.ParseFinality(r.URL.Query().Get("finality"))
+	if err != nil {
+		h.respondError(w, http.StatusBadRequest, "Invalid finality, must be finalized, safe or latest")
+		return models.FinalityLimit{}, false
+	}
+	if finality == models.FinalityLatest {
+		return models.NewFinalityLimit(finality, nil), true
+	}
+
+	chain, err := h.chainRepo.GetChain(r.Context(), chainID)
+	if err != nil {
+		// Unknown chains have no finalized blocks
+		return models.NewFinalityLimit(finality, nil), true
+	}
+	return models.NewFinalityLimit(finality, chain), true
+}
+
+// capabilityNames lists the recorded capabilities of a chain
+func capabilityNames(chain *models.Chain) []string {
+	if chain.Capabilities == nil {
+		return nil
+	}
+
+	capabilities := chain.Capabilities.List()
+	names := make([]string, len(capabilities))
+	for i, capability := range capabilities {
+		names[i] = string(capability)
+	}
+
+	return names
+}
+
+// chainResponse converts a chain to its API representation
+func chainResponse(chain *models.Chain) ChainResponse {
+	return ChainResponse{
+		ChainID:            chain.ChainID,
+		ChainType:          string(chain.ChainType),
+		Name:               chain.Name,
+		Network:            chain.Network,
+		Status:             string(chain.Status),
+		StartBlock:         chain.StartBlock,
+		LatestIndexedBlock: chain.LatestIndexedBlock,
+		LatestChainBlock:   chain.LatestChainBlock,
+		SafeBlock:          chain.SafeBlock,
+		FinalizedBlock:     chain.FinalizedBlock,
+		LastUpdated:        chain.LastUpdated,
+		Capabilities:       capabilityNames(chain),
+	}
+}
+
 // Health check
 
 func (h *Handler) Health(w http.ResponseWriter, r *http.Request) {
@@ -111,7 +192,10 @@ func (h *Handler) GetChain(w http.ResponseWriter, r *http.Request) {
 		StartBlock:         chain.StartBlock,
 		LatestIndexedBlock: chain.LatestIndexedBlock,
 		LatestChainBlock:   chain.LatestChainBlock,
+		SafeBlock:          chain.SafeBlock,
+		FinalizedBlock:     chain.FinalizedBlock,
 		LastUpdated:        chain.LastUpdated,
+		Capabilities:       capabilityNames(chain),
 	}
 
 	h.respondJSON(w, http.StatusOK, response)
@@ -129,6 +213,11 @@ func (h *Handler) GetBlock(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	limit, ok := h.parseFinality(w, r, chainID)
+	if !ok {
+		return
+	}
+
 	block, err := h.blockRepo.GetBlock(r.Context(), chainID, blockNum)
 	if err != nil {
 		h.logger.Error("failed to get block",
@@ -145,6 +234,11 @@ func (h *Handler) GetBlock(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !limit.Allows(block.Number) {
+		h.respondError(w, http.StatusNotFound, fmt.Sprintf("Block %d is not %s", block.Number, limit.Finality))
+		return
+	}
+
 	response := h.convertBlock(block)
 	h.respondJSON(w, http.StatusOK, response)
 }
@@ -153,6 +247,11 @@ func (h *Handler) GetBlockByHash(w http.ResponseWriter, r *http.Request) {
 	chainID := chi.URLParam(r, "chainID")
 	hash := chi.URLParam(r, "hash")
 
+	limit, ok := h.parseFinality(w, r, chainID)
+	if !ok {
+		return
+	}
+
 	block, err := h.blockRepo.GetBlockByHash(r.Context(), chainID, hash)
 	if err != nil {
 		h.logger.Error("failed to get block by hash",
@@ -169,13 +268,25 @@ func (h *Handler) GetBlockByHash(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !limit.Allows(block.Number) {
+		h.respondError(w, http.StatusNotFound, fmt.Sprintf("Block %d is not %s", block.Number, limit.Finality))
+		return
+	}
+
 	response := h.convertBlock(block)
 	h.respondJSON(w, http.StatusOK, response)
 }
 
+// GetLatestBlock returns the highest indexed block, or the highest safe or
+// finalized one when the finality parameter asks for it
 func (h *Handler) GetLatestBlock(w http.ResponseWriter, r *http.Request) {
 	chainID := chi.URLParam(r, "chainID")
 
+	limit, ok := h.parseFinality(w, r, chainID)
+	if !ok {
+		return
+	}
+
 	block, err := h.blockRepo.GetLatestBlock(r.Context(), chainID)
 	if err != nil {
 		h.logger.Error("failed to get latest block",
@@ -191,6 +302,23 @@ func (h *Handler) GetLatestBlock(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !limit.Allows(block.Number) {
+		block, err = h.blockRepo.GetBlock(r.Context(), chainID, limit.Height)
+		if errors.Is(err, repository.ErrBlockNotFound) {
+			h.respondError(w, http.StatusNotFound, fmt.Sprintf("No %s blocks found", limit.Finality))
+			return
+		}
+		if err != nil {
+			h.logger.Error("failed to get latest block",
+				zap.String("chain_id", chainID),
+				zap.String("finality", string(limit.Finality)),
+				zap.Error(err),
+			)
+			h.respondError(w, http.StatusInternalServerError, "Failed to retrieve latest block")
+			return
+		}
+	}
+
 	response := h.convertBlock(block)
 	h.respondJSON(w, http.StatusOK, response)
 }
@@ -232,6 +360,18 @@ func (h *Handler) ListBlocks(w http.ResponseWriter, r *http.Request) {
 		end = start + uint64(limit)
 	}
 
+	finality, ok := h.parseFinality(w, r, chainID)
+	if !ok {
+		return
+	}
+	if finality.Limited && end > finality.Height {
+		end = finality.Height
+	}
+	if start > end {
+		h.respondJSON(w, http.StatusOK, []BlockResponse{})
+		return
+	}
+
 	blocks, err := h.blockRepo.GetBlocks(r.Context(), chainID, start, end)
 	if err != nil {
 		h.logger.Error("failed to list blocks",
@@ -256,6 +396,11 @@ func (h *Handler) GetTransaction(w http.ResponseWriter, r *http.Request) {
 	chainID := chi.URLParam(r, "chainID")
 	hash := chi.URLParam(r, "hash")
 
+	limit, ok := h.parseFinality(w, r, chainID)
+	if !ok {
+		return
+	}
+
 	tx, err := h.txRepo.GetTransaction(r.Context(), chainID, hash)
 	if err != nil {
 		h.logger.Error("failed to get transaction",
@@ -272,6 +417,11 @@ func (h *Handler) GetTransaction(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !limit.Allows(tx.BlockNumber) {
+		h.respondError(w, http.StatusNotFound, fmt.Sprintf("Transaction %s is not %s", hash, limit.Finality))
+		return
+	}
+
 	response := h.convertTransaction(tx)
 	h.respondJSON(w, http.StatusOK, response)
 }
@@ -286,6 +436,15 @@ func (h *Handler) ListTransactionsByBlock(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	limit, ok := h.parseFinality(w, r, chainID)
+	if !ok {
+		return
+	}
+	if !limit.Allows(blockNum) {
+		h.respondJSON(w, http.StatusOK, []TransactionResponse{})
+		return
+	}
+
 	txs, err := h.txRepo.GetTransactionsByBlock(r.Context(), chainID, blockNum)
 	if err != nil {
 		h.logger.Error("failed to list transactions by block",
@@ -323,7 +482,17 @@ func (h *Handler) ListTransactionsByAddress(w http.ResponseWriter, r *http.Reque
 		Offset: 0,
 	}
 
-	txs, err := h.txRepo.GetTransactionsByAddress(r.Context(), chainID, address, paginationOpts)
+	finality, ok := h.parseFinality(w, r, chainID)
+	if !ok {
+		return
+	}
+
+	filter := &models.TransactionFilter{
+		ChainID:        &chainID,
+		Address:        &address,
+		BlockNumberMax: finality.MaxHeight(),
+	}
+	txs, err := h.txRepo.QueryTransactions(r.Context(), filter, paginationOpts)
 	if err != nil {
 		h.logger.Error("failed to list transactions by address",
 			zap.String("chain_id", chainID),
@@ -342,6 +511,186 @@ func (h *Handler) ListTransactionsByAddress(w http.ResponseWriter, r *http.Reque
 	h.respondJSON(w, http.StatusOK, responses)
 }
 
+// Log handlers
+
+// maxLogTopics is the number of topic positions a log can have
+const maxLogTopics = 4
+
+// ListLogs handles GET /chains/{chainID}/logs. Logs can be filtered by
+// emitting address, by topic position with topic0 to topic3 (several values
+// separated by commas match any of them), and by from_block and to_block.
+// Chains whose adapter produces no logs answer 501 Not Implemented.
+func (h *Handler) ListLogs(w http.ResponseWriter, r *http.Request) {
+	chainID := chi.URLParam(r, "chainID")
+	query := r.URL.Query()
+
+	if !h.requireCapability(w, r, chainID, models.CapabilityLogs) {
+		return
+	}
+
+	filter := &models.LogFilter{ChainID: chainID}
+
+	if address := query.Get("address"); address != "" {
+		filter.Address = &address
+	}
+
+	for i := 0; i < maxLogTopics; i++ {
+		var values []string
+		if param := query.Get(fmt.Sprintf("topic%d", i)); param != "" {
+			values = strings.Split(param, ",")
+		}
+		filter.Topics = append(filter.Topics, values)
+	}
+
+	if fromStr := query.Get("from_block"); fromStr != "" {
+		parsed, err := strconv.ParseUint(fromStr, 10, 64)
+		if err != nil {
+			h.respondError(w, http.StatusBadRequest, "Invalid from_block")
+			return
+		}
+		filter.FromBlock = &parsed
+	}
+
+	if toStr := query.Get("to_block"); toStr != "" {
+		parsed, err := strconv.ParseUint(toStr, 10, 64)
+		if err != nil {
+			h.respondError(w, http.StatusBadRequest, "Invalid to_block")
+			return
+		}
+		filter.ToBlock = &parsed
+	}
+
+	if err := filter.Validate(); err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limit := 10
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+
+	offset := 0
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		parsed, err := strconv.Atoi(offsetStr)
+		if err == nil && parsed >= 0 {
+			offset = parsed
+		}
+	}
+
+	logs, err := h.logRepo.QueryLogs(r.Context(), filter, &models.PaginationOptions{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		h.logger.Error("failed to list logs",
+			zap.String("chain_id", chainID),
+			zap.Error(err),
+		)
+		h.respondError(w, http.StatusInternalServerError, "Failed to retrieve logs")
+		return
+	}
+
+	responses := make([]LogResponse, 0, len(logs))
+	for _, log := range logs {
+		responses = append(responses, h.convertLog(log))
+	}
+
+	h.respondJSON(w, http.StatusOK, responses)
+}
+
+// Token transfer handlers
+
+// ListTokenTransfersByAddress handles GET /chains/{chainID}/addresses/{address}/token-transfers.
+// Token transfers are decoded from logs, so chains whose adapter produces no
+// logs answer 501 Not Implemented.
+func (h *Handler) ListTokenTransfersByAddress(w http.ResponseWriter, r *http.Request) {
+	chainID := chi.URLParam(r, "chainID")
+	address := chi.URLParam(r, "address")
+	query := r.URL.Query()
+
+	if !h.requireCapability(w, r, chainID, models.CapabilityLogs) {
+		return
+	}
+
+	filter := &models.TokenTransferFilter{
+		ChainID: chainID,
+		Address: &address,
+	}
+
+	if token := query.Get("token"); token != "" {
+		filter.Token = &token
+	}
+
+	if standardStr := query.Get("standard"); standardStr != "" {
+		standard := models.TokenStandard(strings.ToLower(standardStr))
+		filter.Standard = &standard
+	}
+
+	if fromStr := query.Get("from_block"); fromStr != "" {
+		parsed, err := strconv.ParseUint(fromStr, 10, 64)
+		if err != nil {
+			h.respondError(w, http.StatusBadRequest, "Invalid from_block")
+			return
+		}
+		filter.FromBlock = &parsed
+	}
+
+	if toStr := query.Get("to_block"); toStr != "" {
+		parsed, err := strconv.ParseUint(toStr, 10, 64)
+		if err != nil {
+			h.respondError(w, http.StatusBadRequest, "Invalid to_block")
+			return
+		}
+		filter.ToBlock = &parsed
+	}
+
+	if err := filter.Validate(); err != nil {
+		h.respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	limit := 10
+	if limitStr := query.Get("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err == nil && parsed > 0 && parsed <= 100 {
+			limit = parsed
+		}
+	}
+
+	offset := 0
+	if offsetStr := query.Get("offset"); offsetStr != "" {
+		parsed, err := strconv.Atoi(offsetStr)
+		if err == nil && parsed >= 0 {
+			offset = parsed
+		}
+	}
+
+	transfers, err := h.transferRepo.QueryTokenTransfers(r.Context(), filter, &models.PaginationOptions{
+		Limit:  limit,
+		Offset: offset,
+	})
+	if err != nil {
+		h.logger.Error("failed to list token transfers",
+			zap.String("chain_id", chainID),
+			zap.String("address", address),
+			zap.Error(err),
+		)
+		h.respondError(w, http.StatusInternalServerError, "Failed to retrieve token transfers")
+		return
+	}
+
+	responses := make([]TokenTransferResponse, 0, len(transfers))
+	for _, transfer := range transfers {
+		responses = append(responses, h.convertTokenTransfer(transfer))
+	}
+
+	h.respondJSON(w, http.StatusOK, responses)
+}
+
 // Progress handlers
 
 func (h *Handler) GetProgress(w http.ResponseWriter, r *http.Request) {
@@ -395,6 +744,7 @@ func (h *Handler) convertBlock(block *models.Block) BlockResponse {
 		Miner:      block.Proposer,
 		TxCount:    block.TxCount,
 		IndexedAt:  block.IndexedAt,
+		Finality:   string(block.Finality),
 	}
 
 	if len(block.Transactions) > 0 {
@@ -436,18 +786,77 @@ func (h *Handler) convertTransaction(tx *models.Transaction) TransactionResponse
 	if len(tx.Logs) > 0 {
 		response.Logs = make([]LogResponse, 0, len(tx.Logs))
 		for _, log := range tx.Logs {
-			response.Logs = append(response.Logs, LogResponse{
-				Address:  log.Address,
-				Topics:   log.Topics,
-				Data:     fmt.Sprintf("0x%x", log.Data),
-				LogIndex: log.Index,
-			})
+			response.Logs = append(response.Logs, h.convertLog(log))
+		}
+	}
+
+	if len(tx.InternalTransactions) > 0 {
+		response.InternalTransactions = make([]InternalTransactionResponse, 0, len(tx.InternalTransactions))
+		for _, internal := range tx.InternalTransactions {
+			response.InternalTransactions = append(response.InternalTransactions, h.convertInternalTransaction(internal))
 		}
 	}
 
 	return response
 }
 
+func (h *Handler) convertInternalTransaction(internal *models.InternalTransaction) InternalTransactionResponse {
+	response := InternalTransactionResponse{
+		Type:         string(internal.Type),
+		From:         internal.From,
+		To:           internal.To,
+		Value:        internal.Value,
+		Gas:          internal.Gas,
+		GasUsed:      internal.GasUsed,
+		Error:        internal.Error,
+		TraceAddress: internal.TraceAddress,
+	}
+
+	if len(internal.Input) > 0 {
+		response.Input = fmt.Sprintf("0x%x", internal.Input)
+	}
+
+	return response
+}
+
+func (h *Handler) convertLog(log *models.Log) LogResponse {
+	return LogResponse{
+		Address:     log.Address,
+		Topics:      log.Topics,
+		Data:        fmt.Sprintf("0x%x", log.Data),
+		LogIndex:    log.Index,
+		BlockNumber: log.BlockNumber,
+		BlockHash:   log.BlockHash,
+		TxHash:      log.TxHash,
+		TxIndex:     log.TxIndex,
+	}
+}
+
+func (h *Handler) convertTokenTransfer(transfer *models.TokenTransfer) TokenTransferResponse {
+	response := TokenTransferResponse{
+		ChainID:     transfer.ChainID,
+		Standard:    string(transfer.Standard),
+		Token:       transfer.Token,
+		From:        transfer.From,
+		To:          transfer.To,
+		Operator:    transfer.Operator,
+		Value:       transfer.Value,
+		TokenID:     transfer.TokenID,
+		BlockNumber: transfer.BlockNumber,
+		BlockHash:   transfer.BlockHash,
+		TxHash:      transfer.TxHash,
+		TxIndex:     transfer.TxIndex,
+		LogIndex:    transfer.LogIndex,
+		BatchIndex:  transfer.BatchIndex,
+	}
+
+	if transfer.Timestamp != nil {
+		response.BlockTimestamp = &transfer.Timestamp.Time
+	}
+
+	return response
+}
+
 // GetChainGaps handles GET /chains/{chainID}/gaps
 func (h *Handler) GetChainGaps(w http.ResponseWriter, r *http.Request) {
 	chainID := chi.URLParam(r, "chainID")
@@ -468,14 +877,18 @@ func (h *Handler) GetChainGaps(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Detect gaps
-	gaps, err := recovery.DetectGaps(r.Context(), chainID)
+	// List gaps from the indexed ranges, starting at the chain's start block
+	var startBlock uint64
+	if chain, err := h.chainRepo.GetChain(r.Context(), chainID); err == nil && chain != nil {
+		startBlock = chain.StartBlock
+	}
+	gaps, err := recovery.ListGaps(r.Context(), chainID, startBlock)
 	if err != nil {
-		h.logger.Error("failed to detect gaps",
+		h.logger.Error("failed to list gaps",
 			zap.String("chain_id", chainID),
 			zap.Error(err),
 		)
-		h.respondError(w, http.StatusInternalServerError, "failed to detect gaps")
+		h.respondError(w, http.StatusInternalServerError, "failed to list gaps")
 		return
 	}
 
@@ -508,17 +921,7 @@ func (h *Handler) ListChains(w http.ResponseWriter, r *http.Request) {
 	// Convert to response format
 	responses := make([]ChainResponse, len(chains))
 	for i, chain := range chains {
-		responses[i] = ChainResponse{
-			ChainID:            chain.ChainID,
-			ChainType:          string(chain.ChainType),
-			Name:               chain.Name,
-			Network:            chain.Network,
-			Status:             string(chain.Status),
-			StartBlock:         chain.StartBlock,
-			LatestIndexedBlock: chain.LatestIndexedBlock,
-			LatestChainBlock:   chain.LatestChainBlock,
-			LastUpdated:        chain.LastUpdated,
-		}
+		responses[i] = chainResponse(chain)
 	}
 
 	h.respondJSON(w, http.StatusOK, map[string]interface{}{
@@ -24,7 +24,10 @@ type ChainResponse struct {
 	StartBlock         uint64    `json:"start_block"`
 	LatestIndexedBlock uint64    `json:"latest_indexed_block"`
 	LatestChainBlock   uint64    `json:"latest_chain_block"`
+	SafeBlock          uint64    `json:"safe_block"`
+	FinalizedBlock     uint64    `json:"finalized_block"`
 	LastUpdated        time.Time `json:"last_updated"`
+	Capabilities       []string  `json:"capabilities,omitempty"`
 }
 
 // BlockResponse represents a block in the API
@@ -41,35 +44,75 @@ type BlockResponse struct {
 	TxCount      int                 `json:"tx_count"`
 	Transactions []TransactionResponse `json:"transactions,omitempty"`
 	IndexedAt    time.Time           `json:"indexed_at"`
+	Finality     string              `json:"finality,omitempty"`
 }
 
 // TransactionResponse represents a transaction in the API
 type TransactionResponse struct {
-	ChainID         string        `json:"chain_id"`
-	Hash            string        `json:"hash"`
-	BlockNumber     uint64        `json:"block_number"`
-	BlockHash       string        `json:"block_hash"`
-	BlockTimestamp  time.Time     `json:"block_timestamp"`
-	TxIndex         uint64        `json:"tx_index"`
-	From            string        `json:"from"`
-	To              string        `json:"to,omitempty"`
-	Value           string        `json:"value"`
-	GasPrice        string        `json:"gas_price"`
-	GasUsed         uint64        `json:"gas_used"`
-	Nonce           uint64        `json:"nonce"`
-	Input           string        `json:"input,omitempty"`
-	Status          string        `json:"status"`
-	ContractAddress string        `json:"contract_address,omitempty"`
-	Logs            []LogResponse `json:"logs,omitempty"`
-	IndexedAt       time.Time     `json:"indexed_at"`
-}
-
-// LogResponse represents a transaction log in the API
+	ChainID              string                        `json:"chain_id"`
+	Hash                 string                        `json:"hash"`
+	BlockNumber          uint64                        `json:"block_number"`
+	BlockHash            string                        `json:"block_hash"`
+	BlockTimestamp       time.Time                     `json:"block_timestamp"`
+	TxIndex              uint64                        `json:"tx_index"`
+	From                 string                        `json:"from"`
+	To                   string                        `json:"to,omitempty"`
+	Value                string                        `json:"value"`
+	GasPrice             string                        `json:"gas_price"`
+	GasUsed              uint64                        `json:"gas_used"`
+	Nonce                uint64                        `json:"nonce"`
+	Input                string                        `json:"input,omitempty"`
+	Status               string                        `json:"status"`
+	ContractAddress      string                        `json:"contract_address,omitempty"`
+	Logs                 []LogResponse                 `json:"logs,omitempty"`
+	InternalTransactions []InternalTransactionResponse `json:"internal_transactions,omitempty"`
+	IndexedAt            time.Time                     `json:"indexed_at"`
+}
+
+// InternalTransactionResponse represents a call made by a contract while a
+// transaction executed
+type InternalTransactionResponse struct {
+	Type         string   `json:"type"`
+	From         string   `json:"from"`
+	To           string   `json:"to,omitempty"`
+	Value        string   `json:"value"`
+	Gas          uint64   `json:"gas"`
+	GasUsed      uint64   `json:"gas_used"`
+	Input        string   `json:"input,omitempty"`
+	Error        string   `json:"error,omitempty"`
+	TraceAddress []uint64 `json:"trace_address"`
+}
+
+// LogResponse represents a transaction log in the API. The block and
+// transaction fields are set when logs are listed on their own.
 type LogResponse struct {
-	Address  string   `json:"address"`
-	Topics   []string `json:"topics"`
-	Data     string   `json:"data"`
-	LogIndex uint64   `json:"log_index"`
+	Address     string   `json:"address"`
+	Topics      []string `json:"topics"`
+	Data        string   `json:"data"`
+	LogIndex    uint64   `json:"log_index"`
+	BlockNumber uint64   `json:"block_number,omitempty"`
+	BlockHash   string   `json:"block_hash,omitempty"`
+	TxHash      string   `json:"tx_hash,omitempty"`
+	TxIndex     uint64   `json:"tx_index,omitempty"`
+}
+
+// TokenTransferResponse represents a token transfer in the API
+type TokenTransferResponse struct {
+	ChainID        string     `json:"chain_id"`
+	Standard       string     `json:"standard"`
+	Token          string     `json:"token"`
+	From           string     `json:"from"`
+	To             string     `json:"to"`
+	Operator       string     `json:"operator,omitempty"`
+	Value          string     `json:"value"`
+	TokenID        string     `json:"token_id,omitempty"`
+	BlockNumber    uint64     `json:"block_number"`
+	BlockHash      string     `json:"block_hash"`
+	BlockTimestamp *time.Time `json:"block_timestamp,omitempty"`
+	TxHash         string     `json:"tx_hash"`
+	TxIndex        uint64     `json:"tx_index"`
+	LogIndex       uint64     `json:"log_index"`
+	BatchIndex     uint64     `json:"batch_index,omitempty"`
 }
 
 // ProgressResponse represents indexing progress
@@ -135,3 +178,24 @@ type HealthResponse struct {
 	Uptime    string            `json:"uptime"`
 	Checks    map[string]string `json:"checks"`
 }
+
+// AddChainRequest represents the body of an admin add chain request
+type AddChainRequest struct {
+	ChainID            string   `json:"chain_id"`
+	ChainType          string   `json:"chain_type"`
+	Name               string   `json:"name"`
+	Network            string   `json:"network"`
+	RPCEndpoints       []string `json:"rpc_endpoints"`
+	WSEndpoints        []string `json:"ws_endpoints,omitempty"`
+	StartBlock         uint64   `json:"start_block"`
+	BatchSize          int      `json:"batch_size,omitempty"`
+	Workers            int      `json:"workers,omitempty"`
+	ConfirmationBlocks uint64   `json:"confirmation_blocks"`
+}
+
+// ReindexRangeRequest represents the body of an admin reindex request. The
+// range includes both end blocks.
+type ReindexRangeRequest struct {
+	StartBlock uint64 `json:"start_block"`
+	EndBlock   uint64 `json:"end_block"`
+}
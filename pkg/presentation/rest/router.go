@@ -12,8 +12,10 @@ import (
 	restmw "github.com/sage-x-project/blockchain-indexer/pkg/presentation/rest/middleware"
 )
 
-// NewRouter creates a new HTTP router with all routes configured
-func NewRouter(h *handler.Handler, logger *logger.Logger) chi.Router {
+// NewRouter creates a new HTTP router with all routes configured. The admin
+// routes are only served with a non-empty adminToken, to requests that
+// carry it as a bearer token.
+func NewRouter(h *handler.Handler, logger *logger.Logger, adminToken string) chi.Router {
 	r := chi.NewRouter()
 
 	// Middleware
@@ -64,6 +66,16 @@ func NewRouter(h *handler.Handler, logger *logger.Logger) chi.Router {
 			r.Get("/address/{address}", h.ListTransactionsByAddress)
 		})
 
+		// Log routes
+		r.Route("/chains/{chainID}/logs", func(r chi.Router) {
+			r.Get("/", h.ListLogs)
+		})
+
+		// Address routes
+		r.Route("/chains/{chainID}/addresses/{address}", func(r chi.Router) {
+			r.Get("/token-transfers", h.ListTokenTransfersByAddress)
+		})
+
 		// Progress routes
 		r.Route("/chains/{chainID}/progress", func(r chi.Router) {
 			r.Get("/", h.GetProgress)
@@ -79,6 +91,24 @@ func NewRouter(h *handler.Handler, logger *logger.Logger) chi.Router {
 			r.Get("/", h.GetChainStats)
 		})
 		r.Get("/stats", h.GetGlobalStats)
+
+		// Admin routes
+		if adminToken != "" {
+			r.Route("/admin", func(r chi.Router) {
+				r.Use(restmw.BearerAuthMiddleware(adminToken))
+
+				r.Post("/chains", h.AddChain)
+				r.Delete("/chains/{chainID}", h.RemoveChain)
+				r.Post("/chains/{chainID}/pause", h.PauseChain)
+				r.Post("/chains/{chainID}/resume", h.ResumeChain)
+				r.Post("/chains/{chainID}/reindex", h.ReindexRange)
+				r.Post("/chains/{chainID}/recover-gaps", h.RecoverGaps)
+
+				r.Get("/operations", h.ListOperations)
+				r.Get("/operations/{operationID}", h.GetOperation)
+				r.Get("/operations/{operationID}/watch", h.WatchOperation)
+			})
+		}
 	})
 
 	return r